@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/cmd/staking-api-service/scripts"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func newBackfillCmd() *cobra.Command {
+	backfillCmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Backfill the data derived from the existing documents",
+	}
+	backfillCmd.AddCommand(&cobra.Command{
+		Use:   "pubkey-addresses",
+		Short: "Backfill the btc addresses mappings of the stakers public keys",
+		Args:  cobra.NoArgs,
+		RunE:  runBackfillPubkeyAddresses,
+	})
+	return backfillCmd
+}
+
+func runBackfillPubkeyAddresses(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("Starting backfill of pubkey address mappings.")
+	if err := scripts.BackfillPubkeyAddressesMappings(cmd.Context(), cfg); err != nil {
+		return fmt.Errorf("error while backfilling pubkey address mappings: %w", err)
+	}
+	return nil
+}
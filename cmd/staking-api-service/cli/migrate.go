@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"fmt"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Create the staking db collections and indexes",
+		Long: "Create the staking db collections and indexes, and update the expiry of the " +
+			"archived events. The server does the same on startup, the command allows " +
+			"running it ahead of a deployment.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if err := dbmodel.Setup(cmd.Context(), cfg); err != nil {
+				return fmt.Errorf("error while setting up staking db model: %w", err)
+			}
+			return nil
+		},
+	}
+}
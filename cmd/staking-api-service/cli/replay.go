@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/cmd/staking-api-service/scripts"
+	queueclients "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/clients"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func newReplayCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "replay",
+		Short: "Replay the unprocessable messages into their queues",
+		Args:  cobra.NoArgs,
+		RunE:  runReplay,
+	}
+}
+
+func runReplay(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	dbClients, services, err := setupServices(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	queueClients := queueclients.New(ctx, cfg, services)
+
+	log.Info().Msg("Starting replay of unprocessable messages.")
+	err = scripts.ReplayUnprocessableMessages(ctx, cfg, queueClients, dbClients.SharedDBClient)
+	if err != nil {
+		return fmt.Errorf("error while replaying unprocessable messages: %w", err)
+	}
+	return nil
+}
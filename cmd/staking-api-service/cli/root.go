@@ -1,10 +1,15 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/errorreporting"
 	"github.com/spf13/cobra"
 )
 
@@ -12,20 +17,33 @@ const (
 	defaultConfigFileName            = "config.yml"
 	defaultGlobalParamsFileName      = "global_params.json"
 	defaultFinalityProvidersFileName = "finality_providers.json"
+	// errorReportingFlushTimeout bounds the wait for the reported errors to
+	// be sent on exit
+	errorReportingFlushTimeout = 5 * time.Second
 )
 
 var (
-	cfgPath                   string
-	globalParamsPath          string
-	finalityProvidersPath     string
+	cfgPath               string
+	globalParamsPath      string
+	finalityProvidersPath string
+	// The flags below predate the subcommands, they are kept so that the
+	// existing deployments running the root command keep working
 	replayFlag                bool
 	backfillPubkeyAddressFlag bool
+	checkStatsFlag            bool
+	fixStatsFlag              bool
 	rootCmd                   = &cobra.Command{
-		Use: "start-server",
+		Use:   "staking-api-service",
+		Short: "Babylon staking API service",
+		Long: "Babylon staking API service. Without subcommand the server is started, " +
+			"as with the serve subcommand.",
+		RunE:         runRoot,
+		SilenceUsage: true,
 	}
 )
 
-func Setup() error {
+// Execute sets up the commands and runs the one selected by the arguments
+func Execute() error {
 	homePath, err := os.UserHomeDir()
 	if err != nil {
 		return err
@@ -53,45 +71,62 @@ func Setup() error {
 		defaultFinalityProvidersPath,
 		fmt.Sprintf("finality providers file (default %s)", defaultFinalityProvidersPath),
 	)
-	rootCmd.PersistentFlags().BoolVar(
-		&replayFlag,
-		"replay",
-		false,
-		"Replay unprocessable messages",
+
+	rootCmd.Flags().BoolVar(&replayFlag, "replay", false, "Replay unprocessable messages")
+	rootCmd.Flags().BoolVar(
+		&backfillPubkeyAddressFlag, "backfill-pubkey-address", false, "Backfill pubkey address mappings",
 	)
-	rootCmd.PersistentFlags().BoolVar(
-		&backfillPubkeyAddressFlag,
-		"backfill-pubkey-address",
-		false,
-		"Backfill pubkey address mappings",
+	rootCmd.Flags().BoolVar(
+		&checkStatsFlag, "check-stats", false,
+		"Recompute the stats from the delegations and report the drifts",
 	)
-	if err := rootCmd.Execute(); err != nil {
-		return err
+	rootCmd.Flags().BoolVar(
+		&fixStatsFlag, "fix-stats", false,
+		"Recompute the stats from the delegations and rewrite the drifted stats",
+	)
+	deprecatedFlags := map[string]string{
+		"replay":                  "use the replay subcommand",
+		"backfill-pubkey-address": "use the backfill pubkey-addresses subcommand",
+		"check-stats":             "use the verify stats subcommand",
+		"fix-stats":               "use the verify stats --fix subcommand",
+	}
+	for name, message := range deprecatedFlags {
+		if err := rootCmd.Flags().MarkDeprecated(name, message); err != nil {
+			return err
+		}
 	}
 
-	return nil
-}
-
-func getDefaultConfigFile(homePath, filename string) string {
-	return filepath.Join(homePath, filename)
-}
-
-func GetConfigPath() string {
-	return cfgPath
-}
-
-func GetGlobalParamsPath() string {
-	return globalParamsPath
-}
+	rootCmd.AddCommand(
+		newServeCmd(),
+		newMigrateCmd(),
+		newBackfillCmd(),
+		newVerifyCmd(),
+		newReplayCmd(),
+	)
 
-func GetFinalityProvidersPath() string {
-	return finalityProvidersPath
+	// The commands are stopped on SIGINT and SIGTERM, the reported errors
+	// still in flight are sent before exiting
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	defer errorreporting.Flush(errorReportingFlushTimeout)
+	return rootCmd.ExecuteContext(ctx)
 }
 
-func GetReplayFlag() bool {
-	return replayFlag
+// runRoot starts the server, unless one of the deprecated flags selects
+// another operation
+func runRoot(cmd *cobra.Command, args []string) error {
+	switch {
+	case replayFlag:
+		return runReplay(cmd, args)
+	case backfillPubkeyAddressFlag:
+		return runBackfillPubkeyAddresses(cmd, args)
+	case checkStatsFlag || fixStatsFlag:
+		return verifyStats(cmd.Context(), fixStatsFlag, false)
+	default:
+		return runServe(cmd, args)
+	}
 }
 
-func GetBackfillPubkeyAddressFlag() bool {
-	return backfillPubkeyAddressFlag
+func getDefaultConfigFile(homePath, filename string) string {
+	return filepath.Join(homePath, filename)
 }
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/credentials"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/healthcheck"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/logging"
+	queueclients "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/clients"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// shutdownTimeout bounds the wait for the in-flight requests on shutdown
+const shutdownTimeout = 10 * time.Second
+
+func newServeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Start the API server and the queue consumers",
+		Args:  cobra.NoArgs,
+		RunE:  runServe,
+	}
+}
+
+func runServe(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	// The logging is set up first so that the queue consumers and jobs
+	// started below log with the configured level and format
+	if err = logging.Init(cfg.Server.LogLevel, cfg.Logging); err != nil {
+		return fmt.Errorf("error while setting up logging: %w", err)
+	}
+	logging.HandleSignals(ctx)
+
+	// The collections and indexes are kept up to date on startup, the migrate
+	// command allows running it ahead of a deployment
+	if err = dbmodel.Setup(ctx, cfg); err != nil {
+		return fmt.Errorf("error while setting up staking db model: %w", err)
+	}
+
+	dbClients, services, err := setupServices(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	// The finality providers registry is only loaded on startup, which is
+	// when its commission changes are detected
+	if err := services.V1Service.SyncFinalityProviderCommissions(ctx); err != nil {
+		return fmt.Errorf("error while syncing finality provider commissions: %w", err)
+	}
+
+	// Start the event queue processing
+	queueClients := queueclients.New(ctx, cfg, services)
+	queueClients.StartReceivingMessages()
+
+	if err = healthcheck.StartHealthCheckCron(ctx, queueClients, cfg.Server.HealthCheckInterval); err != nil {
+		return fmt.Errorf("error while starting health check cron: %w", err)
+	}
+
+	err = healthcheck.StartQueueLagMonitor(
+		ctx, cfg.Queue, queueClients.Credentials, cfg.QueueMonitor, queueClients.GetConsumedQueueNames(),
+	)
+	if err != nil {
+		return fmt.Errorf("error while starting queue lag monitor: %w", err)
+	}
+
+	err = credentials.StartRotation(
+		ctx, cfg, func() (*config.Config, error) { return config.Reload(cfgPath) },
+		credentialRotationTargets(cfg, dbClients, queueClients)...,
+	)
+	if err != nil {
+		return fmt.Errorf("error while starting credential rotation: %w", err)
+	}
+
+	if err = services.StartScheduler(ctx, cfg); err != nil {
+		return fmt.Errorf("error while starting scheduler: %w", err)
+	}
+	services.StartExportWorker(ctx, cfg.Exports)
+
+	apiServer, err := api.New(ctx, cfg, services, func(ctx context.Context, dryRun bool) (int, error) {
+		return queueClients.ReplayUnprocessableMessages(ctx, dbClients.SharedDBClient, dryRun)
+	})
+	if err != nil {
+		return fmt.Errorf("error while setting up staking api service: %w", err)
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := apiServer.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("error while shutting down staking api service")
+		}
+	}()
+	if err = apiServer.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("error while starting staking api service: %w", err)
+	}
+	return nil
+}
+
+// credentialRotationTargets returns the connections whose credentials are
+// rotated, which are re-established with the new credentials right away
+func credentialRotationTargets(
+	cfg *config.Config, dbClients *dbclients.DbClients, queueClients *queueclients.QueueClients,
+) []credentials.Target {
+	if cfg.CredentialRotation == nil {
+		return nil
+	}
+	drainTimeout := cfg.CredentialRotation.DrainTimeout
+	return []credentials.Target{
+		{
+			Name: "staking-db",
+			Extract: func(cfg *config.Config) credentials.Credentials {
+				return credentials.Credentials{Username: cfg.StakingDb.Username, Password: cfg.StakingDb.Password}
+			},
+			Rotate: func(ctx context.Context, newCredentials credentials.Credentials) error {
+				return dbClients.StakingMongoClient.Rotate(ctx, newCredentials, drainTimeout)
+			},
+		},
+		{
+			Name: "indexer-db",
+			Extract: func(cfg *config.Config) credentials.Credentials {
+				return credentials.Credentials{Username: cfg.IndexerDb.Username, Password: cfg.IndexerDb.Password}
+			},
+			Rotate: func(ctx context.Context, newCredentials credentials.Credentials) error {
+				return dbClients.IndexerMongoClient.Rotate(ctx, newCredentials, drainTimeout)
+			},
+		},
+		{
+			Name: "queue",
+			Extract: func(cfg *config.Config) credentials.Credentials {
+				return credentials.Credentials{Username: cfg.Queue.QueueUser, Password: cfg.Queue.QueuePassword}
+			},
+			Rotate: queueClients.RotateCredentials,
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/errorreporting"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// loadConfig loads the config file and sets up the metrics and the error
+// reporting, which are used by every command
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.New(cfgPath)
+	if err != nil {
+		return nil, fmt.Errorf("error while loading config file %s: %w", cfgPath, err)
+	}
+
+	// initialize metrics with the metrics port from config
+	metrics.Init(cfg.Metrics.GetMetricsPort())
+
+	if err = errorreporting.Init(cfg.ErrorReporting); err != nil {
+		return nil, fmt.Errorf("error while setting up error reporting: %w", err)
+	}
+	return cfg, nil
+}
+
+// setupServices wires the db clients and the services layer the same way for
+// the server and the operational commands
+func setupServices(
+	ctx context.Context, cfg *config.Config,
+) (*dbclients.DbClients, *services.Services, error) {
+	params, err := types.NewGlobalParams(globalParamsPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while loading global params file %s: %w", globalParamsPath, err)
+	}
+
+	finalityProviders, err := types.NewFinalityProviders(finalityProvidersPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error while loading finality providers file %s: %w", finalityProvidersPath, err,
+		)
+	}
+
+	dbClients, err := dbclients.New(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while setting up staking db clients: %w", err)
+	}
+
+	// initialize clients package which is used to interact with external services
+	clients, err := clients.New(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while setting up clients: %w", err)
+	}
+
+	services, err := services.New(ctx, cfg, params, finalityProviders, clients, dbClients)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while setting up staking services layer: %w", err)
+	}
+	return dbClients, services, nil
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/cmd/staking-api-service/scripts"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func newVerifyCmd() *cobra.Command {
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the consistency of the stored data",
+	}
+	var fix, includeOverflow bool
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Recompute the stats from the delegations and report the drifts",
+		Long: "Recompute the overall and finality provider stats from the delegations and " +
+			"report the drifts against the stored stats. With --fix the drifted stats are " +
+			"rewritten, the queue consumers shall be stopped meanwhile. The overflow stats are " +
+			"only checked with --include-overflow, run it once along with --fix to backfill " +
+			"them on the deployments predating the overflow stats.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return verifyStats(cmd.Context(), fix, includeOverflow)
+		},
+	}
+	statsCmd.Flags().BoolVar(&fix, "fix", false, "rewrite the drifted stats with the recomputed values")
+	statsCmd.Flags().BoolVar(&includeOverflow, "include-overflow", false, "also check the overflow stats")
+	checksumsCmd := &cobra.Command{
+		Use:   "checksums",
+		Short: "Verify the checksums of the delegations",
+		Long: "Verify the checksum of each delegation against its immutable fields, i.e. the " +
+			"staking tx, its value and the public keys. The command fails if any delegation " +
+			"has been mutated or corrupted, so that the job running it alerts.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return verifyChecksums(cmd.Context())
+		},
+	}
+	verifyCmd.AddCommand(statsCmd, checksumsCmd)
+	return verifyCmd
+}
+
+func verifyStats(ctx context.Context, fix, includeOverflow bool) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	log.Info().Bool("fix", fix).Bool("includeOverflow", includeOverflow).
+		Msg("Starting stats consistency check.")
+	if _, err := scripts.CheckStatsConsistency(ctx, cfg, fix, includeOverflow); err != nil {
+		return fmt.Errorf("error while checking stats consistency: %w", err)
+	}
+	return nil
+}
+
+func verifyChecksums(ctx context.Context) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("Starting delegation checksums verification.")
+	report, err := scripts.CheckDelegationChecksums(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("error while verifying delegation checksums: %w", err)
+	}
+	if len(report.Mismatches) > 0 {
+		return fmt.Errorf("%d delegations do not match their checksum", len(report.Mismatches))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	"github.com/rs/zerolog/log"
+)
+
+// ChecksumReport is the outcome of the verification of the delegation
+// checksums
+type ChecksumReport struct {
+	Verified int
+	// Unverified are the delegations inserted before the checksum was
+	// introduced
+	Unverified int
+	// Mismatches are the staking tx hashes of the delegations whose
+	// immutable fields no longer match their checksum
+	Mismatches []string
+}
+
+// CheckDelegationChecksums scans the delegation collection and verifies the
+// checksum of each delegation against its immutable fields. Each mismatch is
+// logged as an error, the delegations are left unchanged.
+func CheckDelegationChecksums(ctx context.Context, cfg *config.Config) (*ChecksumReport, error) {
+	client, err := dbclient.NewMongoClient(ctx, cfg.StakingDb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create db client: %w", err)
+	}
+	v1dbClient, err := v1dbclient.New(ctx, client, cfg.StakingDb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create db client: %w", err)
+	}
+
+	report := &ChecksumReport{}
+	pageToken := ""
+	for {
+		result, err := v1dbClient.ScanDelegationsPaginated(ctx, pageToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delegations: %w", err)
+		}
+		for _, delegation := range result.Data {
+			verified, matches := delegation.VerifyChecksum()
+			if !verified {
+				report.Unverified++
+				continue
+			}
+			report.Verified++
+			if !matches {
+				log.Error().Str("stakingTxHashHex", delegation.StakingTxHashHex).
+					Str("checksum", delegation.Checksum).Str("computed", delegation.ComputeChecksum()).
+					Msg("delegation checksum mismatch")
+				report.Mismatches = append(report.Mismatches, delegation.StakingTxHashHex)
+			}
+		}
+		if result.PaginationToken == "" {
+			break
+		}
+		pageToken = result.PaginationToken
+	}
+
+	log.Info().Int("verified", report.Verified).Int("unverified", report.Unverified).
+		Int("mismatches", len(report.Mismatches)).Msg("Delegation checksums verified")
+	return report, nil
+}
@@ -0,0 +1,169 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+const overallStatsDriftKey = "overall"
+
+// StatsDrift is a stats field whose stored value differs from the value
+// recomputed from the delegation collection
+type StatsDrift struct {
+	// Key is either "overall" or the finality provider pk hex
+	Key      string
+	Field    string
+	Expected int64
+	Actual   int64
+}
+
+// CheckStatsConsistency recomputes the overall and per finality provider stats
+// from the delegation collection and reports the drift against the stored
+// stats. If fix is set, the stored stats are rewritten with the recomputed
+// values. The stats events shall be fully processed (i.e. the queue consumers
+// stopped) when fixing, otherwise the in-flight events will cause new drifts.
+// The overflow stats are only compared if includeOverflow is set, as they are
+// only counted since the overflow stats were introduced and drift on the
+// deployments predating them until they are backfilled, i.e. fixed once with
+// includeOverflow set.
+func CheckStatsConsistency(
+	ctx context.Context, cfg *config.Config, fix, includeOverflow bool,
+) ([]StatsDrift, error) {
+	client, err := dbclient.NewMongoClient(ctx, cfg.StakingDb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create db client: %w", err)
+	}
+	v1dbClient, err := v1dbclient.New(ctx, client, cfg.StakingDb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create db client: %w", err)
+	}
+
+	expectedOverall, err := v1dbClient.RecomputeOverallStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recompute overall stats: %w", err)
+	}
+	actualOverall, err := v1dbClient.GetOverallStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch overall stats: %w", err)
+	}
+	overallDrifts := compareStats(
+		overallStatsDriftKey,
+		overallStatsFields(expectedOverall, includeOverflow),
+		overallStatsFields(actualOverall, includeOverflow),
+	)
+
+	expectedFps, err := v1dbClient.RecomputeFinalityProviderStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recompute finality provider stats: %w", err)
+	}
+	actualFps, err := v1dbClient.FindAllFinalityProviderStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch finality provider stats: %w", err)
+	}
+	actualFpsMap := make(map[string]*v1dbmodel.FinalityProviderStatsDocument, len(actualFps))
+	for _, fpStats := range actualFps {
+		actualFpsMap[fpStats.FinalityProviderPkHex] = fpStats
+	}
+	var fpDrifts []StatsDrift
+	for _, expected := range expectedFps {
+		actual, ok := actualFpsMap[expected.FinalityProviderPkHex]
+		if !ok {
+			actual = &v1dbmodel.FinalityProviderStatsDocument{}
+		}
+		delete(actualFpsMap, expected.FinalityProviderPkHex)
+		fpDrifts = append(fpDrifts, compareStats(
+			expected.FinalityProviderPkHex, fpStatsFields(expected), fpStatsFields(actual),
+		)...)
+	}
+	// Finality providers without any delegation shall not have stats
+	for _, actual := range actualFps {
+		if _, ok := actualFpsMap[actual.FinalityProviderPkHex]; ok {
+			fpDrifts = append(fpDrifts, compareStats(
+				actual.FinalityProviderPkHex,
+				fpStatsFields(&v1dbmodel.FinalityProviderStatsDocument{}), fpStatsFields(actual),
+			)...)
+		}
+	}
+
+	drifts := append(overallDrifts, fpDrifts...)
+	for _, drift := range drifts {
+		log.Warn().Str("key", drift.Key).Str("field", drift.Field).
+			Int64("expected", drift.Expected).Int64("actual", drift.Actual).
+			Msg("stats drift detected")
+	}
+	log.Info().Msgf("Found %d stats drifts", len(drifts))
+
+	if !fix {
+		return drifts, nil
+	}
+	if len(overallDrifts) > 0 {
+		if !includeOverflow {
+			// The overflow stats are left as they are
+			expectedOverall.OverflowTvl = actualOverall.OverflowTvl
+			expectedOverall.OverflowDelegations = actualOverall.OverflowDelegations
+		}
+		if err := v1dbClient.ReplaceOverallStats(ctx, expectedOverall); err != nil {
+			return nil, fmt.Errorf("failed to rewrite overall stats: %w", err)
+		}
+		log.Info().Msg("Rewrote the overall stats")
+	}
+	if len(fpDrifts) > 0 {
+		if err := v1dbClient.ReplaceFinalityProviderStats(ctx, expectedFps); err != nil {
+			return nil, fmt.Errorf("failed to rewrite finality provider stats: %w", err)
+		}
+		log.Info().Msg("Rewrote the finality provider stats")
+	}
+	return drifts, nil
+}
+
+type statsField struct {
+	name  string
+	value int64
+}
+
+func overallStatsFields(stats *v1dbmodel.OverallStatsDocument, includeOverflow bool) []statsField {
+	fields := []statsField{
+		{"active_tvl", stats.ActiveTvl},
+		{"total_tvl", stats.TotalTvl},
+		{"active_delegations", stats.ActiveDelegations},
+		{"total_delegations", stats.TotalDelegations},
+		{"total_stakers", int64(stats.TotalStakers)},
+	}
+	if includeOverflow {
+		fields = append(fields,
+			statsField{"overflow_tvl", stats.OverflowTvl},
+			statsField{"overflow_delegations", stats.OverflowDelegations},
+		)
+	}
+	return fields
+}
+
+func fpStatsFields(stats *v1dbmodel.FinalityProviderStatsDocument) []statsField {
+	return []statsField{
+		{"active_tvl", stats.ActiveTvl},
+		{"total_tvl", stats.TotalTvl},
+		{"active_delegations", stats.ActiveDelegations},
+		{"total_delegations", stats.TotalDelegations},
+	}
+}
+
+func compareStats(key string, expected, actual []statsField) []StatsDrift {
+	var drifts []StatsDrift
+	for i := range expected {
+		if expected[i].value != actual[i].value {
+			drifts = append(drifts, StatsDrift{
+				Key:      key,
+				Field:    expected[i].name,
+				Expected: expected[i].value,
+				Actual:   actual[i].value,
+			})
+		}
+	}
+	return drifts
+}
@@ -0,0 +1,80 @@
+package indexerdbclient
+
+import (
+	"context"
+	"errors"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetBsns retrieves the registered BSNs sorted by their id
+func (indexerdbclient *IndexerDatabase) GetBsns(
+	ctx context.Context, paginationToken string,
+) (*db.DbResultMap[indexerdbmodel.IndexerBsnDocument], error) {
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.BsnCollection)
+
+	filter := bson.M{}
+	if paginationToken != "" {
+		decodedToken, err := dbmodel.DecodePaginationToken[indexerdbmodel.IndexerBsnPagination](paginationToken)
+		if err != nil {
+			return nil, &db.InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter["_id"] = bson.M{"$gt": decodedToken.BsnId}
+	}
+	options := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, indexerdbclient.Cfg.MaxPaginationLimit,
+		indexerdbmodel.BuildBsnPaginationToken,
+	)
+}
+
+// GetBsn retrieves a registered BSN by its id
+func (indexerdbclient *IndexerDatabase) GetBsn(
+	ctx context.Context, bsnId string,
+) (*indexerdbmodel.IndexerBsnDocument, error) {
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.BsnCollection)
+	var bsn indexerdbmodel.IndexerBsnDocument
+	err := client.FindOne(ctx, bson.M{"_id": bsnId}).Decode(&bsn)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     bsnId,
+				Message: "BSN not found",
+			}
+		}
+		return nil, err
+	}
+	return &bsn, nil
+}
+
+// GetFinalityProviderPksByBsn retrieves the public keys of the finality
+// providers securing the given BSNs, keyed by the BSN id
+func (indexerdbclient *IndexerDatabase) GetFinalityProviderPksByBsn(
+	ctx context.Context, bsnIds []string,
+) (map[string][]string, error) {
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.FinalityProviderDetailsCollection)
+	options := options.Find().SetProjection(bson.M{"_id": 1, "bsn_id": 1})
+	cursor, err := client.Find(ctx, bson.M{"bsn_id": bson.M{"$in": bsnIds}}, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var providers []indexerdbmodel.IndexerFinalityProviderDetails
+	if err := cursor.All(ctx, &providers); err != nil {
+		return nil, err
+	}
+	pksByBsn := make(map[string][]string, len(bsnIds))
+	for _, provider := range providers {
+		pksByBsn[provider.BsnId] = append(pksByBsn[provider.BsnId], provider.BtcPk)
+	}
+	return pksByBsn, nil
+}
@@ -7,13 +7,14 @@ import (
 	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (indexerdbclient *IndexerDatabase) GetDelegation(ctx context.Context, stakingTxHashHex string) (*indexerdbmodel.IndexerDelegationDetails, error) {
-	client := indexerdbclient.Client.Database(indexerdbclient.DbName).Collection(indexerdbmodel.BTCDelegationDetailsCollection)
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.BTCDelegationDetailsCollection)
 	filter := bson.M{"_id": stakingTxHashHex}
 	var delegation indexerdbmodel.IndexerDelegationDetails
 	err := client.FindOne(ctx, filter).Decode(&delegation)
@@ -30,9 +31,10 @@ func (indexerdbclient *IndexerDatabase) GetDelegation(ctx context.Context, staki
 }
 
 func (indexerdbclient *IndexerDatabase) GetDelegations(
-	ctx context.Context, stakerPKHex string, paginationToken string,
+	ctx context.Context, stakerPKHex string,
+	rangeFilter *types.DelegationRangeFilter, paginationToken string,
 ) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error) {
-	client := indexerdbclient.Client.Database(indexerdbclient.DbName).Collection(indexerdbmodel.BTCDelegationDetailsCollection)
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.BTCDelegationDetailsCollection)
 
 	// Base filter with stakingTxHashHex
 	filter := bson.M{"staker_btc_pk_hex": stakerPKHex}
@@ -52,14 +54,74 @@ func (indexerdbclient *IndexerDatabase) GetDelegations(
 		}
 
 		// Add start_height filter while maintaining the stakingTxHashHex filter
-		filter = bson.M{
-			"staker_btc_pk_hex": stakerPKHex,
-			"start_height":      bson.M{"$gt": decodedToken.StartHeight},
-		}
+		filter["start_height"] = bson.M{"$gt": decodedToken.StartHeight}
 	}
+	filter = buildDelegationRangeFilter(filter, rangeFilter)
 
 	return db.FindWithPagination(
 		ctx, client, filter, options, indexerdbclient.Cfg.MaxPaginationLimit,
 		indexerdbmodel.BuildDelegationPaginationToken,
 	)
 }
+
+func (indexerdbclient *IndexerDatabase) CountDelegations(
+	ctx context.Context, stakerPKHex string, rangeFilter *types.DelegationRangeFilter,
+) (int64, error) {
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.BTCDelegationDetailsCollection)
+	filter := buildDelegationRangeFilter(bson.M{"staker_btc_pk_hex": stakerPKHex}, rangeFilter)
+	return client.CountDocuments(ctx, filter)
+}
+
+func (indexerdbclient *IndexerDatabase) GetRegisteredStakingTxHashes(
+	ctx context.Context, stakingTxHashHexes []string,
+) (map[string]bool, error) {
+	registered := make(map[string]bool)
+	if len(stakingTxHashHexes) == 0 {
+		return registered, nil
+	}
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.BTCDelegationDetailsCollection)
+	options := options.Find().SetProjection(bson.M{"_id": 1})
+	cursor, err := client.Find(ctx, bson.M{"_id": bson.M{"$in": stakingTxHashHexes}}, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []indexerdbmodel.IndexerDelegationDetails
+	if err := cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+	for _, delegation := range delegations {
+		registered[delegation.StakingTxHashHex] = true
+	}
+	return registered, nil
+}
+
+// buildDelegationRangeFilter adds the staking amount and the delegation
+// creation timestamp bounds to the base filter
+func buildDelegationRangeFilter(baseFilter bson.M, rangeFilter *types.DelegationRangeFilter) bson.M {
+	if rangeFilter == nil {
+		return baseFilter
+	}
+	amountFilter := bson.M{}
+	if rangeFilter.MinValue != 0 {
+		amountFilter["$gte"] = int64(rangeFilter.MinValue)
+	}
+	if rangeFilter.MaxValue != 0 {
+		amountFilter["$lte"] = int64(rangeFilter.MaxValue)
+	}
+	if len(amountFilter) > 0 {
+		baseFilter["staking_amount"] = amountFilter
+	}
+	timestampFilter := bson.M{}
+	if rangeFilter.FromTimestamp != 0 {
+		timestampFilter["$gte"] = rangeFilter.FromTimestamp
+	}
+	if rangeFilter.ToTimestamp != 0 {
+		timestampFilter["$lte"] = rangeFilter.ToTimestamp
+	}
+	if len(timestampFilter) > 0 {
+		baseFilter["btc_delegation_created_bbn_block.timestamp"] = timestampFilter
+	}
+	return baseFilter
+}
@@ -16,7 +16,7 @@ func (indexerdbclient *IndexerDatabase) GetFinalityProviderByPk(
 	ctx context.Context,
 	fpPk string,
 ) (*indexerdbmodel.IndexerFinalityProviderDetails, error) {
-	client := indexerdbclient.Client.Database(indexerdbclient.DbName).Collection(indexerdbmodel.FinalityProviderDetailsCollection)
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.FinalityProviderDetailsCollection)
 
 	filter := bson.M{}
 	filter = indexerdbclient.applyFpPkFilter(filter, fpPk)
@@ -36,7 +36,7 @@ func (indexerdbclient *IndexerDatabase) GetFinalityProviders(
 	state types.FinalityProviderQueryingState,
 	paginationToken string,
 ) (*db.DbResultMap[indexerdbmodel.IndexerFinalityProviderDetails], error) {
-	client := indexerdbclient.Client.Database(indexerdbclient.DbName).Collection(indexerdbmodel.FinalityProviderDetailsCollection)
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.FinalityProviderDetailsCollection)
 
 	filter := bson.M{}
 	filter = indexerdbclient.applyStateFilter(filter, state)
@@ -59,7 +59,7 @@ func (indexerdbclient *IndexerDatabase) SearchFinalityProviders(
 	searchQuery string,
 	paginationToken string,
 ) (*db.DbResultMap[indexerdbmodel.IndexerFinalityProviderDetails], error) {
-	client := indexerdbclient.Client.Database(indexerdbclient.DbName).Collection(indexerdbmodel.FinalityProviderDetailsCollection)
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.FinalityProviderDetailsCollection)
 
 	filter := indexerdbclient.applySearchFilter(bson.M{}, searchQuery)
 	filter = indexerdbclient.applyPaginationFilter(filter, paginationToken)
@@ -75,6 +75,28 @@ func (indexerdbclient *IndexerDatabase) SearchFinalityProviders(
 	)
 }
 
+// GetFinalityProvidersByBsn retrieves the finality providers securing the BSN
+func (indexerdbclient *IndexerDatabase) GetFinalityProvidersByBsn(
+	ctx context.Context,
+	bsnId string,
+	paginationToken string,
+) (*db.DbResultMap[indexerdbmodel.IndexerFinalityProviderDetails], error) {
+	client := indexerdbclient.Db(ctx).Collection(indexerdbmodel.FinalityProviderDetailsCollection)
+
+	filter := bson.M{"bsn_id": bsnId}
+	filter = indexerdbclient.applyPaginationFilter(filter, paginationToken)
+
+	options := options.Find().SetSort(bson.D{
+		{Key: "commission", Value: 1},
+		{Key: "_id", Value: 1},
+	})
+
+	return db.FindWithPagination(
+		ctx, client, filter, options, indexerdbclient.Cfg.MaxPaginationLimit,
+		indexerdbmodel.BuildFinalityProviderPaginationToken,
+	)
+}
+
 func (indexerdbclient *IndexerDatabase) applyFpPkFilter(filter bson.M, fpPk string) bson.M {
 	if fpPk != "" {
 		filter["_id"] = fpPk
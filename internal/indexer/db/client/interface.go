@@ -18,7 +18,18 @@ type IndexerDBClient interface {
 	GetFinalityProviders(ctx context.Context, state types.FinalityProviderQueryingState, paginationToken string) (*db.DbResultMap[indexerdbmodel.IndexerFinalityProviderDetails], error)
 	SearchFinalityProviders(ctx context.Context, searchQuery string, paginationToken string) (*db.DbResultMap[indexerdbmodel.IndexerFinalityProviderDetails], error)
 	GetFinalityProviderByPk(ctx context.Context, fpPk string) (*indexerdbmodel.IndexerFinalityProviderDetails, error)
+	GetFinalityProvidersByBsn(ctx context.Context, bsnId string, paginationToken string) (*db.DbResultMap[indexerdbmodel.IndexerFinalityProviderDetails], error)
+	GetFinalityProviderPksByBsn(ctx context.Context, bsnIds []string) (map[string][]string, error)
+	// BSNs
+	GetBsns(ctx context.Context, paginationToken string) (*db.DbResultMap[indexerdbmodel.IndexerBsnDocument], error)
+	GetBsn(ctx context.Context, bsnId string) (*indexerdbmodel.IndexerBsnDocument, error)
 	// Staker Delegations
 	GetDelegation(ctx context.Context, stakingTxHashHex string) (*indexerdbmodel.IndexerDelegationDetails, error)
-	GetDelegations(ctx context.Context, stakerPKHex string, paginationToken string) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error)
+	GetDelegations(ctx context.Context, stakerPKHex string, rangeFilter *types.DelegationRangeFilter, paginationToken string) (*db.DbResultMap[indexerdbmodel.IndexerDelegationDetails], error)
+	// CountDelegations counts the delegations of the staker with the same
+	// filters as GetDelegations, over all the pages
+	CountDelegations(ctx context.Context, stakerPKHex string, rangeFilter *types.DelegationRangeFilter) (int64, error)
+	// GetRegisteredStakingTxHashes returns which of the staking tx hashes have
+	// a delegation registered on the Babylon chain
+	GetRegisteredStakingTxHashes(ctx context.Context, stakingTxHashHexes []string) (map[string]bool, error)
 }
@@ -10,7 +10,7 @@ import (
 )
 
 func (db *IndexerDatabase) GetBbnStakingParams(ctx context.Context) ([]*indexertypes.BbnStakingParams, error) {
-	cursor, err := db.Client.Database(db.DbName).Collection(indexerdbmodel.GlobalParamsCollection).Find(ctx, bson.M{
+	cursor, err := db.Db(ctx).Collection(indexerdbmodel.GlobalParamsCollection).Find(ctx, bson.M{
 		"type": indexertypes.STAKING_PARAMS_TYPE,
 	})
 	if err != nil {
@@ -64,7 +64,7 @@ func (db *IndexerDatabase) GetBbnStakingParams(ctx context.Context) ([]*indexert
 }
 
 func (db *IndexerDatabase) GetBtcCheckpointParams(ctx context.Context) ([]*indexertypes.BtcCheckpointParams, error) {
-	cursor, err := db.Client.Database(db.DbName).Collection(indexerdbmodel.GlobalParamsCollection).Find(ctx, bson.M{
+	cursor, err := db.Db(ctx).Collection(indexerdbmodel.GlobalParamsCollection).Find(ctx, bson.M{
 		"type": indexertypes.CHECKPOINT_PARAMS_TYPE,
 	})
 	if err != nil {
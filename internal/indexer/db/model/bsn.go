@@ -0,0 +1,29 @@
+package indexerdbmodel
+
+import dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+
+// IndexerBsnDocument is a BSN (consumer chain) registered on the Babylon chain
+type IndexerBsnDocument struct {
+	BsnId       string `bson:"_id"` // Primary key, the chain id of the BSN
+	Name        string `bson:"name"`
+	Description string `bson:"description"`
+	// Type is the kind of the BSN, e.g. cosmos or rollup
+	Type                string `bson:"type"`
+	RegisteredBbnHeight int64  `bson:"registered_bbn_height"`
+}
+
+type IndexerBsnPagination struct {
+	BsnId string `json:"bsn_id"`
+}
+
+func BuildBsnPaginationToken(b IndexerBsnDocument) (string, error) {
+	page := &IndexerBsnPagination{
+		BsnId: b.BsnId,
+	}
+	token, err := dbmodel.GetPaginationToken(page)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
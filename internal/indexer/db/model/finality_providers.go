@@ -23,6 +23,9 @@ type IndexerFinalityProviderDetails struct {
 	Commission     string                `bson:"commission"`
 	State          FinalityProviderState `bson:"state"`
 	Description    Description           `bson:"description"`
+	// BsnId is the BSN the finality provider secures, it's empty for the
+	// finality providers of the Babylon chain itself
+	BsnId string `bson:"bsn_id,omitempty"`
 }
 
 // Description represents the nested description field
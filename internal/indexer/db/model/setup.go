@@ -5,4 +5,5 @@ const (
 	BTCDelegationDetailsCollection    = "btc_delegation_details"
 	TimeLockCollection                = "timelock"
 	GlobalParamsCollection            = "global_params"
+	BsnCollection                     = "bsn"
 )
@@ -0,0 +1,357 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/logging"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/scheduler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// MessageReplayer sends the unprocessable messages back into their queue and
+// returns the number of replayed messages. In dry run, the messages are only
+// checked and the number of messages which would be replayed is returned.
+type MessageReplayer func(ctx context.Context, dryRun bool) (int, error)
+
+type MaintenancePublic struct {
+	Enabled bool `json:"enabled"`
+}
+
+type ReplayPublic struct {
+	Replayed int `json:"replayed"`
+	// DryRun is set when the messages have only been checked, not replayed
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+type ScheduledJobPublic struct {
+	Name     string `json:"name"`
+	Schedule string `json:"schedule"`
+	// Leader is set if this replica runs the job
+	Leader  bool `json:"leader"`
+	Running bool `json:"running"`
+	// NextRunAt is the next scheduled run on this replica
+	NextRunAt string `json:"next_run_at,omitempty"`
+	// LastRun is omitted if the job never ran on any replica
+	LastRun *ScheduledJobRunPublic `json:"last_run,omitempty"`
+}
+
+type ScheduledJobRunPublic struct {
+	// Holder is the replica which ran the job
+	Holder       string `json:"holder"`
+	Trigger      string `json:"trigger"`
+	Outcome      string `json:"outcome"`
+	Error        string `json:"error,omitempty"`
+	StartedAt    string `json:"started_at"`
+	FinishedAt   string `json:"finished_at,omitempty"`
+	DurationMs   int64  `json:"duration_ms"`
+	SuccessCount int64  `json:"success_count"`
+	FailureCount int64  `json:"failure_count"`
+}
+
+type JobTriggerPublic struct {
+	Name string `json:"name"`
+	// DryRun is set when the job has only been checked, not triggered
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+type RouteSloPublic struct {
+	Route    string `json:"route"`
+	Requests int64  `json:"requests"`
+	// The latencies are estimated from the latency buckets, they are zero if
+	// the route had no request over the window
+	P95Ms       int64   `json:"p95_ms"`
+	P99Ms       int64   `json:"p99_ms"`
+	P95BudgetMs int64   `json:"p95_budget_ms,omitempty"`
+	P99BudgetMs int64   `json:"p99_budget_ms,omitempty"`
+	P95BurnRate float64 `json:"p95_burn_rate"`
+	P99BurnRate float64 `json:"p99_burn_rate"`
+}
+
+// DryRunPublic is returned by the admin mutations called with dry_run=true,
+// Before is the state in effect and After the state the mutation would lead
+// to. Nothing is changed.
+type DryRunPublic[T any] struct {
+	DryRun bool `json:"dry_run"`
+	Before T    `json:"before"`
+	After  T    `json:"after"`
+}
+
+// newAdminHttpServer creates the admin listener, nil if the admin listener
+// is not configured
+func (a *Server) newAdminHttpServer() (*http.Server, error) {
+	if a.cfg.Admin == nil {
+		return nil, nil
+	}
+	r := chi.NewRouter()
+	r.Use(middlewares.ClientIpMiddleware(a.cfg.ClientIp))
+	r.Use(middlewares.TracingMiddleware)
+	r.Use(middlewares.LoggingMiddleware)
+	a.SetupAdminRoutes(r)
+	srv := &http.Server{
+		Addr:         fmt.Sprintf("%s:%d", a.cfg.Admin.Host, a.cfg.Admin.Port),
+		WriteTimeout: a.cfg.Admin.WriteTimeout,
+		ReadTimeout:  a.cfg.Server.ReadTimeout,
+		IdleTimeout:  a.cfg.Server.IdleTimeout,
+		Handler:      r,
+	}
+	if a.cfg.Admin.TLS != nil {
+		tlsConfig, err := a.cfg.Admin.TLS.Build()
+		if err != nil {
+			return nil, fmt.Errorf("error while setting up admin tls: %w", err)
+		}
+		srv.TLSConfig = tlsConfig
+	}
+	return srv, nil
+}
+
+// SetupAdminRoutes registers the operational endpoints, all of them require
+// an admin token and a client IP of the admin allowlist. Any admin token can
+// inspect the service, the mutating endpoints require the role of the token.
+func (a *Server) SetupAdminRoutes(r *chi.Mux) {
+	if a.ipFilter != nil {
+		r.Use(a.ipFilter.AdminAllowlistMiddleware)
+	}
+	r.Use(middlewares.AdminAuthMiddleware(a.cfg.Admin))
+	if a.cfg.RouteLimits != nil {
+		r.Use(middlewares.RouteLimitsMiddleware(a.cfg.RouteLimits.Admin.WithServerDefaults(a.cfg.Server)))
+	}
+
+	r.Get("/health/details", registerAdminHandler(a.handlers.SharedHandler.GetHealthDetails))
+	r.Handle("/metrics", promhttp.Handler())
+
+	queueAdmin := r.With(middlewares.RequireAdminRole(config.AdminRoleQueueAdmin))
+	dataAdmin := r.With(middlewares.RequireAdminRole(config.AdminRoleDataAdmin))
+
+	r.Get("/admin/maintenance", registerAdminHandler(a.getMaintenance))
+	queueAdmin.Put("/admin/maintenance", registerAdminHandler(a.setMaintenance))
+	r.Get("/admin/logging", registerAdminHandler(a.getLogging))
+	r.Get("/admin/jobs", registerAdminHandler(a.getJobs))
+	r.Get("/admin/slo", registerAdminHandler(a.getSlo))
+	queueAdmin.Put("/admin/logging", registerAdminHandler(a.setLogging))
+	dataAdmin.Post("/admin/erasure", registerAdminHandler(a.handlers.V1Handler.EraseStakerData))
+	// The scheduled jobs archive the delegations and refresh the stats
+	dataAdmin.Post("/admin/jobs/{name}/trigger", registerAdminHandler(a.triggerJob))
+	if a.replayer != nil {
+		queueAdmin.Post("/admin/unprocessable-messages/replay", registerAdminHandler(a.replayUnprocessableMessages))
+	}
+
+	r.Get("/debug/runtime", registerAdminHandler(a.handlers.SharedHandler.GetRuntimeStats))
+	r.Get("/admin/db/index-report", registerAdminHandler(a.handlers.SharedHandler.GetIndexReport))
+
+	r.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	r.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	r.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	r.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	// Serves the index along with the named profiles, such as heap or goroutine
+	r.HandleFunc("/debug/pprof/*", pprof.Index)
+}
+
+func (a *Server) getMaintenance(request *http.Request) (*handler.Result, *types.Error) {
+	return handler.NewResult(MaintenancePublic{Enabled: a.maintenance.Load()}), nil
+}
+
+// setMaintenance toggles the maintenance mode of this instance only, the
+// other instances must be toggled separately
+func (a *Server) setMaintenance(request *http.Request) (*handler.Result, *types.Error) {
+	dryRun, dryRunErr := handler.ParseDryRunQuery(request)
+	if dryRunErr != nil {
+		return nil, dryRunErr
+	}
+	var payload MaintenancePublic
+	if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid input format")
+	}
+	if dryRun {
+		return handler.NewResult(DryRunPublic[MaintenancePublic]{
+			DryRun: true,
+			Before: MaintenancePublic{Enabled: a.maintenance.Load()},
+			After:  payload,
+		}), nil
+	}
+	a.maintenance.Store(payload.Enabled)
+	log.Ctx(request.Context()).Warn().Bool("enabled", payload.Enabled).Msg("maintenance mode toggled")
+	return handler.NewResult(payload), nil
+}
+
+func (a *Server) getLogging(request *http.Request) (*handler.Result, *types.Error) {
+	return handler.NewResult(logging.Get()), nil
+}
+
+// setLogging changes the log format and levels of this instance only, the
+// levels and the format left empty are unchanged
+func (a *Server) setLogging(request *http.Request) (*handler.Result, *types.Error) {
+	dryRun, dryRunErr := handler.ParseDryRunQuery(request)
+	if dryRunErr != nil {
+		return nil, dryRunErr
+	}
+	var payload logging.Settings
+	if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid input format")
+	}
+	if dryRun {
+		settings, err := logging.Preview(payload)
+		if err != nil {
+			return nil, types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		return handler.NewResult(DryRunPublic[logging.Settings]{
+			DryRun: true,
+			Before: logging.Get(),
+			After:  settings,
+		}), nil
+	}
+	if err := logging.Update(payload); err != nil {
+		return nil, types.NewError(http.StatusBadRequest, types.BadRequest, err)
+	}
+	settings := logging.Get()
+	log.Ctx(request.Context()).WithLevel(zerolog.NoLevel).Str("level", settings.Level).
+		Str("format", settings.Format).Interface("modules", settings.Modules).Msg("logging changed")
+	return handler.NewResult(settings), nil
+}
+
+func (a *Server) replayUnprocessableMessages(request *http.Request) (*handler.Result, *types.Error) {
+	dryRun, dryRunErr := handler.ParseDryRunQuery(request)
+	if dryRunErr != nil {
+		return nil, dryRunErr
+	}
+	replayed, err := a.replayer(request.Context(), dryRun)
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Int("replayed", replayed).Bool("dryRun", dryRun).
+			Msg("failed to replay unprocessable messages")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return handler.NewResult(ReplayPublic{Replayed: replayed, DryRun: dryRun}), nil
+}
+
+// getJobs returns the scheduled jobs of this replica with their last run on
+// any replica, followed by the jobs which only ran on other replicas
+func (a *Server) getJobs(request *http.Request) (*handler.Result, *types.Error) {
+	jobs, err := a.scheduler.Jobs(request.Context())
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Msg("failed to fetch the scheduled jobs status")
+		return nil, types.NewInternalServiceError(err)
+	}
+	jobsPublic := make([]ScheduledJobPublic, 0, len(jobs))
+	for _, job := range jobs {
+		jobPublic := ScheduledJobPublic{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			Leader:   job.Leader,
+			Running:  job.Running,
+		}
+		if !job.NextRunAt.IsZero() {
+			jobPublic.NextRunAt = job.NextRunAt.UTC().Format(time.RFC3339)
+		}
+		if run := job.LastRun; run != nil {
+			jobPublic.LastRun = &ScheduledJobRunPublic{
+				Holder:       run.Holder,
+				Trigger:      string(run.LastTrigger),
+				Outcome:      string(run.LastOutcome),
+				Error:        run.LastError,
+				StartedAt:    run.LastStartedAt.UTC().Format(time.RFC3339),
+				DurationMs:   run.LastDurationMs,
+				SuccessCount: run.SuccessCount,
+				FailureCount: run.FailureCount,
+			}
+			if !run.LastFinishedAt.IsZero() {
+				jobPublic.LastRun.FinishedAt = run.LastFinishedAt.UTC().Format(time.RFC3339)
+			}
+		}
+		jobsPublic = append(jobsPublic, jobPublic)
+	}
+	return handler.NewResult(jobsPublic), nil
+}
+
+// getSlo returns the latencies and burn rates of the routes with a latency
+// budget, it's empty if the slo is not configured
+func (a *Server) getSlo(request *http.Request) (*handler.Result, *types.Error) {
+	slosPublic := []RouteSloPublic{}
+	if a.latencySlo == nil {
+		return handler.NewResult(slosPublic), nil
+	}
+	for _, slo := range a.latencySlo.Summary() {
+		slosPublic = append(slosPublic, RouteSloPublic{
+			Route:       slo.Route,
+			Requests:    slo.Requests,
+			P95Ms:       slo.P95.Milliseconds(),
+			P99Ms:       slo.P99.Milliseconds(),
+			P95BudgetMs: slo.P95Budget.Milliseconds(),
+			P99BudgetMs: slo.P99Budget.Milliseconds(),
+			P95BurnRate: slo.P95BurnRate,
+			P99BurnRate: slo.P99BurnRate,
+		})
+	}
+	return handler.NewResult(slosPublic), nil
+}
+
+// triggerJob runs the scheduled job right away on this replica, which must be
+// the leader of the job. It returns once the run is started.
+func (a *Server) triggerJob(request *http.Request) (*handler.Result, *types.Error) {
+	dryRun, dryRunErr := handler.ParseDryRunQuery(request)
+	if dryRunErr != nil {
+		return nil, dryRunErr
+	}
+	name := chi.URLParam(request, "name")
+	var err error
+	if dryRun {
+		err = a.scheduler.CanTrigger(name)
+	} else {
+		err = a.scheduler.Trigger(name)
+	}
+	switch {
+	case errors.Is(err, scheduler.ErrUnknownJob):
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, err.Error())
+	case errors.Is(err, scheduler.ErrNotLeader), errors.Is(err, scheduler.ErrAlreadyRunning):
+		return nil, types.NewErrorWithMsg(http.StatusConflict, types.Conflict, err.Error())
+	case err != nil:
+		return nil, types.NewErrorWithMsg(http.StatusServiceUnavailable, types.ServiceUnavailable, err.Error())
+	}
+	return &handler.Result{
+		Data:   &handler.PublicResponse[JobTriggerPublic]{Data: JobTriggerPublic{Name: name, DryRun: dryRun}},
+		Status: http.StatusAccepted,
+	}, nil
+}
+
+// registerAdminHandler serves the admin handlers, unlike the public ones they
+// are not recorded in the http request metrics.
+func registerAdminHandler(handlerFunc func(*http.Request) (*handler.Result, *types.Error)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := handlerFunc(r)
+		if err != nil {
+			writeResponse(w, r, err.StatusCode, &ErrorResponse{
+				ErrorCode: string(err.ErrorCode),
+				Message:   err.Err.Error(),
+			})
+			return
+		}
+		writeResponse(w, r, result.Status, result.Data)
+	}
+}
+
+func (a *Server) startAdmin() {
+	if a.adminHttpServer == nil {
+		return
+	}
+	if ip := net.ParseIP(a.cfg.Admin.Host); ip != nil && ip.IsUnspecified() {
+		log.Warn().Msg("admin server is bound to all interfaces, it should be bound to an internal interface")
+	}
+	go func() {
+		log.Info().Msgf("Starting admin server on %s", a.adminHttpServer.Addr)
+		if err := listenAndServe(a.adminHttpServer); err != nil && err != http.ErrServerClosed {
+			log.Fatal().Err(err).Msgf("error while starting admin server on %s", a.adminHttpServer.Addr)
+		}
+	}()
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+const defaultApiKeyUsageDays = 7
+
+type ApiKeyUsagePublic struct {
+	ApiKey            string                           `json:"api_key"`
+	DailyRequestQuota int64                            `json:"daily_request_quota"`
+	DailyBytesQuota   int64                            `json:"daily_bytes_quota"`
+	Usage             []service.ApiKeyDailyUsagePublic `json:"usage"`
+}
+
+// getApiKeyUsage godoc
+// @Summary Get API key usage
+// @Description Fetches the daily usage and the quotas of the API key of the request, the most recent day first.
+// @Description The usage is updated periodically, hence it can lag behind the actual usage.
+// @Produce json
+// @Tags shared
+// @Param X-Api-Key header string true "API key"
+// @Param days query int false "Number of days to fetch, including today, between 1 and 90, 7 by default"
+// @Success 200 {object} handler.PublicResponse[ApiKeyUsagePublic] "API key usage"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid API key"
+// @Router /v1/api-key/usage [get]
+func (a *Server) getApiKeyUsage(request *http.Request) (*handler.Result, *types.Error) {
+	apiKey := middlewares.GetApiKeyName(request)
+	if apiKey == "" {
+		return nil, types.NewErrorWithMsg(
+			http.StatusUnauthorized, types.Unauthorized, middlewares.ApiKeyHeader+" header is required",
+		)
+	}
+	days := defaultApiKeyUsageDays
+	if daysQuery := request.URL.Query().Get("days"); daysQuery != "" {
+		var err error
+		if days, err = strconv.Atoi(daysQuery); err != nil {
+			return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid days")
+		}
+	}
+	usage, err := a.handlers.SharedHandler.Service.GetApiKeyUsage(request.Context(), apiKey, days)
+	if err != nil {
+		return nil, err
+	}
+	quotas := a.cfg.ApiKeys.Keys[apiKey]
+	return handler.NewResult(ApiKeyUsagePublic{
+		ApiKey:            apiKey,
+		DailyRequestQuota: quotas.DailyRequestQuota,
+		DailyBytesQuota:   quotas.DailyBytesQuota,
+		Usage:             usage,
+	}), nil
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+type DeprecationPublic struct {
+	Path         string `json:"path"`
+	DeprecatedAt string `json:"deprecated_at"`
+	Sunset       string `json:"sunset,omitempty"`
+	Replacement  string `json:"replacement,omitempty"`
+	Link         string `json:"link,omitempty"`
+}
+
+// GetDeprecations godoc
+// @Summary List the deprecated endpoints
+// @Description Returns the deprecated endpoints sorted by path, along with their sunset date, their replacement and their migration guide.
+// @Description The responses of the deprecated endpoints carry the Deprecation and Sunset headers as well.
+// @Produce json
+// @Tags shared
+// @Success 200 {object} handler.PublicResponse[[]DeprecationPublic] "Deprecated endpoints"
+// @Router /v1/deprecations [get]
+func (h *Handler) GetDeprecations(request *http.Request) (*Result, *types.Error) {
+	deprecations := []DeprecationPublic{}
+	if h.Config.Deprecations != nil {
+		for path, deprecation := range h.Config.Deprecations.Routes {
+			deprecations = append(deprecations, DeprecationPublic{
+				Path:         path,
+				DeprecatedAt: deprecation.DeprecatedAtTime.Format(config.DeprecationDateLayout),
+				Sunset:       deprecation.Sunset,
+				Replacement:  deprecation.Replacement,
+				Link:         deprecation.Link,
+			})
+		}
+	}
+	sort.Slice(deprecations, func(i, j int) bool { return deprecations[i].Path < deprecations[j].Path })
+	return NewResult(deprecations), nil
+}
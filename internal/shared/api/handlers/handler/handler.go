@@ -3,10 +3,15 @@ package handler
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
@@ -17,18 +22,26 @@ import (
 type Handler struct {
 	Config  *config.Config
 	Service service.SharedServiceProvider
+	// Clock is used instead of time.Now() so that tests can control the time
+	Clock clock.Clock
 }
 
-func New(ctx context.Context, config *config.Config, service service.SharedServiceProvider) (*Handler, error) {
-	return &Handler{Config: config, Service: service}, nil
+func New(
+	ctx context.Context, config *config.Config, service service.SharedServiceProvider, clock clock.Clock,
+) (*Handler, error) {
+	return &Handler{Config: config, Service: service, Clock: clock}, nil
 }
 
+const NDJSONContentType = "application/x-ndjson"
+
 type ResultOptions struct {
 	Code int
 }
 
 type paginationResponse struct {
 	NextKey string `json:"next_key"`
+	// Total is only set if requested by the include_total query
+	Total *types.TotalCount `json:"total,omitempty"`
 }
 
 type PublicResponse[T any] struct {
@@ -36,9 +49,17 @@ type PublicResponse[T any] struct {
 	Pagination *paginationResponse `json:"pagination,omitempty"`
 }
 
+// StreamFunc streams the items of the response through the encode function,
+// which fails once the client is gone
+type StreamFunc func(encode func(item any) error) error
+
 type Result struct {
 	Data   interface{}
 	Status int
+	// Stream is set instead of the data if the response is streamed
+	Stream StreamFunc
+	// NoBody is set if only the status code and the headers are sent
+	NoBody bool
 }
 
 // NewResult returns a successful result, with default status code 200
@@ -47,17 +68,50 @@ func NewResultWithPagination[T any](data T, pageToken string) *Result {
 	return &Result{Data: res, Status: http.StatusOK}
 }
 
+// NewResultWithPaginationTotal returns a successful result along with the
+// total count of the list, omitted if nil
+func NewResultWithPaginationTotal[T any](data T, pageToken string, total *types.TotalCount) *Result {
+	res := &PublicResponse[T]{Data: data, Pagination: &paginationResponse{NextKey: pageToken, Total: total}}
+	return &Result{Data: res, Status: http.StatusOK}
+}
+
 func NewResult[T any](data T) *Result {
 	res := &PublicResponse[T]{Data: data}
 	return &Result{Data: res, Status: http.StatusOK}
 }
 
+// NewNoBodyResult returns a result sent without body, the status code being
+// the outcome of the request, e.g. of an existence check
+func NewNoBodyResult(status int) *Result {
+	return &Result{Status: status, NoBody: true}
+}
+
+// NewStreamResult returns a successful result streamed as newline delimited
+// json, the items are encoded as they are produced by the stream
+func NewStreamResult(stream StreamFunc) *Result {
+	return &Result{Stream: stream, Status: http.StatusOK}
+}
+
+// AcceptsNDJSON checks whether the request accepts a newline delimited json
+// response
+func AcceptsNDJSON(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaType := range strings.Split(accept, ",") {
+			mediaType, _, _ = strings.Cut(mediaType, ";")
+			if strings.TrimSpace(mediaType) == NDJSONContentType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func ParsePaginationQuery(r *http.Request) (string, *types.Error) {
 	pageKey := r.URL.Query().Get("pagination_key")
 	if pageKey == "" {
 		return "", nil
 	}
-	if !utils.IsBase64Encoded(pageKey) {
+	if !utils.IsBase64URLEncoded(pageKey) {
 		return "", types.NewErrorWithMsg(
 			http.StatusBadRequest, types.BadRequest, "invalid pagination key format",
 		)
@@ -151,6 +205,41 @@ func ParseBtcAddressesQuery(
 	return addresses, nil
 }
 
+// ParseIncludeTotalQuery parses the optional include_total query, the total
+// is never included if the total counts are not enabled
+func ParseIncludeTotalQuery(r *http.Request, cfg *config.TotalCountConfig) (bool, *types.Error) {
+	includeTotal := r.URL.Query().Get("include_total")
+	if includeTotal == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(includeTotal)
+	if err != nil {
+		return false, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid include_total")
+	}
+	return value && cfg != nil, nil
+}
+
+// ParseLongPollTimeoutQuery parses the optional timeout query of a long poll,
+// as a duration e.g. 30s, up to the configured max timeout which is also the
+// default. Long polling is rejected if not enabled.
+func ParseLongPollTimeoutQuery(r *http.Request, cfg *config.LongPollConfig) (time.Duration, *types.Error) {
+	if cfg == nil {
+		return 0, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "long polling is not enabled")
+	}
+	str := r.URL.Query().Get("timeout")
+	if str == "" {
+		return cfg.MaxTimeout, nil
+	}
+	timeout, err := time.ParseDuration(str)
+	if err != nil || timeout <= 0 || timeout > cfg.MaxTimeout {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid timeout, must be a positive duration up to %s", cfg.MaxTimeout),
+		)
+	}
+	return timeout, nil
+}
+
 // ParseStateFilterQuery parses the state filter query and returns the state enum
 // If the state is not provided, it returns an empty string
 func ParseStateFilterQuery(
@@ -169,6 +258,148 @@ func ParseStateFilterQuery(
 	return stateEnum, nil
 }
 
+// ParseDelegationRangeFilterQuery parses the optional min_value, max_value,
+// from_timestamp and to_timestamp queries used to filter the delegations
+func ParseDelegationRangeFilterQuery(r *http.Request) (*types.DelegationRangeFilter, *types.Error) {
+	minValue, err := parseUint64Query(r, "min_value")
+	if err != nil {
+		return nil, err
+	}
+	maxValue, err := parseUint64Query(r, "max_value")
+	if err != nil {
+		return nil, err
+	}
+	fromTimestamp, err := parseUint64Query(r, "from_timestamp")
+	if err != nil {
+		return nil, err
+	}
+	toTimestamp, err := parseUint64Query(r, "to_timestamp")
+	if err != nil {
+		return nil, err
+	}
+	if fromTimestamp > math.MaxInt64 || toTimestamp > math.MaxInt64 {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "timestamp is out of range",
+		)
+	}
+	if maxValue != 0 && minValue > maxValue {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "min_value must not be greater than max_value",
+		)
+	}
+	if toTimestamp != 0 && fromTimestamp > toTimestamp {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "from_timestamp must not be greater than to_timestamp",
+		)
+	}
+	return &types.DelegationRangeFilter{
+		MinValue:      minValue,
+		MaxValue:      maxValue,
+		FromTimestamp: int64(fromTimestamp),
+		ToTimestamp:   int64(toTimestamp),
+	}, nil
+}
+
+// ParseIncludeArchivedQuery parses the optional include_archived query
+func ParseIncludeArchivedQuery(r *http.Request) (bool, *types.Error) {
+	includeArchived := r.URL.Query().Get("include_archived")
+	if includeArchived == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(includeArchived)
+	if err != nil {
+		return false, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid include_archived")
+	}
+	return value, nil
+}
+
+// ParseDryRunQuery parses the optional dry_run query of the admin mutations
+func ParseDryRunQuery(r *http.Request) (bool, *types.Error) {
+	dryRun := r.URL.Query().Get("dry_run")
+	if dryRun == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(dryRun)
+	if err != nil {
+		return false, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid dry_run")
+	}
+	return value, nil
+}
+
+// ParseTimestampFormatQuery parses the optional timestamp_format query, the
+// timestamps are formatted as ISO8601 if it is not provided
+func ParseTimestampFormatQuery(r *http.Request) (utils.TimestampFormat, *types.Error) {
+	format := utils.TimestampFormat(r.URL.Query().Get("timestamp_format"))
+	switch format {
+	case "":
+		return utils.TimestampFormatISO8601, nil
+	case utils.TimestampFormatISO8601, utils.TimestampFormatUnix:
+		return format, nil
+	}
+	return "", types.NewErrorWithMsg(
+		http.StatusBadRequest, types.BadRequest, "invalid timestamp_format, must be one of iso8601, unix",
+	)
+}
+
+// parseUint64Query parses an optional unsigned integer query, 0 is returned
+// if the query is not provided
+func parseUint64Query(r *http.Request, queryName string) (uint64, *types.Error) {
+	str := r.URL.Query().Get(queryName)
+	if str == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseUint(str, 10, 64)
+	if err != nil {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("invalid %s, must be a non-negative integer", queryName),
+		)
+	}
+	return value, nil
+}
+
+// ParseFieldsQuery parses the optional comma separated list of the fields to
+// return, which must be json fields of the given public type. It returns nil
+// if the query is not provided.
+func ParseFieldsQuery(r *http.Request, publicType any) ([]string, *types.Error) {
+	str := r.URL.Query().Get("fields")
+	if str == "" {
+		return nil, nil
+	}
+	allowed := make(map[string]bool)
+	for _, name := range utils.JSONFieldNames(publicType) {
+		allowed[name] = true
+	}
+	var fields []string
+	selected := make(map[string]bool)
+	for _, field := range strings.Split(str, ",") {
+		field = strings.TrimSpace(field)
+		if !allowed[field] {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, fmt.Sprintf("invalid fields, unknown field %q", field),
+			)
+		}
+		if !selected[field] {
+			selected[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// SelectFields trims the data to the given fields, the data is returned as is
+// if no field is given
+func SelectFields(data any, fields []string) (any, *types.Error) {
+	if len(fields) == 0 {
+		return data, nil
+	}
+	selected, err := utils.SelectJSONFields(data, fields)
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+	return selected, nil
+}
+
 func ParseFPSearchQuery(r *http.Request, queryName string, isOptional bool) (string, *types.Error) {
 	// max length of a public key in hex and the max length of a finality provider moniker is 64
 	const maxSearchQueryLength = 64
@@ -217,3 +448,21 @@ func ParseFPStateQuery(r *http.Request, isOptional bool) (types.FinalityProvider
 	}
 	return stateEnum, nil
 }
+
+// bsnIdRegex matches the chain ids the BSNs are registered with
+var bsnIdRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+
+func ParseBsnIdQuery(r *http.Request, queryName string) (string, *types.Error) {
+	bsnId := r.URL.Query().Get(queryName)
+	if bsnId == "" {
+		return "", types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, queryName+" is required",
+		)
+	}
+	if !bsnIdRegex.MatchString(bsnId) {
+		return "", types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
+		)
+	}
+	return bsnId, nil
+}
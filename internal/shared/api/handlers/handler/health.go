@@ -1,23 +1,67 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/healthcheck"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 )
 
 // HealthCheck godoc
 // @Summary Health check endpoint
 // @Description Health check the service, including ping database connection
+// @Description and the queue processing lag if the queue monitor is configured
+// @Description and the queue connections if the queue reconnection is configured
 // @Produce json
 // @Tags shared
 // @Success 200 {string} handler.PublicResponse[string] "Server is up and running"
+// @Failure 503 {object} types.Error "Queue processing is falling behind or queue connection is lost"
 // @Router /healthcheck [get]
 func (h *Handler) HealthCheck(request *http.Request) (*Result, *types.Error) {
 	err := h.Service.DoHealthCheck(request.Context())
 	if err != nil {
 		return nil, types.NewInternalServiceError(err)
 	}
+	if err := healthcheck.QueueConnectionError(); err != nil {
+		return nil, types.NewError(http.StatusServiceUnavailable, types.ServiceUnavailable, err)
+	}
+	if err := healthcheck.QueueLagError(); err != nil {
+		return nil, types.NewError(http.StatusServiceUnavailable, types.ServiceUnavailable, err)
+	}
 
 	return NewResult("Server is up and running"), nil
 }
+
+type HealthDetailsPublic struct {
+	Status     string                          `json:"status"`
+	Components []service.ComponentHealthPublic `json:"components"`
+}
+
+// GetHealthDetails reports the health of each component the service depends
+// on. Unlike the public health check, the queue lag is always reported as it
+// does not depend on the health check configuration. Only served on the admin
+// listener.
+func (h *Handler) GetHealthDetails(request *http.Request) (*Result, *types.Error) {
+	components := h.Service.GetDbHealthDetails(request.Context())
+	components = append(components, service.NewComponentHealth("queue_connections", healthcheck.QueueConnectionError()))
+	var lagErr error
+	if lagging := healthcheck.LaggingQueueNames(); len(lagging) > 0 {
+		lagErr = fmt.Errorf("queue processing is falling behind: %s", strings.Join(lagging, ", "))
+	}
+	components = append(components, service.NewComponentHealth("queue_lag", lagErr))
+
+	details := HealthDetailsPublic{Status: service.HealthStatusHealthy, Components: components}
+	status := http.StatusOK
+	for _, component := range components {
+		if component.Status != service.HealthStatusHealthy {
+			details.Status = service.HealthStatusUnhealthy
+			status = http.StatusServiceUnavailable
+		}
+	}
+	result := NewResult(details)
+	result.Status = status
+	return result, nil
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// GetRuntimeStats returns the goroutines, heap, GC pauses and mongo sessions
+// in progress of the service. Only served on the admin listener.
+func (h *Handler) GetRuntimeStats(request *http.Request) (*Result, *types.Error) {
+	return NewResult(h.Service.GetRuntimeStats(request.Context())), nil
+}
+
+// GetIndexReport returns the usage of the indexes of the staking db, flagging
+// the unused ones, along with the hot query shapes lacking an index. Only
+// served on the admin listener.
+func (h *Handler) GetIndexReport(request *http.Request) (*Result, *types.Error) {
+	report, err := h.Service.GetIndexReport(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(report), nil
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/version"
+)
+
+// GetVersion godoc
+// @Summary Version endpoint
+// @Description Returns the version, the git commit, the build date and the features enabled at build time of the deployed service
+// @Produce json
+// @Tags shared
+// @Success 200 {object} handler.PublicResponse[version.Info] "Build information of the service"
+// @Router /version [get]
+func (h *Handler) GetVersion(request *http.Request) (*Result, *types.Error) {
+	return NewResult(version.Get()), nil
+}
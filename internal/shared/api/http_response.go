@@ -1,10 +1,17 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/errorreporting"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	logger "github.com/rs/zerolog"
@@ -26,14 +33,53 @@ func (e *ErrorResponse) Error() string {
 	return e.Message
 }
 
-func registerHandler(handlerFunc func(*http.Request) (*handler.Result, *types.Error)) func(http.ResponseWriter, *http.Request) {
+func newServiceUnavailableError() *ErrorResponse {
+	return &ErrorResponse{
+		ErrorCode: types.ServiceUnavailable.String(),
+		Message:   "Service is temporarily unavailable, please retry later",
+	}
+}
+
+func newRequestTimeoutError() *ErrorResponse {
+	return &ErrorResponse{
+		ErrorCode: types.RequestTimeout.String(),
+		Message:   "Request timed out",
+	}
+}
+
+func (a *Server) registerHandler(handlerFunc func(*http.Request) (*handler.Result, *types.Error)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Set up metrics recording for the endpoint
 		timer := metrics.StartHttpRequestDurationTimer(r.URL.Path)
 
+		// Reads of the read-only requests can be served by the secondaries
+		// according to the configured read preference
+		readOnly := r.Method == http.MethodGet || r.Method == http.MethodHead
+		if readOnly {
+			r = r.WithContext(dbclient.WithReadOnly(r.Context()))
+		}
+
 		// Handle the actual business logic
 		result, err := handlerFunc(r)
 
+		// The db circuit breaker has rejected an operation of the handler
+		var unavailableErr *db.UnavailableError
+		if err != nil && errors.As(err.Err, &unavailableErr) {
+			logger.Ctx(r.Context()).Warn().Err(err.Err).Msg("request rejected as the database is unavailable")
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(unavailableErr.RetryAfter.Seconds()))))
+			timer(http.StatusServiceUnavailable)
+			writeResponse(w, r, http.StatusServiceUnavailable, newServiceUnavailableError())
+			return
+		}
+
+		// The handler deadline of the route group has expired
+		if err != nil && errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+			logger.Ctx(r.Context()).Warn().Err(err).Msg("request handler timed out")
+			timer(http.StatusRequestTimeout)
+			writeResponse(w, r, http.StatusRequestTimeout, newRequestTimeoutError())
+			return
+		}
+
 		if err != nil {
 			if http.StatusText(err.StatusCode) == "" {
 				logger.Ctx(r.Context()).Error().Err(err).Int("status_code", err.StatusCode).Msg("invalid status code")
@@ -47,6 +93,7 @@ func registerHandler(handlerFunc func(*http.Request) (*handler.Result, *types.Er
 			// Log the error
 			if err.StatusCode >= http.StatusInternalServerError {
 				logger.Ctx(r.Context()).Error().Err(errorResponse).Msg("request failed with 5xx error")
+				errorreporting.CaptureHttpError(r, err.StatusCode, err.ErrorCode, err.Err)
 				errorResponse.Message = "Internal service error" // Hide the internal message error from client
 			}
 			timer(err.StatusCode)
@@ -63,11 +110,85 @@ func registerHandler(handlerFunc func(*http.Request) (*handler.Result, *types.Er
 			return
 		}
 
+		if result.Stream != nil {
+			timer(writeStream(w, r, result.Stream))
+			return
+		}
+
+		if result.NoBody {
+			timer(result.Status)
+			w.WriteHeader(result.Status)
+			return
+		}
+
 		defer timer(result.Status)
 		writeResponse(w, r, result.Status, result.Data)
 	}
 }
 
+// streamFlushInterval is the number of streamed items after which the
+// response is flushed to the client
+const streamFlushInterval = 100
+
+// writeStream writes the streamed items as newline delimited json and returns
+// the status code. The header is only written along with the first item, so
+// that an error occurring before any item is answered as a regular error.
+func writeStream(w http.ResponseWriter, r *http.Request, stream handler.StreamFunc) int {
+	rc := http.NewResponseController(w)
+	encoder := json.NewEncoder(w)
+	started := false
+	count := 0
+	writeHeader := func() {
+		w.Header().Set("Content-Type", handler.NDJSONContentType)
+		w.WriteHeader(http.StatusOK)
+		started = true
+	}
+	streamErr := stream(func(item any) error {
+		if !started {
+			writeHeader()
+		}
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+		count++
+		if count%streamFlushInterval == 0 {
+			// Not all the response writers support flushing, e.g. recorders
+			_ = rc.Flush()
+		}
+		return nil
+	})
+	if streamErr == nil {
+		if !started {
+			writeHeader()
+		}
+		_ = rc.Flush()
+		return http.StatusOK
+	}
+
+	var err *types.Error
+	if !errors.As(streamErr, &err) {
+		err = types.NewInternalServiceError(streamErr)
+	}
+	errorResponse := &ErrorResponse{
+		ErrorCode: string(err.ErrorCode),
+		Message:   err.Err.Error(),
+	}
+	if err.StatusCode >= http.StatusInternalServerError {
+		logger.Ctx(r.Context()).Error().Err(errorResponse).Int("streamed", count).Msg("stream failed with 5xx error")
+		errorreporting.CaptureHttpError(r, err.StatusCode, err.ErrorCode, err.Err)
+		errorResponse.Message = "Internal service error"
+	}
+	if !started {
+		writeResponse(w, r, err.StatusCode, errorResponse)
+		return err.StatusCode
+	}
+	// The status code is already sent, the error is reported as the last line
+	if encodeErr := encoder.Encode(errorResponse); encodeErr != nil {
+		metrics.RecordHttpResponseWriteFailure(err.StatusCode)
+	}
+	return err.StatusCode
+}
+
 // Write and return response
 func writeResponse(w http.ResponseWriter, r *http.Request, statusCode int, res interface{}) {
 	respBytes, err := json.Marshal(res)
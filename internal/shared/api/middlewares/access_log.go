@@ -0,0 +1,185 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+const redactedValue = "[REDACTED]"
+
+// AccessLogMiddleware logs each request once completed, with its route,
+// status, latency, client IP and client id. The configured query params and JSON body
+// fields are redacted.
+func AccessLogMiddleware(cfg *config.AccessLogConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if cfg == nil {
+			return next
+		}
+		redactedParams := toSet(cfg.RedactedQueryParams)
+		redactedFields := toSet(cfg.RedactedBodyFields)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			startTime := time.Now()
+			writer := &accessLogWriter{ResponseWriter: w}
+			var requestBody *boundedBuffer
+			if cfg.LogBodies {
+				writer.body = &boundedBuffer{limit: cfg.MaxBodySize}
+				if r.Body != nil && r.Body != http.NoBody {
+					// The body is captured as the handler reads it, so that the
+					// read limits of the routes still apply
+					requestBody = &boundedBuffer{limit: cfg.MaxBodySize}
+					r.Body = &teeReadCloser{Reader: io.TeeReader(r.Body, requestBody), Closer: r.Body}
+				}
+			}
+
+			next.ServeHTTP(writer, r)
+
+			status := writer.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			event := log.Info().
+				Str("method", r.Method).
+				Str("route", routePattern(r)).
+				Str("clientIp", GetClientIp(r)).
+				Str("query", redactQuery(r, redactedParams)).
+				Int("status", status).
+				Int64("latencyMs", time.Since(startTime).Milliseconds()).
+				Int("responseSize", writer.size)
+			if cfg.ClientIdHeader != "" {
+				event = event.Str("clientId", r.Header.Get(cfg.ClientIdHeader))
+			}
+			if traceId := r.Context().Value(tracing.TraceIdKey); traceId != nil {
+				event = event.Interface("traceId", traceId)
+			}
+			if body, ok := redactBody(requestBody, redactedFields); ok {
+				event = event.RawJSON("requestBody", body)
+			}
+			if body, ok := redactBody(writer.body, redactedFields); ok {
+				event = event.RawJSON("responseBody", body)
+			}
+			event.Msg("access log")
+		})
+	}
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+func redactQuery(r *http.Request, redactedParams map[string]bool) string {
+	if r.URL.RawQuery == "" || len(redactedParams) == 0 {
+		return r.URL.RawQuery
+	}
+	query := r.URL.Query()
+	for name, values := range query {
+		if redactedParams[name] {
+			for i := range values {
+				values[i] = redactedValue
+			}
+		}
+	}
+	return query.Encode()
+}
+
+// redactBody returns the redacted body if it has been fully captured and is
+// JSON, as the truncated or non JSON bodies cannot be reliably redacted.
+func redactBody(body *boundedBuffer, redactedFields map[string]bool) ([]byte, bool) {
+	if body == nil || body.truncated || body.buf.Len() == 0 {
+		return nil, false
+	}
+	var value interface{}
+	if err := json.Unmarshal(body.buf.Bytes(), &value); err != nil {
+		return nil, false
+	}
+	redacted, err := json.Marshal(redactJSONValue(value, redactedFields))
+	if err != nil {
+		return nil, false
+	}
+	return redacted, true
+}
+
+func redactJSONValue(value interface{}, redactedFields map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if redactedFields[key] {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactJSONValue(field, redactedFields)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSONValue(item, redactedFields)
+		}
+	}
+	return value
+}
+
+// boundedBuffer keeps up to limit bytes of what is written into it
+type boundedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *boundedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.buf.Len(); remaining < len(p) {
+		b.truncated = true
+		b.buf.Write(p[:max(remaining, 0)])
+	} else {
+		b.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// accessLogWriter records the status, size and optionally the body of the
+// response
+type accessLogWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+	body   *boundedBuffer
+}
+
+func (w *accessLogWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *accessLogWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	if w.body != nil {
+		_, _ = w.body.Write(b[:n])
+	}
+	return n, err
+}
+
+// Unwrap allows the response controller to reach the underlying writer, e.g.
+// to flush the streamed responses
+func (w *accessLogWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
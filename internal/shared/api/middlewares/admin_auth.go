@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/rs/zerolog/log"
+)
+
+const bearerPrefix = "Bearer "
+
+type adminRolesContextKey struct{}
+
+// adminToken is a bearer token of the admin api along with its roles
+type adminToken struct {
+	name  string
+	token []byte
+	roles []string
+}
+
+// AdminAuthMiddleware rejects the requests not carrying the admin auth token
+// or one of the admin tokens as a bearer token. The roles of the token are
+// checked by RequireAdminRole.
+func AdminAuthMiddleware(cfg *config.AdminConfig) func(http.Handler) http.Handler {
+	tokens := []adminToken{{name: "auth-token", token: []byte(cfg.AuthToken), roles: config.AdminRoles}}
+	for name, token := range cfg.Tokens {
+		tokens = append(tokens, adminToken{name: name, token: []byte(token.Token), roles: token.Roles})
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			bearer, found := strings.CutPrefix(header, bearerPrefix)
+			var matched *adminToken
+			if found {
+				// All the tokens are compared so that the time taken doesn't
+				// tell which one matched
+				for i := range tokens {
+					if subtle.ConstantTimeCompare([]byte(bearer), tokens[i].token) == 1 {
+						matched = &tokens[i]
+					}
+				}
+			}
+			if matched == nil {
+				log.Ctx(r.Context()).Warn().Str("path", r.URL.Path).Msg("unauthorized admin request")
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			logger := log.Ctx(r.Context()).With().Str("adminToken", matched.name).Logger()
+			ctx := logger.WithContext(context.WithValue(r.Context(), adminRolesContextKey{}, matched.roles))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireAdminRole rejects the admin requests whose token hasn't been granted
+// the role. It must be used after AdminAuthMiddleware.
+func RequireAdminRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			roles, _ := r.Context().Value(adminRolesContextKey{}).([]string)
+			if !slices.Contains(roles, role) {
+				log.Ctx(r.Context()).Warn().Str("path", r.URL.Path).Str("role", role).
+					Msg("admin request lacking the required role")
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
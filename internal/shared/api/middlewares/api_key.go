@@ -0,0 +1,226 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ApiKeyHeader = "X-Api-Key"
+	// apiKeyFlushTimeout bounds the final flush once the context is done
+	apiKeyFlushTimeout = 10 * time.Second
+)
+
+type apiKeyContextKey struct{}
+
+// ApiKeyUsageStore sums the usage of the api keys over all the instances
+type ApiKeyUsageStore interface {
+	IncrementApiKeyUsage(
+		ctx context.Context, apiKey string, dayStart time.Time, requests, bytes int64,
+	) (*dbmodel.ApiKeyUsageDocument, *types.Error)
+}
+
+// ApiKeyQuotas tracks the daily usage of the api keys and rejects the
+// requests of the api keys which have exceeded their quotas. The usage is
+// counted in memory and flushed into the store periodically, the quotas are
+// enforced on the usage of all the instances as of the last flush along with
+// the usage of this instance since then.
+type ApiKeyQuotas struct {
+	cfg   *config.ApiKeysConfig
+	store ApiKeyUsageStore
+	clock clock.Clock
+	// names holds the api key names keyed by the hash of the api key, so that
+	// the lookup does not leak the keys through timing
+	names map[[sha256.Size]byte]string
+
+	mu    sync.Mutex
+	usage map[string]*apiKeyUsage
+}
+
+type apiKeyUsage struct {
+	dayStart time.Time
+	// flushedRequests and flushedBytes are the usage of all the instances as
+	// of the last flush
+	flushedRequests int64
+	flushedBytes    int64
+	// pendingRequests and pendingBytes are the usage of this instance which
+	// is not flushed yet
+	pendingRequests int64
+	pendingBytes    int64
+}
+
+func NewApiKeyQuotas(cfg *config.ApiKeysConfig, store ApiKeyUsageStore, clock clock.Clock) *ApiKeyQuotas {
+	names := make(map[[sha256.Size]byte]string, len(cfg.Keys))
+	for name, apiKey := range cfg.Keys {
+		names[sha256.Sum256([]byte(apiKey.Key))] = name
+	}
+	return &ApiKeyQuotas{
+		cfg:   cfg,
+		store: store,
+		clock: clock,
+		names: names,
+		usage: make(map[string]*apiKeyUsage),
+	}
+}
+
+// Start flushes the usage periodically until the context is done, the usage
+// is flushed a last time then
+func (q *ApiKeyQuotas) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(q.cfg.FlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				flushCtx, cancel := context.WithTimeout(context.Background(), apiKeyFlushTimeout)
+				q.Flush(flushCtx)
+				cancel()
+				return
+			case <-ticker.C:
+				q.Flush(ctx)
+			}
+		}
+	}()
+}
+
+// Flush writes the pending usage into the store and refreshes the usage of
+// all the instances. The usage failed to be flushed is kept pending.
+func (q *ApiKeyQuotas) Flush(ctx context.Context) {
+	type pending struct {
+		name            string
+		dayStart        time.Time
+		requests, bytes int64
+	}
+	var flushes []pending
+	today := q.clock.Now().UTC().Truncate(24 * time.Hour)
+	q.mu.Lock()
+	for name, usage := range q.usage {
+		// The usage of a past day is dropped once flushed
+		if usage.dayStart.Before(today) && usage.pendingRequests == 0 && usage.pendingBytes == 0 {
+			delete(q.usage, name)
+			continue
+		}
+		flushes = append(flushes, pending{name, usage.dayStart, usage.pendingRequests, usage.pendingBytes})
+		usage.pendingRequests, usage.pendingBytes = 0, 0
+	}
+	q.mu.Unlock()
+
+	for _, flush := range flushes {
+		// The usage of the other instances is refreshed even if this one has
+		// none pending
+		flushed, err := q.store.IncrementApiKeyUsage(ctx, flush.name, flush.dayStart, flush.requests, flush.bytes)
+		q.mu.Lock()
+		usage := q.usage[flush.name]
+		sameDay := usage != nil && usage.dayStart.Equal(flush.dayStart)
+		switch {
+		case err != nil && sameDay:
+			usage.pendingRequests += flush.requests
+			usage.pendingBytes += flush.bytes
+		case err == nil && sameDay:
+			usage.flushedRequests, usage.flushedBytes = flushed.Requests, flushed.Bytes
+		}
+		q.mu.Unlock()
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("apiKey", flush.name).Msg("failed to flush api key usage")
+		}
+	}
+}
+
+// Middleware identifies the api key of the request and counts the request
+// along with its response bytes. The requests with an unknown api key are
+// rejected with 401, and the ones of an api key over quota with 429.
+func (q *ApiKeyQuotas) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey := r.Header.Get(ApiKeyHeader)
+		if apiKey == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		name, ok := q.names[sha256.Sum256([]byte(apiKey))]
+		if !ok {
+			writeErrorResponse(w, http.StatusUnauthorized, types.Unauthorized, "invalid api key")
+			return
+		}
+
+		now := q.clock.Now().UTC()
+		dayStart := now.Truncate(24 * time.Hour)
+		if !q.reserve(name, dayStart) {
+			metrics.RecordApiKeyQuotaExceeded(name)
+			retryAfter := dayStart.Add(24 * time.Hour).Sub(now)
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			writeErrorResponse(w, http.StatusTooManyRequests, types.TooManyRequests, "api key daily quota exceeded")
+			return
+		}
+
+		counter := &byteCountingWriter{ResponseWriter: w}
+		defer func() {
+			q.addBytes(name, dayStart, counter.bytes)
+		}()
+		ctx := context.WithValue(r.Context(), apiKeyContextKey{}, name)
+		next.ServeHTTP(counter, r.WithContext(ctx))
+	})
+}
+
+// reserve counts the request unless the api key has exceeded its quotas
+func (q *ApiKeyQuotas) reserve(name string, dayStart time.Time) bool {
+	quotas := q.cfg.Keys[name]
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	usage := q.usage[name]
+	if usage == nil || !usage.dayStart.Equal(dayStart) {
+		// The pending usage of the previous day is lost if not flushed yet
+		usage = &apiKeyUsage{dayStart: dayStart}
+		q.usage[name] = usage
+	}
+	if quotas.DailyRequestQuota > 0 && usage.flushedRequests+usage.pendingRequests >= quotas.DailyRequestQuota {
+		return false
+	}
+	if quotas.DailyBytesQuota > 0 && usage.flushedBytes+usage.pendingBytes >= quotas.DailyBytesQuota {
+		return false
+	}
+	usage.pendingRequests++
+	return true
+}
+
+func (q *ApiKeyQuotas) addBytes(name string, dayStart time.Time, bytes int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if usage := q.usage[name]; usage != nil && usage.dayStart.Equal(dayStart) {
+		usage.pendingBytes += bytes
+	}
+}
+
+// GetApiKeyName returns the name of the api key of the request, empty if
+// the request has no api key
+func GetApiKeyName(r *http.Request) string {
+	name, _ := r.Context().Value(apiKeyContextKey{}).(string)
+	return name
+}
+
+// byteCountingWriter counts the bytes of the response body
+type byteCountingWriter struct {
+	http.ResponseWriter
+	bytes int64
+}
+
+func (w *byteCountingWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap allows the response controller to reach the underlying writer
+func (w *byteCountingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
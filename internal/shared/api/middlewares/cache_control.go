@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+)
+
+const noStoreCacheControl = "no-store"
+
+// CacheControlMiddleware sets the Cache-Control header of the responses. The
+// successful GET and HEAD responses of the configured routes are cacheable for their
+// max age, all the other responses must not be stored.
+func CacheControlMiddleware(cfg *config.CacheControlConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if cfg == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cacheControl := noStoreCacheControl
+			if maxAge, ok := cfg.Routes[r.URL.Path]; ok && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+				cacheControl = fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+			}
+			next.ServeHTTP(&cacheControlWriter{ResponseWriter: w, cacheControl: cacheControl}, r)
+		})
+	}
+}
+
+// cacheControlWriter sets the Cache-Control header once the status code is
+// known, as only the successful responses are cacheable
+type cacheControlWriter struct {
+	http.ResponseWriter
+	cacheControl string
+	wroteHeader  bool
+}
+
+func (w *cacheControlWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if statusCode == http.StatusOK {
+			w.Header().Set("Cache-Control", w.cacheControl)
+		} else {
+			w.Header().Set("Cache-Control", noStoreCacheControl)
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *cacheControlWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap allows the response controller to reach the underlying writer, e.g.
+// to flush the streamed responses
+func (w *cacheControlWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
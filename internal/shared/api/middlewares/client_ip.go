@@ -0,0 +1,86 @@
+package middlewares
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+)
+
+type clientIpContextKey struct{}
+
+// ClientIpMiddleware resolves the client IP of the requests forwarded by the
+// trusted proxies. The X-Forwarded-For hops are walked from the closest one
+// and the first address which is not a trusted proxy is the client IP, as the
+// hops further away may have been forged by the client.
+func ClientIpMiddleware(cfg *config.ClientIpConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if cfg == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientIp := resolveClientIp(cfg, r)
+			ctx := context.WithValue(r.Context(), clientIpContextKey{}, clientIp)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetClientIp returns the resolved client IP of the request, which is the
+// remote address of the connection if the client IP is not configured
+func GetClientIp(r *http.Request) string {
+	if clientIp, ok := r.Context().Value(clientIpContextKey{}).(string); ok {
+		return clientIp
+	}
+	if addr, ok := parseIp(r.RemoteAddr); ok {
+		return addr.String()
+	}
+	return r.RemoteAddr
+}
+
+func resolveClientIp(cfg *config.ClientIpConfig, r *http.Request) string {
+	current, ok := parseIp(r.RemoteAddr)
+	if !ok {
+		return r.RemoteAddr
+	}
+	if !cfg.IsTrustedProxy(current) {
+		return current.String()
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	if len(hops) == 0 {
+		hops = r.Header.Values("X-Real-IP")
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseIp(hops[i])
+		if !ok {
+			// The hop reported by the closest trusted proxy is unusable
+			break
+		}
+		current = hop
+		if !cfg.IsTrustedProxy(hop) {
+			break
+		}
+	}
+	return current.String()
+}
+
+// parseIp parses an IP optionally followed by a port, as found in the remote
+// address and some forwarding headers
+func parseIp(value string) (netip.Addr, bool) {
+	value = strings.TrimSpace(value)
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap().WithZone(""), true
+}
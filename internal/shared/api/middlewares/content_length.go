@@ -11,8 +11,14 @@ var methodsToCheck = map[string]struct{}{
 	http.MethodPut:  {},
 }
 
+// ContentLengthMiddleware limits the request body size of the public routes
+// to the server max content length. It's superseded by the max content
+// length of each route group if the route limits are configured.
 func ContentLengthMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
+		if cfg.RouteLimits != nil {
+			return next
+		}
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if _, ok := methodsToCheck[r.Method]; ok {
 				// immediately return error if content length exceeds cfg maxContentLength size
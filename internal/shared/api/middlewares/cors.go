@@ -30,9 +30,12 @@ func CorsMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 				}
 			}
 
-			// Default CORS options for other routes
+			// Default CORS options for other routes, the default allowed headers
+			// are extended with the idempotency key and the api key
 			return cors.Options{
 				AllowedOrigins: cfg.Server.AllowedOrigins,
+				AllowedHeaders: []string{"Accept", "Content-Type", "X-Requested-With", IdempotencyKeyHeader, ApiKeyHeader},
+				ExposedHeaders: []string{IdempotentReplayedHeader, "Retry-After"},
 				MaxAge:         maxAge,
 			}
 		}
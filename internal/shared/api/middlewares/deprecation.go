@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// DeprecationMiddleware sets the Deprecation (RFC 9745) and Sunset (RFC 8594)
+// headers of the responses of the deprecated routes, along with the links to
+// their replacement and migration guide. The routes past their sunset are
+// rejected with a 410 if the sunset is enforced.
+func DeprecationMiddleware(cfg *config.DeprecationsConfig, clk clock.Clock) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if cfg == nil || len(cfg.Routes) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deprecation, ok := cfg.Routes[r.URL.Path]
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			header := w.Header()
+			header.Set("Deprecation", fmt.Sprintf("@%d", deprecation.DeprecatedAtTime.Unix()))
+			if !deprecation.SunsetTime.IsZero() {
+				header.Set("Sunset", deprecation.SunsetTime.UTC().Format(http.TimeFormat))
+			}
+			if deprecation.Link != "" {
+				header.Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"; type=\"text/html\"", deprecation.Link))
+			}
+			if deprecation.Replacement != "" {
+				header.Add("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", deprecation.Replacement))
+			}
+
+			if cfg.EnforceSunset && !deprecation.SunsetTime.IsZero() && !clk.Now().Before(deprecation.SunsetTime) {
+				writeErrorResponse(w, http.StatusGone, types.Gone, "This endpoint has been sunset")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// writeErrorResponse writes the error in the same format as the handlers'
+// error responses
+func writeErrorResponse(w http.ResponseWriter, statusCode int, errorCode types.ErrorCode, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"errorCode": errorCode.String(),
+		"message":   message,
+	})
+}
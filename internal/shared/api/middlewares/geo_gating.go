@@ -0,0 +1,77 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/netip"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// CountryResolver resolves the ISO 3166-1 alpha-2 code of the country of an
+// address, empty if unknown
+type CountryResolver interface {
+	Country(addr netip.Addr) (string, error)
+}
+
+// GeoGate blocks or flags the requests of the restricted jurisdictions, it's
+// applied to the write endpoints only
+type GeoGate struct {
+	cfg      *config.GeoGatingConfig
+	resolver CountryResolver
+}
+
+func NewGeoGate(cfg *config.GeoGatingConfig, resolver CountryResolver) *GeoGate {
+	return &GeoGate{cfg: cfg, resolver: resolver}
+}
+
+// GeoGatedResponse is the body of the requests blocked by the geo gating
+type GeoGatedResponse struct {
+	ErrorCode string `json:"errorCode"`
+	Message   string `json:"message"`
+	Country   string `json:"country"`
+}
+
+// Middleware resolves the country of the client IP and applies the gating
+// action if it's restricted. The requests whose country cannot be resolved
+// are served.
+func (g *GeoGate) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, ok := parseIp(GetClientIp(r))
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		country, err := g.resolver.Country(addr)
+		if err != nil {
+			log.Ctx(r.Context()).Warn().Err(err).Str("clientIp", addr.String()).
+				Msg("failed to resolve the country of the client ip")
+			next.ServeHTTP(w, r)
+			return
+		}
+		if country == "" || !g.cfg.IsRestricted(country) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		metrics.RecordGeoGatedRequest(country, g.cfg.Action)
+		if g.cfg.Action == config.GeoGatingFlag {
+			log.Ctx(r.Context()).Warn().Str("country", country).Str("path", r.URL.Path).
+				Msg("request from a restricted jurisdiction")
+			next.ServeHTTP(w, r)
+			return
+		}
+		log.Ctx(r.Context()).Info().Str("country", country).Str("path", r.URL.Path).
+			Msg("request from a restricted jurisdiction rejected")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnavailableForLegalReasons)
+		_ = json.NewEncoder(w).Encode(GeoGatedResponse{
+			ErrorCode: types.UnavailableForLegalReasons.String(),
+			Message:   "This operation is not available in your jurisdiction",
+			Country:   country,
+		})
+	})
+}
@@ -0,0 +1,181 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on the responses replayed from the first
+	// request carrying the idempotency key
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// The response is stored even if the client has gone away, as the client
+	// is expected to retry
+	idempotencyStoreTimeout = 10 * time.Second
+)
+
+// IdempotencyStore persists the responses of the requests carrying an
+// idempotency key
+type IdempotencyStore interface {
+	ReserveIdempotencyKey(ctx context.Context, key, requestHash string) (*dbmodel.IdempotencyKeyDocument, *types.Error)
+	CompleteIdempotencyKey(
+		ctx context.Context, key string, statusCode int, contentType string, body []byte,
+	) *types.Error
+	ReleaseIdempotencyKey(ctx context.Context, key string) *types.Error
+}
+
+// IdempotencyMiddleware replays the response of the first request carrying
+// an Idempotency-Key header to its retries, so that the clients can safely
+// retry the write requests. The key can only be reused for the same request
+// body, and the retries are rejected while the first request is in progress.
+// The 5xx responses are not stored, their retries are processed again.
+func IdempotencyMiddleware(cfg *config.IdempotencyConfig, store IdempotencyStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if cfg == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+			if idempotencyKey == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(idempotencyKey) > maxIdempotencyKeyLength {
+				writeErrorResponse(
+					w, http.StatusBadRequest, types.BadRequest,
+					fmt.Sprintf("%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength),
+				)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					writeErrorResponse(w, http.StatusRequestEntityTooLarge, types.RequestTooLarge, "Request Entity Too Large")
+					return
+				}
+				writeErrorResponse(w, http.StatusBadRequest, types.BadRequest, "failed to read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			bodyHash := sha256.Sum256(body)
+			requestHash := hex.EncodeToString(bodyHash[:])
+			// The keys are scoped by client and by endpoint, the clients
+			// happening to use the same key do not share their responses
+			key := idempotencyClientScope(r) + " " + r.Method + " " + r.URL.Path + " " + idempotencyKey
+
+			ctx := r.Context()
+			existing, reserveErr := store.ReserveIdempotencyKey(ctx, key, requestHash)
+			if reserveErr != nil {
+				writeErrorResponse(w, reserveErr.StatusCode, reserveErr.ErrorCode, reserveErr.Err.Error())
+				return
+			}
+			if existing != nil {
+				writeExistingResponse(w, existing, requestHash)
+				return
+			}
+
+			recorder := &idempotencyWriter{ResponseWriter: w}
+			completed := false
+			defer func() {
+				// The key of the request which has panicked is released
+				storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), idempotencyStoreTimeout)
+				defer cancel()
+				if !completed {
+					_ = store.ReleaseIdempotencyKey(storeCtx, key)
+					return
+				}
+				status := recorder.status
+				if status == 0 {
+					status = http.StatusOK
+				}
+				if status >= http.StatusInternalServerError {
+					_ = store.ReleaseIdempotencyKey(storeCtx, key)
+					return
+				}
+				_ = store.CompleteIdempotencyKey(
+					storeCtx, key, status, recorder.Header().Get("Content-Type"), recorder.body.Bytes(),
+				)
+			}()
+			next.ServeHTTP(recorder, r)
+			completed = true
+		})
+	}
+}
+
+// idempotencyClientScope identifies the client of the request by its api key,
+// or by its IP if the request has no api key
+func idempotencyClientScope(r *http.Request) string {
+	if name := GetApiKeyName(r); name != "" {
+		return "api-key:" + name
+	}
+	return "ip:" + GetClientIp(r)
+}
+
+func writeExistingResponse(w http.ResponseWriter, existing *dbmodel.IdempotencyKeyDocument, requestHash string) {
+	if existing.RequestHash != requestHash {
+		writeErrorResponse(
+			w, http.StatusUnprocessableEntity, types.IdempotencyKeyMismatch,
+			fmt.Sprintf("%s has already been used for another request", IdempotencyKeyHeader),
+		)
+		return
+	}
+	if !existing.Completed {
+		writeErrorResponse(
+			w, http.StatusConflict, types.IdempotencyKeyInProgress,
+			fmt.Sprintf("a request with the same %s is in progress", IdempotencyKeyHeader),
+		)
+		return
+	}
+	log.Debug().Int("status", existing.StatusCode).Msg("replaying idempotent response")
+	if existing.ContentType != "" {
+		w.Header().Set("Content-Type", existing.ContentType)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(existing.StatusCode)
+	_, _ = w.Write(existing.Body)
+}
+
+// idempotencyWriter records the status and the body of the response while
+// writing it
+type idempotencyWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *idempotencyWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap allows the response controller to reach the underlying writer
+func (w *idempotencyWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
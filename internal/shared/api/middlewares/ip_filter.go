@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// IpFilter rejects the requests according to the client IP, before reaching
+// the handlers. Its lists can be replaced while serving.
+type IpFilter struct {
+	cfg atomic.Pointer[config.IpFilterConfig]
+}
+
+func NewIpFilter(cfg *config.IpFilterConfig) *IpFilter {
+	filter := &IpFilter{}
+	filter.cfg.Store(cfg)
+	return filter
+}
+
+// Update replaces the lists of the filter
+func (f *IpFilter) Update(cfg *config.IpFilterConfig) {
+	f.cfg.Store(cfg)
+	log.Info().Int("adminAllowlist", len(cfg.AdminAllowlistPrefixes)).
+		Int("publicDenylist", len(cfg.PublicDenylistPrefixes)).
+		Msg("ip filter updated")
+}
+
+// AdminAllowlistMiddleware rejects the clients outside of the admin
+// allowlist, as well as the clients whose IP cannot be resolved
+func (f *IpFilter) AdminAllowlistMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, ok := parseIp(GetClientIp(r))
+		if !ok || !f.cfg.Load().IsAdminAllowed(addr) {
+			writeForbidden(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// PublicDenylistMiddleware rejects the clients of the public denylist
+func (f *IpFilter) PublicDenylistMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if addr, ok := parseIp(GetClientIp(r)); ok && f.cfg.Load().IsPublicDenied(addr) {
+			writeForbidden(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeForbidden(w http.ResponseWriter, r *http.Request) {
+	// Debug only, as the blocked clients may keep on flooding the service
+	log.Debug().Str("clientIp", GetClientIp(r)).Str("path", r.URL.Path).
+		Msg("request rejected by the ip filter")
+	writeErrorResponse(w, http.StatusForbidden, types.Forbidden, "Access denied")
+}
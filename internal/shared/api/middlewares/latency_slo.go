@@ -0,0 +1,245 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+)
+
+// sloSlots is the number of slots the window is split into, the oldest slot
+// is dropped as a whole once it falls out of the window
+const sloSlots = 12
+
+// latencyBucketBounds are the upper bounds of the latency buckets the
+// percentiles are estimated from, the last bucket is unbounded
+var latencyBucketBounds = [...]time.Duration{
+	5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond,
+	50 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond,
+	500 * time.Millisecond, time.Second, 2500 * time.Millisecond,
+	5 * time.Second, 10 * time.Second, 30 * time.Second,
+}
+
+// latencyBuckets counts the requests per latency bucket
+type latencyBuckets [len(latencyBucketBounds) + 1]int64
+
+// LatencySlo tracks the latencies of the routes with a latency budget over a
+// rolling window. The burn rate is the fraction of the requests over the
+// budget relative to the fraction allowed by the objective, a burn rate above
+// 1 exhausts the error budget before the end of the window.
+type LatencySlo struct {
+	cfg      *config.SloConfig
+	clock    clock.Clock
+	slotSize time.Duration
+
+	mu     sync.Mutex
+	routes map[string]*routeLatency
+}
+
+type routeLatency struct {
+	slots [sloSlots]latencySlot
+}
+
+type latencySlot struct {
+	start   time.Time
+	count   int64
+	overP95 int64
+	overP99 int64
+	buckets latencyBuckets
+}
+
+// RouteSlo is the latency summary of a route over the window
+type RouteSlo struct {
+	Route     string
+	Requests  int64
+	P95       time.Duration
+	P99       time.Duration
+	P95Budget time.Duration
+	P99Budget time.Duration
+	// P95BurnRate and P99BurnRate are zero if the budget is not set
+	P95BurnRate float64
+	P99BurnRate float64
+}
+
+func NewLatencySlo(cfg *config.SloConfig, clock clock.Clock) *LatencySlo {
+	return &LatencySlo{
+		cfg:      cfg,
+		clock:    clock,
+		slotSize: cfg.Window / sloSlots,
+		routes:   make(map[string]*routeLatency),
+	}
+}
+
+// Middleware records the latency of the requests of the routes with a budget
+func (s *LatencySlo) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := s.cfg.Routes[r.URL.Path]; !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		start := s.clock.Now()
+		next.ServeHTTP(w, r)
+		s.Record(r.URL.Path, s.clock.Now().Sub(start))
+	})
+}
+
+// Record records the latency of a request of the route and refreshes the
+// burn rates of the route
+func (s *LatencySlo) Record(route string, latency time.Duration) {
+	budget, ok := s.cfg.Routes[route]
+	if !ok {
+		return
+	}
+	now := s.clock.Now()
+	s.mu.Lock()
+	rl, ok := s.routes[route]
+	if !ok {
+		rl = &routeLatency{}
+		s.routes[route] = rl
+	}
+	slot := s.currentSlot(rl, now)
+	slot.count++
+	if budget.P95 > 0 && latency > budget.P95 {
+		slot.overP95++
+	}
+	if budget.P99 > 0 && latency > budget.P99 {
+		slot.overP99++
+	}
+	slot.buckets[latencyBucket(latency)]++
+	summary := s.summarize(route, budget, rl, now)
+	s.mu.Unlock()
+
+	recordBurnRates(summary)
+}
+
+// Start refreshes the burn rates of all the routes at every slot until the
+// context is done, so that the burn rates of a route decay once its requests
+// have stopped rather than being stuck at the value of the last request
+func (s *LatencySlo) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.slotSize)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.RefreshBurnRates()
+			}
+		}
+	}()
+}
+
+// RefreshBurnRates records the burn rates of all the routes over the window
+func (s *LatencySlo) RefreshBurnRates() {
+	for _, summary := range s.Summary() {
+		recordBurnRates(summary)
+	}
+}
+
+func recordBurnRates(summary RouteSlo) {
+	if summary.P95Budget > 0 {
+		metrics.RecordSloBurnRate(summary.Route, "p95", summary.P95BurnRate)
+	}
+	if summary.P99Budget > 0 {
+		metrics.RecordSloBurnRate(summary.Route, "p99", summary.P99BurnRate)
+	}
+}
+
+// Summary returns the latency summary of the routes with a budget, ordered by
+// route
+func (s *LatencySlo) Summary() []RouteSlo {
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	summaries := make([]RouteSlo, 0, len(s.cfg.Routes))
+	for route, budget := range s.cfg.Routes {
+		rl, ok := s.routes[route]
+		if !ok {
+			rl = &routeLatency{}
+		}
+		summaries = append(summaries, s.summarize(route, budget, rl, now))
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Route < summaries[j].Route
+	})
+	return summaries
+}
+
+// currentSlot returns the slot of the time, reset if it was last used for an
+// earlier window
+func (s *LatencySlo) currentSlot(rl *routeLatency, now time.Time) *latencySlot {
+	start := now.Truncate(s.slotSize)
+	slot := &rl.slots[(start.UnixNano()/int64(s.slotSize))%sloSlots]
+	if !slot.start.Equal(start) {
+		*slot = latencySlot{start: start}
+	}
+	return slot
+}
+
+func (s *LatencySlo) summarize(
+	route string, budget *config.RouteLatencyBudget, rl *routeLatency, now time.Time,
+) RouteSlo {
+	summary := RouteSlo{Route: route, P95Budget: budget.P95, P99Budget: budget.P99}
+	var overP95, overP99 int64
+	var buckets latencyBuckets
+	windowStart := now.Add(-s.cfg.Window)
+	for i := range rl.slots {
+		slot := &rl.slots[i]
+		if slot.count == 0 || !slot.start.After(windowStart) {
+			continue
+		}
+		summary.Requests += slot.count
+		overP95 += slot.overP95
+		overP99 += slot.overP99
+		for b, count := range slot.buckets {
+			buckets[b] += count
+		}
+	}
+	if summary.Requests == 0 {
+		return summary
+	}
+	summary.P95 = latencyPercentile(buckets, summary.Requests, 0.95)
+	summary.P99 = latencyPercentile(buckets, summary.Requests, 0.99)
+	if budget.P95 > 0 {
+		summary.P95BurnRate = float64(overP95) / float64(summary.Requests) / 0.05
+	}
+	if budget.P99 > 0 {
+		summary.P99BurnRate = float64(overP99) / float64(summary.Requests) / 0.01
+	}
+	return summary
+}
+
+func latencyBucket(latency time.Duration) int {
+	for i, bound := range latencyBucketBounds {
+		if latency <= bound {
+			return i
+		}
+	}
+	return len(latencyBucketBounds)
+}
+
+// latencyPercentile estimates the percentile as the upper bound of its
+// bucket, the unbounded bucket is reported as the last bound
+func latencyPercentile(buckets latencyBuckets, total int64, percentile float64) time.Duration {
+	rank := int64(float64(total)*percentile + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+	var cumulative int64
+	for i, count := range buckets {
+		cumulative += count
+		if cumulative >= rank {
+			if i < len(latencyBucketBounds) {
+				return latencyBucketBounds[i]
+			}
+			break
+		}
+	}
+	return latencyBucketBounds[len(latencyBucketBounds)-1]
+}
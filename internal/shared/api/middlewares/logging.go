@@ -5,8 +5,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/logging"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/tracing"
-	"github.com/rs/zerolog/log"
 )
 
 func LoggingMiddleware(next http.Handler) http.Handler {
@@ -19,7 +20,7 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 		}
 
 		startTime := time.Now()
-		logger := log.With().Str("path", r.URL.Path).Logger()
+		logger := logging.Module(config.LogModuleHandlers).With().Str("path", r.URL.Path).Logger()
 
 		// Attach traceId into each log within the request chain
 		traceId := r.Context().Value(tracing.TraceIdKey)
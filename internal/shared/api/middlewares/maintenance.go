@@ -0,0 +1,30 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// maintenanceExemptPaths are served in maintenance mode so that the load
+// balancers keep the instance registered
+var maintenanceExemptPaths = map[string]struct{}{
+	"/healthcheck": {},
+}
+
+// MaintenanceMiddleware rejects the requests with a 503 while the maintenance
+// mode is enabled
+func MaintenanceMiddleware(enabled func() bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, exempt := maintenanceExemptPaths[r.URL.Path]; exempt || !enabled() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			writeErrorResponse(
+				w, http.StatusServiceUnavailable, types.ServiceUnavailable,
+				"Service is under maintenance, please retry later",
+			)
+		})
+	}
+}
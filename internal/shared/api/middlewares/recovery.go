@@ -0,0 +1,89 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/errorreporting"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/tracing"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog/log"
+)
+
+// RecoveryMiddleware recovers from the panics of the handlers, so that a
+// single faulty request is answered with an internal service error instead of
+// having its connection dropped. The panic is logged with its stack trace.
+func RecoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writer := &recoveryWriter{ResponseWriter: w}
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// ErrAbortHandler is the documented way to abort the response, the
+			// http server silently closes the connection for it
+			if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(recovered)
+			}
+			route := routePattern(r)
+			metrics.RecordHttpPanic(route)
+			event := log.Error().
+				Str("panic", fmt.Sprint(recovered)).
+				Str("method", r.Method).
+				Str("route", route).
+				Bytes("stack", debug.Stack())
+			if traceId := r.Context().Value(tracing.TraceIdKey); traceId != nil {
+				event = event.Interface("traceId", traceId)
+			}
+			event.Msg("recovered from panic while handling request")
+			errorreporting.CaptureHttpError(
+				r, http.StatusInternalServerError, types.InternalServiceError, fmt.Errorf("panic: %v", recovered),
+			)
+
+			// The status can't be changed once the response has been started
+			if writer.wroteHeader {
+				return
+			}
+			writeErrorResponse(
+				w, http.StatusInternalServerError, types.InternalServiceError,
+				"Internal service error",
+			)
+		}()
+		next.ServeHTTP(writer, r)
+	})
+}
+
+// routePattern returns the matched route pattern of the request, or its path
+// if the request has not been routed
+func routePattern(r *http.Request) string {
+	if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+		return routeCtx.RoutePattern()
+	}
+	return r.URL.Path
+}
+
+// recoveryWriter records whether the response has been started
+type recoveryWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *recoveryWriter) WriteHeader(statusCode int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *recoveryWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap allows the response controller to reach the underlying writer
+func (w *recoveryWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
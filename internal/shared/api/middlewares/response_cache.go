@@ -0,0 +1,199 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+)
+
+const (
+	cacheResultHit   = "hit"
+	cacheResultStale = "stale"
+	cacheResultMiss  = "miss"
+
+	// CacheStatusHeader tells whether the response was served from the cache
+	CacheStatusHeader = "X-Cache"
+
+	// The background refreshes are detached from the request triggering them,
+	// hence they have their own timeout
+	responseCacheRefreshTimeout = 30 * time.Second
+)
+
+type cachedResponse struct {
+	status   int
+	header   http.Header
+	body     []byte
+	storedAt time.Time
+}
+
+// ResponseCache caches the successful GET responses per URL. A cached response
+// is served as fresh until its TTL, then served stale while a single
+// background request refreshes it, until its stale TTL has elapsed as well.
+// The concurrent misses of an URL wait for a single request to the handler,
+// so that bursts of traffic only hit the database once.
+type ResponseCache struct {
+	cfg *config.ResponseCacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+	// inFlight holds the URLs being fetched, the channel is closed once done
+	inFlight map[string]chan struct{}
+}
+
+func NewResponseCache(cfg *config.ResponseCacheConfig) *ResponseCache {
+	return &ResponseCache{
+		cfg:      cfg,
+		entries:  make(map[string]*cachedResponse),
+		inFlight: make(map[string]chan struct{}),
+	}
+}
+
+func (c *ResponseCache) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+		key := r.URL.RequestURI()
+		for {
+			c.mu.Lock()
+			if entry := c.entries[key]; entry != nil {
+				age := time.Since(entry.storedAt)
+				if age < c.cfg.TTL {
+					c.mu.Unlock()
+					metrics.RecordResponseCacheResult(r.URL.Path, cacheResultHit)
+					writeCachedResponse(w, entry, cacheResultHit, age)
+					return
+				}
+				if age < c.cfg.TTL+c.cfg.StaleTTL {
+					if _, refreshing := c.inFlight[key]; !refreshing {
+						c.inFlight[key] = make(chan struct{})
+						ctx, cancel := context.WithTimeout(
+							context.WithoutCancel(r.Context()), responseCacheRefreshTimeout,
+						)
+						refreshRequest := r.Clone(ctx)
+						go func() {
+							defer cancel()
+							// The refresh is detached from the request, its panics
+							// are recovered here
+							c.fetch(RecoveryMiddleware(next), refreshRequest, key)
+						}()
+					}
+					c.mu.Unlock()
+					metrics.RecordResponseCacheResult(r.URL.Path, cacheResultStale)
+					writeCachedResponse(w, entry, cacheResultStale, age)
+					return
+				}
+			}
+			// Wait for the request in flight and check the cache again
+			if done, fetching := c.inFlight[key]; fetching {
+				c.mu.Unlock()
+				select {
+				case <-done:
+					continue
+				case <-r.Context().Done():
+					return
+				}
+			}
+			c.inFlight[key] = make(chan struct{})
+			c.mu.Unlock()
+
+			metrics.RecordResponseCacheResult(r.URL.Path, cacheResultMiss)
+			writeCachedResponse(w, c.fetch(next, r, key), cacheResultMiss, 0)
+			return
+		}
+	})
+}
+
+// fetch serves the request through the handler and caches the response if
+// successful. The URL must have been marked as in flight.
+func (c *ResponseCache) fetch(next http.Handler, r *http.Request, key string) *cachedResponse {
+	recorder := &responseRecorder{header: make(http.Header)}
+	defer func() {
+		c.mu.Lock()
+		done := c.inFlight[key]
+		delete(c.inFlight, key)
+		c.mu.Unlock()
+		close(done)
+	}()
+	next.ServeHTTP(recorder, r)
+
+	response := &cachedResponse{
+		status:   recorder.status,
+		header:   recorder.header,
+		body:     recorder.body.Bytes(),
+		storedAt: time.Now(),
+	}
+	if response.status == 0 {
+		response.status = http.StatusOK
+	}
+	if response.status == http.StatusOK {
+		c.store(key, response)
+	}
+	return response
+}
+
+func (c *ResponseCache) store(key string, response *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.cfg.MaxEntries {
+		// Evict the expired entries, or the oldest one if none has expired
+		var oldestKey string
+		var oldest time.Time
+		for k, entry := range c.entries {
+			if time.Since(entry.storedAt) >= c.cfg.TTL+c.cfg.StaleTTL {
+				delete(c.entries, k)
+				continue
+			}
+			if oldestKey == "" || entry.storedAt.Before(oldest) {
+				oldestKey, oldest = k, entry.storedAt
+			}
+		}
+		if len(c.entries) >= c.cfg.MaxEntries {
+			delete(c.entries, oldestKey)
+		}
+	}
+	c.entries[key] = response
+}
+
+func writeCachedResponse(w http.ResponseWriter, response *cachedResponse, result string, age time.Duration) {
+	for name, values := range response.header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(CacheStatusHeader, result)
+	if result != cacheResultMiss {
+		w.Header().Set("Age", strconv.Itoa(int(age.Seconds())))
+	}
+	w.WriteHeader(response.status)
+	_, _ = w.Write(response.body)
+}
+
+// responseRecorder buffers the response so that it can be cached
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
@@ -0,0 +1,67 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// RouteLimitsMiddleware applies the timeouts and the max request body size
+// of the route group. The body is read upfront within the read timeout, so
+// that a slow or oversized body is rejected before reaching the handler.
+func RouteLimitsMiddleware(limits *config.RouteGroupLimits) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limits == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The deadlines are not supported by all the response writers,
+			// e.g. the recorders used in tests, hence the errors are ignored
+			rc := http.NewResponseController(w)
+			now := time.Now()
+			if limits.ReadTimeout > 0 {
+				_ = rc.SetReadDeadline(now.Add(limits.ReadTimeout))
+			}
+			if limits.WriteTimeout > 0 {
+				_ = rc.SetWriteDeadline(now.Add(limits.WriteTimeout))
+			}
+
+			if limits.MaxContentLength > 0 && r.Body != nil && r.Body != http.NoBody {
+				if r.ContentLength > limits.MaxContentLength {
+					writeErrorResponse(w, http.StatusRequestEntityTooLarge, types.RequestTooLarge, "Request entity too large")
+					return
+				}
+				body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limits.MaxContentLength))
+				if err != nil {
+					var maxBytesErr *http.MaxBytesError
+					switch {
+					case errors.As(err, &maxBytesErr):
+						writeErrorResponse(w, http.StatusRequestEntityTooLarge, types.RequestTooLarge, "Request entity too large")
+					case errors.Is(err, os.ErrDeadlineExceeded):
+						log.Ctx(r.Context()).Warn().Str("path", r.URL.Path).Msg("request body read timed out")
+						writeErrorResponse(w, http.StatusRequestTimeout, types.RequestTimeout, "Request body read timed out")
+					default:
+						writeErrorResponse(w, http.StatusBadRequest, types.BadRequest, "Failed to read the request body")
+					}
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			if limits.HandlerTimeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), limits.HandlerTimeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,43 +1,145 @@
 package api
 
 import (
+	"net/http"
+
 	_ "github.com/babylonlabs-io/staking-api-service/docs"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	v1handler "github.com/babylonlabs-io/staking-api-service/internal/v1/api/handlers"
 	"github.com/go-chi/chi"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 func (a *Server) SetupRoutes(r *chi.Mux) {
 	handlers := a.handlers
-	// Extend on the healthcheck endpoint here
-	r.Get("/healthcheck", registerHandler(handlers.SharedHandler.HealthCheck))
-
-	r.Get("/v1/staker/delegations", registerHandler(handlers.V1Handler.GetStakerDelegations))
-	r.Post("/v1/unbonding", registerHandler(handlers.V1Handler.UnbondDelegation))
-	r.Get("/v1/unbonding/eligibility", registerHandler(handlers.V1Handler.GetUnbondingEligibility))
-	r.Get("/v1/global-params", registerHandler(handlers.V1Handler.GetBabylonGlobalParams))
-	r.Get("/v1/finality-providers", registerHandler(handlers.V1Handler.GetFinalityProviders))
-	r.Get("/v1/stats", registerHandler(handlers.V1Handler.GetOverallStats))
-	r.Get("/v1/stats/staker", registerHandler(handlers.V1Handler.GetStakersStats))
-	r.Get("/v1/staker/delegation/check", registerHandler(handlers.V1Handler.CheckStakerDelegationExist))
-	r.Get("/v1/delegation", registerHandler(handlers.V1Handler.GetDelegationByTxHash))
-
-	// Only register these routes if the asset has been configured
-	// The endpoints are used to check ordinals within the UTXOs
-	// Don't deprecate this endpoint
-	if a.cfg.Assets != nil {
-		r.Post("/v1/ordinals/verify-utxos", registerHandler(handlers.SharedHandler.VerifyUTXOs))
+	if a.latencySlo != nil {
+		r.Use(a.latencySlo.Middleware)
+	}
+	r.Use(middlewares.CacheControlMiddleware(a.cfg.CacheControl))
+	r.Use(middlewares.DeprecationMiddleware(a.cfg.Deprecations, a.clock))
+	// Toggled on the admin listener
+	r.Use(middlewares.MaintenanceMiddleware(a.maintenance.Load))
+
+	var publicLimits, unbondingLimits, batchLimits *config.RouteGroupLimits
+	if a.cfg.RouteLimits != nil {
+		publicLimits = a.cfg.RouteLimits.Public.WithServerDefaults(a.cfg.Server)
+		unbondingLimits = a.cfg.RouteLimits.Unbonding.WithServerDefaults(a.cfg.Server)
+		batchLimits = a.cfg.RouteLimits.Batch
+	}
+	batchLimits = batchLimits.WithDefaultMaxContentLength(v1handler.MAX_STAKERS_STATS_CONTENT_LENGTH)
+
+	// The unbonding requests, the partner attributions, the delegation labels
+	// and the export jobs are stored into the db, hence they are given their
+	// own limits. Their retries are made safe by the idempotency keys. Being
+	// the write endpoints, they are gated by the jurisdiction of the client.
+	r.Group(func(r chi.Router) {
+		if a.geoGate != nil {
+			r.Use(a.geoGate.Middleware)
+		}
+		r.Use(middlewares.ContentLengthMiddleware(a.cfg))
+		r.Use(middlewares.RouteLimitsMiddleware(unbondingLimits))
+		r.Use(middlewares.IdempotencyMiddleware(a.cfg.Idempotency, handlers.SharedHandler.Service))
+		r.Post("/v1/unbonding", a.registerHandler(handlers.V1Handler.UnbondDelegation))
+		if a.cfg.Partners != nil {
+			r.Post("/v1/delegation/partner", a.registerHandler(handlers.V1Handler.AttributeDelegationToPartner))
+		}
+		if a.cfg.Exports != nil {
+			r.Post("/v1/exports", a.registerHandler(handlers.V1Handler.CreateExportJob))
+		}
+		// The labels are private to the api key which attached them
+		if a.apiKeyQuotas != nil {
+			r.Put("/v1/delegation/labels", a.registerHandler(handlers.V1Handler.SetDelegationLabels))
+		}
+	})
+
+	// The stats and the finality providers are polled by all the clients,
+	// they are served from the response cache if configured
+	var cached []func(http.Handler) http.Handler
+	if a.responseCache != nil {
+		cached = append(cached, a.responseCache.Middleware)
 	}
 
-	// Don't deprecate this endpoint
-	r.Get("/v1/staker/pubkey-lookup", registerHandler(handlers.V1Handler.GetPubKeys))
+	// The batch requests carry up to a thousand public keys, hence they are
+	// not limited by the max content length of the server
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.RouteLimitsMiddleware(batchLimits))
+		r.Post("/v1/stakers/stats", a.registerHandler(handlers.V1Handler.GetStakersStatsByPks))
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.ContentLengthMiddleware(a.cfg))
+		r.Use(middlewares.RouteLimitsMiddleware(publicLimits))
+
+		// Extend on the healthcheck endpoint here
+		r.Get("/healthcheck", a.registerHandler(handlers.SharedHandler.HealthCheck))
+		r.Get("/version", a.registerHandler(handlers.SharedHandler.GetVersion))
+		r.Get("/v1/deprecations", a.registerHandler(handlers.SharedHandler.GetDeprecations))
+
+		if a.apiKeyQuotas != nil {
+			r.Get("/v1/api-key/usage", a.registerHandler(a.getApiKeyUsage))
+			r.Get("/v1/delegation/labels", a.registerHandler(handlers.V1Handler.GetDelegationLabels))
+			r.Get("/v1/delegations/labeled", a.registerHandler(handlers.V1Handler.GetLabeledDelegations))
+		}
+
+		r.Get("/v1/staker/delegations", a.registerHandler(handlers.V1Handler.GetStakerDelegations))
+		r.Get("/v1/staker/delegations/stream", a.registerHandler(handlers.V1Handler.StreamStakerDelegations))
+		r.Get("/v1/staker/summary", a.registerHandler(handlers.V1Handler.GetStakerSummary))
+		r.Get("/v1/search", a.registerHandler(handlers.V1Handler.Search))
+		r.Get("/v1/unbonding/eligibility", a.registerHandler(handlers.V1Handler.GetUnbondingEligibility))
+		r.Get("/v1/unbonding/status", a.registerHandler(handlers.V1Handler.GetUnbondingRequestStatus))
+		r.Get("/v1/global-params", a.registerHandler(handlers.V1Handler.GetBabylonGlobalParams))
+		r.Get("/v1/network/tip", a.registerHandler(handlers.V1Handler.GetNetworkTip))
+		r.With(cached...).Get("/v1/finality-providers", a.registerHandler(handlers.V1Handler.GetFinalityProviders))
+		r.Get("/v1/finality-provider", a.registerHandler(handlers.V1Handler.GetFinalityProvider))
+		r.Get("/v1/finality-provider/commission-history", a.registerHandler(handlers.V1Handler.GetFinalityProviderCommissionHistory))
+		r.Get("/v1/finality-provider/delegation-count", a.registerHandler(handlers.V1Handler.GetFinalityProviderDelegationCount))
+		r.With(cached...).Get("/v1/stats", a.registerHandler(handlers.V1Handler.GetOverallStats))
+		r.Get("/v1/stats/staker", a.registerHandler(handlers.V1Handler.GetStakersStats))
+		if a.cfg.StatsRefresher != nil {
+			r.Get("/v1/stats/delta", a.registerHandler(handlers.V1Handler.GetStatsDelta))
+		}
+		if a.cfg.Partners != nil {
+			r.Get("/v1/stats/partner", a.registerHandler(handlers.V1Handler.GetPartnerStats))
+		}
+		if a.cfg.Exports != nil {
+			r.Get("/v1/exports", a.registerHandler(handlers.V1Handler.GetExportJob))
+		}
+		r.Get("/v1/staker/delegation/check", a.registerHandler(handlers.V1Handler.CheckStakerDelegationExist))
+		r.Get("/v1/delegation", a.registerHandler(handlers.V1Handler.GetDelegationByTxHash))
+		// The HEAD requests are served by the existence check rather than
+		// reading the whole delegation
+		r.Head("/v1/delegation", a.registerHandler(handlers.V1Handler.CheckDelegationExists))
+		r.Get("/v1/delegation/exists", a.registerHandler(handlers.V1Handler.CheckDelegationExists))
+		r.Head("/v1/delegation/exists", a.registerHandler(handlers.V1Handler.CheckDelegationExists))
+		r.Get("/v1/delegation/by-tx", a.registerHandler(handlers.V1Handler.GetDelegationByAnyTxHash))
+		r.Get("/v1/delegation/timeline", a.registerHandler(handlers.V1Handler.GetDelegationTimeline))
+		r.Get("/v1/delegations/changes", a.registerHandler(handlers.V1Handler.GetDelegationChanges))
+		r.Post("/v1/staking/verify", a.registerHandler(handlers.V1Handler.VerifyStakingTx))
+		r.Post("/v1/staking/eligibility", a.registerHandler(handlers.V1Handler.CheckStakingEligibility))
+
+		// Only register these routes if the asset has been configured
+		// The endpoints are used to check ordinals within the UTXOs
+		// Don't deprecate this endpoint
+		if a.cfg.Assets != nil {
+			r.Post("/v1/ordinals/verify-utxos", a.registerHandler(handlers.SharedHandler.VerifyUTXOs))
+		}
+
+		// Don't deprecate this endpoint
+		r.Get("/v1/staker/pubkey-lookup", a.registerHandler(handlers.V1Handler.GetPubKeys))
 
-	r.Get("/swagger/*", httpSwagger.WrapHandler)
+		r.Get("/swagger/*", httpSwagger.WrapHandler)
 
-	// V2 API
-	r.Get("/v2/finality-providers", registerHandler(handlers.V2Handler.GetFinalityProviders))
-	r.Get("/v2/params", registerHandler(handlers.V2Handler.GetParams))
-	r.Get("/v2/delegation", registerHandler(handlers.V2Handler.GetDelegation))
-	r.Get("/v2/delegations", registerHandler(handlers.V2Handler.GetDelegations))
-	r.Get("/v2/stats", registerHandler(handlers.V2Handler.GetOverallStats))
-	r.Get("/v2/staker/stats", registerHandler(handlers.V2Handler.GetStakerStats))
+		// V2 API
+		r.With(cached...).Get("/v2/finality-providers", a.registerHandler(handlers.V2Handler.GetFinalityProviders))
+		r.Get("/v2/params", a.registerHandler(handlers.V2Handler.GetParams))
+		r.Get("/v2/delegation", a.registerHandler(handlers.V2Handler.GetDelegation))
+		r.Get("/v2/delegation/transition-status", a.registerHandler(handlers.V2Handler.GetDelegationTransitionStatus))
+		r.Get("/v2/delegations", a.registerHandler(handlers.V2Handler.GetDelegations))
+		r.Get("/v2/delegations/unified", a.registerHandler(handlers.V2Handler.GetUnifiedDelegations))
+		r.With(cached...).Get("/v2/stats", a.registerHandler(handlers.V2Handler.GetOverallStats))
+		r.Get("/v2/staker/stats", a.registerHandler(handlers.V2Handler.GetStakerStats))
+		r.With(cached...).Get("/v2/bsns", a.registerHandler(handlers.V2Handler.GetBsns))
+		r.Get("/v2/bsn/finality-providers", a.registerHandler(handlers.V2Handler.GetBsnFinalityProviders))
+	})
 }
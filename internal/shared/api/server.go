@@ -2,40 +2,71 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/geoip"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/scheduler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
 	"github.com/go-chi/chi"
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
 type Server struct {
 	httpServer *http.Server
-	handlers   *handlers.Handlers
-	cfg        *config.Config
+	// adminHttpServer is nil if the admin listener is not configured
+	adminHttpServer *http.Server
+	handlers        *handlers.Handlers
+	cfg             *config.Config
+	// replayer is nil if the unprocessable messages cannot be replayed
+	replayer MessageReplayer
+	// maintenance rejects the public requests while enabled
+	maintenance atomic.Bool
+	// responseCache is nil if the response cache is not configured
+	responseCache *middlewares.ResponseCache
+	// ipFilter is nil if the ip filter is not configured
+	ipFilter *middlewares.IpFilter
+	// apiKeyQuotas is nil if the api keys are not configured
+	apiKeyQuotas *middlewares.ApiKeyQuotas
+	// geoGate is nil if the geo gating is not configured
+	geoGate *middlewares.GeoGate
+	// scheduler runs the scheduled jobs of this replica
+	scheduler *scheduler.Scheduler
+	// latencySlo is nil if the slo is not configured
+	latencySlo *middlewares.LatencySlo
+	clock      clock.Clock
 }
 
 func New(
-	ctx context.Context, cfg *config.Config, services *services.Services,
+	ctx context.Context, cfg *config.Config, services *services.Services, replayer MessageReplayer,
 ) (*Server, error) {
 	r := chi.NewRouter()
 
-	logLevel, err := zerolog.ParseLevel(cfg.Server.LogLevel)
-	if err != nil {
-		log.Fatal().Err(err).Msg("error while parsing log level")
-	}
-	zerolog.SetGlobalLevel(logLevel)
-
 	r.Use(middlewares.CorsMiddleware(cfg))
 	r.Use(middlewares.SecurityHeadersMiddleware())
+	r.Use(middlewares.ClientIpMiddleware(cfg.ClientIp))
+	var ipFilter *middlewares.IpFilter
+	if cfg.IpFilter != nil {
+		ipFilter = middlewares.NewIpFilter(cfg.IpFilter)
+		config.WatchIpFilter(cfg.IpFilter, ipFilter.Update)
+		r.Use(ipFilter.PublicDenylistMiddleware)
+	}
 	r.Use(middlewares.TracingMiddleware)
 	r.Use(middlewares.LoggingMiddleware)
-	r.Use(middlewares.ContentLengthMiddleware(cfg))
+	r.Use(middlewares.AccessLogMiddleware(cfg.AccessLog))
+	r.Use(middlewares.RecoveryMiddleware)
+	var apiKeyQuotas *middlewares.ApiKeyQuotas
+	if cfg.ApiKeys != nil {
+		apiKeyQuotas = middlewares.NewApiKeyQuotas(cfg.ApiKeys, services.SharedService, services.Clock)
+		apiKeyQuotas.Start(ctx)
+		r.Use(apiKeyQuotas.Middleware)
+	}
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
@@ -44,9 +75,12 @@ func New(
 		IdleTimeout:  cfg.Server.IdleTimeout,
 		Handler:      r,
 	}
-
-	if err != nil {
-		log.Fatal().Err(err).Msg("error while setting up handlers")
+	if cfg.Server.TLS != nil {
+		tlsConfig, err := cfg.Server.TLS.Build()
+		if err != nil {
+			return nil, fmt.Errorf("error while setting up server tls: %w", err)
+		}
+		srv.TLSConfig = tlsConfig
 	}
 
 	handlers, err := handlers.New(ctx, cfg, services)
@@ -55,15 +89,59 @@ func New(
 	}
 
 	server := &Server{
-		httpServer: srv,
-		handlers:   handlers,
-		cfg:        cfg,
+		httpServer:   srv,
+		handlers:     handlers,
+		cfg:          cfg,
+		replayer:     replayer,
+		ipFilter:     ipFilter,
+		apiKeyQuotas: apiKeyQuotas,
+		scheduler:    services.Scheduler,
+		clock:        services.Clock,
+	}
+	if cfg.Slo != nil {
+		server.latencySlo = middlewares.NewLatencySlo(cfg.Slo, services.Clock)
+		server.latencySlo.Start(ctx)
+	}
+	if cfg.ResponseCache != nil {
+		server.responseCache = middlewares.NewResponseCache(cfg.ResponseCache)
+	}
+	if cfg.GeoGating != nil {
+		countryReader, err := geoip.Open(cfg.GeoGating.DatabasePath)
+		if err != nil {
+			return nil, fmt.Errorf("error while setting up geo gating: %w", err)
+		}
+		server.geoGate = middlewares.NewGeoGate(cfg.GeoGating, countryReader)
 	}
 	server.SetupRoutes(r)
+	server.adminHttpServer, err = server.newAdminHttpServer()
+	if err != nil {
+		return nil, err
+	}
 	return server, nil
 }
 
 func (a *Server) Start() error {
+	a.startAdmin()
 	log.Info().Msgf("Starting server on %s", a.httpServer.Addr)
-	return a.httpServer.ListenAndServe()
+	return listenAndServe(a.httpServer)
+}
+
+// Shutdown stops the servers once their in-flight requests are done, up to
+// the deadline of the context
+func (a *Server) Shutdown(ctx context.Context) error {
+	log.Info().Msg("Shutting down server")
+	var adminErr error
+	if a.adminHttpServer != nil {
+		adminErr = a.adminHttpServer.Shutdown(ctx)
+	}
+	return errors.Join(a.httpServer.Shutdown(ctx), adminErr)
+}
+
+// listenAndServe serves over TLS if the TLS config of the server is set, the
+// certificates are already loaded into it
+func listenAndServe(srv *http.Server) error {
+	if srv.TLSConfig != nil {
+		return srv.ListenAndServeTLS("", "")
+	}
+	return srv.ListenAndServe()
 }
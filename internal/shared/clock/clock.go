@@ -0,0 +1,20 @@
+package clock
+
+import "time"
+
+// Clock tells the current time. It's injected into the services and the db
+// clients instead of calling time.Now() so that tests can control the time.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+// New returns the clock reading the system time
+func New() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
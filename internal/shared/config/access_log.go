@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"strings"
+)
+
+// AccessLogConfig defines the access log of the API requests, emitted as JSON
+// so that it can be ingested by the log pipelines.
+type AccessLogConfig struct {
+	// ClientIdHeader is the request header identifying the client, it's logged
+	// along with the request if set
+	ClientIdHeader string `mapstructure:"client-id-header"`
+	// RedactedQueryParams are the query params whose values are not logged
+	RedactedQueryParams []string `mapstructure:"redacted-query-params"`
+	// LogBodies enables the logging of the JSON request and response bodies of
+	// up to MaxBodySize bytes, the larger or non JSON bodies are not logged
+	LogBodies   bool `mapstructure:"log-bodies"`
+	MaxBodySize int  `mapstructure:"max-body-size"`
+	// RedactedBodyFields are the JSON fields, at any depth of the bodies,
+	// whose values are not logged
+	RedactedBodyFields []string `mapstructure:"redacted-body-fields"`
+}
+
+func (cfg *AccessLogConfig) Validate() error {
+	if strings.ContainsAny(cfg.ClientIdHeader, " :\t\r\n") {
+		return errors.New("invalid access log client id header")
+	}
+	if cfg.LogBodies && cfg.MaxBodySize <= 0 {
+		return errors.New("access log max body size must be positive when logging the bodies")
+	}
+	for _, param := range cfg.RedactedQueryParams {
+		if param == "" {
+			return errors.New("access log redacted query params cannot be empty")
+		}
+	}
+	for _, field := range cfg.RedactedBodyFields {
+		if field == "" {
+			return errors.New("access log redacted body fields cannot be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+)
+
+// minAdminAuthTokenLength is the minimum length of the admin auth token, to
+// rule out guessable tokens
+const minAdminAuthTokenLength = 32
+
+// The admin roles. Every admin token can inspect the service, the mutating
+// admin routes require a role on top.
+const (
+	// AdminRoleReadOnly only grants the inspection of the service
+	AdminRoleReadOnly = "read-only"
+	// AdminRoleQueueAdmin grants the replay of the queue messages along with
+	// the runtime settings of the instance, e.g. the maintenance mode
+	AdminRoleQueueAdmin = "queue-admin"
+	// AdminRoleDataAdmin grants the mutation of the stored data, e.g. the
+	// erasure of a staker or a run of the scheduled jobs
+	AdminRoleDataAdmin = "data-admin"
+)
+
+// AdminRoles lists the admin roles, the admin auth token is granted all of them
+var AdminRoles = []string{AdminRoleReadOnly, AdminRoleQueueAdmin, AdminRoleDataAdmin}
+
+// AdminConfig defines the admin listener serving the operational endpoints,
+// such as the profiling ones, which must not be exposed publicly.
+type AdminConfig struct {
+	// Host should be bound to an internal interface
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+	// AuthToken is the bearer token granted all the admin roles
+	AuthToken string `mapstructure:"auth-token"`
+	// Tokens are the additional bearer tokens by name, restricted to their
+	// roles
+	Tokens map[string]*AdminTokenConfig `mapstructure:"tokens"`
+	// WriteTimeout must be longer than the requested profiling durations
+	WriteTimeout time.Duration `mapstructure:"write-timeout"`
+	// TLS is optional, the admin listener serves plain HTTP if not set. The
+	// client certificates should be verified if the listener is reachable
+	// from outside the internal network.
+	TLS *TLSConfig `mapstructure:"tls"`
+}
+
+func (cfg *AdminConfig) Validate() error {
+	if net.ParseIP(cfg.Host) == nil {
+		return fmt.Errorf("invalid admin host: %v", cfg.Host)
+	}
+	if cfg.Port < 1024 || cfg.Port > 65535 {
+		return errors.New("admin port must be between 1024 and 65535 (inclusive)")
+	}
+	if len(cfg.AuthToken) < minAdminAuthTokenLength {
+		return fmt.Errorf("admin auth token must be at least %d characters long", minAdminAuthTokenLength)
+	}
+	if cfg.WriteTimeout <= 0 {
+		return errors.New("admin write timeout must be positive")
+	}
+	if cfg.TLS != nil {
+		if err := cfg.TLS.Validate(); err != nil {
+			return fmt.Errorf("invalid admin tls: %w", err)
+		}
+	}
+	tokens := map[string]string{cfg.AuthToken: "auth-token"}
+	for name, token := range cfg.Tokens {
+		if token == nil || len(token.Token) < minAdminAuthTokenLength {
+			return fmt.Errorf("admin token %s must be at least %d characters long", name, minAdminAuthTokenLength)
+		}
+		if other, ok := tokens[token.Token]; ok {
+			return fmt.Errorf("admin tokens %s and %s must differ", other, name)
+		}
+		tokens[token.Token] = name
+		if len(token.Roles) == 0 {
+			return fmt.Errorf("admin token %s must have at least one role", name)
+		}
+		for _, role := range token.Roles {
+			if !slices.Contains(AdminRoles, role) {
+				return fmt.Errorf("invalid role %q of admin token %s, expected one of %v", role, name, AdminRoles)
+			}
+		}
+	}
+	return nil
+}
+
+// AdminTokenConfig is a bearer token of the admin api restricted to roles
+type AdminTokenConfig struct {
+	Token string   `mapstructure:"token"`
+	Roles []string `mapstructure:"roles"`
+}
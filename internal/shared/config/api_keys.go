@@ -0,0 +1,69 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const minApiKeyLength = 32
+
+var apiKeyNameRegex = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// ApiKeysConfig defines the api keys identifying the partners, whose usage
+// is tracked per UTC day and limited by daily quotas. The requests without
+// api key are neither tracked nor limited.
+type ApiKeysConfig struct {
+	// Keys holds the api keys keyed by their lowercase name, the name is the
+	// one the usage is tracked under
+	Keys map[string]*ApiKeyConfig `mapstructure:"keys"`
+	// FlushInterval is the interval the usage of this instance is written into
+	// the db at. The quotas are enforced on the usage of all the instances as
+	// of their last flush, hence they can be exceeded within the interval.
+	FlushInterval time.Duration `mapstructure:"flush-interval"`
+	// Retention is the time the daily usage is kept for, forever if 0
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+type ApiKeyConfig struct {
+	// Key is the value of the X-Api-Key header
+	Key string `mapstructure:"key"`
+	// DailyRequestQuota is the max number of requests per day, unlimited if 0
+	DailyRequestQuota int64 `mapstructure:"daily-request-quota"`
+	// DailyBytesQuota is the max number of response bytes per day, unlimited
+	// if 0
+	DailyBytesQuota int64 `mapstructure:"daily-bytes-quota"`
+}
+
+func (cfg *ApiKeysConfig) Validate() error {
+	if len(cfg.Keys) == 0 {
+		return errors.New("at least one api key must be configured")
+	}
+	keys := make(map[string]string, len(cfg.Keys))
+	for name, apiKey := range cfg.Keys {
+		if !apiKeyNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid api key name %q", name)
+		}
+		if apiKey == nil || len(apiKey.Key) < minApiKeyLength {
+			return fmt.Errorf("api key %s must be at least %d characters long", name, minApiKeyLength)
+		}
+		if other, ok := keys[apiKey.Key]; ok {
+			return fmt.Errorf("api keys %s and %s must differ", other, name)
+		}
+		keys[apiKey.Key] = name
+		if apiKey.DailyRequestQuota < 0 || apiKey.DailyBytesQuota < 0 {
+			return fmt.Errorf("quotas of api key %s cannot be negative", name)
+		}
+	}
+	if cfg.FlushInterval <= 0 {
+		return errors.New("api keys flush interval must be positive")
+	}
+	if cfg.Retention < 0 {
+		return errors.New("api keys retention cannot be negative")
+	}
+	if cfg.Retention > 0 && cfg.Retention < 24*time.Hour {
+		return errors.New("api keys retention must be at least a day")
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CacheControlConfig defines the Cache-Control header of the responses, so
+// that the CDN and edge layers cache the slowly changing data, such as the
+// stats and the finality providers.
+type CacheControlConfig struct {
+	// Routes maps the route paths to the max age of their successful
+	// responses, the other responses are marked as no-store
+	Routes map[string]time.Duration `mapstructure:"routes"`
+}
+
+func (cfg *CacheControlConfig) Validate() error {
+	for path, maxAge := range cfg.Routes {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("invalid cache control route %s, must start with /", path)
+		}
+		if maxAge < time.Second {
+			return fmt.Errorf("cache control max age of route %s must be at least 1s", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// ClientIpConfig defines how the client IP is resolved when the API is served
+// behind proxies or load balancers.
+type ClientIpConfig struct {
+	// TrustedProxies are the IPs or CIDRs of the proxies whose forwarding
+	// headers, X-Forwarded-For and X-Real-IP, are trusted
+	TrustedProxies []string `mapstructure:"trusted-proxies"`
+
+	TrustedProxyPrefixes []netip.Prefix
+}
+
+func (cfg *ClientIpConfig) Validate() error {
+	prefixes, err := parsePrefixes(cfg.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("invalid trusted proxy: %w", err)
+	}
+	cfg.TrustedProxyPrefixes = prefixes
+	return nil
+}
+
+// IsTrustedProxy returns whether the address belongs to a trusted proxy
+func (cfg *ClientIpConfig) IsTrustedProxy(addr netip.Addr) bool {
+	return prefixesContain(cfg.TrustedProxyPrefixes, addr)
+}
+
+// parsePrefixes parses the CIDRs, a single IP is parsed as the CIDR of that
+// IP only
+func parsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(value)
+			if addrErr != nil {
+				return nil, fmt.Errorf("%v is neither an IP nor a CIDR", value)
+			}
+			addr = addr.Unmap()
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+func prefixesContain(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
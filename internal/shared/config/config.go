@@ -1,9 +1,9 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"strings"
 
 	queue "github.com/babylonlabs-io/staking-queue-client/config"
 	"github.com/spf13/viper"
@@ -16,6 +16,99 @@ type Config struct {
 	Queue     *queue.QueueConfig `mapstructure:"queue"`
 	Metrics   *MetricsConfig     `mapstructure:"metrics"`
 	Assets    *AssetsConfig      `mapstructure:"assets"`
+	// QueueMonitor is optional, the queue lag is not monitored if not set
+	QueueMonitor *QueueMonitorConfig `mapstructure:"queue-monitor"`
+	// QueueReconnect is optional, a broken queue connection is not recovered
+	// and fails the queue health check if not set
+	QueueReconnect *QueueReconnectConfig `mapstructure:"queue-reconnect"`
+	// QueueWorkers is optional, each queue is processed by a single worker
+	// if not set
+	QueueWorkers *QueueWorkersConfig `mapstructure:"queue-workers"`
+	// QueueRedelivery is optional, the failed messages are requeued with the
+	// fixed queue requeue delay if not set
+	QueueRedelivery *QueueRedeliveryConfig `mapstructure:"queue-redelivery"`
+	// QueueBackpressure is optional, the queue workers are not throttled by
+	// the health of the staking db if not set
+	QueueBackpressure *QueueBackpressureConfig `mapstructure:"queue-backpressure"`
+	// StatsRefresher is optional, the overall stats are computed from the
+	// shards on each request if not set
+	StatsRefresher *StatsRefresherConfig `mapstructure:"stats-refresher"`
+	// EventArchive is optional, the consumed queue messages are not archived
+	// if not set
+	EventArchive *EventArchiveConfig `mapstructure:"event-archive"`
+	// Admin is optional, the admin listener is not started if not set
+	Admin *AdminConfig `mapstructure:"admin"`
+	// RouteLimits is optional, the server timeouts and max content length
+	// apply to all the routes if not set
+	RouteLimits *RouteLimitsConfig `mapstructure:"route-limits"`
+	// ResponseCache is optional, the stats and finality providers responses
+	// are not cached if not set
+	ResponseCache *ResponseCacheConfig `mapstructure:"response-cache"`
+	// CacheControl is optional, the responses have no Cache-Control header
+	// if not set
+	CacheControl *CacheControlConfig `mapstructure:"cache-control"`
+	// Deprecations is optional, no route is marked as deprecated if not set
+	Deprecations *DeprecationsConfig `mapstructure:"deprecations"`
+	// AccessLog is optional, the requests are not access logged if not set
+	AccessLog *AccessLogConfig `mapstructure:"access-log"`
+	// ErrorReporting is optional, the errors are only logged if not set
+	ErrorReporting *ErrorReportingConfig `mapstructure:"error-reporting"`
+	// ClientIp is optional, the client IP is the remote address of the
+	// connection and the forwarding headers are ignored if not set
+	ClientIp *ClientIpConfig `mapstructure:"client-ip"`
+	// IpFilter is optional, the clients are not filtered by IP if not set
+	IpFilter *IpFilterConfig `mapstructure:"ip-filter"`
+	// GeoGating is optional, the write endpoints are served to all the
+	// jurisdictions if not set
+	GeoGating *GeoGatingConfig `mapstructure:"geo-gating"`
+	// Secrets is optional, it's only needed to configure the access to the
+	// secret managers referenced by the config values
+	Secrets *SecretsConfig `mapstructure:"secrets"`
+	// CredentialRotation is optional, the credentials are only read at
+	// startup if not set
+	CredentialRotation *CredentialRotationConfig `mapstructure:"credential-rotation"`
+	// Shadow is optional, the messages are neither mirrored nor consumed from
+	// the shadow queues if not set
+	Shadow *ShadowConfig `mapstructure:"shadow"`
+	// Idempotency is optional, the Idempotency-Key header is ignored if not
+	// set
+	Idempotency *IdempotencyConfig `mapstructure:"idempotency"`
+	// Partners is optional, the delegations can not be attributed to
+	// partners if not set
+	Partners *PartnersConfig `mapstructure:"partners"`
+	// ApiKeys is optional, the X-Api-Key header is ignored if not set
+	ApiKeys *ApiKeysConfig `mapstructure:"api-keys"`
+	// Exports is optional, the export jobs api is not served if not set
+	Exports *ExportsConfig `mapstructure:"exports"`
+	// DelegationArchive is optional, the delegations are never moved out of
+	// the delegations collection if not set
+	DelegationArchive *DelegationArchiveConfig `mapstructure:"delegation-archive"`
+	// DelegationMigration is optional, the delegations stored at a previous
+	// schema version are migrated on every read if not set
+	DelegationMigration *DelegationMigrationConfig `mapstructure:"delegation-migration"`
+	// ExpiryScanner is optional, the delegations are only transitioned to
+	// unbonded by their expiry events if not set
+	ExpiryScanner *ExpiryScannerConfig `mapstructure:"expiry-scanner"`
+	// TotalCount is optional, the include_total query is ignored if not set
+	TotalCount *TotalCountConfig `mapstructure:"total-count"`
+	// Logging is optional, the logs are emitted as JSON at the server log
+	// level if not set
+	Logging *LoggingConfig `mapstructure:"logging"`
+	// LongPoll is optional, the wait_for_state query of the delegation
+	// endpoint is rejected if not set
+	LongPoll *LongPollConfig `mapstructure:"long-poll"`
+	// LeaderElection is optional, each replica runs all the scheduled jobs if
+	// not set
+	LeaderElection *LeaderElectionConfig `mapstructure:"leader-election"`
+	// Scheduler is optional, the scheduled jobs run every interval of their
+	// own config if not set
+	Scheduler *SchedulerConfig `mapstructure:"scheduler"`
+	// Slo is optional, the route latencies are not tracked against budgets
+	// if not set
+	Slo *SloConfig `mapstructure:"slo"`
+	// StakerPolicy is optional, the delegations of the stakers are only
+	// bounded by the global params if not set
+	StakerPolicy *StakerPolicyConfig `mapstructure:"staker-policy"`
 }
 
 func (cfg *Config) Validate() error {
@@ -46,41 +139,266 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.QueueMonitor != nil {
+		if err := cfg.QueueMonitor.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.QueueReconnect != nil {
+		if err := cfg.QueueReconnect.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.QueueWorkers != nil {
+		if err := cfg.QueueWorkers.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.QueueRedelivery != nil {
+		if err := cfg.QueueRedelivery.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.QueueBackpressure != nil {
+		if err := cfg.QueueBackpressure.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.StatsRefresher != nil {
+		if err := cfg.StatsRefresher.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.EventArchive != nil {
+		if err := cfg.EventArchive.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Admin != nil {
+		if err := cfg.Admin.Validate(); err != nil {
+			return err
+		}
+		// The admin listener is configured independently of the public one
+		if cfg.Admin.Port == cfg.Server.Port || cfg.Admin.Port == cfg.Metrics.Port {
+			return fmt.Errorf("admin port must differ from the server and metrics ports")
+		}
+	}
+
+	if cfg.RouteLimits != nil {
+		if err := cfg.RouteLimits.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ResponseCache != nil {
+		if err := cfg.ResponseCache.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.CacheControl != nil {
+		if err := cfg.CacheControl.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Deprecations != nil {
+		if err := cfg.Deprecations.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.AccessLog != nil {
+		if err := cfg.AccessLog.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ErrorReporting != nil {
+		if err := cfg.ErrorReporting.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ClientIp != nil {
+		if err := cfg.ClientIp.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.IpFilter != nil {
+		if err := cfg.IpFilter.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.GeoGating != nil {
+		if err := cfg.GeoGating.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Secrets != nil {
+		if err := cfg.Secrets.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.CredentialRotation != nil {
+		if err := cfg.CredentialRotation.Validate(); err != nil {
+			return err
+		}
+		// The queue connections stay authenticated, they are redialed by the
+		// reconnecting clients
+		if cfg.QueueReconnect == nil {
+			return fmt.Errorf("credential rotation requires the queue reconnection")
+		}
+	}
+
+	if cfg.Shadow != nil {
+		if err := cfg.Shadow.Validate(cfg.StakingDb); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Idempotency != nil {
+		if err := cfg.Idempotency.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Partners != nil {
+		if err := cfg.Partners.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ApiKeys != nil {
+		if err := cfg.ApiKeys.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Exports != nil {
+		if err := cfg.Exports.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.DelegationArchive != nil {
+		if err := cfg.DelegationArchive.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.DelegationMigration != nil {
+		if err := cfg.DelegationMigration.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ExpiryScanner != nil {
+		if err := cfg.ExpiryScanner.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.TotalCount != nil {
+		if err := cfg.TotalCount.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Logging != nil {
+		if err := cfg.Logging.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.LongPoll != nil {
+		if err := cfg.LongPoll.Validate(cfg.Server, cfg.RouteLimits); err != nil {
+			return err
+		}
+	}
+
+	if cfg.LeaderElection != nil {
+		if err := cfg.LeaderElection.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Scheduler != nil {
+		if err := cfg.Scheduler.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Slo != nil {
+		if err := cfg.Slo.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.StakerPolicy != nil {
+		if err := cfg.StakerPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 // New returns a fully parsed Config object from a given file directory
 func New(cfgFile string) (*Config, error) {
-	_, err := os.Stat(cfgFile)
+	// The env overrides of a previous load must not leak into this one
+	viper.Reset()
+	cfg, overridden, resolvedSecrets, err := load(viper.GetViper(), cfgFile)
 	if err != nil {
 		return nil, err
 	}
+	logEffectiveConfig(overridden, resolvedSecrets)
+
+	return cfg, nil
+}
 
-	viper.SetConfigFile(cfgFile)
+// Reload parses the config file again, along with its env overrides and
+// secrets, without affecting the config loaded by New. It's used to pick up
+// the rotated credentials.
+func Reload(cfgFile string) (*Config, error) {
+	cfg, _, _, err := load(viper.New(), cfgFile)
+	return cfg, err
+}
 
-	viper.AutomaticEnv()
-	/*
-		Below code will replace nested fields in yml into `_` and any `-` into `__` when you try to override this config via env variable
-		To give an example:
-		1. `some.config.a` can be overriden by `SOME_CONFIG_A`
-		2. `some.config-a` can be overriden by `SOME_CONFIG__A`
-		This is to avoid using `-` in the environment variable as it's not supported in all os terminal/bash
-		Note: vipner package use `.` as delimitter by default. Read more here: https://pkg.go.dev/github.com/spf13/viper#readme-accessing-nested-keys
-	*/
-	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "__"))
+func load(v *viper.Viper, cfgFile string) (*Config, []string, []string, error) {
+	_, err := os.Stat(cfgFile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	v.SetConfigFile(cfgFile)
 
-	err = viper.ReadInConfig()
+	err = v.ReadInConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	overridden := applyEnvOverrides(v)
+	resolvedSecrets, err := resolveSecrets(context.Background(), v)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
 	var cfg Config
-	if err = viper.Unmarshal(&cfg); err != nil {
-		return nil, err
+	if err = v.Unmarshal(&cfg); err != nil {
+		return nil, nil, nil, err
 	}
 	if err = cfg.Validate(); err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-
-	return &cfg, nil
+	return &cfg, overridden, resolvedSecrets, nil
 }
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// CredentialRotationConfig defines the rotation of the Mongo and RabbitMQ
+// credentials at runtime. The config file, its env overrides and its secrets
+// are reloaded at the check interval, and the connections are re-established
+// with the credentials which have changed.
+type CredentialRotationConfig struct {
+	CheckInterval time.Duration `mapstructure:"check-interval"`
+	// DrainTimeout is the time left to the in-flight db operations to
+	// complete on the connections of the previous credentials
+	DrainTimeout time.Duration `mapstructure:"drain-timeout"`
+}
+
+func (cfg *CredentialRotationConfig) Validate() error {
+	if cfg.CheckInterval <= 0 {
+		return errors.New("credential rotation check interval must be positive")
+	}
+	if cfg.DrainTimeout <= 0 {
+		return errors.New("credential rotation drain timeout must be positive")
+	}
+	return nil
+}
@@ -3,13 +3,26 @@ package config
 import (
 	"fmt"
 	"net/url"
+	"slices"
 	"strconv"
+	"time"
 )
 
 const (
 	maxLogicalShardCount = 100
 )
 
+var (
+	validReadPreferences = []string{
+		"primary", "primaryPreferred", "secondary", "secondaryPreferred", "nearest",
+	}
+	validReadConcerns = []string{"local", "available", "majority"}
+	// Transactions do not support the available read concern
+	validTransactionReadConcerns = []string{"local", "majority", "snapshot"}
+)
+
+const WriteConcernMajority = "majority"
+
 type DbConfig struct {
 	Username           string `mapstructure:"username"`
 	Password           string `mapstructure:"password"`
@@ -18,6 +31,84 @@ type DbConfig struct {
 	MaxPaginationLimit int64  `mapstructure:"max-pagination-limit"`
 	DbBatchSizeLimit   int64  `mapstructure:"db-batch-size-limit"`
 	LogicalShardCount  *int64 `mapstructure:"logical-shard-count"`
+	// ReadPreference and ReadConcern apply to the reads of the read-only (GET)
+	// API requests only, writes and transactions always use the primary.
+	// Defaults to the settings of the connection string if not set.
+	ReadPreference string `mapstructure:"read-preference"`
+	ReadConcern    string `mapstructure:"read-concern"`
+	// WriteConcern is the acknowledgment requested for all the writes, either
+	// "majority" or the number of members. WriteConcernJournal requests the
+	// writes to be acknowledged once written to the on-disk journal.
+	// Defaults to the settings of the connection string if not set.
+	WriteConcern        string        `mapstructure:"write-concern"`
+	WriteConcernJournal *bool         `mapstructure:"write-concern-journal"`
+	WriteConcernTimeout time.Duration `mapstructure:"write-concern-timeout"`
+	// TransactionReadConcern is the read concern of the transactions updating
+	// the stats and the delegations. CausalConsistency applies to the sessions
+	// the transactions run in, the driver default (enabled) is used if not set.
+	TransactionReadConcern string `mapstructure:"transaction-read-concern"`
+	CausalConsistency      *bool  `mapstructure:"causal-consistency"`
+	// Connection pool and timeout tuning, the driver defaults are used if not set
+	MaxPoolSize            uint64        `mapstructure:"max-pool-size"`
+	MinPoolSize            uint64        `mapstructure:"min-pool-size"`
+	MaxConnIdleTime        time.Duration `mapstructure:"max-conn-idle-time"`
+	ServerSelectionTimeout time.Duration `mapstructure:"server-selection-timeout"`
+	SocketTimeout          time.Duration `mapstructure:"socket-timeout"`
+	// CircuitBreaker is optional, the db operations are not guarded if not set
+	CircuitBreaker *DbCircuitBreakerConfig `mapstructure:"circuit-breaker"`
+	// SlowQuery is optional, the db commands are not monitored if not set
+	SlowQuery *DbSlowQueryConfig `mapstructure:"slow-query"`
+	// FieldEncryption is optional, the sensitive fields are stored in
+	// plaintext if not set
+	FieldEncryption *FieldEncryptionConfig `mapstructure:"field-encryption"`
+}
+
+type DbSlowQueryConfig struct {
+	// Threshold is the duration above which a db command is logged as slow
+	Threshold time.Duration `mapstructure:"threshold"`
+	// ExplainSampleRate is the fraction, between 0 and 1, of the slow queries
+	// whose query plan is captured with the explain command
+	ExplainSampleRate float64 `mapstructure:"explain-sample-rate"`
+}
+
+func (cfg *DbSlowQueryConfig) Validate() error {
+	if cfg.Threshold <= 0 {
+		return fmt.Errorf("slow query threshold must be positive")
+	}
+	if cfg.ExplainSampleRate < 0 || cfg.ExplainSampleRate > 1 {
+		return fmt.Errorf("slow query explain sample rate must be between 0 and 1")
+	}
+	return nil
+}
+
+type DbCircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive transient failures
+	// after which the circuit is opened
+	FailureThreshold int `mapstructure:"failure-threshold"`
+	// OpenDuration is how long the circuit stays open before a trial request
+	// is let through, it's also used as the Retry-After of the 503 responses
+	OpenDuration time.Duration `mapstructure:"open-duration"`
+	// MaxRetries is the max number of retries of read requests failing with
+	// transient errors
+	MaxRetries int `mapstructure:"max-retries"`
+	// RetryBackoff is the delay before the first retry, doubled for each retry
+	RetryBackoff time.Duration `mapstructure:"retry-backoff"`
+}
+
+func (cfg *DbCircuitBreakerConfig) Validate() error {
+	if cfg.FailureThreshold <= 0 {
+		return fmt.Errorf("circuit breaker failure threshold must be positive")
+	}
+	if cfg.OpenDuration <= 0 {
+		return fmt.Errorf("circuit breaker open duration must be positive")
+	}
+	if cfg.MaxRetries < 0 {
+		return fmt.Errorf("circuit breaker max retries cannot be negative")
+	}
+	if cfg.RetryBackoff < 0 {
+		return fmt.Errorf("circuit breaker retry backoff cannot be negative")
+	}
+	return nil
 }
 
 func (cfg *DbConfig) Validate() error {
@@ -84,5 +175,57 @@ func (cfg *DbConfig) Validate() error {
 		}
 	}
 
+	if cfg.ReadPreference != "" && !slices.Contains(validReadPreferences, cfg.ReadPreference) {
+		return fmt.Errorf("invalid read preference %s, must be one of %v", cfg.ReadPreference, validReadPreferences)
+	}
+
+	if cfg.ReadConcern != "" && !slices.Contains(validReadConcerns, cfg.ReadConcern) {
+		return fmt.Errorf("invalid read concern %s, must be one of %v", cfg.ReadConcern, validReadConcerns)
+	}
+
+	if cfg.WriteConcern != "" && cfg.WriteConcern != WriteConcernMajority {
+		w, err := strconv.Atoi(cfg.WriteConcern)
+		if err != nil || w < 1 {
+			return fmt.Errorf("invalid write concern %s, must be %s or a positive number", cfg.WriteConcern, WriteConcernMajority)
+		}
+	}
+
+	if cfg.WriteConcernTimeout < 0 {
+		return fmt.Errorf("write concern timeout cannot be negative")
+	}
+
+	if cfg.TransactionReadConcern != "" && !slices.Contains(validTransactionReadConcerns, cfg.TransactionReadConcern) {
+		return fmt.Errorf(
+			"invalid transaction read concern %s, must be one of %v",
+			cfg.TransactionReadConcern, validTransactionReadConcerns,
+		)
+	}
+
+	if cfg.MaxPoolSize > 0 && cfg.MinPoolSize > cfg.MaxPoolSize {
+		return fmt.Errorf("min pool size cannot be greater than max pool size")
+	}
+
+	if cfg.MaxConnIdleTime < 0 || cfg.ServerSelectionTimeout < 0 || cfg.SocketTimeout < 0 {
+		return fmt.Errorf("db connection timeouts cannot be negative")
+	}
+
+	if cfg.CircuitBreaker != nil {
+		if err := cfg.CircuitBreaker.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.SlowQuery != nil {
+		if err := cfg.SlowQuery.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.FieldEncryption != nil {
+		if err := cfg.FieldEncryption.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// DelegationArchiveConfig defines how the delegations in a terminal state are
+// moved from the delegations collection into the archive collection
+type DelegationArchiveConfig struct {
+	// Interval between two runs of the archiver
+	Interval time.Duration `mapstructure:"interval"`
+	// MinAge is how long a delegation stays in the delegations collection
+	// after reaching a terminal state
+	MinAge time.Duration `mapstructure:"min-age"`
+	// BatchSize is the number of delegations moved at once
+	BatchSize int64 `mapstructure:"batch-size"`
+}
+
+func (cfg *DelegationArchiveConfig) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("delegation archive interval must be positive")
+	}
+	if cfg.MinAge < 24*time.Hour {
+		return errors.New("delegation archive min age must be at least 24h")
+	}
+	if cfg.BatchSize <= 0 {
+		return errors.New("delegation archive batch size must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// DelegationMigrationConfig defines how the delegations stored at a previous
+// schema version are written back at the current one
+type DelegationMigrationConfig struct {
+	// Interval between two runs of the migrator
+	Interval time.Duration `mapstructure:"interval"`
+	// BatchSize is the number of delegations migrated at once
+	BatchSize int64 `mapstructure:"batch-size"`
+}
+
+func (cfg *DelegationMigrationConfig) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("delegation migration interval must be positive")
+	}
+	if cfg.BatchSize <= 0 {
+		return errors.New("delegation migration batch size must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DeprecationDateLayout is the layout of the deprecation and sunset dates
+const DeprecationDateLayout = "2006-01-02"
+
+// DeprecationsConfig defines the deprecated routes, e.g. the v1 routes
+// replaced during phase-2. Their responses carry the Deprecation and Sunset
+// headers so that the clients are told to migrate.
+type DeprecationsConfig struct {
+	// Routes maps the route paths to their deprecation
+	Routes map[string]*RouteDeprecation `mapstructure:"routes"`
+	// EnforceSunset rejects the requests of the routes past their sunset
+	// with a 410, they are still served otherwise
+	EnforceSunset bool `mapstructure:"enforce-sunset"`
+}
+
+type RouteDeprecation struct {
+	// DeprecatedAt is the date the route has been deprecated at, YYYY-MM-DD
+	DeprecatedAt string `mapstructure:"deprecated-at"`
+	// Sunset is optional, the date the route stops being served, YYYY-MM-DD
+	Sunset string `mapstructure:"sunset"`
+	// Replacement is optional, the path of the route to migrate to
+	Replacement string `mapstructure:"replacement"`
+	// Link is optional, the url of the migration guide
+	Link string `mapstructure:"link"`
+
+	DeprecatedAtTime time.Time
+	// SunsetTime is zero if the route has no sunset
+	SunsetTime time.Time
+}
+
+func (cfg *DeprecationsConfig) Validate() error {
+	for path, deprecation := range cfg.Routes {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("invalid deprecated route %s, must start with /", path)
+		}
+		if deprecation == nil {
+			return fmt.Errorf("deprecated route %s has no deprecation date", path)
+		}
+		if err := deprecation.validate(); err != nil {
+			return fmt.Errorf("invalid deprecation of route %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (d *RouteDeprecation) validate() error {
+	deprecatedAt, err := time.Parse(DeprecationDateLayout, d.DeprecatedAt)
+	if err != nil {
+		return fmt.Errorf("invalid deprecated-at date %q, YYYY-MM-DD is expected", d.DeprecatedAt)
+	}
+	d.DeprecatedAtTime = deprecatedAt
+	if d.Sunset != "" {
+		sunset, err := time.Parse(DeprecationDateLayout, d.Sunset)
+		if err != nil {
+			return fmt.Errorf("invalid sunset date %q, YYYY-MM-DD is expected", d.Sunset)
+		}
+		if !sunset.After(deprecatedAt) {
+			return fmt.Errorf("the sunset must be after the deprecation")
+		}
+		d.SunsetTime = sunset
+	}
+	if d.Replacement != "" && !strings.HasPrefix(d.Replacement, "/") {
+		return fmt.Errorf("invalid replacement %s, must start with /", d.Replacement)
+	}
+	if d.Link != "" {
+		link, err := url.Parse(d.Link)
+		if err != nil || link.Scheme == "" || link.Host == "" {
+			return fmt.Errorf("invalid link %s, an absolute url is expected", d.Link)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is the prefix of the env vars overriding the config values
+const EnvPrefix = "STAKING_API_"
+
+const maskedValue = "[REDACTED]"
+
+// envKeyReplacer turns the nested keys into env var names, `.` is replaced
+// by `_` and `-` by `__` as `-` is not supported by all the shells, e.g.
+// `staking-db.address` is overridden by `STAKING_API_STAKING__DB_ADDRESS`
+var envKeyReplacer = strings.NewReplacer(".", "_", "-", "__")
+
+// secretKeyParts are the parts of the config keys whose values are masked
+// when logging the config
+var secretKeyParts = []string{"password", "token", "secret", "dsn"}
+
+// isSecretKey tells whether the value of the config key is masked when
+// logging the config. Besides the secretKeyParts, the keys named `key` or
+// ending with `-key` hold key material, e.g. `api-keys.keys.*.key`, unlike
+// the ones only referring to a key, e.g. `key-file` or `active-key-id`.
+func isSecretKey(key string) bool {
+	lowerKey := strings.ToLower(key)
+	for _, part := range secretKeyParts {
+		if strings.Contains(lowerKey, part) {
+			return true
+		}
+	}
+	return lowerKey == "key" || strings.HasSuffix(lowerKey, "-key")
+}
+
+// applyEnvOverrides overrides the config values set in env vars and returns
+// the overridden keys. Every value of the config tree can be overridden with
+// an EnvPrefix env var, even if absent from the config file, except the
+// entries of the maps. The unprefixed env vars of the existing deployments
+// are still supported for the values of the config file, the prefixed one
+// takes precedence and both take precedence over the config file.
+func applyEnvOverrides(v *viper.Viper) []string {
+	var overridden []string
+	for _, key := range configKeys(reflect.TypeOf(Config{}), "") {
+		name := strings.ToUpper(envKeyReplacer.Replace(key))
+		value, ok := os.LookupEnv(EnvPrefix + name)
+		if !ok && v.InConfig(key) {
+			value, ok = os.LookupEnv(name)
+		}
+		if !ok {
+			continue
+		}
+		// The lists are comma separated, they are split when decoding
+		v.Set(key, value)
+		overridden = append(overridden, key)
+	}
+	return overridden
+}
+
+// configKeys returns the keys of the scalar and list values of the config
+// type, following the mapstructure tags
+func configKeys(t reflect.Type, prefix string) []string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		switch fieldType.Kind() {
+		case reflect.Struct:
+			keys = append(keys, configKeys(fieldType, key)...)
+		case reflect.Map:
+			continue
+		case reflect.Slice:
+			if fieldType.Elem().Kind() == reflect.Struct {
+				continue
+			}
+			keys = append(keys, key)
+		default:
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// logEffectiveConfig logs the config values in force, the secrets and the
+// values resolved from the secret managers being masked
+func logEffectiveConfig(overridden, resolvedSecrets []string) {
+	settings := MaskSecrets(viper.AllSettings())
+	for _, key := range resolvedSecrets {
+		maskKey(settings, key)
+	}
+	sort.Strings(overridden)
+	log.Info().Strs("envOverrides", overridden).Strs("resolvedSecrets", resolvedSecrets).
+		Interface("config", settings).
+		Msg("effective config")
+}
+
+// maskKey masks the value of the nested key of the settings
+func maskKey(settings map[string]interface{}, key string) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		nested, ok := settings[part].(map[string]interface{})
+		if !ok {
+			return
+		}
+		settings = nested
+	}
+	if _, ok := settings[parts[len(parts)-1]]; ok {
+		settings[parts[len(parts)-1]] = maskedValue
+	}
+}
+
+// MaskSecrets returns a copy of the settings whose secret values, and the
+// passwords of the URLs, are masked
+func MaskSecrets(settings map[string]interface{}) map[string]interface{} {
+	masked := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		masked[key] = maskValue(key, value)
+	}
+	return masked
+}
+
+// maskKeySet masks the keys of the key set, the nested settings such as the
+// ones of each api key are masked as any other settings
+func maskKeySet(keys map[string]interface{}) map[string]interface{} {
+	masked := make(map[string]interface{}, len(keys))
+	for id, value := range keys {
+		if nested, ok := value.(map[string]interface{}); ok {
+			masked[id] = MaskSecrets(nested)
+			continue
+		}
+		masked[id] = maskedValue
+	}
+	return masked
+}
+
+func maskValue(key string, value interface{}) interface{} {
+	if isSecretKey(key) {
+		return maskedValue
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// The key sets are keyed by key id, e.g. `staking-db.field-encryption.keys`
+		if strings.ToLower(key) == "keys" {
+			return maskKeySet(v)
+		}
+		return MaskSecrets(v)
+	case string:
+		if u, err := url.Parse(v); err == nil && u.User != nil {
+			return u.Redacted()
+		}
+	}
+	return value
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// ErrorReportingConfig defines the reporting of the errors to Sentry. The 5xx
+// responses of the handlers and the unprocessable queue messages are reported.
+type ErrorReportingConfig struct {
+	Dsn         string `mapstructure:"dsn"`
+	Environment string `mapstructure:"environment"`
+	// DedupWindow is the period during which the errors of a same fingerprint
+	// are reported only once
+	DedupWindow time.Duration `mapstructure:"dedup-window"`
+	// RedactedQueryParams are the query params whose values are not reported
+	RedactedQueryParams []string `mapstructure:"redacted-query-params"`
+}
+
+func (cfg *ErrorReportingConfig) Validate() error {
+	if cfg.Dsn == "" {
+		return errors.New("error reporting dsn is required")
+	}
+	if _, err := sentry.NewDsn(cfg.Dsn); err != nil {
+		return errors.New("invalid error reporting dsn")
+	}
+	if cfg.DedupWindow < 0 {
+		return errors.New("error reporting dedup window cannot be negative")
+	}
+	for _, param := range cfg.RedactedQueryParams {
+		if param == "" {
+			return errors.New("error reporting redacted query params cannot be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventArchiveConfig enables the archival of every consumed queue message
+// into the events collection.
+type EventArchiveConfig struct {
+	// Retention is how long the archived events are kept before being
+	// removed by the TTL index, 0 keeps them forever
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+func (cfg *EventArchiveConfig) Validate() error {
+	if cfg.Retention < 0 {
+		return fmt.Errorf("event archive retention must not be negative")
+	}
+	if cfg.Retention > 0 && cfg.Retention < time.Second {
+		return fmt.Errorf("event archive retention must be at least 1s")
+	}
+	return nil
+}
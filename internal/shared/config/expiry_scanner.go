@@ -0,0 +1,29 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// ExpiryScannerConfig defines how the delegations whose timelock has expired
+// are looked up from their stored heights, to be transitioned to unbonded
+// even if their expiry event is lost
+type ExpiryScannerConfig struct {
+	// Interval between two scans
+	Interval time.Duration `mapstructure:"interval"`
+	// GraceBlocks is the number of blocks past the expiry height left to the
+	// expiry event before the scanner transitions the delegation itself
+	GraceBlocks uint64 `mapstructure:"grace-blocks"`
+	// BatchSize is the max number of delegations transitioned per scan
+	BatchSize int64 `mapstructure:"batch-size"`
+}
+
+func (cfg *ExpiryScannerConfig) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("expiry scanner interval must be positive")
+	}
+	if cfg.BatchSize <= 0 {
+		return errors.New("expiry scanner batch size must be positive")
+	}
+	return nil
+}
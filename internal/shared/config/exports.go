@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// maxExportUrlTtl is the longest validity of the S3 signed urls
+const maxExportUrlTtl = 7 * 24 * time.Hour
+
+// ExportsConfig defines the asynchronous export jobs, the exported files are
+// written into an S3 compatible bucket and downloaded through signed urls
+type ExportsConfig struct {
+	Bucket string `mapstructure:"bucket"`
+	Region string `mapstructure:"region"`
+	// Endpoint is optional, it's only needed for the S3 compatible stores
+	// other than AWS
+	Endpoint string `mapstructure:"endpoint"`
+	// Prefix is prepended to the keys of the exported files
+	Prefix string `mapstructure:"prefix"`
+	// UrlTtl is the validity of the signed download urls
+	UrlTtl time.Duration `mapstructure:"url-ttl"`
+	// PollInterval is the interval at which the pending jobs are polled
+	PollInterval time.Duration `mapstructure:"poll-interval"`
+	// JobTimeout is the time after which a running job whose claim has not
+	// been renewed, e.g. interrupted by a restart, is picked up again. The
+	// claim is renewed every third of the timeout while the job runs.
+	JobTimeout time.Duration `mapstructure:"job-timeout"`
+	// Retention is how long the jobs are kept before being removed by the
+	// TTL index, the files shall be expired by a lifecycle rule of the bucket
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+func (cfg *ExportsConfig) Validate() error {
+	if cfg.Bucket == "" {
+		return errors.New("exports bucket must be set")
+	}
+	if cfg.Region == "" {
+		return errors.New("exports region must be set")
+	}
+	if cfg.UrlTtl <= 0 || cfg.UrlTtl > maxExportUrlTtl {
+		return errors.New("exports url ttl must be positive and at most 7 days")
+	}
+	if cfg.PollInterval <= 0 {
+		return errors.New("exports poll interval must be positive")
+	}
+	if cfg.JobTimeout <= 0 {
+		return errors.New("exports job timeout must be positive")
+	}
+	if cfg.Retention < cfg.UrlTtl {
+		return errors.New("exports retention must not be lower than the url ttl")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+const (
+	fieldEncryptionKeySize = 32
+	minBlindIndexKeySize   = 32
+)
+
+// FieldEncryptionConfig defines the AES-256-GCM keys encrypting the sensitive
+// fields of the documents before they are written to the db, i.e. the
+// private labels the api key holders attach to the delegations and the
+// partners the delegations are attributed to. The keys can reference a
+// secret manager, so that they are managed by its KMS.
+type FieldEncryptionConfig struct {
+	// Keys are the base64 encoded 32 bytes keys keyed by lowercase key id.
+	// The rotated keys are kept to decrypt the fields they have encrypted.
+	Keys map[string]string `mapstructure:"keys"`
+	// ActiveKeyId is the id of the key encrypting the fields
+	ActiveKeyId string `mapstructure:"active-key-id"`
+	// BlindIndexKey is the base64 encoded key of the HMAC indexing the
+	// encrypted fields looked up by value, it can't be changed without
+	// indexing these fields again
+	BlindIndexKey string `mapstructure:"blind-index-key"`
+}
+
+func (cfg *FieldEncryptionConfig) Validate() error {
+	if len(cfg.Keys) == 0 {
+		return errors.New("field encryption requires at least one key")
+	}
+	for id, key := range cfg.Keys {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || len(decoded) != fieldEncryptionKeySize {
+			return fmt.Errorf("field encryption key %s must be a base64 encoded %d bytes key", id, fieldEncryptionKeySize)
+		}
+	}
+	if _, ok := cfg.Keys[cfg.ActiveKeyId]; !ok {
+		return fmt.Errorf("field encryption active key %s is not configured", cfg.ActiveKeyId)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(cfg.BlindIndexKey)
+	if err != nil || len(decoded) < minBlindIndexKeySize {
+		return fmt.Errorf("field encryption blind index key must be a base64 encoded key of at least %d bytes", minBlindIndexKeySize)
+	}
+	return nil
+}
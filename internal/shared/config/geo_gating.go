@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// GeoGatingBlock rejects the write requests of the restricted
+	// jurisdictions with a 451
+	GeoGatingBlock = "block"
+	// GeoGatingFlag serves the write requests of the restricted
+	// jurisdictions, they are only logged and counted
+	GeoGatingFlag = "flag"
+)
+
+// GeoGatingConfig defines the jurisdictions restricted from the write
+// endpoints, the jurisdiction of a request is resolved from its client IP
+// with a GeoIP database
+type GeoGatingConfig struct {
+	// DatabasePath is the path of the GeoIP2 or GeoLite2 country database,
+	// in the MaxMind DB format
+	DatabasePath string `mapstructure:"database-path"`
+	// RestrictedCountries are the ISO 3166-1 alpha-2 codes of the restricted
+	// jurisdictions
+	RestrictedCountries []string `mapstructure:"restricted-countries"`
+	// Action is either block or flag
+	Action string `mapstructure:"action"`
+}
+
+func (cfg *GeoGatingConfig) Validate() error {
+	if cfg.DatabasePath == "" {
+		return errors.New("geo gating database path is required")
+	}
+	if len(cfg.RestrictedCountries) == 0 {
+		return errors.New("geo gating restricted countries are required")
+	}
+	for _, country := range cfg.RestrictedCountries {
+		if len(country) != 2 || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+			return fmt.Errorf("invalid geo gating restricted country %q, an uppercase ISO 3166-1 alpha-2 code is expected", country)
+		}
+	}
+	if cfg.Action != GeoGatingBlock && cfg.Action != GeoGatingFlag {
+		return fmt.Errorf("invalid geo gating action %q, must be %s or %s", cfg.Action, GeoGatingBlock, GeoGatingFlag)
+	}
+	return nil
+}
+
+// IsRestricted returns whether the country is a restricted jurisdiction
+func (cfg *GeoGatingConfig) IsRestricted(country string) bool {
+	for _, restricted := range cfg.RestrictedCountries {
+		if restricted == country {
+			return true
+		}
+	}
+	return false
+}
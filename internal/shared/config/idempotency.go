@@ -0,0 +1,30 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// IdempotencyConfig defines the replay of the responses of the write
+// requests carrying an Idempotency-Key header
+type IdempotencyConfig struct {
+	// Ttl is the time the responses are kept for, the retries of a request
+	// shall happen within it
+	Ttl time.Duration `mapstructure:"ttl"`
+	// LockTimeout is the time after which a request still in progress, e.g.
+	// interrupted by a restart, no longer holds its key back from the retries
+	LockTimeout time.Duration `mapstructure:"lock-timeout"`
+}
+
+func (cfg *IdempotencyConfig) Validate() error {
+	if cfg.Ttl <= 0 {
+		return errors.New("idempotency ttl must be positive")
+	}
+	if cfg.LockTimeout <= 0 {
+		return errors.New("idempotency lock timeout must be positive")
+	}
+	if cfg.LockTimeout >= cfg.Ttl {
+		return errors.New("idempotency lock timeout must be lower than the ttl")
+	}
+	return nil
+}
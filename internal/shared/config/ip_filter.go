@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const ipFilterConfigKey = "ip-filter"
+
+// IpFilterConfig defines the client IPs allowed to reach the admin routes and
+// the ones blocked from the public routes. The lists are reloaded whenever the
+// config file changes, so that abusive clients can be blocked without a
+// redeploy.
+type IpFilterConfig struct {
+	// AdminAllowlist are the IPs or CIDRs allowed on the admin routes, all
+	// the clients are allowed if empty
+	AdminAllowlist []string `mapstructure:"admin-allowlist"`
+	// PublicDenylist are the IPs or CIDRs rejected on the public routes
+	PublicDenylist []string `mapstructure:"public-denylist"`
+
+	AdminAllowlistPrefixes []netip.Prefix
+	PublicDenylistPrefixes []netip.Prefix
+}
+
+func (cfg *IpFilterConfig) Validate() error {
+	adminPrefixes, err := parsePrefixes(cfg.AdminAllowlist)
+	if err != nil {
+		return fmt.Errorf("invalid ip filter admin allowlist: %w", err)
+	}
+	publicPrefixes, err := parsePrefixes(cfg.PublicDenylist)
+	if err != nil {
+		return fmt.Errorf("invalid ip filter public denylist: %w", err)
+	}
+	cfg.AdminAllowlistPrefixes = adminPrefixes
+	cfg.PublicDenylistPrefixes = publicPrefixes
+	return nil
+}
+
+// IsAdminAllowed returns whether the address can reach the admin routes
+func (cfg *IpFilterConfig) IsAdminAllowed(addr netip.Addr) bool {
+	return len(cfg.AdminAllowlistPrefixes) == 0 || prefixesContain(cfg.AdminAllowlistPrefixes, addr)
+}
+
+// IsPublicDenied returns whether the address is blocked from the public routes
+func (cfg *IpFilterConfig) IsPublicDenied(addr netip.Addr) bool {
+	return prefixesContain(cfg.PublicDenylistPrefixes, addr)
+}
+
+// ReloadIpFilter reads the ip filter lists of the reloaded config. The reload
+// is refused if the ip-filter section is missing, e.g. the file is read while
+// being written, or if it empties the admin allowlist of the previous config,
+// as all the clients would then be allowed on the admin routes.
+func ReloadIpFilter(v *viper.Viper, previous *IpFilterConfig) (*IpFilterConfig, error) {
+	if !v.IsSet(ipFilterConfigKey) {
+		return nil, fmt.Errorf("the %s config is missing", ipFilterConfigKey)
+	}
+	cfg := &IpFilterConfig{}
+	if err := v.UnmarshalKey(ipFilterConfigKey, cfg); err != nil {
+		return nil, fmt.Errorf("failed to read the ip filter config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if len(previous.AdminAllowlistPrefixes) > 0 && len(cfg.AdminAllowlistPrefixes) == 0 {
+		return nil, fmt.Errorf("the ip filter admin allowlist cannot be emptied by a reload")
+	}
+	return cfg, nil
+}
+
+// WatchIpFilter calls onChange with the ip filter lists each time the config
+// file loaded by New changes, starting from the current lists. A change
+// refused by ReloadIpFilter is logged and ignored, the previous lists remain
+// in force.
+func WatchIpFilter(current *IpFilterConfig, onChange func(cfg *IpFilterConfig)) {
+	viper.OnConfigChange(func(fsnotify.Event) {
+		cfg, err := ReloadIpFilter(viper.GetViper(), current)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to reload the ip filter config, the previous one is kept")
+			return
+		}
+		current = cfg
+		onChange(cfg)
+	})
+	viper.WatchConfig()
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// LeaderElectionConfig defines the leases electing the single replica that
+// runs each of the scheduled jobs, such as the stats refresher, the delegation
+// archiver and the expiry scanner
+type LeaderElectionConfig struct {
+	// LeaseDuration is how long a replica stays the leader of a job without
+	// renewing its lease, it bounds how long the job is not run once its
+	// leader is gone. The leader renews its leases every third of it.
+	LeaseDuration time.Duration `mapstructure:"lease-duration"`
+	// InstanceId identifies the replica holding the leases, the hostname is
+	// used if not set
+	InstanceId string `mapstructure:"instance-id"`
+}
+
+func (cfg *LeaderElectionConfig) Validate() error {
+	if cfg.LeaseDuration < 3*time.Second {
+		return errors.New("leader election lease duration must be at least 3s")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	LogFormatJson    = "json"
+	LogFormatConsole = "console"
+)
+
+// The modules whose log level can be overridden
+const (
+	LogModuleDb       = "db"
+	LogModuleQueue    = "queue"
+	LogModuleHandlers = "handlers"
+)
+
+var LogModules = []string{LogModuleDb, LogModuleQueue, LogModuleHandlers}
+
+// LoggingConfig defines the format of the logs and the log levels of the
+// modules, the other logs are emitted at the server log level. Both can be
+// changed at runtime through the admin listener.
+type LoggingConfig struct {
+	// Format is either json or console, json if not set
+	Format string `mapstructure:"format"`
+	// Modules overrides the server log level of the db, queue and handlers
+	// logs
+	Modules map[string]string `mapstructure:"modules"`
+}
+
+func (cfg *LoggingConfig) Validate() error {
+	if err := ValidateLogFormat(cfg.Format); cfg.Format != "" && err != nil {
+		return err
+	}
+	for module, level := range cfg.Modules {
+		if err := ValidateLogModule(module); err != nil {
+			return err
+		}
+		if _, err := ParseLogLevel(level); err != nil {
+			return fmt.Errorf("invalid log level of module %s: %w", module, err)
+		}
+	}
+	return nil
+}
+
+func ValidateLogFormat(format string) error {
+	if format != LogFormatJson && format != LogFormatConsole {
+		return fmt.Errorf("log format must be either %s or %s", LogFormatJson, LogFormatConsole)
+	}
+	return nil
+}
+
+func ValidateLogModule(module string) error {
+	for _, known := range LogModules {
+		if module == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown log module %s", module)
+}
+
+// ParseLogLevel parses the log levels supported by the service, from debug
+// to fatal
+func ParseLogLevel(level string) (zerolog.Level, error) {
+	parsedLevel, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return zerolog.NoLevel, fmt.Errorf("invalid log level: %w", err)
+	}
+	if parsedLevel < zerolog.DebugLevel || parsedLevel > zerolog.FatalLevel {
+		return zerolog.NoLevel, fmt.Errorf("only log levels from debug to fatal are supported")
+	}
+	return parsedLevel, nil
+}
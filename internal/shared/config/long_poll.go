@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LongPollConfig defines how long the delegation requests can be held until
+// the delegation reaches the awaited state
+type LongPollConfig struct {
+	// MaxTimeout is the max duration a request can be held for, it's also the
+	// timeout of the requests not giving one
+	MaxTimeout time.Duration `mapstructure:"max-timeout"`
+	// MaxWaiters is the max number of requests held at once, each of them
+	// holds a change stream on the database. The requests over it are
+	// rejected until a held one is answered.
+	MaxWaiters int `mapstructure:"max-waiters"`
+}
+
+// Validate checks the long poll timeout along with the timeouts of the public
+// routes, which must leave time to answer once the long poll timeout fires
+func (cfg *LongPollConfig) Validate(server *ServerConfig, routeLimits *RouteLimitsConfig) error {
+	if cfg.MaxTimeout < time.Second {
+		return errors.New("long poll max timeout must be at least 1s")
+	}
+	if cfg.MaxWaiters <= 0 {
+		return errors.New("long poll max waiters must be positive")
+	}
+	writeTimeout := server.WriteTimeout
+	var handlerTimeout time.Duration
+	if routeLimits != nil && routeLimits.Public != nil {
+		if routeLimits.Public.WriteTimeout > 0 {
+			writeTimeout = routeLimits.Public.WriteTimeout
+		}
+		handlerTimeout = routeLimits.Public.HandlerTimeout
+	}
+	if writeTimeout > 0 && cfg.MaxTimeout >= writeTimeout {
+		return fmt.Errorf("long poll max timeout must be shorter than the write timeout %s", writeTimeout)
+	}
+	if handlerTimeout > 0 && cfg.MaxTimeout >= handlerTimeout {
+		return fmt.Errorf("long poll max timeout must be shorter than the handler timeout %s", handlerTimeout)
+	}
+	return nil
+}
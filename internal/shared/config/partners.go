@@ -0,0 +1,32 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var partnerIdRegex = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// PartnersConfig defines the partners, e.g. the wallets and the integrators,
+// which can attribute the delegations they originate to themselves
+type PartnersConfig struct {
+	// Secrets holds the HMAC secret of each partner keyed by the partner id.
+	// The partner ids are lowercase as the config keys are case insensitive.
+	Secrets map[string]string `mapstructure:"secrets"`
+}
+
+func (cfg *PartnersConfig) Validate() error {
+	if len(cfg.Secrets) == 0 {
+		return errors.New("at least one partner must be configured")
+	}
+	for partnerId, secret := range cfg.Secrets {
+		if !partnerIdRegex.MatchString(partnerId) {
+			return fmt.Errorf("invalid partner id %q", partnerId)
+		}
+		if secret == "" {
+			return fmt.Errorf("secret of partner %s cannot be empty", partnerId)
+		}
+	}
+	return nil
+}
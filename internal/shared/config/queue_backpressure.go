@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// QueueBackpressureConfig enables the throttling of the queue consumers while
+// the staking db is struggling, so that the messages are consumed slower
+// instead of failing and being requeued.
+type QueueBackpressureConfig struct {
+	// Interval is the period over which the db commands are evaluated before
+	// adjusting the number of workers
+	Interval time.Duration `mapstructure:"interval"`
+	// LatencyThreshold is the average duration of the db commands above which
+	// the number of workers is halved
+	LatencyThreshold time.Duration `mapstructure:"latency-threshold"`
+	// FailureRateThreshold is the fraction, between 0 and 1, of failed db
+	// commands above which the number of workers is halved
+	FailureRateThreshold float64 `mapstructure:"failure-rate-threshold"`
+}
+
+func (cfg *QueueBackpressureConfig) Validate() error {
+	if cfg.Interval < time.Second {
+		return fmt.Errorf("queue backpressure interval must be at least 1s")
+	}
+	if cfg.LatencyThreshold <= 0 {
+		return fmt.Errorf("queue backpressure latency threshold must be positive")
+	}
+	if cfg.FailureRateThreshold <= 0 || cfg.FailureRateThreshold > 1 {
+		return fmt.Errorf("queue backpressure failure rate threshold must be in (0, 1]")
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// QueueMonitorConfig defines the thresholds used to detect the queue message
+// processing falling behind. A threshold of 0 disables the respective check.
+type QueueMonitorConfig struct {
+	// Interval between two inspections of the queues
+	Interval time.Duration `mapstructure:"interval"`
+	// MaxQueueDepth is the number of ready messages in a queue above which
+	// the queue is considered lagging
+	MaxQueueDepth int `mapstructure:"max-queue-depth"`
+	// MaxMessageAge is the age of the oldest message in a queue above which
+	// the queue is considered lagging
+	MaxMessageAge time.Duration `mapstructure:"max-message-age"`
+	// FailHealthCheck makes the healthcheck endpoint return 503 while any of
+	// the queues is lagging
+	FailHealthCheck bool `mapstructure:"fail-health-check"`
+	// WebhookUrl is called with a POST request when a queue starts lagging
+	WebhookUrl string `mapstructure:"webhook-url"`
+}
+
+func (cfg *QueueMonitorConfig) Validate() error {
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("queue monitor interval must be positive")
+	}
+	if cfg.MaxQueueDepth < 0 {
+		return fmt.Errorf("queue monitor max queue depth cannot be negative")
+	}
+	if cfg.MaxMessageAge < 0 {
+		return fmt.Errorf("queue monitor max message age cannot be negative")
+	}
+	if cfg.WebhookUrl != "" {
+		if _, err := url.ParseRequestURI(cfg.WebhookUrl); err != nil {
+			return fmt.Errorf("invalid queue monitor webhook url: %w", err)
+		}
+	}
+	return nil
+}
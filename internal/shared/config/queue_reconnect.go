@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// QueueReconnectConfig enables the automatic reconnection of the queue
+// clients once their connection or channel to the broker is broken.
+type QueueReconnectConfig struct {
+	// InitialBackoff is the delay before the first reconnection attempt, it's
+	// doubled after each failed attempt
+	InitialBackoff time.Duration `mapstructure:"initial-backoff"`
+	// MaxBackoff caps the delay between two reconnection attempts
+	MaxBackoff time.Duration `mapstructure:"max-backoff"`
+	// MaxDisconnectedDuration is how long a queue can stay disconnected before
+	// the queue health check fails and terminates the service, 0 never fails
+	MaxDisconnectedDuration time.Duration `mapstructure:"max-disconnected-duration"`
+}
+
+func (cfg *QueueReconnectConfig) Validate() error {
+	if cfg.InitialBackoff <= 0 {
+		return fmt.Errorf("queue reconnect initial backoff must be positive")
+	}
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		return fmt.Errorf("queue reconnect max backoff must not be less than the initial backoff")
+	}
+	if cfg.MaxDisconnectedDuration < 0 {
+		return fmt.Errorf("queue reconnect max disconnected duration cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// QueueRedeliveryConfig enables the redelivery of the failed messages with an
+// exponential delay per retry attempt, instead of the fixed requeue delay.
+type QueueRedeliveryConfig struct {
+	// InitialDelay is the delay before the first redelivery, it's doubled for
+	// each following attempt
+	InitialDelay time.Duration `mapstructure:"initial-delay"`
+	// MaxDelay caps the delay before a redelivery
+	MaxDelay time.Duration `mapstructure:"max-delay"`
+}
+
+func (cfg *QueueRedeliveryConfig) Validate() error {
+	if cfg.InitialDelay < time.Millisecond {
+		return fmt.Errorf("queue redelivery initial delay must be at least 1ms")
+	}
+	if cfg.MaxDelay < cfg.InitialDelay {
+		return fmt.Errorf("queue redelivery max delay must not be less than the initial delay")
+	}
+	return nil
+}
+
+// DelayFor returns the delay before redelivering a message that has already
+// been attempted the given number of times
+func (cfg *QueueRedeliveryConfig) DelayFor(attempts int32) time.Duration {
+	delay := cfg.InitialDelay
+	for i := int32(0); i < attempts && delay < cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, cfg.MaxDelay)
+}
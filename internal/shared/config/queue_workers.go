@@ -0,0 +1,37 @@
+package config
+
+import "fmt"
+
+const maxQueueWorkers = 64
+
+// QueueWorkersConfig sets the number of workers concurrently processing the
+// messages of each queue, so that the time sensitive events (e.g. expired,
+// unbonding) can be given a dedicated worker pool and are not starved behind
+// a large backlog of other events. The events of a delegation are always
+// processed by the same worker, in the order they are received.
+type QueueWorkersConfig struct {
+	// Default is the number of workers of the queues not listed in Queues
+	Default int `mapstructure:"default"`
+	// Queues maps the queue name to its number of workers
+	Queues map[string]int `mapstructure:"queues"`
+}
+
+func (cfg *QueueWorkersConfig) Validate() error {
+	if cfg.Default < 1 || cfg.Default > maxQueueWorkers {
+		return fmt.Errorf("queue workers default must be between 1 and %d", maxQueueWorkers)
+	}
+	for queueName, workers := range cfg.Queues {
+		if workers < 1 || workers > maxQueueWorkers {
+			return fmt.Errorf("queue workers of %s must be between 1 and %d", queueName, maxQueueWorkers)
+		}
+	}
+	return nil
+}
+
+// WorkerCount returns the number of workers of the given queue
+func (cfg *QueueWorkersConfig) WorkerCount(queueName string) int {
+	if workers, ok := cfg.Queues[queueName]; ok {
+		return workers
+	}
+	return cfg.Default
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// ResponseCacheConfig defines the in-process cache of the stats and the
+// finality providers responses, served with stale-while-revalidate semantics.
+type ResponseCacheConfig struct {
+	// TTL is the duration a cached response is served as fresh
+	TTL time.Duration `mapstructure:"ttl"`
+	// StaleTTL is the duration a cached response is still served past its TTL
+	// while it's refreshed in the background
+	StaleTTL time.Duration `mapstructure:"stale-ttl"`
+	// MaxEntries bounds the number of cached responses, one per distinct URL
+	MaxEntries int `mapstructure:"max-entries"`
+}
+
+func (cfg *ResponseCacheConfig) Validate() error {
+	if cfg.TTL <= 0 {
+		return errors.New("response cache ttl must be positive")
+	}
+	if cfg.StaleTTL < 0 {
+		return errors.New("response cache stale ttl cannot be negative")
+	}
+	if cfg.MaxEntries <= 0 {
+		return errors.New("response cache max entries must be positive")
+	}
+	return nil
+}
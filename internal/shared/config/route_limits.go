@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RouteLimitsConfig sets the timeouts and the maximum request body size of
+// each route group, so that the slow or oversized requests are cut before
+// reaching the database.
+type RouteLimitsConfig struct {
+	// Public applies to the public routes other than the unbonding ones
+	Public *RouteGroupLimits `mapstructure:"public"`
+	// Unbonding applies to the unbonding request submission
+	Unbonding *RouteGroupLimits `mapstructure:"unbonding"`
+	// Batch applies to the public routes taking a batch in the request body,
+	// e.g. the stats of many stakers. Their max content length defaults to
+	// the size of the largest batch rather than to the server one.
+	Batch *RouteGroupLimits `mapstructure:"batch"`
+	// Admin applies to the routes of the admin listener
+	Admin *RouteGroupLimits `mapstructure:"admin"`
+}
+
+// RouteGroupLimits are the limits of a route group, a zero value leaves the
+// corresponding server setting in place, see WithServerDefaults
+type RouteGroupLimits struct {
+	// ReadTimeout is the maximum duration for reading the request body
+	ReadTimeout time.Duration `mapstructure:"read-timeout"`
+	// WriteTimeout is the maximum duration for writing the response
+	WriteTimeout time.Duration `mapstructure:"write-timeout"`
+	// HandlerTimeout is the deadline of the request handling, a request
+	// exceeding it is answered with a 408
+	HandlerTimeout time.Duration `mapstructure:"handler-timeout"`
+	// MaxContentLength is the maximum request body size in bytes, a larger
+	// request is answered with a 413
+	MaxContentLength int64 `mapstructure:"max-content-length"`
+}
+
+// WithServerDefaults returns the limits of the route group, falling back to
+// the max content length of the server if the group doesn't set one. The
+// group limits may be nil if the group is not configured.
+func (cfg *RouteGroupLimits) WithServerDefaults(server *ServerConfig) *RouteGroupLimits {
+	return cfg.WithDefaultMaxContentLength(server.MaxContentLength)
+}
+
+// WithDefaultMaxContentLength returns the limits of the route group, falling
+// back to the given max content length if the group doesn't set one
+func (cfg *RouteGroupLimits) WithDefaultMaxContentLength(maxContentLength int64) *RouteGroupLimits {
+	limits := RouteGroupLimits{}
+	if cfg != nil {
+		limits = *cfg
+	}
+	if limits.MaxContentLength == 0 {
+		limits.MaxContentLength = maxContentLength
+	}
+	return &limits
+}
+
+func (cfg *RouteLimitsConfig) Validate() error {
+	groups := map[string]*RouteGroupLimits{
+		"public":    cfg.Public,
+		"unbonding": cfg.Unbonding,
+		"batch":     cfg.Batch,
+		"admin":     cfg.Admin,
+	}
+	for name, limits := range groups {
+		if limits == nil {
+			continue
+		}
+		if err := limits.Validate(); err != nil {
+			return fmt.Errorf("invalid %s route limits: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (cfg *RouteGroupLimits) Validate() error {
+	if cfg.ReadTimeout < 0 || cfg.WriteTimeout < 0 || cfg.HandlerTimeout < 0 {
+		return fmt.Errorf("timeouts cannot be negative")
+	}
+	if cfg.MaxContentLength < 0 {
+		return fmt.Errorf("max content length cannot be negative")
+	}
+	if cfg.WriteTimeout > 0 && cfg.HandlerTimeout > cfg.WriteTimeout {
+		return fmt.Errorf("handler timeout must not exceed the write timeout")
+	}
+	return nil
+}
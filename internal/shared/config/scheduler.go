@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// SchedulerConfig overrides how the scheduled jobs, such as the stats
+// refresher, the delegation archiver and the expiry scanner, are run. The jobs
+// not listed run every interval of their own config.
+type SchedulerConfig struct {
+	// Jobs are keyed by job name
+	Jobs map[string]*ScheduledJobConfig `mapstructure:"jobs"`
+}
+
+type ScheduledJobConfig struct {
+	// Enabled must be set for the job to run, a job listed without it is
+	// disabled
+	Enabled bool `mapstructure:"enabled"`
+	// Schedule is either a standard cron expression, such as "0 * * * *", or a
+	// descriptor, such as "@hourly" or "@every 10m". The interval of the job
+	// config is used if not set.
+	Schedule string `mapstructure:"schedule"`
+	// Jitter is the max random delay of each run, so that the jobs of the same
+	// schedule don't hit the db all at once
+	Jitter time.Duration `mapstructure:"jitter"`
+}
+
+func (cfg *SchedulerConfig) Validate() error {
+	for name, job := range cfg.Jobs {
+		if job == nil {
+			return fmt.Errorf("scheduled job %s must be configured", name)
+		}
+		if err := job.Validate(); err != nil {
+			return fmt.Errorf("invalid scheduled job %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (cfg *ScheduledJobConfig) Validate() error {
+	if cfg.Schedule != "" {
+		if _, err := cron.ParseStandard(cfg.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule: %w", err)
+		}
+	}
+	if cfg.Jitter < 0 {
+		return errors.New("jitter must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/secrets"
+	"github.com/spf13/viper"
+)
+
+const (
+	secretsConfigKey      = "secrets"
+	defaultSecretsTimeout = 10 * time.Second
+)
+
+// SecretsConfig defines the access to the external secret managers. Any
+// config value can reference a secret instead of holding it in plaintext,
+// e.g. `aws-sm://staking-api/mongo#password`,
+// `gcp-sm://projects/my-project/secrets/mongo-password/versions/latest` or
+// `vault://secret/data/staking-api#queue_password`. The references are
+// resolved at startup.
+type SecretsConfig struct {
+	// AwsRegion is the region of AWS Secrets Manager
+	AwsRegion string `mapstructure:"aws-region"`
+	// VaultAddress and VaultToken default to the VAULT_ADDR and VAULT_TOKEN
+	// env vars
+	VaultAddress string        `mapstructure:"vault-address"`
+	VaultToken   string        `mapstructure:"vault-token"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+}
+
+func (cfg *SecretsConfig) Validate() error {
+	if cfg.Timeout < 0 {
+		return errors.New("secrets timeout cannot be negative")
+	}
+	return nil
+}
+
+// resolveSecrets replaces the secret references of the config values by the
+// secrets and returns the resolved keys
+func resolveSecrets(ctx context.Context, v *viper.Viper) ([]string, error) {
+	secretsCfg := &SecretsConfig{}
+	if err := v.UnmarshalKey(secretsConfigKey, secretsCfg); err != nil {
+		return nil, fmt.Errorf("invalid secrets config: %w", err)
+	}
+	if err := secretsCfg.Validate(); err != nil {
+		return nil, err
+	}
+	if secretsCfg.Timeout == 0 {
+		secretsCfg.Timeout = defaultSecretsTimeout
+	}
+	resolver := secrets.NewResolver(secrets.Options{
+		AwsRegion:    secretsCfg.AwsRegion,
+		VaultAddress: secretsCfg.VaultAddress,
+		VaultToken:   secretsCfg.VaultToken,
+		Timeout:      secretsCfg.Timeout,
+	})
+
+	var resolved []string
+	keys := v.AllKeys()
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, ok := v.Get(key).(string)
+		if !ok || !secrets.IsReference(value) {
+			continue
+		}
+		secret, err := resolver.Resolve(ctx, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the secret of %s: %w", key, err)
+		}
+		v.Set(key, secret)
+		resolved = append(resolved, key)
+	}
+	return resolved, nil
+}
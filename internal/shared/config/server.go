@@ -8,7 +8,6 @@ import (
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
 	"github.com/btcsuite/btcd/chaincfg"
-	"github.com/rs/zerolog"
 )
 
 type ServerConfig struct {
@@ -22,6 +21,8 @@ type ServerConfig struct {
 	LogLevel             string        `mapstructure:"log-level"`
 	MaxContentLength     int64         `mapstructure:"max-content-length"`
 	HealthCheckInterval  int           `mapstructure:"health-check-interval"`
+	// TLS is optional, the listener serves plain HTTP if not set
+	TLS *TLSConfig `mapstructure:"tls"`
 
 	BTCNetParam *chaincfg.Params
 }
@@ -56,6 +57,12 @@ func (cfg *ServerConfig) Validate() error {
 		return fmt.Errorf("HealthCheckInterval must be a positive integer")
 	}
 
+	if cfg.TLS != nil {
+		if err := cfg.TLS.Validate(); err != nil {
+			return fmt.Errorf("invalid server tls: %w", err)
+		}
+	}
+
 	btcNet, err := utils.GetBtcNetParamesFromString(cfg.BTCNet)
 	if err != nil {
 		return errors.New("invalid btc-net")
@@ -72,10 +79,6 @@ func (cfg *ServerConfig) ValidateServerLogLevel() error {
 		return nil
 	}
 
-	if parsedLevel, err := zerolog.ParseLevel(cfg.LogLevel); err != nil {
-		return fmt.Errorf("invalid log level: %w", err)
-	} else if parsedLevel < zerolog.DebugLevel || parsedLevel > zerolog.FatalLevel {
-		return fmt.Errorf("only log levels from debug to fatal are supported")
-	}
-	return nil
+	_, err := ParseLogLevel(cfg.LogLevel)
+	return err
 }
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// ShadowRoleMirror is the role of the primary deployment, which copies
+	// the consumed messages into the shadow queues
+	ShadowRoleMirror = "mirror"
+	// ShadowRoleShadow is the role of the shadow deployment, which consumes
+	// the shadow queues and writes into its own sandbox staking db
+	ShadowRoleShadow = "shadow"
+)
+
+// ShadowConfig defines the shadow consumption of the production events, used
+// to validate the handler changes before the cutover. The primary deployment
+// mirrors each consumed message into a shadow queue, named after the queue
+// with the queue suffix, and the shadow deployment processes them against
+// its sandbox staking db. The shadow queues shall be bounded (e.g. with a
+// max-length policy) as they grow while no shadow deployment is running.
+type ShadowConfig struct {
+	Role        string `mapstructure:"role"`
+	QueueSuffix string `mapstructure:"queue-suffix"`
+	// PrimaryDb is the staking db of the primary deployment, it's only used
+	// by the shadow role, read-only, to log the divergences of the sandbox
+	// documents. The documents are not compared if not set.
+	PrimaryDb *DbConfig `mapstructure:"primary-db"`
+	// CompareDelay leaves the primary deployment the time to process the
+	// event before the documents are compared
+	CompareDelay time.Duration `mapstructure:"compare-delay"`
+	// ComparedCollections are the collections whose documents, keyed by the
+	// staking tx hash of the event, are compared
+	ComparedCollections []string `mapstructure:"compared-collections"`
+	// IgnoredFields are the document fields expected to differ, e.g. the
+	// timestamps of the writes
+	IgnoredFields []string `mapstructure:"ignored-fields"`
+}
+
+// QueueName returns the name of the shadow queue of the given queue
+func (cfg *ShadowConfig) QueueName(queueName string) string {
+	return queueName + cfg.QueueSuffix
+}
+
+// IsShadow returns whether the deployment consumes the shadow queues
+func (cfg *ShadowConfig) IsShadow() bool {
+	return cfg != nil && cfg.Role == ShadowRoleShadow
+}
+
+// IsMirror returns whether the deployment mirrors its messages into the
+// shadow queues
+func (cfg *ShadowConfig) IsMirror() bool {
+	return cfg != nil && cfg.Role == ShadowRoleMirror
+}
+
+func (cfg *ShadowConfig) Validate(stakingDb *DbConfig) error {
+	if cfg.Role != ShadowRoleMirror && cfg.Role != ShadowRoleShadow {
+		return fmt.Errorf("shadow role must be either %s or %s", ShadowRoleMirror, ShadowRoleShadow)
+	}
+	if cfg.QueueSuffix == "" {
+		return errors.New("shadow queue suffix must be set")
+	}
+	if cfg.PrimaryDb == nil {
+		return nil
+	}
+	if cfg.Role != ShadowRoleShadow {
+		return errors.New("shadow primary db is only used by the shadow role")
+	}
+	if err := cfg.PrimaryDb.Validate(); err != nil {
+		return fmt.Errorf("invalid shadow primary db: %w", err)
+	}
+	// The shadow deployment must never write into the primary db
+	if cfg.PrimaryDb.Address == stakingDb.Address && cfg.PrimaryDb.DbName == stakingDb.DbName {
+		return errors.New("shadow primary db must differ from the staking db")
+	}
+	if cfg.CompareDelay <= 0 {
+		return errors.New("shadow compare delay must be positive")
+	}
+	if len(cfg.ComparedCollections) == 0 {
+		return errors.New("shadow compared collections must be set")
+	}
+	return nil
+}
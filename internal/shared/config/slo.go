@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SloConfig defines the latency budgets of the routes, the p95 and p99
+// latencies of each route are tracked over the window against its budgets.
+type SloConfig struct {
+	// Window is the rolling window the latencies and burn rates are computed
+	// over
+	Window time.Duration `mapstructure:"window"`
+	// Routes maps the route paths to their latency budgets
+	Routes map[string]*RouteLatencyBudget `mapstructure:"routes"`
+}
+
+// RouteLatencyBudget is the latency 95% and 99% of the requests of the route
+// must be served within, a zero budget is not tracked
+type RouteLatencyBudget struct {
+	P95 time.Duration `mapstructure:"p95"`
+	P99 time.Duration `mapstructure:"p99"`
+}
+
+func (cfg *SloConfig) Validate() error {
+	if cfg.Window < time.Minute {
+		return fmt.Errorf("slo window must be at least 1m")
+	}
+	for path, budget := range cfg.Routes {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("invalid slo route %s, must start with /", path)
+		}
+		if budget == nil || (budget.P95 <= 0 && budget.P99 <= 0) {
+			return fmt.Errorf("slo route %s has no latency budget", path)
+		}
+		if budget.P95 < 0 || budget.P99 < 0 {
+			return fmt.Errorf("slo route %s has a negative latency budget", path)
+		}
+		if budget.P95 > 0 && budget.P99 > 0 && budget.P99 < budget.P95 {
+			return fmt.Errorf("slo route %s p99 budget must not be lower than its p95 budget", path)
+		}
+	}
+	return nil
+}
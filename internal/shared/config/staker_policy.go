@@ -0,0 +1,37 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// StakerPolicyConfig defines the anti-abuse limits applied to the delegations
+// of each staker. A limit of 0 is not enforced.
+type StakerPolicyConfig struct {
+	// MaxActiveDelegations is the max number of pending and active
+	// delegations of a staker
+	MaxActiveDelegations int64 `mapstructure:"max-active-delegations"`
+	// MinStakingAmount and MaxStakingAmount bound the amount of a delegation,
+	// within the bounds of the global params
+	MinStakingAmount uint64 `mapstructure:"min-staking-amount"`
+	MaxStakingAmount uint64 `mapstructure:"max-staking-amount"`
+	// Cooldown is the min duration between the staking start of two
+	// delegations of a staker
+	Cooldown time.Duration `mapstructure:"cooldown"`
+}
+
+func (cfg *StakerPolicyConfig) Validate() error {
+	if cfg.MaxActiveDelegations < 0 {
+		return errors.New("staker policy max active delegations must not be negative")
+	}
+	if cfg.MaxStakingAmount != 0 && cfg.MaxStakingAmount < cfg.MinStakingAmount {
+		return errors.New("staker policy max staking amount must not be lower than its min staking amount")
+	}
+	if cfg.Cooldown < 0 {
+		return errors.New("staker policy cooldown must not be negative")
+	}
+	if cfg.Cooldown%time.Second != 0 {
+		return errors.New("staker policy cooldown must be a whole number of seconds")
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// StatsRefresherConfig defines how often the overall stats shards are
+// consolidated into the materialized overall stats document.
+type StatsRefresherConfig struct {
+	// Interval between two refreshes of the materialized overall stats
+	Interval time.Duration `mapstructure:"interval"`
+	// MaxStaleness is the age of the materialized overall stats above which
+	// the stats are computed from the shards instead
+	MaxStaleness time.Duration `mapstructure:"max-staleness"`
+}
+
+func (cfg *StatsRefresherConfig) Validate() error {
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("stats refresher interval must be positive")
+	}
+	if cfg.MaxStaleness < cfg.Interval {
+		return fmt.Errorf("stats refresher max staleness must not be less than the interval")
+	}
+	return nil
+}
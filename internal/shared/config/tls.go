@@ -0,0 +1,52 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSConfig enables TLS on a listener, along with the verification of the
+// client certificates if a client CA is set
+type TLSConfig struct {
+	CertFile string `mapstructure:"cert-file"`
+	KeyFile  string `mapstructure:"key-file"`
+	// ClientCAFile is optional, the clients must present a certificate
+	// signed by one of its CAs if set
+	ClientCAFile string `mapstructure:"client-ca-file"`
+}
+
+func (cfg *TLSConfig) Validate() error {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return errors.New("tls cert file and key file must be set")
+	}
+	_, err := cfg.Build()
+	return err
+}
+
+// Build loads the certificates into the TLS config of the listener
+func (cfg *TLSConfig) Build() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error while loading tls certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading tls client ca file: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificate found in tls client ca file")
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
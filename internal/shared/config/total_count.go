@@ -0,0 +1,13 @@
+package config
+
+// TotalCountConfig enables the include_total query of the paginated lists
+type TotalCountConfig struct {
+	// Approximate serves the maintained counters, e.g. the staker stats, or
+	// the collection estimates rather than counting the matching documents.
+	// The lists without such a counter are always counted exactly.
+	Approximate bool `mapstructure:"approximate"`
+}
+
+func (cfg *TotalCountConfig) Validate() error {
+	return nil
+}
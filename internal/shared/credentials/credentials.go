@@ -0,0 +1,30 @@
+package credentials
+
+import (
+	"sync/atomic"
+)
+
+// Credentials are the username and password of a db or of the broker
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Holder holds the current credentials, which are replaced when rotated
+type Holder struct {
+	current atomic.Pointer[Credentials]
+}
+
+func NewHolder(credentials Credentials) *Holder {
+	h := &Holder{}
+	h.current.Store(&credentials)
+	return h
+}
+
+func (h *Holder) Get() Credentials {
+	return *h.current.Load()
+}
+
+func (h *Holder) Set(credentials Credentials) {
+	h.current.Store(&credentials)
+}
@@ -0,0 +1,87 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog/log"
+)
+
+// Target is a connection whose credentials can be rotated
+type Target struct {
+	Name string
+	// Extract returns the credentials of the target from the config
+	Extract func(cfg *config.Config) Credentials
+	// Rotate re-establishes the connection with the new credentials
+	Rotate func(ctx context.Context, credentials Credentials) error
+}
+
+// Rotator reloads the config and rotates the credentials of the targets
+// which have changed
+type Rotator struct {
+	reload  func() (*config.Config, error)
+	targets []Target
+	current map[string]Credentials
+}
+
+func NewRotator(cfg *config.Config, reload func() (*config.Config, error), targets ...Target) *Rotator {
+	current := make(map[string]Credentials, len(targets))
+	for _, target := range targets {
+		current[target.Name] = target.Extract(cfg)
+	}
+	return &Rotator{reload: reload, targets: targets, current: current}
+}
+
+// Check rotates the credentials which have changed since the last check. A
+// failed rotation is attempted again on the next check, the connection
+// keeping on using the previous credentials meanwhile.
+func (r *Rotator) Check(ctx context.Context) {
+	cfg, err := r.reload()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to reload the config to check the credentials")
+		return
+	}
+	for _, target := range r.targets {
+		credentials := target.Extract(cfg)
+		if credentials == r.current[target.Name] {
+			continue
+		}
+		if err := target.Rotate(ctx, credentials); err != nil {
+			log.Error().Err(err).Str("target", target.Name).Msg("failed to rotate the credentials")
+			continue
+		}
+		r.current[target.Name] = credentials
+		log.Info().Str("target", target.Name).Msg("credentials rotated")
+	}
+}
+
+// StartRotation checks the credentials at the configured interval. It's a
+// no-op if the credential rotation is not configured.
+func StartRotation(
+	ctx context.Context, cfg *config.Config, reload func() (*config.Config, error), targets ...Target,
+) error {
+	if cfg.CredentialRotation == nil {
+		return nil
+	}
+	rotator := NewRotator(cfg, reload, targets...)
+
+	// The checks must not overlap, as the rotator is not safe for concurrent
+	// use and a rotation may outlast the interval
+	c := cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger)))
+	_, err := c.AddFunc(fmt.Sprintf("@every %s", cfg.CredentialRotation.CheckInterval), func() {
+		rotator.Check(ctx)
+	})
+	if err != nil {
+		return err
+	}
+	c.Start()
+	log.Info().Msg("Initiated Credential Rotation Cron")
+
+	go func() {
+		<-ctx.Done()
+		c.Stop()
+	}()
+	return nil
+}
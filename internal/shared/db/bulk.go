@@ -0,0 +1,14 @@
+package db
+
+// BulkWriteResult is the outcome of an unordered bulk write, in which an item
+// failing to be written doesn't prevent the others from being written
+type BulkWriteResult struct {
+	// Written is the number of items written
+	Written int64
+	// Failed maps the index of each failed item to its error
+	Failed map[int]error
+}
+
+func NewBulkWriteResult() *BulkWriteResult {
+	return &BulkWriteResult{Failed: make(map[int]error)}
+}
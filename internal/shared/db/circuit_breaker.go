@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type circuitState int
+
+const transientTransactionErrorLabel = "TransientTransactionError"
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreaker stops calling the database for a cool down period once the
+// number of consecutive transient failures reaches the threshold, so that a
+// database hiccup results in fast 503s instead of piling up slow 500s. Only
+// the driver errors, i.e. network errors and timeouts, count as failures.
+// Transient failures are retried with an exponential backoff before being
+// counted as a failure.
+// A nil CircuitBreaker executes the operations without retry.
+type CircuitBreaker struct {
+	cfg *config.DbCircuitBreakerConfig
+
+	mu                  sync.Mutex
+	state               circuitState
+	consecutiveFailures int
+	openedAt            time.Time
+}
+
+// NewCircuitBreaker returns a circuit breaker, or nil if it's not configured
+func NewCircuitBreaker(cfg *config.DbCircuitBreakerConfig) *CircuitBreaker {
+	if cfg == nil {
+		return nil
+	}
+	return &CircuitBreaker{cfg: cfg}
+}
+
+// Execute runs the operation if the circuit is not open. The operation is
+// retried up to the configured number of times if `retryable` is true and the
+// operation failed with a transient error.
+// It returns an UnavailableError if the circuit is open or if the operation
+// kept failing with transient errors.
+func (cb *CircuitBreaker) Execute(
+	ctx context.Context, retryable bool, operation func() error,
+) error {
+	if cb == nil {
+		return operation()
+	}
+	if retryAfter, ok := cb.allow(); !ok {
+		return &UnavailableError{RetryAfter: retryAfter}
+	}
+
+	maxAttempts := 1
+	if retryable {
+		maxAttempts += cb.cfg.MaxRetries
+	}
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			backoff := cb.cfg.RetryBackoff * time.Duration(1<<(attempt-1))
+			select {
+			case <-ctx.Done():
+				cb.recordFailure()
+				return &UnavailableError{RetryAfter: cb.cfg.OpenDuration, Err: err}
+			case <-time.After(backoff):
+			}
+		}
+		err = operation()
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// The caller's deadline expired, which says nothing about the
+			// health of the database, e.g. a slow request body
+			cb.releaseTrial()
+			return err
+		}
+		if err == nil || !IsTransientError(err) {
+			// The database is reachable, the error is not related to its health
+			cb.recordSuccess()
+			return err
+		}
+	}
+	cb.recordFailure()
+	return &UnavailableError{RetryAfter: cb.cfg.OpenDuration, Err: err}
+}
+
+// ExecuteWithResult runs the operation returning a result through the
+// circuit breaker, see Execute
+func ExecuteWithResult[T any](
+	ctx context.Context, cb *CircuitBreaker, retryable bool, operation func() (T, error),
+) (T, error) {
+	var result T
+	err := cb.Execute(ctx, retryable, func() error {
+		var err error
+		result, err = operation()
+		return err
+	})
+	return result, err
+}
+
+// allow checks whether the operation can be executed, if not, it returns the
+// remaining time before the circuit will be half-open.
+func (cb *CircuitBreaker) allow() (time.Duration, bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case circuitOpen:
+		elapsed := time.Since(cb.openedAt)
+		if elapsed < cb.cfg.OpenDuration {
+			return cb.cfg.OpenDuration - elapsed, false
+		}
+		// Let a single trial operation through
+		cb.state = circuitHalfOpen
+		return 0, true
+	case circuitHalfOpen:
+		// A trial operation is in flight
+		return cb.cfg.OpenDuration, false
+	default:
+		return 0, true
+	}
+}
+
+func (cb *CircuitBreaker) recordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.state = circuitClosed
+	cb.consecutiveFailures = 0
+}
+
+// releaseTrial lets another trial operation through if the trial operation
+// in flight completed without telling whether the database is healthy
+func (cb *CircuitBreaker) releaseTrial() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == circuitHalfOpen {
+		cb.state = circuitOpen
+	}
+}
+
+func (cb *CircuitBreaker) recordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.consecutiveFailures++
+	if cb.state == circuitHalfOpen || cb.consecutiveFailures >= cb.cfg.FailureThreshold {
+		cb.state = circuitOpen
+		cb.openedAt = time.Now()
+	}
+}
+
+// IsTransientError checks if the error is caused by a temporary unavailability
+// of the database, e.g. network errors, timeouts or transient transaction errors.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if mongo.IsNetworkError(err) || mongo.IsTimeout(err) || errors.Is(err, mongo.ErrClientDisconnected) {
+		return true
+	}
+	var labeledErr mongo.LabeledError
+	return errors.As(err, &labeledErr) && labeledErr.HasErrorLabel(transientTransactionErrorLabel)
+}
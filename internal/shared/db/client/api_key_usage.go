@@ -0,0 +1,50 @@
+package dbclient
+
+import (
+	"context"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (db *Database) IncrementApiKeyUsage(
+	ctx context.Context, apiKey string, dayStart time.Time, requests, bytes int64,
+) (*dbmodel.ApiKeyUsageDocument, error) {
+	client := db.Db(ctx).Collection(dbmodel.ApiKeyUsageCollection)
+	update := bson.M{
+		"$inc": bson.M{"requests": requests, "bytes": bytes},
+		"$setOnInsert": bson.M{
+			"api_key":   apiKey,
+			"day_start": dayStart,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var usage dbmodel.ApiKeyUsageDocument
+	err := client.FindOneAndUpdate(
+		ctx, bson.M{"_id": dbmodel.BuildApiKeyUsageId(apiKey, dayStart)}, update, opts,
+	).Decode(&usage)
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+func (db *Database) FindApiKeyUsage(
+	ctx context.Context, apiKey string, fromDayStart time.Time,
+) ([]dbmodel.ApiKeyUsageDocument, error) {
+	client := db.Db(ctx).Collection(dbmodel.ApiKeyUsageCollection)
+	filter := bson.M{"api_key": apiKey, "day_start": bson.M{"$gte": fromDayStart}}
+	cursor, err := client.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "day_start", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var usage []dbmodel.ApiKeyUsageDocument
+	if err = cursor.All(ctx, &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
@@ -3,24 +3,124 @@ package dbclient
 import (
 	"context"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type Database struct {
 	DbName string
-	Client *mongo.Client
+	Client *MongoClient
 	Cfg    *config.DbConfig
+	// Clock is used instead of time.Now() so that tests can control the time
+	Clock clock.Clock
+	// FieldCipher encrypts the sensitive fields, nil if the field encryption
+	// is not configured
+	FieldCipher *db.FieldCipher
 }
 
-func NewMongoClient(ctx context.Context, cfg *config.DbConfig) (*mongo.Client, error) {
-	credential := options.Credential{
+func NewMongoClient(ctx context.Context, cfg *config.DbConfig) (*MongoClient, error) {
+	client, err := connectMongo(ctx, cfg, options.Credential{
 		Username: cfg.Username,
 		Password: cfg.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	m := WrapMongoClient(client)
+	m.cfg = cfg
+	return m, nil
+}
+
+func connectMongo(ctx context.Context, cfg *config.DbConfig, credential options.Credential) (*mongo.Client, error) {
+	clientOps := options.Client().ApplyURI(cfg.Address).SetAuth(credential).
+		SetPoolMonitor(newPoolMonitor(cfg.DbName))
+	if cfg.MaxPoolSize > 0 {
+		clientOps.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.MinPoolSize > 0 {
+		clientOps.SetMinPoolSize(cfg.MinPoolSize)
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		clientOps.SetMaxConnIdleTime(cfg.MaxConnIdleTime)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		clientOps.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+	if cfg.SocketTimeout > 0 {
+		clientOps.SetSocketTimeout(cfg.SocketTimeout)
+	}
+	if wc := newWriteConcern(cfg); wc != nil {
+		clientOps.SetWriteConcern(wc)
+	}
+	var slowQueries *slowQueryMonitor
+	if cfg.SlowQuery != nil {
+		slowQueries = newSlowQueryMonitor(cfg.SlowQuery, cfg.DbName)
+	}
+	clientOps.SetMonitor(newCommandMonitor(
+		db.CommandStatsOf(cfg.DbName), db.QueryShapesOf(cfg.DbName), slowQueries,
+	))
+	client, err := mongo.Connect(ctx, clientOps)
+	if err != nil {
+		return nil, err
+	}
+	if slowQueries != nil {
+		// The explain commands are sent through the monitored client
+		slowQueries.client.Store(client)
+	}
+	return client, nil
+}
+
+// newCommandMonitor records the duration and the failures of the commands
+// into the command stats of the db, and their query shapes for the index
+// report, then passes the commands to the slow query monitor if set
+func newCommandMonitor(
+	stats *db.CommandStats, shapes *db.QueryShapes, slowQueries *slowQueryMonitor,
+) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			shapes.Record(e.CommandName, e.Command)
+			if slowQueries != nil {
+				slowQueries.started(ctx, e)
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			stats.Record(e.Duration, false)
+			if slowQueries != nil {
+				slowQueries.finished(e.CommandFinishedEvent)
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			stats.Record(e.Duration, true)
+			if slowQueries != nil {
+				slowQueries.finished(e.CommandFinishedEvent)
+			}
+		},
+	}
+}
+
+// newPoolMonitor records the connection pool events as metrics
+func newPoolMonitor(dbName string) *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				metrics.RecordDbPoolConnections(dbName, 1)
+			case event.ConnectionClosed:
+				metrics.RecordDbPoolConnections(dbName, -1)
+			case event.GetSucceeded:
+				metrics.RecordDbPoolCheckedOut(dbName, 1)
+			case event.ConnectionReturned:
+				metrics.RecordDbPoolCheckedOut(dbName, -1)
+			case event.GetFailed:
+				metrics.RecordDbPoolCheckOutFailure(dbName, e.Reason)
+			}
+		},
 	}
-	clientOps := options.Client().ApplyURI(cfg.Address).SetAuth(credential)
-	return mongo.Connect(ctx, clientOps)
 }
 
 func (db *Database) Ping(ctx context.Context) error {
@@ -31,10 +131,11 @@ func (db *Database) Ping(ctx context.Context) error {
 	return nil
 }
 
-func New(ctx context.Context, client *mongo.Client, cfg *config.DbConfig) (*Database, error) {
+func New(ctx context.Context, client *MongoClient, cfg *config.DbConfig) (*Database, error) {
 	return &Database{
 		DbName: cfg.DbName,
 		Client: client,
 		Cfg:    cfg,
+		Clock:  clock.New(),
 	}, nil
 }
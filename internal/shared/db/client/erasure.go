@@ -0,0 +1,13 @@
+package dbclient
+
+import (
+	"context"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+)
+
+func (db *Database) InsertErasureRecord(ctx context.Context, record *dbmodel.ErasureRecordDocument) error {
+	client := db.Db(ctx).Collection(dbmodel.ErasureAuditCollection)
+	_, err := client.InsertOne(ctx, record)
+	return err
+}
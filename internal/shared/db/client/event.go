@@ -0,0 +1,13 @@
+package dbclient
+
+import (
+	"context"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+)
+
+func (db *Database) SaveEvent(ctx context.Context, event *dbmodel.EventDocument) error {
+	client := db.Db(ctx).Collection(dbmodel.EventsCollection)
+	_, err := client.InsertOne(ctx, event)
+	return err
+}
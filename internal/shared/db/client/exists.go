@@ -0,0 +1,26 @@
+package dbclient
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Exists checks whether a document of the collection matches the filter
+// without reading the document. Only the _id is projected, so that the query
+// is covered by the index of the filter if it includes the _id.
+func (db *Database) Exists(ctx context.Context, collection string, filter interface{}) (bool, error) {
+	client := db.Db(ctx).Collection(collection)
+	opts := options.FindOne().SetProjection(bson.M{"_id": 1})
+	err := client.FindOne(ctx, filter, opts).Err()
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
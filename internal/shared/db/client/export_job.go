@@ -0,0 +1,113 @@
+package dbclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	shareddb "github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (db *Database) InsertExportJob(ctx context.Context, job *dbmodel.ExportJobDocument) error {
+	client := db.Db(ctx).Collection(dbmodel.ExportJobsCollection)
+	_, err := client.InsertOne(ctx, job)
+	return err
+}
+
+func (db *Database) FindExportJob(ctx context.Context, id string) (*dbmodel.ExportJobDocument, error) {
+	client := db.Db(ctx).Collection(dbmodel.ExportJobsCollection)
+	var job dbmodel.ExportJobDocument
+	if err := client.FindOne(ctx, bson.M{"_id": id}).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &shareddb.NotFoundError{
+				Key:     id,
+				Message: "Export job not found",
+			}
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (db *Database) ClaimExportJob(
+	ctx context.Context, now, staleBefore time.Time,
+) (*dbmodel.ExportJobDocument, error) {
+	client := db.Db(ctx).Collection(dbmodel.ExportJobsCollection)
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": dbmodel.ExportJobPending},
+		bson.M{"status": dbmodel.ExportJobRunning, "heartbeat_at": bson.M{"$lt": staleBefore}},
+		// The jobs claimed before the heartbeat was recorded
+		bson.M{
+			"status":       dbmodel.ExportJobRunning,
+			"heartbeat_at": bson.M{"$exists": false},
+			"started_at":   bson.M{"$lt": staleBefore},
+		},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": dbmodel.ExportJobRunning, "started_at": now, "heartbeat_at": now},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job dbmodel.ExportJobDocument
+	if err := client.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (db *Database) RenewExportJobClaim(
+	ctx context.Context, id string, startedAt, now time.Time,
+) (bool, error) {
+	client := db.Db(ctx).Collection(dbmodel.ExportJobsCollection)
+	result, err := client.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": dbmodel.ExportJobRunning, "started_at": startedAt},
+		bson.M{"$set": bson.M{"heartbeat_at": now}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (db *Database) CompleteExportJob(
+	ctx context.Context, id string, startedAt time.Time, objectKey string, completedAt time.Time,
+) error {
+	return db.finishExportJob(ctx, id, startedAt, bson.M{
+		"status":       dbmodel.ExportJobCompleted,
+		"object_key":   objectKey,
+		"completed_at": completedAt,
+	})
+}
+
+func (db *Database) FailExportJob(
+	ctx context.Context, id string, startedAt time.Time, reason string, completedAt time.Time,
+) error {
+	return db.finishExportJob(ctx, id, startedAt, bson.M{
+		"status":       dbmodel.ExportJobFailed,
+		"error":        reason,
+		"completed_at": completedAt,
+	})
+}
+
+// finishExportJob updates the job unless it has been picked up again by
+// another worker in between, in which case the other worker finishes it
+func (db *Database) finishExportJob(ctx context.Context, id string, startedAt time.Time, fields bson.M) error {
+	client := db.Db(ctx).Collection(dbmodel.ExportJobsCollection)
+	_, err := client.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": dbmodel.ExportJobRunning, "started_at": startedAt},
+		bson.M{"$set": fields},
+	)
+	return err
+}
@@ -0,0 +1,75 @@
+package dbclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (db *Database) ReserveIdempotencyKey(
+	ctx context.Context, key, requestHash string, lockTimeout time.Duration,
+) (*dbmodel.IdempotencyKeyDocument, error) {
+	client := db.Db(ctx).Collection(dbmodel.IdempotencyKeysCollection)
+	now := db.Clock.Now()
+	_, err := client.InsertOne(ctx, &dbmodel.IdempotencyKeyDocument{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, err
+	}
+
+	var existing dbmodel.IdempotencyKeyDocument
+	if err := client.FindOne(ctx, bson.M{"_id": key}).Decode(&existing); err != nil {
+		// The key has expired in between, the retry will reserve it
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("idempotency key expired while being reserved")
+		}
+		return nil, err
+	}
+	if existing.Completed || existing.RequestHash != requestHash || now.Sub(existing.CreatedAt) < lockTimeout {
+		return &existing, nil
+	}
+	// The request holding the key has not completed within the lock timeout,
+	// the key is taken over unless another retry has taken it over already
+	result, err := client.UpdateOne(
+		ctx,
+		bson.M{"_id": key, "completed": false, "created_at": existing.CreatedAt},
+		bson.M{"$set": bson.M{"created_at": now}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result.ModifiedCount == 0 {
+		existing.CreatedAt = now
+		return &existing, nil
+	}
+	return nil, nil
+}
+
+func (db *Database) CompleteIdempotencyKey(
+	ctx context.Context, key string, statusCode int, contentType string, body []byte,
+) error {
+	client := db.Db(ctx).Collection(dbmodel.IdempotencyKeysCollection)
+	_, err := client.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$set": bson.M{
+		"completed":    true,
+		"status_code":  statusCode,
+		"content_type": contentType,
+		"body":         body,
+	}})
+	return err
+}
+
+func (db *Database) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	client := db.Db(ctx).Collection(dbmodel.IdempotencyKeysCollection)
+	_, err := client.DeleteOne(ctx, bson.M{"_id": key, "completed": false})
+	return err
+}
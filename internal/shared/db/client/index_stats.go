@@ -0,0 +1,45 @@
+package dbclient
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (db *Database) FindIndexStats(ctx context.Context) ([]dbmodel.IndexStatsDocument, error) {
+	database := db.Db(ctx)
+	collections, err := database.ListCollectionNames(ctx, bson.M{"type": "collection"})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(collections)
+
+	var stats []dbmodel.IndexStatsDocument
+	for _, collection := range collections {
+		if strings.HasPrefix(collection, "system.") {
+			continue
+		}
+		cursor, err := database.Collection(collection).Aggregate(
+			ctx, mongo.Pipeline{{{Key: "$indexStats", Value: bson.M{}}}},
+		)
+		if err != nil {
+			return nil, err
+		}
+		var collectionStats []dbmodel.IndexStatsDocument
+		if err := cursor.All(ctx, &collectionStats); err != nil {
+			return nil, err
+		}
+		sort.Slice(collectionStats, func(i, j int) bool {
+			return collectionStats[i].Name < collectionStats[j].Name
+		})
+		for i := range collectionStats {
+			collectionStats[i].Collection = collection
+		}
+		stats = append(stats, collectionStats...)
+	}
+	return stats, nil
+}
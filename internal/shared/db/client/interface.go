@@ -2,12 +2,16 @@ package dbclient
 
 import (
 	"context"
+	"time"
 
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 )
 
 type DBClient interface {
 	Ping(ctx context.Context) error
+	// Exists checks whether a document of the collection matches the filter
+	// without reading the document
+	Exists(ctx context.Context, collection string, filter interface{}) (bool, error)
 	// InsertPkAddressMappings inserts the btc public key and
 	// its corresponding btc addresses into the database.
 	InsertPkAddressMappings(
@@ -28,7 +32,82 @@ type DBClient interface {
 	FindPkMappingsByNativeSegwitAddress(
 		ctx context.Context, nativeSegwitAddresses []string,
 	) ([]*dbmodel.PkAddressMapping, error)
-	SaveUnprocessableMessage(ctx context.Context, messageBody, receipt string) error
+	// DeletePkAddressMappings deletes the btc addresses mapped to the btc
+	// public key and returns the number of mappings deleted
+	DeletePkAddressMappings(ctx context.Context, pkHex string) (int64, error)
+	// CountPkAddressMappings counts the mappings DeletePkAddressMappings
+	// would delete
+	CountPkAddressMappings(ctx context.Context, pkHex string) (int64, error)
+	SaveUnprocessableMessage(ctx context.Context, messageBody, receipt, reason string) error
 	FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error)
 	DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error
+	// SaveEvent appends the consumed queue message to the events archive
+	SaveEvent(ctx context.Context, event *dbmodel.EventDocument) error
+	// ReserveIdempotencyKey reserves the idempotency key for the request. The
+	// existing document is returned if the key is already reserved, either
+	// completed or in progress, or if it has been used for another request.
+	// A key still in progress after the lock timeout is taken over.
+	ReserveIdempotencyKey(
+		ctx context.Context, key, requestHash string, lockTimeout time.Duration,
+	) (*dbmodel.IdempotencyKeyDocument, error)
+	// CompleteIdempotencyKey stores the response of the request holding the key
+	CompleteIdempotencyKey(
+		ctx context.Context, key string, statusCode int, contentType string, body []byte,
+	) error
+	// DeleteIdempotencyKey releases the key of a request in progress, so that
+	// it can be retried
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+	// IncrementApiKeyUsage adds the usage to the one of the api key over the
+	// day and returns the resulting usage
+	IncrementApiKeyUsage(
+		ctx context.Context, apiKey string, dayStart time.Time, requests, bytes int64,
+	) (*dbmodel.ApiKeyUsageDocument, error)
+	// FindApiKeyUsage returns the daily usage of the api key since the given
+	// day, the most recent day first
+	FindApiKeyUsage(
+		ctx context.Context, apiKey string, fromDayStart time.Time,
+	) ([]dbmodel.ApiKeyUsageDocument, error)
+	InsertExportJob(ctx context.Context, job *dbmodel.ExportJobDocument) error
+	// FindExportJob returns NotFoundError if the job does not exist
+	FindExportJob(ctx context.Context, id string) (*dbmodel.ExportJobDocument, error)
+	// ClaimExportJob marks the oldest pending job, or a running job whose
+	// claim has not been renewed since staleBefore, as running and returns
+	// it. It returns nil if there is no job to run.
+	ClaimExportJob(ctx context.Context, now, staleBefore time.Time) (*dbmodel.ExportJobDocument, error)
+	// RenewExportJobClaim renews the claim of the job started at startedAt
+	// at now. It returns false if the job has been claimed again since then.
+	RenewExportJobClaim(ctx context.Context, id string, startedAt, now time.Time) (bool, error)
+	// CompleteExportJob marks the job started at startedAt as completed, it's
+	// a no-op if the job has been claimed again since then
+	CompleteExportJob(
+		ctx context.Context, id string, startedAt time.Time, objectKey string, completedAt time.Time,
+	) error
+	// FailExportJob marks the job started at startedAt as failed, it's a
+	// no-op if the job has been claimed again since then
+	FailExportJob(
+		ctx context.Context, id string, startedAt time.Time, reason string, completedAt time.Time,
+	) error
+	// InsertErasureRecord records the erasure of the off-chain data of a staker
+	InsertErasureRecord(ctx context.Context, record *dbmodel.ErasureRecordDocument) error
+	// AcquireLeaderLease acquires or renews the lease of the job until
+	// expiresAt. It returns false if the lease is held by another replica and
+	// has not expired at now.
+	AcquireLeaderLease(ctx context.Context, job, holder string, now, expiresAt time.Time) (bool, error)
+	// ReleaseLeaderLease releases the lease of the job if held by the holder
+	ReleaseLeaderLease(ctx context.Context, job, holder string) error
+	// StartScheduledJobRun records the start of a run of the job
+	StartScheduledJobRun(
+		ctx context.Context, name, holder string, trigger dbmodel.ScheduledJobTrigger, startedAt time.Time,
+	) error
+	// CompleteScheduledJobRun records the outcome of the run of the job, a
+	// failure if runErr is set
+	CompleteScheduledJobRun(
+		ctx context.Context, name string, runErr error, finishedAt time.Time, duration time.Duration,
+	) error
+	// FindScheduledJobRuns returns the status of the last run of each job,
+	// sorted by job name
+	FindScheduledJobRuns(ctx context.Context) ([]dbmodel.ScheduledJobDocument, error)
+	// FindIndexStats returns the usage of the indexes of all the collections,
+	// ordered by collection and index name
+	FindIndexStats(ctx context.Context) ([]dbmodel.IndexStatsDocument, error)
 }
@@ -0,0 +1,38 @@
+package dbclient
+
+import (
+	"context"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (db *Database) AcquireLeaderLease(
+	ctx context.Context, job, holder string, now, expiresAt time.Time,
+) (bool, error) {
+	client := db.Db(ctx).Collection(dbmodel.LeaderLeasesCollection)
+	// The lease is renewed by its holder or taken over once expired, the
+	// upsert of a lease held by another replica fails on the duplicate _id
+	filter := bson.M{"_id": job, "$or": bson.A{
+		bson.M{"holder": holder},
+		bson.M{"expires_at": bson.M{"$lte": now}},
+	}}
+	update := bson.M{"$set": bson.M{"holder": holder, "expires_at": expiresAt}}
+	_, err := client.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *Database) ReleaseLeaderLease(ctx context.Context, job, holder string) error {
+	client := db.Db(ctx).Collection(dbmodel.LeaderLeasesCollection)
+	_, err := client.DeleteOne(ctx, bson.M{"_id": job, "holder": holder})
+	return err
+}
@@ -0,0 +1,99 @@
+package dbclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/credentials"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/logging"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// dbLogger logs the db events, its level can be set apart from the other logs
+var dbLogger = logging.Module(config.LogModuleDb)
+
+// MongoClient is the mongo client shared by the db clients of a db. It's
+// replaced by a client authenticated with the new credentials when they are
+// rotated, the previous client being disconnected once its in-flight
+// operations are completed. The operations must hence not keep the client
+// returned by Current beyond their own duration.
+type MongoClient struct {
+	current atomic.Pointer[mongo.Client]
+	// cfg is nil if the client cannot be rotated
+	cfg *config.DbConfig
+}
+
+// WrapMongoClient wraps a connected client, which cannot be rotated
+func WrapMongoClient(client *mongo.Client) *MongoClient {
+	m := &MongoClient{}
+	m.current.Store(client)
+	return m
+}
+
+// Current returns the client authenticated with the current credentials
+func (m *MongoClient) Current() *mongo.Client {
+	return m.current.Load()
+}
+
+func (m *MongoClient) Database(name string, opts ...*options.DatabaseOptions) *mongo.Database {
+	return m.Current().Database(name, opts...)
+}
+
+func (m *MongoClient) StartSession(opts ...*options.SessionOptions) (mongo.Session, error) {
+	return m.Current().StartSession(opts...)
+}
+
+func (m *MongoClient) Ping(ctx context.Context, rp *readpref.ReadPref) error {
+	return m.Current().Ping(ctx, rp)
+}
+
+func (m *MongoClient) Disconnect(ctx context.Context) error {
+	return m.Current().Disconnect(ctx)
+}
+
+func (m *MongoClient) NumberSessionsInProgress() int {
+	return m.Current().NumberSessionsInProgress()
+}
+
+// Rotate connects with the new credentials and replaces the current client
+// once the new credentials are verified. The previous client is given up to
+// the drain timeout to complete its in-flight operations before being
+// disconnected.
+func (m *MongoClient) Rotate(
+	ctx context.Context, newCredentials credentials.Credentials, drainTimeout time.Duration,
+) error {
+	if m.cfg == nil {
+		return errors.New("mongo client cannot be rotated")
+	}
+	client, err := connectMongo(ctx, m.cfg, options.Credential{
+		Username: newCredentials.Username,
+		Password: newCredentials.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect with the new credentials: %w", err)
+	}
+	// The connections are established lazily, the ping authenticates one
+	if err := client.Ping(ctx, readpref.Primary()); err != nil {
+		_ = client.Disconnect(ctx)
+		return fmt.Errorf("failed to authenticate with the new credentials: %w", err)
+	}
+	previous := m.current.Swap(client)
+
+	go func() {
+		drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		defer cancel()
+		// Disconnect waits for the connections in use to be returned to the
+		// pool, up to the drain timeout
+		if err := previous.Disconnect(drainCtx); err != nil {
+			dbLogger.Warn().Err(err).Str("db", m.cfg.DbName).
+				Msg("previous mongo client disconnected before draining")
+		}
+	}()
+	return nil
+}
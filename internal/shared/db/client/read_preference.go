@@ -0,0 +1,48 @@
+package dbclient
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type readOnlyCtxKey struct{}
+
+// WithReadOnly marks the context as serving a read-only request, the reads
+// performed with it use the configured read preference and read concern.
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyCtxKey{}, true)
+}
+
+// IsReadOnly returns true if the context has been marked as read-only
+func IsReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyCtxKey{}).(bool)
+	return readOnly
+}
+
+// Db returns the database handle to be used with the given context.
+// Reads of read-only requests are routed according to the configured read
+// preference, everything else (writes and transactions) stays on the primary.
+func (db *Database) Db(ctx context.Context) *mongo.Database {
+	if !IsReadOnly(ctx) || db.Cfg == nil {
+		return db.Client.Database(db.DbName)
+	}
+	opts := options.Database()
+	if db.Cfg.ReadPreference != "" {
+		// The mode is validated when loading the config
+		mode, err := readpref.ModeFromString(db.Cfg.ReadPreference)
+		if err == nil {
+			rp, err := readpref.New(mode)
+			if err == nil {
+				opts.SetReadPreference(rp)
+			}
+		}
+	}
+	if db.Cfg.ReadConcern != "" {
+		opts.SetReadConcern(readconcern.New(readconcern.Level(db.Cfg.ReadConcern)))
+	}
+	return db.Client.Database(db.DbName, opts)
+}
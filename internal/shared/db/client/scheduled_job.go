@@ -0,0 +1,61 @@
+package dbclient
+
+import (
+	"context"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (db *Database) StartScheduledJobRun(
+	ctx context.Context, name, holder string, trigger dbmodel.ScheduledJobTrigger, startedAt time.Time,
+) error {
+	client := db.Db(ctx).Collection(dbmodel.ScheduledJobsCollection)
+	update := bson.M{"$set": bson.M{
+		"holder":          holder,
+		"last_trigger":    trigger,
+		"last_outcome":    dbmodel.ScheduledJobRunning,
+		"last_started_at": startedAt,
+	}}
+	_, err := client.UpdateOne(ctx, bson.M{"_id": name}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (db *Database) CompleteScheduledJobRun(
+	ctx context.Context, name string, runErr error, finishedAt time.Time, duration time.Duration,
+) error {
+	client := db.Db(ctx).Collection(dbmodel.ScheduledJobsCollection)
+	set := bson.M{
+		"last_outcome":     dbmodel.ScheduledJobSucceeded,
+		"last_error":       "",
+		"last_finished_at": finishedAt,
+		"last_duration_ms": duration.Milliseconds(),
+	}
+	count := "success_count"
+	if runErr != nil {
+		set["last_outcome"] = dbmodel.ScheduledJobFailed
+		set["last_error"] = runErr.Error()
+		count = "failure_count"
+	}
+	update := bson.M{"$set": set, "$inc": bson.M{count: 1}}
+	_, err := client.UpdateOne(ctx, bson.M{"_id": name}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (db *Database) FindScheduledJobRuns(ctx context.Context) ([]dbmodel.ScheduledJobDocument, error) {
+	client := db.Db(ctx).Collection(dbmodel.ScheduledJobsCollection)
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := client.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var runs []dbmodel.ScheduledJobDocument
+	if err := cursor.All(ctx, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
@@ -0,0 +1,134 @@
+package dbclient
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// The explain commands are detached from the slow command, hence they have
+// their own timeout
+const explainTimeout = 10 * time.Second
+
+// explainableCommands are the commands whose query plan can be captured
+var explainableCommands = map[string]bool{
+	"find":          true,
+	"aggregate":     true,
+	"count":         true,
+	"distinct":      true,
+	"update":        true,
+	"delete":        true,
+	"findAndModify": true,
+}
+
+// sessionCommandFields are set by the driver on the commands, they are not
+// accepted by the explain command
+var sessionCommandFields = map[string]bool{
+	"lsid":             true,
+	"txnNumber":        true,
+	"autocommit":       true,
+	"startTransaction": true,
+	"readConcern":      true,
+	"writeConcern":     true,
+}
+
+// slowQueryMonitor logs the db commands exceeding the configured threshold.
+// The query plan of a sample of the slow commands is captured by running the
+// explain command for them, so that the missing indexes can be spotted.
+type slowQueryMonitor struct {
+	cfg    *config.DbSlowQueryConfig
+	dbName string
+	client atomic.Pointer[mongo.Client]
+	// commands holds the explainable commands in flight, keyed by connection
+	// and request id, as the finished events do not carry the command
+	commands sync.Map
+}
+
+func newSlowQueryMonitor(cfg *config.DbSlowQueryConfig, dbName string) *slowQueryMonitor {
+	return &slowQueryMonitor{cfg: cfg, dbName: dbName}
+}
+
+func (m *slowQueryMonitor) started(_ context.Context, e *event.CommandStartedEvent) {
+	if m.cfg.ExplainSampleRate == 0 || !explainableCommands[e.CommandName] {
+		return
+	}
+	// The command buffer is owned by the driver
+	m.commands.Store(commandKey(e.ConnectionID, e.RequestID), append(bson.Raw(nil), e.Command...))
+}
+
+func (m *slowQueryMonitor) finished(e event.CommandFinishedEvent) {
+	stored, _ := m.commands.LoadAndDelete(commandKey(e.ConnectionID, e.RequestID))
+	if e.Duration < m.cfg.Threshold {
+		return
+	}
+	metrics.RecordDbSlowQuery(m.dbName, e.CommandName)
+	command, _ := stored.(bson.Raw)
+	collection := ""
+	if command != nil {
+		collection, _ = command.Index(0).Value().StringValueOK()
+	}
+	dbLogger.Warn().Str("db", e.DatabaseName).Str("command", e.CommandName).
+		Str("collection", collection).Dur("duration", e.Duration).
+		Msg("slow db command")
+
+	client := m.client.Load()
+	if command == nil || client == nil || rand.Float64() >= m.cfg.ExplainSampleRate {
+		return
+	}
+	go m.explain(client, e.DatabaseName, e.CommandName, collection, command)
+}
+
+// explain captures and logs the winning query plan of the command
+func (m *slowQueryMonitor) explain(
+	client *mongo.Client, dbName, commandName, collection string, command bson.Raw,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), explainTimeout)
+	defer cancel()
+
+	elements, err := command.Elements()
+	if err != nil {
+		dbLogger.Error().Err(err).Str("command", commandName).Msg("error while reading slow db command")
+		return
+	}
+	explained := make(bson.D, 0, len(elements))
+	for _, element := range elements {
+		key := element.Key()
+		if strings.HasPrefix(key, "$") || sessionCommandFields[key] {
+			continue
+		}
+		explained = append(explained, bson.E{Key: key, Value: element.Value()})
+	}
+
+	var plan bson.M
+	err = client.Database(dbName).RunCommand(ctx, bson.D{
+		{Key: "explain", Value: explained},
+		{Key: "verbosity", Value: "queryPlanner"},
+	}).Decode(&plan)
+	if err != nil {
+		dbLogger.Error().Err(err).Str("command", commandName).Str("collection", collection).
+			Msg("error while explaining slow db command")
+		return
+	}
+	// Some aggregations report their plan per stage, the whole output is
+	// logged for them
+	var winningPlan interface{} = plan
+	if queryPlanner, ok := plan["queryPlanner"].(bson.M); ok {
+		winningPlan = queryPlanner["winningPlan"]
+	}
+	dbLogger.Warn().Str("db", dbName).Str("command", commandName).Str("collection", collection).
+		Interface("winningPlan", winningPlan).Msg("slow db command query plan")
+}
+
+func commandKey(connectionID string, requestID int64) string {
+	return connectionID + "/" + strconv.FormatInt(requestID, 10)
+}
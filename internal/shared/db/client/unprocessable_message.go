@@ -8,10 +8,10 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (db *Database) SaveUnprocessableMessage(ctx context.Context, messageBody, receipt string) error {
-	unprocessableMsgClient := db.Client.Database(db.DbName).Collection(dbmodel.V1UnprocessableMsgCollection)
+func (db *Database) SaveUnprocessableMessage(ctx context.Context, messageBody, receipt, reason string) error {
+	unprocessableMsgClient := db.Db(ctx).Collection(dbmodel.V1UnprocessableMsgCollection)
 
-	_, err := unprocessableMsgClient.InsertOne(ctx, dbmodel.NewUnprocessableMessageDocument(messageBody, receipt))
+	_, err := unprocessableMsgClient.InsertOne(ctx, dbmodel.NewUnprocessableMessageDocument(messageBody, receipt, reason))
 	if err != nil {
 		return err
 	}
@@ -20,7 +20,7 @@ func (db *Database) SaveUnprocessableMessage(ctx context.Context, messageBody, r
 }
 
 func (db *Database) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error) {
-	client := db.Client.Database(db.DbName).Collection(dbmodel.V1UnprocessableMsgCollection)
+	client := db.Db(ctx).Collection(dbmodel.V1UnprocessableMsgCollection)
 	filter := bson.M{}
 	options := options.FindOptions{}
 
@@ -39,7 +39,7 @@ func (db *Database) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.Un
 }
 
 func (db *Database) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
-	unprocessableMsgClient := db.Client.Database(db.DbName).Collection(dbmodel.V1UnprocessableMsgCollection)
+	unprocessableMsgClient := db.Db(ctx).Collection(dbmodel.V1UnprocessableMsgCollection)
 	filter := bson.M{"receipt": Receipt}
 	_, err := unprocessableMsgClient.DeleteOne(ctx, filter)
 	return err
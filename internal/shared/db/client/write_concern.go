@@ -0,0 +1,57 @@
+package dbclient
+
+import (
+	"strconv"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// newWriteConcern returns the configured write concern, nil if none of the
+// write concern settings is set
+func newWriteConcern(cfg *config.DbConfig) *writeconcern.WriteConcern {
+	if cfg.WriteConcern == "" && cfg.WriteConcernJournal == nil && cfg.WriteConcernTimeout == 0 {
+		return nil
+	}
+	wc := &writeconcern.WriteConcern{
+		Journal:  cfg.WriteConcernJournal,
+		WTimeout: cfg.WriteConcernTimeout,
+	}
+	if cfg.WriteConcern == config.WriteConcernMajority {
+		wc.W = config.WriteConcernMajority
+	} else if cfg.WriteConcern != "" {
+		// The value is validated when loading the config
+		w, err := strconv.Atoi(cfg.WriteConcern)
+		if err == nil {
+			wc.W = w
+		}
+	}
+	return wc
+}
+
+// SessionOptions returns the options of the sessions the transactions run in
+func (db *Database) SessionOptions() *options.SessionOptions {
+	opts := options.Session()
+	if db.Cfg != nil && db.Cfg.CausalConsistency != nil {
+		opts.SetCausalConsistency(*db.Cfg.CausalConsistency)
+	}
+	return opts
+}
+
+// TransactionOptions returns the read and write concerns of the transactions,
+// the client settings are inherited for the ones not configured.
+func (db *Database) TransactionOptions() *options.TransactionOptions {
+	opts := options.Transaction()
+	if db.Cfg == nil {
+		return opts
+	}
+	if db.Cfg.TransactionReadConcern != "" {
+		opts.SetReadConcern(readconcern.New(readconcern.Level(db.Cfg.TransactionReadConcern)))
+	}
+	if wc := newWriteConcern(db.Cfg); wc != nil {
+		opts.SetWriteConcern(wc)
+	}
+	return opts
+}
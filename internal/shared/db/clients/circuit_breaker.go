@@ -0,0 +1,691 @@
+package dbclients
+
+import (
+	"context"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v2dbclient "github.com/babylonlabs-io/staking-api-service/internal/v2/db/client"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+)
+
+// The staking db clients are guarded by the circuit breaker, each operation
+// is executed through it so that the API requests and the queue consumers
+// fail fast alike while the db is unavailable. Only the reads are retried on
+// transient errors, the streams are not as their callbacks would be called
+// again.
+
+type breakerDBClient struct {
+	client dbclient.DBClient
+	cb     *db.CircuitBreaker
+}
+
+type breakerV1DBClient struct {
+	*breakerDBClient
+	client v1dbclient.V1DBClient
+}
+
+type breakerV2DBClient struct {
+	*breakerDBClient
+	client v2dbclient.V2DBClient
+}
+
+func withCircuitBreaker(
+	cb *db.CircuitBreaker, shared dbclient.DBClient, v1 v1dbclient.V1DBClient, v2 v2dbclient.V2DBClient,
+) (dbclient.DBClient, v1dbclient.V1DBClient, v2dbclient.V2DBClient) {
+	return &breakerDBClient{client: shared, cb: cb},
+		&breakerV1DBClient{breakerDBClient: &breakerDBClient{client: v1, cb: cb}, client: v1},
+		&breakerV2DBClient{breakerDBClient: &breakerDBClient{client: v2, cb: cb}, client: v2}
+}
+
+func (c *breakerDBClient) AcquireLeaderLease(ctx context.Context, job string, holder string, now time.Time, expiresAt time.Time) (bool, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (bool, error) {
+		return c.client.AcquireLeaderLease(ctx, job, holder, now, expiresAt)
+	})
+}
+
+func (c *breakerDBClient) ClaimExportJob(ctx context.Context, now time.Time, staleBefore time.Time) (*dbmodel.ExportJobDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (*dbmodel.ExportJobDocument, error) {
+		return c.client.ClaimExportJob(ctx, now, staleBefore)
+	})
+}
+
+func (c *breakerDBClient) CompleteExportJob(ctx context.Context, id string, startedAt time.Time, objectKey string, completedAt time.Time) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.CompleteExportJob(ctx, id, startedAt, objectKey, completedAt)
+	})
+}
+
+func (c *breakerDBClient) CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.CompleteIdempotencyKey(ctx, key, statusCode, contentType, body)
+	})
+}
+
+func (c *breakerDBClient) CompleteScheduledJobRun(ctx context.Context, name string, runErr error, finishedAt time.Time, duration time.Duration) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.CompleteScheduledJobRun(ctx, name, runErr, finishedAt, duration)
+	})
+}
+
+func (c *breakerDBClient) CountPkAddressMappings(ctx context.Context, pkHex string) (int64, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (int64, error) {
+		return c.client.CountPkAddressMappings(ctx, pkHex)
+	})
+}
+
+func (c *breakerDBClient) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.DeleteIdempotencyKey(ctx, key)
+	})
+}
+
+func (c *breakerDBClient) DeletePkAddressMappings(ctx context.Context, pkHex string) (int64, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (int64, error) {
+		return c.client.DeletePkAddressMappings(ctx, pkHex)
+	})
+}
+
+func (c *breakerDBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.DeleteUnprocessableMessage(ctx, Receipt)
+	})
+}
+
+func (c *breakerDBClient) Exists(ctx context.Context, collection string, filter interface{}) (bool, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (bool, error) {
+		return c.client.Exists(ctx, collection, filter)
+	})
+}
+
+func (c *breakerDBClient) FailExportJob(ctx context.Context, id string, startedAt time.Time, reason string, completedAt time.Time) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.FailExportJob(ctx, id, startedAt, reason, completedAt)
+	})
+}
+
+func (c *breakerDBClient) FindApiKeyUsage(ctx context.Context, apiKey string, fromDayStart time.Time) ([]dbmodel.ApiKeyUsageDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]dbmodel.ApiKeyUsageDocument, error) {
+		return c.client.FindApiKeyUsage(ctx, apiKey, fromDayStart)
+	})
+}
+
+func (c *breakerDBClient) FindExportJob(ctx context.Context, id string) (*dbmodel.ExportJobDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*dbmodel.ExportJobDocument, error) {
+		return c.client.FindExportJob(ctx, id)
+	})
+}
+
+func (c *breakerDBClient) FindIndexStats(ctx context.Context) ([]dbmodel.IndexStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]dbmodel.IndexStatsDocument, error) {
+		return c.client.FindIndexStats(ctx)
+	})
+}
+
+func (c *breakerDBClient) FindPkMappingsByNativeSegwitAddress(ctx context.Context, nativeSegwitAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]*dbmodel.PkAddressMapping, error) {
+		return c.client.FindPkMappingsByNativeSegwitAddress(ctx, nativeSegwitAddresses)
+	})
+}
+
+func (c *breakerDBClient) FindPkMappingsByTaprootAddress(ctx context.Context, taprootAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]*dbmodel.PkAddressMapping, error) {
+		return c.client.FindPkMappingsByTaprootAddress(ctx, taprootAddresses)
+	})
+}
+
+func (c *breakerDBClient) FindScheduledJobRuns(ctx context.Context) ([]dbmodel.ScheduledJobDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]dbmodel.ScheduledJobDocument, error) {
+		return c.client.FindScheduledJobRuns(ctx)
+	})
+}
+
+func (c *breakerDBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]dbmodel.UnprocessableMessageDocument, error) {
+		return c.client.FindUnprocessableMessages(ctx)
+	})
+}
+
+func (c *breakerDBClient) IncrementApiKeyUsage(ctx context.Context, apiKey string, dayStart time.Time, requests int64, bytes int64) (*dbmodel.ApiKeyUsageDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (*dbmodel.ApiKeyUsageDocument, error) {
+		return c.client.IncrementApiKeyUsage(ctx, apiKey, dayStart, requests, bytes)
+	})
+}
+
+func (c *breakerDBClient) InsertErasureRecord(ctx context.Context, record *dbmodel.ErasureRecordDocument) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.InsertErasureRecord(ctx, record)
+	})
+}
+
+func (c *breakerDBClient) InsertExportJob(ctx context.Context, job *dbmodel.ExportJobDocument) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.InsertExportJob(ctx, job)
+	})
+}
+
+func (c *breakerDBClient) InsertPkAddressMappings(ctx context.Context, stakerPkHex string, taproot string, nativeSigwitOdd string, nativeSigwitEven string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.InsertPkAddressMappings(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
+	})
+}
+
+func (c *breakerDBClient) Ping(ctx context.Context) error {
+	return c.cb.Execute(ctx, true, func() error {
+		return c.client.Ping(ctx)
+	})
+}
+
+func (c *breakerDBClient) ReleaseLeaderLease(ctx context.Context, job string, holder string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.ReleaseLeaderLease(ctx, job, holder)
+	})
+}
+
+func (c *breakerDBClient) RenewExportJobClaim(ctx context.Context, id string, startedAt time.Time, now time.Time) (bool, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (bool, error) {
+		return c.client.RenewExportJobClaim(ctx, id, startedAt, now)
+	})
+}
+
+func (c *breakerDBClient) ReserveIdempotencyKey(ctx context.Context, key string, requestHash string, lockTimeout time.Duration) (*dbmodel.IdempotencyKeyDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (*dbmodel.IdempotencyKeyDocument, error) {
+		return c.client.ReserveIdempotencyKey(ctx, key, requestHash, lockTimeout)
+	})
+}
+
+func (c *breakerDBClient) SaveEvent(ctx context.Context, event *dbmodel.EventDocument) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SaveEvent(ctx, event)
+	})
+}
+
+func (c *breakerDBClient) SaveUnprocessableMessage(ctx context.Context, messageBody string, receipt string, reason string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SaveUnprocessableMessage(ctx, messageBody, receipt, reason)
+	})
+}
+
+func (c *breakerDBClient) StartScheduledJobRun(ctx context.Context, name string, holder string, trigger dbmodel.ScheduledJobTrigger, startedAt time.Time) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.StartScheduledJobRun(ctx, name, holder, trigger, startedAt)
+	})
+}
+
+func (c *breakerV1DBClient) ArchiveDelegations(ctx context.Context, states []types.DelegationState, updatedBefore int64, limit int64) (int64, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (int64, error) {
+		return c.client.ArchiveDelegations(ctx, states, updatedBefore, limit)
+	})
+}
+
+func (c *breakerV1DBClient) MigrateDelegations(ctx context.Context, limit int64) (int64, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (int64, error) {
+		return c.client.MigrateDelegations(ctx, limit)
+	})
+}
+
+func (c *breakerV1DBClient) ArchivedDelegationExists(ctx context.Context, stakingTxHashHex string) (bool, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (bool, error) {
+		return c.client.ArchivedDelegationExists(ctx, stakingTxHashHex)
+	})
+}
+
+func (c *breakerV1DBClient) BulkSaveActiveStakingDelegations(ctx context.Context, delegations []*v1dbmodel.DelegationDocument) (*db.BulkWriteResult, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (*db.BulkWriteResult, error) {
+		return c.client.BulkSaveActiveStakingDelegations(ctx, delegations)
+	})
+}
+
+func (c *breakerV1DBClient) BulkTransitionToUnbondedState(ctx context.Context, stakingTxHashHexes []string, eligiblePreviousState []types.DelegationState) (*db.BulkWriteResult, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (*db.BulkWriteResult, error) {
+		return c.client.BulkTransitionToUnbondedState(ctx, stakingTxHashHexes, eligiblePreviousState)
+	})
+}
+
+func (c *breakerV1DBClient) BulkTransitionToWithdrawnState(ctx context.Context, stakingTxHashHexes []string, withdrawalTxs map[string]*v1dbmodel.WithdrawalTransaction) (*db.BulkWriteResult, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (*db.BulkWriteResult, error) {
+		return c.client.BulkTransitionToWithdrawnState(ctx, stakingTxHashHexes, withdrawalTxs)
+	})
+}
+
+func (c *breakerV1DBClient) CheckDelegationExistByStakerPk(ctx context.Context, address string, extraFilter *v1dbclient.DelegationFilter) (bool, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (bool, error) {
+		return c.client.CheckDelegationExistByStakerPk(ctx, address, extraFilter)
+	})
+}
+
+func (c *breakerV1DBClient) CountDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *v1dbclient.DelegationFilter) (int64, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (int64, error) {
+		return c.client.CountDelegationsByStakerPk(ctx, stakerPk, extraFilter)
+	})
+}
+
+func (c *breakerV1DBClient) CountStakerPartnerAttributions(ctx context.Context, stakerPkHex string) (int64, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (int64, error) {
+		return c.client.CountStakerPartnerAttributions(ctx, stakerPkHex)
+	})
+}
+
+func (c *breakerV1DBClient) CountStakerStats(ctx context.Context, estimated bool) (int64, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (int64, error) {
+		return c.client.CountStakerStats(ctx, estimated)
+	})
+}
+
+func (c *breakerV1DBClient) DelegationExists(ctx context.Context, stakingTxHashHex string) (bool, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (bool, error) {
+		return c.client.DelegationExists(ctx, stakingTxHashHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindAllFinalityProviderStats(ctx context.Context) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+		return c.client.FindAllFinalityProviderStats(ctx)
+	})
+}
+
+func (c *breakerV1DBClient) FindArchivedDelegationByTxHashHex(ctx context.Context, stakingTxHashHex string) (*v1dbmodel.DelegationDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.DelegationDocument, error) {
+		return c.client.FindArchivedDelegationByTxHashHex(ctx, stakingTxHashHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindBtcReorgByBlockHash(ctx context.Context, blockHash string) (*v1dbmodel.BtcReorgDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.BtcReorgDocument, error) {
+		return c.client.FindBtcReorgByBlockHash(ctx, blockHash)
+	})
+}
+
+func (c *breakerV1DBClient) FindDelegationByAnyTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.DelegationDocument, error) {
+		return c.client.FindDelegationByAnyTxHashHex(ctx, txHashHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.DelegationDocument, error) {
+		return c.client.FindDelegationByTxHashHex(ctx, txHashHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindDelegationChanges(ctx context.Context, cursor string) ([]v1dbmodel.DelegationDocument, string, error) {
+	var delegations []v1dbmodel.DelegationDocument
+	var nextCursor string
+	err := c.cb.Execute(ctx, true, func() error {
+		var err error
+		delegations, nextCursor, err = c.client.FindDelegationChanges(ctx, cursor)
+		return err
+	})
+	return delegations, nextCursor, err
+}
+
+func (c *breakerV1DBClient) FindDelegationLabels(ctx context.Context, apiKey string, stakingTxHashHex string) (*v1dbmodel.DelegationLabelsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.DelegationLabelsDocument, error) {
+		return c.client.FindDelegationLabels(ctx, apiKey, stakingTxHashHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindDelegationLabelsByLabel(ctx context.Context, apiKey string, label string, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationLabelsDocument], error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*db.DbResultMap[v1dbmodel.DelegationLabelsDocument], error) {
+		return c.client.FindDelegationLabelsByLabel(ctx, apiKey, label, paginationToken)
+	})
+}
+
+func (c *breakerV1DBClient) FindDelegationMilestones(ctx context.Context, stakingTxHashHex string) ([]v1dbmodel.DelegationAuditTrailDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]v1dbmodel.DelegationAuditTrailDocument, error) {
+		return c.client.FindDelegationMilestones(ctx, stakingTxHashHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *v1dbclient.DelegationFilter, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+		return c.client.FindDelegationsByStakerPk(ctx, stakerPk, extraFilter, paginationToken)
+	})
+}
+
+func (c *breakerV1DBClient) FindExpiredDelegations(ctx context.Context, height uint64, limit int64) ([]v1dbmodel.DelegationDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]v1dbmodel.DelegationDocument, error) {
+		return c.client.FindExpiredDelegations(ctx, height, limit)
+	})
+}
+
+func (c *breakerV1DBClient) FindFinalityProviderPkHexesWithStats(ctx context.Context, finalityProviderPkHex []string) ([]string, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]string, error) {
+		return c.client.FindFinalityProviderPkHexesWithStats(ctx, finalityProviderPkHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindFinalityProviderStats(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error) {
+		return c.client.FindFinalityProviderStats(ctx, paginationToken)
+	})
+}
+
+func (c *breakerV1DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx context.Context, finalityProviderPkHex []string) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+		return c.client.FindFinalityProviderStatsByFinalityProviderPkHex(ctx, finalityProviderPkHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindFpCommissionHistory(ctx context.Context, fpPkHex string) ([]v1dbmodel.FpCommissionChangeDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]v1dbmodel.FpCommissionChangeDocument, error) {
+		return c.client.FindFpCommissionHistory(ctx, fpPkHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindHourlyOverallStats(ctx context.Context, at time.Time) (*v1dbmodel.HourlyOverallStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.HourlyOverallStatsDocument, error) {
+		return c.client.FindHourlyOverallStats(ctx, at)
+	})
+}
+
+func (c *breakerV1DBClient) FindPartnerStats(ctx context.Context, partnerId string) (*v1dbmodel.PartnerStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.PartnerStatsDocument, error) {
+		return c.client.FindPartnerStats(ctx, partnerId)
+	})
+}
+
+func (c *breakerV1DBClient) FindRecentDelegationsByFinalityProviderPk(ctx context.Context, fpPkHex string, limit int64) ([]v1dbmodel.DelegationDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]v1dbmodel.DelegationDocument, error) {
+		return c.client.FindRecentDelegationsByFinalityProviderPk(ctx, fpPkHex, limit)
+	})
+}
+
+func (c *breakerV1DBClient) FindStakerStateSummaries(ctx context.Context, stakerPkHex string) ([]v1dbmodel.StakerStateSummaryDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]v1dbmodel.StakerStateSummaryDocument, error) {
+		return c.client.FindStakerStateSummaries(ctx, stakerPkHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindStakerStatsByStakerPkHexes(ctx context.Context, stakerPkHexes []string) ([]*v1dbmodel.StakerStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]*v1dbmodel.StakerStatsDocument, error) {
+		return c.client.FindStakerStatsByStakerPkHexes(ctx, stakerPkHexes)
+	})
+}
+
+func (c *breakerV1DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error) {
+		return c.client.FindTopStakersByTvl(ctx, paginationToken)
+	})
+}
+
+func (c *breakerV1DBClient) FindUnbondingByTxHashHex(ctx context.Context, unbondingTxHashHex string) (*v1dbmodel.UnbondingDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.UnbondingDocument, error) {
+		return c.client.FindUnbondingByTxHashHex(ctx, unbondingTxHashHex)
+	})
+}
+
+func (c *breakerV1DBClient) FindUnbondingSignature(ctx context.Context, signatureHex string) (*v1dbmodel.UnbondingSignatureDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.UnbondingSignatureDocument, error) {
+		return c.client.FindUnbondingSignature(ctx, signatureHex)
+	})
+}
+
+func (c *breakerV1DBClient) GetLatestBtcInfo(ctx context.Context) (*v1dbmodel.BtcInfo, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.BtcInfo, error) {
+		return c.client.GetLatestBtcInfo(ctx)
+	})
+}
+
+func (c *breakerV1DBClient) GetMaterializedOverallStats(ctx context.Context) (*v1dbmodel.MaterializedOverallStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.MaterializedOverallStatsDocument, error) {
+		return c.client.GetMaterializedOverallStats(ctx)
+	})
+}
+
+func (c *breakerV1DBClient) GetOrCreateStatsLock(ctx context.Context, stakingTxHashHex string, state string) (*v1dbmodel.StatsLockDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (*v1dbmodel.StatsLockDocument, error) {
+		return c.client.GetOrCreateStatsLock(ctx, stakingTxHashHex, state)
+	})
+}
+
+func (c *breakerV1DBClient) GetOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.OverallStatsDocument, error) {
+		return c.client.GetOverallStats(ctx)
+	})
+}
+
+func (c *breakerV1DBClient) GetStakerStats(ctx context.Context, stakerPkHex string) (*v1dbmodel.StakerStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.StakerStatsDocument, error) {
+		return c.client.GetStakerStats(ctx, stakerPkHex)
+	})
+}
+
+func (c *breakerV1DBClient) IncrementFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, amount uint64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.IncrementFinalityProviderStats(ctx, stakingTxHashHex, fpPkHex, amount)
+	})
+}
+
+func (c *breakerV1DBClient) IncrementOverallStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.IncrementOverallStats(ctx, stakingTxHashHex, stakerPkHex, amount)
+	})
+}
+
+func (c *breakerV1DBClient) IncrementOverflowStats(ctx context.Context, stakingTxHashHex string, amount uint64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.IncrementOverflowStats(ctx, stakingTxHashHex, amount)
+	})
+}
+
+func (c *breakerV1DBClient) IncrementStakerStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.IncrementStakerStats(ctx, stakingTxHashHex, stakerPkHex, amount)
+	})
+}
+
+func (c *breakerV1DBClient) RecomputeFinalityProviderStats(ctx context.Context) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+		return c.client.RecomputeFinalityProviderStats(ctx)
+	})
+}
+
+func (c *breakerV1DBClient) RecomputeOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v1dbmodel.OverallStatsDocument, error) {
+		return c.client.RecomputeOverallStats(ctx)
+	})
+}
+
+func (c *breakerV1DBClient) RefreshMaterializedOverallStats(ctx context.Context) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.RefreshMaterializedOverallStats(ctx)
+	})
+}
+
+func (c *breakerV1DBClient) RemoveStakerPartnerAttributions(ctx context.Context, stakerPkHex string) (int64, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (int64, error) {
+		return c.client.RemoveStakerPartnerAttributions(ctx, stakerPkHex)
+	})
+}
+
+func (c *breakerV1DBClient) ReplaceFinalityProviderStats(ctx context.Context, stats []*v1dbmodel.FinalityProviderStatsDocument) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.ReplaceFinalityProviderStats(ctx, stats)
+	})
+}
+
+func (c *breakerV1DBClient) ReplaceOverallStats(ctx context.Context, stats *v1dbmodel.OverallStatsDocument) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.ReplaceOverallStats(ctx, stats)
+	})
+}
+
+func (c *breakerV1DBClient) RollbackReorgedDelegation(ctx context.Context, stakingTxHashHex string) (types.DelegationState, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (types.DelegationState, error) {
+		return c.client.RollbackReorgedDelegation(ctx, stakingTxHashHex)
+	})
+}
+
+func (c *breakerV1DBClient) SaveActiveStakingDelegation(ctx context.Context, stakingTxHashHex string, stakerPkHex string, fpPkHex string, stakingTxHex string, amount uint64, startHeight uint64, timelock uint64, outputIndex uint64, startTimestamp int64, isOverflow bool, state types.DelegationState) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SaveActiveStakingDelegation(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, state)
+	})
+}
+
+func (c *breakerV1DBClient) SaveBtcReorg(ctx context.Context, blockHash string, blockHeight uint64, stakingTxHashHexes []string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SaveBtcReorg(ctx, blockHash, blockHeight, stakingTxHashHexes)
+	})
+}
+
+func (c *breakerV1DBClient) SaveDelegationMilestone(ctx context.Context, stakingTxHashHex string, milestone v1dbmodel.DelegationMilestone, txHashHex string, blockHeight uint64, timestamp int64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SaveDelegationMilestone(ctx, stakingTxHashHex, milestone, txHashHex, blockHeight, timestamp)
+	})
+}
+
+func (c *breakerV1DBClient) SaveFpCommissionChange(ctx context.Context, fpPkHex string, sequence int64, commission string, previousCommission string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SaveFpCommissionChange(ctx, fpPkHex, sequence, commission, previousCommission)
+	})
+}
+
+func (c *breakerV1DBClient) SaveTimeLockExpireCheck(ctx context.Context, stakingTxHashHex string, expireHeight uint64, txType string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SaveTimeLockExpireCheck(ctx, stakingTxHashHex, expireHeight, txType)
+	})
+}
+
+func (c *breakerV1DBClient) SaveUnbondingTx(ctx context.Context, stakingTxHashHex string, unbondingTxHashHex string, txHex string, signatureHex string, psbtBase64 string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SaveUnbondingTx(ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex, psbtBase64)
+	})
+}
+
+func (c *breakerV1DBClient) ScanDelegationsPaginated(ctx context.Context, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+		return c.client.ScanDelegationsPaginated(ctx, paginationToken)
+	})
+}
+
+func (c *breakerV1DBClient) SetDelegationLabels(ctx context.Context, apiKey string, stakingTxHashHex string, labels []string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SetDelegationLabels(ctx, apiKey, stakingTxHashHex, labels)
+	})
+}
+
+func (c *breakerV1DBClient) SetDelegationPartner(ctx context.Context, stakingTxHashHex string, partnerId string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SetDelegationPartner(ctx, stakingTxHashHex, partnerId)
+	})
+}
+
+func (c *breakerV1DBClient) StreamDelegationsByFinalityProviderPk(ctx context.Context, fpPkHex string, fn func(*v1dbmodel.DelegationDocument) error) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.StreamDelegationsByFinalityProviderPk(ctx, fpPkHex, fn)
+	})
+}
+
+func (c *breakerV1DBClient) StreamDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *v1dbclient.DelegationFilter, paginationToken string, fn func(*v1dbmodel.DelegationDocument) error) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.StreamDelegationsByStakerPk(ctx, stakerPk, extraFilter, paginationToken, fn)
+	})
+}
+
+func (c *breakerV1DBClient) SubtractFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, amount uint64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SubtractFinalityProviderStats(ctx, stakingTxHashHex, fpPkHex, amount)
+	})
+}
+
+func (c *breakerV1DBClient) SubtractOverallStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SubtractOverallStats(ctx, stakingTxHashHex, stakerPkHex, amount)
+	})
+}
+
+func (c *breakerV1DBClient) SubtractStakerStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SubtractStakerStats(ctx, stakingTxHashHex, stakerPkHex, amount)
+	})
+}
+
+func (c *breakerV1DBClient) TransitionPendingToActiveState(ctx context.Context, fromStartHeight uint64, toStartHeight uint64) ([]string, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() ([]string, error) {
+		return c.client.TransitionPendingToActiveState(ctx, fromStartHeight, toStartHeight)
+	})
+}
+
+func (c *breakerV1DBClient) TransitionToUnbondedState(ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.TransitionToUnbondedState(ctx, stakingTxHashHex, eligiblePreviousState)
+	})
+}
+
+func (c *breakerV1DBClient) TransitionToUnbondingState(ctx context.Context, txHashHex string, startHeight uint64, timelock uint64, outputIndex uint64, txHex string, unbondingTxHashHex string, startTimestamp int64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.TransitionToUnbondingState(ctx, txHashHex, startHeight, timelock, outputIndex, txHex, unbondingTxHashHex, startTimestamp)
+	})
+}
+
+func (c *breakerV1DBClient) TransitionToWithdrawnState(ctx context.Context, txHashHex string, withdrawalTx *v1dbmodel.WithdrawalTransaction) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.TransitionToWithdrawnState(ctx, txHashHex, withdrawalTx)
+	})
+}
+
+func (c *breakerV1DBClient) UpsertLatestBtcInfo(ctx context.Context, height uint64, babylonHeight uint64, confirmedTvl uint64, unconfirmedTvl uint64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.UpsertLatestBtcInfo(ctx, height, babylonHeight, confirmedTvl, unconfirmedTvl)
+	})
+}
+
+func (c *breakerV1DBClient) WaitForDelegationState(ctx context.Context, stakingTxHashHex string, states []types.DelegationState) (*v1dbmodel.DelegationDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, false, func() (*v1dbmodel.DelegationDocument, error) {
+		return c.client.WaitForDelegationState(ctx, stakingTxHashHex, states)
+	})
+}
+
+func (c *breakerV2DBClient) FindCovenantSignatures(ctx context.Context, stakingTxHashHexes []string) ([]*v2dbmodel.V2CovenantSignaturesDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]*v2dbmodel.V2CovenantSignaturesDocument, error) {
+		return c.client.FindCovenantSignatures(ctx, stakingTxHashHexes)
+	})
+}
+
+func (c *breakerV2DBClient) GetFinalityProvidersStats(ctx context.Context, fpPkHexes []string) ([]*v2dbmodel.V2FinalityProviderStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() ([]*v2dbmodel.V2FinalityProviderStatsDocument, error) {
+		return c.client.GetFinalityProvidersStats(ctx, fpPkHexes)
+	})
+}
+
+func (c *breakerV2DBClient) GetMaterializedOverallStats(ctx context.Context) (*v2dbmodel.V2MaterializedOverallStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v2dbmodel.V2MaterializedOverallStatsDocument, error) {
+		return c.client.GetMaterializedOverallStats(ctx)
+	})
+}
+
+func (c *breakerV2DBClient) GetOverallStats(ctx context.Context) (*v2dbmodel.V2OverallStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v2dbmodel.V2OverallStatsDocument, error) {
+		return c.client.GetOverallStats(ctx)
+	})
+}
+
+func (c *breakerV2DBClient) GetStakerStats(ctx context.Context, stakerPKHex string) (*v2dbmodel.V2StakerStatsDocument, error) {
+	return db.ExecuteWithResult(ctx, c.cb, true, func() (*v2dbmodel.V2StakerStatsDocument, error) {
+		return c.client.GetStakerStats(ctx, stakerPKHex)
+	})
+}
+
+func (c *breakerV2DBClient) IncrementSlashedStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, fpPkHexes []string, slashedAmount uint64) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.IncrementSlashedStats(ctx, stakingTxHashHex, stakerPkHex, fpPkHexes, slashedAmount)
+	})
+}
+
+func (c *breakerV2DBClient) RefreshMaterializedOverallStats(ctx context.Context) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.RefreshMaterializedOverallStats(ctx)
+	})
+}
+
+func (c *breakerV2DBClient) SaveCovenantSignature(ctx context.Context, stakingTxHashHex string, covenantBtcPkHex string) error {
+	return c.cb.Execute(ctx, false, func() error {
+		return c.client.SaveCovenantSignature(ctx, stakingTxHashHex, covenantBtcPkHex)
+	})
+}
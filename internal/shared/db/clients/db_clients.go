@@ -5,16 +5,16 @@ import (
 
 	indexerdbclient "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/client"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
 	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
 	v2dbclient "github.com/babylonlabs-io/staking-api-service/internal/v2/db/client"
 	"github.com/rs/zerolog/log"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type DbClients struct {
-	StakingMongoClient *mongo.Client
-	IndexerMongoClient *mongo.Client
+	StakingMongoClient *dbclient.MongoClient
+	IndexerMongoClient *dbclient.MongoClient
 	SharedDBClient     dbclient.DBClient
 	V1DBClient         v1dbclient.V1DBClient
 	V2DBClient         v2dbclient.V2DBClient
@@ -43,6 +43,14 @@ func New(ctx context.Context, cfg *config.Config) (*DbClients, error) {
 		return nil, err
 	}
 
+	var sharedDBClient dbclient.DBClient = dbClient
+	var v1DBClient v1dbclient.V1DBClient = v1dbClient
+	var v2DBClient v2dbclient.V2DBClient = v2dbClient
+	// The staking db operations are guarded by the circuit breaker if set
+	if cb := db.NewCircuitBreaker(cfg.StakingDb.CircuitBreaker); cb != nil {
+		sharedDBClient, v1DBClient, v2DBClient = withCircuitBreaker(cb, dbClient, v1dbClient, v2dbClient)
+	}
+
 	indexerMongoClient, err := dbclient.NewMongoClient(ctx, cfg.IndexerDb)
 	if err != nil {
 		return nil, err
@@ -57,9 +65,9 @@ func New(ctx context.Context, cfg *config.Config) (*DbClients, error) {
 	dbClients := DbClients{
 		StakingMongoClient: stakingMongoClient,
 		IndexerMongoClient: indexerMongoClient,
-		SharedDBClient:     dbClient,
-		V1DBClient:         v1dbClient,
-		V2DBClient:         v2dbClient,
+		SharedDBClient:     sharedDBClient,
+		V1DBClient:         v1DBClient,
+		V2DBClient:         v2DBClient,
 		IndexerDBClient:    indexerDbClient,
 	}
 
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"sync"
+	"time"
+)
+
+// commandStats holds the command stats of each db, keyed by db name
+var commandStats sync.Map
+
+// CommandStats accumulates the duration and the failures of the commands sent
+// to a db since the previous snapshot
+type CommandStats struct {
+	mu            sync.Mutex
+	commands      int64
+	failures      int64
+	totalDuration time.Duration
+}
+
+// CommandStatsOf returns the command stats of the given db
+func CommandStatsOf(dbName string) *CommandStats {
+	stats, _ := commandStats.LoadOrStore(dbName, &CommandStats{})
+	return stats.(*CommandStats)
+}
+
+// Record adds a completed command to the stats
+func (s *CommandStats) Record(duration time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands++
+	s.totalDuration += duration
+	if failed {
+		s.failures++
+	}
+}
+
+// Snapshot returns the number of commands since the previous snapshot along
+// with their average duration and failure rate, then resets the stats
+func (s *CommandStats) Snapshot() (commands int64, avgDuration time.Duration, failureRate float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	commands = s.commands
+	if commands > 0 {
+		avgDuration = s.totalDuration / time.Duration(commands)
+		failureRate = float64(s.failures) / float64(commands)
+	}
+	s.commands, s.failures, s.totalDuration = 0, 0, 0
+	return commands, avgDuration, failureRate
+}
@@ -1,5 +1,13 @@
 package db
 
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
 // DuplicateKeyError is an error type for duplicate key errors
 type DuplicateKeyError struct {
 	Key     string
@@ -15,6 +23,32 @@ func IsDuplicateKeyError(err error) bool {
 	return ok
 }
 
+// IsDuplicateKeyOnField returns whether the driver error is a duplicate key
+// error of the unique index on the field, rather than of another unique index
+// of the collection
+func IsDuplicateKeyOnField(err error, field string) bool {
+	var writeErr mongo.WriteException
+	if !errors.As(err, &writeErr) {
+		return false
+	}
+	for _, e := range writeErr.WriteErrors {
+		if !mongo.IsDuplicateKeyError(e) {
+			continue
+		}
+		if e.Raw != nil {
+			if _, lookupErr := e.Raw.LookupErr("keyPattern", field); lookupErr == nil {
+				return true
+			}
+		}
+		// The servers not reporting the key pattern name the index in the
+		// message, e.g. "index: unbonding_tx_hash_hex_1 dup key"
+		if strings.Contains(e.Message, "index: "+field+"_") {
+			return true
+		}
+	}
+	return false
+}
+
 // InvalidPaginationTokenError is an error type for invalid pagination token errors
 type InvalidPaginationTokenError struct {
 	Message string
@@ -43,3 +77,26 @@ func IsNotFoundError(err error) bool {
 	_, ok := err.(*NotFoundError)
 	return ok
 }
+
+// UnavailableError is returned when the database is temporarily unavailable,
+// the operation can be retried after RetryAfter.
+type UnavailableError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *UnavailableError) Error() string {
+	if e.Err != nil {
+		return "database is temporarily unavailable: " + e.Err.Error()
+	}
+	return "database is temporarily unavailable"
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+func IsUnavailableError(err error) bool {
+	var unavailableErr *UnavailableError
+	return errors.As(err, &unavailableErr)
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+)
+
+// FieldCipher encrypts the sensitive fields of the documents with AES-256-GCM.
+// The encrypted values are prefixed by the id of their key, so that they can
+// still be decrypted once the active key is rotated.
+type FieldCipher struct {
+	keys          map[string]cipher.AEAD
+	activeKeyId   string
+	blindIndexKey []byte
+}
+
+// NewFieldCipher returns nil if the field encryption is not configured
+func NewFieldCipher(cfg *config.FieldEncryptionConfig) (*FieldCipher, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	keys := make(map[string]cipher.AEAD, len(cfg.Keys))
+	for id, key := range cfg.Keys {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field encryption key %s: %w", id, err)
+		}
+		block, err := aes.NewCipher(decoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field encryption key %s: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		keys[id] = aead
+	}
+	blindIndexKey, err := base64.StdEncoding.DecodeString(cfg.BlindIndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field encryption blind index key: %w", err)
+	}
+	return &FieldCipher{
+		keys:          keys,
+		activeKeyId:   cfg.ActiveKeyId,
+		blindIndexKey: blindIndexKey,
+	}, nil
+}
+
+// Encrypt encrypts the value with the active key. The associated data, such
+// as the id of the document, must be given again to decrypt the value, which
+// prevents moving an encrypted value to another document.
+func (c *FieldCipher) Encrypt(value, associatedData string) (string, error) {
+	aead := c.keys[c.activeKeyId]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(associatedData))
+	return c.activeKeyId + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *FieldCipher) Decrypt(encrypted, associatedData string) (string, error) {
+	keyId, encoded, found := strings.Cut(encrypted, ":")
+	if !found {
+		return "", fmt.Errorf("invalid encrypted field")
+	}
+	aead, ok := c.keys[keyId]
+	if !ok {
+		return "", fmt.Errorf("unknown field encryption key %s", keyId)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted field")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	value, err := aead.Open(nil, nonce, ciphertext, []byte(associatedData))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt field with key %s: %w", keyId, err)
+	}
+	return string(value), nil
+}
+
+// BlindIndex returns the HMAC of the value, which the encrypted fields looked
+// up by value are queried by instead of their value
+func (c *FieldCipher) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, c.blindIndexKey)
+	// Writing into a hash never returns an error
+	_, _ = mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,20 @@
+package dbmodel
+
+import (
+	"fmt"
+	"time"
+)
+
+// ApiKeyUsageDocument is the usage of an api key over a UTC day, summed over
+// all the instances
+type ApiKeyUsageDocument struct {
+	Id       string    `bson:"_id"` // api key name + day
+	ApiKey   string    `bson:"api_key"`
+	DayStart time.Time `bson:"day_start"` // TTL index
+	Requests int64     `bson:"requests"`
+	Bytes    int64     `bson:"bytes"`
+}
+
+func BuildApiKeyUsageId(apiKey string, dayStart time.Time) string {
+	return fmt.Sprintf("%s:%s", apiKey, dayStart.UTC().Format(time.DateOnly))
+}
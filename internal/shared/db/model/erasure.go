@@ -0,0 +1,19 @@
+package dbmodel
+
+import "time"
+
+// ErasureRecordDocument is the audit record of an erasure of the off-chain
+// data associated to a staker. The staker is only recorded by the hash of its
+// public key, so that the record itself doesn't keep the association.
+type ErasureRecordDocument struct {
+	Id           string `bson:"_id"`
+	StakerPkHash string `bson:"staker_pk_hash"`
+	// PartnerAttributions is the number of delegations whose partner
+	// attribution has been removed
+	PartnerAttributions int64 `bson:"partner_attributions"`
+	// AddressMappings is the number of address mappings removed
+	AddressMappings int64 `bson:"address_mappings"`
+	// RequestedBy is the client IP of the admin request
+	RequestedBy string    `bson:"requested_by"`
+	ErasedAt    time.Time `bson:"erased_at"`
+}
@@ -0,0 +1,27 @@
+package dbmodel
+
+import (
+	"time"
+)
+
+// The outcome of the processing of a consumed queue message
+const (
+	EventOutcomeProcessed     = "processed"
+	EventOutcomeRequeued      = "requeued"
+	EventOutcomeUnprocessable = "unprocessable"
+)
+
+// EventDocument is an archived queue message along with the outcome of its
+// processing. A message is archived once per processing attempt.
+type EventDocument struct {
+	QueueName        string    `bson:"queue_name"`
+	EventType        *int      `bson:"event_type,omitempty"`
+	StakingTxHashHex string    `bson:"staking_tx_hash_hex,omitempty"`
+	Payload          string    `bson:"payload"`
+	Attempt          int32     `bson:"attempt"`
+	Outcome          string    `bson:"outcome"`
+	StatusCode       int       `bson:"status_code,omitempty"`
+	Error            string    `bson:"error,omitempty"`
+	ReceivedAt       time.Time `bson:"received_at"` // TTL index
+	ProcessedAt      time.Time `bson:"processed_at"`
+}
@@ -0,0 +1,44 @@
+package dbmodel
+
+import (
+	"time"
+)
+
+type ExportJobType string
+
+const (
+	// ExportFinalityProviderDelegations exports all the delegations of a
+	// finality provider
+	ExportFinalityProviderDelegations ExportJobType = "finality_provider_delegations"
+	// ExportFinalityProvidersStats exports the current stats of all the
+	// finality providers
+	ExportFinalityProvidersStats ExportJobType = "finality_providers_stats"
+)
+
+type ExportJobStatus string
+
+const (
+	ExportJobPending   ExportJobStatus = "pending"
+	ExportJobRunning   ExportJobStatus = "running"
+	ExportJobCompleted ExportJobStatus = "completed"
+	ExportJobFailed    ExportJobStatus = "failed"
+)
+
+// ExportJobDocument is an asynchronous export, picked up by any instance
+// running the export worker
+type ExportJobDocument struct {
+	Id                    string          `bson:"_id"`
+	Type                  ExportJobType   `bson:"type"`
+	FinalityProviderPkHex string          `bson:"finality_provider_pk_hex,omitempty"`
+	Status                ExportJobStatus `bson:"status"`
+	// Attempts is the number of times the job has been picked up
+	Attempts    int       `bson:"attempts"`
+	ObjectKey   string    `bson:"object_key,omitempty"`
+	Error       string    `bson:"error,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"` // TTL index
+	StartedAt   time.Time `bson:"started_at,omitempty"`
+	CompletedAt time.Time `bson:"completed_at,omitempty"`
+	// HeartbeatAt is renewed while the job is running, the job is picked up
+	// again by another worker once it's older than the job timeout
+	HeartbeatAt time.Time `bson:"heartbeat_at,omitempty"`
+}
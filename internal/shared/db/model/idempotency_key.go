@@ -0,0 +1,22 @@
+package dbmodel
+
+import (
+	"time"
+)
+
+// IdempotencyKeyDocument is the response of a write request, replayed to the
+// retries of the request carrying the same idempotency key. The response is
+// not set while the request is in progress.
+type IdempotencyKeyDocument struct {
+	// Key is the idempotency key scoped by the method and the path of the
+	// request
+	Key string `bson:"_id"`
+	// RequestHash identifies the request body, a key cannot be reused for
+	// another request
+	RequestHash string    `bson:"request_hash"`
+	Completed   bool      `bson:"completed"`
+	StatusCode  int       `bson:"status_code,omitempty"`
+	ContentType string    `bson:"content_type,omitempty"`
+	Body        []byte    `bson:"body,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"` // TTL index
+}
@@ -0,0 +1,20 @@
+package dbmodel
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// IndexStatsDocument is the usage of an index reported by $indexStats, the
+// accesses are counted by the mongod serving the command since its start or
+// the creation of the index
+type IndexStatsDocument struct {
+	Collection string `bson:"-"`
+	Name       string `bson:"name"`
+	Key        bson.D `bson:"key"`
+	Accesses   struct {
+		Ops   int64     `bson:"ops"`
+		Since time.Time `bson:"since"`
+	} `bson:"accesses"`
+}
@@ -0,0 +1,13 @@
+package dbmodel
+
+import (
+	"time"
+)
+
+// LeaderLeaseDocument is the lease of the replica running a scheduled job,
+// the other replicas skip the job until the lease expires
+type LeaderLeaseDocument struct {
+	Job       string    `bson:"_id"`
+	Holder    string    `bson:"holder"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
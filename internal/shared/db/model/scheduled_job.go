@@ -0,0 +1,37 @@
+package dbmodel
+
+import (
+	"time"
+)
+
+type ScheduledJobOutcome string
+
+const (
+	ScheduledJobRunning   ScheduledJobOutcome = "running"
+	ScheduledJobSucceeded ScheduledJobOutcome = "succeeded"
+	ScheduledJobFailed    ScheduledJobOutcome = "failed"
+)
+
+type ScheduledJobTrigger string
+
+const (
+	// ScheduledJobTriggerSchedule is a run on the schedule of the job
+	ScheduledJobTriggerSchedule ScheduledJobTrigger = "schedule"
+	// ScheduledJobTriggerManual is a run triggered through the admin api
+	ScheduledJobTriggerManual ScheduledJobTrigger = "manual"
+)
+
+// ScheduledJobDocument is the status of the last run of a scheduled job,
+// whichever replica ran it, along with the outcomes of all its runs
+type ScheduledJobDocument struct {
+	Name           string              `bson:"_id"`
+	Holder         string              `bson:"holder"`
+	LastTrigger    ScheduledJobTrigger `bson:"last_trigger"`
+	LastOutcome    ScheduledJobOutcome `bson:"last_outcome"`
+	LastError      string              `bson:"last_error,omitempty"`
+	LastStartedAt  time.Time           `bson:"last_started_at"`
+	LastFinishedAt time.Time           `bson:"last_finished_at,omitempty"`
+	LastDurationMs int64               `bson:"last_duration_ms"`
+	SuccessCount   int64               `bson:"success_count"`
+	FailureCount   int64               `bson:"failure_count"`
+}
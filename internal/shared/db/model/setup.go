@@ -2,6 +2,7 @@ package dbmodel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -16,21 +17,53 @@ import (
 const (
 	// Shared
 	PkAddressMappingsCollection = "pk_address_mappings"
+	EventsCollection            = "events"
+	IdempotencyKeysCollection   = "idempotency_keys"
+	ApiKeyUsageCollection       = "api_key_usage"
+	ExportJobsCollection        = "export_jobs"
+	ErasureAuditCollection      = "erasure_audit"
+	LeaderLeasesCollection      = "leader_leases"
+	ScheduledJobsCollection     = "scheduled_jobs"
 	// V1
-	V1StatsLockCollection             = "stats_lock"
-	V1OverallStatsCollection          = "overall_stats"
-	V1FinalityProviderStatsCollection = "finality_providers_stats"
-	V1StakerStatsCollection           = "staker_stats"
-	V1DelegationCollection            = "delegations"
-	V1TimeLockCollection              = "timelock_queue"
-	V1UnbondingCollection             = "unbonding_queue"
-	V1BtcInfoCollection               = "btc_info"
-	V1UnprocessableMsgCollection      = "unprocessable_messages"
+	V1StatsLockCollection                = "stats_lock"
+	V1OverallStatsCollection             = "overall_stats"
+	V1FinalityProviderStatsCollection    = "finality_providers_stats"
+	V1StakerStatsCollection              = "staker_stats"
+	V1DelegationCollection               = "delegations"
+	V1TimeLockCollection                 = "timelock_queue"
+	V1UnbondingCollection                = "unbonding_queue"
+	V1BtcInfoCollection                  = "btc_info"
+	V1UnprocessableMsgCollection         = "unprocessable_messages"
+	V1BtcReorgCollection                 = "btc_reorgs"
+	V1MaterializedOverallStatsCollection = "overall_stats_materialized"
+	V1DelegationAuditTrailCollection     = "delegation_audit_trail"
+	V1CountersCollection                 = "counters"
+	V1UnbondingSignaturesCollection      = "unbonding_signatures"
+	V1FpCommissionHistoryCollection      = "finality_provider_commission_history"
+	V1HourlyOverallStatsCollection       = "overall_stats_hourly"
+	V1DelegationArchiveCollection        = "delegations_archive"
+	V1DelegationLabelsCollection         = "delegation_labels"
+	V1DelegationTombstonesCollection     = "delegation_tombstones"
 	// V2
-	V2StatsLockCollection             = "v2_stats_lock"
-	V2OverallStatsCollection          = "v2_overall_stats"
-	V2FinalityProviderStatsCollection = "v2_finality_providers_stats"
-	V2StakerStatsCollection           = "v2_staker_stats"
+	V2StatsLockCollection                = "v2_stats_lock"
+	V2OverallStatsCollection             = "v2_overall_stats"
+	V2FinalityProviderStatsCollection    = "v2_finality_providers_stats"
+	V2StakerStatsCollection              = "v2_staker_stats"
+	V2MaterializedOverallStatsCollection = "v2_overall_stats_materialized"
+	V2CovenantSignaturesCollection       = "v2_covenant_signatures"
+)
+
+// HourlyStatsRetention is the retention of the hourly overall stats, which
+// covers the longest stats delta window
+const HourlyStatsRetention = 8 * 24 * time.Hour
+
+const (
+	eventsTTLIndexName       = "received_at_ttl"
+	idempotencyTTLIndexName  = "created_at_ttl"
+	apiKeyUsageTTLIndexName  = "day_start_ttl"
+	exportJobsTTLIndexName   = "created_at_ttl"
+	hourlyStatsTTLIndexName  = "hour_ttl"
+	indexOptionsConflictCode = 85
 )
 
 type index struct {
@@ -45,6 +78,13 @@ var collections = map[string][]index{
 		{Indexes: map[string]int{"native_segwit_odd": 1}, Unique: true},
 		{Indexes: map[string]int{"native_segwit_even": 1}, Unique: true},
 	},
+	EventsCollection:          {{Indexes: map[string]int{"staking_tx_hash_hex": 1}, Unique: false}},
+	IdempotencyKeysCollection: {{Indexes: map[string]int{}}},
+	ApiKeyUsageCollection:     {{Indexes: map[string]int{"api_key": 1}, Unique: false}},
+	ExportJobsCollection:      {{Indexes: map[string]int{"status": 1}, Unique: false}},
+	ErasureAuditCollection:    {{Indexes: map[string]int{"staker_pk_hash": 1}, Unique: false}},
+	LeaderLeasesCollection:    {{Indexes: map[string]int{}}},
+	ScheduledJobsCollection:   {{Indexes: map[string]int{}}},
 	// V1
 	V1StatsLockCollection:             {{Indexes: map[string]int{}}},
 	V1OverallStatsCollection:          {{Indexes: map[string]int{}}},
@@ -52,16 +92,47 @@ var collections = map[string][]index{
 	V1StakerStatsCollection:           {{Indexes: map[string]int{"active_tvl": -1}, Unique: false}},
 	V1DelegationCollection: {
 		{Indexes: map[string]int{"staker_pk_hex": 1, "staking_tx.start_height": -1, "_id": 1}, Unique: false},
+		{Indexes: map[string]int{"staking_value": 1}, Unique: false},
+		{Indexes: map[string]int{"staking_tx.start_timestamp": 1}, Unique: false},
+		{Indexes: map[string]int{"unbonding_tx.tx_hash_hex": 1}, Unique: false},
+		{Indexes: map[string]int{"withdrawal_tx.tx_hash_hex": 1}, Unique: false},
+		{Indexes: map[string]int{"change_seq": 1}, Unique: false},
+		{Indexes: map[string]int{"partner_id": 1}, Unique: false},
+		{Indexes: map[string]int{"partner_id_index": 1}, Unique: false},
+		{Indexes: map[string]int{"finality_provider_pk_hex": 1}, Unique: false},
+		{Indexes: map[string]int{"updated_at": 1}, Unique: false},
+		{Indexes: map[string]int{"schema_version": 1}, Unique: false},
+		{Indexes: map[string]int{"expiry_height": 1}, Unique: false},
+	},
+	V1TimeLockCollection:                 {{Indexes: map[string]int{"expire_height": 1}, Unique: false}},
+	V1UnbondingCollection:                {{Indexes: map[string]int{"unbonding_tx_hash_hex": 1}, Unique: true}},
+	V1UnprocessableMsgCollection:         {{Indexes: map[string]int{}}},
+	V1BtcInfoCollection:                  {{Indexes: map[string]int{}}},
+	V1BtcReorgCollection:                 {{Indexes: map[string]int{"block_height": -1}, Unique: false}},
+	V1MaterializedOverallStatsCollection: {{Indexes: map[string]int{}}},
+	V1DelegationAuditTrailCollection:     {{Indexes: map[string]int{"staking_tx_hash_hex": 1}, Unique: false}},
+	V1CountersCollection:                 {{Indexes: map[string]int{}}},
+	V1UnbondingSignaturesCollection:      {{Indexes: map[string]int{}}},
+	V1FpCommissionHistoryCollection:      {{Indexes: map[string]int{"finality_provider_pk_hex": 1}, Unique: false}},
+	V1HourlyOverallStatsCollection:       {{Indexes: map[string]int{}}},
+	V1DelegationTombstonesCollection:     {{Indexes: map[string]int{"change_seq": 1}, Unique: false}},
+	V1DelegationLabelsCollection: {
+		{Indexes: map[string]int{"api_key": 1, "labels": 1}, Unique: false},
+		{Indexes: map[string]int{"api_key": 1, "label_indexes": 1}, Unique: false},
+	},
+	V1DelegationArchiveCollection: {
+		{Indexes: map[string]int{"staker_pk_hex": 1, "staking_tx.start_height": -1, "_id": 1}, Unique: false},
+		{Indexes: map[string]int{"partner_id": 1}, Unique: false},
+		{Indexes: map[string]int{"partner_id_index": 1}, Unique: false},
+		{Indexes: map[string]int{"finality_provider_pk_hex": 1}, Unique: false},
 	},
-	V1TimeLockCollection:         {{Indexes: map[string]int{"expire_height": 1}, Unique: false}},
-	V1UnbondingCollection:        {{Indexes: map[string]int{"unbonding_tx_hash_hex": 1}, Unique: true}},
-	V1UnprocessableMsgCollection: {{Indexes: map[string]int{}}},
-	V1BtcInfoCollection:          {{Indexes: map[string]int{}}},
 	// V2
-	V2StatsLockCollection:             {{Indexes: map[string]int{}}},
-	V2StakerStatsCollection:           {{Indexes: map[string]int{}}},
-	V2FinalityProviderStatsCollection: {{Indexes: map[string]int{"active_tvl": -1}, Unique: false}},
-	V2OverallStatsCollection:          {{Indexes: map[string]int{}}},
+	V2StatsLockCollection:                {{Indexes: map[string]int{}}},
+	V2StakerStatsCollection:              {{Indexes: map[string]int{}}},
+	V2FinalityProviderStatsCollection:    {{Indexes: map[string]int{"active_tvl": -1}, Unique: false}},
+	V2OverallStatsCollection:             {{Indexes: map[string]int{}}},
+	V2MaterializedOverallStatsCollection: {{Indexes: map[string]int{}}},
+	V2CovenantSignaturesCollection:       {{Indexes: map[string]int{}}},
 }
 
 func Setup(ctx context.Context, cfg *config.Config) error {
@@ -93,10 +164,71 @@ func Setup(ctx context.Context, cfg *config.Config) error {
 		}
 	}
 
+	if cfg.EventArchive != nil && cfg.EventArchive.Retention > 0 {
+		createTTLIndex(ctx, database, EventsCollection, eventsTTLIndexName, "received_at", cfg.EventArchive.Retention)
+	}
+	if cfg.Idempotency != nil {
+		createTTLIndex(
+			ctx, database, IdempotencyKeysCollection, idempotencyTTLIndexName, "created_at", cfg.Idempotency.Ttl,
+		)
+	}
+	if cfg.ApiKeys != nil && cfg.ApiKeys.Retention > 0 {
+		createTTLIndex(
+			ctx, database, ApiKeyUsageCollection, apiKeyUsageTTLIndexName, "day_start", cfg.ApiKeys.Retention,
+		)
+	}
+	if cfg.Exports != nil {
+		createTTLIndex(
+			ctx, database, ExportJobsCollection, exportJobsTTLIndexName, "created_at", cfg.Exports.Retention,
+		)
+	}
+	if cfg.StatsRefresher != nil {
+		createTTLIndex(
+			ctx, database, V1HourlyOverallStatsCollection, hourlyStatsTTLIndexName, "hour", HourlyStatsRetention,
+		)
+	}
+
 	log.Info().Msg("Collections and Indexes created successfully.")
 	return nil
 }
 
+// createTTLIndex expires the documents of the collection once the retention
+// has elapsed since their time field. The expiry of an existing TTL index is
+// updated if the retention has changed.
+func createTTLIndex(
+	ctx context.Context, database *mongo.Database, collectionName, indexName, field string, retention time.Duration,
+) {
+	expireAfterSeconds := int32(retention.Seconds())
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: field, Value: 1}},
+		Options: options.Index().
+			SetName(indexName).
+			SetExpireAfterSeconds(expireAfterSeconds),
+	}
+	_, err := database.Collection(collectionName).Indexes().CreateOne(ctx, index)
+	if err == nil {
+		log.Debug().Msg("TTL index created successfully on collection: " + collectionName)
+		return
+	}
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.Code != indexOptionsConflictCode {
+		log.Error().Err(err).Msg("Failed to create TTL index on collection: " + collectionName)
+		return
+	}
+	err = database.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collectionName},
+		{Key: "index", Value: bson.D{
+			{Key: "name", Value: indexName},
+			{Key: "expireAfterSeconds", Value: expireAfterSeconds},
+		}},
+	}).Err()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to update TTL index on collection: " + collectionName)
+		return
+	}
+	log.Debug().Msg("TTL index updated successfully on collection: " + collectionName)
+}
+
 func createCollection(ctx context.Context, database *mongo.Database, collectionName string) {
 	// Check if the collection already exists.
 	if _, err := database.Collection(collectionName).Indexes().CreateOne(ctx, mongo.IndexModel{}); err != nil {
@@ -3,11 +3,14 @@ package dbmodel
 type UnprocessableMessageDocument struct {
 	MessageBody string `bson:"message_body"`
 	Receipt     string `bson:"receipt"`
+	// Reason is the reason the message could not be processed
+	Reason string `bson:"reason,omitempty"`
 }
 
-func NewUnprocessableMessageDocument(messageBody, receipt string) *UnprocessableMessageDocument {
+func NewUnprocessableMessageDocument(messageBody, receipt, reason string) *UnprocessableMessageDocument {
 	return &UnprocessableMessageDocument{
 		MessageBody: messageBody,
 		Receipt:     receipt,
+		Reason:      reason,
 	}
 }
@@ -54,10 +54,20 @@ func FindWithPagination[T any](
 	if err != nil {
 		return nil, err
 	}
+	return DecodeWithPagination(ctx, cursor, limit, paginationKeyBuilder)
+}
+
+// DecodeWithPagination decodes the documents of the cursor into the result
+// map. The cursor is expected to return up to one more document than the
+// limit, to check if there are more results.
+func DecodeWithPagination[T any](
+	ctx context.Context, cursor *mongo.Cursor, limit int64,
+	paginationKeyBuilder func(T) (string, error),
+) (*DbResultMap[T], error) {
 	defer cursor.Close(ctx)
 
 	var result []T
-	if err = cursor.All(ctx, &result); err != nil {
+	if err := cursor.All(ctx, &result); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// maxQueryShapes bounds the distinct query shapes tracked per db, the shapes
+// observed once the bound is reached are not tracked
+const maxQueryShapes = 1000
+
+// queryShapes holds the observed query shapes of each db, keyed by db name
+var queryShapes sync.Map
+
+// QueryShape is the collection along with the fields a query filters and
+// sorts on, regardless of their values
+type QueryShape struct {
+	Collection string
+	// Filter holds the filtered fields in alphabetical order
+	Filter []string
+	// Sort holds the sort fields in the sort order
+	Sort []string
+}
+
+type ObservedQueryShape struct {
+	QueryShape
+	Count int64
+}
+
+// QueryShapes counts the query shapes of the commands sent to a db since the
+// start of the process
+type QueryShapes struct {
+	mu     sync.Mutex
+	shapes map[string]*ObservedQueryShape
+}
+
+// QueryShapesOf returns the observed query shapes of the given db
+func QueryShapesOf(dbName string) *QueryShapes {
+	shapes, _ := queryShapes.LoadOrStore(dbName, &QueryShapes{shapes: make(map[string]*ObservedQueryShape)})
+	return shapes.(*QueryShapes)
+}
+
+// Record counts the query shape of the command, the commands which do not
+// query a collection are ignored
+func (s *QueryShapes) Record(commandName string, command bson.Raw) {
+	shape, ok := ShapeOfCommand(commandName, command)
+	if !ok {
+		return
+	}
+	key := shape.Collection + "|" + strings.Join(shape.Filter, ",") + "|" + strings.Join(shape.Sort, ",")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	observed, ok := s.shapes[key]
+	if !ok {
+		if len(s.shapes) >= maxQueryShapes {
+			return
+		}
+		observed = &ObservedQueryShape{QueryShape: shape}
+		s.shapes[key] = observed
+	}
+	observed.Count++
+}
+
+// Snapshot returns the observed query shapes, the most frequent first
+func (s *QueryShapes) Snapshot() []ObservedQueryShape {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := make([]ObservedQueryShape, 0, len(s.shapes))
+	for _, observed := range s.shapes {
+		snapshot = append(snapshot, *observed)
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Count != snapshot[j].Count {
+			return snapshot[i].Count > snapshot[j].Count
+		}
+		return snapshot[i].Collection < snapshot[j].Collection
+	})
+	return snapshot
+}
+
+// SupportedBy tells whether an index with the given key fields, in the key
+// order, can serve the query instead of a collection scan, that is its first
+// field is filtered on, or sorted on first for the queries without filter.
+func (shape QueryShape) SupportedBy(indexFields []string) bool {
+	if len(indexFields) == 0 {
+		return false
+	}
+	for _, field := range shape.Filter {
+		if field == indexFields[0] {
+			return true
+		}
+	}
+	return len(shape.Filter) == 0 && len(shape.Sort) > 0 && shape.Sort[0] == indexFields[0]
+}
+
+// ShapeOfCommand returns the query shape of the find, count, distinct,
+// findAndModify and aggregate commands, the filter of an aggregation is the
+// one of its first $match stage
+func ShapeOfCommand(commandName string, command bson.Raw) (QueryShape, bool) {
+	collection, ok := command.Lookup(commandName).StringValueOK()
+	if !ok {
+		return QueryShape{}, false
+	}
+	var filter, sortSpec bson.Raw
+	switch commandName {
+	case "find":
+		filter, _ = command.Lookup("filter").DocumentOK()
+		sortSpec, _ = command.Lookup("sort").DocumentOK()
+	case "count", "distinct", "findAndModify":
+		filter, _ = command.Lookup("query").DocumentOK()
+		sortSpec, _ = command.Lookup("sort").DocumentOK()
+	case "aggregate":
+		pipeline, ok := command.Lookup("pipeline").ArrayOK()
+		if !ok {
+			return QueryShape{}, false
+		}
+		stages, _ := pipeline.Values()
+		for i, stage := range stages {
+			stageDoc, ok := stage.DocumentOK()
+			if !ok {
+				continue
+			}
+			if match, ok := stageDoc.Lookup("$match").DocumentOK(); ok && i == 0 {
+				filter = match
+			}
+			if sortStage, ok := stageDoc.Lookup("$sort").DocumentOK(); ok && sortSpec == nil {
+				sortSpec = sortStage
+			}
+		}
+	default:
+		return QueryShape{}, false
+	}
+
+	shape := QueryShape{Collection: collection, Filter: filterFields(filter)}
+	sort.Strings(shape.Filter)
+	if elements, err := sortSpec.Elements(); err == nil {
+		for _, element := range elements {
+			shape.Sort = append(shape.Sort, element.Key())
+		}
+	}
+	return shape, true
+}
+
+// filterFields returns the fields of the filter, along with the ones of its
+// $and and $or clauses, without duplicates
+func filterFields(filter bson.Raw) []string {
+	seen := make(map[string]bool)
+	var fields []string
+	var collect func(doc bson.Raw)
+	collect = func(doc bson.Raw) {
+		elements, err := doc.Elements()
+		if err != nil {
+			return
+		}
+		for _, element := range elements {
+			key := element.Key()
+			if key == "$and" || key == "$or" {
+				clauses, ok := element.Value().ArrayOK()
+				if !ok {
+					continue
+				}
+				values, _ := clauses.Values()
+				for _, clause := range values {
+					if clauseDoc, ok := clause.DocumentOK(); ok {
+						collect(clauseDoc)
+					}
+				}
+				continue
+			}
+			if strings.HasPrefix(key, "$") || seen[key] {
+				continue
+			}
+			seen[key] = true
+			fields = append(fields, key)
+		}
+	}
+	collect(filter)
+	return fields
+}
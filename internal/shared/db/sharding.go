@@ -0,0 +1,17 @@
+package db
+
+import (
+	"hash/fnv"
+	"strconv"
+)
+
+// LogicalShardId returns the logical shard the write of the given key goes to,
+// ranged from 0 to shardCount-1. The shard is derived from the hash of the key
+// so that the retries of a write land on the same shard, while the writes of
+// different keys remain spread across the shards.
+func LogicalShardId(key string, shardCount int64) string {
+	h := fnv.New64a()
+	// Writing into a hash never returns an error
+	_, _ = h.Write([]byte(key))
+	return strconv.FormatUint(h.Sum64()%uint64(shardCount), 10)
+}
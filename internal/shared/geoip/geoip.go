@@ -0,0 +1,41 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// CountryReader resolves the country of the IPs from a GeoIP2 or GeoLite2
+// country database, in the MaxMind DB format
+type CountryReader struct {
+	reader *maxminddb.Reader
+}
+
+type countryRecord struct {
+	Country struct {
+		IsoCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+}
+
+// Open loads the database at path, it's memory mapped for the lifetime of the
+// process
+func Open(path string) (*CountryReader, error) {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the geoip database: %w", err)
+	}
+	return &CountryReader{reader: reader}, nil
+}
+
+// Country returns the ISO 3166-1 alpha-2 code of the country of the address,
+// empty if the address is not in the database
+func (r *CountryReader) Country(addr netip.Addr) (string, error) {
+	var record countryRecord
+	if err := r.reader.Lookup(net.IP(addr.AsSlice()), &record); err != nil {
+		return "", err
+	}
+	return record.Country.IsoCode, nil
+}
@@ -1,22 +1,37 @@
 package clients
 
 import (
+	"fmt"
+
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients/objectstore"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients/ordinals"
 )
 
 type Clients struct {
 	Ordinals ordinals.OrdinalsClient
+	// ObjectStore is nil if the exports are not configured
+	ObjectStore objectstore.ObjectStore
 }
 
-func New(cfg *config.Config) *Clients {
+func New(cfg *config.Config) (*Clients, error) {
 	var ordinalsClient ordinals.OrdinalsClient
 	// If the assets config is set, create the ordinal related clients
 	if cfg.Assets != nil {
 		ordinalsClient = ordinals.New(cfg.Assets.Ordinals)
 	}
 
-	return &Clients{
-		Ordinals: ordinalsClient,
+	var objectStore objectstore.ObjectStore
+	if cfg.Exports != nil {
+		s3Store, err := objectstore.New(cfg.Exports)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the exports object store: %w", err)
+		}
+		objectStore = s3Store
 	}
+
+	return &Clients{
+		Ordinals:    ordinalsClient,
+		ObjectStore: objectStore,
+	}, nil
 }
@@ -0,0 +1,70 @@
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+)
+
+// ObjectStore writes the exported files and signs their download urls
+type ObjectStore interface {
+	Upload(ctx context.Context, key string, body io.Reader, contentType string) error
+	SignedUrl(key string, ttl time.Duration) (string, error)
+}
+
+// S3Store stores the objects in an S3 compatible bucket, the credentials are
+// taken from the default AWS credentials chain
+type S3Store struct {
+	bucket   string
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+func New(cfg *config.ExportsConfig) (*S3Store, error) {
+	awsConfig := aws.Config{Region: aws.String(cfg.Region)}
+	if cfg.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.Endpoint)
+		// The S3 compatible stores seldom support the virtual hosted buckets
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the aws session: %w", err)
+	}
+	client := s3.New(sess)
+	return &S3Store{
+		bucket:   cfg.Bucket,
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+	}, nil
+}
+
+// Upload streams the body into the object, in multiple parts if needed
+func (s *S3Store) Upload(ctx context.Context, key string, body io.Reader, contentType string) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+// SignedUrl returns a url downloading the object until the ttl elapses
+func (s *S3Store) SignedUrl(key string, ttl time.Duration) (string, error) {
+	req, _ := s.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return req.Presign(ttl)
+}
@@ -0,0 +1,211 @@
+package errorreporting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/tracing"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/getsentry/sentry-go"
+	"github.com/go-chi/chi"
+)
+
+const (
+	// maxPayloadSize is the size above which the message payloads are
+	// truncated in the reported errors
+	maxPayloadSize = 8 * 1024
+	redactedValue  = "[REDACTED]"
+)
+
+// defaultReporter is nil if the error reporting is not enabled
+var defaultReporter *Reporter
+
+// Reporter reports the errors to Sentry. The errors are fingerprinted so that
+// they are grouped by Sentry, and a same fingerprint is reported once per
+// dedup window.
+type Reporter struct {
+	hub         *sentry.Hub
+	dedupWindow time.Duration
+	// redactedParams are the query params whose values are not reported
+	redactedParams map[string]bool
+
+	mu           sync.Mutex
+	lastReported map[string]time.Time
+}
+
+// Init enables the reporting of the errors. It's a no-op if the error
+// reporting is not configured.
+func Init(cfg *config.ErrorReportingConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	reporter, err := New(cfg, nil)
+	if err != nil {
+		return err
+	}
+	defaultReporter = reporter
+	return nil
+}
+
+// New creates a reporter sending the errors through the transport, the Sentry
+// HTTP transport is used if nil.
+func New(cfg *config.ErrorReportingConfig, transport sentry.Transport) (*Reporter, error) {
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:              cfg.Dsn,
+		Environment:      cfg.Environment,
+		AttachStacktrace: true,
+		Transport:        transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the error reporting client: %w", err)
+	}
+	redactedParams := make(map[string]bool, len(cfg.RedactedQueryParams))
+	for _, param := range cfg.RedactedQueryParams {
+		redactedParams[param] = true
+	}
+	return &Reporter{
+		hub:            sentry.NewHub(client, sentry.NewScope()),
+		dedupWindow:    cfg.DedupWindow,
+		redactedParams: redactedParams,
+		lastReported:   make(map[string]time.Time),
+	}, nil
+}
+
+// CaptureHttpError reports an error answered with a 5xx response
+func CaptureHttpError(r *http.Request, statusCode int, errorCode types.ErrorCode, err error) {
+	if defaultReporter != nil {
+		defaultReporter.CaptureHttpError(r, statusCode, errorCode, err)
+	}
+}
+
+// CaptureUnprocessableMessage reports a queue message which could not be
+// processed
+func CaptureUnprocessableMessage(ctx context.Context, messageBody, reason string) {
+	if defaultReporter != nil {
+		defaultReporter.CaptureUnprocessableMessage(ctx, messageBody, reason)
+	}
+}
+
+// Flush waits for the reported errors to be sent, up to the timeout. It's
+// called on shutdown so that the last errors are not lost.
+func Flush(timeout time.Duration) {
+	if defaultReporter != nil {
+		defaultReporter.Flush(timeout)
+	}
+}
+
+// CaptureHttpError reports the error fingerprinted on the route, the status,
+// the error code and the type of the wrapped error. The message is left out
+// as it often holds the hashes or the keys of the request.
+func (rep *Reporter) CaptureHttpError(r *http.Request, statusCode int, errorCode types.ErrorCode, err error) {
+	route := r.URL.Path
+	if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+		route = routeCtx.RoutePattern()
+	}
+	fingerprint := []string{
+		"http", r.Method, route, strconv.Itoa(statusCode), errorCode.String(), rootErrorType(err),
+	}
+	requestContext := sentry.Context{
+		"method": r.Method,
+		"route":  route,
+		"query":  rep.redactQuery(r),
+		"status": statusCode,
+	}
+	if traceId := r.Context().Value(tracing.TraceIdKey); traceId != nil {
+		requestContext["traceId"] = traceId
+	}
+	rep.capture(fingerprint, err, map[string]string{"kind": "http", "route": route}, "request", requestContext)
+}
+
+// rootErrorType returns the type of the innermost wrapped error
+func rootErrorType(err error) string {
+	for {
+		wrapped := errors.Unwrap(err)
+		if wrapped == nil {
+			return fmt.Sprintf("%T", err)
+		}
+		err = wrapped
+	}
+}
+
+// redactQuery returns the query of the request with the values of the
+// redacted params replaced
+func (rep *Reporter) redactQuery(r *http.Request) string {
+	if r.URL.RawQuery == "" || len(rep.redactedParams) == 0 {
+		return r.URL.RawQuery
+	}
+	query := r.URL.Query()
+	for name, values := range query {
+		if rep.redactedParams[name] {
+			for i := range values {
+				values[i] = redactedValue
+			}
+		}
+	}
+	return query.Encode()
+}
+
+func (rep *Reporter) CaptureUnprocessableMessage(ctx context.Context, messageBody, reason string) {
+	payload := messageBody
+	if len(payload) > maxPayloadSize {
+		payload = payload[:maxPayloadSize] + "...(truncated)"
+	}
+	messageContext := sentry.Context{
+		"payload": payload,
+		"reason":  reason,
+	}
+	if traceId := ctx.Value(tracing.TraceIdKey); traceId != nil {
+		messageContext["traceId"] = traceId
+	}
+	rep.capture(
+		[]string{"unprocessable_message", reason}, fmt.Errorf("unprocessable message: %s", reason),
+		map[string]string{"kind": "unprocessable_message"}, "message", messageContext,
+	)
+}
+
+func (rep *Reporter) capture(
+	fingerprint []string, err error, tags map[string]string, contextKey string, context sentry.Context,
+) {
+	if !rep.shouldReport(fmt.Sprint(fingerprint)) {
+		return
+	}
+	rep.hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetFingerprint(fingerprint)
+		scope.SetTags(tags)
+		scope.SetContext(contextKey, context)
+		rep.hub.CaptureException(err)
+	})
+}
+
+// shouldReport returns whether the fingerprint has not been reported within
+// the dedup window, and marks it as reported if so
+func (rep *Reporter) shouldReport(key string) bool {
+	if rep.dedupWindow == 0 {
+		return true
+	}
+	now := time.Now()
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	if last, ok := rep.lastReported[key]; ok && now.Sub(last) < rep.dedupWindow {
+		return false
+	}
+	// Forget the expired fingerprints so that the map does not grow unbounded
+	for k, last := range rep.lastReported {
+		if now.Sub(last) >= rep.dedupWindow {
+			delete(rep.lastReported, k)
+		}
+	}
+	rep.lastReported[key] = now
+	return true
+}
+
+// Flush waits for the reported errors to be sent, up to the timeout
+func (rep *Reporter) Flush(timeout time.Duration) bool {
+	return rep.hub.Flush(timeout)
+}
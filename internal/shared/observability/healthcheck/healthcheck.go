@@ -3,6 +3,7 @@ package healthcheck
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
 	queueclient "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/client"
@@ -56,6 +57,16 @@ func queueHealthCheck(queueClient queueclient.QueueClient) {
 	}
 }
 
+// QueueConnectionError returns an error listing the queues currently
+// reconnecting to the broker, nil if all the queues are connected.
+func QueueConnectionError() error {
+	disconnected := queueclient.DisconnectedQueueNames()
+	if len(disconnected) == 0 {
+		return nil
+	}
+	return fmt.Errorf("queue connection lost, reconnecting: %s", strings.Join(disconnected, ", "))
+}
+
 func terminateService() {
 	logger.Fatal().Msg("Terminating service due to health check failure.")
 }
@@ -0,0 +1,267 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/credentials"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	queueConfig "github.com/babylonlabs-io/staking-queue-client/config"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/robfig/cron/v3"
+)
+
+const webhookRequestTimeout = 5 * time.Second
+
+// queueLagMonitor is set once the monitor is started, it's used by the
+// healthcheck endpoint to report lagging queues.
+var queueLagMonitor *QueueLagMonitor
+
+// QueueInspector returns the number of messages ready to be consumed in the queue
+type QueueInspector func(queueName string) (int, error)
+
+// QueueLagAlert is the payload sent to the webhook when a queue starts lagging
+type QueueLagAlert struct {
+	QueueName               string  `json:"queue_name"`
+	Depth                   int     `json:"depth"`
+	OldestMessageAgeSeconds float64 `json:"oldest_message_age_seconds"`
+	Reason                  string  `json:"reason"`
+}
+
+type queueLagState struct {
+	// nonEmptySince is the time the queue was first seen non-empty since it
+	// was last seen empty, zero if the queue is empty
+	nonEmptySince time.Time
+	lagging       bool
+}
+
+// QueueLagMonitor periodically inspects the consumed queues and records the
+// queue depth and the oldest message age. A queue is flagged as lagging once
+// any of the configured thresholds is exceeded.
+// As the queue messages do not carry a publish timestamp, the oldest message
+// age is estimated as the time elapsed since the queue was last seen empty.
+type QueueLagMonitor struct {
+	cfg        *config.QueueMonitorConfig
+	queueNames []string
+	inspect    QueueInspector
+	httpClient *http.Client
+
+	mu     sync.RWMutex
+	states map[string]*queueLagState
+}
+
+func NewQueueLagMonitor(
+	cfg *config.QueueMonitorConfig, queueNames []string, inspect QueueInspector,
+) *QueueLagMonitor {
+	states := make(map[string]*queueLagState, len(queueNames))
+	for _, name := range queueNames {
+		states[name] = &queueLagState{}
+	}
+	return &QueueLagMonitor{
+		cfg:        cfg,
+		queueNames: queueNames,
+		inspect:    inspect,
+		httpClient: &http.Client{Timeout: webhookRequestTimeout},
+		states:     states,
+	}
+}
+
+// StartQueueLagMonitor starts inspecting the queues at the configured interval.
+// It's a no-op if the queue monitor is not configured.
+func StartQueueLagMonitor(
+	ctx context.Context, queueCfg *queueConfig.QueueConfig, queueCredentials *credentials.Holder,
+	monitorCfg *config.QueueMonitorConfig, queueNames []string,
+) error {
+	if monitorCfg == nil {
+		return nil
+	}
+	inspector := newAmqpQueueInspector(queueCfg, queueCredentials)
+	monitor := NewQueueLagMonitor(monitorCfg, queueNames, inspector.inspect)
+
+	c := cron.New()
+	_, err := c.AddFunc(fmt.Sprintf("@every %s", monitorCfg.Interval), func() {
+		monitor.Check(time.Now())
+	})
+	if err != nil {
+		return err
+	}
+	queueLagMonitor = monitor
+	c.Start()
+	logger.Info().Msg("Initiated Queue Lag Monitor Cron")
+
+	go func() {
+		<-ctx.Done()
+		logger.Info().Msg("Stopping Queue Lag Monitor Cron")
+		c.Stop()
+		inspector.close()
+	}()
+
+	return nil
+}
+
+// Check inspects all the queues and updates their lagging status. The webhook
+// is called for each queue that starts lagging.
+func (m *QueueLagMonitor) Check(now time.Time) {
+	for _, name := range m.queueNames {
+		depth, err := m.inspect(name)
+		if err != nil {
+			logger.Error().Err(err).Str("queueName", name).Msg("error while inspecting queue")
+			metrics.RecordQueueOperationFailure("inspectQueue", name)
+			continue
+		}
+
+		m.mu.Lock()
+		state := m.states[name]
+		var age time.Duration
+		if depth == 0 {
+			state.nonEmptySince = time.Time{}
+		} else {
+			if state.nonEmptySince.IsZero() {
+				state.nonEmptySince = now
+			}
+			age = now.Sub(state.nonEmptySince)
+		}
+		reason := m.lagReason(depth, age)
+		wasLagging := state.lagging
+		state.lagging = reason != ""
+		m.mu.Unlock()
+
+		metrics.RecordQueueLag(name, depth, age, reason != "")
+		if reason == "" {
+			if wasLagging {
+				logger.Info().Str("queueName", name).Msg("queue processing caught up")
+			}
+			continue
+		}
+		if !wasLagging {
+			logger.Warn().Str("queueName", name).Int("depth", depth).
+				Dur("oldestMessageAge", age).Msg("queue processing is falling behind: " + reason)
+			m.notifyWebhook(QueueLagAlert{
+				QueueName:               name,
+				Depth:                   depth,
+				OldestMessageAgeSeconds: age.Seconds(),
+				Reason:                  reason,
+			})
+		}
+	}
+}
+
+// LaggingQueues returns the sorted names of the queues currently lagging
+func (m *QueueLagMonitor) LaggingQueues() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var lagging []string
+	for name, state := range m.states {
+		if state.lagging {
+			lagging = append(lagging, name)
+		}
+	}
+	sort.Strings(lagging)
+	return lagging
+}
+
+func (m *QueueLagMonitor) lagReason(depth int, age time.Duration) string {
+	if m.cfg.MaxQueueDepth > 0 && depth > m.cfg.MaxQueueDepth {
+		return fmt.Sprintf("queue depth %d exceeds %d", depth, m.cfg.MaxQueueDepth)
+	}
+	if m.cfg.MaxMessageAge > 0 && age > m.cfg.MaxMessageAge {
+		return fmt.Sprintf("oldest message age %s exceeds %s", age, m.cfg.MaxMessageAge)
+	}
+	return ""
+}
+
+func (m *QueueLagMonitor) notifyWebhook(alert QueueLagAlert) {
+	if m.cfg.WebhookUrl == "" {
+		return
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		logger.Error().Err(err).Msg("error while marshalling queue lag alert")
+		return
+	}
+	resp, err := m.httpClient.Post(m.cfg.WebhookUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		logger.Error().Err(err).Str("queueName", alert.QueueName).Msg("error while sending queue lag alert")
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		logger.Error().Int("status", resp.StatusCode).Str("queueName", alert.QueueName).
+			Msg("queue lag alert webhook returned an error status")
+	}
+}
+
+// QueueLagError returns an error listing the lagging queues if the queue
+// monitor is configured to fail the health check, nil otherwise.
+func QueueLagError() error {
+	if queueLagMonitor == nil || !queueLagMonitor.cfg.FailHealthCheck {
+		return nil
+	}
+	lagging := queueLagMonitor.LaggingQueues()
+	if len(lagging) == 0 {
+		return nil
+	}
+	return fmt.Errorf("queue processing is falling behind: %s", strings.Join(lagging, ", "))
+}
+
+// LaggingQueueNames returns the sorted names of the queues currently lagging,
+// nil if the queue monitor is not configured
+func LaggingQueueNames() []string {
+	if queueLagMonitor == nil {
+		return nil
+	}
+	return queueLagMonitor.LaggingQueues()
+}
+
+// amqpQueueInspector inspects the queues through a dedicated connection so
+// that the consumers' channels are not affected by failed inspections.
+type amqpQueueInspector struct {
+	url         string
+	credentials *credentials.Holder
+	mu          sync.Mutex
+	conn        *amqp091.Connection
+}
+
+func newAmqpQueueInspector(cfg *queueConfig.QueueConfig, queueCredentials *credentials.Holder) *amqpQueueInspector {
+	return &amqpQueueInspector{url: cfg.Url, credentials: queueCredentials}
+}
+
+func (i *amqpQueueInspector) inspect(queueName string) (int, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.conn == nil || i.conn.IsClosed() {
+		current := i.credentials.Get()
+		conn, err := amqp091.Dial(fmt.Sprintf("amqp://%s:%s@%s", current.Username, current.Password, i.url))
+		if err != nil {
+			return 0, fmt.Errorf("failed to connect to queue: %w", err)
+		}
+		i.conn = conn
+	}
+	// An inspection failure closes the channel, hence a channel per inspection
+	ch, err := i.conn.Channel()
+	if err != nil {
+		return 0, fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer ch.Close()
+	q, err := ch.QueueInspect(queueName)
+	if err != nil {
+		return 0, err
+	}
+	return q.Messages, nil
+}
+
+func (i *amqpQueueInspector) close() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.conn != nil && !i.conn.IsClosed() {
+		i.conn.Close()
+	}
+}
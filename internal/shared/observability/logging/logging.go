@@ -0,0 +1,238 @@
+package logging
+
+import (
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Settings are the log format and levels in effect
+type Settings struct {
+	Level  string `json:"level"`
+	Format string `json:"format"`
+	// Modules are the log levels overriding the level of the modules
+	Modules map[string]string `json:"modules"`
+}
+
+// levels holds the log levels in effect, it's replaced as a whole on update
+type levels struct {
+	level   zerolog.Level
+	modules map[string]zerolog.Level
+}
+
+func (l *levels) of(module string) zerolog.Level {
+	if level, ok := l.modules[module]; ok {
+		return level
+	}
+	return l.level
+}
+
+var (
+	// mu serializes the updates of the settings
+	mu sync.Mutex
+	// initialLevel is the level configured on startup, restored by the
+	// signal toggling the debug level
+	initialLevel zerolog.Level
+	current      atomic.Pointer[levels]
+	format       atomic.Pointer[string]
+	output       = &switchWriter{}
+	// base is the logger all the loggers are derived from, it has no level
+	// hook so that the module loggers only filter by their own level
+	base    = zerolog.New(output).With().Timestamp().Logger()
+	modules = make(map[string]*zerolog.Logger, len(config.LogModules))
+)
+
+func init() {
+	// Nothing is filtered until the logging is initialized
+	current.Store(&levels{level: zerolog.TraceLevel})
+	jsonFormat := config.LogFormatJson
+	format.Store(&jsonFormat)
+	output.set(newWriter(jsonFormat))
+	for _, module := range config.LogModules {
+		logger := base.With().Str("module", module).Logger().Hook(levelHook{module: module})
+		modules[module] = &logger
+	}
+}
+
+// Init replaces the global logger by one whose format and levels can be
+// changed at runtime. The level applies to all the logs but the ones of the
+// modules with their own level.
+func Init(level string, cfg *config.LoggingConfig) error {
+	settings := Settings{Level: level, Format: config.LogFormatJson}
+	if settings.Level == "" {
+		settings.Level = zerolog.InfoLevel.String()
+	}
+	if cfg != nil {
+		if cfg.Format != "" {
+			settings.Format = cfg.Format
+		}
+		settings.Modules = cfg.Modules
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if err := apply(settings, true); err != nil {
+		return err
+	}
+	log.Logger = base.Hook(levelHook{})
+	return nil
+}
+
+// Module returns the logger of the module, whose level can be set apart from
+// the other logs
+func Module(module string) *zerolog.Logger {
+	logger, ok := modules[module]
+	if !ok {
+		return &log.Logger
+	}
+	return logger
+}
+
+// Get returns the log format and levels in effect
+func Get() Settings {
+	l := current.Load()
+	settings := Settings{
+		Level:   l.level.String(),
+		Format:  *format.Load(),
+		Modules: make(map[string]string, len(l.modules)),
+	}
+	for module, level := range l.modules {
+		settings.Modules[module] = level.String()
+	}
+	return settings
+}
+
+// Update changes the log format and levels. The empty level and format are
+// left unchanged, and so are the modules not listed. A module listed with an
+// empty level falls back to the level of the other logs.
+func Update(update Settings) error {
+	mu.Lock()
+	defer mu.Unlock()
+	settings, err := merge(update)
+	if err != nil {
+		return err
+	}
+	return apply(settings, false)
+}
+
+// Preview returns the log format and levels Update would put in effect,
+// without changing them
+func Preview(update Settings) (Settings, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	settings, err := merge(update)
+	if err != nil {
+		return Settings{}, err
+	}
+	if _, err := parse(settings); err != nil {
+		return Settings{}, err
+	}
+	return settings, nil
+}
+
+// merge applies the update to the settings in effect
+func merge(update Settings) (Settings, error) {
+	settings := Get()
+	if update.Level != "" {
+		settings.Level = update.Level
+	}
+	if update.Format != "" {
+		settings.Format = update.Format
+	}
+	for module, level := range update.Modules {
+		if level == "" {
+			if err := config.ValidateLogModule(module); err != nil {
+				return Settings{}, err
+			}
+			delete(settings.Modules, module)
+			continue
+		}
+		settings.Modules[module] = level
+	}
+	return settings, nil
+}
+
+// parse validates the settings and returns their levels
+func parse(settings Settings) (*levels, error) {
+	level, err := config.ParseLogLevel(settings.Level)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.ValidateLogFormat(settings.Format); err != nil {
+		return nil, err
+	}
+	l := &levels{level: level, modules: make(map[string]zerolog.Level, len(settings.Modules))}
+	for module, moduleLevel := range settings.Modules {
+		if err := config.ValidateLogModule(module); err != nil {
+			return nil, err
+		}
+		if l.modules[module], err = config.ParseLogLevel(moduleLevel); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// apply validates the settings before putting them in effect
+func apply(settings Settings, initial bool) error {
+	l, err := parse(settings)
+	if err != nil {
+		return err
+	}
+	level := l.level
+
+	if initial {
+		initialLevel = level
+	}
+	if *format.Load() != settings.Format {
+		output.set(newWriter(settings.Format))
+		format.Store(&settings.Format)
+	}
+	current.Store(l)
+	// The global level lets through the most verbose level in effect, the
+	// level hooks filter the rest
+	globalLevel := level
+	for _, moduleLevel := range l.modules {
+		globalLevel = min(globalLevel, moduleLevel)
+	}
+	zerolog.SetGlobalLevel(globalLevel)
+	return nil
+}
+
+// levelHook discards the logs below the level of the module, or the level of
+// the other logs if the module is empty
+type levelHook struct {
+	module string
+}
+
+func (h levelHook) Run(e *zerolog.Event, level zerolog.Level, _ string) {
+	if level < current.Load().of(h.module) {
+		e.Discard()
+	}
+}
+
+// switchWriter writes the logs into the writer of the current format, so that
+// the format of the loggers already derived is switched as well
+type switchWriter struct {
+	writer atomic.Pointer[io.Writer]
+}
+
+func (w *switchWriter) set(writer io.Writer) {
+	w.writer.Store(&writer)
+}
+
+func (w *switchWriter) Write(p []byte) (int, error) {
+	return (*w.writer.Load()).Write(p)
+}
+
+func newWriter(format string) io.Writer {
+	if format == config.LogFormatConsole {
+		return zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}
+	}
+	return os.Stderr
+}
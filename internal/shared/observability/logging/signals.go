@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleSignals changes the logging on the signals until the context is done:
+// SIGUSR1 toggles the debug level of the logs, restoring the level configured
+// on startup, and SIGUSR2 toggles between the JSON and console formats. The
+// module levels are left unchanged.
+func HandleSignals(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-signals:
+				var err error
+				if sig == syscall.SIGUSR1 {
+					err = toggleDebugLevel()
+				} else {
+					err = toggleFormat()
+				}
+				if err != nil {
+					log.Error().Err(err).Str("signal", sig.String()).Msg("failed to change the logging")
+					continue
+				}
+				settings := Get()
+				log.WithLevel(zerolog.NoLevel).Str("level", settings.Level).Str("format", settings.Format).
+					Msg("logging changed")
+			}
+		}
+	}()
+}
+
+func toggleDebugLevel() error {
+	level := zerolog.DebugLevel
+	if current.Load().level == zerolog.DebugLevel {
+		level = initialLevel
+	}
+	return Update(Settings{Level: level.String()})
+}
+
+func toggleFormat() error {
+	format := config.LogFormatConsole
+	if Get().Format == config.LogFormatConsole {
+		format = config.LogFormatJson
+	}
+	return Update(Settings{Format: format})
+}
@@ -35,6 +35,29 @@ var (
 	httpResponseWriteFailureCounter  *prometheus.CounterVec
 	clientRequestDurationHistogram   *prometheus.HistogramVec
 	serviceCrashCounter              *prometheus.CounterVec
+	queueDepthGauge                  *prometheus.GaugeVec
+	queueOldestMessageAgeGauge       *prometheus.GaugeVec
+	queueLaggingGauge                *prometheus.GaugeVec
+	queueRequeueCounter              *prometheus.CounterVec
+	dbPoolConnectionsGauge           *prometheus.GaugeVec
+	dbPoolCheckedOutGauge            *prometheus.GaugeVec
+	dbPoolCheckOutFailureCounter     *prometheus.CounterVec
+	invalidStateTransitionCounter    *prometheus.CounterVec
+	queueConnectedGauge              *prometheus.GaugeVec
+	queueMessageSchemaVersionCounter *prometheus.CounterVec
+	responseCacheCounter             *prometheus.CounterVec
+	dbSlowQueryCounter               *prometheus.CounterVec
+	httpPanicCounter                 *prometheus.CounterVec
+	shadowDivergenceCounter          *prometheus.CounterVec
+	apiKeyQuotaExceededCounter       *prometheus.CounterVec
+	queueBackpressureRatioGauge      prometheus.Gauge
+	expiryScannerRunCounter          *prometheus.CounterVec
+	expiryScannerTransitionCounter   *prometheus.CounterVec
+	geoGatedRequestCounter           *prometheus.CounterVec
+	leaderElectionGauge              *prometheus.GaugeVec
+	scheduledJobRunCounter           *prometheus.CounterVec
+	sloBurnRateGauge                 *prometheus.GaugeVec
+	stakerPolicyViolationCounter     *prometheus.CounterVec
 )
 
 // Init initializes the metrics package.
@@ -133,6 +156,189 @@ func registerMetrics() {
 		[]string{"type"},
 	)
 
+	queueDepthGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "queue_depth",
+			Help: "Number of messages ready to be consumed per queue name.",
+		},
+		[]string{"queuename"},
+	)
+
+	queueOldestMessageAgeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "queue_oldest_message_age_seconds",
+			Help: "Estimated age of the oldest message waiting in the queue in seconds.",
+		},
+		[]string{"queuename"},
+	)
+
+	queueLaggingGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "queue_lagging",
+			Help: "Whether the queue processing is falling behind the configured thresholds (1) or not (0).",
+		},
+		[]string{"queuename"},
+	)
+
+	queueRequeueCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "queue_requeue_total",
+			Help: "Total number of messages requeued for retry per queue name.",
+		},
+		[]string{"queuename"},
+	)
+
+	dbPoolConnectionsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "db_pool_connections",
+			Help: "Number of open connections in the db connection pool per db name.",
+		},
+		[]string{"dbname"},
+	)
+
+	dbPoolCheckedOutGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "db_pool_checked_out_connections",
+			Help: "Number of connections checked out of the db connection pool per db name.",
+		},
+		[]string{"dbname"},
+	)
+
+	dbPoolCheckOutFailureCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "db_pool_checkout_failure_total",
+			Help: "Total number of failed connection checkouts from the db connection pool.",
+		},
+		[]string{"dbname", "reason"},
+	)
+
+	invalidStateTransitionCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "invalid_state_transition_total",
+			Help: "Total number of rejected delegation state transitions per source and target state.",
+		},
+		[]string{"from", "to"},
+	)
+
+	queueConnectedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "queue_connected",
+			Help: "Whether the queue client is connected to the broker (1) or reconnecting (0).",
+		},
+		[]string{"queuename"},
+	)
+
+	queueMessageSchemaVersionCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "queue_message_schema_version_total",
+			Help: "Total number of consumed queue messages per schema version.",
+		},
+		[]string{"queuename", "version"},
+	)
+
+	responseCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_response_cache_total",
+			Help: "Total number of cacheable requests per endpoint and cache result (hit, stale or miss).",
+		},
+		[]string{"endpoint", "result"},
+	)
+
+	dbSlowQueryCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "db_slow_query_total",
+			Help: "Total number of db commands exceeding the slow query threshold per db name and command.",
+		},
+		[]string{"dbname", "command"},
+	)
+
+	httpPanicCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_panic_total",
+			Help: "Total number of panics recovered while handling http requests per endpoint.",
+		},
+		[]string{"endpoint"},
+	)
+
+	shadowDivergenceCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shadow_divergence_total",
+			Help: "Total number of shadow documents diverging from the primary deployment per queue and collection.",
+		},
+		[]string{"queue", "collection"},
+	)
+
+	apiKeyQuotaExceededCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "api_key_quota_exceeded_total",
+			Help: "Total number of requests rejected as their api key has exceeded its daily quotas.",
+		},
+		[]string{"api_key"},
+	)
+
+	queueBackpressureRatioGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "queue_backpressure_ratio",
+			Help: "Fraction of the queue workers allowed to process messages given the health of the staking db.",
+		},
+	)
+
+	expiryScannerRunCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "expiry_scanner_runs_total",
+			Help: "Total number of expiry scans per outcome.",
+		},
+		[]string{"outcome"},
+	)
+
+	expiryScannerTransitionCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "expiry_scanner_transitions_total",
+			Help: "Total number of expired delegations transitioned to unbonded by the expiry scanner as their expiry event was missing, per tx type.",
+		},
+		[]string{"tx_type"},
+	)
+
+	geoGatedRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "geo_gated_requests_total",
+			Help: "Total number of write requests from the restricted jurisdictions per country and gating action.",
+		},
+		[]string{"country", "action"},
+	)
+
+	leaderElectionGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "leader_election_leader",
+			Help: "Whether the replica is the leader running the scheduled job (1) or not (0).",
+		},
+		[]string{"job"},
+	)
+
+	scheduledJobRunCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scheduled_job_runs_total",
+			Help: "Total number of scheduled job runs per job and outcome.",
+		},
+		[]string{"job", "outcome"},
+	)
+
+	sloBurnRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "http_slo_burn_rate",
+			Help: "Burn rate of the latency budget per route and objective over the slo window.",
+		},
+		[]string{"route", "objective"},
+	)
+
+	stakerPolicyViolationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "staker_policy_violations_total",
+			Help: "Total number of indexed delegations violating the staker policy per rule.",
+		},
+		[]string{"rule"},
+	)
+
 	prometheus.MustRegister(
 		httpRequestDurationHistogram,
 		eventProcessingDurationHistogram,
@@ -141,6 +347,29 @@ func registerMetrics() {
 		httpResponseWriteFailureCounter,
 		clientRequestDurationHistogram,
 		serviceCrashCounter,
+		queueDepthGauge,
+		queueOldestMessageAgeGauge,
+		queueLaggingGauge,
+		queueRequeueCounter,
+		dbPoolConnectionsGauge,
+		dbPoolCheckedOutGauge,
+		dbPoolCheckOutFailureCounter,
+		invalidStateTransitionCounter,
+		queueConnectedGauge,
+		queueMessageSchemaVersionCounter,
+		responseCacheCounter,
+		dbSlowQueryCounter,
+		httpPanicCounter,
+		shadowDivergenceCounter,
+		apiKeyQuotaExceededCounter,
+		queueBackpressureRatioGauge,
+		expiryScannerRunCounter,
+		expiryScannerTransitionCounter,
+		geoGatedRequestCounter,
+		leaderElectionGauge,
+		scheduledJobRunCounter,
+		sloBurnRateGauge,
+		stakerPolicyViolationCounter,
 	)
 }
 
@@ -202,3 +431,133 @@ func StartClientRequestDurationTimer(baseUrl, method, path string) func(statusCo
 func RecordServiceCrash(service string) {
 	serviceCrashCounter.WithLabelValues(service).Inc()
 }
+
+// RecordQueueLag records the depth, the oldest message age and the lagging
+// status of the queue.
+func RecordQueueLag(queuename string, depth int, oldestMessageAge time.Duration, lagging bool) {
+	queueDepthGauge.WithLabelValues(queuename).Set(float64(depth))
+	queueOldestMessageAgeGauge.WithLabelValues(queuename).Set(oldestMessageAge.Seconds())
+	laggingValue := 0.0
+	if lagging {
+		laggingValue = 1
+	}
+	queueLaggingGauge.WithLabelValues(queuename).Set(laggingValue)
+}
+
+// RecordQueueRequeue increments the requeue counter of the queue.
+func RecordQueueRequeue(queuename string) {
+	queueRequeueCounter.WithLabelValues(queuename).Inc()
+}
+
+// RecordDbPoolConnections adds the delta to the number of open connections
+// of the db connection pool.
+func RecordDbPoolConnections(dbname string, delta float64) {
+	dbPoolConnectionsGauge.WithLabelValues(dbname).Add(delta)
+}
+
+// RecordDbPoolCheckedOut adds the delta to the number of connections checked
+// out of the db connection pool.
+func RecordDbPoolCheckedOut(dbname string, delta float64) {
+	dbPoolCheckedOutGauge.WithLabelValues(dbname).Add(delta)
+}
+
+// RecordDbPoolCheckOutFailure increments the connection checkout failure counter.
+func RecordDbPoolCheckOutFailure(dbname, reason string) {
+	dbPoolCheckOutFailureCounter.WithLabelValues(dbname, reason).Inc()
+}
+
+// RecordInvalidStateTransition increments the rejected delegation state
+// transition counter.
+func RecordInvalidStateTransition(from, to string) {
+	invalidStateTransitionCounter.WithLabelValues(from, to).Inc()
+}
+
+// RecordQueueConnected records whether the queue client is connected to the
+// broker.
+func RecordQueueConnected(queuename string, connected bool) {
+	connectedValue := 0.0
+	if connected {
+		connectedValue = 1
+	}
+	queueConnectedGauge.WithLabelValues(queuename).Set(connectedValue)
+}
+
+// RecordQueueMessageSchemaVersion increments the consumed queue messages
+// counter of the schema version.
+func RecordQueueMessageSchemaVersion(queuename string, version int) {
+	queueMessageSchemaVersionCounter.WithLabelValues(queuename, fmt.Sprintf("%d", version)).Inc()
+}
+
+// RecordResponseCacheResult increments the cacheable requests counter of the
+// cache result.
+func RecordResponseCacheResult(endpoint, result string) {
+	responseCacheCounter.WithLabelValues(endpoint, result).Inc()
+}
+
+// RecordDbSlowQuery increments the slow db commands counter.
+func RecordDbSlowQuery(dbname, command string) {
+	dbSlowQueryCounter.WithLabelValues(dbname, command).Inc()
+}
+
+// RecordHttpPanic increments the recovered http handler panics counter.
+func RecordHttpPanic(endpoint string) {
+	httpPanicCounter.WithLabelValues(endpoint).Inc()
+}
+
+// RecordShadowDivergence increments the shadow documents divergences counter.
+func RecordShadowDivergence(queueName, collection string) {
+	shadowDivergenceCounter.WithLabelValues(queueName, collection).Inc()
+}
+
+// RecordApiKeyQuotaExceeded increments the requests rejected over quota counter.
+func RecordApiKeyQuotaExceeded(apiKey string) {
+	apiKeyQuotaExceededCounter.WithLabelValues(apiKey).Inc()
+}
+
+// RecordQueueBackpressureRatio records the fraction of the queue workers
+// allowed to process messages.
+func RecordQueueBackpressureRatio(ratio float64) {
+	queueBackpressureRatioGauge.Set(ratio)
+}
+
+// RecordExpiryScannerRun increments the expiry scans counter.
+func RecordExpiryScannerRun(outcome Outcome) {
+	expiryScannerRunCounter.WithLabelValues(outcome.String()).Inc()
+}
+
+// RecordExpiryScannerTransitions adds the delegations transitioned to
+// unbonded by the expiry scanner.
+func RecordExpiryScannerTransitions(txType string, count int64) {
+	expiryScannerTransitionCounter.WithLabelValues(txType).Add(float64(count))
+}
+
+// RecordGeoGatedRequest increments the requests from the restricted
+// jurisdictions counter.
+func RecordGeoGatedRequest(country, action string) {
+	geoGatedRequestCounter.WithLabelValues(country, action).Inc()
+}
+
+// RecordLeaderElection records whether the replica is the leader of the
+// scheduled job.
+func RecordLeaderElection(job string, leader bool) {
+	leaderValue := 0.0
+	if leader {
+		leaderValue = 1
+	}
+	leaderElectionGauge.WithLabelValues(job).Set(leaderValue)
+}
+
+// RecordScheduledJobRun increments the scheduled job runs counter.
+func RecordScheduledJobRun(job string, outcome Outcome) {
+	scheduledJobRunCounter.WithLabelValues(job, outcome.String()).Inc()
+}
+
+// RecordSloBurnRate records the burn rate of the latency budget of the route.
+func RecordSloBurnRate(route, objective string, burnRate float64) {
+	sloBurnRateGauge.WithLabelValues(route, objective).Set(burnRate)
+}
+
+// RecordStakerPolicyViolation increments the staker policy violations counter.
+func RecordStakerPolicyViolation(rule string) {
+	stakerPolicyViolationCounter.WithLabelValues(rule).Inc()
+}
@@ -0,0 +1,131 @@
+package queueclient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// backpressureMinCommands is the number of db commands below which an
+	// interval says too little about the health of the db to be evaluated
+	backpressureMinCommands = 10
+	// backpressureRecoveryStep is the fraction of the workers given back
+	// after each healthy interval
+	backpressureRecoveryStep = 0.1
+	// backpressureMinRatio keeps a worker per queue at the lowest
+	backpressureMinRatio = 1.0 / 64
+)
+
+// Backpressure limits the number of workers of each queue processing messages
+// at once from the recent latency and failure rate of the db commands. The
+// allowed fraction of the workers is halved after each interval in which the
+// db struggled, and increased step by step while it's healthy. The messages
+// held back stay unacknowledged, hence the broker stops delivering once the
+// prefetch count of the consumer is reached.
+// A nil Backpressure doesn't limit the workers.
+type Backpressure struct {
+	cfg   *config.QueueBackpressureConfig
+	stats *db.CommandStats
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// ratio is the fraction of the workers allowed to process messages
+	ratio    float64
+	inFlight map[string]int
+}
+
+func NewBackpressure(cfg *config.QueueBackpressureConfig, stats *db.CommandStats) *Backpressure {
+	b := &Backpressure{cfg: cfg, stats: stats, ratio: 1, inFlight: make(map[string]int)}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// Start adjusts the allowed fraction of the workers every interval until the
+// context is done
+func (b *Backpressure) Start(ctx context.Context) {
+	metrics.RecordQueueBackpressureRatio(1)
+	go func() {
+		ticker := time.NewTicker(b.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				b.Adjust()
+			}
+		}
+	}()
+}
+
+// Adjust evaluates the db commands since the previous call and updates the
+// allowed fraction of the workers accordingly
+func (b *Backpressure) Adjust() {
+	commands, avgDuration, failureRate := b.stats.Snapshot()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	previous := b.ratio
+	struggling := commands >= backpressureMinCommands &&
+		(avgDuration > b.cfg.LatencyThreshold || failureRate > b.cfg.FailureRateThreshold)
+	if struggling {
+		b.ratio = math.Max(b.ratio/2, backpressureMinRatio)
+	} else {
+		b.ratio = math.Min(b.ratio+backpressureRecoveryStep, 1)
+	}
+	if b.ratio == previous {
+		return
+	}
+	if struggling {
+		log.Warn().Dur("avgDuration", avgDuration).Float64("failureRate", failureRate).
+			Float64("ratio", b.ratio).Msg("staking db is struggling, throttling the queue workers")
+	} else if b.ratio == 1 {
+		log.Info().Msg("staking db recovered, the queue workers are no longer throttled")
+	}
+	metrics.RecordQueueBackpressureRatio(b.ratio)
+	b.cond.Broadcast()
+}
+
+// Limit returns the number of workers currently allowed out of the given
+// number of workers
+func (b *Backpressure) Limit(workers int) int {
+	if b == nil {
+		return workers
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.limit(workers)
+}
+
+func (b *Backpressure) limit(workers int) int {
+	return max(1, int(math.Ceil(float64(workers)*b.ratio)))
+}
+
+// acquire waits until a worker of the queue is allowed to process a message
+func (b *Backpressure) acquire(queueName string, workers int) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.inFlight[queueName] >= b.limit(workers) {
+		b.cond.Wait()
+	}
+	b.inFlight[queueName]++
+}
+
+func (b *Backpressure) release(queueName string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.inFlight[queueName]--
+	b.cond.Broadcast()
+}
@@ -2,10 +2,18 @@ package queueclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/credentials"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/logging"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/tracing"
+	queuehandler "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 
@@ -18,28 +26,178 @@ type Queue struct {
 	ProcessingTimeout time.Duration
 	MaxRetryAttempts  int32
 	StatsQueueClient  client.QueueClient
+	// ArchiveEvent archives each consumed message along with its processing outcome
+	ArchiveEvent queuehandler.EventArchiver
+	// ReconnectCfg is nil if the broken queue connections are not recovered
+	ReconnectCfg *config.QueueReconnectConfig
+	// WorkersCfg is nil if each queue is processed by a single worker
+	WorkersCfg *config.QueueWorkersConfig
+	// Backpressure is nil if the workers are not throttled by the health of
+	// the staking db
+	Backpressure *Backpressure
+	// Credentials are the broker credentials, which are used by the next
+	// connections once rotated
+	Credentials *credentials.Holder
+	// ShadowCfg is nil if the messages are neither mirrored nor consumed from
+	// the shadow queues
+	ShadowCfg *config.ShadowConfig
+	// redeliverer is nil if the failed messages are requeued with the fixed
+	// requeue delay
+	redeliverer *DelayedRedeliverer
+	// shadowComparator is nil if the shadow documents are not compared
+	shadowComparator *shadowComparator
+	// reconnecting are the clients dialed, which are redialed when the
+	// credentials are rotated
+	reconnectingMu sync.Mutex
+	reconnecting   []*ReconnectingQueueClient
 }
 
-func New(ctx context.Context, cfg *queueConfig.QueueConfig, service *services.Services) *Queue {
-	statsQueueClient, err := client.NewQueueClient(
-		cfg, client.StakingStatsQueueName,
-	)
+func New(ctx context.Context, cfg *config.Config, service *services.Services) *Queue {
+	q := &Queue{
+		ProcessingTimeout: time.Duration(cfg.Queue.QueueProcessingTimeout) * time.Second,
+		MaxRetryAttempts:  cfg.Queue.MsgMaxRetryAttempts,
+		ArchiveEvent:      service.SharedService.ArchiveEvent,
+		ReconnectCfg:      cfg.QueueReconnect,
+		WorkersCfg:        cfg.QueueWorkers,
+		Credentials: credentials.NewHolder(credentials.Credentials{
+			Username: cfg.Queue.QueueUser,
+			Password: cfg.Queue.QueuePassword,
+		}),
+		ShadowCfg: cfg.Shadow,
+	}
+	if cfg.QueueBackpressure != nil {
+		q.Backpressure = NewBackpressure(cfg.QueueBackpressure, db.CommandStatsOf(cfg.StakingDb.DbName))
+		q.Backpressure.Start(ctx)
+	}
+	if cfg.QueueRedelivery != nil {
+		q.redeliverer = NewDelayedRedeliverer(cfg.Queue, cfg.QueueRedelivery, q.Credentials)
+	}
+	if cfg.Shadow.IsShadow() && cfg.Shadow.PrimaryDb != nil {
+		comparator, err := newShadowComparator(ctx, cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while creating shadow comparator")
+		}
+		q.shadowComparator = comparator
+	}
+
+	statsQueueClient, err := q.NewQueueClient(cfg.Queue, client.StakingStatsQueueName)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating StatsQueueClient")
 	}
+	q.StatsQueueClient = statsQueueClient
+
+	return q
+}
+
+// WorkerCount returns the number of workers processing the given queue
+func (q *Queue) WorkerCount(queueName string) int {
+	if q.WorkersCfg == nil {
+		return 1
+	}
+	return q.WorkersCfg.WorkerCount(queueName)
+}
+
+// NewQueueClient creates the client of the given queue. The client recovers
+// from broken connections if the queue reconnection is configured, and
+// requeues the failed messages with an exponential delay if the queue
+// redelivery is configured. The shadow deployment consumes the shadow queue
+// instead, which the primary deployment mirrors the messages into.
+func (q *Queue) NewQueueClient(cfg *queueConfig.QueueConfig, queueName string) (client.QueueClient, error) {
+	consumedQueueName := queueName
+	if q.ShadowCfg.IsShadow() {
+		consumedQueueName = q.ShadowCfg.QueueName(queueName)
+	}
+	queueClient, err := q.dial(cfg, consumedQueueName)
+	if err != nil {
+		return nil, err
+	}
+	if q.redeliverer != nil {
+		queueClient = &delayedRedeliveryQueueClient{QueueClient: queueClient, redeliverer: q.redeliverer}
+	}
+
+	if q.ShadowCfg.IsShadow() {
+		var onProcessed func(messageBody string)
+		if q.shadowComparator != nil {
+			onProcessed = q.shadowComparator.onProcessed(queueName)
+		}
+		queueClient = NewShadowQueueClient(queueClient, queueName, onProcessed)
+	}
+	// The stats events are emitted by the consumers of the other queues, the
+	// shadow deployment emits its own
+	if q.ShadowCfg.IsMirror() && queueName != client.StakingStatsQueueName {
+		mirror, err := q.dial(cfg, q.ShadowCfg.QueueName(queueName))
+		if err != nil {
+			return nil, err
+		}
+		queueClient = NewMirroringQueueClient(queueClient, mirror)
+	}
+	return queueClient, nil
+}
+
+// dial connects to the given queue, through a reconnecting client if the
+// queue reconnection is configured
+func (q *Queue) dial(cfg *queueConfig.QueueConfig, queueName string) (client.QueueClient, error) {
+	if q.ReconnectCfg == nil {
+		return client.NewQueueClient(q.withCredentials(cfg), queueName)
+	}
+	// The reconnections pick up the rotated credentials
+	reconnecting, err := NewReconnectingQueueClient(q.ReconnectCfg, queueName, func() (client.QueueClient, error) {
+		return client.NewQueueClient(q.withCredentials(cfg), queueName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	q.reconnectingMu.Lock()
+	q.reconnecting = append(q.reconnecting, reconnecting)
+	q.reconnectingMu.Unlock()
+	return reconnecting, nil
+}
+
+// RotateCredentials replaces the broker credentials and redials the queues
+// with them. The previous credentials are kept if the first queue cannot be
+// redialed, otherwise the queues left are redialed on the next rotation.
+func (q *Queue) RotateCredentials(ctx context.Context, newCredentials credentials.Credentials) error {
+	if q.ReconnectCfg == nil {
+		return errors.New("queue connections cannot be redialed without the queue reconnection")
+	}
+	q.reconnectingMu.Lock()
+	reconnecting := append([]*ReconnectingQueueClient(nil), q.reconnecting...)
+	q.reconnectingMu.Unlock()
+
+	previous := q.Credentials.Get()
+	q.Credentials.Set(newCredentials)
+	for i, queueClient := range reconnecting {
+		if err := queueClient.Redial(ctx); err != nil {
+			if i == 0 {
+				q.Credentials.Set(previous)
+			}
+			return fmt.Errorf("failed to redial queue %s: %w", queueClient.GetQueueName(), err)
+		}
+	}
+	if q.redeliverer != nil {
+		q.redeliverer.closeConnection()
+	}
+	return nil
+}
 
-	return &Queue{
-		ProcessingTimeout: time.Duration(cfg.QueueProcessingTimeout) * time.Second,
-		MaxRetryAttempts:  cfg.MsgMaxRetryAttempts,
-		StatsQueueClient:  statsQueueClient,
+// withCredentials returns a copy of the queue config with the current
+// credentials
+func (q *Queue) withCredentials(cfg *queueConfig.QueueConfig) *queueConfig.QueueConfig {
+	if q.Credentials == nil {
+		return cfg
 	}
+	current := q.Credentials.Get()
+	withCredentials := *cfg
+	withCredentials.QueueUser = current.Username
+	withCredentials.QueuePassword = current.Password
+	return &withCredentials
 }
 
 func attachLoggerContext(ctx context.Context, message client.QueueMessage, queueClient client.QueueClient) context.Context {
 	ctx = tracing.AttachTracingIntoContext(ctx)
 
 	traceId := ctx.Value(tracing.TraceIdKey)
-	return log.With().
+	return logging.Module(config.LogModuleQueue).With().
 		Str("receipt", message.Receipt).
 		Str("queueName", queueClient.GetQueueName()).
 		Interface("traceId", traceId).
@@ -48,8 +206,8 @@ func attachLoggerContext(ctx context.Context, message client.QueueMessage, queue
 
 func recordErrorLog(err *types.Error) {
 	if err.StatusCode >= http.StatusInternalServerError {
-		log.Error().Err(err).Msg("event processing failed with 5xx error")
+		logging.Module(config.LogModuleQueue).Error().Err(err).Msg("event processing failed with 5xx error")
 	} else {
-		log.Warn().Err(err).Msg("event processing failed with 4xx error")
+		logging.Module(config.LogModuleQueue).Warn().Err(err).Msg("event processing failed with 4xx error")
 	}
 }
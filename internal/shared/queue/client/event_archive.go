@@ -0,0 +1,62 @@
+package queueclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	queuehandler "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// The processing context may already be expired once the message has been
+// handled, the archival uses its own timeout instead.
+const archiveEventTimeout = 5 * time.Second
+
+// archivedEventKeys are the fields shared by the queue events that are
+// extracted to allow querying the archive
+type archivedEventKeys struct {
+	EventType        *int   `json:"event_type"`
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+}
+
+// archiveEvent saves the consumed message along with its processing outcome.
+// Failures are only logged as the archival must not affect the processing.
+func archiveEvent(
+	ctx context.Context, archiver queuehandler.EventArchiver, queueName, messageBody string,
+	attempts int32, outcome string, processingErr *types.Error, receivedAt time.Time,
+) {
+	if archiver == nil {
+		return
+	}
+	event := &dbmodel.EventDocument{
+		QueueName:   queueName,
+		Payload:     messageBody,
+		Attempt:     attempts,
+		Outcome:     outcome,
+		StatusCode:  http.StatusOK,
+		ReceivedAt:  receivedAt,
+		ProcessedAt: time.Now(),
+	}
+	// Malformed messages are archived as well, only without the keys
+	var keys archivedEventKeys
+	if err := json.Unmarshal([]byte(messageBody), &keys); err == nil {
+		event.EventType = keys.EventType
+		event.StakingTxHashHex = keys.StakingTxHashHex
+	}
+	if processingErr != nil {
+		event.StatusCode = processingErr.StatusCode
+		event.Error = processingErr.Error()
+	}
+
+	archiveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), archiveEventTimeout)
+	defer cancel()
+	if err := archiver(archiveCtx, event); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while archiving message")
+		metrics.RecordQueueOperationFailure("archiveEvent", queueName)
+	}
+}
@@ -2,12 +2,17 @@ package queueclient
 
 import (
 	"context"
+	"encoding/json"
+	"hash/fnv"
 	"net/http"
+	"sync"
 	"time"
 
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/tracing"
 	queuehandler "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/handler"
+	queueschema "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/schema"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-queue-client/client"
 	"github.com/rs/zerolog/log"
@@ -16,7 +21,8 @@ import (
 func StartQueueMessageProcessing(
 	queueClient client.QueueClient,
 	handler queuehandler.MessageHandler, unprocessableHandler queuehandler.UnprocessableMessageHandler,
-	maxRetryAttempts int32, processingTimeout time.Duration,
+	eventArchiver queuehandler.EventArchiver, backpressure *Backpressure,
+	maxRetryAttempts int32, processingTimeout time.Duration, workers int,
 ) {
 	messagesChan, err := queueClient.ReceiveMessages()
 	log.Info().Str("queueName", queueClient.GetQueueName()).Msg("start receiving messages from queue")
@@ -24,69 +30,174 @@ func StartQueueMessageProcessing(
 		log.Fatal().Err(err).Str("queueName", queueClient.GetQueueName()).Msg("error setting up message channel from queue")
 	}
 
+	// The messages are dispatched to the workers by the hash of their staking
+	// tx hash, so that the events of a delegation are processed in order while
+	// the events of different delegations are processed in parallel
+	partitions := make([]chan client.QueueMessage, workers)
+	var wg sync.WaitGroup
+	for i := range partitions {
+		partitions[i] = make(chan client.QueueMessage)
+		wg.Add(1)
+		go func(messages <-chan client.QueueMessage) {
+			defer wg.Done()
+			for message := range messages {
+				backpressure.acquire(queueClient.GetQueueName(), workers)
+				processMessage(
+					queueClient, message, handler, unprocessableHandler, eventArchiver,
+					maxRetryAttempts, processingTimeout,
+				)
+				backpressure.release(queueClient.GetQueueName())
+			}
+		}(partitions[i])
+	}
 	go func() {
+		var next int
 		for message := range messagesChan {
-			attempts := message.GetRetryAttempts()
-			// For each message, create a new context with a deadline or timeout
-			ctx, cancel := context.WithTimeout(context.Background(), processingTimeout)
-			ctx = attachLoggerContext(ctx, message, queueClient)
-			// Attach the tracingInfo for the message processing
-			_, err := tracing.WrapWithSpan[any](ctx, "message_processing", func() (any, *types.Error) {
-				timer := metrics.StartEventProcessingDurationTimer(queueClient.GetQueueName(), attempts)
-				// Process the message
-				err := handler(ctx, message.Body)
-				if err != nil {
-					timer(err.StatusCode)
-				} else {
-					timer(http.StatusOK)
-				}
-				return nil, err
-			})
-			if err != nil {
-				recordErrorLog(err)
-				// We will retry the message if it has not exceeded the max retry attempts
-				// otherwise, we will dump the message into db for manual inspection and remove from the queue
-				if attempts > maxRetryAttempts {
-					log.Ctx(ctx).Error().Err(err).
-						Msg("exceeded retry attempts, message will be dumped into db for manual inspection")
-					metrics.RecordUnprocessableEntity(queueClient.GetQueueName())
-					saveUnprocessableMsgErr := unprocessableHandler(ctx, message.Body, message.Receipt)
-					if saveUnprocessableMsgErr != nil {
-						log.Ctx(ctx).Error().Err(saveUnprocessableMsgErr).
-							Msg("error while saving unprocessable message")
-						metrics.RecordQueueOperationFailure("unprocessableHandler", queueClient.GetQueueName())
-						cancel()
-						continue
-					}
-				} else {
-					log.Ctx(ctx).Error().Err(err).
-						Msg("error while processing message from queue, will be requeued")
-					reQueueErr := queueClient.ReQueueMessage(ctx, message)
-					if reQueueErr != nil {
-						log.Ctx(ctx).Error().Err(reQueueErr).
-							Msg("error while requeuing message")
-						metrics.RecordQueueOperationFailure("reQueueMessage", queueClient.GetQueueName())
-					}
-					cancel()
-					continue
-				}
-			}
+			partitions[messagePartition(message.Body, workers, &next)] <- message
+		}
+		for _, partition := range partitions {
+			close(partition)
+		}
+		wg.Wait()
+		log.Info().Str("queueName", queueClient.GetQueueName()).Msg("stopped receiving messages from queue")
+	}()
+}
 
-			delErr := queueClient.DeleteMessage(message.Receipt)
-			if delErr != nil {
-				log.Ctx(ctx).Error().Err(delErr).
-					Msg("error while deleting message from queue")
-				metrics.RecordQueueOperationFailure("deleteMessage", queueClient.GetQueueName())
-			}
+// messagePartition returns the worker of the message from the hash of its
+// staking tx hash. The messages without a staking tx hash, e.g. the btc info
+// events, don't need to be ordered and are spread over the workers.
+func messagePartition(messageBody string, partitions int, next *int) int {
+	var key struct {
+		StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	}
+	if err := json.Unmarshal([]byte(messageBody), &key); err != nil || key.StakingTxHashHex == "" {
+		*next = (*next + 1) % partitions
+		return *next
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(key.StakingTxHashHex))
+	return int(hash.Sum32() % uint32(partitions))
+}
 
-			tracingInfo := ctx.Value(tracing.TracingInfoKey)
-			logEvent := log.Ctx(ctx).Debug()
-			if tracingInfo != nil {
-				logEvent = logEvent.Interface("tracingInfo", tracingInfo)
+// processMessage runs the handler of the message, then removes the message
+// from the queue. The failed message is requeued, or dumped into db once it
+// exceeded the max retry attempts.
+func processMessage(
+	queueClient client.QueueClient, message client.QueueMessage,
+	handler queuehandler.MessageHandler, unprocessableHandler queuehandler.UnprocessableMessageHandler,
+	eventArchiver queuehandler.EventArchiver,
+	maxRetryAttempts int32, processingTimeout time.Duration,
+) {
+	attempts := message.GetRetryAttempts()
+	receivedAt := time.Now()
+	// For each message, create a new context with a deadline or timeout
+	ctx, cancel := context.WithTimeout(context.Background(), processingTimeout)
+	defer cancel()
+	ctx = attachLoggerContext(ctx, message, queueClient)
+	// Malformed messages would fail on every attempt, hence they are
+	// dumped into db right away instead of being retried
+	schemaVersion, schemaErr := queueschema.ValidateMessage(queueClient.GetQueueName(), message.Body)
+	if schemaErr != nil {
+		handleSchemaViolation(
+			ctx, queueClient, message, unprocessableHandler, eventArchiver,
+			attempts, schemaErr, receivedAt,
+		)
+		return
+	}
+	if queueschema.HasSchema(queueClient.GetQueueName()) {
+		metrics.RecordQueueMessageSchemaVersion(queueClient.GetQueueName(), schemaVersion)
+	}
+	// Attach the tracingInfo for the message processing
+	_, err := tracing.WrapWithSpan[any](ctx, "message_processing", func() (any, *types.Error) {
+		timer := metrics.StartEventProcessingDurationTimer(queueClient.GetQueueName(), attempts)
+		// Process the message
+		err := handler(ctx, message.Body)
+		if err != nil {
+			timer(err.StatusCode)
+		} else {
+			timer(http.StatusOK)
+		}
+		return nil, err
+	})
+	outcome := dbmodel.EventOutcomeProcessed
+	if err != nil {
+		outcome = dbmodel.EventOutcomeRequeued
+		if attempts > maxRetryAttempts {
+			outcome = dbmodel.EventOutcomeUnprocessable
+		}
+	}
+	archiveEvent(
+		ctx, eventArchiver, queueClient.GetQueueName(), message.Body,
+		attempts, outcome, err, receivedAt,
+	)
+	if err != nil {
+		recordErrorLog(err)
+		// We will retry the message if it has not exceeded the max retry attempts
+		// otherwise, we will dump the message into db for manual inspection and remove from the queue
+		if attempts > maxRetryAttempts {
+			log.Ctx(ctx).Error().Err(err).
+				Msg("exceeded retry attempts, message will be dumped into db for manual inspection")
+			metrics.RecordUnprocessableEntity(queueClient.GetQueueName())
+			saveUnprocessableMsgErr := unprocessableHandler(ctx, message.Body, message.Receipt, err.Error())
+			if saveUnprocessableMsgErr != nil {
+				log.Ctx(ctx).Error().Err(saveUnprocessableMsgErr).
+					Msg("error while saving unprocessable message")
+				metrics.RecordQueueOperationFailure("unprocessableHandler", queueClient.GetQueueName())
+				return
 			}
-			logEvent.Msg("message processed successfully")
-			cancel()
+		} else {
+			log.Ctx(ctx).Error().Err(err).
+				Msg("error while processing message from queue, will be requeued")
+			metrics.RecordQueueRequeue(queueClient.GetQueueName())
+			reQueueErr := queueClient.ReQueueMessage(ctx, message)
+			if reQueueErr != nil {
+				log.Ctx(ctx).Error().Err(reQueueErr).
+					Msg("error while requeuing message")
+				metrics.RecordQueueOperationFailure("reQueueMessage", queueClient.GetQueueName())
+			}
+			return
 		}
-		log.Info().Str("queueName", queueClient.GetQueueName()).Msg("stopped receiving messages from queue")
-	}()
+	}
+
+	delErr := queueClient.DeleteMessage(message.Receipt)
+	if delErr != nil {
+		log.Ctx(ctx).Error().Err(delErr).
+			Msg("error while deleting message from queue")
+		metrics.RecordQueueOperationFailure("deleteMessage", queueClient.GetQueueName())
+	}
+
+	tracingInfo := ctx.Value(tracing.TracingInfoKey)
+	logEvent := log.Ctx(ctx).Debug()
+	if tracingInfo != nil {
+		logEvent = logEvent.Interface("tracingInfo", tracingInfo)
+	}
+	logEvent.Msg("message processed successfully")
+}
+
+// handleSchemaViolation dumps the message that does not match the schema of
+// its queue into db along with the violation and removes it from the queue.
+// The message is left in the queue if it cannot be dumped into db.
+func handleSchemaViolation(
+	ctx context.Context, queueClient client.QueueClient, message client.QueueMessage,
+	unprocessableHandler queuehandler.UnprocessableMessageHandler, eventArchiver queuehandler.EventArchiver,
+	attempts int32, schemaErr error, receivedAt time.Time,
+) {
+	log.Ctx(ctx).Error().Err(schemaErr).
+		Msg("message does not match the queue schema, it will be dumped into db for manual inspection")
+	metrics.RecordUnprocessableEntity(queueClient.GetQueueName())
+	archiveEvent(
+		ctx, eventArchiver, queueClient.GetQueueName(), message.Body, attempts,
+		dbmodel.EventOutcomeUnprocessable,
+		types.NewError(http.StatusBadRequest, types.SchemaViolation, schemaErr), receivedAt,
+	)
+	saveErr := unprocessableHandler(ctx, message.Body, message.Receipt, schemaErr.Error())
+	if saveErr != nil {
+		log.Ctx(ctx).Error().Err(saveErr).Msg("error while saving unprocessable message")
+		metrics.RecordQueueOperationFailure("unprocessableHandler", queueClient.GetQueueName())
+		return
+	}
+	if delErr := queueClient.DeleteMessage(message.Receipt); delErr != nil {
+		log.Ctx(ctx).Error().Err(delErr).Msg("error while deleting message from queue")
+		metrics.RecordQueueOperationFailure("deleteMessage", queueClient.GetQueueName())
+	}
 }
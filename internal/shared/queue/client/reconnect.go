@@ -0,0 +1,335 @@
+package queueclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/rs/zerolog/log"
+)
+
+// The receipts handed out are prefixed with the generation of the underlying
+// client, as delivery tags are only valid on the channel they come from.
+const receiptSeparator = ":"
+
+var errStaleReceipt = errors.New(
+	"message was delivered on a closed channel, it will be redelivered by the broker",
+)
+
+// disconnectedQueues holds the queues currently reconnecting along with the
+// time they got disconnected
+var (
+	disconnectedQueuesMu sync.RWMutex
+	disconnectedQueues   = make(map[string]time.Time)
+)
+
+// QueueClientDialer creates a new client connected to the queue, declaring
+// the queue if it does not exist
+type QueueClientDialer func() (client.QueueClient, error)
+
+// ReconnectingQueueClient wraps a queue client and replaces it with a newly
+// dialed one, which re-declares the queues and the consumer, once the
+// connection or the channel to the broker is broken.
+type ReconnectingQueueClient struct {
+	cfg       *config.QueueReconnectConfig
+	queueName string
+	dial      QueueClientDialer
+
+	mu                sync.RWMutex
+	current           client.QueueClient
+	generation        uint64
+	disconnectedSince time.Time
+	stopped           bool
+	stopCh            chan struct{}
+	// consuming is set once the messages are received, the redialed clients
+	// are then handed over to the consumer through redialCh
+	consuming bool
+	redialCh  chan client.QueueClient
+}
+
+func NewReconnectingQueueClient(
+	cfg *config.QueueReconnectConfig, queueName string, dial QueueClientDialer,
+) (*ReconnectingQueueClient, error) {
+	current, err := dial()
+	if err != nil {
+		return nil, err
+	}
+	metrics.RecordQueueConnected(queueName, true)
+	return &ReconnectingQueueClient{
+		cfg:       cfg,
+		queueName: queueName,
+		dial:      dial,
+		current:   current,
+		stopCh:    make(chan struct{}),
+		redialCh:  make(chan client.QueueClient),
+	}, nil
+}
+
+// ReceiveMessages consumes the queue until the client is stopped. A broken
+// consumer is transparently replaced once the client is reconnected.
+func (c *ReconnectingQueueClient) ReceiveMessages() (<-chan client.QueueMessage, error) {
+	c.mu.Lock()
+	current, generation := c.current, c.generation
+	c.consuming = true
+	c.mu.Unlock()
+	msgs, err := current.ReceiveMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	output := make(chan client.QueueMessage)
+	go func() {
+		defer close(output)
+		for {
+			select {
+			case message, ok := <-msgs:
+				if !ok {
+					if c.isStopped() {
+						return
+					}
+					generation, msgs, ok = c.reconnect()
+					if !ok {
+						return
+					}
+					continue
+				}
+				message.Receipt = strconv.FormatUint(generation, 10) + receiptSeparator + message.Receipt
+				select {
+				case output <- message:
+				case <-c.stopCh:
+					return
+				}
+			case newClient := <-c.redialCh:
+				newMsgs, err := newClient.ReceiveMessages()
+				if err != nil {
+					// The current client is kept
+					log.Error().Err(err).Str("queueName", c.queueName).Msg("failed to consume the redialed queue")
+					_ = newClient.Stop()
+					continue
+				}
+				newGeneration, ok := c.replace(newClient)
+				if !ok {
+					return
+				}
+				generation, msgs = newGeneration, newMsgs
+			}
+		}
+	}()
+	return output, nil
+}
+
+// Redial replaces the client by a newly dialed one, e.g. to authenticate
+// with rotated credentials. The current client is kept if the dial fails. The
+// messages delivered by the current client can no longer be acknowledged and
+// are redelivered by the broker.
+func (c *ReconnectingQueueClient) Redial(ctx context.Context) error {
+	newClient, err := c.dial()
+	if err != nil {
+		return err
+	}
+	c.mu.RLock()
+	consuming := c.consuming
+	c.mu.RUnlock()
+	if !consuming {
+		c.replace(newClient)
+		return nil
+	}
+
+	// The consumer starts consuming from the new client before replacing
+	// the current one
+	select {
+	case c.redialCh <- newClient:
+		return nil
+	case <-c.stopCh:
+	case <-ctx.Done():
+	}
+	_ = newClient.Stop()
+	return ctx.Err()
+}
+
+// replace makes the new client the current one and stops the previous one.
+// It returns the generation of the new client, or false if the client is
+// stopped in which case the new client is stopped instead.
+func (c *ReconnectingQueueClient) replace(newClient client.QueueClient) (uint64, bool) {
+	c.mu.Lock()
+	if c.stopped {
+		c.mu.Unlock()
+		_ = newClient.Stop()
+		return 0, false
+	}
+	previous := c.current
+	c.current = newClient
+	c.generation++
+	generation := c.generation
+	c.mu.Unlock()
+
+	_ = previous.Stop()
+	return generation, true
+}
+
+// reconnect dials the queue with an exponential backoff until it succeeds or
+// the client is stopped, and starts consuming from the new client.
+func (c *ReconnectingQueueClient) reconnect() (uint64, <-chan client.QueueMessage, bool) {
+	c.setDisconnected(time.Now())
+	log.Warn().Str("queueName", c.queueName).Msg("queue connection lost, reconnecting")
+
+	backoff := c.cfg.InitialBackoff
+	for {
+		select {
+		case <-c.stopCh:
+			return 0, nil, false
+		case <-time.After(backoff):
+		}
+
+		newClient, err := c.dial()
+		var msgs <-chan client.QueueMessage
+		if err == nil {
+			msgs, err = newClient.ReceiveMessages()
+			if err != nil {
+				_ = newClient.Stop()
+			}
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("queueName", c.queueName).Dur("backoff", backoff).
+				Msg("failed to reconnect to queue")
+			metrics.RecordQueueOperationFailure("reconnect", c.queueName)
+			backoff = min(backoff*2, c.cfg.MaxBackoff)
+			continue
+		}
+
+		// The previous connection is already broken, hence the error of
+		// stopping it is expected
+		generation, ok := c.replace(newClient)
+		if !ok {
+			return 0, nil, false
+		}
+		c.setDisconnected(time.Time{})
+		log.Info().Str("queueName", c.queueName).Msg("reconnected to queue")
+		return generation, msgs, true
+	}
+}
+
+func (c *ReconnectingQueueClient) SendMessage(ctx context.Context, messageBody string) error {
+	return c.getCurrent().SendMessage(ctx, messageBody)
+}
+
+func (c *ReconnectingQueueClient) DeleteMessage(receipt string) error {
+	current, deliveryReceipt, err := c.resolveReceipt(receipt)
+	if err != nil {
+		return err
+	}
+	return current.DeleteMessage(deliveryReceipt)
+}
+
+func (c *ReconnectingQueueClient) ReQueueMessage(ctx context.Context, message client.QueueMessage) error {
+	current, deliveryReceipt, err := c.resolveReceipt(message.Receipt)
+	if err != nil {
+		return err
+	}
+	message.Receipt = deliveryReceipt
+	return current.ReQueueMessage(ctx, message)
+}
+
+// Ping reports the queue as healthy while reconnecting, unless it has been
+// disconnected for longer than the configured max disconnected duration.
+func (c *ReconnectingQueueClient) Ping(ctx context.Context) error {
+	c.mu.RLock()
+	current, disconnectedSince := c.current, c.disconnectedSince
+	c.mu.RUnlock()
+	if disconnectedSince.IsZero() {
+		return current.Ping(ctx)
+	}
+	if c.cfg.MaxDisconnectedDuration > 0 && time.Since(disconnectedSince) > c.cfg.MaxDisconnectedDuration {
+		return fmt.Errorf("queue %s has been disconnected since %s", c.queueName, disconnectedSince.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (c *ReconnectingQueueClient) Stop() error {
+	c.mu.Lock()
+	if c.stopped {
+		c.mu.Unlock()
+		return nil
+	}
+	c.stopped = true
+	close(c.stopCh)
+	current := c.current
+	c.mu.Unlock()
+
+	c.setDisconnected(time.Time{})
+	return current.Stop()
+}
+
+func (c *ReconnectingQueueClient) GetQueueName() string {
+	return c.queueName
+}
+
+func (c *ReconnectingQueueClient) getCurrent() client.QueueClient {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current
+}
+
+func (c *ReconnectingQueueClient) isStopped() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.stopped
+}
+
+// resolveReceipt returns the client the message was delivered by along with
+// the receipt of the underlying client. Messages delivered before a
+// reconnection can no longer be acknowledged.
+func (c *ReconnectingQueueClient) resolveReceipt(receipt string) (client.QueueClient, string, error) {
+	generationStr, deliveryReceipt, found := strings.Cut(receipt, receiptSeparator)
+	if !found {
+		return nil, "", fmt.Errorf("invalid receipt %s", receipt)
+	}
+	generation, err := strconv.ParseUint(generationStr, 10, 64)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid receipt %s: %w", receipt, err)
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if generation != c.generation {
+		return nil, "", errStaleReceipt
+	}
+	return c.current, deliveryReceipt, nil
+}
+
+// setDisconnected records the time the queue got disconnected, a zero time
+// marks the queue as connected.
+func (c *ReconnectingQueueClient) setDisconnected(since time.Time) {
+	c.mu.Lock()
+	c.disconnectedSince = since
+	c.mu.Unlock()
+
+	disconnectedQueuesMu.Lock()
+	if since.IsZero() {
+		delete(disconnectedQueues, c.queueName)
+	} else {
+		disconnectedQueues[c.queueName] = since
+	}
+	disconnectedQueuesMu.Unlock()
+	metrics.RecordQueueConnected(c.queueName, since.IsZero())
+}
+
+// DisconnectedQueueNames returns the sorted names of the queues currently
+// reconnecting to the broker
+func DisconnectedQueueNames() []string {
+	disconnectedQueuesMu.RLock()
+	defer disconnectedQueuesMu.RUnlock()
+	names := make([]string, 0, len(disconnectedQueues))
+	for name := range disconnectedQueues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,144 @@
+package queueclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/credentials"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	queueConfig "github.com/babylonlabs-io/staking-queue-client/config"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// processingAttemptsHeader is the header the queue client reads the number of
+// processing attempts from
+const processingAttemptsHeader = "x-processing-attempts"
+
+// DelayedRedeliverer publishes the failed messages into delay queues, one per
+// delay, which dead-letter the messages back into their queue once the delay
+// has elapsed. A queue per delay is required as the messages only expire from
+// the head of a queue, a single queue would hold back the shorter delays.
+type DelayedRedeliverer struct {
+	cfg         *config.QueueRedeliveryConfig
+	url         string
+	credentials *credentials.Holder
+	queueType   string
+
+	mu       sync.Mutex
+	conn     *amqp091.Connection
+	ch       *amqp091.Channel
+	declared map[string]bool
+}
+
+func NewDelayedRedeliverer(
+	queueCfg *queueConfig.QueueConfig, cfg *config.QueueRedeliveryConfig, creds *credentials.Holder,
+) *DelayedRedeliverer {
+	return &DelayedRedeliverer{
+		cfg:         cfg,
+		url:         queueCfg.Url,
+		credentials: creds,
+		queueType:   queueCfg.QueueType,
+	}
+}
+
+// Redeliver publishes the message into the delay queue matching its number
+// of processing attempts.
+func (r *DelayedRedeliverer) Redeliver(ctx context.Context, queueName string, message client.QueueMessage) error {
+	delay := r.cfg.DelayFor(message.GetRetryAttempts())
+	delayQueueName := fmt.Sprintf("%s_delay_%dms", queueName, delay.Milliseconds())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ch, err := r.channel()
+	if err != nil {
+		return err
+	}
+	if !r.declared[delayQueueName] {
+		_, err := ch.QueueDeclare(delayQueueName, true, false, false, false, amqp091.Table{
+			"x-queue-type":              r.queueType,
+			"x-message-ttl":             delay.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queueName,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to declare delay queue %s: %w", delayQueueName, err)
+		}
+		r.declared[delayQueueName] = true
+	}
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, "", delayQueueName, true, false, amqp091.Publishing{
+		DeliveryMode: amqp091.Persistent,
+		ContentType:  "text/plain",
+		Body:         []byte(message.Body),
+		Headers:      amqp091.Table{processingAttemptsHeader: message.IncrementRetryAttempts()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish message into delay queue %s: %w", delayQueueName, err)
+	}
+	confirmed, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to confirm message published into delay queue %s: %w", delayQueueName, err)
+	}
+	if !confirmed {
+		return fmt.Errorf("message not confirmed when publishing into delay queue %s", delayQueueName)
+	}
+	return nil
+}
+
+// channel returns the publishing channel, the connection and the channel are
+// opened again if closed.
+func (r *DelayedRedeliverer) channel() (*amqp091.Channel, error) {
+	if r.ch != nil && !r.ch.IsClosed() {
+		return r.ch, nil
+	}
+	if r.conn == nil || r.conn.IsClosed() {
+		current := r.credentials.Get()
+		conn, err := amqp091.Dial(fmt.Sprintf("amqp://%s:%s@%s", current.Username, current.Password, r.url))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to queue: %w", err)
+		}
+		r.conn = conn
+	}
+	ch, err := r.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("failed to enable publish confirms: %w", err)
+	}
+	r.ch = ch
+	// The declarations are redone in case the broker lost them
+	r.declared = make(map[string]bool)
+	return ch, nil
+}
+
+// closeConnection closes the publishing connection, the next message opens a
+// new one with the current credentials
+func (r *DelayedRedeliverer) closeConnection() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conn != nil {
+		_ = r.conn.Close()
+	}
+	r.conn, r.ch = nil, nil
+}
+
+// delayedRedeliveryQueueClient requeues the failed messages through the
+// delayed redeliverer instead of the fixed delay queue of the queue client.
+type delayedRedeliveryQueueClient struct {
+	client.QueueClient
+	redeliverer *DelayedRedeliverer
+}
+
+func (c *delayedRedeliveryQueueClient) ReQueueMessage(ctx context.Context, message client.QueueMessage) error {
+	if err := c.redeliverer.Redeliver(ctx, c.GetQueueName(), message); err != nil {
+		return fmt.Errorf("failed to requeue message: %w", err)
+	}
+	if err := c.DeleteMessage(message.Receipt); err != nil {
+		return fmt.Errorf("failed to delete message while requeuing: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package queueclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// shadowMirrorTimeout bounds the copy of a message into its shadow queue,
+	// which holds back the processing of the message
+	shadowMirrorTimeout  = 5 * time.Second
+	shadowCompareTimeout = 30 * time.Second
+)
+
+// MirroringQueueClient copies each message received for the first time into
+// the shadow queue, before it's processed. The redelivered messages are not
+// copied again, the shadow deployment retries its own failures.
+type MirroringQueueClient struct {
+	client.QueueClient
+	mirror client.QueueClient
+}
+
+func NewMirroringQueueClient(queueClient, mirror client.QueueClient) *MirroringQueueClient {
+	return &MirroringQueueClient{QueueClient: queueClient, mirror: mirror}
+}
+
+func (c *MirroringQueueClient) ReceiveMessages() (<-chan client.QueueMessage, error) {
+	messages, err := c.QueueClient.ReceiveMessages()
+	if err != nil {
+		return nil, err
+	}
+	mirrored := make(chan client.QueueMessage)
+	go func() {
+		defer close(mirrored)
+		for message := range messages {
+			if message.GetRetryAttempts() == 0 {
+				c.mirrorMessage(message)
+			}
+			mirrored <- message
+		}
+	}()
+	return mirrored, nil
+}
+
+// mirrorMessage never fails the processing of the message, the shadow
+// deployment misses the message instead
+func (c *MirroringQueueClient) mirrorMessage(message client.QueueMessage) {
+	ctx, cancel := context.WithTimeout(context.Background(), shadowMirrorTimeout)
+	defer cancel()
+	if err := c.mirror.SendMessage(ctx, message.Body); err != nil {
+		log.Error().Err(err).Str("queueName", c.GetQueueName()).
+			Str("shadowQueueName", c.mirror.GetQueueName()).
+			Msg("error while mirroring message into shadow queue")
+		metrics.RecordQueueOperationFailure("shadowMirror", c.GetQueueName())
+	}
+}
+
+func (c *MirroringQueueClient) Stop() error {
+	return errors.Join(c.QueueClient.Stop(), c.mirror.Stop())
+}
+
+// ShadowQueueClient consumes the shadow queue of a queue under the name of
+// the queue, so that the schemas, the workers and the metrics of the queue
+// apply. The onProcessed callback is called with the body of each message
+// removed from the shadow queue, i.e. either processed or unprocessable.
+type ShadowQueueClient struct {
+	client.QueueClient
+	queueName   string
+	onProcessed func(messageBody string)
+	// bodies holds the body of the messages in flight, keyed by receipt
+	bodies sync.Map
+}
+
+func NewShadowQueueClient(
+	queueClient client.QueueClient, queueName string, onProcessed func(messageBody string),
+) *ShadowQueueClient {
+	return &ShadowQueueClient{QueueClient: queueClient, queueName: queueName, onProcessed: onProcessed}
+}
+
+func (c *ShadowQueueClient) GetQueueName() string {
+	return c.queueName
+}
+
+func (c *ShadowQueueClient) ReceiveMessages() (<-chan client.QueueMessage, error) {
+	messages, err := c.QueueClient.ReceiveMessages()
+	if err != nil || c.onProcessed == nil {
+		return messages, err
+	}
+	tracked := make(chan client.QueueMessage)
+	go func() {
+		defer close(tracked)
+		for message := range messages {
+			c.bodies.Store(message.Receipt, message.Body)
+			tracked <- message
+		}
+	}()
+	return tracked, nil
+}
+
+func (c *ShadowQueueClient) DeleteMessage(receipt string) error {
+	if err := c.QueueClient.DeleteMessage(receipt); err != nil {
+		return err
+	}
+	if body, ok := c.bodies.LoadAndDelete(receipt); ok {
+		c.onProcessed(body.(string))
+	}
+	return nil
+}
+
+func (c *ShadowQueueClient) ReQueueMessage(ctx context.Context, message client.QueueMessage) error {
+	c.bodies.Delete(message.Receipt)
+	return c.QueueClient.ReQueueMessage(ctx, message)
+}
+
+// shadowComparator logs the divergences between the documents written by
+// the shadow deployment into its sandbox db and the ones of the primary
+// deployment
+type shadowComparator struct {
+	cfg           *config.ShadowConfig
+	sandbox       *dbclient.MongoClient
+	sandboxDbName string
+	primary       *dbclient.MongoClient
+	ignoredFields map[string]bool
+}
+
+func newShadowComparator(ctx context.Context, cfg *config.Config) (*shadowComparator, error) {
+	sandbox, err := dbclient.NewMongoClient(ctx, cfg.StakingDb)
+	if err != nil {
+		return nil, err
+	}
+	primary, err := dbclient.NewMongoClient(ctx, cfg.Shadow.PrimaryDb)
+	if err != nil {
+		return nil, err
+	}
+	ignoredFields := make(map[string]bool, len(cfg.Shadow.IgnoredFields))
+	for _, field := range cfg.Shadow.IgnoredFields {
+		ignoredFields[field] = true
+	}
+	return &shadowComparator{
+		cfg:           cfg.Shadow,
+		sandbox:       sandbox,
+		sandboxDbName: cfg.StakingDb.DbName,
+		primary:       primary,
+		ignoredFields: ignoredFields,
+	}, nil
+}
+
+// onProcessed returns the callback comparing the documents of the events of
+// the given queue once the compare delay has elapsed. The events without
+// staking tx hash are not compared.
+func (c *shadowComparator) onProcessed(queueName string) func(messageBody string) {
+	return func(messageBody string) {
+		var event struct {
+			StakingTxHashHex string `json:"staking_tx_hash_hex"`
+		}
+		if err := json.Unmarshal([]byte(messageBody), &event); err != nil || event.StakingTxHashHex == "" {
+			return
+		}
+		time.AfterFunc(c.cfg.CompareDelay, func() {
+			c.compare(queueName, event.StakingTxHashHex)
+		})
+	}
+}
+
+func (c *shadowComparator) compare(queueName, stakingTxHashHex string) {
+	ctx, cancel := context.WithTimeout(context.Background(), shadowCompareTimeout)
+	defer cancel()
+	for _, collection := range c.cfg.ComparedCollections {
+		sandboxDocument, err := findShadowDocument(
+			ctx, c.sandbox.Database(c.sandboxDbName), collection, stakingTxHashHex,
+		)
+		if err != nil {
+			log.Error().Err(err).Str("collection", collection).Str("stakingTxHashHex", stakingTxHashHex).
+				Msg("error while fetching sandbox document")
+			continue
+		}
+		primaryDocument, err := findShadowDocument(
+			ctx, c.primary.Database(c.cfg.PrimaryDb.DbName), collection, stakingTxHashHex,
+		)
+		if err != nil {
+			log.Error().Err(err).Str("collection", collection).Str("stakingTxHashHex", stakingTxHashHex).
+				Msg("error while fetching primary document")
+			continue
+		}
+		fields := DiffShadowDocuments(sandboxDocument, primaryDocument, c.ignoredFields)
+		if len(fields) == 0 {
+			continue
+		}
+		metrics.RecordShadowDivergence(queueName, collection)
+		log.Warn().Str("queueName", queueName).Str("collection", collection).
+			Str("stakingTxHashHex", stakingTxHashHex).Strs("fields", fields).
+			Bool("missingInSandbox", sandboxDocument == nil).
+			Bool("missingInPrimary", primaryDocument == nil).
+			Msg("shadow document diverges from the primary deployment")
+	}
+}
+
+// findShadowDocument returns the document of the staking tx, or nil if there
+// is none
+func findShadowDocument(
+	ctx context.Context, database *mongo.Database, collection, stakingTxHashHex string,
+) (bson.M, error) {
+	var document bson.M
+	err := database.Collection(collection).FindOne(ctx, bson.M{"_id": stakingTxHashHex}).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	return document, err
+}
+
+// DiffShadowDocuments returns the sorted fields whose values differ between
+// the sandbox and the primary documents, a missing document having no fields
+func DiffShadowDocuments(sandbox, primary bson.M, ignoredFields map[string]bool) []string {
+	fields := make(map[string]bool, len(sandbox))
+	for field := range sandbox {
+		fields[field] = true
+	}
+	for field := range primary {
+		fields[field] = true
+	}
+	var diff []string
+	for field := range fields {
+		if ignoredFields[field] {
+			continue
+		}
+		sandboxValue, inSandbox := sandbox[field]
+		primaryValue, inPrimary := primary[field]
+		if inSandbox != inPrimary || !reflect.DeepEqual(sandboxValue, primaryValue) {
+			diff = append(diff, field)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
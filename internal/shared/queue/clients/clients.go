@@ -3,22 +3,26 @@ package queueclients
 import (
 	"context"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/credentials"
 	queueclient "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/client"
 	queuehandler "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/handler"
 	queuehandlers "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/handlers"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
 	v1queueclient "github.com/babylonlabs-io/staking-api-service/internal/v1/queue/client"
 	v2queueclient "github.com/babylonlabs-io/staking-api-service/internal/v2/queue/client"
-	queueConfig "github.com/babylonlabs-io/staking-queue-client/config"
 	"github.com/rs/zerolog/log"
 )
 
 type QueueClients struct {
 	V1QueueClient *v1queueclient.V1QueueClient
 	V2QueueClient *v2queueclient.V2QueueClient
+	// Credentials are the broker credentials shared by the queue clients
+	Credentials *credentials.Holder
+	queue       *queueclient.Queue
 }
 
-func New(ctx context.Context, cfg *queueConfig.QueueConfig, services *services.Services) *QueueClients {
+func New(ctx context.Context, cfg *config.Config, services *services.Services) *QueueClients {
 	queueClient := queueclient.New(ctx, cfg, services)
 	queueHandler := queuehandler.New(queueClient.StatsQueueClient.SendMessage)
 	queueHandlers, err := queuehandlers.New(services, queueHandler)
@@ -26,17 +30,39 @@ func New(ctx context.Context, cfg *queueConfig.QueueConfig, services *services.S
 		log.Fatal().Err(err).Msg("error while setting up queue handlers")
 	}
 
-	v1QueueClient := v1queueclient.New(cfg, queueHandlers.V1QueueHandler, queueClient)
-	v2QueueClient := v2queueclient.New(cfg, queueHandlers.V2QueueHandler, queueClient)
+	v1QueueClient := v1queueclient.New(cfg.Queue, queueHandlers.V1QueueHandler, queueClient)
+	v2QueueClient := v2queueclient.New(cfg.Queue, queueHandlers.V2QueueHandler, queueClient)
 
 	return &QueueClients{
 		V1QueueClient: v1QueueClient,
 		V2QueueClient: v2QueueClient,
+		Credentials:   queueClient.Credentials,
+		queue:         queueClient,
 	}
 }
 
+// RotateCredentials redials the queues with the new broker credentials
+func (q *QueueClients) RotateCredentials(ctx context.Context, newCredentials credentials.Credentials) error {
+	return q.queue.RotateCredentials(ctx, newCredentials)
+}
+
 func (q *QueueClients) StartReceivingMessages() {
 	log.Printf("Starting to receive messages from queue clients")
 	q.V1QueueClient.StartReceivingMessages()
 	q.V2QueueClient.StartReceivingMessages()
 }
+
+// GetConsumedQueueNames returns the names of all the queues being consumed,
+// i.e. the names of the shadow queues on the shadow deployment
+func (q *QueueClients) GetConsumedQueueNames() []string {
+	names := append(
+		q.V1QueueClient.GetConsumedQueueNames(),
+		q.V2QueueClient.GetConsumedQueueNames()...,
+	)
+	if shadowCfg := q.V1QueueClient.ShadowCfg; shadowCfg.IsShadow() {
+		for i, name := range names {
+			names[i] = shadowCfg.QueueName(name)
+		}
+	}
+	return names
+}
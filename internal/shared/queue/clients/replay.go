@@ -0,0 +1,76 @@
+package queueclients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	queueClient "github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/rs/zerolog/log"
+)
+
+type genericEvent struct {
+	EventType queueClient.EventType `json:"event_type"`
+}
+
+// ReplayUnprocessableMessages sends the unprocessable messages back into
+// their queue and removes them from the db. It returns the number of
+// replayed messages, the replay stops at the first failure. In dry run, the
+// messages are only checked to have a queue and the number of messages which
+// would be replayed is returned.
+func (q *QueueClients) ReplayUnprocessableMessages(ctx context.Context, db dbclient.DBClient, dryRun bool) (int, error) {
+	unprocessableMessages, err := db.FindUnprocessableMessages(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve unprocessable messages: %w", err)
+	}
+
+	for i, msg := range unprocessableMessages {
+		var event genericEvent
+		if err := json.Unmarshal([]byte(msg.MessageBody), &event); err != nil {
+			return i, fmt.Errorf("failed to unmarshal event message: %w", err)
+		}
+		queue, err := q.eventQueue(event)
+		if err != nil {
+			return i, fmt.Errorf("failed to process message: %w", err)
+		}
+		if dryRun {
+			continue
+		}
+
+		if err := queue.SendMessage(ctx, msg.MessageBody); err != nil {
+			return i, fmt.Errorf("failed to process message: %w", err)
+		}
+
+		if err := db.DeleteUnprocessableMessage(ctx, msg.Receipt); err != nil {
+			return i, fmt.Errorf("failed to delete unprocessable message: %w", err)
+		}
+	}
+
+	if dryRun {
+		return len(unprocessableMessages), nil
+	}
+	log.Ctx(ctx).Info().Int("count", len(unprocessableMessages)).
+		Msg("Reprocessing of unprocessable messages completed.")
+	return len(unprocessableMessages), nil
+}
+
+// eventQueue returns the queue of the EventType of the event message.
+func (q *QueueClients) eventQueue(event genericEvent) (queueClient.QueueClient, error) {
+	switch event.EventType {
+	case queueClient.ActiveStakingEventType:
+		return q.V1QueueClient.ActiveStakingQueueClient, nil
+	case queueClient.UnbondingStakingEventType:
+		return q.V1QueueClient.UnbondingStakingQueueClient, nil
+	case queueClient.WithdrawStakingEventType:
+		return q.V1QueueClient.WithdrawStakingQueueClient, nil
+	case queueClient.ExpiredStakingEventType:
+		return q.V1QueueClient.ExpiredStakingQueueClient, nil
+	case queueClient.StatsEventType:
+		return q.V1QueueClient.StatsQueueClient, nil
+	case queueClient.BtcInfoEventType:
+		return q.V1QueueClient.BtcInfoQueueClient, nil
+	default:
+		return nil, fmt.Errorf("unknown event type: %v", event.EventType)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	queueschema "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/schema"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	queueclient "github.com/babylonlabs-io/staking-queue-client/client"
 	"github.com/rs/zerolog/log"
@@ -15,7 +17,8 @@ type QueueHandler struct {
 }
 
 type MessageHandler func(ctx context.Context, messageBody string) *types.Error
-type UnprocessableMessageHandler func(ctx context.Context, messageBody, receipt string) *types.Error
+type UnprocessableMessageHandler func(ctx context.Context, messageBody, receipt, reason string) *types.Error
+type EventArchiver func(ctx context.Context, event *dbmodel.EventDocument) *types.Error
 
 func New(
 	emitStatsEvent func(ctx context.Context, messageBody string) error,
@@ -31,6 +34,12 @@ func (qh *QueueHandler) EmitStatsEvent(ctx context.Context, statsEvent queueclie
 		log.Ctx(ctx).Err(err).Msg("Failed to marshal the stats event")
 		return types.NewError(http.StatusBadRequest, types.BadRequest, err)
 	}
+	// Ensures the emitted schema version is one of the versions read by the
+	// consumers
+	if _, err := queueschema.ValidateMessage(queueclient.StakingStatsQueueName, string(jsonData)); err != nil {
+		log.Ctx(ctx).Err(err).Msg("The stats event does not match the queue schema")
+		return types.NewError(http.StatusInternalServerError, types.SchemaViolation, err)
+	}
 
 	err = qh.emitStatsEvent(ctx, string(jsonData))
 
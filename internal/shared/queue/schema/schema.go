@@ -0,0 +1,181 @@
+package queueschema
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// The schemas are named <queue name>.v<schema version>.json, a new file is
+// added whenever the schema version of an event is incremented and the file
+// of the version before the previous one is removed.
+//
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+var schemaFileName = regexp.MustCompile(`^(.+)\.v(\d+)\.json$`)
+
+// schemas holds the schema of each event version, keyed by queue name
+var schemas = mustLoadSchemas(schemaFiles)
+
+// SchemaViolationError is returned when a message does not match the schema
+// of its queue and schema version
+type SchemaViolationError struct {
+	QueueName string
+	Reason    string
+}
+
+func (e *SchemaViolationError) Error() string {
+	return fmt.Sprintf("schema violation on queue %s: %s", e.QueueName, e.Reason)
+}
+
+// HasSchema returns true if the messages of the queue are validated
+func HasSchema(queueName string) bool {
+	_, ok := schemas[queueName]
+	return ok
+}
+
+// LatestVersion returns the latest schema version of the queue messages, the
+// version the producers are expected to emit once upgraded.
+func LatestVersion(queueName string) (int, bool) {
+	versions, ok := schemas[queueName]
+	if !ok {
+		return 0, false
+	}
+	return latestVersion(versions), true
+}
+
+// SupportedVersions returns the sorted schema versions accepted on the queue.
+// Both the latest version N and the previous version N-1 are accepted, so the
+// producers can be upgraded independently of the service.
+func SupportedVersions(queueName string) []int {
+	versions := schemas[queueName]
+	supported := make([]int, 0, len(versions))
+	for version := range versions {
+		supported = append(supported, version)
+	}
+	sort.Ints(supported)
+	return supported
+}
+
+// ValidateMessage validates the message body against the schema of its
+// schema version and returns the schema version. A message without schema
+// version is validated against the version 0 of the schema. Messages of
+// queues without schema are not validated.
+func ValidateMessage(queueName, messageBody string) (int, error) {
+	versions, ok := schemas[queueName]
+	if !ok {
+		return 0, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(messageBody)))
+	decoder.UseNumber()
+	var message any
+	if err := decoder.Decode(&message); err != nil {
+		return 0, &SchemaViolationError{QueueName: queueName, Reason: "message is not valid JSON: " + err.Error()}
+	}
+	if decoder.More() {
+		return 0, &SchemaViolationError{QueueName: queueName, Reason: "message contains data after the JSON value"}
+	}
+	object, ok := message.(map[string]any)
+	if !ok {
+		return 0, &SchemaViolationError{QueueName: queueName, Reason: "message is not a JSON object"}
+	}
+
+	version := 0
+	if rawVersion, ok := object["schema_version"]; ok {
+		number, isNumber := rawVersion.(json.Number)
+		parsed, err := strconv.Atoi(number.String())
+		if !isNumber || err != nil || parsed < 0 {
+			return 0, &SchemaViolationError{
+				QueueName: queueName, Reason: "schema_version must be a non-negative integer",
+			}
+		}
+		version = parsed
+	}
+	schema, ok := versions[version]
+	if !ok {
+		reason := fmt.Sprintf(
+			"schema version %d is no longer supported, supported versions are %v",
+			version, SupportedVersions(queueName),
+		)
+		if version > latestVersion(versions) {
+			reason = fmt.Sprintf(
+				"schema version %d is newer than the supported versions %v",
+				version, SupportedVersions(queueName),
+			)
+		}
+		return version, &SchemaViolationError{QueueName: queueName, Reason: reason}
+	}
+	if err := schema.validate("", message); err != nil {
+		return version, &SchemaViolationError{
+			QueueName: queueName, Reason: fmt.Sprintf("schema version %d: %s", version, err),
+		}
+	}
+	return version, nil
+}
+
+func latestVersion(versions map[int]*Schema) int {
+	latest := 0
+	for version := range versions {
+		latest = max(latest, version)
+	}
+	return latest
+}
+
+func mustLoadSchemas(files fs.FS) map[string]map[int]*Schema {
+	loaded, err := loadSchemas(files)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load queue message schemas: %v", err))
+	}
+	return loaded
+}
+
+func loadSchemas(files fs.FS) (map[string]map[int]*Schema, error) {
+	entries, err := fs.ReadDir(files, "schemas")
+	if err != nil {
+		return nil, err
+	}
+	loaded := make(map[string]map[int]*Schema)
+	for _, entry := range entries {
+		match := schemaFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected schema file name %s", entry.Name())
+		}
+		version, err := strconv.Atoi(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema version in %s: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(files, "schemas/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		schema, err := ParseSchema(content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema %s: %w", entry.Name(), err)
+		}
+		if loaded[match[1]] == nil {
+			loaded[match[1]] = make(map[int]*Schema)
+		}
+		loaded[match[1]][version] = schema
+	}
+	// Only the latest version and the previous one are read, the schemas of
+	// the older versions must be removed once no producer emits them anymore
+	for queueName, versions := range loaded {
+		latest := latestVersion(versions)
+		for version := range versions {
+			if version < latest-1 {
+				return nil, fmt.Errorf(
+					"schema version %d of %s is older than the previous version %d",
+					version, queueName, latest-1,
+				)
+			}
+		}
+	}
+	return loaded, nil
+}
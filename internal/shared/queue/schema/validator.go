@@ -0,0 +1,157 @@
+package queueschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+)
+
+// Schema is the subset of JSON Schema used to describe the queue events:
+// type, properties, required, items, minimum, minLength and enum.
+type Schema struct {
+	Type       string             `json:"type,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Minimum    *json.Number       `json:"minimum,omitempty"`
+	MinLength  *int               `json:"minLength,omitempty"`
+	Enum       []any              `json:"enum,omitempty"`
+}
+
+// ParseSchema parses a JSON schema, the unsupported keywords are ignored
+func ParseSchema(content []byte) (*Schema, error) {
+	var schema Schema
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// validate checks the value decoded with json.Number numbers against the
+// schema, the path is used to locate the violation in the error.
+func (s *Schema) validate(path string, value any) error {
+	if s.Type != "" && !matchesType(s.Type, value) {
+		return fmt.Errorf("%s must be of type %s", pathName(path), s.Type)
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		return fmt.Errorf("%s must be one of %v", pathName(path), s.Enum)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, field := range s.Required {
+			if _, ok := v[field]; !ok {
+				return fmt.Errorf("%s is required", pathName(joinPath(path, field)))
+			}
+		}
+		// Sorted to report the violations in a stable order
+		fields := make([]string, 0, len(s.Properties))
+		for field := range s.Properties {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			fieldValue, ok := v[field]
+			if !ok {
+				continue
+			}
+			if err := s.Properties[field].validate(joinPath(path, field), fieldValue); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		if s.MinLength != nil && len(v) < *s.MinLength {
+			return fmt.Errorf("%s must be at least %d characters long", pathName(path), *s.MinLength)
+		}
+	case json.Number:
+		if s.Minimum != nil && compareNumbers(v, *s.Minimum) < 0 {
+			return fmt.Errorf("%s must be greater than or equal to %s", pathName(path), s.Minimum.String())
+		}
+	}
+	return nil
+}
+
+func matchesType(schemaType string, value any) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		rat, ok := new(big.Rat).SetString(number.String())
+		return ok && rat.IsInt()
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+func inEnum(enum []any, value any) bool {
+	for _, allowed := range enum {
+		allowedNumber, allowedIsNumber := allowed.(json.Number)
+		number, isNumber := value.(json.Number)
+		if allowedIsNumber && isNumber {
+			if compareNumbers(allowedNumber, number) == 0 {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareNumbers compares the numbers without loss of precision, the uint64
+// fields of the events do not fit into a float64.
+func compareNumbers(a, b json.Number) int {
+	ratA, okA := new(big.Rat).SetString(a.String())
+	ratB, okB := new(big.Rat).SetString(b.String())
+	if !okA || !okB {
+		return 0
+	}
+	return ratA.Cmp(ratB)
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func pathName(path string) string {
+	if path == "" {
+		return "message"
+	}
+	return path
+}
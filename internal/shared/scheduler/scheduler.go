@@ -0,0 +1,263 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrUnknownJob     = errors.New("unknown scheduled job")
+	ErrNotLeader      = errors.New("scheduled job is led by another replica")
+	ErrAlreadyRunning = errors.New("scheduled job is already running")
+	ErrNotStarted     = errors.New("scheduler is not started")
+)
+
+// Job is run by the scheduler on its schedule
+type Job struct {
+	Name string
+	// Schedule is either a standard cron expression or a descriptor, such as
+	// "@every 10s"
+	Schedule string
+	// Jitter is the max random delay of each scheduled run
+	Jitter time.Duration
+	// RunOnStart runs the job once when the scheduler starts, rather than
+	// waiting for its first scheduled run
+	RunOnStart bool
+	Run        func(ctx context.Context) error
+}
+
+// LeaderElector elects the single replica running each job
+type LeaderElector interface {
+	Campaign(ctx context.Context, job string)
+	IsLeader(job string) bool
+	Holder() string
+}
+
+// JobStatus is the status of a job registered on this replica
+type JobStatus struct {
+	Name     string
+	Schedule string
+	// Leader tells whether this replica runs the job
+	Leader bool
+	// Running tells whether the job is running on this replica
+	Running bool
+	// NextRunAt is the next scheduled run on this replica
+	NextRunAt time.Time
+	// LastRun is nil if the job never ran on any replica
+	LastRun *dbmodel.ScheduledJobDocument
+}
+
+type scheduledJob struct {
+	Job
+	schedule cron.Schedule
+	entryId  cron.EntryID
+	// running guards against overlapping runs, either scheduled or manual
+	running atomic.Bool
+}
+
+// Scheduler runs the jobs on their schedule, a run is skipped while the
+// previous one of the job is still in progress. The status of the last run of
+// each job is stored in the staking db.
+type Scheduler struct {
+	db      dbclient.DBClient
+	clock   clock.Clock
+	elector LeaderElector
+	holder  string
+	cron    *cron.Cron
+	jobs    []*scheduledJob
+	// ctx is the context of the runs, set once started
+	ctx context.Context
+}
+
+// New creates the scheduler, every replica runs the jobs if the elector is nil
+func New(db dbclient.DBClient, clk clock.Clock, elector LeaderElector) (*Scheduler, error) {
+	var holder string
+	if elector != nil {
+		holder = elector.Holder()
+	} else {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the hostname identifying the job runs: %w", err)
+		}
+		holder = hostname
+	}
+	return &Scheduler{
+		db:      db,
+		clock:   clk,
+		elector: elector,
+		holder:  holder,
+		cron:    cron.New(),
+	}, nil
+}
+
+// Add registers the job, it must be called before Start
+func (s *Scheduler) Add(job Job) error {
+	schedule, err := cron.ParseStandard(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid schedule of job %s: %w", job.Name, err)
+	}
+	s.jobs = append(s.jobs, &scheduledJob{Job: job, schedule: schedule})
+	return nil
+}
+
+// Start runs the jobs on their schedule until the context is done. The jobs
+// to run on start are run before returning.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.ctx = ctx
+	for _, job := range s.jobs {
+		if s.elector != nil {
+			s.elector.Campaign(ctx, job.Name)
+		}
+		if job.RunOnStart {
+			s.run(ctx, job, dbmodel.ScheduledJobTriggerSchedule)
+		}
+		job.entryId = s.cron.Schedule(job.schedule, cron.FuncJob(func() {
+			if job.Jitter > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(time.Duration(rand.Int63n(int64(job.Jitter)))):
+				}
+			}
+			s.run(ctx, job, dbmodel.ScheduledJobTriggerSchedule)
+		}))
+		log.Info().Str("job", job.Name).Str("schedule", job.Schedule).Msg("Scheduled job")
+	}
+	s.cron.Start()
+
+	go func() {
+		<-ctx.Done()
+		log.Info().Msg("Stopping the scheduler")
+		s.cron.Stop()
+	}()
+}
+
+// Jobs returns the status of the jobs registered on this replica, along with
+// their last run on any replica. The jobs which ran on other replicas only are
+// listed after them with their last run alone.
+func (s *Scheduler) Jobs(ctx context.Context) ([]JobStatus, error) {
+	runs, err := s.db.FindScheduledJobRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastRuns := make(map[string]*dbmodel.ScheduledJobDocument, len(runs))
+	for i := range runs {
+		lastRuns[runs[i].Name] = &runs[i]
+	}
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		status := JobStatus{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			Leader:   s.elector == nil || s.elector.IsLeader(job.Name),
+			Running:  job.running.Load(),
+			LastRun:  lastRuns[job.Name],
+		}
+		if job.entryId != 0 {
+			status.NextRunAt = s.cron.Entry(job.entryId).Next
+		}
+		statuses = append(statuses, status)
+		delete(lastRuns, job.Name)
+	}
+	for i := range runs {
+		if run, ok := lastRuns[runs[i].Name]; ok {
+			statuses = append(statuses, JobStatus{Name: run.Name, LastRun: run})
+		}
+	}
+	return statuses, nil
+}
+
+// Trigger runs the job right away, outside of its schedule, and returns once
+// the run is started. The job must be led by this replica and not already
+// running.
+func (s *Scheduler) Trigger(name string) error {
+	job, err := s.triggerable(name)
+	if err != nil {
+		return err
+	}
+	if !job.running.CompareAndSwap(false, true) {
+		return ErrAlreadyRunning
+	}
+	log.Info().Str("job", name).Msg("Scheduled job triggered manually")
+	go func() {
+		defer job.running.Store(false)
+		s.execute(s.ctx, job, dbmodel.ScheduledJobTriggerManual)
+	}()
+	return nil
+}
+
+// CanTrigger returns the error Trigger would return, without running the job
+func (s *Scheduler) CanTrigger(name string) error {
+	job, err := s.triggerable(name)
+	if err != nil {
+		return err
+	}
+	if job.running.Load() {
+		return ErrAlreadyRunning
+	}
+	return nil
+}
+
+func (s *Scheduler) triggerable(name string) (*scheduledJob, error) {
+	if s.ctx == nil {
+		return nil, ErrNotStarted
+	}
+	for _, job := range s.jobs {
+		if job.Name != name {
+			continue
+		}
+		if s.elector != nil && !s.elector.IsLeader(name) {
+			return nil, ErrNotLeader
+		}
+		return job, nil
+	}
+	return nil, ErrUnknownJob
+}
+
+func (s *Scheduler) run(ctx context.Context, job *scheduledJob, trigger dbmodel.ScheduledJobTrigger) {
+	if s.elector != nil && !s.elector.IsLeader(job.Name) {
+		return
+	}
+	if !job.running.CompareAndSwap(false, true) {
+		log.Debug().Str("job", job.Name).Msg("Skipped the run of the job still running")
+		return
+	}
+	defer job.running.Store(false)
+	s.execute(ctx, job, trigger)
+}
+
+// execute runs the job and records its status, the errors of the status are
+// only logged as they are not worth failing the run
+func (s *Scheduler) execute(ctx context.Context, job *scheduledJob, trigger dbmodel.ScheduledJobTrigger) {
+	startedAt := s.clock.Now()
+	if err := s.db.StartScheduledJobRun(ctx, job.Name, s.holder, trigger, startedAt); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("job", job.Name).Msg("Failed to record the start of the scheduled job")
+	}
+
+	// The errors are logged by the job itself
+	runErr := job.Run(ctx)
+	outcome := metrics.Success
+	if runErr != nil {
+		outcome = metrics.Error
+	}
+	metrics.RecordScheduledJobRun(job.Name, outcome)
+
+	finishedAt := s.clock.Now()
+	err := s.db.CompleteScheduledJobRun(ctx, job.Name, runErr, finishedAt, finishedAt.Sub(startedAt))
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("job", job.Name).Msg("Failed to record the outcome of the scheduled job")
+	}
+}
@@ -0,0 +1,43 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// awsSecretsManager fetches the secrets from AWS Secrets Manager, the
+// credentials are taken from the default AWS credentials chain
+type awsSecretsManager struct {
+	client *secretsmanager.SecretsManager
+}
+
+func newAwsSecretsManager(region string) (*awsSecretsManager, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            aws.Config{Region: aws.String(region)},
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the aws session: %w", err)
+	}
+	return &awsSecretsManager{client: secretsmanager.New(sess)}, nil
+}
+
+// GetSecret returns the current version of the secret, ref being its name or
+// ARN
+func (m *awsSecretsManager) GetSecret(ctx context.Context, ref string) (string, error) {
+	output, err := m.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(ref),
+	})
+	if err != nil {
+		return "", err
+	}
+	if output.SecretString == nil {
+		return "", errors.New("binary secrets are not supported")
+	}
+	return *output.SecretString, nil
+}
@@ -0,0 +1,86 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"cloud.google.com/go/compute/metadata"
+)
+
+const gcpSecretManagerUrl = "https://secretmanager.googleapis.com/v1/"
+
+// gcpSecretManager fetches the secrets from GCP Secret Manager through its
+// REST API. The access token of the service account is taken from the
+// metadata server, or from GOOGLE_OAUTH_ACCESS_TOKEN when not running on GCP.
+type gcpSecretManager struct {
+	httpClient *http.Client
+}
+
+func newGcpSecretManager() *gcpSecretManager {
+	return &gcpSecretManager{httpClient: &http.Client{}}
+}
+
+// GetSecret returns the secret version, ref being its resource name, e.g.
+// projects/my-project/secrets/mongo-password/versions/latest
+func (m *gcpSecretManager) GetSecret(ctx context.Context, ref string) (string, error) {
+	token, err := m.accessToken()
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpSecretManagerUrl+ref+":access", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+	}
+	var accessed struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &accessed); err != nil {
+		return "", fmt.Errorf("invalid secret response: %w", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(accessed.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("invalid secret payload: %w", err)
+	}
+	return string(data), nil
+}
+
+func (m *gcpSecretManager) accessToken() (string, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+	if !metadata.OnGCE() {
+		return "", errors.New("no gcp credentials, GOOGLE_OAUTH_ACCESS_TOKEN must be set outside of GCP")
+	}
+	tokenJson, err := metadata.Get("instance/service-accounts/default/token")
+	if err != nil {
+		return "", fmt.Errorf("failed to get the gcp access token: %w", err)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal([]byte(tokenJson), &token); err != nil {
+		return "", fmt.Errorf("invalid gcp access token: %w", err)
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,121 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The schemes of the secret references
+const (
+	AwsSecretsManagerScheme = "aws-sm"
+	GcpSecretManagerScheme  = "gcp-sm"
+	VaultScheme             = "vault"
+)
+
+// Provider fetches the secrets of an external secret manager
+type Provider interface {
+	// GetSecret returns the value of the secret identified by the reference,
+	// the scheme being stripped from it
+	GetSecret(ctx context.Context, ref string) (string, error)
+}
+
+type Options struct {
+	AwsRegion    string
+	VaultAddress string
+	VaultToken   string
+	Timeout      time.Duration
+}
+
+// Resolver resolves the secret references of the form
+// `<scheme>://<secret>[#<json-key>]`, the json key selecting a field of the
+// secrets holding a JSON object, e.g. `aws-sm://staking-api/mongo#password`.
+type Resolver struct {
+	opts      Options
+	providers map[string]Provider
+}
+
+func NewResolver(opts Options) *Resolver {
+	return &Resolver{opts: opts, providers: make(map[string]Provider)}
+}
+
+// WithProvider replaces the provider of the scheme
+func (r *Resolver) WithProvider(scheme string, provider Provider) *Resolver {
+	r.providers[scheme] = provider
+	return r
+}
+
+// IsReference returns whether the value is a secret reference
+func IsReference(value string) bool {
+	scheme, _, found := strings.Cut(value, "://")
+	if !found {
+		return false
+	}
+	switch scheme {
+	case AwsSecretsManagerScheme, GcpSecretManagerScheme, VaultScheme:
+		return true
+	}
+	return false
+}
+
+// Resolve returns the secret value of the reference
+func (r *Resolver) Resolve(ctx context.Context, reference string) (string, error) {
+	scheme, rest, _ := strings.Cut(reference, "://")
+	ref, jsonKey, _ := strings.Cut(rest, "#")
+	if ref == "" {
+		return "", fmt.Errorf("empty secret reference: %s", reference)
+	}
+	provider, err := r.provider(scheme)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+	value, err := provider.GetSecret(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s://%s: %w", scheme, ref, err)
+	}
+	if jsonKey == "" {
+		return value, nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", fmt.Errorf("secret %s://%s is not a JSON object", scheme, ref)
+	}
+	field, ok := fields[jsonKey]
+	if !ok {
+		return "", fmt.Errorf("secret %s://%s has no key %s", scheme, ref, jsonKey)
+	}
+	if s, ok := field.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(field), nil
+}
+
+// provider returns the provider of the scheme, created on first use so that
+// only the secret managers actually referenced are set up
+func (r *Resolver) provider(scheme string) (Provider, error) {
+	if provider, ok := r.providers[scheme]; ok {
+		return provider, nil
+	}
+	var provider Provider
+	var err error
+	switch scheme {
+	case AwsSecretsManagerScheme:
+		provider, err = newAwsSecretsManager(r.opts.AwsRegion)
+	case GcpSecretManagerScheme:
+		provider = newGcpSecretManager()
+	case VaultScheme:
+		provider, err = newVault(r.opts.VaultAddress, r.opts.VaultToken)
+	default:
+		return nil, fmt.Errorf("unknown secret scheme: %s", scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.providers[scheme] = provider
+	return provider, nil
+}
@@ -0,0 +1,78 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// vault fetches the secrets from the HashiCorp Vault KV secrets engines
+// through the HTTP API
+type vault struct {
+	address    string
+	token      string
+	httpClient *http.Client
+}
+
+// newVault creates the vault provider, the address and token default to the
+// VAULT_ADDR and VAULT_TOKEN env vars used by the vault tooling
+func newVault(address, token string) (*vault, error) {
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if address == "" || token == "" {
+		return nil, errors.New("vault address and token are required to resolve vault secrets")
+	}
+	return &vault{
+		address:    strings.TrimSuffix(address, "/"),
+		token:      token,
+		httpClient: &http.Client{},
+	}, nil
+}
+
+// GetSecret returns the data of the secret as a JSON object, ref being the
+// API path of the secret, e.g. secret/data/staking-api for the KV v2 engine
+func (v *vault) GetSecret(ctx context.Context, ref string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.address+"/v1/"+ref, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+	}
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("invalid secret response: %w", err)
+	}
+	// The KV v2 engine nests the secret data along with its metadata
+	if data, ok := secret.Data["data"]; ok {
+		if _, versioned := secret.Data["metadata"]; versioned {
+			return string(data), nil
+		}
+	}
+	data, err := json.Marshal(secret.Data)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/scheduler"
+	"github.com/rs/zerolog/log"
+)
+
+// delegationArchiverJob moves the delegations in a terminal state into the
+// archive collection
+func (s *Services) delegationArchiverJob(cfg *config.DelegationArchiveConfig) scheduler.Job {
+	return scheduler.Job{
+		Name:     DelegationArchiverJob,
+		Schedule: everyInterval(cfg.Interval),
+		Run: func(ctx context.Context) error {
+			// Errors are logged by the service, the remaining delegations are
+			// archived on the next run
+			archived, err := s.V1Service.ArchiveDelegations(ctx)
+			if err != nil {
+				return err
+			}
+			if archived > 0 {
+				log.Info().Int64("archived", archived).Msg("Archived delegations")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/scheduler"
+	"github.com/rs/zerolog/log"
+)
+
+// delegationMigratorJob writes back the delegations stored at a previous
+// schema version, which are otherwise migrated on every read
+func (s *Services) delegationMigratorJob(cfg *config.DelegationMigrationConfig) scheduler.Job {
+	return scheduler.Job{
+		Name:     DelegationMigratorJob,
+		Schedule: everyInterval(cfg.Interval),
+		Run: func(ctx context.Context) error {
+			// Errors are logged by the service, the remaining delegations are
+			// migrated on the next run
+			migrated, err := s.V1Service.MigrateDelegations(ctx)
+			if err != nil {
+				return err
+			}
+			if migrated > 0 {
+				log.Info().Int64("migrated", migrated).Msg("Migrated delegations")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/scheduler"
+	"github.com/rs/zerolog/log"
+)
+
+// expiryScannerJob transitions to unbonded the delegations whose timelock has
+// expired while their expiry event is missing
+func (s *Services) expiryScannerJob(cfg *config.ExpiryScannerConfig) scheduler.Job {
+	return scheduler.Job{
+		Name:     ExpiryScannerJob,
+		Schedule: everyInterval(cfg.Interval),
+		Run: func(ctx context.Context) error {
+			// Errors are logged by the service, the expired delegations are
+			// found again on the next run
+			transitioned, err := s.V1Service.ScanExpiredDelegations(ctx)
+			outcome := metrics.Success
+			if err != nil {
+				outcome = metrics.Error
+			}
+			metrics.RecordExpiryScannerRun(outcome)
+			for txType, count := range transitioned {
+				metrics.RecordExpiryScannerTransitions(txType.ToString(), count)
+				log.Warn().Str("txType", txType.ToString()).Int64("transitioned", count).
+					Msg("Expiry scanner transitioned delegations missing their expiry event")
+			}
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+}
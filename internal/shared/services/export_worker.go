@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/rs/zerolog/log"
+)
+
+// StartExportWorker polls the pending export jobs and runs them one at a
+// time until the context is done. It's a no-op if the exports are not
+// configured.
+func (s *Services) StartExportWorker(ctx context.Context, cfg *config.ExportsConfig) {
+	if cfg == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(cfg.PollInterval)
+		defer ticker.Stop()
+		log.Info().Msg("Started export worker")
+		for {
+			// The pending jobs are drained before waiting for the next poll,
+			// errors are logged by the service
+			for ctx.Err() == nil {
+				processed, err := s.V1Service.ProcessNextExportJob(ctx)
+				if err != nil || !processed {
+					break
+				}
+			}
+			select {
+			case <-ctx.Done():
+				log.Info().Msg("Stopping export worker")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	StatsRefresherJob     = "stats_refresher"
+	DelegationArchiverJob = "delegation_archiver"
+	ExpiryScannerJob      = "expiry_scanner"
+	DelegationMigratorJob = "delegation_migrator"
+)
+
+// leaseReleaseTimeout bounds the release of the leases on shutdown, once the
+// context of the jobs is done
+const leaseReleaseTimeout = 5 * time.Second
+
+// LeaderElector elects, through leases stored in the staking db, the single
+// replica running each of the scheduled jobs. The leader renews its leases
+// every third of the lease duration, the other replicas try to acquire them
+// as often and take over once they expire.
+type LeaderElector struct {
+	db            dbclient.DBClient
+	clock         clock.Clock
+	holder        string
+	leaseDuration time.Duration
+
+	mu      sync.RWMutex
+	leaders map[string]bool
+}
+
+func NewLeaderElector(
+	cfg *config.LeaderElectionConfig, db dbclient.DBClient, clk clock.Clock,
+) (*LeaderElector, error) {
+	holder := cfg.InstanceId
+	if holder == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the hostname identifying the leases: %w", err)
+		}
+		holder = hostname
+	}
+	return &LeaderElector{
+		db:            db,
+		clock:         clk,
+		holder:        holder,
+		leaseDuration: cfg.LeaseDuration,
+		leaders:       make(map[string]bool),
+	}, nil
+}
+
+// Holder returns the identifier of the replica in the leases
+func (e *LeaderElector) Holder() string {
+	return e.holder
+}
+
+// Campaign competes for the lease of the job until the context is done, when
+// the lease is released for another replica to take over right away. The
+// first attempt is made before returning so that IsLeader is up to date.
+func (e *LeaderElector) Campaign(ctx context.Context, job string) {
+	e.acquire(ctx, job)
+	go func() {
+		ticker := time.NewTicker(e.leaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				e.release(job)
+				return
+			case <-ticker.C:
+				e.acquire(ctx, job)
+			}
+		}
+	}()
+}
+
+// IsLeader tells whether the replica holds the lease of the job
+func (e *LeaderElector) IsLeader(job string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leaders[job]
+}
+
+func (e *LeaderElector) acquire(ctx context.Context, job string) {
+	now := e.clock.Now()
+	leader, err := e.db.AcquireLeaderLease(ctx, job, e.holder, now, now.Add(e.leaseDuration))
+	if err != nil {
+		// The job is not run rather than risking a concurrent run, as the
+		// lease may have expired in the meantime
+		log.Ctx(ctx).Error().Err(err).Str("job", job).Msg("Failed to acquire the leader lease")
+		leader = false
+	}
+	e.setLeader(job, leader)
+}
+
+func (e *LeaderElector) release(job string) {
+	if !e.IsLeader(job) {
+		return
+	}
+	e.setLeader(job, false)
+	ctx, cancel := context.WithTimeout(context.Background(), leaseReleaseTimeout)
+	defer cancel()
+	if err := e.db.ReleaseLeaderLease(ctx, job, e.holder); err != nil {
+		log.Warn().Err(err).Str("job", job).Msg("Failed to release the leader lease")
+	}
+}
+
+func (e *LeaderElector) setLeader(job string, leader bool) {
+	e.mu.Lock()
+	wasLeader := e.leaders[job]
+	e.leaders[job] = leader
+	e.mu.Unlock()
+
+	if leader != wasLeader {
+		log.Info().Str("job", job).Str("holder", e.holder).Bool("leader", leader).
+			Msg("Leadership of the job changed")
+	}
+	metrics.RecordLeaderElection(job, leader)
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/scheduler"
+	"github.com/rs/zerolog/log"
+)
+
+// scheduledJobs are the names of the jobs run by the scheduler
+var scheduledJobs = []string{StatsRefresherJob, DelegationArchiverJob, ExpiryScannerJob, DelegationMigratorJob}
+
+// StartScheduler runs the scheduled jobs whose config is set, every interval
+// of their config unless overridden by the scheduler config
+func (s *Services) StartScheduler(ctx context.Context, cfg *config.Config) error {
+	var jobs []scheduler.Job
+	if cfg.StatsRefresher != nil {
+		jobs = append(jobs, s.statsRefresherJob(cfg.StatsRefresher))
+	}
+	if cfg.DelegationArchive != nil {
+		jobs = append(jobs, s.delegationArchiverJob(cfg.DelegationArchive))
+	}
+	if cfg.ExpiryScanner != nil {
+		jobs = append(jobs, s.expiryScannerJob(cfg.ExpiryScanner))
+	}
+	if cfg.DelegationMigration != nil {
+		jobs = append(jobs, s.delegationMigratorJob(cfg.DelegationMigration))
+	}
+
+	overrides := map[string]*config.ScheduledJobConfig{}
+	if cfg.Scheduler != nil {
+		overrides = cfg.Scheduler.Jobs
+	}
+	for name, override := range overrides {
+		if !isScheduledJob(name) {
+			return fmt.Errorf("unknown scheduled job %s", name)
+		}
+		if override.Enabled && !hasJob(jobs, name) {
+			return fmt.Errorf("scheduled job %s is enabled without its config", name)
+		}
+	}
+
+	for _, job := range jobs {
+		if override, ok := overrides[job.Name]; ok {
+			if !override.Enabled {
+				log.Info().Str("job", job.Name).Msg("Scheduled job disabled")
+				continue
+			}
+			if override.Schedule != "" {
+				job.Schedule = override.Schedule
+			}
+			job.Jitter = override.Jitter
+		}
+		if err := s.Scheduler.Add(job); err != nil {
+			return err
+		}
+	}
+	s.Scheduler.Start(ctx)
+	return nil
+}
+
+func everyInterval(interval time.Duration) string {
+	return fmt.Sprintf("@every %s", interval)
+}
+
+func isScheduledJob(name string) bool {
+	for _, job := range scheduledJobs {
+		if job == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasJob(jobs []scheduler.Job, name string) bool {
+	for _, job := range jobs {
+		if job.Name == name {
+			return true
+		}
+	}
+	return false
+}
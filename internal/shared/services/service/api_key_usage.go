@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// MaxApiKeyUsageDays is the max number of days of usage returned at once
+const MaxApiKeyUsageDays = 90
+
+type ApiKeyDailyUsagePublic struct {
+	Day      string `json:"day"`
+	Requests int64  `json:"requests"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// IncrementApiKeyUsage adds the usage of this instance to the usage of the
+// api key over the day, and returns the usage of all the instances
+func (s *Service) IncrementApiKeyUsage(
+	ctx context.Context, apiKey string, dayStart time.Time, requests, bytes int64,
+) (*dbmodel.ApiKeyUsageDocument, *types.Error) {
+	usage, err := s.DbClients.SharedDBClient.IncrementApiKeyUsage(ctx, apiKey, dayStart, requests, bytes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("apiKey", apiKey).Msg("error while incrementing api key usage")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return usage, nil
+}
+
+// GetApiKeyUsage returns the daily usage of the api key over the last days,
+// including today, the most recent day first. The days without usage are
+// omitted.
+func (s *Service) GetApiKeyUsage(
+	ctx context.Context, apiKey string, days int,
+) ([]ApiKeyDailyUsagePublic, *types.Error) {
+	if days < 1 || days > MaxApiKeyUsageDays {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "days must be between 1 and 90",
+		)
+	}
+	today := s.Clock.Now().UTC().Truncate(24 * time.Hour)
+	usage, err := s.DbClients.SharedDBClient.FindApiKeyUsage(ctx, apiKey, today.AddDate(0, 0, 1-days))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("apiKey", apiKey).Msg("error while fetching api key usage")
+		return nil, types.NewInternalServiceError(err)
+	}
+	usagePublic := make([]ApiKeyDailyUsagePublic, 0, len(usage))
+	for _, u := range usage {
+		usagePublic = append(usagePublic, ApiKeyDailyUsagePublic{
+			Day:      u.DayStart.UTC().Format(time.DateOnly),
+			Requests: u.Requests,
+			Bytes:    u.Bytes,
+		})
+	}
+	return usagePublic, nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+)
+
+const (
+	HealthStatusHealthy   = "healthy"
+	HealthStatusUnhealthy = "unhealthy"
+)
+
+type ComponentHealthPublic struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func NewComponentHealth(name string, err error) ComponentHealthPublic {
+	if err != nil {
+		return ComponentHealthPublic{Name: name, Status: HealthStatusUnhealthy, Error: err.Error()}
+	}
+	return ComponentHealthPublic{Name: name, Status: HealthStatusHealthy}
+}
+
+// GetDbHealthDetails pings each database separately, unlike DoHealthCheck
+// which stops at the first failure.
+func (s *Service) GetDbHealthDetails(ctx context.Context) []ComponentHealthPublic {
+	return []ComponentHealthPublic{
+		NewComponentHealth("staking_db", s.DbClients.SharedDBClient.Ping(ctx)),
+		NewComponentHealth("indexer_db", s.DbClients.IndexerDBClient.Ping(ctx)),
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// ReserveIdempotencyKey reserves the idempotency key for the request, or
+// returns the document of the request which already holds it
+func (s *Service) ReserveIdempotencyKey(
+	ctx context.Context, key, requestHash string,
+) (*dbmodel.IdempotencyKeyDocument, *types.Error) {
+	existing, err := s.DbClients.SharedDBClient.ReserveIdempotencyKey(
+		ctx, key, requestHash, s.Cfg.Idempotency.LockTimeout,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while reserving idempotency key")
+		return nil, types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError, "error while reserving idempotency key",
+		)
+	}
+	return existing, nil
+}
+
+// CompleteIdempotencyKey stores the response to be replayed to the retries
+// of the request
+func (s *Service) CompleteIdempotencyKey(
+	ctx context.Context, key string, statusCode int, contentType string, body []byte,
+) *types.Error {
+	err := s.DbClients.SharedDBClient.CompleteIdempotencyKey(ctx, key, statusCode, contentType, body)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while completing idempotency key")
+		return types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError, "error while completing idempotency key",
+		)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey releases the key of a request which has failed, so
+// that its retries are processed again
+func (s *Service) ReleaseIdempotencyKey(ctx context.Context, key string) *types.Error {
+	if err := s.DbClients.SharedDBClient.DeleteIdempotencyKey(ctx, key); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while releasing idempotency key")
+		return types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError, "error while releasing idempotency key",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// minHotQueryShapeCount is the number of times a query shape must have been
+// observed to be reported as missing an index
+const minHotQueryShapeCount = 100
+
+// idIndexName is the name of the index mongo creates on the _id of each
+// collection, it can't be dropped hence it's never reported as unused
+const idIndexName = "_id_"
+
+type IndexUsagePublic struct {
+	Collection string   `json:"collection"`
+	Name       string   `json:"name"`
+	Fields     []string `json:"fields"`
+	Ops        int64    `json:"ops"`
+	Since      string   `json:"since"`
+	// Unused is set if the index has not served any query since
+	Unused bool `json:"unused"`
+}
+
+type QueryShapePublic struct {
+	Collection string   `json:"collection"`
+	Filter     []string `json:"filter"`
+	Sort       []string `json:"sort,omitempty"`
+	Count      int64    `json:"count"`
+}
+
+type IndexReportPublic struct {
+	Indexes []IndexUsagePublic `json:"indexes"`
+	// MissingIndexes holds the hot query shapes observed by this instance
+	// which none of the indexes of their collection can serve
+	MissingIndexes []QueryShapePublic `json:"missing_indexes"`
+}
+
+// GetIndexReport returns the usage of the indexes of the staking db along
+// with the hot query shapes lacking an index. The index usage is the one of
+// the mongod serving the $indexStats command, and the query shapes are the
+// ones observed by this instance since its start.
+func (s *Service) GetIndexReport(ctx context.Context) (*IndexReportPublic, *types.Error) {
+	stats, err := s.DbClients.SharedDBClient.FindIndexStats(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to fetch the index stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	report := &IndexReportPublic{
+		Indexes:        make([]IndexUsagePublic, 0, len(stats)),
+		MissingIndexes: []QueryShapePublic{},
+	}
+	collectionIndexes := make(map[string][][]string)
+	for _, index := range stats {
+		fields := make([]string, 0, len(index.Key))
+		for _, key := range index.Key {
+			fields = append(fields, key.Key)
+		}
+		collectionIndexes[index.Collection] = append(collectionIndexes[index.Collection], fields)
+		report.Indexes = append(report.Indexes, IndexUsagePublic{
+			Collection: index.Collection,
+			Name:       index.Name,
+			Fields:     fields,
+			Ops:        index.Accesses.Ops,
+			Since:      index.Accesses.Since.UTC().Format(time.RFC3339),
+			Unused:     index.Accesses.Ops == 0 && index.Name != idIndexName,
+		})
+	}
+
+	for _, shape := range db.QueryShapesOf(s.Cfg.StakingDb.DbName).Snapshot() {
+		if shape.Count < minHotQueryShapeCount {
+			// The shapes are sorted by count
+			break
+		}
+		indexes, ok := collectionIndexes[shape.Collection]
+		if !ok || (len(shape.Filter) == 0 && len(shape.Sort) == 0) {
+			continue
+		}
+		if isQueryShapeSupported(shape.QueryShape, indexes) {
+			continue
+		}
+		report.MissingIndexes = append(report.MissingIndexes, QueryShapePublic{
+			Collection: shape.Collection,
+			Filter:     shape.Filter,
+			Sort:       shape.Sort,
+			Count:      shape.Count,
+		})
+	}
+	return report, nil
+}
+
+func isQueryShapeSupported(shape db.QueryShape, indexes [][]string) bool {
+	for _, fields := range indexes {
+		if shape.SupportedBy(fields) {
+			return true
+		}
+	}
+	return false
+}
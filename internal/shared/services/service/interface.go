@@ -2,12 +2,29 @@ package service
 
 import (
 	"context"
+	"time"
 
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 )
 
 type SharedServiceProvider interface {
 	DoHealthCheck(ctx context.Context) error
+	GetDbHealthDetails(ctx context.Context) []ComponentHealthPublic
 	VerifyUTXOs(ctx context.Context, utxos []types.UTXOIdentifier, address string) ([]*SafeUTXOPublic, *types.Error)
-	SaveUnprocessableMessages(ctx context.Context, messages string, receipt string) *types.Error
+	SaveUnprocessableMessages(ctx context.Context, messages, receipt, reason string) *types.Error
+	ArchiveEvent(ctx context.Context, event *dbmodel.EventDocument) *types.Error
+	GetRuntimeStats(ctx context.Context) *RuntimeStatsPublic
+	// GetIndexReport returns the usage of the indexes of the staking db along
+	// with the hot query shapes lacking an index
+	GetIndexReport(ctx context.Context) (*IndexReportPublic, *types.Error)
+	ReserveIdempotencyKey(ctx context.Context, key, requestHash string) (*dbmodel.IdempotencyKeyDocument, *types.Error)
+	CompleteIdempotencyKey(
+		ctx context.Context, key string, statusCode int, contentType string, body []byte,
+	) *types.Error
+	ReleaseIdempotencyKey(ctx context.Context, key string) *types.Error
+	IncrementApiKeyUsage(
+		ctx context.Context, apiKey string, dayStart time.Time, requests, bytes int64,
+	) (*dbmodel.ApiKeyUsageDocument, *types.Error)
+	GetApiKeyUsage(ctx context.Context, apiKey string, days int) ([]ApiKeyDailyUsagePublic, *types.Error)
 }
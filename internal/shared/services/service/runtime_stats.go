@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+)
+
+// maxRecentGcPauses is the number of the most recent GC pauses reported
+const maxRecentGcPauses = 10
+
+type GcStatsPublic struct {
+	NumGC        uint32  `json:"num_gc"`
+	PauseTotalMs float64 `json:"pause_total_ms"`
+	// RecentPausesMs holds the most recent GC pauses, most recent first
+	RecentPausesMs []float64 `json:"recent_pauses_ms"`
+	LastGcAt       *int64    `json:"last_gc_at,omitempty"`
+}
+
+type MongoSessionsPublic struct {
+	StakingDb int `json:"staking_db"`
+	IndexerDb int `json:"indexer_db"`
+}
+
+type RuntimeStatsPublic struct {
+	Goroutines     int                 `json:"goroutines"`
+	HeapAllocBytes uint64              `json:"heap_alloc_bytes"`
+	HeapInuseBytes uint64              `json:"heap_inuse_bytes"`
+	HeapObjects    uint64              `json:"heap_objects"`
+	SysBytes       uint64              `json:"sys_bytes"`
+	GC             GcStatsPublic       `json:"gc"`
+	MongoSessions  MongoSessionsPublic `json:"mongo_sessions"`
+}
+
+// GetRuntimeStats returns the runtime stats of the process along with the
+// number of mongo sessions in progress. Reading the memory stats stops the
+// world for a short time, hence it's only exposed on the admin listener.
+func (s *Service) GetRuntimeStats(ctx context.Context) *RuntimeStatsPublic {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	// PauseNs is a circular buffer, the most recent pause is at (NumGC+255)%256
+	recentPauses := make([]float64, 0, maxRecentGcPauses)
+	for i := uint32(0); i < min(memStats.NumGC, maxRecentGcPauses); i++ {
+		pause := memStats.PauseNs[(memStats.NumGC-1-i)%uint32(len(memStats.PauseNs))]
+		recentPauses = append(recentPauses, durationMs(time.Duration(pause)))
+	}
+	gcStats := GcStatsPublic{
+		NumGC:          memStats.NumGC,
+		PauseTotalMs:   durationMs(time.Duration(memStats.PauseTotalNs)),
+		RecentPausesMs: recentPauses,
+	}
+	if memStats.LastGC > 0 {
+		lastGcAt := time.Unix(0, int64(memStats.LastGC)).Unix()
+		gcStats.LastGcAt = &lastGcAt
+	}
+
+	return &RuntimeStatsPublic{
+		Goroutines:     runtime.NumGoroutine(),
+		HeapAllocBytes: memStats.HeapAlloc,
+		HeapInuseBytes: memStats.HeapInuse,
+		HeapObjects:    memStats.HeapObjects,
+		SysBytes:       memStats.Sys,
+		GC:             gcStats,
+		MongoSessions: MongoSessionsPublic{
+			StakingDb: sessionsInProgress(s.DbClients.StakingMongoClient),
+			IndexerDb: sessionsInProgress(s.DbClients.IndexerMongoClient),
+		},
+	}
+}
+
+func sessionsInProgress(client *dbclient.MongoClient) int {
+	if client == nil {
+		return 0
+	}
+	return client.NumberSessionsInProgress()
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -6,9 +6,12 @@ import (
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/errorreporting"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 )
 
@@ -20,6 +23,8 @@ type Service struct {
 	Cfg               *config.Config
 	Params            *types.GlobalParams
 	FinalityProviders []types.FinalityProviderDetails
+	// Clock is used instead of time.Now() so that tests can control the time
+	Clock clock.Clock
 }
 
 func New(
@@ -36,9 +41,16 @@ func New(
 		Cfg:               cfg,
 		Params:            globalParams,
 		FinalityProviders: finalityProviders,
+		Clock:             clock.New(),
 	}, nil
 }
 
+// ApproximateTotalCounts returns whether the totals of the paginated lists
+// are served from the maintained counters when available
+func (s *Service) ApproximateTotalCounts() bool {
+	return s.Cfg.TotalCount != nil && s.Cfg.TotalCount.Approximate
+}
+
 // DoHealthCheck checks the health of the services by ping the database.
 func (s *Service) DoHealthCheck(ctx context.Context) error {
 	if err := s.DbClients.SharedDBClient.Ping(ctx); err != nil {
@@ -47,11 +59,25 @@ func (s *Service) DoHealthCheck(ctx context.Context) error {
 	return s.DbClients.IndexerDBClient.Ping(ctx)
 }
 
-func (s *Service) SaveUnprocessableMessages(ctx context.Context, messageBody, receipt string) *types.Error {
-	err := s.DbClients.V1DBClient.SaveUnprocessableMessage(ctx, messageBody, receipt)
+func (s *Service) SaveUnprocessableMessages(ctx context.Context, messageBody, receipt, reason string) *types.Error {
+	errorreporting.CaptureUnprocessableMessage(ctx, messageBody, reason)
+	err := s.DbClients.V1DBClient.SaveUnprocessableMessage(ctx, messageBody, receipt, reason)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while saving unprocessable message")
 		return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "error while saving unprocessable message")
 	}
 	return nil
 }
+
+// ArchiveEvent saves the consumed queue message into the events archive. It's
+// a no-op if the event archive is not enabled.
+func (s *Service) ArchiveEvent(ctx context.Context, event *dbmodel.EventDocument) *types.Error {
+	if s.Cfg.EventArchive == nil {
+		return nil
+	}
+	if err := s.DbClients.SharedDBClient.SaveEvent(ctx, event); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while archiving event")
+		return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "error while archiving event")
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	transitionHookMaxAttempts   = 3
+	transitionHookRetryInterval = time.Second
+	transitionHookTimeout       = 10 * time.Second
+)
+
+// TransitionEvent describes a delegation state transition that has been
+// successfully persisted.
+type TransitionEvent struct {
+	StakingTxHashHex string
+	State            types.DelegationState
+	OccurredAt       time.Time
+	// Removed is set when the delegation has been rolled back by a BTC reorg,
+	// State is then the state it was rolled back from
+	Removed bool
+}
+
+// TransitionHook is run after a successful delegation state transition. A hook
+// returning an error is retried up to transitionHookMaxAttempts times.
+type TransitionHook func(ctx context.Context, event TransitionEvent) error
+
+var (
+	transitionHooksMu sync.RWMutex
+	transitionHooks   = make(map[string]TransitionHook)
+)
+
+// RegisterTransitionHook registers the hook under the given name, allowing
+// deployment specific code to react to the delegation state transitions
+// without modifying the core handlers. It's meant to be called from an init
+// function and panics if the name is already registered.
+func RegisterTransitionHook(name string, hook TransitionHook) {
+	transitionHooksMu.Lock()
+	defer transitionHooksMu.Unlock()
+	if hook == nil {
+		panic("transition hook must not be nil")
+	}
+	if _, exists := transitionHooks[name]; exists {
+		panic(fmt.Sprintf("transition hook %s is already registered", name))
+	}
+	transitionHooks[name] = hook
+}
+
+// UnregisterTransitionHook removes the hook registered under the given name.
+func UnregisterTransitionHook(name string) {
+	transitionHooksMu.Lock()
+	defer transitionHooksMu.Unlock()
+	delete(transitionHooks, name)
+}
+
+// NotifyTransition runs all the registered hooks asynchronously, the hooks
+// failures are logged and never affect the transition itself. occurredAt is
+// read from the clock of the caller.
+func NotifyTransition(
+	ctx context.Context, stakingTxHashHex string, state types.DelegationState, occurredAt time.Time,
+) {
+	notifyTransitionHooks(ctx, TransitionEvent{
+		StakingTxHashHex: stakingTxHashHex,
+		State:            state,
+		OccurredAt:       occurredAt,
+	})
+}
+
+// NotifyRemoval runs all the registered hooks for a delegation rolled back
+// from the given state by a BTC reorg, as NotifyTransition does.
+func NotifyRemoval(
+	ctx context.Context, stakingTxHashHex string, state types.DelegationState, occurredAt time.Time,
+) {
+	notifyTransitionHooks(ctx, TransitionEvent{
+		StakingTxHashHex: stakingTxHashHex,
+		State:            state,
+		OccurredAt:       occurredAt,
+		Removed:          true,
+	})
+}
+
+func notifyTransitionHooks(ctx context.Context, event TransitionEvent) {
+	transitionHooksMu.RLock()
+	names := make([]string, 0, len(transitionHooks))
+	for name := range transitionHooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	hooks := make([]TransitionHook, 0, len(names))
+	for _, name := range names {
+		hooks = append(hooks, transitionHooks[name])
+	}
+	transitionHooksMu.RUnlock()
+
+	// The hooks outlive the processing of the transition
+	hookCtx := context.WithoutCancel(ctx)
+	for i, hook := range hooks {
+		go runTransitionHook(hookCtx, names[i], hook, event)
+	}
+}
+
+func runTransitionHook(ctx context.Context, name string, hook TransitionHook, event TransitionEvent) {
+	var err error
+	for attempt := 1; attempt <= transitionHookMaxAttempts; attempt++ {
+		err = callTransitionHook(ctx, name, hook, event)
+		if err == nil {
+			return
+		}
+		if attempt < transitionHookMaxAttempts {
+			time.Sleep(transitionHookRetryInterval * time.Duration(attempt))
+		}
+	}
+	log.Ctx(ctx).Error().Err(err).Str("hook", name).
+		Str("stakingTxHashHex", event.StakingTxHashHex).Str("state", event.State.ToString()).
+		Msg("transition hook failed after retries")
+}
+
+// callTransitionHook runs the hook once, a panicking hook is reported as a
+// failed attempt instead of crashing the service.
+func callTransitionHook(
+	ctx context.Context, name string, hook TransitionHook, event TransitionEvent,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("transition hook %s panicked: %v", name, r)
+		}
+	}()
+	hookCtx, cancel := context.WithTimeout(ctx, transitionHookTimeout)
+	defer cancel()
+	return hook(hookCtx, event)
+}
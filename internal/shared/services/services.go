@@ -3,9 +3,11 @@ package services
 import (
 	"context"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/scheduler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
@@ -16,6 +18,10 @@ type Services struct {
 	SharedService service.SharedServiceProvider
 	V1Service     v1service.V1ServiceProvider
 	V2Service     v2service.V2ServiceProvider
+	Scheduler     *scheduler.Scheduler
+	// Clock is the clock of the shared service, used by the api as well so
+	// that tests can control the time
+	Clock clock.Clock
 }
 
 func New(
@@ -43,6 +49,20 @@ func New(
 		SharedService: service,
 		V1Service:     v1Service,
 		V2Service:     v2Service,
+		Clock:         service.Clock,
+	}
+	// Every replica runs the scheduled jobs if the leader election is not
+	// configured
+	var elector scheduler.LeaderElector
+	if cfg.LeaderElection != nil {
+		elector, err = NewLeaderElector(cfg.LeaderElection, dbClients.SharedDBClient, clock.New())
+		if err != nil {
+			return nil, err
+		}
+	}
+	services.Scheduler, err = scheduler.New(dbClients.SharedDBClient, clock.New(), elector)
+	if err != nil {
+		return nil, err
 	}
 
 	return &services, nil
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/scheduler"
+)
+
+// statsRefresherJob refreshes the materialized overall stats served by the
+// stats endpoints. They are refreshed right away on start rather than waiting
+// for the first run.
+func (s *Services) statsRefresherJob(cfg *config.StatsRefresherConfig) scheduler.Job {
+	return scheduler.Job{
+		Name:       StatsRefresherJob,
+		Schedule:   everyInterval(cfg.Interval),
+		RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			// Errors are logged by the services, the stats endpoints fall back
+			// to the shards once the materialized stats become stale
+			v1Err := s.V1Service.RefreshOverallStats(ctx)
+			v2Err := s.V2Service.RefreshOverallStats(ctx)
+			if v1Err != nil {
+				return v1Err
+			}
+			if v2Err != nil {
+				return v2Err
+			}
+			return nil
+		},
+	}
+}
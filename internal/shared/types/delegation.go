@@ -5,6 +5,7 @@ import "fmt"
 type DelegationState string
 
 const (
+	Pending            DelegationState = "pending"
 	Active             DelegationState = "active"
 	UnbondingRequested DelegationState = "unbonding_requested"
 	Unbonding          DelegationState = "unbonding"
@@ -18,6 +19,8 @@ func (s DelegationState) ToString() string {
 
 func FromStringToDelegationState(s string) (DelegationState, error) {
 	switch s {
+	case "pending":
+		return Pending, nil
 	case "active":
 		return Active, nil
 	case "unbonding_requested":
@@ -32,3 +35,15 @@ func FromStringToDelegationState(s string) (DelegationState, error) {
 		return "", fmt.Errorf("invalid delegation state: %s", s)
 	}
 }
+
+// DelegationRangeFilter filters the delegations by staking value and staking
+// start timestamp (unix seconds), all the bounds are inclusive.
+// A zero value means the bound is not set.
+type DelegationRangeFilter struct {
+	MinValue      uint64
+	MaxValue      uint64
+	FromTimestamp int64
+	ToTimestamp   int64
+	// IncludeArchived also reads the delegations moved into the archive
+	IncludeArchived bool
+}
@@ -20,6 +20,29 @@ const (
 	Forbidden            ErrorCode = "FORBIDDEN"
 	UnprocessableEntity  ErrorCode = "UNPROCESSABLE_ENTITY"
 	RequestTimeout       ErrorCode = "REQUEST_TIMEOUT"
+	RequestTooLarge      ErrorCode = "REQUEST_ENTITY_TOO_LARGE"
+	ServiceUnavailable   ErrorCode = "SERVICE_UNAVAILABLE"
+	Conflict             ErrorCode = "CONFLICT"
+	Unauthorized         ErrorCode = "UNAUTHORIZED"
+	TooManyRequests      ErrorCode = "TOO_MANY_REQUESTS"
+	Gone                 ErrorCode = "GONE"
+	// Geo gating
+	UnavailableForLegalReasons ErrorCode = "UNAVAILABLE_FOR_LEGAL_REASONS"
+	// Delegation state machine
+	InvalidStateTransition ErrorCode = "INVALID_STATE_TRANSITION"
+	// Unbonding request verification
+	UnbondingInputMismatch    ErrorCode = "UNBONDING_INPUT_MISMATCH"
+	UnbondingFeeMismatch      ErrorCode = "UNBONDING_FEE_MISMATCH"
+	UnbondingOutputMismatch   ErrorCode = "UNBONDING_OUTPUT_SCRIPT_MISMATCH"
+	UnbondingTxMismatch       ErrorCode = "UNBONDING_TX_MISMATCH"
+	InvalidUnbondingSignature ErrorCode = "INVALID_UNBONDING_SIGNATURE"
+	StakingTxMismatch         ErrorCode = "STAKING_TX_MISMATCH"
+	UnbondingRequestReplayed  ErrorCode = "UNBONDING_REQUEST_REPLAYED"
+	// Queue message validation
+	SchemaViolation ErrorCode = "SCHEMA_VIOLATION"
+	// Idempotent requests
+	IdempotencyKeyInProgress ErrorCode = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	IdempotencyKeyMismatch   ErrorCode = "IDEMPOTENCY_KEY_MISMATCH"
 )
 
 // Error represents an error with an HTTP status code and an application-specific error code.
@@ -0,0 +1,10 @@
+package types
+
+// TotalCount is the total number of items of a paginated list, over all its
+// pages
+type TotalCount struct {
+	Count int64 `json:"count"`
+	// Approximate is set if the count comes from a counter maintained
+	// alongside the items, or from an estimate, rather than being counted
+	Approximate bool `json:"approximate"`
+}
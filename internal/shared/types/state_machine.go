@@ -0,0 +1,96 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// delegationStates lists all the delegation states in lifecycle order
+var delegationStates = []DelegationState{
+	Pending, Active, UnbondingRequested, Unbonding, Unbonded, Withdrawn,
+}
+
+// delegationTransitions is the table of the allowed delegation state transitions.
+// - Pending is allowed to transition to Unbonding and Unbonded as the on-chain
+// unbonding tx or the timelock expiry proves the staking tx is confirmed.
+// - Active is allowed to directly transition to Unbonding without the need of
+// UnbondingRequested due to bootstrap usecase.
+var delegationTransitions = map[DelegationState][]DelegationState{
+	Pending:            {Active, Unbonding, Unbonded},
+	Active:             {UnbondingRequested, Unbonding, Unbonded},
+	UnbondingRequested: {Unbonding},
+	Unbonding:          {Unbonded},
+	Unbonded:           {Withdrawn},
+	Withdrawn:          {},
+}
+
+// CanTransition returns true if the delegation is allowed to transition from
+// the given state to the target state.
+func CanTransition(from, to DelegationState) bool {
+	for _, state := range delegationTransitions[from] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StatesTransitionableTo returns the states allowed to transition to the
+// target state, in lifecycle order.
+func StatesTransitionableTo(to DelegationState) []DelegationState {
+	var states []DelegationState
+	for _, from := range delegationStates {
+		if CanTransition(from, to) {
+			states = append(states, from)
+		}
+	}
+	return states
+}
+
+// TerminalStates returns the states the delegation can't transition from, in
+// lifecycle order.
+func TerminalStates() []DelegationState {
+	var states []DelegationState
+	for _, state := range delegationStates {
+		if len(delegationTransitions[state]) == 0 {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+// IsOutdatedTransition returns true if the delegation already reached or
+// passed the target state, meaning the transition has already been processed.
+func IsOutdatedTransition(from, to DelegationState) bool {
+	return from == to || isReachable(to, from)
+}
+
+// isReachable returns true if the target state can be reached from the given
+// state through one or more transitions.
+func isReachable(from, to DelegationState) bool {
+	visited := map[DelegationState]bool{from: true}
+	queue := []DelegationState{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range delegationTransitions[current] {
+			if next == to {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+// NewInvalidTransitionError creates the error returned when the delegation is
+// not allowed to transition from the given state to the target state.
+func NewInvalidTransitionError(from, to DelegationState) *Error {
+	return NewErrorWithMsg(
+		http.StatusForbidden, InvalidStateTransition,
+		fmt.Sprintf("delegation is not allowed to transition from %s to %s", from, to),
+	)
+}
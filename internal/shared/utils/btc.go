@@ -73,8 +73,61 @@ func parseUnbondingTxHex(unbondingTxHex string) (*wire.MsgTx, error) {
 	return unbondingTx, nil
 }
 
+// ParseStakingTxHex decodes the staking tx hex and parses it as a V0 staking tx
+// against the tag and covenant committee of the provided params.
+func ParseStakingTxHex(
+	stakingTxHex string,
+	params *types.VersionedGlobalParams,
+	btcNetParam *chaincfg.Params,
+) (*wire.MsgTx, *btcstaking.ParsedV0StakingTx, error) {
+	stakingTx, _, err := bbntypes.NewBTCTxFromHex(stakingTxHex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode staking tx from hex: %w", err)
+	}
+	tag, err := hex.DecodeString(params.Tag)
+	if err != nil {
+		return stakingTx, nil, fmt.Errorf("failed to decode tag from hex: %w", err)
+	}
+	covenantPks, err := GetCovenantPksFromStrings(params.CovenantPks)
+	if err != nil {
+		return stakingTx, nil, fmt.Errorf("failed to decode coveant public keys from strings: %w", err)
+	}
+	parsedTx, err := btcstaking.ParseV0StakingTx(
+		stakingTx, tag, covenantPks, uint32(params.CovenantQuorum), btcNetParam,
+	)
+	if err != nil {
+		return stakingTx, nil, fmt.Errorf("the tx is not a valid staking tx: %w", err)
+	}
+	return stakingTx, parsedTx, nil
+}
+
+// UnbondingVerificationError is returned by VerifyUnbondingRequest if the
+// unbonding tx does not match the one expected from the staking tx and params.
+// The code identifies which part of the unbonding tx is mismatched.
+type UnbondingVerificationError struct {
+	Code types.ErrorCode
+	Err  error
+}
+
+func (e *UnbondingVerificationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UnbondingVerificationError) Unwrap() error {
+	return e.Err
+}
+
+func newUnbondingVerificationError(code types.ErrorCode, format string, args ...any) error {
+	return &UnbondingVerificationError{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// VerifyUnbondingRequest reconstructs the expected unbonding tx from the stored
+// staking tx and the params, and verifies the provided unbonding tx and the
+// staker signature against it.
+// Mismatches of the unbonding tx are returned as UnbondingVerificationError.
 func VerifyUnbondingRequest(
 	stakingTxHashHex,
+	stakingTxHex,
 	unbondingTxHashHex,
 	unbondingTxHex,
 	stakerPkHex,
@@ -112,19 +165,22 @@ func VerifyUnbondingRequest(
 		return fmt.Errorf("failed to decode staking tx hash from hex: %w", err)
 	}
 	if !unbondingTx.TxIn[0].PreviousOutPoint.Hash.IsEqual(stakingTxHash) {
-		return fmt.Errorf("the unbonding tx input must match the previous staking tx hash, expected: %s, got: %s",
+		return newUnbondingVerificationError(
+			types.UnbondingInputMismatch,
+			"the unbonding tx input must match the previous staking tx hash, expected: %s, got: %s",
 			stakingTxHashHex,
 			unbondingTx.TxIn[0].PreviousOutPoint.Hash.String(),
 		)
 	}
 	if uint64(unbondingTx.TxIn[0].PreviousOutPoint.Index) != stakingOutputIndex {
-		return fmt.Errorf("the unbonding tx input must match the previous staking tx output index, expected: %d, got: %d",
+		return newUnbondingVerificationError(
+			types.UnbondingInputMismatch,
+			"the unbonding tx input must match the previous staking tx output index, expected: %d, got: %d",
 			stakingOutputIndex,
 			unbondingTx.TxIn[0].PreviousOutPoint.Index,
 		)
 	}
 
-	// 4. verify that the unbonding output is constructed as expected
 	covenantPks, err := GetCovenantPksFromStrings(params.CovenantPks)
 	if err != nil {
 		return fmt.Errorf("failed to decode coveant public keys from strings: %w", err)
@@ -140,6 +196,39 @@ func VerifyUnbondingRequest(
 		return fmt.Errorf("failed to decode finality provider public key from hex: %w", err)
 	}
 
+	// 4. verify the stored staking tx contains the expected staking output, the
+	// output is the one spent by the unbonding tx
+	stakingInfo, err := btcstaking.BuildStakingInfo(
+		stakerPk,
+		[]*btcec.PublicKey{finalityProviderPk},
+		covenantPks,
+		uint32(params.CovenantQuorum),
+		uint16(stakingTimeLock),
+		btcutil.Amount(stakingValue),
+		btcNetParam,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build staking info")
+	}
+	stakingTx, _, err := bbntypes.NewBTCTxFromHex(stakingTxHex)
+	if err != nil {
+		return fmt.Errorf("failed to decode staking tx from hex: %w", err)
+	}
+	stakingTxHashFromTx := stakingTx.TxHash()
+	if !stakingTxHashFromTx.IsEqual(stakingTxHash) {
+		return newUnbondingVerificationError(
+			types.StakingTxMismatch, "the staking tx does not match the staking tx hash",
+		)
+	}
+	if stakingOutputIndex >= uint64(len(stakingTx.TxOut)) ||
+		!outputsAreEqual(stakingInfo.StakingOutput, stakingTx.TxOut[stakingOutputIndex]) {
+		return newUnbondingVerificationError(
+			types.StakingTxMismatch, "the staking tx output does not match the expected staking output",
+		)
+	}
+	stakingOutput := stakingTx.TxOut[stakingOutputIndex]
+
+	// 5. verify that the unbonding output is constructed as expected
 	expectedUnbondingOutputValue := btcutil.Amount(stakingValue) - btcutil.Amount(params.UnbondingFee)
 	if expectedUnbondingOutputValue <= 0 {
 		return fmt.Errorf("staking output value is too low, got %v, unbonding fee: %v",
@@ -159,23 +248,37 @@ func VerifyUnbondingRequest(
 		return fmt.Errorf("failed to build unbonding info")
 	}
 
-	if !outputsAreEqual(unbondingInfo.UnbondingOutput, unbondingTx.TxOut[0]) {
-		return fmt.Errorf("unbonding output does not match expected output")
+	unbondingOutput := unbondingTx.TxOut[0]
+	if !bytes.Equal(unbondingInfo.UnbondingOutput.PkScript, unbondingOutput.PkScript) {
+		return newUnbondingVerificationError(
+			types.UnbondingOutputMismatch, "unbonding output script does not match expected script",
+		)
+	}
+	if unbondingInfo.UnbondingOutput.Value != unbondingOutput.Value {
+		return newUnbondingVerificationError(
+			types.UnbondingFeeMismatch, "unbonding fee does not match the params, expected: %d, got: %d",
+			params.UnbondingFee, stakingOutput.Value-unbondingOutput.Value,
+		)
 	}
 
-	// 5. verify the signature
-	stakingInfo, err := btcstaking.BuildStakingInfo(
-		stakerPk,
-		[]*btcec.PublicKey{finalityProviderPk},
-		covenantPks,
-		uint32(params.CovenantQuorum),
-		uint16(stakingTimeLock),
-		btcutil.Amount(stakingValue),
-		btcNetParam,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to build staking info")
+	// 6. reconstruct the expected unbonding tx, it must be identical to the
+	// provided one apart from the witness
+	expectedUnbondingTx := wire.NewMsgTx(unbondingTx.Version)
+	expectedUnbondingTx.LockTime = unbondingTx.LockTime
+	expectedUnbondingTx.AddTxIn(wire.NewTxIn(
+		wire.NewOutPoint(stakingTxHash, uint32(stakingOutputIndex)), nil, nil,
+	))
+	expectedUnbondingTx.TxIn[0].Sequence = unbondingTx.TxIn[0].Sequence
+	expectedUnbondingTx.AddTxOut(unbondingInfo.UnbondingOutput)
+	expectedUnbondingTxHash := expectedUnbondingTx.TxHash()
+	if !expectedUnbondingTxHash.IsEqual(unbondingTxHash) {
+		return newUnbondingVerificationError(
+			types.UnbondingTxMismatch, "unbonding tx does not match the expected unbonding tx",
+		)
 	}
+
+	// 7. verify the staker signature over the sighash of the unbonding path
+	// spending the staking output
 	sigBytes, err := hex.DecodeString(unbondingSigHex)
 	if err != nil {
 		return fmt.Errorf("failed to decode unbonding signature from hex")
@@ -185,13 +288,15 @@ func VerifyUnbondingRequest(
 		return fmt.Errorf("failed to build unbonding path spend info")
 	}
 	if err := btcstaking.VerifyTransactionSigWithOutput(
-		unbondingTx,
-		stakingInfo.StakingOutput,
+		expectedUnbondingTx,
+		stakingOutput,
 		unbondingSpendInfo.GetPkScriptPath(),
 		stakerPk,
 		sigBytes,
 	); err != nil {
-		return fmt.Errorf("invalid unbonding signature")
+		return newUnbondingVerificationError(
+			types.InvalidUnbondingSignature, "invalid unbonding signature",
+		)
 	}
 	return nil
 }
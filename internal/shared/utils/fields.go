@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// JSONFieldNames returns the json names of the top level fields of the given
+// struct, or of the struct pointed to
+func JSONFieldNames(v any) []string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// SelectJSONFields keeps only the given top level fields of the json encoding
+// of the struct, or of each struct of the slice. Fields omitted from the
+// encoding remain omitted.
+func SelectJSONFields(v any, fields []string) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[field] = true
+	}
+	selectFields := func(object map[string]json.RawMessage) map[string]json.RawMessage {
+		for key := range object {
+			if !keep[key] {
+				delete(object, key)
+			}
+		}
+		return object
+	}
+
+	var list []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &list); err == nil {
+		for i := range list {
+			list[i] = selectFields(list[i])
+		}
+		return list, nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+	return selectFields(object), nil
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// The PSBT (BIP-174) key types used for the unbonding request. Only the
+// subset required to extract the unsigned unbonding tx and the staker
+// taproot script spend signature is supported.
+const (
+	psbtGlobalUnsignedTxType = 0x00
+	psbtInTapScriptSigType   = 0x14
+	// Max size of a single PSBT key or value, prevents huge allocations
+	// from a malformed length prefix.
+	psbtMaxKVSize = 1 << 20
+)
+
+var psbtMagic = []byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+// UnbondingPsbt holds the unbonding tx and the staker signature extracted
+// from a PSBT.
+type UnbondingPsbt struct {
+	UnbondingTxHashHex string
+	UnbondingTxHex     string
+	StakerSigHex       string
+}
+
+type psbtKV struct {
+	key   []byte
+	value []byte
+}
+
+// ParseUnbondingPsbt decodes the base64 encoded PSBT and extracts the
+// unsigned unbonding tx along with the taproot script spend signature of
+// the staker on the first input.
+// The unbonding tx must spend exactly one input and the signature must use
+// the default sighash type (64 bytes).
+func ParseUnbondingPsbt(psbtBase64, stakerPkHex string) (*UnbondingPsbt, error) {
+	psbtBytes, err := base64.StdEncoding.DecodeString(psbtBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode psbt from base64: %w", err)
+	}
+	stakerPk, err := GetSchnorrPkFromHex(stakerPkHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode staker public key from hex: %w", err)
+	}
+	stakerXonlyPk := schnorr.SerializePubKey(stakerPk)
+
+	r := bytes.NewReader(psbtBytes)
+	magic := make([]byte, len(psbtMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, psbtMagic) {
+		return nil, fmt.Errorf("invalid psbt magic bytes")
+	}
+
+	globals, err := readPsbtMap(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read psbt global map: %w", err)
+	}
+	var unbondingTx *wire.MsgTx
+	for _, kv := range globals {
+		if len(kv.key) == 1 && kv.key[0] == psbtGlobalUnsignedTxType {
+			unbondingTx = wire.NewMsgTx(wire.TxVersion)
+			if err := unbondingTx.DeserializeNoWitness(bytes.NewReader(kv.value)); err != nil {
+				return nil, fmt.Errorf("failed to decode psbt unsigned tx: %w", err)
+			}
+		}
+	}
+	if unbondingTx == nil {
+		return nil, fmt.Errorf("psbt does not contain the unsigned tx")
+	}
+	if len(unbondingTx.TxIn) != 1 {
+		return nil, fmt.Errorf("unbonding tx must have exactly one input")
+	}
+
+	// The input maps follow the global map, only the first one is relevant
+	input, err := readPsbtMap(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read psbt input map: %w", err)
+	}
+	var sigBytes []byte
+	for _, kv := range input {
+		if len(kv.key) == 0 || kv.key[0] != psbtInTapScriptSigType {
+			continue
+		}
+		// key: type || x-only pubkey (32 bytes) || leaf hash (32 bytes)
+		if len(kv.key) != 1+64 {
+			return nil, fmt.Errorf("invalid psbt taproot script signature key")
+		}
+		if !bytes.Equal(kv.key[1:33], stakerXonlyPk) {
+			continue
+		}
+		if len(kv.value) != schnorr.SignatureSize {
+			return nil, fmt.Errorf("unsupported staker signature, only the default sighash type is allowed")
+		}
+		sigBytes = kv.value
+	}
+	if sigBytes == nil {
+		return nil, fmt.Errorf("psbt does not contain the staker signature")
+	}
+
+	var buf bytes.Buffer
+	if err := unbondingTx.Serialize(&buf); err != nil {
+		return nil, fmt.Errorf("failed to serialize unbonding tx: %w", err)
+	}
+	return &UnbondingPsbt{
+		UnbondingTxHashHex: unbondingTx.TxHash().String(),
+		UnbondingTxHex:     hex.EncodeToString(buf.Bytes()),
+		StakerSigHex:       hex.EncodeToString(sigBytes),
+	}, nil
+}
+
+// readPsbtMap reads the key-value pairs of a PSBT map up to the separator.
+func readPsbtMap(r io.Reader) ([]psbtKV, error) {
+	var kvs []psbtKV
+	for {
+		key, err := readPsbtBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		// A zero length key is the map separator
+		if len(key) == 0 {
+			return kvs, nil
+		}
+		value, err := readPsbtBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, psbtKV{key: key, value: value})
+	}
+}
+
+func readPsbtBytes(r io.Reader) ([]byte, error) {
+	size, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if size > psbtMaxKVSize {
+		return nil, fmt.Errorf("psbt field is too large")
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -1,45 +1,61 @@
 package utils
 
-import "github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
 
 // QualifiedStatesToUnbondingRequest returns the qualified exisitng states to transition to "unbonding_request"
 func QualifiedStatesToUnbondingRequest() []types.DelegationState {
-	return []types.DelegationState{types.Active}
+	return types.StatesTransitionableTo(types.UnbondingRequested)
 }
 
 // QualifiedStatesToUnbonding returns the qualified exisitng states to transition to "unbonding"
-// The Active state is allowed to directly transition to Unbonding without the need of UnbondingRequested due to bootstrap usecase
 func QualifiedStatesToUnbonding() []types.DelegationState {
-	return []types.DelegationState{types.Active, types.UnbondingRequested}
-}
-
-// List of states to be ignored for unbonding as it means it's already been processed
-func OutdatedStatesForUnbonding() []types.DelegationState {
-	return []types.DelegationState{types.Unbonding, types.Unbonded, types.Withdrawn}
+	return types.StatesTransitionableTo(types.Unbonding)
 }
 
 // QualifiedStatesToUnbonded returns the qualified exisitng states to transition to "unbonded"
+// The staking tx timelock can only expire before the unbonding while the
+// unbonding tx timelock can only expire once unbonding.
 func QualifiedStatesToUnbonded(unbondTxType types.StakingTxType) []types.DelegationState {
-	switch unbondTxType {
-	case types.ActiveTxType:
-		return []types.DelegationState{types.Active}
-	case types.UnbondingTxType:
-		return []types.DelegationState{types.Unbonding}
-	default:
-		return nil
+	var states []types.DelegationState
+	for _, state := range types.StatesTransitionableTo(types.Unbonded) {
+		switch unbondTxType {
+		case types.ActiveTxType:
+			if state != types.Unbonding {
+				states = append(states, state)
+			}
+		case types.UnbondingTxType:
+			if state == types.Unbonding {
+				states = append(states, state)
+			}
+		}
 	}
-}
-
-// List of states to be ignored for unbonded(timelock expired) as it means it's already been processed
-func OutdatedStatesForUnbonded() []types.DelegationState {
-	return []types.DelegationState{types.Unbonded, types.Withdrawn}
+	return states
 }
 
 // QualifiedStatesToWithdrawn returns the qualified exisitng states to transition to "withdrawn"
 func QualifiedStatesToWithdraw() []types.DelegationState {
-	return []types.DelegationState{types.Unbonded}
+	return types.StatesTransitionableTo(types.Withdrawn)
+}
+
+// QualifiedStatesToActive returns the qualified exisitng states to transition to "active"
+func QualifiedStatesToActive() []types.DelegationState {
+	return types.StatesTransitionableTo(types.Active)
 }
 
-func OutdatedStatesForWithdraw() []types.DelegationState {
-	return []types.DelegationState{types.Withdrawn}
+// InvalidTransitionError logs and records the rejected delegation state
+// transition, and returns the corresponding error.
+func InvalidTransitionError(
+	ctx context.Context, stakingTxHashHex string, from, to types.DelegationState,
+) *types.Error {
+	log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).
+		Str("from", from.ToString()).Str("to", to.ToString()).
+		Msg("delegation is not allowed to transition to the target state")
+	metrics.RecordInvalidStateTransition(from.ToString(), to.ToString())
+	return types.NewInvalidTransitionError(from, to)
 }
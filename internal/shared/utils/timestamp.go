@@ -1,6 +1,65 @@
 package utils
 
-import "time"
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// TimestampFormat is the format of the timestamps in the api responses
+type TimestampFormat string
+
+const (
+	// TimestampFormatISO8601 formats the timestamps as RFC3339 date times
+	TimestampFormatISO8601 TimestampFormat = "iso8601"
+	// TimestampFormatUnix formats the timestamps as unix seconds
+	TimestampFormatUnix TimestampFormat = "unix"
+)
+
+// FormatTimestamp formats the epoch time in seconds in the given format,
+// ISO8601 being the default
+func FormatTimestamp(epochtime int64, format TimestampFormat) string {
+	if format == TimestampFormatUnix {
+		return strconv.FormatInt(epochtime, 10)
+	}
+	return ParseTimestampToIsoFormat(epochtime)
+}
+
+// Timestamp is a timestamp of the api responses, it's marshalled as an
+// ISO8601 string or as a number of unix seconds depending on its format
+type Timestamp struct {
+	Unix   int64
+	Format TimestampFormat
+}
+
+func (t Timestamp) String() string {
+	return FormatTimestamp(t.Unix, t.Format)
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.Format == TimestampFormatUnix {
+		return strconv.AppendInt(nil, t.Unix, 10), nil
+	}
+	return json.Marshal(ParseTimestampToIsoFormat(t.Unix))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var unix int64
+	if err := json.Unmarshal(data, &unix); err == nil {
+		*t = Timestamp{Unix: unix, Format: TimestampFormatUnix}
+		return nil
+	}
+	var iso string
+	if err := json.Unmarshal(data, &iso); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(time.RFC3339, iso)
+	if err != nil {
+		return err
+	}
+	*t = Timestamp{Unix: parsed.Unix(), Format: TimestampFormatISO8601}
+	return nil
+}
 
 func ParseTimestampToIsoFormat(epochtime int64) string {
 	// Convert the int64 epoch time to a time.Time object
@@ -10,12 +69,17 @@ func ParseTimestampToIsoFormat(epochtime int64) string {
 }
 
 func GetTodayStartTimestampInSeconds() int64 {
-	// Get the current time in UTC
-	now := time.Now().UTC()
+	return GetDayStartTimestampInSeconds(time.Now())
+}
+
+// GetDayStartTimestampInSeconds returns the start of the UTC day of the time
+func GetDayStartTimestampInSeconds(t time.Time) int64 {
+	// Get the time in UTC
+	t = t.UTC()
 
-	// Create a new time representing today at 12AM UTC
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	// Create a new time representing the day at 12AM UTC
+	startOfDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 
-	// Convert the start of today to a Unix timestamp in seconds
+	// Convert the start of the day to a Unix timestamp in seconds
 	return startOfDay.Unix()
 }
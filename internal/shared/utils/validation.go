@@ -13,11 +13,21 @@ import (
 // IsValidTxHash checks if the given string is a valid BTC transaction hash
 // Note: it does not check the actual content of the hash.
 func IsValidTxHash(txHash string) bool {
-	// Check if the hash is valid
+	// The shorter hashes are zero padded by chainhash, hence the length check
+	if len(txHash) != chainhash.MaxHashStringSize {
+		return false
+	}
 	_, err := chainhash.NewHashFromStr(txHash)
 	return err == nil
 }
 
+// IsBase64URLEncoded checks if the given string is a valid padded URL-safe
+// Base64 encoded string, as the pagination tokens are.
+func IsBase64URLEncoded(s string) bool {
+	_, err := base64.URLEncoding.DecodeString(s)
+	return err == nil
+}
+
 // IsBase64Encoded checks if the given string is a valid Base64 encoded string.
 // Note: it does not check the actual content of the string.
 func IsBase64Encoded(s string) bool {
@@ -0,0 +1,55 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// The build information is injected at build time with
+// -ldflags "-X github.com/babylonlabs-io/staking-api-service/internal/shared/version.Version=..."
+var (
+	Version   = "dev"
+	GitCommit = ""
+	BuildDate = ""
+	// Features is the comma separated list of the features enabled at build time
+	Features = ""
+)
+
+type Info struct {
+	Version   string   `json:"version"`
+	GitCommit string   `json:"git_commit"`
+	BuildDate string   `json:"build_date"`
+	GoVersion string   `json:"go_version"`
+	Features  []string `json:"features"`
+}
+
+// Get returns the build information of the binary. The git commit and the
+// build date fall back to the version control information stamped by the go
+// toolchain if they were not injected.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Features:  []string{},
+	}
+	for _, feature := range strings.Split(Features, ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			info.Features = append(info.Features, feature)
+		}
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.GitCommit == "":
+				info.GitCommit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildDate == "":
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+	return info
+}
@@ -5,14 +5,22 @@ import (
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
 )
 
 // GetDelegationByTxHash @Summary Get a delegation
 // @Description Retrieves a delegation by a given transaction hash
+// @Description With wait_for_state, the request is held until the delegation is in the state or the timeout elapses,
+// @Description the delegation is returned as of then. The delegation is also waited for if it doesn't exist yet.
 // @Produce json
 // @Tags v1
 // @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Param fields query string false "Comma separated fields of the delegation to return, e.g. staking_tx_hash_hex,state,staking_value"
+// @Param timestamp_format query string false "Format of the timestamps, iso8601 by default" Enums(iso8601, unix)
+// @Param include_archived query boolean false "Look up the archived delegations as well"
+// @Param wait_for_state query types.DelegationState false "Hold the request until the delegation is in the state, if long polling is enabled"
+// @Param timeout query string false "Max duration to hold the request for with wait_for_state, e.g. 30s, the configured max by default"
 // @Success 200 {object} handler.PublicResponse[v1service.DelegationPublic] "Delegation"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/delegation [get]
@@ -21,10 +29,171 @@ func (h *V1Handler) GetDelegationByTxHash(request *http.Request) (*handler.Resul
 	if err != nil {
 		return nil, err
 	}
-	delegation, err := h.Service.GetDelegation(request.Context(), stakingTxHash)
+	fields, err := handler.ParseFieldsQuery(request, v1service.DelegationPublic{})
 	if err != nil {
 		return nil, err
 	}
+	timestampFormat, err := handler.ParseTimestampFormatQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	includeArchived, err := handler.ParseIncludeArchivedQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	waitForState, err := handler.ParseStateFilterQuery(request, "wait_for_state")
+	if err != nil {
+		return nil, err
+	}
+	var delegation *v1model.DelegationDocument
+	if waitForState != "" {
+		timeout, timeoutErr := handler.ParseLongPollTimeoutQuery(request, h.Config.LongPoll)
+		if timeoutErr != nil {
+			return nil, timeoutErr
+		}
+		delegation, err = h.Service.WaitForDelegationState(request.Context(), stakingTxHash, waitForState, timeout)
+	} else {
+		delegation, err = h.Service.GetDelegation(request.Context(), stakingTxHash)
+	}
+	if err != nil && includeArchived && err.ErrorCode == types.NotFound {
+		delegation, err = h.Service.GetArchivedDelegation(request.Context(), stakingTxHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	btcTipHeight, err := h.Service.GetBtcTipHeight(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	delegationPublic := v1service.FromDelegationDocument(delegation, btcTipHeight)
+	delegationPublic.FormatTimestamps(timestampFormat)
+	data, err := handler.SelectFields(delegationPublic, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(data), nil
+}
+
+// CheckDelegationExists @Summary Check the existence of a delegation
+// @Description Checks whether a delegation exists by its staking transaction hash without returning it,
+// @Description the response has no body. It's meant for the high-frequency existence polling.
+// @Tags v1
+// @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Success 200 "Delegation exists"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 "Delegation not found"
+// @Router /v1/delegation/exists [get]
+// @Router /v1/delegation/exists [head]
+// @Router /v1/delegation [head]
+func (h *V1Handler) CheckDelegationExists(request *http.Request) (*handler.Result, *types.Error) {
+	stakingTxHash, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	exists, err := h.Service.DelegationExists(request.Context(), stakingTxHash)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return handler.NewNoBodyResult(http.StatusNotFound), nil
+	}
+	return handler.NewNoBodyResult(http.StatusOK), nil
+}
+
+// GetDelegationByAnyTxHash @Summary Get a delegation by any of its transaction hashes
+// @Description Resolves a staking, unbonding or withdrawal transaction hash to the delegation it belongs to
+// @Produce json
+// @Tags v1
+// @Param tx_hash_hex query string true "Staking, unbonding or withdrawal transaction hash in hex format"
+// @Param fields query string false "Comma separated fields of the delegation to return, e.g. staking_tx_hash_hex,state,staking_value"
+// @Param timestamp_format query string false "Format of the timestamps, iso8601 by default" Enums(iso8601, unix)
+// @Success 200 {object} handler.PublicResponse[v1service.DelegationPublic] "Delegation"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/delegation/by-tx [get]
+func (h *V1Handler) GetDelegationByAnyTxHash(request *http.Request) (*handler.Result, *types.Error) {
+	txHash, err := handler.ParseTxHashQuery(request, "tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	fields, err := handler.ParseFieldsQuery(request, v1service.DelegationPublic{})
+	if err != nil {
+		return nil, err
+	}
+	timestampFormat, err := handler.ParseTimestampFormatQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	delegation, err := h.Service.GetDelegationByAnyTxHash(request.Context(), txHash)
+	if err != nil {
+		return nil, err
+	}
+	btcTipHeight, err := h.Service.GetBtcTipHeight(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	delegationPublic := v1service.FromDelegationDocument(delegation, btcTipHeight)
+	delegationPublic.FormatTimestamps(timestampFormat)
+	data, err := handler.SelectFields(delegationPublic, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(data), nil
+}
+
+// GetDelegationTimeline @Summary Get the timeline of a delegation
+// @Description Retrieves the lifecycle milestones of a delegation (staked, unbonding requested,
+// @Description unbonding confirmed, expired, withdrawn) ordered from the staking to the withdrawal
+// @Produce json
+// @Tags v1
+// @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Success 200 {object} handler.PublicResponse[[]v1service.DelegationMilestonePublic]{array} "Delegation milestones"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/delegation/timeline [get]
+func (h *V1Handler) GetDelegationTimeline(request *http.Request) (*handler.Result, *types.Error) {
+	stakingTxHash, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	timeline, err := h.Service.GetDelegationTimeline(request.Context(), stakingTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(timeline), nil
+}
+
+// GetDelegationChanges @Summary Get the delegation changes
+// @Description Incremental sync of the delegations for downstream indexers. Returns the delegations
+// @Description written after the `since` cursor in the order they were written. The `next_key` of the
+// @Description pagination is the cursor to poll the next changes from, it is returned even if there
+// @Description is no new change. Omit the cursor to start from the first delegation.
+// @Description Delegations removed by a BTC reorg are not part of the changes.
+// @Produce json
+// @Tags v1
+// @Param since query string false "Change cursor returned by the previous call"
+// @Param timestamp_format query string false "Format of the start timestamps, iso8601 by default" Enums(iso8601, unix)
+// @Success 200 {object} handler.PublicResponse[[]v1service.DelegationChangePublic]{array} "Delegation changes and the next cursor"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/delegations/changes [get]
+func (h *V1Handler) GetDelegationChanges(request *http.Request) (*handler.Result, *types.Error) {
+	cursor := request.URL.Query().Get("since")
+	timestampFormat, err := handler.ParseTimestampFormatQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	changes, nextCursor, err := h.Service.GetDelegationChanges(request.Context(), cursor)
+	if err != nil {
+		return nil, err
+	}
+	for i := range changes {
+		changes[i].FormatTimestamps(timestampFormat)
+	}
 
-	return handler.NewResult(v1service.FromDelegationDocument(delegation)), nil
+	return handler.NewResultWithPagination(changes, nextCursor), nil
 }
@@ -0,0 +1,166 @@
+package v1handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+)
+
+// maxDelegationLabels is the max number of labels of a delegation per api key
+const maxDelegationLabels = 16
+
+var delegationLabelRegex = regexp.MustCompile(`^[A-Za-z0-9_.:@-]{1,64}$`)
+
+type SetDelegationLabelsRequestPayload struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	// Labels replace the labels of the delegation, they are removed if empty
+	Labels []string `json:"labels"`
+}
+
+func parseSetDelegationLabelsRequestPayload(
+	request *http.Request,
+) (*SetDelegationLabelsRequestPayload, *types.Error) {
+	payload := &SetDelegationLabelsRequestPayload{}
+	err := json.NewDecoder(request.Body).Decode(payload)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	if !utils.IsValidTxHash(payload.StakingTxHashHex) {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid staking transaction hash",
+		)
+	}
+	if len(payload.Labels) > maxDelegationLabels {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("too many labels, at most %d are allowed", maxDelegationLabels),
+		)
+	}
+	labels := make([]string, 0, len(payload.Labels))
+	seen := make(map[string]bool, len(payload.Labels))
+	for _, label := range payload.Labels {
+		if !delegationLabelRegex.MatchString(label) {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, fmt.Sprintf("invalid label %q", label),
+			)
+		}
+		if !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	payload.Labels = labels
+	return payload, nil
+}
+
+// requireApiKey returns the name of the api key of the request, the labels
+// being private to the api key which attached them
+func requireApiKey(request *http.Request) (string, *types.Error) {
+	apiKey := middlewares.GetApiKeyName(request)
+	if apiKey == "" {
+		return "", types.NewErrorWithMsg(
+			http.StatusUnauthorized, types.Unauthorized, middlewares.ApiKeyHeader+" header is required",
+		)
+	}
+	return apiKey, nil
+}
+
+// SetDelegationLabels godoc
+// @Summary Set the labels of a delegation
+// @Description Replaces the private labels the API key attaches to the delegation, e.g. to reconcile the
+// @Description positions of the custodial users. The labels are only returned to the same API key.
+// @Description An empty list of labels removes the labels of the delegation.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param X-Api-Key header string true "API key"
+// @Param payload body SetDelegationLabelsRequestPayload true "Delegation Labels Payload"
+// @Success 200 {object} handler.PublicResponse[v1service.DelegationLabelsPublic] "Labels of the delegation"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Failure 401 {object} types.Error "Missing or invalid API key"
+// @Failure 404 {object} types.Error "Delegation not found"
+// @Router /v1/delegation/labels [put]
+func (h *V1Handler) SetDelegationLabels(request *http.Request) (*handler.Result, *types.Error) {
+	apiKey, err := requireApiKey(request)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := parseSetDelegationLabelsRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := h.Service.SetDelegationLabels(
+		request.Context(), apiKey, payload.StakingTxHashHex, payload.Labels,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(labels), nil
+}
+
+// GetDelegationLabels godoc
+// @Summary Get the labels of a delegation
+// @Description Retrieves the private labels the API key has attached to the delegation, none if not labeled
+// @Produce json
+// @Tags v1
+// @Param X-Api-Key header string true "API key"
+// @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Success 200 {object} handler.PublicResponse[v1service.DelegationLabelsPublic] "Labels of the delegation"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid API key"
+// @Router /v1/delegation/labels [get]
+func (h *V1Handler) GetDelegationLabels(request *http.Request) (*handler.Result, *types.Error) {
+	apiKey, err := requireApiKey(request)
+	if err != nil {
+		return nil, err
+	}
+	stakingTxHash, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	labels, err := h.Service.GetDelegationLabels(request.Context(), apiKey, stakingTxHash)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(labels), nil
+}
+
+// GetLabeledDelegations godoc
+// @Summary Get the delegations with a label
+// @Description Retrieves the delegations the API key has attached the label to, ordered by staking transaction hash
+// @Produce json
+// @Tags v1
+// @Param X-Api-Key header string true "API key"
+// @Param label query string true "Label of the delegations"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} handler.PublicResponse[[]v1service.DelegationLabelsPublic]{array} "Labeled delegations and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 401 {object} types.Error "Missing or invalid API key"
+// @Router /v1/delegations/labeled [get]
+func (h *V1Handler) GetLabeledDelegations(request *http.Request) (*handler.Result, *types.Error) {
+	apiKey, err := requireApiKey(request)
+	if err != nil {
+		return nil, err
+	}
+	label := request.URL.Query().Get("label")
+	if !delegationLabelRegex.MatchString(label) {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid label")
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	delegations, newPaginationKey, err := h.Service.GetDelegationsByLabel(
+		request.Context(), apiKey, label, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPagination(delegations, newPaginationKey), nil
+}
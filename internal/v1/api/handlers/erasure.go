@@ -0,0 +1,58 @@
+package v1handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// EraseStakerData removes the off-chain data associated to a staker, given
+// either by its public key or one of its addresses. In dry run, the data are
+// only counted. Served on the admin listener only.
+func (h *V1Handler) EraseStakerData(request *http.Request) (*handler.Result, *types.Error) {
+	stakerPkHex, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", true)
+	if err != nil {
+		return nil, err
+	}
+	dryRun, err := handler.ParseDryRunQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	if stakerPkHex == "" {
+		if request.URL.Query().Get("address") == "" {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "either staker_btc_pk or address is required",
+			)
+		}
+		address, err := handler.ParseBtcAddressQuery(request, "address", h.Handler.Config.Server.BTCNetParam)
+		if err != nil {
+			return nil, err
+		}
+		pkHexes, err := h.Service.GetStakerPublicKeysByAddresses(request.Context(), []string{address})
+		if err != nil {
+			return nil, err
+		}
+		if stakerPkHex = pkHexes[address]; stakerPkHex == "" {
+			return nil, types.NewErrorWithMsg(
+				http.StatusNotFound, types.NotFound, "no staker public key known for the address",
+			)
+		}
+	}
+
+	if dryRun {
+		preview, err := h.Service.PreviewStakerDataErasure(request.Context(), stakerPkHex)
+		if err != nil {
+			return nil, err
+		}
+		return handler.NewResult(preview), nil
+	}
+	erasure, err := h.Service.EraseStakerData(
+		request.Context(), stakerPkHex, middlewares.GetClientIp(request),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(erasure), nil
+}
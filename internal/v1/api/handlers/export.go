@@ -0,0 +1,69 @@
+package v1handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/google/uuid"
+)
+
+type CreateExportJobRequestPayload struct {
+	// Type is either finality_provider_delegations or finality_providers_stats
+	Type dbmodel.ExportJobType `json:"type"`
+	// FinalityProviderPkHex is required by the finality_provider_delegations
+	// exports
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex,omitempty"`
+}
+
+// CreateExportJob godoc
+// @Summary Create an export job
+// @Description Queues an asynchronous export, written as newline delimited json into the object storage.
+// @Description The status of the job, along with its download url once completed, is fetched from /v1/exports.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param payload body CreateExportJobRequestPayload true "Export Job Payload"
+// @Success 202 {object} handler.PublicResponse[v1service.ExportJobPublic] "Export job queued"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Router /v1/exports [post]
+func (h *V1Handler) CreateExportJob(request *http.Request) (*handler.Result, *types.Error) {
+	payload := &CreateExportJobRequestPayload{}
+	if err := json.NewDecoder(request.Body).Decode(payload); err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	job, err := h.Service.CreateExportJob(request.Context(), payload.Type, payload.FinalityProviderPkHex)
+	if err != nil {
+		return nil, err
+	}
+	return &handler.Result{
+		Data:   &handler.PublicResponse[*v1service.ExportJobPublic]{Data: job},
+		Status: http.StatusAccepted,
+	}, nil
+}
+
+// GetExportJob godoc
+// @Summary Get an export job
+// @Description Fetches the status of the export job. Once completed, the job comes with a signed download url
+// @Description valid until download_url_expires_at, a new url is signed on each request.
+// @Produce json
+// @Tags v1
+// @Param id query string true "Id of the export job"
+// @Success 200 {object} handler.PublicResponse[v1service.ExportJobPublic] "Export job"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Export job not found"
+// @Router /v1/exports [get]
+func (h *V1Handler) GetExportJob(request *http.Request) (*handler.Result, *types.Error) {
+	id := request.URL.Query().Get("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid id")
+	}
+	job, err := h.Service.GetExportJob(request.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(job), nil
+}
@@ -14,6 +14,7 @@ import (
 // @Produce json
 // @Tags v1
 // @Param fp_btc_pk query string false "Public key of the finality provider to fetch"
+// @Param fields query string false "Comma separated fields of the finality providers to return"
 // @Param pagination_key query string false "Pagination key to fetch the next page of finality providers"
 // @Success 200 {object} handler.PublicResponse[[]v1service.FpDetailsPublic] "A list of finality providers sorted by ActiveTvl in descending order"
 // @Router /v1/finality-providers [get]
@@ -22,6 +23,10 @@ func (h *V1Handler) GetFinalityProviders(request *http.Request) (*handler.Result
 	if err != nil {
 		return nil, err
 	}
+	fields, err := handler.ParseFieldsQuery(request, v1service.FpDetailsPublic{})
+	if err != nil {
+		return nil, err
+	}
 	if fpPk != "" {
 		var result []*v1service.FpDetailsPublic
 		fp, err := h.Service.GetFinalityProvider(request.Context(), fpPk)
@@ -31,8 +36,12 @@ func (h *V1Handler) GetFinalityProviders(request *http.Request) (*handler.Result
 		if fp != nil {
 			result = append(result, fp)
 		}
+		data, err := handler.SelectFields(result, fields)
+		if err != nil {
+			return nil, err
+		}
 
-		return handler.NewResult(result), nil
+		return handler.NewResult(data), nil
 	}
 
 	paginationKey, err := handler.ParsePaginationQuery(request)
@@ -43,5 +52,73 @@ func (h *V1Handler) GetFinalityProviders(request *http.Request) (*handler.Result
 	if err != nil {
 		return nil, err
 	}
-	return handler.NewResultWithPagination(fps, paginationToken), nil
+	data, err := handler.SelectFields(fps, fields)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPagination(data, paginationToken), nil
+}
+
+// GetFinalityProvider gets the details of a finality provider.
+// @Summary Get Finality Provider
+// @Description Fetches the description, commission and registry status of a finality provider along with its current stats and its latest delegations.
+// @Produce json
+// @Tags v1
+// @Param fp_btc_pk query string true "Public key of the finality provider to fetch"
+// @Success 200 {object} handler.PublicResponse[v1service.FpDetailPublic] "Details of the finality provider"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/finality-provider [get]
+func (h *V1Handler) GetFinalityProvider(request *http.Request) (*handler.Result, *types.Error) {
+	fpPk, err := handler.ParsePublicKeyQuery(request, "fp_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	fp, err := h.Service.GetFinalityProviderDetail(request.Context(), fpPk)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(fp), nil
+}
+
+// GetFinalityProviderCommissionHistory gets the commission history of a finality provider.
+// @Summary Get Finality Provider Commission History
+// @Description Fetches the commission changes of a finality provider detected from the finality providers registry, the latest first.
+// @Produce json
+// @Tags v1
+// @Param fp_btc_pk query string true "Public key of the finality provider"
+// @Success 200 {object} handler.PublicResponse[[]v1service.FpCommissionChangePublic] "Commission changes of the finality provider"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/finality-provider/commission-history [get]
+func (h *V1Handler) GetFinalityProviderCommissionHistory(request *http.Request) (*handler.Result, *types.Error) {
+	fpPk, err := handler.ParsePublicKeyQuery(request, "fp_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	history, err := h.Service.GetFinalityProviderCommissionHistory(request.Context(), fpPk)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(history), nil
+}
+
+// GetFinalityProviderDelegationCount gets the delegation count of a finality provider.
+// @Summary Get Finality Provider Delegation Count
+// @Description Fetches the number of active and total delegations of a finality provider from its stats, without counting its delegations.
+// @Produce json
+// @Tags v1
+// @Param fp_btc_pk query string true "Public key of the finality provider"
+// @Success 200 {object} handler.PublicResponse[v1service.FpDelegationCountPublic] "Delegation count of the finality provider"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/finality-provider/delegation-count [get]
+func (h *V1Handler) GetFinalityProviderDelegationCount(request *http.Request) (*handler.Result, *types.Error) {
+	fpPk, err := handler.ParsePublicKeyQuery(request, "fp_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	count, err := h.Service.GetFinalityProviderDelegationCount(request.Context(), fpPk)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(count), nil
 }
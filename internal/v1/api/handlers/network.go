@@ -0,0 +1,24 @@
+package v1handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// GetNetworkTip gets the latest BTC and Babylon heights
+// @Summary Get Network Tip
+// @Description Fetches the latest BTC height, and the Babylon height if reported, which the confirmations and the timelocks are computed against.
+// @Produce json
+// @Tags v1
+// @Success 200 {object} handler.PublicResponse[v1service.NetworkTipPublic] "Latest heights of the network"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/network/tip [get]
+func (h *V1Handler) GetNetworkTip(request *http.Request) (*handler.Result, *types.Error) {
+	tip, err := h.Service.GetNetworkTip(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(tip), nil
+}
@@ -0,0 +1,104 @@
+package v1handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+)
+
+var (
+	partnerIdRegex        = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+	partnerSignatureRegex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+)
+
+type AttributeDelegationRequestPayload struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	PartnerId        string `json:"partner_id"`
+	// SignatureHex is the hex encoded HMAC-SHA256 of
+	// `<partner_id>:<staking_tx_hash_hex>` with the partner secret
+	SignatureHex string `json:"signature_hex"`
+}
+
+type AttributeDelegationResponse struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	PartnerId        string `json:"partner_id"`
+}
+
+func parseAttributeDelegationRequestPayload(
+	request *http.Request,
+) (*AttributeDelegationRequestPayload, *types.Error) {
+	payload := &AttributeDelegationRequestPayload{}
+	err := json.NewDecoder(request.Body).Decode(payload)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	if !utils.IsValidTxHash(payload.StakingTxHashHex) {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid staking transaction hash",
+		)
+	}
+	if !partnerIdRegex.MatchString(payload.PartnerId) {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid partner id")
+	}
+	if !partnerSignatureRegex.MatchString(payload.SignatureHex) {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid signature hex")
+	}
+	return payload, nil
+}
+
+// AttributeDelegationToPartner godoc
+// @Summary Attribute a delegation to a partner
+// @Description Attributes the delegation to the partner which originated it, the attribution is signed with the partner secret.
+// @Description A delegation can only be attributed to a single partner, attributing it again to the same partner is a no-op.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param payload body AttributeDelegationRequestPayload true "Partner Attribution Payload"
+// @Success 200 {object} handler.PublicResponse[AttributeDelegationResponse] "Delegation attributed to the partner"
+// @Failure 400 {object} types.Error "Invalid request payload or unknown partner"
+// @Failure 403 {object} types.Error "Invalid partner signature"
+// @Failure 404 {object} types.Error "Delegation not found"
+// @Failure 409 {object} types.Error "Delegation already attributed to another partner"
+// @Router /v1/delegation/partner [post]
+func (h *V1Handler) AttributeDelegationToPartner(request *http.Request) (*handler.Result, *types.Error) {
+	payload, err := parseAttributeDelegationRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	err = h.Service.AttributeDelegationToPartner(
+		request.Context(), payload.StakingTxHashHex, payload.PartnerId, payload.SignatureHex,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(AttributeDelegationResponse{
+		StakingTxHashHex: payload.StakingTxHashHex,
+		PartnerId:        payload.PartnerId,
+	}), nil
+}
+
+// GetPartnerStats godoc
+// @Summary Get Partner Stats
+// @Description Fetches the stats of the delegations attributed to the partner including tvl, total delegations, active tvl and active delegations.
+// @Produce json
+// @Tags v1
+// @Param partner_id query string true "Id of the partner"
+// @Success 200 {object} handler.PublicResponse[v1service.PartnerStatsPublic] "Partner stats"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Partner not found"
+// @Router /v1/stats/partner [get]
+func (h *V1Handler) GetPartnerStats(request *http.Request) (*handler.Result, *types.Error) {
+	partnerId := request.URL.Query().Get("partner_id")
+	if !partnerIdRegex.MatchString(partnerId) {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid partner_id")
+	}
+	stats, err := h.Service.GetPartnerStats(request.Context(), partnerId)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(stats), nil
+}
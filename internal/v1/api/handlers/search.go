@@ -0,0 +1,31 @@
+package v1handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
+
+// Search godoc
+// @Summary Search the delegations, stakers and finality providers
+// @Description Detects whether the query is a transaction hash, a staker or finality provider public key,
+// @Description a BTC address or a finality provider moniker, and returns the typed matches.
+// @Produce json
+// @Tags v1
+// @Param q query string true "Transaction hash, public key, BTC address or finality provider moniker"
+// @Success 200 {object} handler.PublicResponse[[]v1service.SearchMatchPublic]{array} "Search matches"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/search [get]
+func (h *V1Handler) Search(request *http.Request) (*handler.Result, *types.Error) {
+	query, err := handler.ParseFPSearchQuery(request, "q", false)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := h.Service.Search(request.Context(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(matches), nil
+}
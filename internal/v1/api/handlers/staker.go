@@ -2,10 +2,12 @@ package v1handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
 )
 
 type DelegationCheckPublicResponse struct {
@@ -13,38 +15,158 @@ type DelegationCheckPublicResponse struct {
 	Code int  `json:"code"`
 }
 
+// stakerDelegationsQuery holds the parsed queries of the staker delegations
+type stakerDelegationsQuery struct {
+	stakerBtcPk     string
+	paginationKey   string
+	stateFilter     types.DelegationState
+	rangeFilter     *types.DelegationRangeFilter
+	fields          []string
+	timestampFormat utils.TimestampFormat
+}
+
+func parseStakerDelegationsQuery(request *http.Request) (*stakerDelegationsQuery, *types.Error) {
+	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	stateFilter, err := handler.ParseStateFilterQuery(request, "state")
+	if err != nil {
+		return nil, err
+	}
+	rangeFilter, err := handler.ParseDelegationRangeFilterQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	rangeFilter.IncludeArchived, err = handler.ParseIncludeArchivedQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := handler.ParseFieldsQuery(request, v1service.DelegationPublic{})
+	if err != nil {
+		return nil, err
+	}
+	timestampFormat, err := handler.ParseTimestampFormatQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	return &stakerDelegationsQuery{
+		stakerBtcPk:     stakerBtcPk,
+		paginationKey:   paginationKey,
+		stateFilter:     stateFilter,
+		rangeFilter:     rangeFilter,
+		fields:          fields,
+		timestampFormat: timestampFormat,
+	}, nil
+}
+
 // GetStakerDelegations @Summary Get staker delegations
 // @Description Retrieves delegations for a given staker
+// @Description The delegations are streamed as newline delimited json if the request accepts application/x-ndjson
 // @Produce json
 // @Tags v1
 // @Deprecated
 // @Param staker_btc_pk query string true "Staker BTC Public Key"
 // @Param state query types.DelegationState false "Filter by state"
+// @Param min_value query integer false "Minimum staking value in satoshis (inclusive)"
+// @Param max_value query integer false "Maximum staking value in satoshis (inclusive)"
+// @Param from_timestamp query integer false "Minimum staking start timestamp in unix seconds (inclusive)"
+// @Param to_timestamp query integer false "Maximum staking start timestamp in unix seconds (inclusive)"
+// @Param include_archived query boolean false "Include the archived delegations"
+// @Param fields query string false "Comma separated fields of the delegations to return, e.g. staking_tx_hash_hex,state,staking_value"
+// @Param timestamp_format query string false "Format of the timestamps, iso8601 by default" Enums(iso8601, unix)
 // @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Param include_total query boolean false "Include the total count of the delegations along with the pagination token, if enabled"
 // @Success 200 {object} handler.PublicResponse[[]v1service.DelegationPublic]{array} "List of delegations and pagination token"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/staker/delegations [get]
 func (h *V1Handler) GetStakerDelegations(request *http.Request) (*handler.Result, *types.Error) {
-	stakerBtcPk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+	if handler.AcceptsNDJSON(request) {
+		return h.StreamStakerDelegations(request)
+	}
+	query, err := parseStakerDelegationsQuery(request)
 	if err != nil {
 		return nil, err
 	}
-	paginationKey, err := handler.ParsePaginationQuery(request)
+	includeTotal, err := handler.ParseIncludeTotalQuery(request, h.Config.TotalCount)
 	if err != nil {
 		return nil, err
 	}
-	stateFilter, err := handler.ParseStateFilterQuery(request, "state")
+	delegations, newPaginationKey, err := h.Service.DelegationsByStakerPk(
+		request.Context(), query.stakerBtcPk, query.stateFilter, query.rangeFilter,
+		query.fields, query.paginationKey,
+	)
 	if err != nil {
 		return nil, err
 	}
-	delegations, newPaginationKey, err := h.Service.DelegationsByStakerPk(
-		request.Context(), stakerBtcPk, stateFilter, paginationKey,
+	for i := range delegations {
+		delegations[i].FormatTimestamps(query.timestampFormat)
+	}
+	data, err := handler.SelectFields(delegations, query.fields)
+	if err != nil {
+		return nil, err
+	}
+	if !includeTotal {
+		return handler.NewResultWithPagination(data, newPaginationKey), nil
+	}
+
+	total, err := h.Service.CountDelegationsByStakerPk(
+		request.Context(), query.stakerBtcPk, query.stateFilter, query.rangeFilter,
 	)
 	if err != nil {
 		return nil, err
 	}
+	return handler.NewResultWithPaginationTotal(data, newPaginationKey, total), nil
+}
 
-	return handler.NewResultWithPagination(delegations, newPaginationKey), nil
+// StreamStakerDelegations @Summary Stream staker delegations
+// @Description Streams all the delegations of a given staker as newline delimited json, one delegation
+// @Description per line, as they are read from the database instead of paginating them.
+// @Description The pagination key of a previous page can be given to stream the delegations after it.
+// @Description If an error occurs once the streaming started, the last line is the error.
+// @Produce application/x-ndjson
+// @Tags v1
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param state query types.DelegationState false "Filter by state"
+// @Param min_value query integer false "Minimum staking value in satoshis (inclusive)"
+// @Param max_value query integer false "Maximum staking value in satoshis (inclusive)"
+// @Param from_timestamp query integer false "Minimum staking start timestamp in unix seconds (inclusive)"
+// @Param to_timestamp query integer false "Maximum staking start timestamp in unix seconds (inclusive)"
+// @Param include_archived query boolean false "Include the archived delegations"
+// @Param fields query string false "Comma separated fields of the delegations to return, e.g. staking_tx_hash_hex,state,staking_value"
+// @Param timestamp_format query string false "Format of the timestamps, iso8601 by default" Enums(iso8601, unix)
+// @Param pagination_key query string false "Pagination key to stream the delegations after"
+// @Success 200 {object} v1service.DelegationPublic "Delegations, one per line"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/delegations/stream [get]
+func (h *V1Handler) StreamStakerDelegations(request *http.Request) (*handler.Result, *types.Error) {
+	query, err := parseStakerDelegationsQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewStreamResult(func(encode func(item any) error) error {
+		streamErr := h.Service.StreamDelegationsByStakerPk(
+			request.Context(), query.stakerBtcPk, query.stateFilter, query.rangeFilter,
+			query.fields, query.paginationKey,
+			func(delegation v1service.DelegationPublic) error {
+				delegation.FormatTimestamps(query.timestampFormat)
+				item, err := handler.SelectFields(delegation, query.fields)
+				if err != nil {
+					return err
+				}
+				return encode(item)
+			},
+		)
+		if streamErr != nil {
+			return streamErr
+		}
+		return nil
+	}), nil
 }
 
 // CheckStakerDelegationExist @Summary Check if a staker has an active delegation
@@ -64,7 +186,7 @@ func (h *V1Handler) CheckStakerDelegationExist(request *http.Request) (*handler.
 		return nil, err
 	}
 
-	afterTimestamp, err := parseTimeframeToAfterTimestamp(request.URL.Query().Get("timeframe"))
+	afterTimestamp, err := parseTimeframeToAfterTimestamp(request.URL.Query().Get("timeframe"), h.Clock.Now())
 	if err != nil {
 		return nil, err
 	}
@@ -96,15 +218,36 @@ func buildDelegationCheckResponse(exist bool) *handler.Result {
 	}
 }
 
-func parseTimeframeToAfterTimestamp(timeframe string) (int64, *types.Error) {
+func parseTimeframeToAfterTimestamp(timeframe string, now time.Time) (int64, *types.Error) {
 	switch timeframe {
 	case "": // We ignore and return 0 if no timeframe is provided
 		return 0, nil
 	case "today":
-		return utils.GetTodayStartTimestampInSeconds(), nil
+		return utils.GetDayStartTimestampInSeconds(now), nil
 	default:
 		return 0, types.NewErrorWithMsg(
 			http.StatusBadRequest, types.BadRequest, "invalid timeframe value",
 		)
 	}
 }
+
+// GetStakerSummary @Summary Get staker summary
+// @Description Summarizes the delegations of the staker: the count and the tvl by state, the first and the last
+// @Description staking timestamps, the withdrawn amount and the pending unbondings
+// @Produce json
+// @Tags v1
+// @Param staker_pk_hex query string true "Staker BTC Public Key"
+// @Success 200 {object} handler.PublicResponse[v1service.StakerSummaryPublic] "Staker summary"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/summary [get]
+func (h *V1Handler) GetStakerSummary(request *http.Request) (*handler.Result, *types.Error) {
+	stakerPkHex, err := handler.ParsePublicKeyQuery(request, "staker_pk_hex", false)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := h.Service.GetStakerSummary(request.Context(), stakerPkHex)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(summary), nil
+}
@@ -0,0 +1,115 @@
+package v1handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+)
+
+type VerifyStakingTxRequestPayload struct {
+	StakingTxHex string `json:"staking_tx_hex"`
+}
+
+func parseVerifyStakingTxRequestPayload(request *http.Request) (*VerifyStakingTxRequestPayload, *types.Error) {
+	payload := &VerifyStakingTxRequestPayload{}
+	err := json.NewDecoder(request.Body).Decode(payload)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	if !utils.IsValidTxHex(payload.StakingTxHex) {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid staking transaction hex",
+		)
+	}
+
+	return payload, nil
+}
+
+// VerifyStakingTx godoc
+// @Summary Verify staking transaction
+// @Description Decodes the provided staking transaction and validates it against the current global params
+// @Description (covenant committee, staking time and amount bounds, registered finality provider).
+// @Description The result is a pass/fail report of each check, allowing a dry-run before broadcasting.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param payload body VerifyStakingTxRequestPayload true "Staking transaction to verify"
+// @Success 200 {object} handler.PublicResponse[v1service.StakingTxVerificationPublic] "Verification report"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Router /v1/staking/verify [post]
+func (h *V1Handler) VerifyStakingTx(request *http.Request) (*handler.Result, *types.Error) {
+	payload, err := parseVerifyStakingTxRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	report, err := h.Service.VerifyStakingTx(request.Context(), payload.StakingTxHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(report), nil
+}
+
+type StakingEligibilityRequestPayload struct {
+	StakerPkHex           string `json:"staker_pk_hex"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	StakingAmount         uint64 `json:"staking_amount"`
+	StakingTime           uint64 `json:"staking_time"`
+}
+
+func parseStakingEligibilityRequestPayload(request *http.Request) (*StakingEligibilityRequestPayload, *types.Error) {
+	payload := &StakingEligibilityRequestPayload{}
+	err := json.NewDecoder(request.Body).Decode(payload)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	if _, err := utils.GetSchnorrPkFromHex(payload.StakerPkHex); err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid staker public key",
+		)
+	}
+	if _, err := utils.GetSchnorrPkFromHex(payload.FinalityProviderPkHex); err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid finality provider public key",
+		)
+	}
+	if payload.StakingAmount == 0 || payload.StakingTime == 0 {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "staking amount and time must be positive",
+		)
+	}
+
+	return payload, nil
+}
+
+// CheckStakingEligibility godoc
+// @Summary Check staking eligibility
+// @Description Simulates whether a staking of the given amount and time to the finality provider would be
+// @Description accepted at the current BTC tip height (staking time and amount bounds, staking cap headroom,
+// @Description registered finality provider and staker constraints), before constructing the staking transaction.
+// @Description The result is the verdict along with a pass/fail report of each check.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param payload body StakingEligibilityRequestPayload true "Staking to check"
+// @Success 200 {object} handler.PublicResponse[v1service.StakingEligibilityPublic] "Eligibility report"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Router /v1/staking/eligibility [post]
+func (h *V1Handler) CheckStakingEligibility(request *http.Request) (*handler.Result, *types.Error) {
+	payload, err := parseStakingEligibilityRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	report, err := h.Service.CheckStakingEligibility(
+		request.Context(), payload.StakerPkHex, payload.FinalityProviderPkHex,
+		payload.StakingAmount, payload.StakingTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(report), nil
+}
@@ -1,10 +1,12 @@
 package v1handlers
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
 	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
 )
 
@@ -33,6 +35,7 @@ func (h *V1Handler) GetOverallStats(request *http.Request) (*handler.Result, *ty
 // @Tags v1
 // @Param  staker_btc_pk query string false "Public key of the staker to fetch"
 // @Param  pagination_key query string false "Pagination key to fetch the next page of top stakers"
+// @Param  include_total query boolean false "Include the total count of the stakers along with the pagination token, if enabled"
 // @Success 200 {object} handler.PublicResponse[[]v1service.StakerStatsPublic]{array} "List of top stakers by active tvl"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/stats/staker [get]
@@ -60,10 +63,87 @@ func (h *V1Handler) GetStakersStats(request *http.Request) (*handler.Result, *ty
 	if err != nil {
 		return nil, err
 	}
+	includeTotal, err := handler.ParseIncludeTotalQuery(request, h.Config.TotalCount)
+	if err != nil {
+		return nil, err
+	}
 	topStakerStats, paginationToken, err := h.Service.GetTopStakersByActiveTvl(request.Context(), paginationKey)
 	if err != nil {
 		return nil, err
 	}
+	if !includeTotal {
+		return handler.NewResultWithPagination(topStakerStats, paginationToken), nil
+	}
+
+	total, err := h.Service.CountStakers(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPaginationTotal(topStakerStats, paginationToken, total), nil
+}
+
+// MAX_NUM_STAKERS_STATS_PKS is the maximum number of stakers whose stats can
+// be fetched in a single request
+const MAX_NUM_STAKERS_STATS_PKS = 1000
+
+// MAX_STAKERS_STATS_CONTENT_LENGTH fits MAX_NUM_STAKERS_STATS_PKS public keys
+// in the request body, each of them taking 67 bytes along with its quotes
+// and separator, plus some room for the rest of the payload
+const MAX_STAKERS_STATS_CONTENT_LENGTH = 67*MAX_NUM_STAKERS_STATS_PKS + 1024
+
+type StakersStatsRequestPayload struct {
+	StakerBtcPks []string `json:"staker_btc_pks"`
+}
+
+// GetStakersStatsByPks gets the stats of a list of stakers
+// @Summary Get Stats of Stakers
+// @Description Fetches the stats of up to 1000 stakers in one request, in the order of the given public keys.
+// @Description The stakers without stats are not included in the response.
+// @Accept json
+// @Produce json
+// @Tags v1
+// @Param payload body StakersStatsRequestPayload true "Public keys of the stakers to fetch"
+// @Success 200 {object} handler.PublicResponse[[]v1service.StakerStatsPublic]{array} "Stats of the stakers"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/stakers/stats [post]
+func (h *V1Handler) GetStakersStatsByPks(request *http.Request) (*handler.Result, *types.Error) {
+	var payload StakersStatsRequestPayload
+	if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid input format")
+	}
+	if len(payload.StakerBtcPks) == 0 {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "staker_btc_pks is required")
+	}
+	if len(payload.StakerBtcPks) > MAX_NUM_STAKERS_STATS_PKS {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "too many staker_btc_pks in the request")
+	}
+	for _, pkHex := range payload.StakerBtcPks {
+		if _, err := utils.GetSchnorrPkFromHex(pkHex); err != nil {
+			return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid staker_btc_pks")
+		}
+	}
+
+	stakersStats, err := h.Service.GetStakersStatsByPks(request.Context(), payload.StakerBtcPks)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(stakersStats), nil
+}
 
-	return handler.NewResultWithPagination(topStakerStats, paginationToken), nil
+// GetStatsDelta gets the change of the overall stats over a window
+// @Summary Get Overall Stats Delta
+// @Description Fetches the change of the overall stats over the last 24 hours or 7 days, compared against the hourly snapshot taken at the start of the window.
+// @Produce json
+// @Tags v1
+// @Param window query string true "Window of the change" Enums(24h, 7d)
+// @Success 200 {object} handler.PublicResponse[v1service.StatsDeltaPublic] "Change of the overall stats"
+// @Failure 400 {object} types.Error "Invalid window"
+// @Failure 404 {object} types.Error "Not enough stats history for the window"
+// @Router /v1/stats/delta [get]
+func (h *V1Handler) GetStatsDelta(request *http.Request) (*handler.Result, *types.Error) {
+	delta, err := h.Service.GetStatsDelta(request.Context(), request.URL.Query().Get("window"))
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(delta), nil
 }
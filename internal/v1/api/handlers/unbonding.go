@@ -14,6 +14,9 @@ type UnbondDelegationRequestPayload struct {
 	UnbondingTxHashHex       string `json:"unbonding_tx_hash_hex"`
 	UnbondingTxHex           string `json:"unbonding_tx_hex"`
 	StakerSignedSignatureHex string `json:"staker_signed_signature_hex"`
+	// Alternative to the unbonding tx hex and signature, the base64 encoded
+	// PSBT containing the unbonding tx and the staker signature
+	UnbondingPsbtBase64 string `json:"unbonding_psbt_base64,omitempty"`
 }
 
 func parseUnbondDelegationRequestPayload(request *http.Request) (*UnbondDelegationRequestPayload, *types.Error) {
@@ -33,6 +36,20 @@ func parseUnbondDelegationRequestPayload(request *http.Request) (*UnbondDelegati
 			http.StatusBadRequest, types.BadRequest, "invalid unbonding transaction hash",
 		)
 	}
+	if payload.UnbondingPsbtBase64 != "" {
+		if payload.UnbondingTxHex != "" || payload.StakerSignedSignatureHex != "" {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest,
+				"unbonding psbt can not be provided along with the unbonding transaction hex or signature",
+			)
+		}
+		if !utils.IsBase64Encoded(payload.UnbondingPsbtBase64) {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid unbonding psbt",
+			)
+		}
+		return payload, nil
+	}
 	if !utils.IsValidTxHex(payload.UnbondingTxHex) {
 		return nil, types.NewErrorWithMsg(
 			http.StatusBadRequest, types.BadRequest, "invalid unbonding transaction hex",
@@ -50,12 +67,14 @@ func parseUnbondDelegationRequestPayload(request *http.Request) (*UnbondDelegati
 // UnbondDelegation godoc
 // @Summary Unbond delegation
 // @Description Unbonds a delegation by processing the provided transaction details. This is an async operation.
+// @Description The unbonding transaction and staker signature can alternatively be submitted as a base64 encoded PSBT.
 // @Accept json
 // @Produce json
 // @Tags v1
 // @Param payload body UnbondDelegationRequestPayload true "Unbonding Request Payload"
 // @Success 202 "Request accepted and will be processed asynchronously"
 // @Failure 400 {object} types.Error "Invalid request payload"
+// @Failure 403 {object} types.Error "Delegation not eligible for unbonding, or UNBONDING_REQUEST_REPLAYED if the signature has already been submitted"
 // @Router /v1/unbonding [post]
 func (h *V1Handler) UnbondDelegation(request *http.Request) (*handler.Result, *types.Error) {
 	payload, err := parseUnbondDelegationRequestPayload(request)
@@ -65,7 +84,7 @@ func (h *V1Handler) UnbondDelegation(request *http.Request) (*handler.Result, *t
 	unbondErr := h.Service.UnbondDelegation(
 		request.Context(), payload.StakingTxHashHex,
 		payload.UnbondingTxHashHex, payload.UnbondingTxHex,
-		payload.StakerSignedSignatureHex,
+		payload.StakerSignedSignatureHex, payload.UnbondingPsbtBase64,
 	)
 	if unbondErr != nil {
 		return nil, unbondErr
@@ -95,3 +114,27 @@ func (h *V1Handler) GetUnbondingEligibility(request *http.Request) (*handler.Res
 
 	return &handler.Result{Status: http.StatusOK}, nil
 }
+
+// GetUnbondingRequestStatus godoc
+// @Summary Get the status of an unbonding request
+// @Description Retrieves the status of a submitted unbonding request: accepted, picked_up by the
+// @Description unbonding pipeline, broadcast, confirmed on BTC, or failed to be broadcast.
+// @Produce json
+// @Tags v1
+// @Param unbonding_tx_hash_hex query string true "Unbonding transaction hash in hex format"
+// @Success 200 {object} handler.PublicResponse[v1service.UnbondingRequestStatusPublic] "Unbonding request status"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/unbonding/status [get]
+func (h *V1Handler) GetUnbondingRequestStatus(request *http.Request) (*handler.Result, *types.Error) {
+	unbondingTxHashHex, err := handler.ParseTxHashQuery(request, "unbonding_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	status, err := h.Service.GetUnbondingRequestStatus(request.Context(), unbondingTxHashHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(status), nil
+}
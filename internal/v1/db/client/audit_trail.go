@@ -0,0 +1,56 @@
+package v1dbclient
+
+import (
+	"context"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveDelegationMilestone records the milestone in the delegation audit trail.
+// Recording an already existing milestone is a no-op.
+func (v1dbclient *V1Database) SaveDelegationMilestone(
+	ctx context.Context, stakingTxHashHex string, milestone v1dbmodel.DelegationMilestone,
+	txHashHex string, blockHeight uint64, timestamp int64,
+) error {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationAuditTrailCollection)
+	id := v1dbmodel.BuildDelegationAuditTrailId(stakingTxHashHex, milestone)
+	document := v1dbmodel.DelegationAuditTrailDocument{
+		Id:               id,
+		StakingTxHashHex: stakingTxHashHex,
+		Milestone:        milestone,
+		TxHashHex:        txHashHex,
+		BlockHeight:      blockHeight,
+		Timestamp:        timestamp,
+		RecordedAt:       v1dbclient.Clock.Now().Unix(),
+	}
+	_, err := client.UpdateOne(
+		ctx, bson.M{"_id": id}, bson.M{"$setOnInsert": document},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// FindDelegationMilestones returns the milestones recorded for the delegation
+// in the order they were recorded.
+func (v1dbclient *V1Database) FindDelegationMilestones(
+	ctx context.Context, stakingTxHashHex string,
+) ([]v1dbmodel.DelegationAuditTrailDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationAuditTrailCollection)
+	cursor, err := client.Find(
+		ctx, bson.M{"staking_tx_hash_hex": stakingTxHashHex},
+		options.Find().SetSort(bson.D{{Key: "recorded_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var milestones []v1dbmodel.DelegationAuditTrailDocument
+	if err := cursor.All(ctx, &milestones); err != nil {
+		return nil, err
+	}
+	return milestones, nil
+}
@@ -12,11 +12,11 @@ import (
 )
 
 func (v1dbclient *V1Database) UpsertLatestBtcInfo(
-	ctx context.Context, height uint64, confirmedTvl, unconfirmedTvl uint64,
+	ctx context.Context, height, babylonHeight uint64, confirmedTvl, unconfirmedTvl uint64,
 ) error {
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1BtcInfoCollection)
 	// Start a session
-	session, sessionErr := v1dbclient.Client.StartSession()
+	session, sessionErr := v1dbclient.Client.StartSession(v1dbclient.SessionOptions())
 	if sessionErr != nil {
 		return sessionErr
 	}
@@ -33,6 +33,7 @@ func (v1dbclient *V1Database) UpsertLatestBtcInfo(
 		btcInfo := &v1dbmodel.BtcInfo{
 			ID:             v1dbmodel.LatestBtcInfoId,
 			BtcHeight:      height,
+			BabylonHeight:  babylonHeight,
 			ConfirmedTvl:   confirmedTvl,
 			UnconfirmedTvl: unconfirmedTvl,
 		}
@@ -59,12 +60,12 @@ func (v1dbclient *V1Database) UpsertLatestBtcInfo(
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	_, txErr := session.WithTransaction(ctx, transactionWork, v1dbclient.TransactionOptions())
 	return txErr
 }
 
 func (v1dbclient *V1Database) GetLatestBtcInfo(ctx context.Context) (*v1dbmodel.BtcInfo, error) {
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1BtcInfoCollection)
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1BtcInfoCollection)
 	var btcInfo v1dbmodel.BtcInfo
 	err := client.FindOne(ctx, bson.M{"_id": v1dbmodel.LatestBtcInfoId}).Decode(&btcInfo)
 	if err != nil {
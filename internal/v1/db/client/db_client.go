@@ -3,21 +3,28 @@ package v1dbclient
 import (
 	"context"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/clock"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type V1Database struct {
 	*dbclient.Database
 }
 
-func New(ctx context.Context, client *mongo.Client, cfg *config.DbConfig) (*V1Database, error) {
+func New(ctx context.Context, client *dbclient.MongoClient, cfg *config.DbConfig) (*V1Database, error) {
+	fieldCipher, err := db.NewFieldCipher(cfg.FieldEncryption)
+	if err != nil {
+		return nil, err
+	}
 	return &V1Database{
 		Database: &dbclient.Database{
-			DbName: cfg.DbName,
-			Client: client,
-			Cfg:    cfg,
+			DbName:      cfg.DbName,
+			Client:      client,
+			Cfg:         cfg,
+			Clock:       clock.New(),
+			FieldCipher: fieldCipher,
 		},
 	}, nil
 }
@@ -3,6 +3,7 @@ package v1dbclient
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,13 +13,14 @@ import (
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
 	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 )
 
 func (v1dbclient *V1Database) SaveActiveStakingDelegation(
 	ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string,
 	stakingTxHex string, amount, startHeight, timelock, outputIndex uint64,
-	startTimestamp int64, isOverflow bool,
+	startTimestamp int64, isOverflow bool, state types.DelegationState,
 ) error {
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
 	document := v1dbmodel.DelegationDocument{
@@ -26,7 +28,7 @@ func (v1dbclient *V1Database) SaveActiveStakingDelegation(
 		StakerPkHex:           stakerPkHex,
 		FinalityProviderPkHex: fpPkHex,
 		StakingValue:          amount,
-		State:                 types.Active,
+		State:                 state,
 		StakingTx: &v1dbmodel.TimelockTransaction{
 			TxHex:          stakingTxHex,
 			OutputIndex:    outputIndex,
@@ -34,9 +36,16 @@ func (v1dbclient *V1Database) SaveActiveStakingDelegation(
 			StartHeight:    startHeight,
 			TimeLock:       timelock,
 		},
-		IsOverflow: isOverflow,
+		IsOverflow:    isOverflow,
+		SchemaVersion: v1dbmodel.DelegationSchemaVersion,
 	}
-	_, err := client.InsertOne(ctx, document)
+	document.ExpiryHeight = document.StakingTx.ExpiryHeight()
+	document.Checksum = document.ComputeChecksum()
+	_, err := v1dbclient.writeDelegationChange(ctx, func(sessCtx mongo.SessionContext, changeFields bson.M) (interface{}, error) {
+		document.ChangeSeq = changeFields["change_seq"].(int64)
+		document.UpdatedAt = changeFields["updated_at"].(int64)
+		return client.InsertOne(sessCtx, document)
+	})
 	if err != nil {
 		var writeErr mongo.WriteException
 		if errors.As(err, &writeErr) {
@@ -60,7 +69,7 @@ func (v1dbclient *V1Database) SaveActiveStakingDelegation(
 func (v1dbclient *V1Database) CheckDelegationExistByStakerPk(
 	ctx context.Context, stakerPk string, extraFilter *DelegationFilter,
 ) (bool, error) {
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
 	filter := buildAdditionalDelegationFilter(
 		bson.M{"staker_pk_hex": stakerPk}, extraFilter,
 	)
@@ -79,10 +88,124 @@ func (v1dbclient *V1Database) FindDelegationsByStakerPk(
 	ctx context.Context, stakerPk string,
 	extraFilter *DelegationFilter, paginationToken string,
 ) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter, options, err := buildDelegationsByStakerPkQuery(stakerPk, extraFilter, paginationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one more than the limit to check if there are more results
+	options.SetLimit(v1dbclient.Cfg.MaxPaginationLimit + 1)
+	cursor, err := v1dbclient.findDelegations(ctx, filter, options, extraFilter.includeArchived())
+	if err != nil {
+		return nil, err
+	}
+	result, err := db.DecodeWithPagination(
+		ctx, cursor, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbmodel.BuildDelegationByStakerPaginationToken,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := v1dbclient.decryptPartnerIds(result.Data); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v1dbclient *V1Database) StreamDelegationsByStakerPk(
+	ctx context.Context, stakerPk string,
+	extraFilter *DelegationFilter, paginationToken string,
+	fn func(delegation *v1dbmodel.DelegationDocument) error,
+) error {
+	filter, options, err := buildDelegationsByStakerPkQuery(stakerPk, extraFilter, paginationToken)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := v1dbclient.findDelegations(ctx, filter, options, extraFilter.includeArchived())
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var delegation v1dbmodel.DelegationDocument
+		if err := cursor.Decode(&delegation); err != nil {
+			return err
+		}
+		if err := v1dbclient.decryptPartnerId(&delegation); err != nil {
+			return err
+		}
+		if err := fn(&delegation); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (v1dbclient *V1Database) StreamDelegationsByFinalityProviderPk(
+	ctx context.Context, fpPkHex string,
+	fn func(delegation *v1dbmodel.DelegationDocument) error,
+) error {
+	cursor, err := v1dbclient.findDelegations(
+		ctx, bson.M{"finality_provider_pk_hex": fpPkHex}, options.Find(), true,
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var delegation v1dbmodel.DelegationDocument
+		if err := cursor.Decode(&delegation); err != nil {
+			return err
+		}
+		if err := v1dbclient.decryptPartnerId(&delegation); err != nil {
+			return err
+		}
+		if err := fn(&delegation); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (v1dbclient *V1Database) FindRecentDelegationsByFinalityProviderPk(
+	ctx context.Context, fpPkHex string, limit int64,
+) ([]v1dbmodel.DelegationDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
+	options := options.Find().SetSort(bson.D{
+		{Key: "staking_tx.start_height", Value: -1},
+		{Key: "_id", Value: 1},
+	}).SetLimit(limit)
+	cursor, err := client.Find(ctx, bson.M{"finality_provider_pk_hex": fpPkHex}, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	delegations := make([]v1dbmodel.DelegationDocument, 0, limit)
+	if err := cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+	if err := v1dbclient.decryptPartnerIds(delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+func (v1dbclient *V1Database) CountDelegationsByStakerPk(
+	ctx context.Context, stakerPk string, extraFilter *DelegationFilter,
+) (int64, error) {
+	filter := buildAdditionalDelegationFilter(bson.M{"staker_pk_hex": stakerPk}, extraFilter)
+	return v1dbclient.countDelegations(ctx, filter, extraFilter.includeArchived())
+}
 
+// buildDelegationsByStakerPkQuery builds the filter and the options of the
+// delegations of the staker, sorted by the staking start height, starting
+// after the pagination token if any
+func buildDelegationsByStakerPkQuery(
+	stakerPk string, extraFilter *DelegationFilter, paginationToken string,
+) (bson.M, *options.FindOptions, error) {
 	filter := bson.M{"staker_pk_hex": stakerPk}
-	filter = buildAdditionalDelegationFilter(filter, extraFilter)
 	options := options.Find().SetSort(bson.D{
 		{Key: "staking_tx.start_height", Value: -1},
 		{Key: "_id", Value: 1},
@@ -92,28 +215,29 @@ func (v1dbclient *V1Database) FindDelegationsByStakerPk(
 	if paginationToken != "" {
 		decodedToken, err := dbmodel.DecodePaginationToken[v1dbmodel.DelegationByStakerPagination](paginationToken)
 		if err != nil {
-			return nil, &db.InvalidPaginationTokenError{
+			return nil, nil, &db.InvalidPaginationTokenError{
 				Message: "Invalid pagination token",
 			}
 		}
-		filter = bson.M{
-			"$or": []bson.M{
-				{"staker_pk_hex": stakerPk, "staking_tx.start_height": bson.M{"$lt": decodedToken.StakingStartHeight}},
-				{"staker_pk_hex": stakerPk, "staking_tx.start_height": decodedToken.StakingStartHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
-			},
+		filter["$or"] = []bson.M{
+			{"staking_tx.start_height": bson.M{"$lt": decodedToken.StakingStartHeight}},
+			{"staking_tx.start_height": decodedToken.StakingStartHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
 		}
 	}
-
-	return db.FindWithPagination(
-		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
-		v1dbmodel.BuildDelegationByStakerPaginationToken,
-	)
+	// The extra filter applies to the subsequent pages as well
+	filter = buildAdditionalDelegationFilter(filter, extraFilter)
+	if extraFilter != nil && len(extraFilter.Fields) > 0 {
+		options.SetProjection(buildDelegationProjection(
+			extraFilter.Fields, "_id", "staking_tx.start_height",
+		))
+	}
+	return filter, options, nil
 }
 
 // SaveUnbondingTx saves the unbonding transaction details for a staking transaction
 // It returns an NotFoundError if the staking transaction is not found
 func (v1dbclient *V1Database) FindDelegationByTxHashHex(ctx context.Context, stakingTxHashHex string) (*v1dbmodel.DelegationDocument, error) {
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
 	filter := bson.M{"_id": stakingTxHashHex}
 	var delegation v1dbmodel.DelegationDocument
 	err := client.FindOne(ctx, filter).Decode(&delegation)
@@ -126,14 +250,60 @@ func (v1dbclient *V1Database) FindDelegationByTxHashHex(ctx context.Context, sta
 		}
 		return nil, err
 	}
+	if err := v1dbclient.decryptPartnerId(&delegation); err != nil {
+		return nil, err
+	}
 	return &delegation, nil
 }
 
+func (v1dbclient *V1Database) DelegationExists(ctx context.Context, stakingTxHashHex string) (bool, error) {
+	return v1dbclient.Exists(ctx, dbmodel.V1DelegationCollection, bson.M{"_id": stakingTxHashHex})
+}
+
+func (v1dbclient *V1Database) FindDelegationByAnyTxHashHex(
+	ctx context.Context, txHashHex string,
+) (*v1dbmodel.DelegationDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{"$or": []bson.M{
+		{"_id": txHashHex},
+		{"unbonding_tx.tx_hash_hex": txHashHex},
+		{"withdrawal_tx.tx_hash_hex": txHashHex},
+	}}
+	var delegation v1dbmodel.DelegationDocument
+	err := client.FindOne(ctx, filter).Decode(&delegation)
+	if err == nil {
+		if err := v1dbclient.decryptPartnerId(&delegation); err != nil {
+			return nil, err
+		}
+		return &delegation, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	// The unbonding tx is only set on the delegation once confirmed on BTC,
+	// fallback to the unbonding requests for the pending ones.
+	var unbonding v1dbmodel.UnbondingDocument
+	err = v1dbclient.Db(ctx).Collection(dbmodel.V1UnbondingCollection).FindOne(
+		ctx, bson.M{"unbonding_tx_hash_hex": txHashHex},
+	).Decode(&unbonding)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     txHashHex,
+				Message: "Delegation not found",
+			}
+		}
+		return nil, err
+	}
+	return v1dbclient.FindDelegationByTxHashHex(ctx, unbonding.StakingTxHashHex)
+}
+
 func (v1dbclient *V1Database) ScanDelegationsPaginated(
 	ctx context.Context,
 	paginationToken string,
 ) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
 	filter := bson.M{}
 	options := options.Find()
 	options.SetSort(bson.M{"_id": 1})
@@ -150,10 +320,17 @@ func (v1dbclient *V1Database) ScanDelegationsPaginated(
 	}
 
 	// Perform the paginated query and return the results
-	return db.FindWithPagination(
+	result, err := db.FindWithPagination(
 		ctx, client, filter, options, v1dbclient.Cfg.MaxPaginationLimit,
 		v1dbmodel.BuildDelegationScanPaginationToken,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if err := v1dbclient.decryptPartnerIds(result.Data); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // TransitionState updates the state of a staking transaction to a new state
@@ -164,12 +341,14 @@ func (v1dbclient *V1Database) transitionState(
 ) error {
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
 	filter := bson.M{"_id": stakingTxHashHex, "state": bson.M{"$in": eligiblePreviousState}}
-	update := bson.M{"$set": bson.M{"state": newState}}
-	for field, value := range additionalUpdates {
-		// Add additional fields to the $set operation
-		update["$set"].(bson.M)[field] = value
-	}
-	_, err := client.UpdateOne(ctx, filter, update)
+	_, err := v1dbclient.writeDelegationChange(ctx, func(sessCtx mongo.SessionContext, setFields bson.M) (interface{}, error) {
+		setFields["state"] = newState
+		for field, value := range additionalUpdates {
+			// Add additional fields to the $set operation
+			setFields[field] = value
+		}
+		return client.UpdateOne(sessCtx, filter, bson.M{"$set": setFields})
+	})
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return &db.NotFoundError{
@@ -182,6 +361,54 @@ func (v1dbclient *V1Database) transitionState(
 	return nil
 }
 
+// TransitionPendingToActiveState transitions all the pending delegations whose
+// staking tx start height is within [fromStartHeight, toStartHeight] to active.
+// It returns the staking tx hashes of the delegations transitioned.
+func (v1dbclient *V1Database) TransitionPendingToActiveState(
+	ctx context.Context, fromStartHeight, toStartHeight uint64,
+) ([]string, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{
+		"state": bson.M{"$in": utils.QualifiedStatesToActive()},
+		"staking_tx.start_height": bson.M{
+			"$gte": fromStartHeight,
+			"$lte": toStartHeight,
+		},
+	}
+	// All the delegations transitioned at once share the same change sequence.
+	// They are read within the transaction so that the returned ones are the
+	// ones updated.
+	result, err := v1dbclient.writeDelegationChange(ctx, func(sessCtx mongo.SessionContext, changeFields bson.M) (interface{}, error) {
+		cursor, err := client.Find(sessCtx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
+			return nil, err
+		}
+		var delegations []v1dbmodel.DelegationDocument
+		if err := cursor.All(sessCtx, &delegations); err != nil {
+			return nil, err
+		}
+		if len(delegations) == 0 {
+			return []string(nil), nil
+		}
+		stakingTxHashHexes := make([]string, 0, len(delegations))
+		for _, delegation := range delegations {
+			stakingTxHashHexes = append(stakingTxHashHexes, delegation.StakingTxHashHex)
+		}
+		changeFields["state"] = types.Active.ToString()
+		_, err = client.UpdateMany(
+			sessCtx, bson.M{"_id": bson.M{"$in": stakingTxHashHexes}}, bson.M{"$set": changeFields},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return stakingTxHashHexes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
+
 func buildAdditionalDelegationFilter(
 	baseFilter primitive.M,
 	filters *DelegationFilter,
@@ -190,9 +417,50 @@ func buildAdditionalDelegationFilter(
 		if filters.States != nil {
 			baseFilter["state"] = bson.M{"$in": filters.States}
 		}
+		timestampFilter := bson.M{}
 		if filters.AfterTimestamp != 0 {
-			baseFilter["staking_tx.start_timestamp"] = bson.M{"$gte": filters.AfterTimestamp}
+			timestampFilter["$gte"] = filters.AfterTimestamp
+		}
+		if filters.BeforeTimestamp != 0 {
+			timestampFilter["$lte"] = filters.BeforeTimestamp
+		}
+		if len(timestampFilter) > 0 {
+			baseFilter["staking_tx.start_timestamp"] = timestampFilter
+		}
+		valueFilter := bson.M{}
+		if filters.MinStakingValue != 0 {
+			valueFilter["$gte"] = int64(filters.MinStakingValue)
+		}
+		if filters.MaxStakingValue != 0 {
+			valueFilter["$lte"] = int64(filters.MaxStakingValue)
+		}
+		if len(valueFilter) > 0 {
+			baseFilter["staking_value"] = valueFilter
 		}
 	}
 	return baseFilter
 }
+
+// buildDelegationProjection includes the given fields along with the required
+// ones. A field nested in another included field is left out, as Mongo
+// rejects the projections with path collisions.
+func buildDelegationProjection(fields []string, requiredFields ...string) bson.D {
+	allFields := append(requiredFields, fields...)
+	included := make(map[string]bool, len(allFields))
+	for _, field := range allFields {
+		included[field] = true
+	}
+	var projection bson.D
+	projected := make(map[string]bool, len(allFields))
+	for _, field := range allFields {
+		if projected[field] {
+			continue
+		}
+		if parent, _, nested := strings.Cut(field, "."); nested && included[parent] {
+			continue
+		}
+		projected[field] = true
+		projection = append(projection, bson.E{Key: field, Value: 1})
+	}
+	return projection
+}
@@ -0,0 +1,182 @@
+package v1dbclient
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+)
+
+// ArchiveDelegations moves up to limit delegations in the given states which
+// have not been updated since updatedBefore from the delegations collection
+// into the archive collection, within a single transaction. It returns the
+// number of delegations archived.
+func (v1dbclient *V1Database) ArchiveDelegations(
+	ctx context.Context, states []types.DelegationState, updatedBefore, limit int64,
+) (int64, error) {
+	delegationClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	archiveClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationArchiveCollection)
+	filter := archivableDelegationsFilter(states, updatedBefore)
+	cursor, err := delegationClient.Find(
+		ctx, filter, options.Find().SetSort(bson.M{"updated_at": 1}).SetLimit(limit),
+	)
+	if err != nil {
+		return 0, err
+	}
+	var delegations []v1dbmodel.DelegationDocument
+	if err := cursor.All(ctx, &delegations); err != nil {
+		return 0, err
+	}
+	if len(delegations) == 0 {
+		return 0, nil
+	}
+
+	// Start a session
+	session, sessionErr := v1dbclient.Client.StartSession(v1dbclient.SessionOptions())
+	if sessionErr != nil {
+		return 0, sessionErr
+	}
+	defer session.EndSession(ctx)
+
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		var archived int64
+		for _, delegation := range delegations {
+			// The filter is applied again so that a delegation updated since
+			// it was read is left in place without an archived copy. The
+			// deleted document is archived rather than the one read.
+			deleteFilter := archivableDelegationsFilter(states, updatedBefore)
+			deleteFilter["_id"] = delegation.StakingTxHashHex
+			var deleted v1dbmodel.DelegationDocument
+			err := delegationClient.FindOneAndDelete(sessCtx, deleteFilter).Decode(&deleted)
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			_, err = archiveClient.ReplaceOne(
+				sessCtx, bson.M{"_id": deleted.StakingTxHashHex}, deleted,
+				options.Replace().SetUpsert(true),
+			)
+			if err != nil {
+				return nil, err
+			}
+			archived++
+		}
+		return archived, nil
+	}
+
+	// Execute the transaction
+	result, txErr := session.WithTransaction(ctx, transactionWork, v1dbclient.TransactionOptions())
+	if txErr != nil {
+		return 0, txErr
+	}
+	return result.(int64), nil
+}
+
+// FindArchivedDelegationByTxHashHex finds the delegation in the archive
+// collection. It returns a NotFoundError if the delegation is not archived.
+func (v1dbclient *V1Database) FindArchivedDelegationByTxHashHex(
+	ctx context.Context, stakingTxHashHex string,
+) (*v1dbmodel.DelegationDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationArchiveCollection)
+	var delegation v1dbmodel.DelegationDocument
+	err := client.FindOne(ctx, bson.M{"_id": stakingTxHashHex}).Decode(&delegation)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     stakingTxHashHex,
+				Message: "Archived delegation not found",
+			}
+		}
+		return nil, err
+	}
+	if err := v1dbclient.decryptPartnerId(&delegation); err != nil {
+		return nil, err
+	}
+	return &delegation, nil
+}
+
+// ArchivedDelegationExists checks whether the delegation is archived without
+// reading the archived delegation
+func (v1dbclient *V1Database) ArchivedDelegationExists(ctx context.Context, stakingTxHashHex string) (bool, error) {
+	return v1dbclient.Exists(ctx, dbmodel.V1DelegationArchiveCollection, bson.M{"_id": stakingTxHashHex})
+}
+
+// findDelegations runs the query over the delegations collection, along with
+// the archive collection if the archived delegations are included. The
+// results of both collections are merged in the order of the query.
+func (v1dbclient *V1Database) findDelegations(
+	ctx context.Context, filter bson.M, opts *options.FindOptions, includeArchived bool,
+) (*mongo.Cursor, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
+	if !includeArchived {
+		return client.Find(ctx, filter, opts)
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$unionWith": bson.M{
+			"coll":     dbmodel.V1DelegationArchiveCollection,
+			"pipeline": bson.A{bson.M{"$match": filter}},
+		}},
+	}
+	if opts.Sort != nil {
+		pipeline = append(pipeline, bson.M{"$sort": opts.Sort})
+	}
+	if opts.Projection != nil {
+		pipeline = append(pipeline, bson.M{"$project": opts.Projection})
+	}
+	if opts.Limit != nil {
+		pipeline = append(pipeline, bson.M{"$limit": *opts.Limit})
+	}
+	return client.Aggregate(ctx, pipeline)
+}
+
+// archivedDelegationsPipeline starts an aggregation over the delegations
+// matching the filter in both the delegations and the archive collections,
+// so that the totals are not reduced by archiving the delegations
+func archivedDelegationsPipeline(filter bson.M) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$unionWith", Value: bson.M{
+			"coll":     dbmodel.V1DelegationArchiveCollection,
+			"pipeline": bson.A{bson.M{"$match": filter}},
+		}}},
+	}
+}
+
+// countDelegations counts the delegations matching the filter in the
+// delegations collection, along with the archive collection if the archived
+// delegations are included
+func (v1dbclient *V1Database) countDelegations(
+	ctx context.Context, filter bson.M, includeArchived bool,
+) (int64, error) {
+	count, err := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection).CountDocuments(ctx, filter)
+	if err != nil || !includeArchived {
+		return count, err
+	}
+	archived, err := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationArchiveCollection).CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return count + archived, nil
+}
+
+func archivableDelegationsFilter(states []types.DelegationState, updatedBefore int64) bson.M {
+	return bson.M{
+		"state":      bson.M{"$in": states},
+		"updated_at": bson.M{"$lt": updatedBefore},
+	}
+}
+
+func (filter *DelegationFilter) includeArchived() bool {
+	return filter != nil && filter.IncludeArchived
+}
@@ -0,0 +1,203 @@
+package v1dbclient
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+)
+
+// DelegationStateTransition is the transition of a delegation within a bulk
+// state transition
+type DelegationStateTransition struct {
+	StakingTxHashHex string
+	// AdditionalUpdates are set along with the new state, e.g. the withdrawal tx
+	AdditionalUpdates map[string]interface{}
+}
+
+// BulkSaveActiveStakingDelegations inserts the delegations in a single
+// transaction, setting their change sequence and update time. The
+// delegations which already exist are reported by their index with a
+// DuplicateKeyError, the other ones are inserted.
+func (v1dbclient *V1Database) BulkSaveActiveStakingDelegations(
+	ctx context.Context, delegations []*v1dbmodel.DelegationDocument,
+) (*db.BulkWriteResult, error) {
+	if len(delegations) == 0 {
+		return db.NewBulkWriteResult(), nil
+	}
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	stakingTxHashHexes := make([]string, 0, len(delegations))
+	for _, delegation := range delegations {
+		stakingTxHashHexes = append(stakingTxHashHexes, delegation.StakingTxHashHex)
+	}
+
+	// All the delegations saved at once share the same change sequence. A
+	// duplicate key error would abort the transaction, the existing
+	// delegations are left out of the insert instead.
+	result, err := v1dbclient.writeDelegationChange(ctx, func(sessCtx mongo.SessionContext, changeFields bson.M) (interface{}, error) {
+		result := db.NewBulkWriteResult()
+		cursor, err := client.Find(sessCtx,
+			bson.M{"_id": bson.M{"$in": stakingTxHashHexes}},
+			options.Find().SetProjection(bson.M{"_id": 1}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		var existing []struct {
+			StakingTxHashHex string `bson:"_id"`
+		}
+		if err := cursor.All(sessCtx, &existing); err != nil {
+			return nil, err
+		}
+		isSaved := make(map[string]bool, len(delegations))
+		for _, delegation := range existing {
+			isSaved[delegation.StakingTxHashHex] = true
+		}
+
+		documents := make([]interface{}, 0, len(delegations))
+		for index, delegation := range delegations {
+			if isSaved[delegation.StakingTxHashHex] {
+				result.Failed[index] = &db.DuplicateKeyError{
+					Key:     delegation.StakingTxHashHex,
+					Message: "Delegation already exists",
+				}
+				continue
+			}
+			isSaved[delegation.StakingTxHashHex] = true
+			document := *delegation
+			document.ChangeSeq = changeFields["change_seq"].(int64)
+			document.UpdatedAt = changeFields["updated_at"].(int64)
+			document.SchemaVersion = v1dbmodel.DelegationSchemaVersion
+			document.ExpiryHeight = document.StakingTx.ExpiryHeight()
+			document.Checksum = document.ComputeChecksum()
+			documents = append(documents, document)
+		}
+		if len(documents) > 0 {
+			if _, err := client.InsertMany(sessCtx, documents); err != nil {
+				return nil, err
+			}
+		}
+		result.Written = int64(len(documents))
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*db.BulkWriteResult), nil
+}
+
+// BulkTransitionToUnbondedState transitions the delegations to `unbonded` in
+// a single unordered bulk write. The delegations not found or not in an
+// eligible state are reported by their index with a NotFoundError.
+func (v1dbclient *V1Database) BulkTransitionToUnbondedState(
+	ctx context.Context, stakingTxHashHexes []string, eligiblePreviousState []types.DelegationState,
+) (*db.BulkWriteResult, error) {
+	transitions := make([]DelegationStateTransition, 0, len(stakingTxHashHexes))
+	for _, stakingTxHashHex := range stakingTxHashHexes {
+		transitions = append(transitions, DelegationStateTransition{StakingTxHashHex: stakingTxHashHex})
+	}
+	return v1dbclient.bulkTransitionState(ctx, types.Unbonded.ToString(), eligiblePreviousState, transitions)
+}
+
+// BulkTransitionToWithdrawnState transitions the delegations to `withdrawn`
+// in a single unordered bulk write, saving the withdrawal tx of the
+// delegations found in withdrawalTxs. The delegations not found or not in an
+// eligible state are reported by their index with a NotFoundError.
+func (v1dbclient *V1Database) BulkTransitionToWithdrawnState(
+	ctx context.Context, stakingTxHashHexes []string,
+	withdrawalTxs map[string]*v1dbmodel.WithdrawalTransaction,
+) (*db.BulkWriteResult, error) {
+	transitions := make([]DelegationStateTransition, 0, len(stakingTxHashHexes))
+	for _, stakingTxHashHex := range stakingTxHashHexes {
+		transition := DelegationStateTransition{StakingTxHashHex: stakingTxHashHex}
+		if withdrawalTx := withdrawalTxs[stakingTxHashHex]; withdrawalTx != nil {
+			transition.AdditionalUpdates = map[string]interface{}{"withdrawal_tx": withdrawalTx}
+		}
+		transitions = append(transitions, transition)
+	}
+	return v1dbclient.bulkTransitionState(
+		ctx, types.Withdrawn.ToString(), utils.QualifiedStatesToWithdraw(), transitions,
+	)
+}
+
+// bulkTransitionState is the bulk counterpart of transitionState. The
+// transitions share the same change sequence, which tells the delegations
+// transitioned apart from the ones not found or not in an eligible state.
+// The staking tx hashes of the transitions must be distinct.
+func (v1dbclient *V1Database) bulkTransitionState(
+	ctx context.Context, newState string,
+	eligiblePreviousState []types.DelegationState, transitions []DelegationStateTransition,
+) (*db.BulkWriteResult, error) {
+	result := db.NewBulkWriteResult()
+	if len(transitions) == 0 {
+		return result, nil
+	}
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	var changeSeq interface{}
+	bulkResult, err := v1dbclient.writeDelegationChange(ctx, func(sessCtx mongo.SessionContext, changeFields bson.M) (interface{}, error) {
+		changeSeq = changeFields["change_seq"]
+		models := make([]mongo.WriteModel, 0, len(transitions))
+		for _, transition := range transitions {
+			setFields := bson.M{"state": newState}
+			for field, value := range changeFields {
+				setFields[field] = value
+			}
+			for field, value := range transition.AdditionalUpdates {
+				setFields[field] = value
+			}
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": transition.StakingTxHashHex, "state": bson.M{"$in": eligiblePreviousState}}).
+				SetUpdate(bson.M{"$set": setFields}))
+		}
+		return client.BulkWrite(sessCtx, models, options.BulkWrite().SetOrdered(false))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if matched := bulkResult.(*mongo.BulkWriteResult).MatchedCount; matched == int64(len(transitions)) {
+		result.Written = matched
+		return result, nil
+	}
+
+	// Some of the delegations were not matched, they are the ones not holding
+	// the change sequence of the transitions
+	stakingTxHashHexes := make([]string, 0, len(transitions))
+	for _, transition := range transitions {
+		stakingTxHashHexes = append(stakingTxHashHexes, transition.StakingTxHashHex)
+	}
+	cursor, err := client.Find(ctx,
+		bson.M{"_id": bson.M{"$in": stakingTxHashHexes}, "change_seq": changeSeq},
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var transitioned []struct {
+		StakingTxHashHex string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &transitioned); err != nil {
+		return nil, err
+	}
+	isTransitioned := make(map[string]bool, len(transitioned))
+	for _, delegation := range transitioned {
+		isTransitioned[delegation.StakingTxHashHex] = true
+	}
+	for index, transition := range transitions {
+		if _, failed := result.Failed[index]; failed || isTransitioned[transition.StakingTxHashHex] {
+			continue
+		}
+		result.Failed[index] = &db.NotFoundError{
+			Key:     transition.StakingTxHashHex,
+			Message: "Delegation not found or not in eligible state to transition",
+		}
+	}
+	result.Written = int64(len(transitioned))
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package v1dbclient
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// nextDelegationChangeSeq increments and returns the delegation change
+// sequence. It must be called within the transaction writing the change, see
+// writeDelegationChange.
+func (v1dbclient *V1Database) nextDelegationChangeSeq(sessCtx mongo.SessionContext) (int64, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1CountersCollection)
+	var counter v1dbmodel.CounterDocument
+	err := client.FindOneAndUpdate(
+		sessCtx,
+		bson.M{"_id": v1dbmodel.DelegationChangeCounterId},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter.Seq, nil
+}
+
+// writeDelegationChange runs the work writing the delegations in a
+// transaction along with the allocation of its change sequence. The
+// concurrent transactions conflict on the counter, so a change is committed
+// before a higher sequence can be allocated and a reader of the changes never
+// skips over a change committed late.
+// The work is given the fields to set on every delegation it writes.
+func (v1dbclient *V1Database) writeDelegationChange(
+	ctx context.Context,
+	work func(sessCtx mongo.SessionContext, changeFields bson.M) (interface{}, error),
+) (interface{}, error) {
+	session, err := v1dbclient.Client.StartSession(v1dbclient.SessionOptions())
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		seq, err := v1dbclient.nextDelegationChangeSeq(sessCtx)
+		if err != nil {
+			return nil, err
+		}
+		changeFields := bson.M{"change_seq": seq, "updated_at": v1dbclient.Clock.Now().Unix()}
+		return work(sessCtx, changeFields)
+	}
+	return session.WithTransaction(ctx, transactionWork, v1dbclient.TransactionOptions())
+}
+
+// FindDelegationChanges returns the delegations written after the cursor in
+// the order they were written, along with the tombstones of the delegations
+// removed since. The returned cursor points to the last returned change, or
+// is the given cursor if there is no new change.
+// An empty cursor starts from the first delegation.
+func (v1dbclient *V1Database) FindDelegationChanges(
+	ctx context.Context, cursor string,
+) ([]v1dbmodel.DelegationDocument, string, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
+
+	filter := bson.M{}
+	if cursor != "" {
+		decodedCursor, err := dbmodel.DecodePaginationToken[v1dbmodel.DelegationChangeCursor](cursor)
+		if err != nil {
+			return nil, "", &db.InvalidPaginationTokenError{
+				Message: "Invalid change cursor",
+			}
+		}
+		var sameSeq any = decodedCursor.ChangeSeq
+		if decodedCursor.ChangeSeq == 0 {
+			sameSeq = bson.M{"$in": bson.A{nil, 0}}
+		}
+		filter = bson.M{"$or": []bson.M{
+			{"change_seq": bson.M{"$gt": decodedCursor.ChangeSeq}},
+			{"change_seq": sameSeq, "_id": bson.M{"$gt": decodedCursor.StakingTxHashHex}},
+		}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$unionWith", Value: bson.M{
+			"coll":     dbmodel.V1DelegationTombstonesCollection,
+			"pipeline": bson.A{bson.M{"$match": filter}},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "change_seq", Value: 1},
+			{Key: "_id", Value: 1},
+		}}},
+		{{Key: "$limit", Value: v1dbclient.Cfg.MaxPaginationLimit}},
+	}
+
+	findCursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, "", err
+	}
+	defer findCursor.Close(ctx)
+	var delegations []v1dbmodel.DelegationDocument
+	if err := findCursor.All(ctx, &delegations); err != nil {
+		return nil, "", err
+	}
+	if err := v1dbclient.decryptPartnerIds(delegations); err != nil {
+		return nil, "", err
+	}
+	if len(delegations) == 0 {
+		return delegations, cursor, nil
+	}
+	nextCursor, err := v1dbmodel.BuildDelegationChangeCursor(delegations[len(delegations)-1])
+	if err != nil {
+		return nil, "", err
+	}
+	return delegations, nextCursor, nil
+}
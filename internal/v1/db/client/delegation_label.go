@@ -0,0 +1,145 @@
+package v1dbclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SetDelegationLabels replaces the labels the api key has attached to the
+// delegation, the labels are removed if none is given
+func (v1dbclient *V1Database) SetDelegationLabels(
+	ctx context.Context, apiKey, stakingTxHashHex string, labels []string,
+) error {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationLabelsCollection)
+	id := v1dbmodel.BuildDelegationLabelsId(apiKey, stakingTxHashHex)
+	if len(labels) == 0 {
+		_, err := client.DeleteOne(ctx, bson.M{"_id": id})
+		return err
+	}
+	document := v1dbmodel.DelegationLabelsDocument{
+		Id:               id,
+		ApiKey:           apiKey,
+		StakingTxHashHex: stakingTxHashHex,
+		Labels:           labels,
+		UpdatedAt:        v1dbclient.Clock.Now().Unix(),
+	}
+	if err := v1dbclient.encryptLabels(&document); err != nil {
+		return err
+	}
+	_, err := client.ReplaceOne(ctx, bson.M{"_id": id}, document, options.Replace().SetUpsert(true))
+	return err
+}
+
+// FindDelegationLabels finds the labels the api key has attached to the
+// delegation. It returns a NotFoundError if the delegation has no label.
+func (v1dbclient *V1Database) FindDelegationLabels(
+	ctx context.Context, apiKey, stakingTxHashHex string,
+) (*v1dbmodel.DelegationLabelsDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationLabelsCollection)
+	id := v1dbmodel.BuildDelegationLabelsId(apiKey, stakingTxHashHex)
+	var document v1dbmodel.DelegationLabelsDocument
+	err := client.FindOne(ctx, bson.M{"_id": id}).Decode(&document)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     stakingTxHashHex,
+				Message: "Delegation labels not found",
+			}
+		}
+		return nil, err
+	}
+	if err := v1dbclient.decryptLabels(&document); err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// FindDelegationLabelsByLabel finds the delegations the api key has attached
+// the label to, ordered by staking tx hash
+func (v1dbclient *V1Database) FindDelegationLabelsByLabel(
+	ctx context.Context, apiKey, label, paginationToken string,
+) (*db.DbResultMap[v1dbmodel.DelegationLabelsDocument], error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationLabelsCollection)
+	filter := bson.M{"api_key": apiKey, "labels": label}
+	if v1dbclient.FieldCipher != nil {
+		// The labels written before the encryption was configured are still
+		// in plaintext
+		labelIndex := v1dbclient.FieldCipher.BlindIndex(v1dbmodel.BuildLabelIndexValue(apiKey, label))
+		filter = bson.M{"api_key": apiKey, "$or": bson.A{
+			bson.M{"labels": label},
+			bson.M{"label_indexes": labelIndex},
+		}}
+	}
+	if paginationToken != "" {
+		decodedToken, err := dbmodel.DecodePaginationToken[v1dbmodel.DelegationLabelsPagination](paginationToken)
+		if err != nil {
+			return nil, &db.InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter["_id"] = bson.M{"$gt": decodedToken.Id}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	result, err := db.FindWithPagination(
+		ctx, client, filter, opts, v1dbclient.Cfg.MaxPaginationLimit,
+		v1dbmodel.BuildDelegationLabelsPaginationToken,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Data {
+		if err := v1dbclient.decryptLabels(&result.Data[i]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// encryptLabels replaces the labels of the document by their encryption and
+// blind index, it's a no-op if the field encryption is not configured
+func (v1dbclient *V1Database) encryptLabels(document *v1dbmodel.DelegationLabelsDocument) error {
+	if v1dbclient.FieldCipher == nil {
+		return nil
+	}
+	for _, label := range document.Labels {
+		encrypted, err := v1dbclient.FieldCipher.Encrypt(label, document.Id)
+		if err != nil {
+			return err
+		}
+		document.EncryptedLabels = append(document.EncryptedLabels, encrypted)
+		document.LabelIndexes = append(document.LabelIndexes,
+			v1dbclient.FieldCipher.BlindIndex(v1dbmodel.BuildLabelIndexValue(document.ApiKey, label)))
+	}
+	document.Labels = nil
+	return nil
+}
+
+// decryptLabels restores the labels of the document from their encryption,
+// the labels stored in plaintext are left as is
+func (v1dbclient *V1Database) decryptLabels(document *v1dbmodel.DelegationLabelsDocument) error {
+	if len(document.EncryptedLabels) == 0 {
+		return nil
+	}
+	if v1dbclient.FieldCipher == nil {
+		return errors.New("delegation labels are encrypted while the field encryption is not configured")
+	}
+	labels := make([]string, 0, len(document.EncryptedLabels))
+	for _, encrypted := range document.EncryptedLabels {
+		label, err := v1dbclient.FieldCipher.Decrypt(encrypted, document.Id)
+		if err != nil {
+			return err
+		}
+		labels = append(labels, label)
+	}
+	document.Labels = labels
+	document.EncryptedLabels = nil
+	document.LabelIndexes = nil
+	return nil
+}
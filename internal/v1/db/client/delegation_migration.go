@@ -0,0 +1,56 @@
+package v1dbclient
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+)
+
+// MigrateDelegations writes back up to limit delegations stored at a previous
+// schema version at the current one. It returns the number of delegations
+// read, a delegation written since it was read is left for the next run.
+func (v1dbclient *V1Database) MigrateDelegations(ctx context.Context, limit int64) (int64, error) {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	// The documents stored before the version was introduced don't have it
+	filter := bson.M{"schema_version": bson.M{"$not": bson.M{"$gte": v1dbmodel.DelegationSchemaVersion}}}
+	cursor, err := client.Find(ctx, filter, options.Find().SetLimit(limit))
+	if err != nil {
+		return 0, err
+	}
+	var delegations []v1dbmodel.DelegationDocument
+	if err := cursor.All(ctx, &delegations); err != nil {
+		return 0, err
+	}
+
+	for _, delegation := range delegations {
+		// The change sequence is increased on every write of the delegation,
+		// it's missing from the delegations written before it was introduced
+		var sameChangeSeq any = delegation.ChangeSeq
+		if delegation.ChangeSeq == 0 {
+			sameChangeSeq = bson.M{"$in": bson.A{nil, 0}}
+		}
+		replaceFilter := bson.M{
+			"_id":            delegation.StakingTxHashHex,
+			"change_seq":     sameChangeSeq,
+			"schema_version": storedSchemaVersionFilter(delegation.StoredSchemaVersion()),
+		}
+		if _, err := client.ReplaceOne(ctx, replaceFilter, delegation); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(delegations)), nil
+}
+
+// storedSchemaVersionFilter matches the documents stored at the given schema
+// version, the documents stored before the version was introduced don't
+// have it
+func storedSchemaVersionFilter(version int) any {
+	if version == 0 {
+		return bson.M{"$in": bson.A{nil, 0}}
+	}
+	return version
+}
@@ -0,0 +1,67 @@
+package v1dbclient
+
+import (
+	"context"
+	"slices"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// delegationChangeEvent is the change stream event of a delegation write
+type delegationChangeEvent struct {
+	FullDocument *v1dbmodel.DelegationDocument `bson:"fullDocument"`
+}
+
+// WaitForDelegationState waits until the delegation is in one of the states,
+// driven by the change stream of the delegations collection. The delegation
+// doesn't need to exist yet. It returns the error of the context once done
+// if the delegation hasn't reached any of the states by then.
+func (v1dbclient *V1Database) WaitForDelegationState(
+	ctx context.Context, stakingTxHashHex string, states []types.DelegationState,
+) (*v1dbmodel.DelegationDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{
+		"documentKey._id": stakingTxHashHex,
+		"operationType":   bson.M{"$in": bson.A{"insert", "update", "replace"}},
+	}}}}
+	stream, err := client.Watch(ctx, pipeline, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close(context.WithoutCancel(ctx))
+
+	// The delegation is read once the stream is open, so that a change made
+	// in between is not missed
+	delegation, err := v1dbclient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
+	if err != nil && !db.IsNotFoundError(err) {
+		return nil, err
+	}
+	if err == nil && slices.Contains(states, delegation.State) {
+		return delegation, nil
+	}
+
+	for stream.Next(ctx) {
+		var event delegationChangeEvent
+		if err := stream.Decode(&event); err != nil {
+			return nil, err
+		}
+		// The full document is missing if the delegation has been removed
+		// since the change, e.g. by a reorg rollback
+		if event.FullDocument != nil && slices.Contains(states, event.FullDocument.State) {
+			if err := v1dbclient.decryptPartnerId(event.FullDocument); err != nil {
+				return nil, err
+			}
+			return event.FullDocument, nil
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	return nil, ctx.Err()
+}
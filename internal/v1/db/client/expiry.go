@@ -0,0 +1,44 @@
+package v1dbclient
+
+import (
+	"context"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindExpiredDelegations finds up to limit delegations eligible to be
+// unbonded whose staking or unbonding timelock, depending on their state,
+// expired at or before the given height. The expiry height is stored on
+// write, the delegations stored before it was introduced are only found once
+// migrated.
+func (v1dbclient *V1Database) FindExpiredDelegations(
+	ctx context.Context, height uint64, limit int64,
+) ([]v1dbmodel.DelegationDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
+	// The expiry height is the one of the unbonding tx in the unbonding
+	// state, of the staking tx in the other states
+	states := append(
+		utils.QualifiedStatesToUnbonded(types.ActiveTxType),
+		utils.QualifiedStatesToUnbonded(types.UnbondingTxType)...,
+	)
+	filter := bson.M{
+		"state":         bson.M{"$in": states},
+		"expiry_height": bson.M{"$lte": height},
+	}
+	cursor, err := client.Find(ctx, filter, options.Find().SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var delegations []v1dbmodel.DelegationDocument
+	if err := cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
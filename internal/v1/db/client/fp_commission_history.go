@@ -0,0 +1,55 @@
+package v1dbclient
+
+import (
+	"context"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveFpCommissionChange records the commission change of the finality
+// provider at the given sequence. Recording an already existing sequence is a
+// no-op, so that the instances syncing concurrently record each change once.
+func (v1dbclient *V1Database) SaveFpCommissionChange(
+	ctx context.Context, fpPkHex string, sequence int64, commission, previousCommission string,
+) error {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1FpCommissionHistoryCollection)
+	id := v1dbmodel.BuildFpCommissionChangeId(fpPkHex, sequence)
+	document := v1dbmodel.FpCommissionChangeDocument{
+		Id:                    id,
+		FinalityProviderPkHex: fpPkHex,
+		Sequence:              sequence,
+		Commission:            commission,
+		PreviousCommission:    previousCommission,
+		RecordedAt:            v1dbclient.Clock.Now().Unix(),
+	}
+	_, err := client.UpdateOne(
+		ctx, bson.M{"_id": id}, bson.M{"$setOnInsert": document},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// FindFpCommissionHistory returns the commission changes of the finality
+// provider, the latest first.
+func (v1dbclient *V1Database) FindFpCommissionHistory(
+	ctx context.Context, fpPkHex string,
+) ([]v1dbmodel.FpCommissionChangeDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1FpCommissionHistoryCollection)
+	cursor, err := client.Find(
+		ctx, bson.M{"finality_provider_pk_hex": fpPkHex},
+		options.Find().SetSort(bson.D{{Key: "sequence", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var changes []v1dbmodel.FpCommissionChangeDocument
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -2,6 +2,7 @@ package v1dbclient
 
 import (
 	"context"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
@@ -14,8 +15,14 @@ type V1DBClient interface {
 	SaveActiveStakingDelegation(
 		ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string,
 		stakingTxHex string, amount, startHeight, timelock, outputIndex uint64,
-		startTimestamp int64, isOverflow bool,
+		startTimestamp int64, isOverflow bool, state types.DelegationState,
 	) error
+	// BulkSaveActiveStakingDelegations inserts the delegations in a single
+	// unordered bulk write. The delegations failing to be inserted are
+	// reported by their index in the result.
+	BulkSaveActiveStakingDelegations(
+		ctx context.Context, delegations []*v1dbmodel.DelegationDocument,
+	) (*db.BulkWriteResult, error)
 	// FindDelegationsByStakerPk finds all delegations by the staker's public key.
 	// The extraFilter parameter can be used to filter the results by the delegation's
 	// properties. The paginationToken parameter is used to fetch the next page of results.
@@ -26,18 +33,106 @@ type V1DBClient interface {
 		ctx context.Context, stakerPk string,
 		extraFilter *DelegationFilter, paginationToken string,
 	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// StreamDelegationsByStakerPk calls fn with each delegation of the staker as
+	// they are read from the cursor, in the same order and with the same filters
+	// as FindDelegationsByStakerPk, but without limiting the number of results.
+	// It stops at the first error returned by fn.
+	StreamDelegationsByStakerPk(
+		ctx context.Context, stakerPk string,
+		extraFilter *DelegationFilter, paginationToken string,
+		fn func(delegation *v1dbmodel.DelegationDocument) error,
+	) error
+	// CountDelegationsByStakerPk counts the delegations of the staker with
+	// the same filters as FindDelegationsByStakerPk, over all the pages
+	CountDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *DelegationFilter) (int64, error)
+	// StreamDelegationsByFinalityProviderPk calls fn with each delegation of
+	// the finality provider, the archived ones included, as they are read
+	// from the cursor, in no particular order. It stops at the first error returned by fn.
+	StreamDelegationsByFinalityProviderPk(
+		ctx context.Context, fpPkHex string,
+		fn func(delegation *v1dbmodel.DelegationDocument) error,
+	) error
+	// FindRecentDelegationsByFinalityProviderPk finds the latest delegations
+	// of the finality provider by staking start height, up to the limit
+	FindRecentDelegationsByFinalityProviderPk(
+		ctx context.Context, fpPkHex string, limit int64,
+	) ([]v1dbmodel.DelegationDocument, error)
 	SaveUnbondingTx(
-		ctx context.Context, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex string,
+		ctx context.Context, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex, psbtBase64 string,
 	) error
+	// FindUnbondingSignature finds the record of a staker signature submitted
+	// for an unbonding request.
+	FindUnbondingSignature(
+		ctx context.Context, signatureHex string,
+	) (*v1dbmodel.UnbondingSignatureDocument, error)
+	// FindUnbondingByTxHashHex finds the unbonding request of the unbonding
+	// tx, along with the state the unbonding pipeline acknowledged it with
+	FindUnbondingByTxHashHex(
+		ctx context.Context, unbondingTxHashHex string,
+	) (*v1dbmodel.UnbondingDocument, error)
 	FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error)
+	// WaitForDelegationState waits until the delegation is in one of the
+	// states, it returns the error of the context if the context is done
+	// before.
+	WaitForDelegationState(
+		ctx context.Context, stakingTxHashHex string, states []types.DelegationState,
+	) (*v1dbmodel.DelegationDocument, error)
+	// DelegationExists checks whether the delegation exists without reading
+	// the delegation document
+	DelegationExists(ctx context.Context, stakingTxHashHex string) (bool, error)
+	// ArchiveDelegations moves up to limit delegations in the given states,
+	// not updated since updatedBefore, into the archive collection. It returns
+	// the number of delegations archived.
+	ArchiveDelegations(
+		ctx context.Context, states []types.DelegationState, updatedBefore, limit int64,
+	) (int64, error)
+	// MigrateDelegations writes back up to limit delegations stored at a
+	// previous schema version at the current one. It returns the number of
+	// delegations read.
+	MigrateDelegations(ctx context.Context, limit int64) (int64, error)
+	// FindArchivedDelegationByTxHashHex finds the delegation in the archive
+	// collection
+	FindArchivedDelegationByTxHashHex(ctx context.Context, stakingTxHashHex string) (*v1dbmodel.DelegationDocument, error)
+	// ArchivedDelegationExists checks whether the delegation is archived
+	// without reading the archived delegation
+	ArchivedDelegationExists(ctx context.Context, stakingTxHashHex string) (bool, error)
+	// FindDelegationByAnyTxHashHex finds the delegation whose staking, unbonding
+	// or withdrawal tx hash matches the given tx hash.
+	FindDelegationByAnyTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error)
+	// FindExpiredDelegations finds up to limit delegations eligible to be
+	// unbonded whose timelock expired at or before the given height
+	FindExpiredDelegations(ctx context.Context, height uint64, limit int64) ([]v1dbmodel.DelegationDocument, error)
 	SaveTimeLockExpireCheck(ctx context.Context, stakingTxHashHex string, expireHeight uint64, txType string) error
 	TransitionToUnbondedState(
 		ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState,
 	) error
 	TransitionToUnbondingState(
-		ctx context.Context, txHashHex string, startHeight, timelock, outputIndex uint64, txHex string, startTimestamp int64,
+		ctx context.Context, txHashHex string, startHeight, timelock, outputIndex uint64,
+		txHex, unbondingTxHashHex string, startTimestamp int64,
 	) error
-	TransitionToWithdrawnState(ctx context.Context, txHashHex string) error
+	TransitionToWithdrawnState(
+		ctx context.Context, txHashHex string, withdrawalTx *v1dbmodel.WithdrawalTransaction,
+	) error
+	// BulkTransitionToUnbondedState transitions the delegations to unbonded
+	// in a single unordered bulk write. The delegations failing to be
+	// transitioned are reported by their index in the result.
+	BulkTransitionToUnbondedState(
+		ctx context.Context, stakingTxHashHexes []string, eligiblePreviousState []types.DelegationState,
+	) (*db.BulkWriteResult, error)
+	// BulkTransitionToWithdrawnState transitions the delegations to withdrawn
+	// in a single unordered bulk write, along with their withdrawal tx if
+	// set. The delegations failing to be transitioned are reported by their
+	// index in the result.
+	BulkTransitionToWithdrawnState(
+		ctx context.Context, stakingTxHashHexes []string,
+		withdrawalTxs map[string]*v1dbmodel.WithdrawalTransaction,
+	) (*db.BulkWriteResult, error)
+	// TransitionPendingToActiveState transitions the pending delegations with
+	// staking start height within the given range to active, and returns their
+	// staking tx hashes.
+	TransitionPendingToActiveState(
+		ctx context.Context, fromStartHeight, toStartHeight uint64,
+	) ([]string, error)
 	GetOrCreateStatsLock(
 		ctx context.Context, stakingTxHashHex string, state string,
 	) (*v1dbmodel.StatsLockDocument, error)
@@ -47,7 +142,18 @@ type V1DBClient interface {
 	IncrementOverallStats(
 		ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
 	) error
+	// IncrementOverflowStats increments the overflow tvl and delegations of
+	// the overall stats, the overflow delegations being excluded from the
+	// other stats.
+	IncrementOverflowStats(ctx context.Context, stakingTxHashHex string, amount uint64) error
 	GetOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error)
+	// RefreshMaterializedOverallStats consolidates the overall stats shards
+	// into the materialized overall stats document.
+	RefreshMaterializedOverallStats(ctx context.Context) error
+	// FindHourlyOverallStats finds the latest hourly overall stats taken at
+	// or before the given time
+	FindHourlyOverallStats(ctx context.Context, at time.Time) (*v1dbmodel.HourlyOverallStatsDocument, error)
+	GetMaterializedOverallStats(ctx context.Context) (*v1dbmodel.MaterializedOverallStatsDocument, error)
 	IncrementFinalityProviderStats(
 		ctx context.Context, stakingTxHashHex, fpPkHex string, amount uint64,
 	) error
@@ -58,6 +164,11 @@ type V1DBClient interface {
 	FindFinalityProviderStatsByFinalityProviderPkHex(
 		ctx context.Context, finalityProviderPkHex []string,
 	) ([]*v1dbmodel.FinalityProviderStatsDocument, error)
+	// FindFinalityProviderPkHexesWithStats returns the subset of the given
+	// finality provider pk hexes having stats.
+	FindFinalityProviderPkHexesWithStats(
+		ctx context.Context, finalityProviderPkHex []string,
+	) ([]string, error)
 	IncrementStakerStats(
 		ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
 	) error
@@ -65,12 +176,41 @@ type V1DBClient interface {
 		ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
 	) error
 	FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error)
+	// CountStakerStats counts the stakers, estimated from the collection
+	// metadata if requested
+	CountStakerStats(ctx context.Context, estimated bool) (int64, error)
+	// RecomputeOverallStats computes the overall stats from the delegation collection.
+	RecomputeOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error)
+	// RecomputeFinalityProviderStats computes the per finality provider stats
+	// from the delegation collection.
+	RecomputeFinalityProviderStats(
+		ctx context.Context,
+	) ([]*v1dbmodel.FinalityProviderStatsDocument, error)
+	FindAllFinalityProviderStats(
+		ctx context.Context,
+	) ([]*v1dbmodel.FinalityProviderStatsDocument, error)
+	// ReplaceOverallStats atomically rewrites all the overall stats shards.
+	ReplaceOverallStats(ctx context.Context, stats *v1dbmodel.OverallStatsDocument) error
+	// ReplaceFinalityProviderStats atomically rewrites the finality provider stats.
+	ReplaceFinalityProviderStats(
+		ctx context.Context, stats []*v1dbmodel.FinalityProviderStatsDocument,
+	) error
+	// FindStakerStateSummaries summarizes the delegations of the staker by
+	// state, computed from the delegations
+	FindStakerStateSummaries(
+		ctx context.Context, stakerPkHex string,
+	) ([]v1dbmodel.StakerStateSummaryDocument, error)
 	// GetStakerStats fetches the staker stats by the staker's public key.
 	GetStakerStats(
 		ctx context.Context, stakerPkHex string,
 	) (*v1dbmodel.StakerStatsDocument, error)
+	// FindStakerStatsByStakerPkHexes fetches the stats of the given stakers,
+	// the stakers without stats are left out
+	FindStakerStatsByStakerPkHexes(
+		ctx context.Context, stakerPkHexes []string,
+	) ([]*v1dbmodel.StakerStatsDocument, error)
 	UpsertLatestBtcInfo(
-		ctx context.Context, height uint64, confirmedTvl uint64, unconfirmedTvl uint64,
+		ctx context.Context, height, babylonHeight uint64, confirmedTvl uint64, unconfirmedTvl uint64,
 	) error
 	GetLatestBtcInfo(ctx context.Context) (*v1dbmodel.BtcInfo, error)
 	CheckDelegationExistByStakerPk(
@@ -83,9 +223,75 @@ type V1DBClient interface {
 		ctx context.Context,
 		paginationToken string,
 	) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)
+	// RollbackReorgedDelegation removes a pending or active delegation whose staking tx
+	// has been reorged out and reverses its stats in a single transaction. It
+	// returns the state the delegation was rolled back from.
+	RollbackReorgedDelegation(ctx context.Context, stakingTxHashHex string) (types.DelegationState, error)
+	SaveBtcReorg(
+		ctx context.Context, blockHash string, blockHeight uint64, stakingTxHashHexes []string,
+	) error
+	FindBtcReorgByBlockHash(ctx context.Context, blockHash string) (*v1dbmodel.BtcReorgDocument, error)
+	// SaveDelegationMilestone records the milestone reached by the delegation
+	// in the audit trail, recording the same milestone again is a no-op.
+	SaveDelegationMilestone(
+		ctx context.Context, stakingTxHashHex string, milestone v1dbmodel.DelegationMilestone,
+		txHashHex string, blockHeight uint64, timestamp int64,
+	) error
+	FindDelegationMilestones(
+		ctx context.Context, stakingTxHashHex string,
+	) ([]v1dbmodel.DelegationAuditTrailDocument, error)
+	// SaveFpCommissionChange records the commission change of the finality
+	// provider at the given sequence, recording the same sequence again is a
+	// no-op.
+	SaveFpCommissionChange(
+		ctx context.Context, fpPkHex string, sequence int64, commission, previousCommission string,
+	) error
+	// FindFpCommissionHistory returns the commission changes of the finality
+	// provider, the latest first.
+	FindFpCommissionHistory(ctx context.Context, fpPkHex string) ([]v1dbmodel.FpCommissionChangeDocument, error)
+	// SetDelegationPartner attributes the delegation to the partner, a
+	// delegation can only be attributed to a single partner.
+	SetDelegationPartner(ctx context.Context, stakingTxHashHex, partnerId string) error
+	// FindPartnerStats computes the stats of the delegations attributed to the
+	// partner.
+	FindPartnerStats(ctx context.Context, partnerId string) (*v1dbmodel.PartnerStatsDocument, error)
+	// RemoveStakerPartnerAttributions removes the partner attribution of all
+	// the delegations of the staker and returns the number of delegations
+	// updated
+	RemoveStakerPartnerAttributions(ctx context.Context, stakerPkHex string) (int64, error)
+	// CountStakerPartnerAttributions counts the delegations of the staker
+	// whose attribution RemoveStakerPartnerAttributions would remove
+	CountStakerPartnerAttributions(ctx context.Context, stakerPkHex string) (int64, error)
+	// SetDelegationLabels replaces the labels the api key has attached to the
+	// delegation, the labels are removed if none is given
+	SetDelegationLabels(ctx context.Context, apiKey, stakingTxHashHex string, labels []string) error
+	// FindDelegationLabels finds the labels the api key has attached to the
+	// delegation
+	FindDelegationLabels(
+		ctx context.Context, apiKey, stakingTxHashHex string,
+	) (*v1dbmodel.DelegationLabelsDocument, error)
+	// FindDelegationLabelsByLabel finds the delegations the api key has
+	// attached the label to, paginated by staking tx hash
+	FindDelegationLabelsByLabel(
+		ctx context.Context, apiKey, label, paginationToken string,
+	) (*db.DbResultMap[v1dbmodel.DelegationLabelsDocument], error)
+	// FindDelegationChanges returns the delegations written after the given
+	// change cursor, along with the cursor to fetch the next changes.
+	FindDelegationChanges(
+		ctx context.Context, cursor string,
+	) ([]v1dbmodel.DelegationDocument, string, error)
 }
 
 type DelegationFilter struct {
 	AfterTimestamp int64
-	States         []types.DelegationState
+	// BeforeTimestamp is the inclusive upper bound of the staking start timestamp
+	BeforeTimestamp int64
+	States          []types.DelegationState
+	MinStakingValue uint64
+	MaxStakingValue uint64
+	// Fields are the document fields to fetch, all the fields are fetched if
+	// empty. The fields the pagination relies on are always fetched.
+	Fields []string
+	// IncludeArchived also reads the delegations of the archive collection
+	IncludeArchived bool
 }
@@ -0,0 +1,100 @@
+package v1dbclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// materializedOverallStatsId is the id of the single document of the
+// materialized overall stats collection
+const materializedOverallStatsId = "overall_stats"
+
+// RefreshMaterializedOverallStats consolidates the overall stats shards into
+// the materialized overall stats document
+func (v1dbclient *V1Database) RefreshMaterializedOverallStats(ctx context.Context) error {
+	stats, err := v1dbclient.GetOverallStats(ctx)
+	if err != nil {
+		return err
+	}
+	document := v1dbmodel.MaterializedOverallStatsDocument{
+		LastRefreshedAt: v1dbclient.Clock.Now().Unix(),
+	}
+	document.OverallStatsDocument = *stats
+	document.Id = materializedOverallStatsId
+
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1MaterializedOverallStatsCollection)
+	_, err = client.ReplaceOne(
+		ctx, bson.M{"_id": materializedOverallStatsId}, document, options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return err
+	}
+
+	// The first refresh of the hour snapshots the stats of the hour
+	hour := v1dbclient.Clock.Now().UTC().Truncate(time.Hour)
+	snapshot := v1dbmodel.HourlyOverallStatsDocument{
+		HourStart:         hour.Unix(),
+		Hour:              hour,
+		ActiveTvl:         stats.ActiveTvl,
+		TotalTvl:          stats.TotalTvl,
+		ActiveDelegations: stats.ActiveDelegations,
+		TotalDelegations:  stats.TotalDelegations,
+		TotalStakers:      stats.TotalStakers,
+	}
+	_, err = v1dbclient.Db(ctx).Collection(dbmodel.V1HourlyOverallStatsCollection).UpdateOne(
+		ctx, bson.M{"_id": snapshot.HourStart}, bson.M{"$setOnInsert": snapshot},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// FindHourlyOverallStats finds the latest hourly overall stats taken at or
+// before the given time. It returns a NotFoundError if there is none.
+func (v1dbclient *V1Database) FindHourlyOverallStats(
+	ctx context.Context, at time.Time,
+) (*v1dbmodel.HourlyOverallStatsDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1HourlyOverallStatsCollection)
+	var document v1dbmodel.HourlyOverallStatsDocument
+	err := client.FindOne(
+		ctx, bson.M{"_id": bson.M{"$lte": at.Unix()}},
+		options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}}),
+	).Decode(&document)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     at.String(),
+				Message: "Hourly overall stats not found",
+			}
+		}
+		return nil, err
+	}
+	return &document, nil
+}
+
+// GetMaterializedOverallStats fetches the materialized overall stats document.
+// It returns a NotFoundError if the stats have never been refreshed.
+func (v1dbclient *V1Database) GetMaterializedOverallStats(
+	ctx context.Context,
+) (*v1dbmodel.MaterializedOverallStatsDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1MaterializedOverallStatsCollection)
+	var document v1dbmodel.MaterializedOverallStatsDocument
+	err := client.FindOne(ctx, bson.M{"_id": materializedOverallStatsId}).Decode(&document)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     materializedOverallStatsId,
+				Message: "Materialized overall stats not found",
+			}
+		}
+		return nil, err
+	}
+	return &document, nil
+}
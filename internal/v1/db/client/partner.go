@@ -0,0 +1,197 @@
+package v1dbclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SetDelegationPartner attributes the delegation to the partner. A delegation
+// is attributed once, attributing it again to the same partner is a no-op.
+// Return not found error if the delegation does not exist, and duplicate key
+// error if it's attributed to another partner
+func (v1dbclient *V1Database) SetDelegationPartner(
+	ctx context.Context, stakingTxHashHex, partnerId string,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
+	partnerFields, err := v1dbclient.partnerIdFields(stakingTxHashHex, partnerId)
+	if err != nil {
+		return err
+	}
+	result, err := v1dbclient.writeDelegationChange(ctx, func(sessCtx mongo.SessionContext, changeFields bson.M) (interface{}, error) {
+		for field, value := range partnerFields {
+			changeFields[field] = value
+		}
+		return client.UpdateOne(
+			sessCtx,
+			bson.M{"$and": bson.A{bson.M{"_id": stakingTxHashHex}, notAttributedFilter()}},
+			bson.M{"$set": changeFields},
+		)
+	})
+	if err != nil {
+		return err
+	}
+	if result.(*mongo.UpdateResult).MatchedCount > 0 {
+		return nil
+	}
+
+	delegation, err := v1dbclient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
+	if err != nil {
+		return err
+	}
+	if delegation.PartnerId != partnerId {
+		return &db.DuplicateKeyError{
+			Key:     stakingTxHashHex,
+			Message: "delegation already attributed to another partner",
+		}
+	}
+	return nil
+}
+
+// RemoveStakerPartnerAttributions removes the partner attribution of all the
+// delegations of the staker, the archived ones included. It returns the
+// number of delegations whose attribution has been removed.
+func (v1dbclient *V1Database) RemoveStakerPartnerAttributions(
+	ctx context.Context, stakerPkHex string,
+) (int64, error) {
+	database := v1dbclient.Client.Database(v1dbclient.DbName)
+	filter := bson.M{"$and": bson.A{bson.M{"staker_pk_hex": stakerPkHex}, attributedFilter()}}
+	removed, err := v1dbclient.writeDelegationChange(ctx, func(sessCtx mongo.SessionContext, changeFields bson.M) (interface{}, error) {
+		result, err := database.Collection(dbmodel.V1DelegationCollection).UpdateMany(
+			sessCtx, filter, bson.M{"$set": changeFields, "$unset": partnerIdUnset()},
+		)
+		if err != nil {
+			return nil, err
+		}
+		// The archived delegations are not part of the delegation changes
+		archived, err := database.Collection(dbmodel.V1DelegationArchiveCollection).UpdateMany(
+			sessCtx, filter, bson.M{"$unset": partnerIdUnset()},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return result.ModifiedCount + archived.ModifiedCount, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed.(int64), nil
+}
+
+// CountStakerPartnerAttributions counts the delegations of the staker, the
+// archived ones included, which are attributed to a partner
+func (v1dbclient *V1Database) CountStakerPartnerAttributions(
+	ctx context.Context, stakerPkHex string,
+) (int64, error) {
+	filter := bson.M{"$and": bson.A{bson.M{"staker_pk_hex": stakerPkHex}, attributedFilter()}}
+	return v1dbclient.countDelegations(ctx, filter, true)
+}
+
+// FindPartnerStats computes the stats of the delegations attributed to the
+// partner, the archived ones included, the same way the overall stats are
+// computed
+func (v1dbclient *V1Database) FindPartnerStats(
+	ctx context.Context, partnerId string,
+) (*v1dbmodel.PartnerStatsDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
+	filter := bson.M{"$and": bson.A{notOverflowFilter(), v1dbclient.partnerIdFilter(partnerId)}}
+	pipeline := append(archivedDelegationsPipeline(filter),
+		bson.D{{Key: "$group", Value: statsFromDelegationsGroup(nil)}},
+	)
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []v1dbmodel.PartnerStatsDocument
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	// No delegation attributed to the partner yet
+	if len(results) == 0 {
+		return &v1dbmodel.PartnerStatsDocument{}, nil
+	}
+	return &results[0], nil
+}
+
+// partnerIdFields returns the fields attributing the delegation to the
+// partner, the partner id is encrypted if the field encryption is configured
+func (v1dbclient *V1Database) partnerIdFields(stakingTxHashHex, partnerId string) (bson.M, error) {
+	if v1dbclient.FieldCipher == nil {
+		return bson.M{"partner_id": partnerId}, nil
+	}
+	encrypted, err := v1dbclient.FieldCipher.Encrypt(partnerId, stakingTxHashHex)
+	if err != nil {
+		return nil, err
+	}
+	return bson.M{
+		"encrypted_partner_id": encrypted,
+		"partner_id_index":     v1dbclient.FieldCipher.BlindIndex(partnerId),
+	}, nil
+}
+
+// partnerIdFilter matches the delegations attributed to the partner. The
+// delegations attributed before the encryption was configured still hold
+// the partner id in plaintext.
+func (v1dbclient *V1Database) partnerIdFilter(partnerId string) bson.M {
+	if v1dbclient.FieldCipher == nil {
+		return bson.M{"partner_id": partnerId}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{"partner_id": partnerId},
+		bson.M{"partner_id_index": v1dbclient.FieldCipher.BlindIndex(partnerId)},
+	}}
+}
+
+// attributedFilter matches the delegations attributed to any partner
+func attributedFilter() bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"partner_id": bson.M{"$exists": true}},
+		bson.M{"partner_id_index": bson.M{"$exists": true}},
+	}}
+}
+
+func notAttributedFilter() bson.M {
+	return bson.M{
+		"partner_id":       bson.M{"$exists": false},
+		"partner_id_index": bson.M{"$exists": false},
+	}
+}
+
+func partnerIdUnset() bson.M {
+	return bson.M{"partner_id": "", "encrypted_partner_id": "", "partner_id_index": ""}
+}
+
+// decryptPartnerId restores the partner id of the delegation from its
+// encryption, the partner ids stored in plaintext are left as is
+func (v1dbclient *V1Database) decryptPartnerId(delegation *v1dbmodel.DelegationDocument) error {
+	if delegation.EncryptedPartnerId == "" {
+		return nil
+	}
+	if v1dbclient.FieldCipher == nil {
+		return errors.New("delegation partner is encrypted while the field encryption is not configured")
+	}
+	partnerId, err := v1dbclient.FieldCipher.Decrypt(delegation.EncryptedPartnerId, delegation.StakingTxHashHex)
+	if err != nil {
+		return err
+	}
+	delegation.PartnerId = partnerId
+	delegation.EncryptedPartnerId = ""
+	delegation.PartnerIdIndex = ""
+	return nil
+}
+
+func (v1dbclient *V1Database) decryptPartnerIds(delegations []v1dbmodel.DelegationDocument) error {
+	for i := range delegations {
+		if err := v1dbclient.decryptPartnerId(&delegations[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
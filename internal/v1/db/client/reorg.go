@@ -0,0 +1,188 @@
+package v1dbclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RollbackReorgedDelegation removes a pending or active delegation whose staking
+// tx has been reorged out of the BTC chain. Within a single transaction it reverses
+// the stats increments already recorded in the stats lock, resets the active
+// stats lock so the delegation can be processed again if the tx is re-included,
+// and removes the pending timelock expire checks along with the audit trail.
+// The removal is part of the delegation changes as a tombstone.
+// It returns the state the delegation was rolled back from, or a NotFoundError
+// if the delegation does not exist or is neither pending nor active.
+func (v1dbclient *V1Database) RollbackReorgedDelegation(
+	ctx context.Context, stakingTxHashHex string,
+) (types.DelegationState, error) {
+	database := v1dbclient.Client.Database(v1dbclient.DbName)
+	delegationClient := database.Collection(dbmodel.V1DelegationCollection)
+	statsLockClient := database.Collection(dbmodel.V1StatsLockCollection)
+	overallStatsClient := database.Collection(dbmodel.V1OverallStatsCollection)
+	fpStatsClient := database.Collection(dbmodel.V1FinalityProviderStatsCollection)
+	stakerStatsClient := database.Collection(dbmodel.V1StakerStatsCollection)
+	timeLockClient := database.Collection(dbmodel.V1TimeLockCollection)
+	auditTrailClient := database.Collection(dbmodel.V1DelegationAuditTrailCollection)
+	tombstoneClient := database.Collection(dbmodel.V1DelegationTombstonesCollection)
+
+	transactionWork := func(sessCtx mongo.SessionContext, changeFields bson.M) (interface{}, error) {
+		var delegation v1dbmodel.DelegationDocument
+		err := delegationClient.FindOne(
+			sessCtx, bson.M{
+				"_id":   stakingTxHashHex,
+				"state": bson.M{"$in": []types.DelegationState{types.Pending, types.Active}},
+			},
+		).Decode(&delegation)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, &db.NotFoundError{
+					Key:     stakingTxHashHex,
+					Message: "Delegation not found or not in pending/active state",
+				}
+			}
+			return nil, err
+		}
+
+		statsLockId := constructStatsLockId(stakingTxHashHex, types.Active.ToString())
+		var statsLock v1dbmodel.StatsLockDocument
+		err = statsLockClient.FindOne(sessCtx, bson.M{"_id": statsLockId}).Decode(&statsLock)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		amount := int64(delegation.StakingValue)
+		reverseUpdate := bson.M{
+			"$inc": bson.M{
+				"active_tvl":         -amount,
+				"total_tvl":          -amount,
+				"active_delegations": -1,
+				"total_delegations":  -1,
+			},
+		}
+		if statsLock.FinalityProviderStats {
+			_, err = fpStatsClient.UpdateOne(
+				sessCtx, bson.M{"_id": delegation.FinalityProviderPkHex}, reverseUpdate,
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if statsLock.StakerStats {
+			_, err = stakerStatsClient.UpdateOne(
+				sessCtx, bson.M{"_id": delegation.StakerPkHex}, reverseUpdate,
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// The overall stats is always updated after the staker stats, so the
+		// staker stats is already reversed at this point and can be used to
+		// determine if the staker has no delegation left.
+		if statsLock.OverallStats {
+			overallUpdate := bson.M{"$inc": bson.M{
+				"active_tvl":         -amount,
+				"total_tvl":          -amount,
+				"active_delegations": -1,
+				"total_delegations":  -1,
+			}}
+			var stakerStats v1dbmodel.StakerStatsDocument
+			stakerErr := stakerStatsClient.FindOne(
+				sessCtx, bson.M{"_id": delegation.StakerPkHex},
+			).Decode(&stakerStats)
+			if stakerErr != nil && !errors.Is(stakerErr, mongo.ErrNoDocuments) {
+				return nil, stakerErr
+			}
+			if stakerStats.TotalDelegations == 0 {
+				overallUpdate["$inc"].(bson.M)["total_stakers"] = -1
+			}
+			shardId := v1dbclient.generateOverallStatsId(stakingTxHashHex)
+			_, err = overallStatsClient.UpdateOne(
+				sessCtx, bson.M{"_id": shardId}, overallUpdate, options.Update().SetUpsert(true),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err = statsLockClient.DeleteOne(sessCtx, bson.M{"_id": statsLockId}); err != nil {
+			return nil, err
+		}
+		if _, err = timeLockClient.DeleteMany(
+			sessCtx, bson.M{"staking_tx_hash_hex": stakingTxHashHex},
+		); err != nil {
+			return nil, err
+		}
+		if _, err = auditTrailClient.DeleteMany(
+			sessCtx, bson.M{"staking_tx_hash_hex": stakingTxHashHex},
+		); err != nil {
+			return nil, err
+		}
+		if _, err = delegationClient.DeleteOne(sessCtx, bson.M{"_id": stakingTxHashHex}); err != nil {
+			return nil, err
+		}
+		// The removal is recorded as a change of the delegation
+		delegation.ChangeSeq = changeFields["change_seq"].(int64)
+		delegation.UpdatedAt = changeFields["updated_at"].(int64)
+		delegation.Removed = true
+		if _, err = tombstoneClient.ReplaceOne(
+			sessCtx, bson.M{"_id": stakingTxHashHex}, delegation, options.Replace().SetUpsert(true),
+		); err != nil {
+			return nil, err
+		}
+		return delegation.State, nil
+	}
+
+	// Execute the transaction along with the change of the delegation
+	result, err := v1dbclient.writeDelegationChange(ctx, transactionWork)
+	if err != nil {
+		return "", err
+	}
+	return result.(types.DelegationState), nil
+}
+
+// SaveBtcReorg records the reorged block hash along with the staking txs that
+// were rolled back. Saving the same block hash again overwrites the record.
+func (v1dbclient *V1Database) SaveBtcReorg(
+	ctx context.Context, blockHash string, blockHeight uint64, stakingTxHashHexes []string,
+) error {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1BtcReorgCollection)
+	document := v1dbmodel.BtcReorgDocument{
+		BlockHash:          blockHash,
+		BlockHeight:        blockHeight,
+		StakingTxHashHexes: stakingTxHashHexes,
+		ProcessedAt:        v1dbclient.Clock.Now().Unix(),
+	}
+	_, err := client.ReplaceOne(
+		ctx, bson.M{"_id": blockHash}, document, options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// FindBtcReorgByBlockHash fetches the reorg record of the given block hash.
+// It returns a NotFoundError if the block has not been recorded as reorged.
+func (v1dbclient *V1Database) FindBtcReorgByBlockHash(
+	ctx context.Context, blockHash string,
+) (*v1dbmodel.BtcReorgDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1BtcReorgCollection)
+	var document v1dbmodel.BtcReorgDocument
+	err := client.FindOne(ctx, bson.M{"_id": blockHash}).Decode(&document)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     blockHash,
+				Message: "Reorged block not found",
+			}
+		}
+		return nil, err
+	}
+	return &document, nil
+}
@@ -2,10 +2,8 @@ package v1dbclient
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/big"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
@@ -23,7 +21,7 @@ import (
 func (db *V1Database) GetOrCreateStatsLock(
 	ctx context.Context, stakingTxHashHex string, txType string,
 ) (*v1dbmodel.StatsLockDocument, error) {
-	client := db.Client.Database(db.DbName).Collection(dbmodel.V1StatsLockCollection)
+	client := db.Db(ctx).Collection(dbmodel.V1StatsLockCollection)
 	id := constructStatsLockId(stakingTxHashHex, txType)
 	filter := bson.M{"_id": id}
 	// Define the default document to be inserted if not found
@@ -56,7 +54,7 @@ func (v1dbclient *V1Database) IncrementOverallStats(
 	stakerStatsClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1StakerStatsCollection)
 
 	// Start a session
-	session, sessionErr := v1dbclient.Client.StartSession()
+	session, sessionErr := v1dbclient.Client.StartSession(v1dbclient.SessionOptions())
 	if sessionErr != nil {
 		return sessionErr
 	}
@@ -89,10 +87,7 @@ func (v1dbclient *V1Database) IncrementOverallStats(
 		if stakerStats.TotalDelegations == 1 {
 			upsertUpdate["$inc"].(bson.M)["total_stakers"] = 1
 		}
-		shardId, err := v1dbclient.generateOverallStatsId()
-		if err != nil {
-			return nil, err
-		}
+		shardId := v1dbclient.generateOverallStatsId(stakingTxHashHex)
 
 		upsertFilter := bson.M{"_id": shardId}
 
@@ -104,7 +99,7 @@ func (v1dbclient *V1Database) IncrementOverallStats(
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	_, txErr := session.WithTransaction(ctx, transactionWork, v1dbclient.TransactionOptions())
 	if txErr != nil {
 		return txErr
 	}
@@ -127,7 +122,7 @@ func (v1dbclient *V1Database) SubtractOverallStats(
 	overallStatsClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1OverallStatsCollection)
 
 	// Start a session
-	session, sessionErr := v1dbclient.Client.StartSession()
+	session, sessionErr := v1dbclient.Client.StartSession(v1dbclient.SessionOptions())
 	if sessionErr != nil {
 		return sessionErr
 	}
@@ -139,10 +134,55 @@ func (v1dbclient *V1Database) SubtractOverallStats(
 		if err != nil {
 			return nil, err
 		}
-		shardId, err := v1dbclient.generateOverallStatsId()
+		shardId := v1dbclient.generateOverallStatsId(stakingTxHashHex)
+
+		upsertFilter := bson.M{"_id": shardId}
+
+		_, err = overallStatsClient.UpdateOne(sessCtx, upsertFilter, upsertUpdate, options.Update().SetUpsert(true))
 		if err != nil {
 			return nil, err
 		}
+		return nil, nil
+	}
+
+	// Execute the transaction
+	_, txErr := session.WithTransaction(ctx, transactionWork, v1dbclient.TransactionOptions())
+	if txErr != nil {
+		return txErr
+	}
+
+	return nil
+}
+
+// IncrementOverflowStats increments the overflow stats for the given staking tx hash.
+// The overflow delegations are not part of the other stats, so only the
+// overflow tvl and delegations of the overall stats are incremented.
+// This method is idempotent, only the first call will be processed. Otherwise it will return a notFoundError for duplicates
+func (v1dbclient *V1Database) IncrementOverflowStats(
+	ctx context.Context, stakingTxHashHex string, amount uint64,
+) error {
+	overallStatsClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1OverallStatsCollection)
+
+	// Start a session
+	session, sessionErr := v1dbclient.Client.StartSession(v1dbclient.SessionOptions())
+	if sessionErr != nil {
+		return sessionErr
+	}
+	defer session.EndSession(ctx)
+
+	upsertUpdate := bson.M{
+		"$inc": bson.M{
+			"overflow_tvl":         int64(amount),
+			"overflow_delegations": 1,
+		},
+	}
+	// Define the work to be done in the transaction
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		err := v1dbclient.updateStatsLockByFieldName(sessCtx, stakingTxHashHex, types.Active.ToString(), "overall_stats")
+		if err != nil {
+			return nil, err
+		}
+		shardId := v1dbclient.generateOverallStatsId(stakingTxHashHex)
 
 		upsertFilter := bson.M{"_id": shardId}
 
@@ -154,7 +194,7 @@ func (v1dbclient *V1Database) SubtractOverallStats(
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	_, txErr := session.WithTransaction(ctx, transactionWork, v1dbclient.TransactionOptions())
 	if txErr != nil {
 		return txErr
 	}
@@ -163,6 +203,7 @@ func (v1dbclient *V1Database) SubtractOverallStats(
 }
 
 // GetOverallStats fetches the overall stats from all the shards and sums them up
+// with an aggregation pipeline
 // Refer to the README.md in this directory for more information on the sharding logic
 func (v1dbclient *V1Database) GetOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error) {
 	// The collection is sharded by the _id field, so we need to query all the shards
@@ -171,9 +212,22 @@ func (v1dbclient *V1Database) GetOverallStats(ctx context.Context) (*v1dbmodel.O
 		shardsId = append(shardsId, fmt.Sprintf("%d", i))
 	}
 
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1OverallStatsCollection)
-	filter := bson.M{"_id": bson.M{"$in": shardsId}}
-	cursor, err := client.Find(ctx, filter)
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1OverallStatsCollection)
+	// The shards are summed up server side, only the result is returned
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": shardsId}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                  nil,
+			"active_tvl":           bson.M{"$sum": "$active_tvl"},
+			"total_tvl":            bson.M{"$sum": "$total_tvl"},
+			"active_delegations":   bson.M{"$sum": "$active_delegations"},
+			"total_delegations":    bson.M{"$sum": "$total_delegations"},
+			"total_stakers":        bson.M{"$sum": "$total_stakers"},
+			"overflow_tvl":         bson.M{"$sum": "$overflow_tvl"},
+			"overflow_delegations": bson.M{"$sum": "$overflow_delegations"},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -184,35 +238,25 @@ func (v1dbclient *V1Database) GetOverallStats(ctx context.Context) (*v1dbmodel.O
 		return nil, err
 	}
 
-	// Sum up the stats for the overall stats
+	// No shard has been created yet
 	var result v1dbmodel.OverallStatsDocument
-	for _, stats := range overallStats {
-		result.ActiveTvl += stats.ActiveTvl
-		result.TotalTvl += stats.TotalTvl
-		result.ActiveDelegations += stats.ActiveDelegations
-		result.TotalDelegations += stats.TotalDelegations
-		result.TotalStakers += stats.TotalStakers
+	if len(overallStats) > 0 {
+		result = overallStats[0]
 	}
 
 	return &result, nil
 }
 
-// Generate the id for the overall stats document. Id is a random number ranged from 0-LogicalShardCount-1
+// Generate the id for the overall stats document. Id is a number ranged from 0-LogicalShardCount-1
+// derived from the staking tx hash, so that the retried writes of a delegation land on the same shard
 // It's a logical shard to avoid locking the same field during concurrent writes
 // The sharding number should never be reduced after roll out
-func (v1dbclient *V1Database) generateOverallStatsId() (string, error) {
-	max := big.NewInt(*v1dbclient.Cfg.LogicalShardCount)
-	// Generate a secure random number within the range [0, max)
-	n, err := rand.Int(rand.Reader, max)
-	if err != nil {
-		return "", err
-	}
-
-	return fmt.Sprint(n), nil
+func (v1dbclient *V1Database) generateOverallStatsId(stakingTxHashHex string) string {
+	return db.LogicalShardId(stakingTxHashHex, *v1dbclient.Cfg.LogicalShardCount)
 }
 
 func (v1dbclient *V1Database) updateStatsLockByFieldName(ctx context.Context, stakingTxHashHex, state string, fieldName string) error {
-	statsLockClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1StatsLockCollection)
+	statsLockClient := v1dbclient.Db(ctx).Collection(dbmodel.V1StatsLockCollection)
 	filter := bson.M{"_id": constructStatsLockId(stakingTxHashHex, state), fieldName: false}
 	update := bson.M{"$set": bson.M{fieldName: true}}
 	result, err := statsLockClient.UpdateOne(ctx, filter, update)
@@ -266,7 +310,7 @@ func (v1dbclient *V1Database) SubtractFinalityProviderStats(
 
 // FindFinalityProviderStats fetches the finality provider stats from the database
 func (v1dbclient *V1Database) FindFinalityProviderStats(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error) {
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1FinalityProviderStatsCollection)
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1FinalityProviderStatsCollection)
 	options := options.Find().SetSort(bson.D{{Key: "active_tvl", Value: -1}}) // Sorting in descending order
 	var filter bson.M
 
@@ -295,7 +339,7 @@ func (v1dbclient *V1Database) FindFinalityProviderStats(ctx context.Context, pag
 func (v1dbclient *V1Database) FindFinalityProviderStatsByFinalityProviderPkHex(
 	ctx context.Context, finalityProviderPkHex []string,
 ) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1FinalityProviderStatsCollection)
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1FinalityProviderStatsCollection)
 	filter := bson.M{"_id": bson.M{"$in": finalityProviderPkHex}}
 	cursor, err := client.Find(ctx, filter)
 	if err != nil {
@@ -311,11 +355,40 @@ func (v1dbclient *V1Database) FindFinalityProviderStatsByFinalityProviderPkHex(
 	return finalityProviders, nil
 }
 
+// FindFinalityProviderPkHexesWithStats returns the subset of the given finality
+// provider pk hexes having stats. Only the pk hexes are returned, gathered
+// server side into a single document.
+func (v1dbclient *V1Database) FindFinalityProviderPkHexesWithStats(
+	ctx context.Context, finalityProviderPkHex []string,
+) ([]string, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1FinalityProviderStatsCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": finalityProviderPkHex}}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "pk_hexes": bson.M{"$push": "$_id"}}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		PkHexes []string `bson:"pk_hexes"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+	return results[0].PkHexes, nil
+}
+
 func (v1dbclient *V1Database) updateFinalityProviderStats(ctx context.Context, state, stakingTxHashHex, fpPkHex string, upsertUpdate primitive.M) error {
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1FinalityProviderStatsCollection)
 
 	// Start a session
-	session, sessionErr := v1dbclient.Client.StartSession()
+	session, sessionErr := v1dbclient.Client.StartSession(v1dbclient.SessionOptions())
 	if sessionErr != nil {
 		return sessionErr
 	}
@@ -337,7 +410,7 @@ func (v1dbclient *V1Database) updateFinalityProviderStats(ctx context.Context, s
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	_, txErr := session.WithTransaction(ctx, transactionWork, v1dbclient.TransactionOptions())
 	if txErr != nil {
 		return txErr
 	}
@@ -379,7 +452,7 @@ func (v1dbclient *V1Database) updateStakerStats(ctx context.Context, state, stak
 	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1StakerStatsCollection)
 
 	// Start a session
-	session, sessionErr := v1dbclient.Client.StartSession()
+	session, sessionErr := v1dbclient.Client.StartSession(v1dbclient.SessionOptions())
 	if sessionErr != nil {
 		return sessionErr
 	}
@@ -401,12 +474,12 @@ func (v1dbclient *V1Database) updateStakerStats(ctx context.Context, state, stak
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	_, txErr := session.WithTransaction(ctx, transactionWork, v1dbclient.TransactionOptions())
 	return txErr
 }
 
 func (v1dbclient *V1Database) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error) {
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1StakerStatsCollection)
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1StakerStatsCollection)
 
 	opts := options.Find().SetSort(bson.D{{Key: "active_tvl", Value: -1}})
 	var filter bson.M
@@ -432,10 +505,66 @@ func (v1dbclient *V1Database) FindTopStakersByTvl(ctx context.Context, paginatio
 	)
 }
 
+func (v1dbclient *V1Database) CountStakerStats(ctx context.Context, estimated bool) (int64, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1StakerStatsCollection)
+	if estimated {
+		return client.EstimatedDocumentCount(ctx)
+	}
+	return client.CountDocuments(ctx, bson.M{})
+}
+
+// FindStakerStateSummaries summarizes the delegations of the staker by state,
+// the archived ones included, the states without delegations are omitted
+func (v1dbclient *V1Database) FindStakerStateSummaries(
+	ctx context.Context, stakerPkHex string,
+) ([]v1dbmodel.StakerStateSummaryDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
+	pipeline := append(archivedDelegationsPipeline(bson.M{"staker_pk_hex": stakerPkHex}),
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":                     "$state",
+			"count":                   bson.M{"$sum": 1},
+			"tvl":                     bson.M{"$sum": "$staking_value"},
+			"first_staking_timestamp": bson.M{"$min": "$staking_tx.start_timestamp"},
+			"last_staking_timestamp":  bson.M{"$max": "$staking_tx.start_timestamp"},
+		}}},
+	)
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var summaries []v1dbmodel.StakerStateSummaryDocument
+	if err = cursor.All(ctx, &summaries); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// FindStakerStatsByStakerPkHexes fetches the stats of the given stakers, the
+// stakers without stats are left out
+func (v1dbclient *V1Database) FindStakerStatsByStakerPkHexes(
+	ctx context.Context, stakerPkHexes []string,
+) ([]*v1dbmodel.StakerStatsDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1StakerStatsCollection)
+	filter := bson.M{"_id": bson.M{"$in": stakerPkHexes}}
+	cursor, err := client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stakerStats []*v1dbmodel.StakerStatsDocument
+	if err = cursor.All(ctx, &stakerStats); err != nil {
+		return nil, err
+	}
+	return stakerStats, nil
+}
+
 func (v1dbclient *V1Database) GetStakerStats(
 	ctx context.Context, stakerPkHex string,
 ) (*v1dbmodel.StakerStatsDocument, error) {
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1StakerStatsCollection)
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1StakerStatsCollection)
 	filter := bson.M{"_id": stakerPkHex}
 	var result v1dbmodel.StakerStatsDocument
 	err := client.FindOne(ctx, filter).Decode(&result)
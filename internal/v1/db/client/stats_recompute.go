@@ -0,0 +1,223 @@
+package v1dbclient
+
+import (
+	"context"
+	"fmt"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// notOverflowFilter matches the delegations counted in the stats, the overflow
+// delegations are only counted in the overflow stats
+func notOverflowFilter() bson.M {
+	return bson.M{"is_overflow": bson.M{"$ne": true}}
+}
+
+// statsFromDelegationsGroup accumulates the stats of the delegations the same
+// way the stats are incremented by the stats events: every delegation is
+// counted in the total stats, and remains counted in the active stats until
+// it's unbonded through an unbonding tx. Delegations unbonded by the staking
+// timelock expiry are not subtracted, see the unbonding queue handler.
+func statsFromDelegationsGroup(groupId interface{}) bson.M {
+	isUnbonded := bson.M{"$ifNull": bson.A{"$unbonding_tx", false}}
+	return bson.M{
+		"_id":       groupId,
+		"total_tvl": bson.M{"$sum": "$staking_value"},
+		"active_tvl": bson.M{"$sum": bson.M{
+			"$cond": bson.A{isUnbonded, 0, "$staking_value"},
+		}},
+		"total_delegations": bson.M{"$sum": 1},
+		"active_delegations": bson.M{"$sum": bson.M{
+			"$cond": bson.A{isUnbonded, 0, 1},
+		}},
+	}
+}
+
+// RecomputeOverallStats computes the overall stats from the delegation
+// collection, the archived delegations included
+func (v1dbclient *V1Database) RecomputeOverallStats(
+	ctx context.Context,
+) (*v1dbmodel.OverallStatsDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
+	// Group by staker first to count the stakers without accumulating their
+	// public keys in a single document
+	pipeline := append(archivedDelegationsPipeline(notOverflowFilter()),
+		bson.D{{Key: "$group", Value: statsFromDelegationsGroup("$staker_pk_hex")}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":                nil,
+			"active_tvl":         bson.M{"$sum": "$active_tvl"},
+			"total_tvl":          bson.M{"$sum": "$total_tvl"},
+			"active_delegations": bson.M{"$sum": "$active_delegations"},
+			"total_delegations":  bson.M{"$sum": "$total_delegations"},
+			"total_stakers":      bson.M{"$sum": 1},
+		}}},
+	)
+	result, err := aggregateOverallStats(ctx, client, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	// The overflow delegations are only counted in the overflow stats, the
+	// same way the overflow stats events are
+	overflowPipeline := append(archivedDelegationsPipeline(bson.M{"is_overflow": true}),
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":                  nil,
+			"overflow_tvl":         bson.M{"$sum": "$staking_value"},
+			"overflow_delegations": bson.M{"$sum": 1},
+		}}},
+	)
+	overflow, err := aggregateOverallStats(ctx, client, overflowPipeline)
+	if err != nil {
+		return nil, err
+	}
+	result.OverflowTvl = overflow.OverflowTvl
+	result.OverflowDelegations = overflow.OverflowDelegations
+	return result, nil
+}
+
+// aggregateOverallStats runs the pipeline grouping the delegations into a
+// single overall stats document, an empty document is returned if no
+// delegation matched
+func aggregateOverallStats(
+	ctx context.Context, client *mongo.Collection, pipeline mongo.Pipeline,
+) (*v1dbmodel.OverallStatsDocument, error) {
+	cursor, err := client.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []v1dbmodel.OverallStatsDocument
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return &v1dbmodel.OverallStatsDocument{}, nil
+	}
+	result := results[0]
+	result.Id = ""
+	return &result, nil
+}
+
+// RecomputeFinalityProviderStats computes the stats of every finality provider
+// having delegations from the delegation collection, the archived delegations
+// included
+func (v1dbclient *V1Database) RecomputeFinalityProviderStats(
+	ctx context.Context,
+) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1DelegationCollection)
+	pipeline := append(archivedDelegationsPipeline(notOverflowFilter()),
+		bson.D{{Key: "$group", Value: statsFromDelegationsGroup("$finality_provider_pk_hex")}},
+		bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	)
+	cursor, err := client.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*v1dbmodel.FinalityProviderStatsDocument
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FindAllFinalityProviderStats fetches the stats of all the finality providers
+// sorted by the finality provider pk hex
+func (v1dbclient *V1Database) FindAllFinalityProviderStats(
+	ctx context.Context,
+) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1FinalityProviderStatsCollection)
+	cursor, err := client.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*v1dbmodel.FinalityProviderStatsDocument
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ReplaceOverallStats rewrites all the overall stats shards within a single
+// transaction, the given stats are stored in the first shard and the other
+// shards are reset.
+func (v1dbclient *V1Database) ReplaceOverallStats(
+	ctx context.Context, stats *v1dbmodel.OverallStatsDocument,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1OverallStatsCollection)
+
+	// Start a session
+	session, sessionErr := v1dbclient.Client.StartSession(v1dbclient.SessionOptions())
+	if sessionErr != nil {
+		return sessionErr
+	}
+	defer session.EndSession(ctx)
+
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		for i := 0; i < int(*v1dbclient.Cfg.LogicalShardCount); i++ {
+			shard := v1dbmodel.OverallStatsDocument{Id: fmt.Sprintf("%d", i)}
+			if i == 0 {
+				shard = *stats
+				shard.Id = "0"
+			}
+			_, err := client.ReplaceOne(
+				sessCtx, bson.M{"_id": shard.Id}, shard, options.Replace().SetUpsert(true),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	// Execute the transaction
+	_, txErr := session.WithTransaction(ctx, transactionWork, v1dbclient.TransactionOptions())
+	return txErr
+}
+
+// ReplaceFinalityProviderStats rewrites the finality provider stats collection
+// with the given stats within a single transaction. The stats of finality
+// providers not part of the given stats are removed.
+func (v1dbclient *V1Database) ReplaceFinalityProviderStats(
+	ctx context.Context, stats []*v1dbmodel.FinalityProviderStatsDocument,
+) error {
+	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1FinalityProviderStatsCollection)
+
+	// Start a session
+	session, sessionErr := v1dbclient.Client.StartSession(v1dbclient.SessionOptions())
+	if sessionErr != nil {
+		return sessionErr
+	}
+	defer session.EndSession(ctx)
+
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		fpPkHexes := make([]string, 0, len(stats))
+		for _, fpStats := range stats {
+			_, err := client.ReplaceOne(
+				sessCtx, bson.M{"_id": fpStats.FinalityProviderPkHex}, fpStats,
+				options.Replace().SetUpsert(true),
+			)
+			if err != nil {
+				return nil, err
+			}
+			fpPkHexes = append(fpPkHexes, fpStats.FinalityProviderPkHex)
+		}
+		_, err := client.DeleteMany(sessCtx, bson.M{"_id": bson.M{"$nin": fpPkHexes}})
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	// Execute the transaction
+	_, txErr := session.WithTransaction(ctx, transactionWork, v1dbclient.TransactionOptions())
+	return txErr
+}
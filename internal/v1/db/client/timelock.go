@@ -12,7 +12,7 @@ func (v1dbclient *V1Database) SaveTimeLockExpireCheck(
 	ctx context.Context, stakingTxHashHex string,
 	expireHeight uint64, txType string,
 ) error {
-	client := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1TimeLockCollection)
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1TimeLockCollection)
 	document := v1dbmodel.NewTimeLockDocument(stakingTxHashHex, expireHeight, txType)
 	_, err := client.InsertOne(ctx, document)
 	if err != nil {
@@ -3,6 +3,7 @@ package v1dbclient
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
@@ -14,27 +15,21 @@ import (
 )
 
 func (v1dbclient *V1Database) SaveUnbondingTx(
-	ctx context.Context, stakingTxHashHex, txHashHex, txHex, signatureHex string,
+	ctx context.Context, stakingTxHashHex, txHashHex, txHex, signatureHex, psbtBase64 string,
 ) error {
 	delegationClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1DelegationCollection)
 	unbondingClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1UnbondingCollection)
-
-	// Start a session
-	session, err := v1dbclient.Client.StartSession()
-	if err != nil {
-		return err
-	}
-	defer session.EndSession(ctx)
+	signatureClient := v1dbclient.Client.Database(v1dbclient.DbName).Collection(dbmodel.V1UnbondingSignaturesCollection)
 
 	// Define the work to be done in the transaction
-	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+	transactionWork := func(sessCtx mongo.SessionContext, changeFields bson.M) (interface{}, error) {
 		// Find the existing delegation document first, it will be used later in the transaction
 		delegationFilter := bson.M{
 			"_id":   stakingTxHashHex,
 			"state": types.Active,
 		}
 		var delegationDocument v1dbmodel.DelegationDocument
-		err = delegationClient.FindOne(sessCtx, delegationFilter).Decode(&delegationDocument)
+		err := delegationClient.FindOne(sessCtx, delegationFilter).Decode(&delegationDocument)
 		if err != nil {
 			if err == mongo.ErrNoDocuments {
 				return nil, &db.NotFoundError{
@@ -45,7 +40,8 @@ func (v1dbclient *V1Database) SaveUnbondingTx(
 			return nil, err
 		}
 		// Update the state to UnbondingRequested
-		delegationUpdate := bson.M{"$set": bson.M{"state": types.UnbondingRequested}}
+		changeFields["state"] = types.UnbondingRequested
+		delegationUpdate := bson.M{"$set": changeFields}
 		result, err := delegationClient.UpdateOne(sessCtx, delegationFilter, delegationUpdate)
 		if err != nil {
 			return nil, err
@@ -66,23 +62,38 @@ func (v1dbclient *V1Database) SaveUnbondingTx(
 			State:              v1dbmodel.UnbondingInitialState,
 			UnbondingTxHashHex: txHashHex,
 			UnbondingTxHex:     txHex,
+			UnbondingPsbtB64:   psbtBase64,
 			StakingTxHex:       delegationDocument.StakingTx.TxHex,
 			StakingOutputIndex: delegationDocument.StakingTx.OutputIndex,
 			StakingTimelock:    delegationDocument.StakingTx.TimeLock,
 			StakingTxHashHex:   stakingTxHashHex,
 			StakingAmount:      delegationDocument.StakingValue,
+			CreatedAt:          v1dbclient.Clock.Now().Unix(),
 		}
 		_, err = unbondingClient.InsertOne(sessCtx, unbondingDocument)
 		if err != nil {
-			var writeErr mongo.WriteException
-			if errors.As(err, &writeErr) {
-				for _, e := range writeErr.WriteErrors {
-					if mongo.IsDuplicateKeyError(e) {
-						return nil, &db.DuplicateKeyError{
-							Key:     txHashHex,
-							Message: "unbonding transaction already exists",
-						}
-					}
+			if db.IsDuplicateKeyOnField(err, "unbonding_tx_hash_hex") {
+				return nil, &db.DuplicateKeyError{
+					Key:     txHashHex,
+					Message: "unbonding transaction already exists",
+				}
+			}
+			return nil, err
+		}
+
+		// Record the signature, a signature can only be submitted once
+		_, err = signatureClient.InsertOne(sessCtx, v1dbmodel.UnbondingSignatureDocument{
+			SignatureHex:       strings.ToLower(signatureHex),
+			StakingTxHashHex:   stakingTxHashHex,
+			UnbondingTxHashHex: txHashHex,
+			SubmittedAt:        v1dbclient.Clock.Now().Unix(),
+		})
+		if err != nil {
+			// The signature is the id of the record
+			if db.IsDuplicateKeyOnField(err, "_id") {
+				return nil, &db.DuplicateKeyError{
+					Key:     signatureHex,
+					Message: "unbonding signature already submitted",
 				}
 			}
 			return nil, err
@@ -91,28 +102,69 @@ func (v1dbclient *V1Database) SaveUnbondingTx(
 		return nil, nil
 	}
 
-	// Execute the transaction
-	_, err = session.WithTransaction(ctx, transactionWork)
+	// Execute the transaction along with the change of the delegation
+	_, err := v1dbclient.writeDelegationChange(ctx, transactionWork)
+	return err
+}
+
+// FindUnbondingSignature finds the record of the submitted unbonding signature.
+// Return not found error if the signature has never been submitted
+func (v1dbclient *V1Database) FindUnbondingSignature(
+	ctx context.Context, signatureHex string,
+) (*v1dbmodel.UnbondingSignatureDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1UnbondingSignaturesCollection)
+	var signature v1dbmodel.UnbondingSignatureDocument
+	err := client.FindOne(ctx, bson.M{"_id": strings.ToLower(signatureHex)}).Decode(&signature)
 	if err != nil {
-		return err
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     signatureHex,
+				Message: "unbonding signature not found",
+			}
+		}
+		return nil, err
 	}
+	return &signature, nil
+}
 
-	return nil
+// FindUnbondingByTxHashHex finds the unbonding request of the unbonding tx.
+// Return not found error if the unbonding tx has never been submitted
+func (v1dbclient *V1Database) FindUnbondingByTxHashHex(
+	ctx context.Context, unbondingTxHashHex string,
+) (*v1dbmodel.UnbondingDocument, error) {
+	client := v1dbclient.Db(ctx).Collection(dbmodel.V1UnbondingCollection)
+	var unbonding v1dbmodel.UnbondingDocument
+	err := client.FindOne(ctx, bson.M{"unbonding_tx_hash_hex": unbondingTxHashHex}).Decode(&unbonding)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     unbondingTxHashHex,
+				Message: "unbonding request not found",
+			}
+		}
+		return nil, err
+	}
+	return &unbonding, nil
 }
 
 // Change the state to `unbonding` and save the unbondingTx data
 // Return not found error if the stakingTxHashHex is not found or the existing state is not eligible for unbonding
 func (v1dbclient *V1Database) TransitionToUnbondingState(
-	ctx context.Context, txHashHex string, startHeight, timelock, outputIndex uint64, txHex string, startTimestamp int64,
+	ctx context.Context, txHashHex string, startHeight, timelock, outputIndex uint64,
+	txHex, unbondingTxHashHex string, startTimestamp int64,
 ) error {
-	unbondingTxMap := make(map[string]interface{})
-	unbondingTxMap["unbonding_tx"] = v1dbmodel.TimelockTransaction{
+	unbondingTx := v1dbmodel.TimelockTransaction{
 		TxHex:          txHex,
+		TxHashHex:      unbondingTxHashHex,
 		OutputIndex:    outputIndex,
 		StartTimestamp: startTimestamp,
 		StartHeight:    startHeight,
 		TimeLock:       timelock,
 	}
+	unbondingTxMap := make(map[string]interface{})
+	unbondingTxMap["unbonding_tx"] = unbondingTx
+	// The unbonding timelock replaces the staking one
+	unbondingTxMap["expiry_height"] = unbondingTx.ExpiryHeight()
 
 	err := v1dbclient.transitionState(
 		ctx, txHashHex, types.Unbonding.ToString(),
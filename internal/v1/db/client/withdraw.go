@@ -5,12 +5,21 @@ import (
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 )
 
-func (v1dbclient *V1Database) TransitionToWithdrawnState(ctx context.Context, txHashHex string) error {
+// TransitionToWithdrawnState changes the state to `withdrawn` and saves the
+// withdrawal tx if provided.
+func (v1dbclient *V1Database) TransitionToWithdrawnState(
+	ctx context.Context, txHashHex string, withdrawalTx *v1dbmodel.WithdrawalTransaction,
+) error {
+	var withdrawalTxMap map[string]interface{}
+	if withdrawalTx != nil {
+		withdrawalTxMap = map[string]interface{}{"withdrawal_tx": withdrawalTx}
+	}
 	err := v1dbclient.transitionState(
 		ctx, txHashHex, types.Withdrawn.ToString(),
-		utils.QualifiedStatesToWithdraw(), nil,
+		utils.QualifiedStatesToWithdraw(), withdrawalTxMap,
 	)
 	if err != nil {
 		return err
@@ -0,0 +1,32 @@
+package v1dbmodel
+
+import "fmt"
+
+// DelegationMilestone is a step of the delegation lifecycle recorded in the
+// audit trail.
+type DelegationMilestone string
+
+const (
+	MilestoneStaked             DelegationMilestone = "staked"
+	MilestoneUnbondingRequested DelegationMilestone = "unbonding_requested"
+	MilestoneUnbondingConfirmed DelegationMilestone = "unbonding_confirmed"
+	MilestoneExpired            DelegationMilestone = "expired"
+	MilestoneWithdrawn          DelegationMilestone = "withdrawn"
+)
+
+// DelegationAuditTrailDocument records a milestone reached by a delegation.
+// A delegation reaches each milestone at most once, the first record is kept
+// if the same milestone is recorded again.
+type DelegationAuditTrailDocument struct {
+	Id               string              `bson:"_id"` // staking tx hash + milestone
+	StakingTxHashHex string              `bson:"staking_tx_hash_hex"`
+	Milestone        DelegationMilestone `bson:"milestone"`
+	TxHashHex        string              `bson:"tx_hash_hex,omitempty"`
+	BlockHeight      uint64              `bson:"block_height,omitempty"`
+	Timestamp        int64               `bson:"timestamp"`
+	RecordedAt       int64               `bson:"recorded_at"`
+}
+
+func BuildDelegationAuditTrailId(stakingTxHashHex string, milestone DelegationMilestone) string {
+	return fmt.Sprintf("%s:%s", stakingTxHashHex, milestone)
+}
@@ -7,4 +7,7 @@ type BtcInfo struct {
 	BtcHeight      uint64 `bson:"btc_height"`
 	ConfirmedTvl   uint64 `bson:"confirmed_tvl"`
 	UnconfirmedTvl uint64 `bson:"unconfirmed_tvl"`
+	// BabylonHeight is the Babylon height reported along with the BTC height,
+	// 0 if not reported
+	BabylonHeight uint64 `bson:"babylon_height"`
 }
@@ -0,0 +1,9 @@
+package v1dbmodel
+
+const DelegationChangeCounterId = "delegation_changes"
+
+// CounterDocument holds a monotonically increasing sequence
+type CounterDocument struct {
+	Id  string `bson:"_id"`
+	Seq int64  `bson:"seq"`
+}
@@ -1,27 +1,70 @@
 package v1dbmodel
 
 import (
-	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 )
 
 type TimelockTransaction struct {
 	TxHex          string `bson:"tx_hex"`
+	TxHashHex      string `bson:"tx_hash_hex,omitempty"`
 	OutputIndex    uint64 `bson:"output_index"`
 	StartTimestamp int64  `bson:"start_timestamp"`
 	StartHeight    uint64 `bson:"start_height"`
 	TimeLock       uint64 `bson:"timelock"`
 }
 
+// ExpiryHeight is the height the timelock of the tx expires at
+func (tx *TimelockTransaction) ExpiryHeight() uint64 {
+	return tx.StartHeight + tx.TimeLock
+}
+
+// WithdrawalTransaction is the tx spending the staking or unbonding output
+// once the timelock has expired.
+type WithdrawalTransaction struct {
+	TxHashHex      string `bson:"tx_hash_hex"`
+	TxHex          string `bson:"tx_hex"`
+	OutputIndex    uint64 `bson:"output_index"`
+	StartTimestamp int64  `bson:"start_timestamp"`
+}
+
 type DelegationDocument struct {
-	StakingTxHashHex      string                `bson:"_id"` // Primary key
-	StakerPkHex           string                `bson:"staker_pk_hex"`
-	FinalityProviderPkHex string                `bson:"finality_provider_pk_hex"`
-	StakingValue          uint64                `bson:"staking_value"`
-	State                 types.DelegationState `bson:"state"`
-	StakingTx             *TimelockTransaction  `bson:"staking_tx"` // Always exist
-	UnbondingTx           *TimelockTransaction  `bson:"unbonding_tx,omitempty"`
-	IsOverflow            bool                  `bson:"is_overflow"`
+	StakingTxHashHex      string                 `bson:"_id"` // Primary key
+	StakerPkHex           string                 `bson:"staker_pk_hex"`
+	FinalityProviderPkHex string                 `bson:"finality_provider_pk_hex"`
+	StakingValue          uint64                 `bson:"staking_value"`
+	State                 types.DelegationState  `bson:"state"`
+	StakingTx             *TimelockTransaction   `bson:"staking_tx"` // Always exist
+	UnbondingTx           *TimelockTransaction   `bson:"unbonding_tx,omitempty"`
+	WithdrawalTx          *WithdrawalTransaction `bson:"withdrawal_tx,omitempty"`
+	IsOverflow            bool                   `bson:"is_overflow"`
+	// ExpiryHeight is the height the timelock of the staking tx expires at,
+	// or the one of the unbonding tx once the delegation is unbonding
+	ExpiryHeight uint64 `bson:"expiry_height"`
+	// PartnerId is the partner the delegation is attributed to, if any
+	PartnerId string `bson:"partner_id,omitempty"`
+	// EncryptedPartnerId and PartnerIdIndex replace the partner id if the
+	// field encryption is configured, the delegations of a partner are then
+	// looked up by the blind index
+	EncryptedPartnerId string `bson:"encrypted_partner_id,omitempty"`
+	PartnerIdIndex     string `bson:"partner_id_index,omitempty"`
+	// ChangeSeq is increased on every write of the delegation, it's not set
+	// on the delegations written before it was introduced.
+	ChangeSeq int64 `bson:"change_seq"`
+	UpdatedAt int64 `bson:"updated_at"`
+	// SchemaVersion is the version of the document, see DelegationSchemaVersion
+	SchemaVersion int `bson:"schema_version"`
+	// Removed is only set on the tombstones of the delegations rolled back by
+	// a BTC reorg, which are part of the delegation changes
+	Removed bool `bson:"removed,omitempty"`
+	// Checksum is computed over the immutable fields at insert time, see
+	// ComputeChecksum. It's not set on the delegations inserted before it was
+	// introduced.
+	Checksum string `bson:"checksum,omitempty"`
+
+	// storedSchemaVersion is the version the document was stored at before
+	// being migrated on read
+	storedSchemaVersion int
 }
 
 type DelegationByStakerPagination struct {
@@ -55,3 +98,21 @@ func BuildDelegationScanPaginationToken(d DelegationDocument) (string, error) {
 	}
 	return token, nil
 }
+
+// DelegationChangeCursor is the position in the delegation change feed
+type DelegationChangeCursor struct {
+	ChangeSeq        int64  `json:"change_seq"`
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+}
+
+func BuildDelegationChangeCursor(d DelegationDocument) (string, error) {
+	cursor := &DelegationChangeCursor{
+		ChangeSeq:        d.ChangeSeq,
+		StakingTxHashHex: d.StakingTxHashHex,
+	}
+	token, err := dbmodel.GetPaginationToken(cursor)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
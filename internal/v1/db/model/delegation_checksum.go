@@ -0,0 +1,36 @@
+package v1dbmodel
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// ComputeChecksum hashes the fields of the delegation which never change once
+// it's inserted: the staking tx, its value and the public keys. A stored
+// checksum differing from the computed one reveals an unexpected mutation or
+// a corruption of the document.
+func (d *DelegationDocument) ComputeChecksum() string {
+	var stakingTx TimelockTransaction
+	if d.StakingTx != nil {
+		stakingTx = *d.StakingTx
+	}
+	// The hex encoded fields can't contain the separator
+	data := fmt.Sprintf(
+		"%s|%s|%d|%d|%d|%d|%s|%s",
+		d.StakingTxHashHex, stakingTx.TxHex, stakingTx.OutputIndex, stakingTx.StartHeight,
+		stakingTx.TimeLock, d.StakingValue, d.StakerPkHex, d.FinalityProviderPkHex,
+	)
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyChecksum tells whether the stored checksum matches the immutable
+// fields of the delegation. The delegations inserted before the checksum
+// was introduced have none and can't be verified.
+func (d *DelegationDocument) VerifyChecksum() (verified bool, matches bool) {
+	if d.Checksum == "" {
+		return false, false
+	}
+	return true, d.Checksum == d.ComputeChecksum()
+}
@@ -0,0 +1,42 @@
+package v1dbmodel
+
+import (
+	"fmt"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+)
+
+// DelegationLabelsDocument holds the private labels an api key has attached
+// to a delegation, they are only returned to the same api key
+type DelegationLabelsDocument struct {
+	Id               string   `bson:"_id"` // api key name + staking tx hash
+	ApiKey           string   `bson:"api_key"`
+	StakingTxHashHex string   `bson:"staking_tx_hash_hex"`
+	Labels           []string `bson:"labels,omitempty"`
+	// EncryptedLabels and LabelIndexes replace the labels if the field
+	// encryption is configured, the labels are then looked up by their
+	// blind index
+	EncryptedLabels []string `bson:"encrypted_labels,omitempty"`
+	LabelIndexes    []string `bson:"label_indexes,omitempty"`
+	UpdatedAt       int64    `bson:"updated_at"`
+}
+
+func BuildDelegationLabelsId(apiKey, stakingTxHashHex string) string {
+	return fmt.Sprintf("%s:%s", apiKey, stakingTxHashHex)
+}
+
+// BuildLabelIndexValue scopes the blind index of the label to the api key, so
+// that the same label of two api keys is not indexed alike
+func BuildLabelIndexValue(apiKey, label string) string {
+	return fmt.Sprintf("%s:%s", apiKey, label)
+}
+
+// DelegationLabelsPagination is used to paginate the labeled delegations of
+// an api key by their id
+type DelegationLabelsPagination struct {
+	Id string `json:"id"`
+}
+
+func BuildDelegationLabelsPaginationToken(d DelegationLabelsDocument) (string, error) {
+	return dbmodel.GetPaginationToken(DelegationLabelsPagination{Id: d.Id})
+}
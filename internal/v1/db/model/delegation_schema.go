@@ -0,0 +1,58 @@
+package v1dbmodel
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// DelegationSchemaVersion is the version of the delegation documents written
+// by the service. The documents stored at a previous version are migrated
+// every time they are read, until the delegation migrator writes them back at
+// this version.
+const DelegationSchemaVersion = 2
+
+// delegationMigrations upgrade a delegation document from the version of
+// their index to the next one. A new version comes with a migration filling
+// in the fields it introduces, e.g. from the fields already stored, so that
+// the existing documents don't need to be rewritten at once.
+var delegationMigrations = [DelegationSchemaVersion]func(d *DelegationDocument){
+	// Version 1 introduces the schema version, the documents are unchanged
+	func(d *DelegationDocument) {},
+	// Version 2 introduces the expiry height, from the unbonding tx if the
+	// delegation has one
+	func(d *DelegationDocument) {
+		if d.UnbondingTx != nil {
+			d.ExpiryHeight = d.UnbondingTx.ExpiryHeight()
+		} else if d.StakingTx != nil {
+			d.ExpiryHeight = d.StakingTx.ExpiryHeight()
+		}
+	},
+}
+
+// UnmarshalBSON decodes the delegation document and migrates it to the
+// current schema version
+func (d *DelegationDocument) UnmarshalBSON(data []byte) error {
+	// The alias type doesn't have the UnmarshalBSON method, which would
+	// otherwise call itself
+	type storedDelegationDocument DelegationDocument
+	var stored storedDelegationDocument
+	if err := bson.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	*d = DelegationDocument(stored)
+	d.storedSchemaVersion = d.SchemaVersion
+	// The documents written by a newer version of the service are left as is
+	for d.SchemaVersion < DelegationSchemaVersion {
+		delegationMigrations[d.SchemaVersion](d)
+		d.SchemaVersion++
+	}
+	return nil
+}
+
+// IsMigrated tells whether the document was stored at a previous schema
+// version and migrated on read
+func (d *DelegationDocument) IsMigrated() bool {
+	return d.storedSchemaVersion < d.SchemaVersion
+}
+
+// StoredSchemaVersion is the version the document was stored at
+func (d *DelegationDocument) StoredSchemaVersion() int {
+	return d.storedSchemaVersion
+}
@@ -0,0 +1,20 @@
+package v1dbmodel
+
+import "fmt"
+
+// FpCommissionChangeDocument records a commission of a finality provider as
+// seen by the registry sync. The changes of a finality provider are numbered
+// from 1, the first one records the commission the finality provider was
+// first seen with.
+type FpCommissionChangeDocument struct {
+	Id                    string `bson:"_id"` // finality provider pk + sequence
+	FinalityProviderPkHex string `bson:"finality_provider_pk_hex"`
+	Sequence              int64  `bson:"sequence"`
+	Commission            string `bson:"commission"`
+	PreviousCommission    string `bson:"previous_commission,omitempty"`
+	RecordedAt            int64  `bson:"recorded_at"`
+}
+
+func BuildFpCommissionChangeId(fpPkHex string, sequence int64) string {
+	return fmt.Sprintf("%s:%d", fpPkHex, sequence)
+}
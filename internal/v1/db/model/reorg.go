@@ -0,0 +1,10 @@
+package v1dbmodel
+
+// BtcReorgDocument records a BTC block that has been reorged out of the
+// canonical chain, together with the delegations that were rolled back.
+type BtcReorgDocument struct {
+	BlockHash          string   `bson:"_id"`
+	BlockHeight        uint64   `bson:"block_height"`
+	StakingTxHashHexes []string `bson:"staking_tx_hash_hexes"`
+	ProcessedAt        int64    `bson:"processed_at"`
+}
@@ -1,6 +1,11 @@
 package v1dbmodel
 
-import dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+import (
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+)
 
 // StatsLockDocument represents the document in the stats lock collection
 // It's used as a lock to prevent concurrent stats calculation for the same staking tx hash
@@ -31,6 +36,49 @@ type OverallStatsDocument struct {
 	ActiveDelegations int64  `bson:"active_delegations"`
 	TotalDelegations  int64  `bson:"total_delegations"`
 	TotalStakers      uint64 `bson:"total_stakers"`
+	// The overflow delegations are excluded from the stats above, they are
+	// only counted in the overflow stats
+	OverflowTvl         int64 `bson:"overflow_tvl"`
+	OverflowDelegations int64 `bson:"overflow_delegations"`
+}
+
+// PartnerStatsDocument holds the stats of the delegations attributed to a
+// partner
+type PartnerStatsDocument struct {
+	ActiveTvl         int64 `bson:"active_tvl"`
+	TotalTvl          int64 `bson:"total_tvl"`
+	ActiveDelegations int64 `bson:"active_delegations"`
+	TotalDelegations  int64 `bson:"total_delegations"`
+}
+
+// StakerStateSummaryDocument summarizes the delegations of a staker in a
+// given state
+type StakerStateSummaryDocument struct {
+	State                 types.DelegationState `bson:"_id"`
+	Count                 int64                 `bson:"count"`
+	Tvl                   int64                 `bson:"tvl"`
+	FirstStakingTimestamp int64                 `bson:"first_staking_timestamp"`
+	LastStakingTimestamp  int64                 `bson:"last_staking_timestamp"`
+}
+
+// MaterializedOverallStatsDocument is the consolidation of the overall stats shards.
+// It's refreshed periodically so that the overall stats are served with a
+// single read.
+type MaterializedOverallStatsDocument struct {
+	OverallStatsDocument `bson:",inline"`
+	LastRefreshedAt      int64 `bson:"last_refreshed_at"`
+}
+
+// HourlyOverallStatsDocument is the snapshot of the overall stats taken by the
+// first refresh of the materialized overall stats within the hour
+type HourlyOverallStatsDocument struct {
+	HourStart         int64     `bson:"_id"`
+	Hour              time.Time `bson:"hour"`
+	ActiveTvl         int64     `bson:"active_tvl"`
+	TotalTvl          int64     `bson:"total_tvl"`
+	ActiveDelegations int64     `bson:"active_delegations"`
+	TotalDelegations  int64     `bson:"total_delegations"`
+	TotalStakers      uint64    `bson:"total_stakers"`
 }
 
 type FinalityProviderStatsDocument struct {
@@ -1,7 +1,15 @@
 package v1dbmodel
 
+// The unbonding pipeline acknowledges the unbonding requests by updating the
+// state of their document from the initial state
 const (
 	UnbondingInitialState = "INSERTED"
+	// UnbondingSentState is set once the unbonding tx is broadcast
+	UnbondingSentState = "SEND"
+	// UnbondingInputAlreadySpentState is set if the staking output was
+	// already spent when the unbonding tx was broadcast
+	UnbondingInputAlreadySpentState = "INPUT_ALREADY_SPENT"
+	UnbondingFailedState            = "FAILED"
 )
 
 type UnbondingDocument struct {
@@ -11,9 +19,20 @@ type UnbondingDocument struct {
 	State              string `bson:"state"`
 	UnbondingTxHashHex string `bson:"unbonding_tx_hash_hex"` // Unique Index
 	UnbondingTxHex     string `bson:"unbonding_tx_hex"`
+	UnbondingPsbtB64   string `bson:"unbonding_psbt_base64,omitempty"` // Only set if submitted as PSBT
 	StakingTxHex       string `bson:"staking_tx_hex"`
 	StakingOutputIndex uint64 `bson:"staking_output_index"`
 	StakingTimelock    uint64 `bson:"staking_timelock"`
 	StakingAmount      uint64 `bson:"staking_amount"`
 	StakingTxHashHex   string `json:"staking_tx_hash_hex"`
+	CreatedAt          int64  `bson:"created_at,omitempty"`
+}
+
+// UnbondingSignatureDocument records a staker signature submitted for an
+// unbonding request, so that the signed payload can not be replayed.
+type UnbondingSignatureDocument struct {
+	SignatureHex       string `bson:"_id"` // lowercase staker signature hex
+	StakingTxHashHex   string `bson:"staking_tx_hash_hex"`
+	UnbondingTxHashHex string `bson:"unbonding_tx_hash_hex"`
+	SubmittedAt        int64  `bson:"submitted_at"`
 }
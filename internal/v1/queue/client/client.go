@@ -3,6 +3,7 @@ package v1queueclient
 import (
 	queueclient "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/client"
 	v1queuehandler "github.com/babylonlabs-io/staking-api-service/internal/v1/queue/handler"
+	v1queueschema "github.com/babylonlabs-io/staking-api-service/internal/v1/queue/schema"
 	client "github.com/babylonlabs-io/staking-queue-client/client"
 	queueConfig "github.com/babylonlabs-io/staking-queue-client/config"
 	"github.com/rs/zerolog/log"
@@ -16,41 +17,48 @@ type V1QueueClient struct {
 	UnbondingStakingQueueClient client.QueueClient
 	WithdrawStakingQueueClient  client.QueueClient
 	BtcInfoQueueClient          client.QueueClient
+	BtcReorgQueueClient         client.QueueClient
 }
 
 func New(cfg *queueConfig.QueueConfig, handler *v1queuehandler.V1QueueHandler, queueClient *queueclient.Queue) *V1QueueClient {
-	activeStakingQueueClient, err := client.NewQueueClient(
+	activeStakingQueueClient, err := queueClient.NewQueueClient(
 		cfg, client.ActiveStakingQueueName,
 	)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating ActiveStakingQueueClient")
 	}
 
-	expiredStakingQueueClient, err := client.NewQueueClient(
+	expiredStakingQueueClient, err := queueClient.NewQueueClient(
 		cfg, client.ExpiredStakingQueueName,
 	)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating ExpiredStakingQueueClient")
 	}
 
-	unbondingStakingQueueClient, err := client.NewQueueClient(
+	unbondingStakingQueueClient, err := queueClient.NewQueueClient(
 		cfg, client.UnbondingStakingQueueName,
 	)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating UnbondingStakingQueueClient")
 	}
-	withdrawStakingQueueClient, err := client.NewQueueClient(
+	withdrawStakingQueueClient, err := queueClient.NewQueueClient(
 		cfg, client.WithdrawStakingQueueName,
 	)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating WithdrawStakingQueueClient")
 	}
-	btcInfoQueueClient, err := client.NewQueueClient(
+	btcInfoQueueClient, err := queueClient.NewQueueClient(
 		cfg, client.BtcInfoQueueName,
 	)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating BtcInfoQueueClient")
 	}
+	btcReorgQueueClient, err := queueClient.NewQueueClient(
+		cfg, v1queueschema.BtcReorgQueueName,
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while creating BtcReorgQueueClient")
+	}
 	return &V1QueueClient{
 		Queue:                       queueClient,
 		Handler:                     handler,
@@ -59,5 +67,6 @@ func New(cfg *queueConfig.QueueConfig, handler *v1queuehandler.V1QueueHandler, q
 		UnbondingStakingQueueClient: unbondingStakingQueueClient,
 		WithdrawStakingQueueClient:  withdrawStakingQueueClient,
 		BtcInfoQueueClient:          btcInfoQueueClient,
+		BtcReorgQueueClient:         btcReorgQueueClient,
 	}
 }
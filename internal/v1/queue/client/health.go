@@ -27,6 +27,7 @@ func (q *V1QueueClient) IsConnectionHealthy() error {
 	checkQueue("WithdrawStakingQueueClient", q.WithdrawStakingQueueClient)
 	checkQueue("StatsQueueClient", q.StatsQueueClient)
 	checkQueue("BtcInfoQueueClient", q.BtcInfoQueueClient)
+	checkQueue("BtcReorgQueueClient", q.BtcReorgQueueClient)
 
 	if len(errorMessages) > 0 {
 		return fmt.Errorf(strings.Join(errorMessages, "; "))
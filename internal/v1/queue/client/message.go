@@ -10,38 +10,44 @@ func (q *V1QueueClient) StartReceivingMessages() {
 	// start processing messages from the active staking queue
 	queueclient.StartQueueMessageProcessing(
 		q.ActiveStakingQueueClient,
-		q.Handler.ActiveStakingHandler, q.Handler.HandleUnprocessedMessage,
-		q.MaxRetryAttempts, q.ProcessingTimeout,
+		q.Handler.ActiveStakingHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.ActiveStakingQueueClient.GetQueueName()),
 	)
 	log.Printf("Starting to receive messages from expired staking queue")
 	queueclient.StartQueueMessageProcessing(
 		q.ExpiredStakingQueueClient,
-		q.Handler.ExpiredStakingHandler, q.Handler.HandleUnprocessedMessage,
-		q.MaxRetryAttempts, q.ProcessingTimeout,
+		q.Handler.ExpiredStakingHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.ExpiredStakingQueueClient.GetQueueName()),
 	)
 	log.Printf("Starting to receive messages from unbonding staking queue")
 	queueclient.StartQueueMessageProcessing(
 		q.UnbondingStakingQueueClient,
-		q.Handler.UnbondingStakingHandler, q.Handler.HandleUnprocessedMessage,
-		q.MaxRetryAttempts, q.ProcessingTimeout,
+		q.Handler.UnbondingStakingHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.UnbondingStakingQueueClient.GetQueueName()),
 	)
 	log.Printf("Starting to receive messages from withdraw staking queue")
 	queueclient.StartQueueMessageProcessing(
 		q.WithdrawStakingQueueClient,
-		q.Handler.WithdrawStakingHandler, q.Handler.HandleUnprocessedMessage,
-		q.MaxRetryAttempts, q.ProcessingTimeout,
+		q.Handler.WithdrawStakingHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.WithdrawStakingQueueClient.GetQueueName()),
 	)
 	log.Printf("Starting to receive messages from stats queue")
 	queueclient.StartQueueMessageProcessing(
 		q.StatsQueueClient,
-		q.Handler.StatsHandler, q.Handler.HandleUnprocessedMessage,
-		q.MaxRetryAttempts, q.ProcessingTimeout,
+		q.Handler.StatsHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.StatsQueueClient.GetQueueName()),
 	)
 	log.Printf("Starting to receive messages from btc info queue")
 	queueclient.StartQueueMessageProcessing(
 		q.BtcInfoQueueClient,
-		q.Handler.BtcInfoHandler, q.Handler.HandleUnprocessedMessage,
-		q.MaxRetryAttempts, q.ProcessingTimeout,
+		q.Handler.BtcInfoHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.BtcInfoQueueClient.GetQueueName()),
+	)
+	log.Printf("Starting to receive messages from btc reorg queue")
+	queueclient.StartQueueMessageProcessing(
+		q.BtcReorgQueueClient,
+		q.Handler.BtcReorgHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.BtcReorgQueueClient.GetQueueName()),
 	)
 	// ...add more queues here
 }
@@ -84,5 +90,25 @@ func (q *V1QueueClient) StopReceivingMessages() {
 			Str("queueName", q.BtcInfoQueueClient.GetQueueName()).
 			Msg("error while stopping queue")
 	}
+	btcReorgQueueErr := q.BtcReorgQueueClient.Stop()
+	if btcReorgQueueErr != nil {
+		log.Error().Err(btcReorgQueueErr).
+			Str("queueName", q.BtcReorgQueueClient.GetQueueName()).
+			Msg("error while stopping queue")
+	}
 	// ...add more queues here
 }
+
+// GetConsumedQueueNames returns the names of all the queues consumed by the
+// v1 queue client
+func (q *V1QueueClient) GetConsumedQueueNames() []string {
+	return []string{
+		q.ActiveStakingQueueClient.GetQueueName(),
+		q.ExpiredStakingQueueClient.GetQueueName(),
+		q.UnbondingStakingQueueClient.GetQueueName(),
+		q.WithdrawStakingQueueClient.GetQueueName(),
+		q.StatsQueueClient.GetQueueName(),
+		q.BtcInfoQueueClient.GetQueueName(),
+		q.BtcReorgQueueClient.GetQueueName(),
+	}
+}
@@ -10,8 +10,15 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// btcInfoEvent is the btc info event along with the Babylon height, which is
+// only reported by the producers which know it
+type btcInfoEvent struct {
+	queueClient.BtcInfoEvent
+	BabylonHeight uint64 `json:"babylon_height"`
+}
+
 func (h *V1QueueHandler) BtcInfoHandler(ctx context.Context, messageBody string) *types.Error {
-	var btcInfo queueClient.BtcInfoEvent
+	var btcInfo btcInfoEvent
 	err := json.Unmarshal([]byte(messageBody), &btcInfo)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to unmarshal the message body into btcInfo")
@@ -19,7 +26,7 @@ func (h *V1QueueHandler) BtcInfoHandler(ctx context.Context, messageBody string)
 	}
 
 	statsErr := h.Service.ProcessBtcInfoStats(
-		ctx, btcInfo.Height, btcInfo.ConfirmedTvl, btcInfo.UnconfirmedTvl,
+		ctx, btcInfo.Height, btcInfo.BabylonHeight, btcInfo.ConfirmedTvl, btcInfo.UnconfirmedTvl,
 	)
 	if statsErr != nil {
 		log.Error().Err(statsErr).Msg("Failed to process unconfirmed tvl stats")
@@ -26,12 +26,17 @@ func (h *V1QueueHandler) ExpiredStakingHandler(ctx context.Context, messageBody
 	if delErr != nil {
 		return delErr
 	}
-	if utils.Contains[types.DelegationState](utils.OutdatedStatesForUnbonded(), del.State) {
+	if types.IsOutdatedTransition(del.State, types.Unbonded) {
 		// Ignore the message as the delegation state already passed the unbonded state. This is an outdated duplication
 		log.Ctx(ctx).Debug().Str("StakingTxHashHex", expiredStakingEvent.StakingTxHashHex).
 			Msg("delegation state is outdated for unbonded event")
 		return nil
 	}
+	if !types.CanTransition(del.State, types.Unbonded) {
+		return utils.InvalidTransitionError(
+			ctx, expiredStakingEvent.StakingTxHashHex, del.State, types.Unbonded,
+		)
+	}
 
 	txType, err := types.StakingTxTypeFromString(expiredStakingEvent.TxType)
 	if err != nil {
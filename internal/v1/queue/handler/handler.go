@@ -20,6 +20,6 @@ func New(queueHandler *queuehandler.QueueHandler, service v1service.V1ServicePro
 	}
 }
 
-func (qh *V1QueueHandler) HandleUnprocessedMessage(ctx context.Context, messageBody, receipt string) *types.Error {
-	return qh.Service.SaveUnprocessableMessages(ctx, messageBody, receipt)
+func (qh *V1QueueHandler) HandleUnprocessedMessage(ctx context.Context, messageBody, receipt, reason string) *types.Error {
+	return qh.Service.SaveUnprocessableMessages(ctx, messageBody, receipt, reason)
 }
@@ -0,0 +1,43 @@
+package v1queuehandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1queueschema "github.com/babylonlabs-io/staking-api-service/internal/v1/queue/schema"
+	"github.com/rs/zerolog/log"
+)
+
+// BtcReorgHandler handles the BTC reorg event. Every active delegation whose
+// staking tx was included in the reorged block is rolled back together with its
+// stats contribution, so that the TVL does not drift after deep reorgs.
+// The handler is idempotent, rolled back delegations are skipped on redelivery.
+// The reorged block is recorded as the final step.
+func (h *V1QueueHandler) BtcReorgHandler(ctx context.Context, messageBody string) *types.Error {
+	var reorgEvent v1queueschema.BtcReorgEvent
+	err := json.Unmarshal([]byte(messageBody), &reorgEvent)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to unmarshal the message body into BtcReorgEvent")
+		return types.NewError(http.StatusBadRequest, types.BadRequest, err)
+	}
+	if reorgEvent.BlockHash == "" {
+		return types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "reorg event is missing the block hash",
+		)
+	}
+
+	rolledBack, rollbackErr := h.Service.RollbackReorgedDelegations(ctx, reorgEvent.StakingTxHashHexes)
+	if rollbackErr != nil {
+		return rollbackErr
+	}
+	log.Ctx(ctx).Info().Str("blockHash", reorgEvent.BlockHash).
+		Uint64("blockHeight", reorgEvent.BlockHeight).
+		Int("rolledBackDelegations", len(rolledBack)).
+		Msg("processed btc reorg event")
+
+	return h.Service.RecordBtcReorg(
+		ctx, reorgEvent.BlockHash, reorgEvent.BlockHeight, reorgEvent.StakingTxHashHexes,
+	)
+}
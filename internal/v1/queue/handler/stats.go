@@ -54,8 +54,16 @@ func (h *V1QueueHandler) StatsHandler(ctx context.Context, messageBody string) *
 		return addressLookupErr
 	}
 
-	// Perform the stats calculation only if the event is not an overflow event
-	if !isOverflow {
+	// The overflow events are only counted in the overflow stats
+	if isOverflow {
+		statsErr := h.Service.ProcessOverflowStatsCalculation(
+			ctx, statsEvent.StakingTxHashHex, state, statsEvent.StakingValue,
+		)
+		if statsErr != nil {
+			log.Ctx(ctx).Error().Err(statsErr).Msg("Failed to process overflow stats calculation")
+			return statsErr
+		}
+	} else {
 		// Perform the stats calculation
 		statsErr := h.Service.ProcessStakingStatsCalculation(
 			ctx, statsEvent.StakingTxHashHex,
@@ -26,12 +26,17 @@ func (h *V1QueueHandler) UnbondingStakingHandler(ctx context.Context, messageBod
 		return delErr
 	}
 	state := del.State
-	if utils.Contains(utils.OutdatedStatesForUnbonding(), state) {
+	if types.IsOutdatedTransition(state, types.Unbonding) {
 		// Ignore the message as the delegation state already passed the unbonding state. This is an outdated duplication
 		log.Ctx(ctx).Debug().Str("StakingTxHashHex", unbondingStakingEvent.StakingTxHashHex).
 			Msg("delegation state is outdated for unbonding event")
 		return nil
 	}
+	if !types.CanTransition(state, types.Unbonding) {
+		return utils.InvalidTransitionError(
+			ctx, unbondingStakingEvent.StakingTxHashHex, state, types.Unbonding,
+		)
+	}
 
 	expireCheckErr := h.Service.ProcessExpireCheck(
 		ctx, unbondingStakingEvent.StakingTxHashHex,
@@ -65,7 +70,8 @@ func (h *V1QueueHandler) UnbondingStakingHandler(ctx context.Context, messageBod
 	transitionErr := h.Service.TransitionToUnbondingState(
 		ctx, unbondingStakingEvent.StakingTxHashHex, unbondingStakingEvent.UnbondingStartHeight,
 		unbondingStakingEvent.UnbondingTimeLock, unbondingStakingEvent.UnbondingOutputIndex,
-		unbondingStakingEvent.UnbondingTxHex, unbondingStakingEvent.UnbondingStartTimestamp,
+		unbondingStakingEvent.UnbondingTxHex, unbondingStakingEvent.UnbondingTxHashHex,
+		unbondingStakingEvent.UnbondingStartTimestamp,
 	)
 	if transitionErr != nil {
 		return transitionErr
@@ -7,12 +7,13 @@ import (
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
-	queueClient "github.com/babylonlabs-io/staking-queue-client/client"
+	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1queueschema "github.com/babylonlabs-io/staking-api-service/internal/v1/queue/schema"
 	"github.com/rs/zerolog/log"
 )
 
 func (h *V1QueueHandler) WithdrawStakingHandler(ctx context.Context, messageBody string) *types.Error {
-	var withdrawnStakingEvent queueClient.WithdrawStakingEvent
+	var withdrawnStakingEvent v1queueschema.WithdrawStakingEvent
 	err := json.Unmarshal([]byte(messageBody), &withdrawnStakingEvent)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to unmarshal the message body into withdrawnStakingEvent")
@@ -29,7 +30,7 @@ func (h *V1QueueHandler) WithdrawStakingHandler(ctx context.Context, messageBody
 
 	stakingTxHashHex := withdrawnStakingEvent.GetStakingTxHashHex()
 
-	if utils.Contains(utils.OutdatedStatesForWithdraw(), state) {
+	if types.IsOutdatedTransition(state, types.Withdrawn) {
 		// Ignore the message as the delegation state is withdrawn. Nothing to do anymore
 		log.Ctx(ctx).Debug().Str("stakingTxHashHex", stakingTxHashHex).
 			Msg("delegation state is outdated for withdrawn event")
@@ -37,17 +38,24 @@ func (h *V1QueueHandler) WithdrawStakingHandler(ctx context.Context, messageBody
 	}
 	// Requeue if the current state is not in the qualified states to transition to withdrawn
 	// We will wait for the unbonded message to be processed first.
-	if !utils.Contains(utils.QualifiedStatesToWithdraw(), state) {
-		errMsg := "delegation is not in the qualified state to transition to withdrawn"
-		log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).
-			Str("state", state.ToString()).Msg(errMsg)
-		return types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, errMsg)
+	if !types.CanTransition(state, types.Withdrawn) {
+		return utils.InvalidTransitionError(ctx, stakingTxHashHex, state, types.Withdrawn)
+	}
+
+	var withdrawalTx *v1model.WithdrawalTransaction
+	if withdrawnStakingEvent.HasWithdrawalTx() {
+		withdrawalTx = &v1model.WithdrawalTransaction{
+			TxHashHex:      withdrawnStakingEvent.WithdrawalTxHashHex,
+			TxHex:          withdrawnStakingEvent.WithdrawalTxHex,
+			OutputIndex:    withdrawnStakingEvent.WithdrawalOutputIndex,
+			StartTimestamp: withdrawnStakingEvent.WithdrawalStartTimestamp,
+		}
 	}
 
 	// Transition to withdrawn state
 	// Please refer to the README.md for the details on the event processing workflow
 	transitionErr := h.Service.TransitionToWithdrawnState(
-		ctx, withdrawnStakingEvent.StakingTxHashHex,
+		ctx, withdrawnStakingEvent.StakingTxHashHex, withdrawalTx,
 	)
 	if transitionErr != nil {
 		return transitionErr
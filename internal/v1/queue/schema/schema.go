@@ -0,0 +1,95 @@
+// temp implementation of events
+// TODO: add events to github.com/babylonlabs-io/staking-queue-client
+package v1queueschema
+
+import (
+	queueClient "github.com/babylonlabs-io/staking-queue-client/client"
+)
+
+const (
+	BtcReorgQueueName string = "btc_reorg_queue"
+)
+
+const (
+	BtcReorgEventType queueClient.EventType = 8
+)
+
+// Event schema versions, only increment when the schema changes
+const (
+	BtcReorgEventVersion        int = 0
+	WithdrawStakingEventVersion int = 1
+)
+
+// BtcReorgEvent is emitted by the indexer when a BTC block that was previously
+// processed is no longer part of the canonical chain. The event carries the
+// staking transactions that were included in the reorged block.
+type BtcReorgEvent struct {
+	SchemaVersion      int                   `json:"schema_version"`
+	EventType          queueClient.EventType `json:"event_type"` // always 8. BtcReorgEventType
+	BlockHash          string                `json:"block_hash"`
+	BlockHeight        uint64                `json:"block_height"`
+	StakingTxHashHexes []string              `json:"staking_tx_hash_hexes"`
+}
+
+func (e BtcReorgEvent) GetEventType() queueClient.EventType {
+	return BtcReorgEventType
+}
+
+// GetStakingTxHashHex returns an empty string as the reorg event is not bound
+// to a single staking transaction.
+func (e BtcReorgEvent) GetStakingTxHashHex() string {
+	return ""
+}
+
+func NewBtcReorgEvent(
+	blockHash string, blockHeight uint64, stakingTxHashHexes []string,
+) BtcReorgEvent {
+	return BtcReorgEvent{
+		SchemaVersion:      BtcReorgEventVersion,
+		EventType:          BtcReorgEventType,
+		BlockHash:          blockHash,
+		BlockHeight:        blockHeight,
+		StakingTxHashHexes: stakingTxHashHexes,
+	}
+}
+
+// WithdrawStakingEvent extends the queue client withdraw event with the
+// withdrawal tx details. The withdrawal fields are not set by the producers
+// still emitting the version 0 of the event.
+type WithdrawStakingEvent struct {
+	SchemaVersion            int                   `json:"schema_version"`
+	EventType                queueClient.EventType `json:"event_type"` // always 3. WithdrawStakingEventType
+	StakingTxHashHex         string                `json:"staking_tx_hash_hex"`
+	WithdrawalTxHashHex      string                `json:"withdrawal_tx_hash_hex,omitempty"`
+	WithdrawalTxHex          string                `json:"withdrawal_tx_hex,omitempty"`
+	WithdrawalOutputIndex    uint64                `json:"withdrawal_output_index,omitempty"`
+	WithdrawalStartTimestamp int64                 `json:"withdrawal_start_timestamp,omitempty"`
+}
+
+func (e WithdrawStakingEvent) GetEventType() queueClient.EventType {
+	return queueClient.WithdrawStakingEventType
+}
+
+func (e WithdrawStakingEvent) GetStakingTxHashHex() string {
+	return e.StakingTxHashHex
+}
+
+// HasWithdrawalTx returns true if the event carries the withdrawal tx details
+func (e WithdrawStakingEvent) HasWithdrawalTx() bool {
+	return e.WithdrawalTxHashHex != ""
+}
+
+func NewWithdrawStakingEvent(
+	stakingTxHashHex, withdrawalTxHashHex, withdrawalTxHex string,
+	withdrawalOutputIndex uint64, withdrawalStartTimestamp int64,
+) WithdrawStakingEvent {
+	return WithdrawStakingEvent{
+		SchemaVersion:            WithdrawStakingEventVersion,
+		EventType:                queueClient.WithdrawStakingEventType,
+		StakingTxHashHex:         stakingTxHashHex,
+		WithdrawalTxHashHex:      withdrawalTxHashHex,
+		WithdrawalTxHex:          withdrawalTxHex,
+		WithdrawalOutputIndex:    withdrawalOutputIndex,
+		WithdrawalStartTimestamp: withdrawalStartTimestamp,
+	}
+}
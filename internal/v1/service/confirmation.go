@@ -0,0 +1,104 @@
+package v1service
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// GetBtcTipHeight returns the latest BTC tip height received from the btc info
+// events. It returns 0 if the tip height is not known yet.
+func (s *V1Service) GetBtcTipHeight(ctx context.Context) (uint64, *types.Error) {
+	btcInfo, err := s.Service.DbClients.V1DBClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return 0, nil
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		return 0, types.NewInternalServiceError(err)
+	}
+	return btcInfo.BtcHeight, nil
+}
+
+// GetConfirmations returns the number of confirmations of a tx included at the
+// given height. It returns 0 if the BTC tip height is not known yet or is below
+// the given height.
+func GetConfirmations(height, btcTipHeight uint64) uint64 {
+	if btcTipHeight == 0 || btcTipHeight < height {
+		return 0
+	}
+	return btcTipHeight - height + 1
+}
+
+// stakingStateByConfirmations returns the initial state of a staking delegation.
+// The delegation stays pending until the staking tx reaches the confirmation depth
+// defined in the global params. If the BTC tip height is not known yet, the
+// delegation is considered active.
+func (s *V1Service) stakingStateByConfirmations(
+	ctx context.Context, startHeight uint64,
+) (types.DelegationState, *types.Error) {
+	btcTipHeight, err := s.GetBtcTipHeight(ctx)
+	if err != nil {
+		return "", err
+	}
+	if btcTipHeight == 0 {
+		return types.Active, nil
+	}
+	params := s.GetVersionedGlobalParamsByHeight(startHeight)
+	if params == nil {
+		return types.Active, nil
+	}
+	if GetConfirmations(startHeight, btcTipHeight) < params.ConfirmationDepth {
+		return types.Pending, nil
+	}
+	return types.Active, nil
+}
+
+// TransitionConfirmedDelegationsToActive transitions all pending delegations
+// whose staking tx has reached the confirmation depth at the given BTC tip
+// height to active. The confirmation depth is taken from the global params
+// version that the staking tx falls into.
+func (s *V1Service) TransitionConfirmedDelegationsToActive(
+	ctx context.Context, btcTipHeight uint64,
+) *types.Error {
+	versions := s.Service.Params.Versions
+	for i, version := range versions {
+		// The staking tx is confirmed if
+		// btcTipHeight - startHeight + 1 >= confirmationDepth
+		if btcTipHeight+1 < version.ConfirmationDepth {
+			continue
+		}
+		confirmedHeight := btcTipHeight + 1 - version.ConfirmationDepth
+		fromHeight := version.ActivationHeight
+		if i == 0 {
+			fromHeight = 0
+		}
+		toHeight := confirmedHeight
+		if i+1 < len(versions) && versions[i+1].ActivationHeight-1 < toHeight {
+			toHeight = versions[i+1].ActivationHeight - 1
+		}
+		if toHeight < fromHeight {
+			continue
+		}
+		stakingTxHashHexes, err := s.Service.DbClients.V1DBClient.TransitionPendingToActiveState(
+			ctx, fromHeight, toHeight,
+		)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Uint64("btcTipHeight", btcTipHeight).
+				Msg("error while transitioning pending delegations to active")
+			return types.NewInternalServiceError(err)
+		}
+		if len(stakingTxHashHexes) > 0 {
+			log.Ctx(ctx).Debug().Int("count", len(stakingTxHashHexes)).Uint64("btcTipHeight", btcTipHeight).
+				Msg("transitioned pending delegations to active")
+		}
+		occurredAt := s.Service.Clock.Now()
+		for _, stakingTxHashHex := range stakingTxHashHexes {
+			service.NotifyTransition(ctx, stakingTxHashHex, types.Active, occurredAt)
+		}
+	}
+	return nil
+}
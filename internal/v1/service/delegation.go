@@ -3,8 +3,10 @@ package v1service
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
 	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
@@ -13,25 +15,46 @@ import (
 )
 
 type TransactionPublic struct {
-	TxHex          string `json:"tx_hex"`
-	OutputIndex    uint64 `json:"output_index"`
-	StartTimestamp string `json:"start_timestamp"`
-	StartHeight    uint64 `json:"start_height"`
-	TimeLock       uint64 `json:"timelock"`
+	TxHex       string `json:"tx_hex"`
+	OutputIndex uint64 `json:"output_index"`
+	// StartTimestamp is formatted as requested by the timestamp_format query
+	StartTimestamp utils.Timestamp `json:"start_timestamp"`
+	// Deprecated: StartTimestampUnix is only kept for the integrations parsing
+	// the unix timestamps, which should request timestamp_format=unix instead
+	StartTimestampUnix int64  `json:"start_timestamp_unix"`
+	StartHeight        uint64 `json:"start_height"`
+	TimeLock           uint64 `json:"timelock"`
+}
+
+type WithdrawalTransactionPublic struct {
+	TxHashHex   string `json:"tx_hash_hex"`
+	TxHex       string `json:"tx_hex"`
+	OutputIndex uint64 `json:"output_index"`
+	// StartTimestamp is formatted as requested by the timestamp_format query
+	StartTimestamp utils.Timestamp `json:"start_timestamp"`
+	// Deprecated: StartTimestampUnix is only kept for the integrations parsing
+	// the unix timestamps, which should request timestamp_format=unix instead
+	StartTimestampUnix int64 `json:"start_timestamp_unix"`
 }
 
 type DelegationPublic struct {
-	StakingTxHashHex      string             `json:"staking_tx_hash_hex"`
-	StakerPkHex           string             `json:"staker_pk_hex"`
-	FinalityProviderPkHex string             `json:"finality_provider_pk_hex"`
-	State                 string             `json:"state"`
-	StakingValue          uint64             `json:"staking_value"`
-	StakingTx             *TransactionPublic `json:"staking_tx"`
-	UnbondingTx           *TransactionPublic `json:"unbonding_tx,omitempty"`
-	IsOverflow            bool               `json:"is_overflow"`
+	StakingTxHashHex      string                       `json:"staking_tx_hash_hex"`
+	StakerPkHex           string                       `json:"staker_pk_hex"`
+	FinalityProviderPkHex string                       `json:"finality_provider_pk_hex"`
+	State                 string                       `json:"state"`
+	StakingValue          uint64                       `json:"staking_value"`
+	StakingTx             *TransactionPublic           `json:"staking_tx"`
+	UnbondingTx           *TransactionPublic           `json:"unbonding_tx,omitempty"`
+	WithdrawalTx          *WithdrawalTransactionPublic `json:"withdrawal_tx,omitempty"`
+	IsOverflow            bool                         `json:"is_overflow"`
+	Confirmations         uint64                       `json:"confirmations"`
+	PartnerId             string                       `json:"partner_id,omitempty"`
 }
 
-func FromDelegationDocument(d *v1model.DelegationDocument) DelegationPublic {
+// FromDelegationDocument converts the delegation document into the public
+// representation. The BTC tip height is used to calculate the number of
+// confirmations of the staking tx, 0 means the tip height is not known.
+func FromDelegationDocument(d *v1model.DelegationDocument, btcTipHeight uint64) DelegationPublic {
 	delPublic := DelegationPublic{
 		StakingTxHashHex:      d.StakingTxHashHex,
 		StakerPkHex:           d.StakerPkHex,
@@ -39,39 +62,80 @@ func FromDelegationDocument(d *v1model.DelegationDocument) DelegationPublic {
 		StakingValue:          d.StakingValue,
 		State:                 d.State.ToString(),
 		StakingTx: &TransactionPublic{
-			TxHex:          d.StakingTx.TxHex,
-			OutputIndex:    d.StakingTx.OutputIndex,
-			StartTimestamp: utils.ParseTimestampToIsoFormat(d.StakingTx.StartTimestamp),
-			StartHeight:    d.StakingTx.StartHeight,
-			TimeLock:       d.StakingTx.TimeLock,
+			TxHex:              d.StakingTx.TxHex,
+			OutputIndex:        d.StakingTx.OutputIndex,
+			StartTimestamp:     utils.Timestamp{Unix: d.StakingTx.StartTimestamp},
+			StartTimestampUnix: d.StakingTx.StartTimestamp,
+			StartHeight:        d.StakingTx.StartHeight,
+			TimeLock:           d.StakingTx.TimeLock,
 		},
-		IsOverflow: d.IsOverflow,
+		IsOverflow:    d.IsOverflow,
+		Confirmations: GetConfirmations(d.StakingTx.StartHeight, btcTipHeight),
+		PartnerId:     d.PartnerId,
 	}
 
 	// Add unbonding transaction if it exists
 	if d.UnbondingTx != nil && d.UnbondingTx.TxHex != "" {
 		delPublic.UnbondingTx = &TransactionPublic{
-			TxHex:          d.UnbondingTx.TxHex,
-			OutputIndex:    d.UnbondingTx.OutputIndex,
-			StartTimestamp: utils.ParseTimestampToIsoFormat(d.UnbondingTx.StartTimestamp),
-			StartHeight:    d.UnbondingTx.StartHeight,
-			TimeLock:       d.UnbondingTx.TimeLock,
+			TxHex:              d.UnbondingTx.TxHex,
+			OutputIndex:        d.UnbondingTx.OutputIndex,
+			StartTimestamp:     utils.Timestamp{Unix: d.UnbondingTx.StartTimestamp},
+			StartTimestampUnix: d.UnbondingTx.StartTimestamp,
+			StartHeight:        d.UnbondingTx.StartHeight,
+			TimeLock:           d.UnbondingTx.TimeLock,
+		}
+	}
+	if d.WithdrawalTx != nil {
+		delPublic.WithdrawalTx = &WithdrawalTransactionPublic{
+			TxHashHex:          d.WithdrawalTx.TxHashHex,
+			TxHex:              d.WithdrawalTx.TxHex,
+			OutputIndex:        d.WithdrawalTx.OutputIndex,
+			StartTimestamp:     utils.Timestamp{Unix: d.WithdrawalTx.StartTimestamp},
+			StartTimestampUnix: d.WithdrawalTx.StartTimestamp,
 		}
 	}
 	return delPublic
 }
 
+// FormatTimestamps formats the start timestamps of the transactions of the
+// delegation, which are formatted as ISO8601 by FromDelegationDocument
+func (d *DelegationPublic) FormatTimestamps(format utils.TimestampFormat) {
+	if d.StakingTx != nil {
+		d.StakingTx.StartTimestamp.Format = format
+	}
+	if d.UnbondingTx != nil {
+		d.UnbondingTx.StartTimestamp.Format = format
+	}
+	if d.WithdrawalTx != nil {
+		d.WithdrawalTx.StartTimestamp.Format = format
+	}
+}
+
+// delegationDocumentFields maps the public delegation fields to the document
+// fields they are derived from
+var delegationDocumentFields = map[string][]string{
+	"staking_tx_hash_hex":      {"_id"},
+	"staker_pk_hex":            {"staker_pk_hex"},
+	"finality_provider_pk_hex": {"finality_provider_pk_hex"},
+	"state":                    {"state"},
+	"staking_value":            {"staking_value"},
+	"staking_tx":               {"staking_tx"},
+	"unbonding_tx":             {"unbonding_tx"},
+	"withdrawal_tx":            {"withdrawal_tx"},
+	"is_overflow":              {"is_overflow"},
+	"confirmations":            {"staking_tx.start_height"},
+	"partner_id":               {"partner_id", "encrypted_partner_id"},
+}
+
+// DelegationsByStakerPk fetches the delegations of the staker. If fields are
+// given, only the document fields they are derived from are fetched and the
+// other public fields are left as zero values.
 func (s *V1Service) DelegationsByStakerPk(
 	ctx context.Context, stakerPk string,
-	state types.DelegationState, pageToken string,
+	state types.DelegationState, rangeFilter *types.DelegationRangeFilter,
+	fields []string, pageToken string,
 ) ([]DelegationPublic, string, *types.Error) {
-	filter := &v1dbclient.DelegationFilter{}
-	if state != "" {
-		filter = &v1dbclient.DelegationFilter{
-			States: []types.DelegationState{state},
-		}
-	}
-
+	filter := buildDelegationFilter(state, rangeFilter, fields)
 	resultMap, err := s.Service.DbClients.V1DBClient.FindDelegationsByStakerPk(ctx, stakerPk, filter, pageToken)
 	if err != nil {
 		if db.IsInvalidPaginationTokenError(err) {
@@ -81,22 +145,121 @@ func (s *V1Service) DelegationsByStakerPk(
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations by staker pk")
 		return nil, "", types.NewInternalServiceError(err)
 	}
+	btcTipHeight, tipErr := s.GetBtcTipHeight(ctx)
+	if tipErr != nil {
+		return nil, "", tipErr
+	}
 	var delegations []DelegationPublic = make([]DelegationPublic, 0, len(resultMap.Data))
 	for _, d := range resultMap.Data {
-		delegations = append(delegations, FromDelegationDocument(&d))
+		delegations = append(delegations, FromDelegationDocument(&d, btcTipHeight))
 	}
 	return delegations, resultMap.PaginationToken, nil
 }
 
+// CountDelegationsByStakerPk returns the total of the delegations of the
+// staker matching the filters of DelegationsByStakerPk. The counter of the
+// staker stats is served for the unfiltered delegations if the approximate
+// counts are enabled.
+func (s *V1Service) CountDelegationsByStakerPk(
+	ctx context.Context, stakerPk string,
+	state types.DelegationState, rangeFilter *types.DelegationRangeFilter,
+) (*types.TotalCount, *types.Error) {
+	unfiltered := state == "" && (rangeFilter == nil || *rangeFilter == types.DelegationRangeFilter{})
+	if unfiltered && s.Service.ApproximateTotalCounts() {
+		stats, err := s.GetStakerStats(ctx, stakerPk)
+		if err != nil {
+			return nil, err
+		}
+		total := &types.TotalCount{Approximate: true}
+		if stats != nil {
+			total.Count = stats.TotalDelegations
+		}
+		return total, nil
+	}
+
+	count, err := s.Service.DbClients.V1DBClient.CountDelegationsByStakerPk(
+		ctx, stakerPk, buildDelegationFilter(state, rangeFilter, nil),
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count delegations by staker pk")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &types.TotalCount{Count: count}, nil
+}
+
+// StreamDelegationsByStakerPk calls fn with each delegation of the staker as
+// they are read from the database, with the same filters as
+// DelegationsByStakerPk but without paginating the results.
+func (s *V1Service) StreamDelegationsByStakerPk(
+	ctx context.Context, stakerPk string,
+	state types.DelegationState, rangeFilter *types.DelegationRangeFilter,
+	fields []string, pageToken string, fn func(delegation DelegationPublic) error,
+) *types.Error {
+	btcTipHeight, tipErr := s.GetBtcTipHeight(ctx)
+	if tipErr != nil {
+		return tipErr
+	}
+	filter := buildDelegationFilter(state, rangeFilter, fields)
+	err := s.Service.DbClients.V1DBClient.StreamDelegationsByStakerPk(
+		ctx, stakerPk, filter, pageToken,
+		func(d *v1model.DelegationDocument) error {
+			return fn(FromDelegationDocument(d, btcTipHeight))
+		},
+	)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when streaming delegations by staker pk")
+			return types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to stream delegations by staker pk")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+func buildDelegationFilter(
+	state types.DelegationState, rangeFilter *types.DelegationRangeFilter, fields []string,
+) *v1dbclient.DelegationFilter {
+	filter := &v1dbclient.DelegationFilter{}
+	for _, field := range fields {
+		filter.Fields = append(filter.Fields, delegationDocumentFields[field]...)
+	}
+	if state != "" {
+		filter.States = []types.DelegationState{state}
+	}
+	if rangeFilter != nil {
+		filter.MinStakingValue = rangeFilter.MinValue
+		filter.MaxStakingValue = rangeFilter.MaxValue
+		filter.AfterTimestamp = rangeFilter.FromTimestamp
+		filter.BeforeTimestamp = rangeFilter.ToTimestamp
+		filter.IncludeArchived = rangeFilter.IncludeArchived
+	}
+	return filter
+}
+
 // SaveActiveStakingDelegation saves the active staking delegation to the database.
+// The delegation is saved as pending if the staking tx has not reached the
+// confirmation depth yet, it will be transitioned to active once the BTC tip
+// height catches up.
 func (s *V1Service) SaveActiveStakingDelegation(
 	ctx context.Context, txHashHex, stakerPkHex, finalityProviderPkHex string,
 	value, startHeight uint64, stakingTimestamp int64, timeLock, stakingOutputIndex uint64,
 	stakingTxHex string, isOverflow bool,
 ) *types.Error {
+	state, stateErr := s.stakingStateByConfirmations(ctx, startHeight)
+	if stateErr != nil {
+		return stateErr
+	}
+	// The delegation is indexed even if it violates the staker policy. The
+	// violations are only flagged once the delegation is saved so that the
+	// redelivered events don't count them again.
+	policyChecks, policyErr := s.checkStakerPolicy(ctx, stakerPkHex, value, stakingTimestamp)
+	if policyErr != nil {
+		return policyErr
+	}
 	err := s.Service.DbClients.V1DBClient.SaveActiveStakingDelegation(
 		ctx, txHashHex, stakerPkHex, finalityProviderPkHex, stakingTxHex,
-		value, startHeight, timeLock, stakingOutputIndex, stakingTimestamp, isOverflow,
+		value, startHeight, timeLock, stakingOutputIndex, stakingTimestamp, isOverflow, state,
 	)
 	if err != nil {
 		if ok := db.IsDuplicateKeyError(err); ok {
@@ -106,23 +269,51 @@ func (s *V1Service) SaveActiveStakingDelegation(
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to save active staking delegation")
 		return types.NewInternalServiceError(err)
 	}
+	s.flagStakerPolicyViolations(ctx, txHashHex, stakerPkHex, policyChecks)
+	s.recordMilestone(ctx, txHashHex, v1model.MilestoneStaked, txHashHex, startHeight, stakingTimestamp)
+	service.NotifyTransition(ctx, txHashHex, state, s.Service.Clock.Now())
 	return nil
 }
 
 func (s *V1Service) IsDelegationPresent(ctx context.Context, txHashHex string) (bool, *types.Error) {
-	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, txHashHex)
+	exists, err := s.Service.DbClients.V1DBClient.DelegationExists(ctx, txHashHex)
 	if err != nil {
-		if db.IsNotFoundError(err) {
-			return false, nil
-		}
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegation by tx hash hex")
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to check the existence of the delegation")
 		return false, types.NewInternalServiceError(err)
 	}
-	if delegation != nil {
+	if exists {
 		return true, nil
 	}
+	return s.isDelegationArchived(ctx, txHashHex)
+}
+
+// isDelegationArchived checks the archive collection so that the replayed
+// events of an archived delegation don't save it again
+func (s *V1Service) isDelegationArchived(ctx context.Context, txHashHex string) (bool, *types.Error) {
+	if s.Service.Cfg.DelegationArchive == nil {
+		return false, nil
+	}
+	archived, err := s.Service.DbClients.V1DBClient.ArchivedDelegationExists(ctx, txHashHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to check whether the delegation is archived")
+		return false, types.NewInternalServiceError(err)
+	}
+	return archived, nil
+}
 
-	return false, nil
+// DelegationExists checks whether the delegation exists, archived or not, it's
+// cheaper than fetching the delegation for the existence polling
+func (s *V1Service) DelegationExists(ctx context.Context, stakingTxHashHex string) (bool, *types.Error) {
+	exists, err := s.Service.DbClients.V1DBClient.DelegationExists(ctx, stakingTxHashHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("Failed to check the existence of the delegation")
+		return false, types.NewInternalServiceError(err)
+	}
+	if exists {
+		return true, nil
+	}
+	return s.isDelegationArchived(ctx, stakingTxHashHex)
 }
 
 func (s *V1Service) GetDelegation(ctx context.Context, txHashHex string) (*v1model.DelegationDocument, *types.Error) {
@@ -138,6 +329,55 @@ func (s *V1Service) GetDelegation(ctx context.Context, txHashHex string) (*v1mod
 	return delegation, nil
 }
 
+// WaitForDelegationState holds until the delegation is in the state or the
+// timeout elapses, the delegation is returned as of then. The delegation not
+// existing yet is waited for as well. The request is rejected if the max
+// number of requests are already waiting.
+func (s *V1Service) WaitForDelegationState(
+	ctx context.Context, txHashHex string, state types.DelegationState, timeout time.Duration,
+) (*v1model.DelegationDocument, *types.Error) {
+	select {
+	case s.longPollWaiters <- struct{}{}:
+		defer func() { <-s.longPollWaiters }()
+	default:
+		log.Ctx(ctx).Warn().Str("stakingTxHash", txHashHex).Msg("Too many requests waiting for a delegation state")
+		return nil, types.NewErrorWithMsg(
+			http.StatusServiceUnavailable, types.ServiceUnavailable,
+			"too many requests are waiting for a delegation state, please retry later",
+		)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	delegation, err := s.Service.DbClients.V1DBClient.WaitForDelegationState(
+		waitCtx, txHashHex, []types.DelegationState{state},
+	)
+	if err == nil {
+		return delegation, nil
+	}
+	// The delegation is read again once the timeout fires, unless the
+	// request itself is done
+	if waitCtx.Err() == nil || ctx.Err() != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHash", txHashHex).Msg("Failed to wait for the delegation state")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return s.GetDelegation(ctx, txHashHex)
+}
+
+// GetDelegationByAnyTxHash resolves the staking, unbonding or withdrawal tx
+// hash to the delegation it belongs to.
+func (s *V1Service) GetDelegationByAnyTxHash(ctx context.Context, txHashHex string) (*v1model.DelegationDocument, *types.Error) {
+	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByAnyTxHashHex(ctx, txHashHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Str("txHash", txHashHex).Msg("Delegation not found by tx hash")
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "delegation not found for the given tx hash")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegation by any tx hash hex")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return delegation, nil
+}
+
 func (s *V1Service) CheckStakerHasActiveDelegationByPk(
 	ctx context.Context, stakerPk string, afterTimestamp int64,
 ) (bool, *types.Error) {
@@ -0,0 +1,55 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// ArchiveDelegations moves the delegations which reached a terminal state
+// longer than the configured min age ago into the archive collection, one
+// batch at a time until none is left. It returns the number of delegations
+// archived.
+func (s *V1Service) ArchiveDelegations(ctx context.Context) (int64, *types.Error) {
+	cfg := s.Service.Cfg.DelegationArchive
+	if cfg == nil {
+		return 0, nil
+	}
+	// The delegations in a terminal state are no longer updated, so the last
+	// update is when the terminal state was reached
+	updatedBefore := s.Service.Clock.Now().Add(-cfg.MinAge).Unix()
+	var total int64
+	for ctx.Err() == nil {
+		archived, err := s.Service.DbClients.V1DBClient.ArchiveDelegations(
+			ctx, types.TerminalStates(), updatedBefore, cfg.BatchSize,
+		)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("archived", total).Msg("error while archiving delegations")
+			return total, types.NewInternalServiceError(err)
+		}
+		total += archived
+		if archived < cfg.BatchSize {
+			break
+		}
+	}
+	return total, nil
+}
+
+// GetArchivedDelegation fetches the delegation from the archive collection
+func (s *V1Service) GetArchivedDelegation(
+	ctx context.Context, txHashHex string,
+) (*v1model.DelegationDocument, *types.Error) {
+	delegation, err := s.Service.DbClients.V1DBClient.FindArchivedDelegationByTxHashHex(ctx, txHashHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "staking delegation not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find archived delegation by tx hash hex")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return delegation, nil
+}
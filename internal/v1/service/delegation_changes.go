@@ -0,0 +1,53 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type DelegationChangePublic struct {
+	DelegationPublic
+	ChangeSeq int64  `json:"change_seq"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// Removed is set if the delegation has been rolled back by a BTC reorg
+	Removed bool `json:"removed,omitempty"`
+}
+
+// GetDelegationChanges returns the delegations written or removed after the
+// cursor along with the cursor to poll the next changes from.
+func (s *V1Service) GetDelegationChanges(
+	ctx context.Context, cursor string,
+) ([]DelegationChangePublic, string, *types.Error) {
+	delegations, nextCursor, err := s.Service.DbClients.V1DBClient.FindDelegationChanges(ctx, cursor)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid cursor when fetching delegation changes")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegation changes")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	btcTipHeight, tipErr := s.GetBtcTipHeight(ctx)
+	if tipErr != nil {
+		return nil, "", tipErr
+	}
+
+	changes := make([]DelegationChangePublic, 0, len(delegations))
+	for _, d := range delegations {
+		change := DelegationChangePublic{
+			DelegationPublic: FromDelegationDocument(&d, btcTipHeight),
+			ChangeSeq:        d.ChangeSeq,
+			Removed:          d.Removed,
+		}
+		if d.UpdatedAt != 0 {
+			change.UpdatedAt = utils.ParseTimestampToIsoFormat(d.UpdatedAt)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nextCursor, nil
+}
@@ -0,0 +1,86 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+type DelegationLabelsPublic struct {
+	StakingTxHashHex string   `json:"staking_tx_hash_hex"`
+	Labels           []string `json:"labels"`
+	UpdatedAt        string   `json:"updated_at,omitempty"`
+}
+
+func fromDelegationLabelsDocument(d *v1dbmodel.DelegationLabelsDocument) DelegationLabelsPublic {
+	return DelegationLabelsPublic{
+		StakingTxHashHex: d.StakingTxHashHex,
+		Labels:           d.Labels,
+		UpdatedAt:        utils.ParseTimestampToIsoFormat(d.UpdatedAt),
+	}
+}
+
+// SetDelegationLabels replaces the private labels the api key has attached to
+// the delegation, the labels are removed if none is given. The archived
+// delegations can be labeled as well.
+func (s *V1Service) SetDelegationLabels(
+	ctx context.Context, apiKey, stakingTxHashHex string, labels []string,
+) (*DelegationLabelsPublic, *types.Error) {
+	exists, err := s.IsDelegationPresent(ctx, stakingTxHashHex)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "delegation not found")
+	}
+	if dbErr := s.Service.DbClients.V1DBClient.SetDelegationLabels(ctx, apiKey, stakingTxHashHex, labels); dbErr != nil {
+		log.Ctx(ctx).Error().Err(dbErr).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("error while setting the delegation labels")
+		return nil, types.NewInternalServiceError(dbErr)
+	}
+	return s.GetDelegationLabels(ctx, apiKey, stakingTxHashHex)
+}
+
+// GetDelegationLabels returns the labels the api key has attached to the
+// delegation, none if the delegation is not labeled by the api key
+func (s *V1Service) GetDelegationLabels(
+	ctx context.Context, apiKey, stakingTxHashHex string,
+) (*DelegationLabelsPublic, *types.Error) {
+	labels, err := s.Service.DbClients.V1DBClient.FindDelegationLabels(ctx, apiKey, stakingTxHashHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return &DelegationLabelsPublic{StakingTxHashHex: stakingTxHashHex, Labels: []string{}}, nil
+		}
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("error while fetching the delegation labels")
+		return nil, types.NewInternalServiceError(err)
+	}
+	labelsPublic := fromDelegationLabelsDocument(labels)
+	return &labelsPublic, nil
+}
+
+// GetDelegationsByLabel returns the delegations the api key has attached the
+// label to, ordered by staking tx hash
+func (s *V1Service) GetDelegationsByLabel(
+	ctx context.Context, apiKey, label, pageToken string,
+) ([]DelegationLabelsPublic, string, *types.Error) {
+	resultMap, err := s.Service.DbClients.V1DBClient.FindDelegationLabelsByLabel(ctx, apiKey, label, pageToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching the labeled delegations")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Str("label", label).Msg("error while fetching the labeled delegations")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	delegations := make([]DelegationLabelsPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		delegations = append(delegations, fromDelegationLabelsDocument(&d))
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
@@ -0,0 +1,31 @@
+package v1service
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// MigrateDelegations writes back the delegations stored at a previous schema
+// version at the current one, one batch at a time until none is left. It
+// returns the number of delegations migrated.
+func (s *V1Service) MigrateDelegations(ctx context.Context) (int64, *types.Error) {
+	cfg := s.Service.Cfg.DelegationMigration
+	if cfg == nil {
+		return 0, nil
+	}
+	var total int64
+	for ctx.Err() == nil {
+		migrated, err := s.Service.DbClients.V1DBClient.MigrateDelegations(ctx, cfg.BatchSize)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Int64("migrated", total).Msg("error while migrating delegations")
+			return total, types.NewInternalServiceError(err)
+		}
+		total += migrated
+		if migrated < cfg.BatchSize {
+			break
+		}
+	}
+	return total, nil
+}
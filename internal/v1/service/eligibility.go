@@ -0,0 +1,122 @@
+package v1service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	EligibilityCheckStakingCap = "staking_cap"
+	EligibilityCheckStaker     = "staker"
+)
+
+type StakingEligibilityPublic struct {
+	Eligible      bool                   `json:"eligible"`
+	ParamsVersion uint64                 `json:"params_version"`
+	Checks        []StakingTxCheckPublic `json:"checks"`
+}
+
+func (r *StakingEligibilityPublic) addCheck(name string, err error) {
+	check := StakingTxCheckPublic{Name: name, Passed: err == nil}
+	if err != nil {
+		check.Message = err.Error()
+		r.Eligible = false
+	}
+	r.Checks = append(r.Checks, check)
+}
+
+// CheckStakingEligibility simulates whether a staking of the given amount and
+// time by the staker to the finality provider would be accepted at the current
+// BTC tip height. The returned report contains the result of each check, the
+// staking is eligible only if all the checks passed.
+func (s *V1Service) CheckStakingEligibility(
+	ctx context.Context, stakerPkHex, fpPkHex string, stakingAmount, stakingTime uint64,
+) (*StakingEligibilityPublic, *types.Error) {
+	btcTipHeight, unconfirmedTvl := uint64(0), uint64(0)
+	btcInfo, err := s.Service.DbClients.V1DBClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if !db.IsNotFoundError(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+			return nil, types.NewInternalServiceError(err)
+		}
+	} else {
+		btcTipHeight = btcInfo.BtcHeight
+		unconfirmedTvl = btcInfo.UnconfirmedTvl
+	}
+	params := s.currentGlobalParams(btcTipHeight)
+	if params == nil {
+		log.Ctx(ctx).Error().Uint64("btcTipHeight", btcTipHeight).Msg("failed to get global params")
+		return nil, types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError,
+			"failed to get global params based on the btc tip height",
+		)
+	}
+
+	report := &StakingEligibilityPublic{
+		Eligible:      true,
+		ParamsVersion: params.Version,
+	}
+
+	var stakingTimeErr error
+	if stakingTime < params.MinStakingTime || stakingTime > params.MaxStakingTime {
+		stakingTimeErr = fmt.Errorf(
+			"staking time %d is out of range [%d, %d]",
+			stakingTime, params.MinStakingTime, params.MaxStakingTime,
+		)
+	}
+	report.addCheck(StakingTxCheckStakingTime, stakingTimeErr)
+
+	var stakingAmountErr error
+	if stakingAmount < params.MinStakingAmount || stakingAmount > params.MaxStakingAmount {
+		stakingAmountErr = fmt.Errorf(
+			"staking amount %d is out of range [%d, %d]",
+			stakingAmount, params.MinStakingAmount, params.MaxStakingAmount,
+		)
+	}
+	report.addCheck(StakingTxCheckStakingAmount, stakingAmountErr)
+
+	// The params either cap the staking by height or by the TVL. The TVL
+	// includes the unconfirmed stakings as they are counted against the cap
+	// before this one.
+	var capErr error
+	if params.CapHeight != 0 {
+		if btcTipHeight >= params.CapHeight {
+			capErr = fmt.Errorf("staking cap height %d has been reached", params.CapHeight)
+		}
+	} else if params.StakingCap != 0 && unconfirmedTvl+stakingAmount > params.StakingCap {
+		capErr = fmt.Errorf(
+			"staking amount %d exceeds the staking cap headroom %d",
+			stakingAmount, params.StakingCap-min(unconfirmedTvl, params.StakingCap),
+		)
+	}
+	report.addCheck(EligibilityCheckStakingCap, capErr)
+
+	var fpErr error
+	if !s.isFinalityProviderRegistered(fpPkHex) {
+		fpErr = fmt.Errorf("finality provider %s is not registered", fpPkHex)
+	}
+	report.addCheck(StakingTxCheckFinalityProvider, fpErr)
+
+	var stakerErr error
+	if stakerPkHex == fpPkHex {
+		stakerErr = fmt.Errorf("staker can not delegate to its own finality provider key")
+	}
+	report.addCheck(EligibilityCheckStaker, stakerErr)
+
+	policyChecks, policyErr := s.checkStakerPolicy(
+		ctx, stakerPkHex, stakingAmount, s.Service.Clock.Now().Unix(),
+	)
+	if policyErr != nil {
+		return nil, policyErr
+	}
+	for _, check := range policyChecks {
+		report.addCheck(check.rule, check.err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,87 @@
+package v1service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+type ErasurePublic struct {
+	Id                  string `json:"id,omitempty"`
+	PartnerAttributions int64  `json:"partner_attributions"`
+	AddressMappings     int64  `json:"address_mappings"`
+	ErasedAt            string `json:"erased_at,omitempty"`
+	// DryRun is set when the data have only been counted, not erased
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// EraseStakerData removes the off-chain data associated to the staker, which
+// are the partner attributions of its delegations and the mappings of its
+// btc addresses, and records the erasure. The on-chain data of the
+// delegations are kept. Erasing the data again is a no-op apart from the new
+// record.
+func (s *V1Service) EraseStakerData(
+	ctx context.Context, stakerPkHex, requestedBy string,
+) (*ErasurePublic, *types.Error) {
+	partnerAttributions, err := s.Service.DbClients.V1DBClient.RemoveStakerPartnerAttributions(ctx, stakerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while removing the partner attributions of the staker")
+		return nil, types.NewInternalServiceError(err)
+	}
+	addressMappings, err := s.Service.DbClients.SharedDBClient.DeletePkAddressMappings(ctx, stakerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while deleting the address mappings of the staker")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	stakerPkHash := sha256.Sum256([]byte(stakerPkHex))
+	record := &dbmodel.ErasureRecordDocument{
+		Id:                  uuid.NewString(),
+		StakerPkHash:        hex.EncodeToString(stakerPkHash[:]),
+		PartnerAttributions: partnerAttributions,
+		AddressMappings:     addressMappings,
+		RequestedBy:         requestedBy,
+		ErasedAt:            s.Service.Clock.Now(),
+	}
+	if err := s.Service.DbClients.SharedDBClient.InsertErasureRecord(ctx, record); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("id", record.Id).Msg("error while recording the erasure")
+		return nil, types.NewInternalServiceError(err)
+	}
+	log.Ctx(ctx).Warn().Str("id", record.Id).Int64("partnerAttributions", partnerAttributions).
+		Int64("addressMappings", addressMappings).Msg("staker data erased")
+
+	return &ErasurePublic{
+		Id:                  record.Id,
+		PartnerAttributions: partnerAttributions,
+		AddressMappings:     addressMappings,
+		ErasedAt:            record.ErasedAt.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// PreviewStakerDataErasure counts the off-chain data EraseStakerData would
+// remove for the staker, without removing nor recording anything
+func (s *V1Service) PreviewStakerDataErasure(
+	ctx context.Context, stakerPkHex string,
+) (*ErasurePublic, *types.Error) {
+	partnerAttributions, err := s.Service.DbClients.V1DBClient.CountStakerPartnerAttributions(ctx, stakerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while counting the partner attributions of the staker")
+		return nil, types.NewInternalServiceError(err)
+	}
+	addressMappings, err := s.Service.DbClients.SharedDBClient.CountPkAddressMappings(ctx, stakerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while counting the address mappings of the staker")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &ErasurePublic{
+		PartnerAttributions: partnerAttributions,
+		AddressMappings:     addressMappings,
+		DryRun:              true,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package v1service
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// ScanExpiredDelegations transitions to unbonded the delegations whose
+// timelock expired more than the configured grace blocks below the BTC tip,
+// as their expiry event should have been processed by then. It's a safety net
+// for the lost expiry events. It returns the number of delegations
+// transitioned per tx type, one batch being transitioned per scan.
+func (s *V1Service) ScanExpiredDelegations(ctx context.Context) (map[types.StakingTxType]int64, *types.Error) {
+	cfg := s.Service.Cfg.ExpiryScanner
+	if cfg == nil {
+		return nil, nil
+	}
+	btcTipHeight, err := s.GetBtcTipHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing can be expired before the tip is known
+	if btcTipHeight <= cfg.GraceBlocks {
+		return nil, nil
+	}
+	delegations, dbErr := s.Service.DbClients.V1DBClient.FindExpiredDelegations(
+		ctx, btcTipHeight-cfg.GraceBlocks, cfg.BatchSize,
+	)
+	if dbErr != nil {
+		log.Ctx(ctx).Error().Err(dbErr).Msg("error while finding the expired delegations")
+		return nil, types.NewInternalServiceError(dbErr)
+	}
+
+	transitioned := make(map[types.StakingTxType]int64)
+	for _, delegation := range delegations {
+		txType := types.ActiveTxType
+		if delegation.State == types.Unbonding {
+			txType = types.UnbondingTxType
+		}
+		// The delegation may have been transitioned by its expiry event in
+		// the meantime, the error is logged and the scan goes on
+		if err := s.TransitionToUnbondedState(ctx, txType, delegation.StakingTxHashHex); err != nil {
+			continue
+		}
+		log.Ctx(ctx).Warn().Str("stakingTxHashHex", delegation.StakingTxHashHex).Str("txType", txType.ToString()).
+			Msg("expired delegation transitioned to unbonded without its expiry event")
+		transitioned[txType]++
+	}
+	return transitioned, nil
+}
@@ -0,0 +1,273 @@
+package v1service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxExportJobAttempts bounds the number of times a job interrupted
+	// before completion, e.g. by a restart, is picked up again
+	maxExportJobAttempts = 3
+	// exportJobHeartbeats is the number of times the claim of a running job
+	// is renewed within the job timeout
+	exportJobHeartbeats = 3
+	exportContentType   = "application/x-ndjson"
+)
+
+type ExportJobPublic struct {
+	Id                    string                  `json:"id"`
+	Type                  dbmodel.ExportJobType   `json:"type"`
+	FinalityProviderPkHex string                  `json:"finality_provider_pk_hex,omitempty"`
+	Status                dbmodel.ExportJobStatus `json:"status"`
+	Error                 string                  `json:"error,omitempty"`
+	CreatedAt             string                  `json:"created_at"`
+	CompletedAt           string                  `json:"completed_at,omitempty"`
+	// DownloadUrl is only set once the job is completed, it expires at
+	// DownloadUrlExpiresAt and can be renewed by fetching the job again
+	DownloadUrl          string `json:"download_url,omitempty"`
+	DownloadUrlExpiresAt string `json:"download_url_expires_at,omitempty"`
+}
+
+// FinalityProviderStatsExport is a line of the finality providers stats
+// export
+type FinalityProviderStatsExport struct {
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	ActiveTvl             int64  `json:"active_tvl"`
+	TotalTvl              int64  `json:"total_tvl"`
+	ActiveDelegations     int64  `json:"active_delegations"`
+	TotalDelegations      int64  `json:"total_delegations"`
+}
+
+func fromExportJobDocument(job *dbmodel.ExportJobDocument) *ExportJobPublic {
+	public := &ExportJobPublic{
+		Id:                    job.Id,
+		Type:                  job.Type,
+		FinalityProviderPkHex: job.FinalityProviderPkHex,
+		Status:                job.Status,
+		Error:                 job.Error,
+		CreatedAt:             job.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if !job.CompletedAt.IsZero() {
+		public.CompletedAt = job.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	return public
+}
+
+// CreateExportJob queues an export of the given type, the file is written by
+// the export worker of any instance
+func (s *V1Service) CreateExportJob(
+	ctx context.Context, jobType dbmodel.ExportJobType, fpPkHex string,
+) (*ExportJobPublic, *types.Error) {
+	switch jobType {
+	case dbmodel.ExportFinalityProviderDelegations:
+		if fpPkHex == "" {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "finality_provider_pk_hex is required",
+			)
+		}
+		if _, err := utils.GetSchnorrPkFromHex(fpPkHex); err != nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid finality_provider_pk_hex",
+			)
+		}
+	case dbmodel.ExportFinalityProvidersStats:
+		if fpPkHex != "" {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "finality_provider_pk_hex is not supported by the export type",
+			)
+		}
+	default:
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid export type")
+	}
+
+	job := &dbmodel.ExportJobDocument{
+		Id:                    uuid.NewString(),
+		Type:                  jobType,
+		FinalityProviderPkHex: fpPkHex,
+		Status:                dbmodel.ExportJobPending,
+		CreatedAt:             s.Service.Clock.Now(),
+	}
+	if err := s.Service.DbClients.SharedDBClient.InsertExportJob(ctx, job); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while creating export job")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return fromExportJobDocument(job), nil
+}
+
+// GetExportJob returns the status of the job, along with a freshly signed
+// download url once the job is completed
+func (s *V1Service) GetExportJob(ctx context.Context, id string) (*ExportJobPublic, *types.Error) {
+	job, err := s.Service.DbClients.SharedDBClient.FindExportJob(ctx, id)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "export job not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Str("exportJobId", id).Msg("error while fetching export job")
+		return nil, types.NewInternalServiceError(err)
+	}
+	public := fromExportJobDocument(job)
+	if job.Status != dbmodel.ExportJobCompleted {
+		return public, nil
+	}
+
+	ttl := s.Service.Cfg.Exports.UrlTtl
+	url, err := s.Service.Clients.ObjectStore.SignedUrl(job.ObjectKey, ttl)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("exportJobId", id).Msg("error while signing export download url")
+		return nil, types.NewInternalServiceError(err)
+	}
+	public.DownloadUrl = url
+	public.DownloadUrlExpiresAt = s.Service.Clock.Now().Add(ttl).UTC().Format(time.RFC3339)
+	return public, nil
+}
+
+// ProcessNextExportJob runs the oldest pending export job, if any, and
+// returns whether a job has been picked up. The failures of the job are
+// recorded on the job rather than returned.
+func (s *V1Service) ProcessNextExportJob(ctx context.Context) (bool, *types.Error) {
+	sharedDb := s.Service.DbClients.SharedDBClient
+	now := s.Service.Clock.Now()
+	job, err := sharedDb.ClaimExportJob(ctx, now, now.Add(-s.Service.Cfg.Exports.JobTimeout))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while claiming export job")
+		return false, types.NewInternalServiceError(err)
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	var jobErr error
+	objectKey := path.Join(s.Service.Cfg.Exports.Prefix, job.Id+".ndjson")
+	if job.Attempts > maxExportJobAttempts {
+		jobErr = errors.New("export job interrupted too many times")
+	} else {
+		jobCtx, stopHeartbeat := s.heartbeatExportJob(ctx, job)
+		jobErr = s.writeExport(jobCtx, job, objectKey)
+		stopHeartbeat()
+	}
+
+	if jobErr != nil {
+		log.Ctx(ctx).Error().Err(jobErr).Str("exportJobId", job.Id).Msg("export job failed")
+		err = sharedDb.FailExportJob(ctx, job.Id, job.StartedAt, jobErr.Error(), s.Service.Clock.Now())
+	} else {
+		err = sharedDb.CompleteExportJob(ctx, job.Id, job.StartedAt, objectKey, s.Service.Clock.Now())
+	}
+	if err != nil {
+		// The job is picked up again once the job timeout has elapsed
+		log.Ctx(ctx).Error().Err(err).Str("exportJobId", job.Id).Msg("error while finishing export job")
+		return true, types.NewInternalServiceError(err)
+	}
+	return true, nil
+}
+
+// heartbeatExportJob renews the claim of the job until the returned function
+// is called, so that a job running longer than the job timeout is not picked
+// up again by another worker. The returned context is cancelled if the claim
+// has been lost in between.
+func (s *V1Service) heartbeatExportJob(
+	ctx context.Context, job *dbmodel.ExportJobDocument,
+) (context.Context, func()) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(s.Service.Cfg.Exports.JobTimeout / exportJobHeartbeats)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-jobCtx.Done():
+				return
+			case <-ticker.C:
+			}
+			renewed, err := s.Service.DbClients.SharedDBClient.RenewExportJobClaim(
+				jobCtx, job.Id, job.StartedAt, s.Service.Clock.Now(),
+			)
+			if err != nil {
+				if jobCtx.Err() == nil {
+					// Retried on the next tick, the claim only goes stale
+					// after the job timeout
+					log.Ctx(ctx).Warn().Err(err).Str("exportJobId", job.Id).
+						Msg("error while renewing the claim of export job")
+				}
+				continue
+			}
+			if !renewed {
+				log.Ctx(ctx).Warn().Str("exportJobId", job.Id).
+					Msg("export job has been claimed by another worker, stopping it")
+				cancel()
+				return
+			}
+		}
+	}()
+	return jobCtx, func() {
+		cancel()
+		<-stopped
+	}
+}
+
+// writeExport streams the export into the object store, the lines are
+// encoded as they are uploaded so that the export is never held in memory
+func (s *V1Service) writeExport(ctx context.Context, job *dbmodel.ExportJobDocument, objectKey string) error {
+	reader, writer := io.Pipe()
+	encoded := make(chan struct{})
+	go func() {
+		defer close(encoded)
+		writer.CloseWithError(s.encodeExport(ctx, job, json.NewEncoder(writer)))
+	}()
+	err := s.Service.Clients.ObjectStore.Upload(ctx, objectKey, reader, exportContentType)
+	// Unblocks the encoding if the upload has failed
+	reader.CloseWithError(err)
+	<-encoded
+	return err
+}
+
+func (s *V1Service) encodeExport(ctx context.Context, job *dbmodel.ExportJobDocument, encoder *json.Encoder) error {
+	switch job.Type {
+	case dbmodel.ExportFinalityProviderDelegations:
+		btcTipHeight, err := s.GetBtcTipHeight(ctx)
+		if err != nil {
+			return err.Err
+		}
+		return s.Service.DbClients.V1DBClient.StreamDelegationsByFinalityProviderPk(
+			ctx, job.FinalityProviderPkHex,
+			func(delegation *v1model.DelegationDocument) error {
+				return encoder.Encode(FromDelegationDocument(delegation, btcTipHeight))
+			},
+		)
+	case dbmodel.ExportFinalityProvidersStats:
+		// Only the current stats are stored, hence the export is a snapshot
+		stats, err := s.Service.DbClients.V1DBClient.FindAllFinalityProviderStats(ctx)
+		if err != nil {
+			return err
+		}
+		for _, stat := range stats {
+			err := encoder.Encode(FinalityProviderStatsExport{
+				FinalityProviderPkHex: stat.FinalityProviderPkHex,
+				ActiveTvl:             stat.ActiveTvl,
+				TotalTvl:              stat.TotalTvl,
+				ActiveDelegations:     stat.ActiveDelegations,
+				TotalDelegations:      stat.TotalDelegations,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errors.New("unsupported export type")
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
-	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 	"github.com/rs/zerolog/log"
 )
 
@@ -132,6 +131,70 @@ func (s *V1Service) GetFinalityProvider(
 	}, nil
 }
 
+// recentFpDelegationsLimit is the number of the latest delegations returned
+// along with the finality provider details
+const recentFpDelegationsLimit = 10
+
+type FpRegistryStatus string
+
+const (
+	// FpRegistered is the status of the finality providers in the global params
+	FpRegistered FpRegistryStatus = "registered"
+	// FpUnregistered is the status of the finality providers which have
+	// received delegations but are not in the global params
+	FpUnregistered FpRegistryStatus = "unregistered"
+)
+
+type FpDetailPublic struct {
+	*FpDetailsPublic
+	Status            FpRegistryStatus   `json:"status"`
+	RecentDelegations []DelegationPublic `json:"recent_delegations"`
+}
+
+// GetFinalityProviderDetail merges the registry data of the finality provider
+// with its stats and its latest delegations. It returns a not found error if
+// the finality provider is neither registered nor has any stats.
+func (s *V1Service) GetFinalityProviderDetail(
+	ctx context.Context, fpPkHex string,
+) (*FpDetailPublic, *types.Error) {
+	fp, err := s.GetFinalityProvider(ctx, fpPkHex)
+	if err != nil {
+		return nil, err
+	}
+	if fp == nil {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "finality provider not found")
+	}
+	status := FpUnregistered
+	for _, fpParams := range s.Service.FinalityProviders {
+		if fpParams.BtcPk == fpPkHex {
+			status = FpRegistered
+			break
+		}
+	}
+
+	delegations, dbErr := s.Service.DbClients.V1DBClient.FindRecentDelegationsByFinalityProviderPk(
+		ctx, fpPkHex, recentFpDelegationsLimit,
+	)
+	if dbErr != nil {
+		log.Ctx(ctx).Error().Err(dbErr).Msg("Failed to find recent delegations of finality provider")
+		return nil, types.NewInternalServiceError(dbErr)
+	}
+	btcTipHeight, err := s.GetBtcTipHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recentDelegations := make([]DelegationPublic, 0, len(delegations))
+	for _, d := range delegations {
+		recentDelegations = append(recentDelegations, FromDelegationDocument(&d, btcTipHeight))
+	}
+
+	return &FpDetailPublic{
+		FpDetailsPublic:   fp,
+		Status:            status,
+		RecentDelegations: recentDelegations,
+	}, nil
+}
+
 func (s *V1Service) GetFinalityProviders(ctx context.Context, page string) ([]*FpDetailsPublic, string, *types.Error) {
 	fpParams := s.GetFinalityProvidersFromGlobalParams()
 	if len(fpParams) == 0 {
@@ -213,19 +276,19 @@ func (s *V1Service) FindRegisteredFinalityProvidersNotInUse(
 	for _, fp := range fpParams {
 		finalityProvidersPkHex = append(finalityProvidersPkHex, fp.BtcPk)
 	}
-	fpStatsByPks, err := s.Service.DbClients.V1DBClient.FindFinalityProviderStatsByFinalityProviderPkHex(ctx, finalityProvidersPkHex)
+	fpPkHexesWithStats, err := s.Service.DbClients.V1DBClient.FindFinalityProviderPkHexesWithStats(ctx, finalityProvidersPkHex)
 	if err != nil {
 		return nil, err
 	}
-	fpStatsByPksMap := make(map[string]*v1model.FinalityProviderStatsDocument)
-	for _, fpStat := range fpStatsByPks {
-		fpStatsByPksMap[fpStat.FinalityProviderPkHex] = fpStat
+	fpPkHexesWithStatsMap := make(map[string]struct{}, len(fpPkHexesWithStats))
+	for _, fpPkHex := range fpPkHexesWithStats {
+		fpPkHexesWithStatsMap[fpPkHex] = struct{}{}
 	}
 
-	// Find the finality providers that are not in the fpStatsByPksMap
+	// Find the finality providers that are not in the fpPkHexesWithStatsMap
 	var fps []*FpDetailsPublic
 	for _, fp := range fpParams {
-		if fpStatsByPksMap[fp.BtcPk] == nil {
+		if _, ok := fpPkHexesWithStatsMap[fp.BtcPk]; !ok {
 			detail := &FpDetailsPublic{
 				Description:       fp.Description,
 				Commission:        fp.Commission,
@@ -257,3 +320,32 @@ func buildFallbackFpDetailsPublic(fpParams []*FpParamsPublic) []*FpDetailsPublic
 	}
 	return finalityProviderDetailsPublic
 }
+
+type FpDelegationCountPublic struct {
+	BtcPk             string `json:"btc_pk"`
+	ActiveDelegations int64  `json:"active_delegations"`
+	TotalDelegations  int64  `json:"total_delegations"`
+}
+
+// GetFinalityProviderDelegationCount returns the number of delegations of the
+// finality provider from its stats document, the delegation collection is not
+// counted. A finality provider without stats has no delegations.
+func (s *V1Service) GetFinalityProviderDelegationCount(
+	ctx context.Context, fpPkHex string,
+) (*FpDelegationCountPublic, *types.Error) {
+	fpStatsByPks, err :=
+		s.Service.DbClients.V1DBClient.FindFinalityProviderStatsByFinalityProviderPkHex(
+			ctx, []string{fpPkHex},
+		)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).
+			Msg("Error while fetching finality provider stats from DB")
+		return nil, types.NewInternalServiceError(err)
+	}
+	count := &FpDelegationCountPublic{BtcPk: fpPkHex}
+	for _, fpStats := range fpStatsByPks {
+		count.ActiveDelegations += fpStats.ActiveDelegations
+		count.TotalDelegations += fpStats.TotalDelegations
+	}
+	return count, nil
+}
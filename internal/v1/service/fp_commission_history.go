@@ -0,0 +1,71 @@
+package v1service
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type FpCommissionChangePublic struct {
+	Commission         string `json:"commission"`
+	PreviousCommission string `json:"previous_commission,omitempty"`
+	RecordedAt         string `json:"recorded_at"`
+}
+
+// SyncFinalityProviderCommissions compares the commissions of the finality
+// providers registry with the latest recorded ones and appends the changes to
+// the commission history. The finality providers seen for the first time get
+// their current commission recorded.
+func (s *V1Service) SyncFinalityProviderCommissions(ctx context.Context) *types.Error {
+	for _, fp := range s.Service.FinalityProviders {
+		history, err := s.Service.DbClients.V1DBClient.FindFpCommissionHistory(ctx, fp.BtcPk)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("fpPkHex", fp.BtcPk).
+				Msg("Failed to find the commission history of finality provider")
+			return types.NewInternalServiceError(err)
+		}
+		var sequence int64
+		var previousCommission string
+		if len(history) > 0 {
+			latest := history[0]
+			if latest.Commission == fp.Commission {
+				continue
+			}
+			sequence, previousCommission = latest.Sequence, latest.Commission
+		}
+		err = s.Service.DbClients.V1DBClient.SaveFpCommissionChange(
+			ctx, fp.BtcPk, sequence+1, fp.Commission, previousCommission,
+		)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("fpPkHex", fp.BtcPk).
+				Msg("Failed to save the commission change of finality provider")
+			return types.NewInternalServiceError(err)
+		}
+		log.Ctx(ctx).Info().Str("fpPkHex", fp.BtcPk).Str("commission", fp.Commission).
+			Str("previousCommission", previousCommission).Msg("Recorded finality provider commission")
+	}
+	return nil
+}
+
+// GetFinalityProviderCommissionHistory returns the commission changes of the
+// finality provider, the latest first.
+func (s *V1Service) GetFinalityProviderCommissionHistory(
+	ctx context.Context, fpPkHex string,
+) ([]FpCommissionChangePublic, *types.Error) {
+	history, err := s.Service.DbClients.V1DBClient.FindFpCommissionHistory(ctx, fpPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find the commission history of finality provider")
+		return nil, types.NewInternalServiceError(err)
+	}
+	changes := make([]FpCommissionChangePublic, 0, len(history))
+	for _, change := range history {
+		changes = append(changes, FpCommissionChangePublic{
+			Commission:         change.Commission,
+			PreviousCommission: change.PreviousCommission,
+			RecordedAt:         utils.ParseTimestampToIsoFormat(change.RecordedAt),
+		})
+	}
+	return changes, nil
+}
@@ -2,7 +2,9 @@ package v1service
 
 import (
 	"context"
+	"time"
 
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
@@ -11,18 +13,49 @@ import (
 type V1ServiceProvider interface {
 	service.SharedServiceProvider
 	// Delegation
-	DelegationsByStakerPk(ctx context.Context, stakerPk string, state types.DelegationState, pageToken string) ([]DelegationPublic, string, *types.Error)
+	DelegationsByStakerPk(ctx context.Context, stakerPk string, state types.DelegationState, rangeFilter *types.DelegationRangeFilter, fields []string, pageToken string) ([]DelegationPublic, string, *types.Error)
+	CountDelegationsByStakerPk(ctx context.Context, stakerPk string, state types.DelegationState, rangeFilter *types.DelegationRangeFilter) (*types.TotalCount, *types.Error)
+	StreamDelegationsByStakerPk(ctx context.Context, stakerPk string, state types.DelegationState, rangeFilter *types.DelegationRangeFilter, fields []string, pageToken string, fn func(delegation DelegationPublic) error) *types.Error
 	SaveActiveStakingDelegation(ctx context.Context, txHashHex, stakerPkHex, finalityProviderPkHex string, value, startHeight uint64, stakingTimestamp int64, timeLock, stakingOutputIndex uint64, stakingTxHex string, isOverflow bool) *types.Error
 	IsDelegationPresent(ctx context.Context, txHashHex string) (bool, *types.Error)
+	DelegationExists(ctx context.Context, stakingTxHashHex string) (bool, *types.Error)
 	GetDelegation(ctx context.Context, txHashHex string) (*v1model.DelegationDocument, *types.Error)
+	// WaitForDelegationState holds until the delegation is in the state or
+	// the timeout elapses, and returns the delegation as of then
+	WaitForDelegationState(
+		ctx context.Context, txHashHex string, state types.DelegationState, timeout time.Duration,
+	) (*v1model.DelegationDocument, *types.Error)
+	// GetArchivedDelegation fetches the delegation from the archive collection
+	GetArchivedDelegation(ctx context.Context, txHashHex string) (*v1model.DelegationDocument, *types.Error)
+	// ArchiveDelegations moves the delegations in a terminal state for longer
+	// than the configured min age into the archive collection
+	ArchiveDelegations(ctx context.Context) (int64, *types.Error)
+	// MigrateDelegations writes back the delegations stored at a previous
+	// schema version at the current one
+	MigrateDelegations(ctx context.Context) (int64, *types.Error)
+	GetDelegationByAnyTxHash(ctx context.Context, txHashHex string) (*v1model.DelegationDocument, *types.Error)
+	GetDelegationTimeline(ctx context.Context, stakingTxHashHex string) ([]DelegationMilestonePublic, *types.Error)
+	GetDelegationChanges(ctx context.Context, cursor string) ([]DelegationChangePublic, string, *types.Error)
+	GetBtcTipHeight(ctx context.Context) (uint64, *types.Error)
 	CheckStakerHasActiveDelegationByPk(ctx context.Context, stakerPkHex string, afterTimestamp int64) (bool, *types.Error)
-	TransitionToUnbondingState(ctx context.Context, txHashHex string, startHeight, timelock, outputIndex uint64, txHex string, startTimestamp int64) *types.Error
-	TransitionToWithdrawnState(ctx context.Context, txHashHex string) *types.Error
-	UnbondDelegation(ctx context.Context, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex string) *types.Error
+	TransitionToUnbondingState(ctx context.Context, txHashHex string, startHeight, timelock, outputIndex uint64, txHex, unbondingTxHashHex string, startTimestamp int64) *types.Error
+	TransitionToWithdrawnState(ctx context.Context, txHashHex string, withdrawalTx *v1model.WithdrawalTransaction) *types.Error
+	UnbondDelegation(ctx context.Context, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex, unbondingPsbtBase64 string) *types.Error
 	IsEligibleForUnbondingRequest(ctx context.Context, stakingTxHashHex string) *types.Error
+	// GetUnbondingRequestStatus returns the status of the submitted unbonding
+	// request through the unbonding pipeline
+	GetUnbondingRequestStatus(ctx context.Context, unbondingTxHashHex string) (*UnbondingRequestStatusPublic, *types.Error)
+	VerifyStakingTx(ctx context.Context, stakingTxHex string) (*StakingTxVerificationPublic, *types.Error)
+	CheckStakingEligibility(ctx context.Context, stakerPkHex, fpPkHex string, stakingAmount, stakingTime uint64) (*StakingEligibilityPublic, *types.Error)
 	// Finality Provider
 	GetFinalityProvidersFromGlobalParams() []*FpParamsPublic
 	GetFinalityProvider(ctx context.Context, finalityProviderPkHex string) (*FpDetailsPublic, *types.Error)
+	GetFinalityProviderDetail(ctx context.Context, fpPkHex string) (*FpDetailPublic, *types.Error)
+	GetFinalityProviderCommissionHistory(ctx context.Context, fpPkHex string) ([]FpCommissionChangePublic, *types.Error)
+	GetFinalityProviderDelegationCount(ctx context.Context, fpPkHex string) (*FpDelegationCountPublic, *types.Error)
+	// SyncFinalityProviderCommissions records the commission changes of the
+	// finality providers registry
+	SyncFinalityProviderCommissions(ctx context.Context) *types.Error
 	GetFinalityProviders(ctx context.Context, pageToken string) ([]*FpDetailsPublic, string, *types.Error)
 	FindRegisteredFinalityProvidersNotInUse(ctx context.Context, fpParams []*FpParamsPublic) ([]*FpDetailsPublic, error)
 	// Global Params
@@ -31,13 +64,54 @@ type V1ServiceProvider interface {
 	// Staker
 	ProcessAndSaveBtcAddresses(ctx context.Context, stakerPkHex string) *types.Error
 	GetStakerPublicKeysByAddresses(ctx context.Context, addresses []string) (map[string]string, *types.Error)
+	GetStakerSummary(ctx context.Context, stakerPkHex string) (*StakerSummaryPublic, *types.Error)
+	// Search returns the delegations, stakers and finality providers matched
+	// by a tx hash, a public key, a BTC address or a moniker
+	Search(ctx context.Context, query string) ([]SearchMatchPublic, *types.Error)
 	// Stats
 	ProcessStakingStatsCalculation(ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string, state types.DelegationState, amount uint64) *types.Error
+	// ProcessOverflowStatsCalculation counts the overflow delegations, which
+	// are excluded from the other stats
+	ProcessOverflowStatsCalculation(ctx context.Context, stakingTxHashHex string, state types.DelegationState, amount uint64) *types.Error
 	GetOverallStats(ctx context.Context) (*OverallStatsPublic, *types.Error)
+	RefreshOverallStats(ctx context.Context) *types.Error
+	// GetStatsDelta returns the change of the overall stats over the window
+	GetStatsDelta(ctx context.Context, window string) (*StatsDeltaPublic, *types.Error)
 	GetStakerStats(ctx context.Context, stakerPkHex string) (*StakerStatsPublic, *types.Error)
+	GetStakersStatsByPks(ctx context.Context, stakerPkHexes []string) ([]StakerStatsPublic, *types.Error)
 	GetTopStakersByActiveTvl(ctx context.Context, pageToken string) ([]StakerStatsPublic, string, *types.Error)
-	ProcessBtcInfoStats(ctx context.Context, btcHeight uint64, confirmedTvl uint64, unconfirmedTvl uint64) *types.Error
+	CountStakers(ctx context.Context) (*types.TotalCount, *types.Error)
+	// GetNetworkTip returns the latest heights received from the btc info events
+	GetNetworkTip(ctx context.Context) (*NetworkTipPublic, *types.Error)
+	ProcessBtcInfoStats(
+		ctx context.Context, btcHeight, babylonHeight uint64, confirmedTvl uint64, unconfirmedTvl uint64,
+	) *types.Error
+	TransitionConfirmedDelegationsToActive(ctx context.Context, btcTipHeight uint64) *types.Error
 	// Timelock
 	ProcessExpireCheck(ctx context.Context, stakingTxHashHex string, startHeight, timelock uint64, txType types.StakingTxType) *types.Error
 	TransitionToUnbondedState(ctx context.Context, stakingType types.StakingTxType, stakingTxHashHex string) *types.Error
+	// ScanExpiredDelegations transitions to unbonded the expired delegations
+	// missing their expiry event
+	ScanExpiredDelegations(ctx context.Context) (map[types.StakingTxType]int64, *types.Error)
+	// Partner
+	AttributeDelegationToPartner(ctx context.Context, stakingTxHashHex, partnerId, signatureHex string) *types.Error
+	GetPartnerStats(ctx context.Context, partnerId string) (*PartnerStatsPublic, *types.Error)
+	// Labels
+	SetDelegationLabels(
+		ctx context.Context, apiKey, stakingTxHashHex string, labels []string,
+	) (*DelegationLabelsPublic, *types.Error)
+	GetDelegationLabels(ctx context.Context, apiKey, stakingTxHashHex string) (*DelegationLabelsPublic, *types.Error)
+	GetDelegationsByLabel(
+		ctx context.Context, apiKey, label, pageToken string,
+	) ([]DelegationLabelsPublic, string, *types.Error)
+	// Export
+	CreateExportJob(ctx context.Context, jobType dbmodel.ExportJobType, fpPkHex string) (*ExportJobPublic, *types.Error)
+	GetExportJob(ctx context.Context, id string) (*ExportJobPublic, *types.Error)
+	ProcessNextExportJob(ctx context.Context) (bool, *types.Error)
+	// Erasure
+	EraseStakerData(ctx context.Context, stakerPkHex, requestedBy string) (*ErasurePublic, *types.Error)
+	PreviewStakerDataErasure(ctx context.Context, stakerPkHex string) (*ErasurePublic, *types.Error)
+	// Reorg
+	RollbackReorgedDelegations(ctx context.Context, stakingTxHashHexes []string) ([]string, *types.Error)
+	RecordBtcReorg(ctx context.Context, blockHash string, blockHeight uint64, stakingTxHashHexes []string) *types.Error
 }
@@ -0,0 +1,33 @@
+package v1service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+type NetworkTipPublic struct {
+	BtcHeight uint64 `json:"btc_height"`
+	// BabylonHeight is omitted if the btc info events don't report it
+	BabylonHeight uint64 `json:"babylon_height,omitempty"`
+}
+
+// GetNetworkTip returns the latest heights received from the btc info events,
+// which are the heights the confirmations are computed against.
+func (s *V1Service) GetNetworkTip(ctx context.Context) (*NetworkTipPublic, *types.Error) {
+	btcInfo, err := s.Service.DbClients.V1DBClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "network tip is not known yet")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &NetworkTipPublic{
+		BtcHeight:     btcInfo.BtcHeight,
+		BabylonHeight: btcInfo.BabylonHeight,
+	}, nil
+}
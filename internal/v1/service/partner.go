@@ -0,0 +1,92 @@
+package v1service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+type PartnerStatsPublic struct {
+	PartnerId         string `json:"partner_id"`
+	ActiveTvl         int64  `json:"active_tvl"`
+	TotalTvl          int64  `json:"total_tvl"`
+	ActiveDelegations int64  `json:"active_delegations"`
+	TotalDelegations  int64  `json:"total_delegations"`
+}
+
+// SignPartnerAttribution returns the hex encoded HMAC-SHA256 signature of the
+// attribution of the delegation to the partner, computed with the partner
+// secret over `<partner id>:<staking tx hash hex>`
+func SignPartnerAttribution(secret, partnerId, stakingTxHashHex string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(partnerId + ":" + strings.ToLower(stakingTxHashHex)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// AttributeDelegationToPartner attributes the delegation to the partner once
+// the attribution signature is verified with the partner secret.
+// A delegation can only be attributed to a single partner.
+func (s *V1Service) AttributeDelegationToPartner(
+	ctx context.Context, stakingTxHashHex, partnerId, signatureHex string,
+) *types.Error {
+	secret, ok := s.partnerSecret(partnerId)
+	if !ok {
+		return types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "unknown partner")
+	}
+	expected, _ := hex.DecodeString(SignPartnerAttribution(secret, partnerId, stakingTxHashHex))
+	signature, err := hex.DecodeString(signatureHex)
+	if err != nil || !hmac.Equal(signature, expected) {
+		log.Ctx(ctx).Warn().Str("partnerId", partnerId).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("invalid partner attribution signature")
+		return types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, "invalid partner signature")
+	}
+
+	err = s.Service.DbClients.V1DBClient.SetDelegationPartner(ctx, stakingTxHashHex, partnerId)
+	if err != nil {
+		if ok := db.IsNotFoundError(err); ok {
+			return types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "delegation not found")
+		}
+		if ok := db.IsDuplicateKeyError(err); ok {
+			return types.NewErrorWithMsg(
+				http.StatusConflict, types.Conflict, "delegation already attributed to another partner",
+			)
+		}
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("error while attributing delegation to partner")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+func (s *V1Service) GetPartnerStats(ctx context.Context, partnerId string) (*PartnerStatsPublic, *types.Error) {
+	if _, ok := s.partnerSecret(partnerId); !ok {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "partner not found")
+	}
+	stats, err := s.Service.DbClients.V1DBClient.FindPartnerStats(ctx, partnerId)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("partnerId", partnerId).Msg("error while fetching partner stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &PartnerStatsPublic{
+		PartnerId:         partnerId,
+		ActiveTvl:         stats.ActiveTvl,
+		TotalTvl:          stats.TotalTvl,
+		ActiveDelegations: stats.ActiveDelegations,
+		TotalDelegations:  stats.TotalDelegations,
+	}, nil
+}
+
+func (s *V1Service) partnerSecret(partnerId string) (string, bool) {
+	if s.Service.Cfg.Partners == nil {
+		return "", false
+	}
+	secret, ok := s.Service.Cfg.Partners.Secrets[partnerId]
+	return secret, ok
+}
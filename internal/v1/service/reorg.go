@@ -0,0 +1,50 @@
+package v1service
+
+import (
+	"context"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// RollbackReorgedDelegations rolls back the pending or active delegations whose
+// staking tx has been reorged out of the BTC chain, reversing their stats
+// contribution. It returns the staking tx hashes of the delegations rolled
+// back, the ones with nothing to roll back (e.g. the delegation does not exist
+// or is no longer pending/active) are skipped.
+func (s *V1Service) RollbackReorgedDelegations(
+	ctx context.Context, stakingTxHashHexes []string,
+) ([]string, *types.Error) {
+	var rolledBack []string
+	for _, stakingTxHashHex := range stakingTxHashHexes {
+		state, err := s.Service.DbClients.V1DBClient.RollbackReorgedDelegation(ctx, stakingTxHashHex)
+		if err != nil {
+			if db.IsNotFoundError(err) {
+				log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).Err(err).
+					Msg("delegation not found or no longer eligible for reorg rollback")
+				continue
+			}
+			log.Ctx(ctx).Error().Str("stakingTxHashHex", stakingTxHashHex).Err(err).
+				Msg("failed to rollback reorged delegation")
+			return rolledBack, types.NewInternalServiceError(err)
+		}
+		service.NotifyRemoval(ctx, stakingTxHashHex, state, s.Service.Clock.Now())
+		rolledBack = append(rolledBack, stakingTxHashHex)
+	}
+	return rolledBack, nil
+}
+
+// RecordBtcReorg saves the reorged block hash and the rolled back staking txs.
+func (s *V1Service) RecordBtcReorg(
+	ctx context.Context, blockHash string, blockHeight uint64, stakingTxHashHexes []string,
+) *types.Error {
+	err := s.Service.DbClients.V1DBClient.SaveBtcReorg(ctx, blockHash, blockHeight, stakingTxHashHexes)
+	if err != nil {
+		log.Ctx(ctx).Error().Str("blockHash", blockHash).Err(err).
+			Msg("failed to record btc reorg")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
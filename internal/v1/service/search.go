@@ -0,0 +1,115 @@
+package v1service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	SearchMatchDelegation       = "delegation"
+	SearchMatchStaker           = "staker"
+	SearchMatchFinalityProvider = "finality_provider"
+
+	// maxMonikerSearchMatches caps the finality providers matched by moniker
+	maxMonikerSearchMatches = 10
+)
+
+type SearchMatchPublic struct {
+	Type string `json:"type"`
+	// Id is the staking tx hash of a delegation, or the BTC public key of a
+	// staker or a finality provider
+	Id string `json:"id"`
+	// Label is the state of a delegation or the moniker of a finality provider
+	Label string `json:"label,omitempty"`
+}
+
+// Search detects whether the query is a tx hash, a public key, a BTC address
+// or a finality provider moniker, and returns the entities it matches. The
+// x-only public keys have the same format as the tx hashes, so a hex query is
+// matched against both.
+func (s *V1Service) Search(ctx context.Context, query string) ([]SearchMatchPublic, *types.Error) {
+	query = strings.TrimSpace(query)
+	if utils.IsValidTxHash(query) {
+		return s.searchHex(ctx, strings.ToLower(query))
+	}
+	if _, err := utils.CheckBtcAddressType(query, s.Service.Cfg.Server.BTCNetParam); err == nil {
+		return s.searchBtcAddress(ctx, query)
+	}
+	return s.searchFinalityProviderMoniker(query), nil
+}
+
+func (s *V1Service) searchHex(ctx context.Context, hex string) ([]SearchMatchPublic, *types.Error) {
+	matches := []SearchMatchPublic{}
+	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByAnyTxHashHex(ctx, hex)
+	if err != nil && !db.IsNotFoundError(err) {
+		log.Ctx(ctx).Error().Err(err).Str("query", hex).Msg("Failed to search delegation by tx hash")
+		return nil, types.NewInternalServiceError(err)
+	}
+	if delegation != nil {
+		matches = append(matches, SearchMatchPublic{
+			Type:  SearchMatchDelegation,
+			Id:    delegation.StakingTxHashHex,
+			Label: delegation.State.ToString(),
+		})
+	}
+
+	if _, err := utils.GetSchnorrPkFromHex(hex); err != nil {
+		return matches, nil
+	}
+	fp, fpErr := s.GetFinalityProvider(ctx, hex)
+	if fpErr != nil {
+		return nil, fpErr
+	}
+	if fp != nil {
+		matches = append(matches, SearchMatchPublic{
+			Type:  SearchMatchFinalityProvider,
+			Id:    fp.BtcPk,
+			Label: fp.Description.Moniker,
+		})
+	}
+	isStaker, err := s.Service.DbClients.V1DBClient.CheckDelegationExistByStakerPk(ctx, hex, nil)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("query", hex).Msg("Failed to search staker by pk")
+		return nil, types.NewInternalServiceError(err)
+	}
+	if isStaker {
+		matches = append(matches, SearchMatchPublic{Type: SearchMatchStaker, Id: hex})
+	}
+	return matches, nil
+}
+
+func (s *V1Service) searchBtcAddress(ctx context.Context, address string) ([]SearchMatchPublic, *types.Error) {
+	addressPks, err := s.GetStakerPublicKeysByAddresses(ctx, []string{address})
+	if err != nil {
+		return nil, err
+	}
+	matches := []SearchMatchPublic{}
+	if pk, ok := addressPks[address]; ok {
+		matches = append(matches, SearchMatchPublic{Type: SearchMatchStaker, Id: pk})
+	}
+	return matches, nil
+}
+
+func (s *V1Service) searchFinalityProviderMoniker(query string) []SearchMatchPublic {
+	matches := []SearchMatchPublic{}
+	query = strings.ToLower(query)
+	for _, fp := range s.GetFinalityProvidersFromGlobalParams() {
+		if !strings.Contains(strings.ToLower(fp.Description.Moniker), query) {
+			continue
+		}
+		matches = append(matches, SearchMatchPublic{
+			Type:  SearchMatchFinalityProvider,
+			Id:    fp.BtcPk,
+			Label: fp.Description.Moniker,
+		})
+		if len(matches) == maxMonikerSearchMatches {
+			break
+		}
+	}
+	return matches
+}
@@ -12,6 +12,9 @@ import (
 
 type V1Service struct {
 	*service.Service
+	// longPollWaiters bounds the number of requests waiting for a delegation
+	// state, it's nil if the long poll is not configured so none can wait
+	longPollWaiters chan struct{}
 }
 
 func New(
@@ -27,7 +30,13 @@ func New(
 		return nil, err
 	}
 
+	var longPollWaiters chan struct{}
+	if cfg.LongPoll != nil {
+		longPollWaiters = make(chan struct{}, cfg.LongPoll.MaxWaiters)
+	}
+
 	return &V1Service{
-		service,
+		Service:         service,
+		longPollWaiters: longPollWaiters,
 	}, nil
 }
@@ -124,3 +124,69 @@ func findPublicKeyByNativeSegwitAddress(
 	}
 	return ""
 }
+
+type StakerStateSummaryPublic struct {
+	Count int64 `json:"count"`
+	Tvl   int64 `json:"tvl"`
+}
+
+type StakerSummaryPublic struct {
+	StakerPkHex string `json:"staker_pk_hex"`
+	// States holds the summary of every delegation state, including the
+	// states without delegations
+	States           map[types.DelegationState]StakerStateSummaryPublic `json:"states"`
+	TotalDelegations int64                                              `json:"total_delegations"`
+	TotalTvl         int64                                              `json:"total_tvl"`
+	// FirstStakingTimestamp and LastStakingTimestamp are the staking start
+	// timestamps of the first and the last delegations, 0 if none
+	FirstStakingTimestamp int64 `json:"first_staking_timestamp"`
+	LastStakingTimestamp  int64 `json:"last_staking_timestamp"`
+	WithdrawnAmount       int64 `json:"withdrawn_amount"`
+	// PendingUnbondings is the number of delegations whose unbonding has been
+	// requested or is in progress
+	PendingUnbondings int64 `json:"pending_unbondings"`
+}
+
+var stakerSummaryStates = []types.DelegationState{
+	types.Pending, types.Active, types.UnbondingRequested,
+	types.Unbonding, types.Unbonded, types.Withdrawn,
+}
+
+// GetStakerSummary summarizes the activity of the staker from its
+// delegations, a staker without delegations has an empty summary
+func (s *V1Service) GetStakerSummary(ctx context.Context, stakerPkHex string) (*StakerSummaryPublic, *types.Error) {
+	summaries, err := s.Service.DbClients.V1DBClient.FindStakerStateSummaries(ctx, stakerPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakerPkHex", stakerPkHex).Msg("Failed to summarize staker delegations")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	summary := &StakerSummaryPublic{
+		StakerPkHex: stakerPkHex,
+		States:      make(map[types.DelegationState]StakerStateSummaryPublic, len(stakerSummaryStates)),
+	}
+	for _, state := range stakerSummaryStates {
+		summary.States[state] = StakerStateSummaryPublic{}
+	}
+	for _, stateSummary := range summaries {
+		summary.States[stateSummary.State] = StakerStateSummaryPublic{
+			Count: stateSummary.Count,
+			Tvl:   stateSummary.Tvl,
+		}
+		summary.TotalDelegations += stateSummary.Count
+		summary.TotalTvl += stateSummary.Tvl
+		if summary.FirstStakingTimestamp == 0 || stateSummary.FirstStakingTimestamp < summary.FirstStakingTimestamp {
+			summary.FirstStakingTimestamp = stateSummary.FirstStakingTimestamp
+		}
+		if stateSummary.LastStakingTimestamp > summary.LastStakingTimestamp {
+			summary.LastStakingTimestamp = stateSummary.LastStakingTimestamp
+		}
+		switch stateSummary.State {
+		case types.Withdrawn:
+			summary.WithdrawnAmount = stateSummary.Tvl
+		case types.UnbondingRequested, types.Unbonding:
+			summary.PendingUnbondings += stateSummary.Count
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,115 @@
+package v1service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	StakerPolicyMaxActiveDelegations = "staker_max_active_delegations"
+	StakerPolicyStakingAmount        = "staker_staking_amount"
+	StakerPolicyCooldown             = "staker_cooldown"
+)
+
+// stakerPolicyCheck is the result of a rule of the staker policy, err is nil
+// if the delegation complies with the rule
+type stakerPolicyCheck struct {
+	rule string
+	err  error
+}
+
+// checkStakerPolicy evaluates the configured rules of the staker policy
+// against a new delegation of the staker of the given amount, started at the
+// given unix timestamp. The rules which are not configured are not returned.
+func (s *V1Service) checkStakerPolicy(
+	ctx context.Context, stakerPkHex string, amount uint64, startTimestamp int64,
+) ([]stakerPolicyCheck, *types.Error) {
+	policy := s.Service.Cfg.StakerPolicy
+	if policy == nil {
+		return nil, nil
+	}
+	var checks []stakerPolicyCheck
+
+	if policy.MaxActiveDelegations > 0 {
+		count, err := s.Service.DbClients.V1DBClient.CountDelegationsByStakerPk(
+			ctx, stakerPkHex, &v1dbclient.DelegationFilter{
+				States: []types.DelegationState{types.Pending, types.Active},
+			},
+		)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("stakerPkHex", stakerPkHex).
+				Msg("error while counting the active delegations of the staker")
+			return nil, types.NewInternalServiceError(err)
+		}
+		var maxErr error
+		if count >= policy.MaxActiveDelegations {
+			maxErr = fmt.Errorf(
+				"staker has %d active delegations, the max is %d",
+				count, policy.MaxActiveDelegations,
+			)
+		}
+		checks = append(checks, stakerPolicyCheck{rule: StakerPolicyMaxActiveDelegations, err: maxErr})
+	}
+
+	if policy.MinStakingAmount > 0 || policy.MaxStakingAmount > 0 {
+		var amountErr error
+		if amount < policy.MinStakingAmount {
+			amountErr = fmt.Errorf(
+				"staking amount %d is below the staker policy min %d", amount, policy.MinStakingAmount,
+			)
+		} else if policy.MaxStakingAmount > 0 && amount > policy.MaxStakingAmount {
+			amountErr = fmt.Errorf(
+				"staking amount %d is above the staker policy max %d", amount, policy.MaxStakingAmount,
+			)
+		}
+		checks = append(checks, stakerPolicyCheck{rule: StakerPolicyStakingAmount, err: amountErr})
+	}
+
+	if policy.Cooldown > 0 {
+		// The delegations are not necessarily processed in order, the ones
+		// started within the cooldown on either side are counted
+		cooldown := int64(policy.Cooldown / time.Second)
+		count, err := s.Service.DbClients.V1DBClient.CountDelegationsByStakerPk(
+			ctx, stakerPkHex, &v1dbclient.DelegationFilter{
+				AfterTimestamp:  startTimestamp - cooldown + 1,
+				BeforeTimestamp: startTimestamp + cooldown - 1,
+			},
+		)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("stakerPkHex", stakerPkHex).
+				Msg("error while counting the recent delegations of the staker")
+			return nil, types.NewInternalServiceError(err)
+		}
+		var cooldownErr error
+		if count > 0 {
+			cooldownErr = fmt.Errorf(
+				"staker has another delegation started within the cooldown of %s", policy.Cooldown,
+			)
+		}
+		checks = append(checks, stakerPolicyCheck{rule: StakerPolicyCooldown, err: cooldownErr})
+	}
+
+	return checks, nil
+}
+
+// flagStakerPolicyViolations logs and counts the failed checks of the staker
+// policy of an indexed delegation
+func (s *V1Service) flagStakerPolicyViolations(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, checks []stakerPolicyCheck,
+) {
+	for _, check := range checks {
+		if check.err == nil {
+			continue
+		}
+		log.Ctx(ctx).Warn().Err(check.err).Str("stakingTxHashHex", stakingTxHashHex).
+			Str("stakerPkHex", stakerPkHex).Str("rule", check.rule).
+			Msg("delegation violates the staker policy")
+		metrics.RecordStakerPolicyViolation(check.rule)
+	}
+}
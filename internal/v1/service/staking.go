@@ -0,0 +1,143 @@
+package v1service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	StakingTxCheckDecode           = "decode"
+	StakingTxCheckStakingOutput    = "staking_output"
+	StakingTxCheckStakingTime      = "staking_time"
+	StakingTxCheckStakingAmount    = "staking_amount"
+	StakingTxCheckFinalityProvider = "finality_provider"
+)
+
+type StakingTxCheckPublic struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+type StakingTxVerificationPublic struct {
+	Valid                 bool                   `json:"valid"`
+	ParamsVersion         uint64                 `json:"params_version"`
+	StakingTxHashHex      string                 `json:"staking_tx_hash_hex,omitempty"`
+	StakerPkHex           string                 `json:"staker_pk_hex,omitempty"`
+	FinalityProviderPkHex string                 `json:"finality_provider_pk_hex,omitempty"`
+	StakingValue          uint64                 `json:"staking_value,omitempty"`
+	StakingTime           uint64                 `json:"staking_time,omitempty"`
+	StakingOutputIndex    uint64                 `json:"staking_output_index,omitempty"`
+	Checks                []StakingTxCheckPublic `json:"checks"`
+}
+
+func (r *StakingTxVerificationPublic) addCheck(name string, err error) {
+	check := StakingTxCheckPublic{Name: name, Passed: err == nil}
+	if err != nil {
+		check.Message = err.Error()
+		r.Valid = false
+	}
+	r.Checks = append(r.Checks, check)
+}
+
+// VerifyStakingTx performs a dry-run verification of the staking tx against the
+// global params that apply at the current BTC tip height. The returned report
+// contains the result of each individual check, the tx is valid only if all
+// the checks passed.
+func (s *V1Service) VerifyStakingTx(
+	ctx context.Context, stakingTxHex string,
+) (*StakingTxVerificationPublic, *types.Error) {
+	btcTipHeight, tipErr := s.GetBtcTipHeight(ctx)
+	if tipErr != nil {
+		return nil, tipErr
+	}
+	params := s.currentGlobalParams(btcTipHeight)
+	if params == nil {
+		log.Ctx(ctx).Error().Uint64("btcTipHeight", btcTipHeight).Msg("failed to get global params")
+		return nil, types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError,
+			"failed to get global params based on the btc tip height",
+		)
+	}
+
+	report := &StakingTxVerificationPublic{
+		Valid:         true,
+		ParamsVersion: params.Version,
+	}
+	stakingTx, parsedTx, err := utils.ParseStakingTxHex(
+		stakingTxHex, params, s.Service.Cfg.Server.BTCNetParam,
+	)
+	if stakingTx == nil {
+		report.addCheck(StakingTxCheckDecode, err)
+		return report, nil
+	}
+	report.addCheck(StakingTxCheckDecode, nil)
+	report.StakingTxHashHex = stakingTx.TxHash().String()
+	report.addCheck(StakingTxCheckStakingOutput, err)
+	if parsedTx == nil {
+		return report, nil
+	}
+
+	stakingTime := uint64(parsedTx.OpReturnData.StakingTime)
+	stakingValue := uint64(parsedTx.StakingOutput.Value)
+	fpPkHex := hex.EncodeToString(parsedTx.OpReturnData.FinalityProviderPublicKey.Marshall())
+	report.StakerPkHex = hex.EncodeToString(parsedTx.OpReturnData.StakerPublicKey.Marshall())
+	report.FinalityProviderPkHex = fpPkHex
+	report.StakingValue = stakingValue
+	report.StakingTime = stakingTime
+	report.StakingOutputIndex = uint64(parsedTx.StakingOutputIdx)
+
+	var stakingTimeErr error
+	if stakingTime < params.MinStakingTime || stakingTime > params.MaxStakingTime {
+		stakingTimeErr = fmt.Errorf(
+			"staking time %d is out of range [%d, %d]",
+			stakingTime, params.MinStakingTime, params.MaxStakingTime,
+		)
+	}
+	report.addCheck(StakingTxCheckStakingTime, stakingTimeErr)
+
+	var stakingAmountErr error
+	if stakingValue < params.MinStakingAmount || stakingValue > params.MaxStakingAmount {
+		stakingAmountErr = fmt.Errorf(
+			"staking amount %d is out of range [%d, %d]",
+			stakingValue, params.MinStakingAmount, params.MaxStakingAmount,
+		)
+	}
+	report.addCheck(StakingTxCheckStakingAmount, stakingAmountErr)
+
+	var fpErr error
+	if !s.isFinalityProviderRegistered(fpPkHex) {
+		fpErr = fmt.Errorf("finality provider %s is not registered", fpPkHex)
+	}
+	report.addCheck(StakingTxCheckFinalityProvider, fpErr)
+
+	return report, nil
+}
+
+// currentGlobalParams returns the global params applied at the given BTC tip
+// height. If the tip height is not known yet, the latest params are returned.
+func (s *V1Service) currentGlobalParams(btcTipHeight uint64) *types.VersionedGlobalParams {
+	if btcTipHeight == 0 {
+		versions := s.Service.Params.Versions
+		if len(versions) == 0 {
+			return nil
+		}
+		return versions[len(versions)-1]
+	}
+	return s.GetVersionedGlobalParamsByHeight(btcTipHeight)
+}
+
+func (s *V1Service) isFinalityProviderRegistered(fpPkHex string) bool {
+	for _, fp := range s.Service.FinalityProviders {
+		if fp.BtcPk == fpPkHex {
+			return true
+		}
+	}
+	return false
+}
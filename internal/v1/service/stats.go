@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 	"github.com/rs/zerolog/log"
 )
 
@@ -18,6 +21,10 @@ type OverallStatsPublic struct {
 	TotalStakers      uint64 `json:"total_stakers"`
 	UnconfirmedTvl    uint64 `json:"unconfirmed_tvl"`
 	PendingTvl        uint64 `json:"pending_tvl"`
+	// OverflowTvl and OverflowDelegations are the delegations exceeding the
+	// staking cap, which are not part of the other stats
+	OverflowTvl         int64 `json:"overflow_tvl"`
+	OverflowDelegations int64 `json:"overflow_delegations"`
 }
 
 type StakerStatsPublic struct {
@@ -153,10 +160,72 @@ func (s *V1Service) ProcessStakingStatsCalculation(
 	return nil
 }
 
+// ProcessOverflowStatsCalculation counts the active delegation exceeding the
+// staking cap in the overflow stats. The overflow delegations are excluded
+// from all the other stats, so the other states are ignored.
+// This method tolerates duplicated calls, only the first call will be processed.
+func (s *V1Service) ProcessOverflowStatsCalculation(
+	ctx context.Context, stakingTxHashHex string, state types.DelegationState, amount uint64,
+) *types.Error {
+	if state != types.Active {
+		return nil
+	}
+	statsLockDocument, err := s.Service.DbClients.V1DBClient.GetOrCreateStatsLock(
+		ctx, stakingTxHashHex, state.ToString(),
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("error while fetching stats lock document")
+		return types.NewInternalServiceError(err)
+	}
+	if statsLockDocument.OverallStats {
+		return nil
+	}
+	err = s.Service.DbClients.V1DBClient.IncrementOverflowStats(ctx, stakingTxHashHex, amount)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			// This is a duplicate call, ignore it
+			return nil
+		}
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("error while incrementing overflow stats")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+// findOverallStats serves the materialized overall stats if the stats
+// refresher is enabled and the stats are fresh enough, otherwise the stats
+// are summed up from the shards.
+func (s *V1Service) findOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error) {
+	if refresherCfg := s.Service.Cfg.StatsRefresher; refresherCfg != nil {
+		materialized, err := s.Service.DbClients.V1DBClient.GetMaterializedOverallStats(ctx)
+		if err == nil {
+			if s.Service.Clock.Now().Sub(time.Unix(materialized.LastRefreshedAt, 0)) <= refresherCfg.MaxStaleness {
+				return &materialized.OverallStatsDocument, nil
+			}
+			log.Ctx(ctx).Warn().Int64("lastRefreshedAt", materialized.LastRefreshedAt).
+				Msg("materialized overall stats are stale, falling back to the shards")
+		} else if !db.IsNotFoundError(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("error while fetching materialized overall stats")
+		}
+	}
+	return s.Service.DbClients.V1DBClient.GetOverallStats(ctx)
+}
+
+// RefreshOverallStats refreshes the materialized overall stats
+func (s *V1Service) RefreshOverallStats(ctx context.Context) *types.Error {
+	if err := s.Service.DbClients.V1DBClient.RefreshMaterializedOverallStats(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while refreshing materialized overall stats")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
 func (s *V1Service) GetOverallStats(
 	ctx context.Context,
 ) (*OverallStatsPublic, *types.Error) {
-	stats, err := s.Service.DbClients.V1DBClient.GetOverallStats(ctx)
+	stats, err := s.findOverallStats(ctx)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while fetching overall stats")
 		return nil, types.NewInternalServiceError(err)
@@ -185,13 +254,15 @@ func (s *V1Service) GetOverallStats(
 	}
 
 	return &OverallStatsPublic{
-		ActiveTvl:         int64(confirmedTvl),
-		TotalTvl:          stats.TotalTvl,
-		ActiveDelegations: stats.ActiveDelegations,
-		TotalDelegations:  stats.TotalDelegations,
-		TotalStakers:      stats.TotalStakers,
-		UnconfirmedTvl:    unconfirmedTvl,
-		PendingTvl:        pendingTvl,
+		ActiveTvl:           int64(confirmedTvl),
+		TotalTvl:            stats.TotalTvl,
+		ActiveDelegations:   stats.ActiveDelegations,
+		TotalDelegations:    stats.TotalDelegations,
+		TotalStakers:        stats.TotalStakers,
+		UnconfirmedTvl:      unconfirmedTvl,
+		PendingTvl:          pendingTvl,
+		OverflowTvl:         stats.OverflowTvl,
+		OverflowDelegations: stats.OverflowDelegations,
 	}, nil
 }
 
@@ -217,6 +288,52 @@ func (s *V1Service) GetStakerStats(
 	}, nil
 }
 
+// GetStakersStatsByPks returns the stats of the given stakers in the order
+// they are given, the stakers without stats are left out
+func (s *V1Service) GetStakersStatsByPks(
+	ctx context.Context, stakerPkHexes []string,
+) ([]StakerStatsPublic, *types.Error) {
+	stats, err := s.Service.DbClients.V1DBClient.FindStakerStatsByStakerPkHexes(ctx, stakerPkHexes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching the stats of the stakers")
+		return nil, types.NewInternalServiceError(err)
+	}
+	statsByPk := make(map[string]*v1dbmodel.StakerStatsDocument, len(stats))
+	for _, d := range stats {
+		statsByPk[d.StakerPkHex] = d
+	}
+
+	stakersStats := make([]StakerStatsPublic, 0, len(stats))
+	for _, stakerPkHex := range stakerPkHexes {
+		d, ok := statsByPk[stakerPkHex]
+		if !ok {
+			continue
+		}
+		// The duplicated stakers are only returned once
+		delete(statsByPk, stakerPkHex)
+		stakersStats = append(stakersStats, StakerStatsPublic{
+			StakerPkHex:       d.StakerPkHex,
+			ActiveTvl:         d.ActiveTvl,
+			TotalTvl:          d.TotalTvl,
+			ActiveDelegations: d.ActiveDelegations,
+			TotalDelegations:  d.TotalDelegations,
+		})
+	}
+	return stakersStats, nil
+}
+
+// CountStakers returns the total of the stakers listed by
+// GetTopStakersByActiveTvl, estimated if the approximate counts are enabled
+func (s *V1Service) CountStakers(ctx context.Context) (*types.TotalCount, *types.Error) {
+	approximate := s.Service.ApproximateTotalCounts()
+	count, err := s.Service.DbClients.V1DBClient.CountStakerStats(ctx, approximate)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while counting stakers")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &types.TotalCount{Count: count, Approximate: approximate}, nil
+}
+
 func (s *V1Service) GetTopStakersByActiveTvl(
 	ctx context.Context, pageToken string,
 ) ([]StakerStatsPublic, string, *types.Error) {
@@ -245,12 +362,76 @@ func (s *V1Service) GetTopStakersByActiveTvl(
 }
 
 func (s *V1Service) ProcessBtcInfoStats(
-	ctx context.Context, btcHeight uint64, confirmedTvl uint64, unconfirmedTvl uint64,
+	ctx context.Context, btcHeight, babylonHeight uint64, confirmedTvl uint64, unconfirmedTvl uint64,
 ) *types.Error {
-	err := s.Service.DbClients.V1DBClient.UpsertLatestBtcInfo(ctx, btcHeight, confirmedTvl, unconfirmedTvl)
+	err := s.Service.DbClients.V1DBClient.UpsertLatestBtcInfo(
+		ctx, btcHeight, babylonHeight, confirmedTvl, unconfirmedTvl,
+	)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while upserting latest btc info")
 		return types.NewInternalServiceError(err)
 	}
-	return nil
+	// The BTC tip height moved, pending delegations may have reached the
+	// confirmation depth.
+	return s.TransitionConfirmedDelegationsToActive(ctx, btcHeight)
+}
+
+// StatsDeltaWindows are the windows the change of the overall stats can be
+// requested over
+var StatsDeltaWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+// StatsDeltaPublic is the change of the overall stats over the window, the
+// stats are compared against the hourly snapshot taken at the start of the
+// window
+type StatsDeltaPublic struct {
+	Window                 string `json:"window"`
+	From                   string `json:"from"`
+	ActiveTvlDelta         int64  `json:"active_tvl_delta"`
+	TotalTvlDelta          int64  `json:"total_tvl_delta"`
+	ActiveDelegationsDelta int64  `json:"active_delegations_delta"`
+	TotalDelegationsDelta  int64  `json:"total_delegations_delta"`
+	TotalStakersDelta      int64  `json:"total_stakers_delta"`
+}
+
+// GetStatsDelta returns the change of the overall stats over the window. The
+// hourly snapshots are recorded by the stats refresher, so the delta is not
+// available until the refresher has run for the length of the window.
+func (s *V1Service) GetStatsDelta(
+	ctx context.Context, window string,
+) (*StatsDeltaPublic, *types.Error) {
+	duration, ok := StatsDeltaWindows[window]
+	if !ok {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid window, expected 24h or 7d",
+		)
+	}
+	from := s.Service.Clock.Now().Add(-duration)
+	snapshot, err := s.Service.DbClients.V1DBClient.FindHourlyOverallStats(ctx, from)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, types.NewErrorWithMsg(
+				http.StatusNotFound, types.NotFound, "not enough stats history for the window",
+			)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching hourly overall stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+	stats, err := s.findOverallStats(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching overall stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	return &StatsDeltaPublic{
+		Window:                 window,
+		From:                   utils.ParseTimestampToIsoFormat(snapshot.HourStart),
+		ActiveTvlDelta:         stats.ActiveTvl - snapshot.ActiveTvl,
+		TotalTvlDelta:          stats.TotalTvl - snapshot.TotalTvl,
+		ActiveDelegationsDelta: stats.ActiveDelegations - snapshot.ActiveDelegations,
+		TotalDelegationsDelta:  stats.TotalDelegations - snapshot.TotalDelegations,
+		TotalStakersDelta:      int64(stats.TotalStakers) - int64(snapshot.TotalStakers),
+	}, nil
 }
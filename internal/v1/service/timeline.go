@@ -0,0 +1,102 @@
+package v1service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// milestoneOrder is the position of each milestone in the delegation lifecycle,
+// used to order the timeline regardless of when the milestones were recorded.
+var milestoneOrder = map[v1model.DelegationMilestone]int{
+	v1model.MilestoneStaked:             0,
+	v1model.MilestoneUnbondingRequested: 1,
+	v1model.MilestoneUnbondingConfirmed: 2,
+	v1model.MilestoneExpired:            3,
+	v1model.MilestoneWithdrawn:          4,
+}
+
+type DelegationMilestonePublic struct {
+	Milestone   string `json:"milestone"`
+	TxHashHex   string `json:"tx_hash_hex,omitempty"`
+	BlockHeight uint64 `json:"block_height,omitempty"`
+	Timestamp   string `json:"timestamp"`
+}
+
+// GetDelegationTimeline returns the lifecycle milestones of the delegation
+// ordered from the staking up to the withdrawal.
+func (s *V1Service) GetDelegationTimeline(
+	ctx context.Context, stakingTxHashHex string,
+) ([]DelegationMilestonePublic, *types.Error) {
+	// Make sure the delegation exist so that an unknown tx is not an empty timeline
+	if _, err := s.GetDelegation(ctx, stakingTxHashHex); err != nil {
+		return nil, err
+	}
+	milestones, err := s.Service.DbClients.V1DBClient.FindDelegationMilestones(ctx, stakingTxHashHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("Failed to find delegation milestones")
+		return nil, types.NewInternalServiceError(err)
+	}
+	sort.SliceStable(milestones, func(i, j int) bool {
+		return milestoneOrder[milestones[i].Milestone] < milestoneOrder[milestones[j].Milestone]
+	})
+
+	timeline := make([]DelegationMilestonePublic, 0, len(milestones))
+	for _, m := range milestones {
+		timeline = append(timeline, DelegationMilestonePublic{
+			Milestone:   string(m.Milestone),
+			TxHashHex:   m.TxHashHex,
+			BlockHeight: m.BlockHeight,
+			Timestamp:   utils.ParseTimestampToIsoFormat(m.Timestamp),
+		})
+	}
+	return timeline, nil
+}
+
+// recordMilestone saves the milestone into the delegation audit trail. The
+// audit trail is informational only, hence a failure is logged but does not
+// fail the processing of the delegation.
+func (s *V1Service) recordMilestone(
+	ctx context.Context, stakingTxHashHex string, milestone v1model.DelegationMilestone,
+	txHashHex string, blockHeight uint64, timestamp int64,
+) {
+	if timestamp == 0 {
+		timestamp = s.Service.Clock.Now().Unix()
+	}
+	err := s.Service.DbClients.V1DBClient.SaveDelegationMilestone(
+		ctx, stakingTxHashHex, milestone, txHashHex, blockHeight, timestamp,
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Str("milestone", string(milestone)).Msg("Failed to record delegation milestone")
+	}
+}
+
+// recordExpiredMilestone records the expiry of the staking or unbonding
+// timelock, the expire height is derived from the expired tx.
+func (s *V1Service) recordExpiredMilestone(
+	ctx context.Context, stakingType types.StakingTxType, stakingTxHashHex string,
+) {
+	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
+	if err != nil {
+		if !db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+				Msg("Failed to find delegation to record the expired milestone")
+		}
+		return
+	}
+	expiredTx := delegation.StakingTx
+	if stakingType == types.UnbondingTxType && delegation.UnbondingTx != nil {
+		expiredTx = delegation.UnbondingTx
+	}
+	s.recordMilestone(
+		ctx, stakingTxHashHex, v1model.MilestoneExpired, "",
+		expiredTx.StartHeight+expiredTx.TimeLock, 0,
+	)
+}
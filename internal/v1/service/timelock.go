@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
 	"github.com/rs/zerolog/log"
@@ -43,6 +44,8 @@ func (s *V1Service) TransitionToUnbondedState(
 		log.Ctx(ctx).Err(err).Str("stakingTxHash", stakingTxHashHex).Msg("Failed to transition to unbonded state")
 		return types.NewInternalServiceError(err)
 	}
+	s.recordExpiredMilestone(ctx, stakingType, stakingTxHashHex)
+	service.NotifyTransition(ctx, stakingTxHashHex, types.Unbonded, s.Service.Clock.Now())
 	return nil
 
 }
@@ -2,24 +2,31 @@ package v1service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 	"github.com/rs/zerolog/log"
 )
 
 // UnbondDelegation verifies the unbonding request and saves the unbonding tx into the DB.
+// The unbonding tx and the staker signature are either provided directly or
+// extracted from the PSBT if `unbondingPsbtBase64` is not empty.
 // It returns an error if the delegation is not eligible for unbonding or if the unbonding request is invalid.
+// A staker signature can only be submitted once, its replays are rejected with UNBONDING_REQUEST_REPLAYED.
 // If successful, it will change the delegation state to `unbonding_requested`
 func (s *V1Service) UnbondDelegation(
 	ctx context.Context,
 	stakingTxHashHex,
 	unbondingTxHashHex,
 	unbondingTxHex,
-	signatureHex string) *types.Error {
+	signatureHex,
+	unbondingPsbtBase64 string) *types.Error {
 	// 1. check the delegation is eligible for unbonding
 	delegationDoc, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
 	if err != nil {
@@ -31,12 +38,32 @@ func (s *V1Service) UnbondDelegation(
 		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
 	}
 
-	if delegationDoc.State != types.Active {
+	// 2. extract the unbonding tx and staker signature from the PSBT
+	if unbondingPsbtBase64 != "" {
+		unbondingPsbt, err := utils.ParseUnbondingPsbt(unbondingPsbtBase64, delegationDoc.StakerPkHex)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("stakingTxHashHex", stakingTxHashHex).
+				Msg("failed to extract unbonding tx from psbt")
+			return types.NewError(http.StatusBadRequest, types.ValidationError, err)
+		}
+		unbondingTxHex = unbondingPsbt.UnbondingTxHex
+		signatureHex = unbondingPsbt.StakerSigHex
+	}
+
+	// 3. reject the replays of an already submitted signature
+	if replayErr := s.checkUnbondingSignatureReplay(
+		ctx, stakingTxHashHex, unbondingTxHashHex, signatureHex,
+	); replayErr != nil {
+		return replayErr
+	}
+
+	if !types.CanTransition(delegationDoc.State, types.UnbondingRequested) {
 		log.Ctx(ctx).Warn().
 			Str("stakingTxHashHex", stakingTxHashHex).
 			Str("state", delegationDoc.State.ToString()).
 			Msg("delegation state is not active, hence not eligible for unbonding")
-		return types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, "delegation state is not active")
+		return types.NewInvalidTransitionError(delegationDoc.State, types.UnbondingRequested)
 	}
 
 	paramsVersion := s.GetVersionedGlobalParamsByHeight(delegationDoc.StakingTx.StartHeight)
@@ -48,9 +75,10 @@ func (s *V1Service) UnbondDelegation(
 		)
 	}
 
-	// 2. verify the unbonding request
+	// 4. verify the unbonding request
 	if err := utils.VerifyUnbondingRequest(
 		delegationDoc.StakingTxHashHex,
+		delegationDoc.StakingTx.TxHex,
 		unbondingTxHashHex,
 		unbondingTxHex,
 		delegationDoc.StakerPkHex,
@@ -64,15 +92,21 @@ func (s *V1Service) UnbondDelegation(
 	); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg(fmt.Sprintf("unbonding request did not pass unbonding request verification, staking tx hash: %s, unbonding tx hash: %s",
 			delegationDoc.StakingTxHashHex, unbondingTxHashHex))
+		var verificationErr *utils.UnbondingVerificationError
+		if errors.As(err, &verificationErr) {
+			return types.NewError(http.StatusForbidden, verificationErr.Code, err)
+		}
 		return types.NewError(http.StatusForbidden, types.ValidationError, err)
 	}
 
-	// 3. save unbonding tx into DB
-	err = s.Service.DbClients.V1DBClient.SaveUnbondingTx(ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex)
+	// 5. save unbonding tx into DB
+	err = s.Service.DbClients.V1DBClient.SaveUnbondingTx(
+		ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex, unbondingPsbtBase64,
+	)
 	if err != nil {
 		if ok := db.IsDuplicateKeyError(err); ok {
 			log.Ctx(ctx).Warn().Err(err).Msg("unbonding request already been submitted into the system")
-			return types.NewError(http.StatusForbidden, types.Forbidden, err)
+			return types.NewError(http.StatusForbidden, types.UnbondingRequestReplayed, err)
 		} else if ok := db.IsNotFoundError(err); ok {
 			log.Ctx(ctx).Warn().Err(err).Msg("no active delegation found for unbonding request")
 			return types.NewError(http.StatusForbidden, types.Forbidden, err)
@@ -80,9 +114,42 @@ func (s *V1Service) UnbondDelegation(
 		log.Ctx(ctx).Error().Err(err).Msg("failed to save unbonding tx")
 		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
 	}
+	s.recordMilestone(ctx, stakingTxHashHex, v1model.MilestoneUnbondingRequested, unbondingTxHashHex, 0, 0)
+	service.NotifyTransition(ctx, stakingTxHashHex, types.UnbondingRequested, s.Service.Clock.Now())
 	return nil
 }
 
+// checkUnbondingSignatureReplay returns an error if the staker signature has
+// already been submitted, either for another unbonding request or for the same
+// one, whose delegation is no longer eligible for unbonding since then.
+func (s *V1Service) checkUnbondingSignatureReplay(
+	ctx context.Context, stakingTxHashHex, unbondingTxHashHex, signatureHex string,
+) *types.Error {
+	signatureDoc, err := s.Service.DbClients.V1DBClient.FindUnbondingSignature(ctx, signatureHex)
+	if err != nil {
+		if ok := db.IsNotFoundError(err); ok {
+			return nil
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching unbonding signature")
+		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+	}
+	log.Ctx(ctx).Warn().
+		Str("stakingTxHashHex", stakingTxHashHex).
+		Str("submittedStakingTxHashHex", signatureDoc.StakingTxHashHex).
+		Str("submittedUnbondingTxHashHex", signatureDoc.UnbondingTxHashHex).
+		Msg("unbonding signature has already been submitted")
+	if signatureDoc.StakingTxHashHex != stakingTxHashHex || signatureDoc.UnbondingTxHashHex != unbondingTxHashHex {
+		return types.NewErrorWithMsg(
+			http.StatusForbidden, types.UnbondingRequestReplayed,
+			"unbonding signature has already been submitted for another unbonding request",
+		)
+	}
+	return types.NewErrorWithMsg(
+		http.StatusForbidden, types.UnbondingRequestReplayed,
+		"unbonding request has already been submitted",
+	)
+}
+
 func (s *V1Service) IsEligibleForUnbondingRequest(ctx context.Context, stakingTxHashHex string) *types.Error {
 	delegationDoc, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
 	if err != nil {
@@ -94,9 +161,9 @@ func (s *V1Service) IsEligibleForUnbondingRequest(ctx context.Context, stakingTx
 		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
 	}
 
-	if delegationDoc.State != types.Active {
+	if !types.CanTransition(delegationDoc.State, types.UnbondingRequested) {
 		log.Ctx(ctx).Warn().Msg("delegation state is not active, hence not eligible for unbonding")
-		return types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, "delegation state is not active")
+		return types.NewInvalidTransitionError(delegationDoc.State, types.UnbondingRequested)
 	}
 	return nil
 }
@@ -106,9 +173,9 @@ func (s *V1Service) IsEligibleForUnbondingRequest(ctx context.Context, stakingTx
 func (s *V1Service) TransitionToUnbondingState(
 	ctx context.Context, stakingTxHashHex string,
 	unbondingStartHeight, unbondingTimelock, unbondingOutputIndex uint64,
-	unbondingTxHex string, unbondingStartTimestamp int64,
+	unbondingTxHex, unbondingTxHashHex string, unbondingStartTimestamp int64,
 ) *types.Error {
-	err := s.Service.DbClients.V1DBClient.TransitionToUnbondingState(ctx, stakingTxHashHex, unbondingStartHeight, unbondingTimelock, unbondingOutputIndex, unbondingTxHex, unbondingStartTimestamp)
+	err := s.Service.DbClients.V1DBClient.TransitionToUnbondingState(ctx, stakingTxHashHex, unbondingStartHeight, unbondingTimelock, unbondingOutputIndex, unbondingTxHex, unbondingTxHashHex, unbondingStartTimestamp)
 	if err != nil {
 		if ok := db.IsNotFoundError(err); ok {
 			log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg("delegation not found or no longer eligible for unbonding")
@@ -117,5 +184,88 @@ func (s *V1Service) TransitionToUnbondingState(
 		log.Ctx(ctx).Error().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg("failed to transition to unbonding state")
 		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
 	}
+	s.recordMilestone(
+		ctx, stakingTxHashHex, v1model.MilestoneUnbondingConfirmed,
+		unbondingTxHashHex, unbondingStartHeight, unbondingStartTimestamp,
+	)
+	service.NotifyTransition(ctx, stakingTxHashHex, types.Unbonding, s.Service.Clock.Now())
 	return nil
 }
+
+// The staker-facing statuses of an unbonding request
+const (
+	UnbondingRequestAccepted  = "accepted"
+	UnbondingRequestPickedUp  = "picked_up"
+	UnbondingRequestBroadcast = "broadcast"
+	UnbondingRequestConfirmed = "confirmed"
+	UnbondingRequestFailed    = "failed"
+)
+
+type UnbondingRequestStatusPublic struct {
+	StakingTxHashHex   string `json:"staking_tx_hash_hex"`
+	UnbondingTxHashHex string `json:"unbonding_tx_hash_hex"`
+	Status             string `json:"status"`
+	// PipelineState is the state the unbonding pipeline acknowledged the
+	// request with
+	PipelineState string `json:"pipeline_state"`
+	RequestedAt   string `json:"requested_at,omitempty"`
+	// ConfirmedHeight is the BTC height of the unbonding tx once confirmed
+	ConfirmedHeight uint64 `json:"confirmed_height,omitempty"`
+}
+
+// GetUnbondingRequestStatus returns the status of the unbonding request from
+// its acknowledgment by the unbonding pipeline, the request is confirmed once
+// the unbonding tx is set on the delegation by its unbonding event.
+func (s *V1Service) GetUnbondingRequestStatus(
+	ctx context.Context, unbondingTxHashHex string,
+) (*UnbondingRequestStatusPublic, *types.Error) {
+	unbonding, err := s.Service.DbClients.V1DBClient.FindUnbondingByTxHashHex(ctx, unbondingTxHashHex)
+	if err != nil {
+		if ok := db.IsNotFoundError(err); ok {
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "unbonding request not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Str("unbondingTxHashHex", unbondingTxHashHex).
+			Msg("error while fetching unbonding request")
+		return nil, types.NewInternalServiceError(err)
+	}
+	status := &UnbondingRequestStatusPublic{
+		StakingTxHashHex:   unbonding.StakingTxHashHex,
+		UnbondingTxHashHex: unbonding.UnbondingTxHashHex,
+		Status:             unbondingRequestStatus(unbonding.State),
+		PipelineState:      unbonding.State,
+	}
+	if unbonding.CreatedAt != 0 {
+		status.RequestedAt = utils.ParseTimestampToIsoFormat(unbonding.CreatedAt)
+	}
+
+	delegation, err := s.Service.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, unbonding.StakingTxHashHex)
+	if err != nil {
+		// The archived delegations are long past their unbonding
+		if ok := db.IsNotFoundError(err); ok {
+			return status, nil
+		}
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", unbonding.StakingTxHashHex).
+			Msg("error while fetching delegation")
+		return nil, types.NewInternalServiceError(err)
+	}
+	if delegation.UnbondingTx != nil && delegation.UnbondingTx.TxHashHex == unbonding.UnbondingTxHashHex {
+		status.Status = UnbondingRequestConfirmed
+		status.ConfirmedHeight = delegation.UnbondingTx.StartHeight
+	}
+	return status, nil
+}
+
+// unbondingRequestStatus maps the state of the unbonding pipeline, the states
+// it does not report as terminal mean that it picked up the request
+func unbondingRequestStatus(pipelineState string) string {
+	switch pipelineState {
+	case v1model.UnbondingInitialState:
+		return UnbondingRequestAccepted
+	case v1model.UnbondingSentState:
+		return UnbondingRequestBroadcast
+	case v1model.UnbondingInputAlreadySpentState, v1model.UnbondingFailedState:
+		return UnbondingRequestFailed
+	default:
+		return UnbondingRequestPickedUp
+	}
+}
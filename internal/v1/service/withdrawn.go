@@ -5,14 +5,16 @@ import (
 	"net/http"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 	"github.com/rs/zerolog/log"
 )
 
 func (s *V1Service) TransitionToWithdrawnState(
-	ctx context.Context, stakingTxHashHex string,
+	ctx context.Context, stakingTxHashHex string, withdrawalTx *v1model.WithdrawalTransaction,
 ) *types.Error {
-	err := s.Service.DbClients.V1DBClient.TransitionToWithdrawnState(ctx, stakingTxHashHex)
+	err := s.Service.DbClients.V1DBClient.TransitionToWithdrawnState(ctx, stakingTxHashHex, withdrawalTx)
 	if err != nil {
 		if ok := db.IsNotFoundError(err); ok {
 			log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg("delegation not found or no longer eligible for withdraw")
@@ -21,5 +23,14 @@ func (s *V1Service) TransitionToWithdrawnState(
 		log.Ctx(ctx).Error().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg("failed to transition to withdrawn state")
 		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
 	}
+	if withdrawalTx != nil {
+		s.recordMilestone(
+			ctx, stakingTxHashHex, v1model.MilestoneWithdrawn,
+			withdrawalTx.TxHashHex, 0, withdrawalTx.StartTimestamp,
+		)
+	} else {
+		s.recordMilestone(ctx, stakingTxHashHex, v1model.MilestoneWithdrawn, "", 0, 0)
+	}
+	service.NotifyTransition(ctx, stakingTxHashHex, types.Withdrawn, s.Service.Clock.Now())
 	return nil
 }
@@ -0,0 +1,69 @@
+package v2handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
+)
+
+// GetBsns gets the registered BSNs
+// @Summary List BSNs
+// @Description Fetches the BSNs (consumer chains) registered on Babylon, along with the TVL aggregated over their finality providers
+// @Produce json
+// @Tags v2
+// @Param pagination_key query string false "Pagination key to fetch the next page"
+// @Success 200 {object} handler.PublicResponse[[]v2service.BsnPublic]{array} "List of BSNs and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 500 {object} types.Error "Error: Internal Server Error"
+// @Router /v2/bsns [get]
+func (h *V2Handler) GetBsns(request *http.Request) (*handler.Result, *types.Error) {
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	bsns, paginationToken, err := h.Service.GetBsns(request.Context(), paginationKey)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPagination(bsns, paginationToken), nil
+}
+
+// GetBsnFinalityProviders gets the finality providers of a BSN
+// @Summary List BSN Finality Providers
+// @Description Fetches the finality providers securing the given BSN
+// @Produce json
+// @Tags v2
+// @Param bsn_id query string true "Id of the BSN"
+// @Param pagination_key query string false "Pagination key to fetch the next page"
+// @Param fields query string false "Comma separated fields of the finality providers to return"
+// @Success 200 {object} handler.PublicResponse[[]v2service.FinalityProviderPublic]{array} "List of finality providers and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Failure 500 {object} types.Error "Error: Internal Server Error"
+// @Router /v2/bsn/finality-providers [get]
+func (h *V2Handler) GetBsnFinalityProviders(request *http.Request) (*handler.Result, *types.Error) {
+	bsnId, err := handler.ParseBsnIdQuery(request, "bsn_id")
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := handler.ParseFieldsQuery(request, v2service.FinalityProviderPublic{})
+	if err != nil {
+		return nil, err
+	}
+
+	providers, paginationToken, err := h.Service.GetBsnFinalityProviders(request.Context(), bsnId, paginationKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := handler.SelectFields(providers, fields)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPagination(data, paginationToken), nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
 )
 
 // GetDelegation @Summary Get a delegation
@@ -13,6 +14,7 @@ import (
 // @Produce json
 // @Tags v2
 // @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Param fields query string false "Comma separated fields of the delegation to return, e.g. state,delegation_staking"
 // @Success 200 {object} handler.PublicResponse[v2service.StakerDelegationPublic] "Staker delegation"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Failure 404 {object} types.Error "Error: Not Found"
@@ -23,12 +25,21 @@ func (h *V2Handler) GetDelegation(request *http.Request) (*handler.Result, *type
 	if err != nil {
 		return nil, err
 	}
+	fields, err := handler.ParseFieldsQuery(request, v2service.StakerDelegationPublic{})
+	if err != nil {
+		return nil, err
+	}
 	delegation, err := h.Service.GetDelegation(request.Context(), stakingTxHash)
 	if err != nil {
 		return nil, err
 	}
 
-	return handler.NewResult(delegation), nil
+	data, err := handler.SelectFields(delegation, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewResult(data), nil
 }
 
 // GetDelegations gets delegations for babylon staking
@@ -37,7 +48,13 @@ func (h *V2Handler) GetDelegation(request *http.Request) (*handler.Result, *type
 // @Produce json
 // @Tags v2
 // @Param staker_pk_hex query string true "Staker public key in hex format"
+// @Param min_value query integer false "Minimum staking amount in satoshis (inclusive)"
+// @Param max_value query integer false "Maximum staking amount in satoshis (inclusive)"
+// @Param from_timestamp query integer false "Minimum delegation creation timestamp in unix seconds (inclusive)"
+// @Param to_timestamp query integer false "Maximum delegation creation timestamp in unix seconds (inclusive)"
+// @Param fields query string false "Comma separated fields of the delegations to return, e.g. state,delegation_staking"
 // @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Param include_total query boolean false "Include the total count of the delegations along with the pagination token, if enabled"
 // @Success 200 {object} handler.PublicResponse[[]v2service.StakerDelegationPublic]{array} "List of staker delegations and pagination token"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Failure 404 {object} types.Error "Error: Not Found"
@@ -53,9 +70,96 @@ func (h *V2Handler) GetDelegations(request *http.Request) (*handler.Result, *typ
 	if err != nil {
 		return nil, err
 	}
-	delegations, paginationToken, err := h.Service.GetDelegations(request.Context(), stakerPKHex, paginationKey)
+	rangeFilter, err := handler.ParseDelegationRangeFilterQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := handler.ParseFieldsQuery(request, v2service.StakerDelegationPublic{})
+	if err != nil {
+		return nil, err
+	}
+	includeTotal, err := handler.ParseIncludeTotalQuery(request, h.Config.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	delegations, paginationToken, err := h.Service.GetDelegations(
+		request.Context(), stakerPKHex, rangeFilter, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	data, err := handler.SelectFields(delegations, fields)
+	if err != nil {
+		return nil, err
+	}
+	if !includeTotal {
+		return handler.NewResultWithPagination(data, paginationToken), nil
+	}
+
+	total, err := h.Service.CountDelegations(request.Context(), stakerPKHex, rangeFilter)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPaginationTotal(data, paginationToken, total), nil
+}
+
+// GetUnifiedDelegations gets the phase-1 and phase-2 delegations of a staker
+// @Summary Get the phase-1 and phase-2 delegations of a staker
+// @Description Fetches the phase-1 (v1) and phase-2 (v2) delegations of the staker as a single list, each delegation carrying its protocol_version.
+// @Description The phase-1 delegations are listed first, followed by the phase-2 ones.
+// @Produce json
+// @Tags v2
+// @Param staker_pk_hex query string true "Staker public key in hex format"
+// @Param min_value query integer false "Minimum staking amount in satoshis (inclusive)"
+// @Param max_value query integer false "Maximum staking amount in satoshis (inclusive)"
+// @Param from_timestamp query integer false "Minimum delegation creation timestamp in unix seconds (inclusive)"
+// @Param to_timestamp query integer false "Maximum delegation creation timestamp in unix seconds (inclusive)"
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} handler.PublicResponse[[]v2service.UnifiedDelegationPublic]{array} "List of staker delegations and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 500 {object} types.Error "Error: Internal Server Error"
+// @Router /v2/delegations/unified [get]
+func (h *V2Handler) GetUnifiedDelegations(request *http.Request) (*handler.Result, *types.Error) {
+	stakerPKHex, err := handler.ParsePublicKeyQuery(request, "staker_pk_hex", false)
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := handler.ParsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	rangeFilter, err := handler.ParseDelegationRangeFilterQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	delegations, paginationToken, err := h.Service.GetUnifiedDelegations(
+		request.Context(), stakerPKHex, rangeFilter, paginationKey,
+	)
 	if err != nil {
 		return nil, err
 	}
 	return handler.NewResultWithPagination(delegations, paginationToken), nil
 }
+
+// GetDelegationTransitionStatus gets the phase-2 registration status of a phase-1 delegation
+// @Summary Get the transition status of a phase-1 delegation
+// @Description Reports whether the phase-1 delegation has been registered on the Babylon chain, is still pending or is eligible for registration
+// @Produce json
+// @Tags v2
+// @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Success 200 {object} handler.PublicResponse[v2service.DelegationTransitionStatusPublic] "Transition status of the delegation"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Failure 500 {object} types.Error "Error: Internal Server Error"
+// @Router /v2/delegation/transition-status [get]
+func (h *V2Handler) GetDelegationTransitionStatus(request *http.Request) (*handler.Result, *types.Error) {
+	stakingTxHash, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	status, err := h.Service.GetDelegationTransitionStatus(request.Context(), stakingTxHash)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResult(status), nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
 )
 
 // GetFinalityProviders gets a list of finality providers with optional filters
@@ -14,6 +15,7 @@ import (
 // @Tags v2
 // @Param pagination_key query string false "Pagination key to fetch the next page"
 // @Param state query string false "Filter by state" Enums(active, standby)
+// @Param fields query string false "Comma separated fields of the finality providers to return"
 // @Success 200 {object} handler.PublicResponse[[]v2service.FinalityProviderPublic]{array} "List of finality providers and pagination token"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Failure 404 {object} types.Error "Error: Not Found"
@@ -29,6 +31,10 @@ func (h *V2Handler) GetFinalityProviders(request *http.Request) (*handler.Result
 	if err != nil {
 		return nil, err
 	}
+	fields, err := handler.ParseFieldsQuery(request, v2service.FinalityProviderPublic{})
+	if err != nil {
+		return nil, err
+	}
 
 	// Get all finality providers with optional state filter
 	providers, paginationToken, err := h.Service.GetFinalityProviders(request.Context(), state, paginationKey)
@@ -36,5 +42,9 @@ func (h *V2Handler) GetFinalityProviders(request *http.Request) (*handler.Result
 	if err != nil {
 		return nil, err
 	}
-	return handler.NewResultWithPagination(providers, paginationToken), nil
+	data, err := handler.SelectFields(providers, fields)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewResultWithPagination(data, paginationToken), nil
 }
@@ -0,0 +1,44 @@
+package v2dbclient
+
+import (
+	"context"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveCovenantSignature records the covenant member as having signed the
+// delegation. It's idempotent so that redelivered events are not double counted.
+func (v2dbclient *V2Database) SaveCovenantSignature(
+	ctx context.Context, stakingTxHashHex, covenantBtcPkHex string,
+) error {
+	client := v2dbclient.Db(ctx).Collection(dbmodel.V2CovenantSignaturesCollection)
+	_, err := client.UpdateOne(
+		ctx,
+		bson.M{"_id": stakingTxHashHex},
+		bson.M{"$addToSet": bson.M{"covenant_btc_pks_hex": covenantBtcPkHex}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// FindCovenantSignatures fetches the covenant signatures of the given
+// delegations. The delegations without any signature are not returned.
+func (v2dbclient *V2Database) FindCovenantSignatures(
+	ctx context.Context, stakingTxHashHexes []string,
+) ([]*v2dbmodel.V2CovenantSignaturesDocument, error) {
+	client := v2dbclient.Db(ctx).Collection(dbmodel.V2CovenantSignaturesCollection)
+	cursor, err := client.Find(ctx, bson.M{"_id": bson.M{"$in": stakingTxHashHexes}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []*v2dbmodel.V2CovenantSignaturesDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
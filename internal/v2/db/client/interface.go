@@ -10,5 +10,15 @@ import (
 type V2DBClient interface {
 	dbclient.DBClient
 	GetOverallStats(ctx context.Context) (*v2dbmodel.V2OverallStatsDocument, error)
+	// RefreshMaterializedOverallStats consolidates the overall stats shards
+	// into the materialized overall stats document.
+	RefreshMaterializedOverallStats(ctx context.Context) error
+	GetMaterializedOverallStats(ctx context.Context) (*v2dbmodel.V2MaterializedOverallStatsDocument, error)
 	GetStakerStats(ctx context.Context, stakerPKHex string) (*v2dbmodel.V2StakerStatsDocument, error)
+	// IncrementSlashedStats records the slashed amount of the delegation into
+	// the overall, finality providers and staker stats.
+	IncrementSlashedStats(ctx context.Context, stakingTxHashHex, stakerPkHex string, fpPkHexes []string, slashedAmount uint64) error
+	GetFinalityProvidersStats(ctx context.Context, fpPkHexes []string) ([]*v2dbmodel.V2FinalityProviderStatsDocument, error)
+	SaveCovenantSignature(ctx context.Context, stakingTxHashHex, covenantBtcPkHex string) error
+	FindCovenantSignatures(ctx context.Context, stakingTxHashHexes []string) ([]*v2dbmodel.V2CovenantSignaturesDocument, error)
 }
@@ -0,0 +1,57 @@
+package v2dbclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// materializedOverallStatsId is the id of the single document of the
+// materialized overall stats collection
+const materializedOverallStatsId = "overall_stats"
+
+// RefreshMaterializedOverallStats consolidates the overall stats shards into
+// the materialized overall stats document
+func (v2dbclient *V2Database) RefreshMaterializedOverallStats(ctx context.Context) error {
+	stats, err := v2dbclient.GetOverallStats(ctx)
+	if err != nil {
+		return err
+	}
+	document := v2dbmodel.V2MaterializedOverallStatsDocument{
+		LastRefreshedAt: v2dbclient.Clock.Now().Unix(),
+	}
+	document.V2OverallStatsDocument = *stats
+	document.Id = materializedOverallStatsId
+
+	client := v2dbclient.Db(ctx).Collection(dbmodel.V2MaterializedOverallStatsCollection)
+	_, err = client.ReplaceOne(
+		ctx, bson.M{"_id": materializedOverallStatsId}, document, options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// GetMaterializedOverallStats fetches the materialized overall stats document.
+// It returns a NotFoundError if the stats have never been refreshed.
+func (v2dbclient *V2Database) GetMaterializedOverallStats(
+	ctx context.Context,
+) (*v2dbmodel.V2MaterializedOverallStatsDocument, error) {
+	client := v2dbclient.Db(ctx).Collection(dbmodel.V2MaterializedOverallStatsCollection)
+	var document v2dbmodel.V2MaterializedOverallStatsDocument
+	err := client.FindOne(ctx, bson.M{"_id": materializedOverallStatsId}).Decode(&document)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &db.NotFoundError{
+				Key:     materializedOverallStatsId,
+				Message: "Materialized overall stats not found",
+			}
+		}
+		return nil, err
+	}
+	return &document, nil
+}
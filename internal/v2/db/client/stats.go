@@ -2,10 +2,8 @@ package v2dbclient
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/big"
 
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
@@ -22,7 +20,7 @@ import (
 func (db *V2Database) GetOrCreateStatsLock(
 	ctx context.Context, stakingTxHashHex string, txType string,
 ) (*v2dbmodel.V2StatsLockDocument, error) {
-	client := db.Client.Database(db.DbName).Collection(dbmodel.V2StatsLockCollection)
+	client := db.Db(ctx).Collection(dbmodel.V2StatsLockCollection)
 	id := constructStatsLockId(stakingTxHashHex, txType)
 	filter := bson.M{"_id": id}
 	// Define the default document to be inserted if not found
@@ -45,6 +43,9 @@ func (db *V2Database) GetOrCreateStatsLock(
 	return &result, nil
 }
 
+// slashedStatsState is the state the slashed stats are locked with
+const slashedStatsState = "slashed"
+
 // IncrementOverallStats increments the overall stats for the given staking tx hash.
 // This method is idempotent, only the first call will be processed. Otherwise it will return a notFoundError for duplicates
 func (v2dbclient *V2Database) IncrementOverallStats(
@@ -55,7 +56,7 @@ func (v2dbclient *V2Database) IncrementOverallStats(
 	fpStatsClient := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2FinalityProviderStatsCollection)
 
 	// Start a session
-	session, sessionErr := v2dbclient.Client.StartSession()
+	session, sessionErr := v2dbclient.Client.StartSession(v2dbclient.SessionOptions())
 	if sessionErr != nil {
 		return sessionErr
 	}
@@ -100,10 +101,7 @@ func (v2dbclient *V2Database) IncrementOverallStats(
 			upsertUpdate["$inc"].(bson.M)["active_finality_providers"] = 1
 		}
 
-		shardId, err := v2dbclient.generateOverallStatsId()
-		if err != nil {
-			return nil, err
-		}
+		shardId := v2dbclient.generateOverallStatsId(stakingTxHashHex)
 
 		upsertFilter := bson.M{"_id": shardId}
 		_, err = overallStatsClient.UpdateOne(sessCtx, upsertFilter, upsertUpdate, options.Update().SetUpsert(true))
@@ -114,7 +112,7 @@ func (v2dbclient *V2Database) IncrementOverallStats(
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	_, txErr := session.WithTransaction(ctx, transactionWork, v2dbclient.TransactionOptions())
 	if txErr != nil {
 		return txErr
 	}
@@ -138,7 +136,7 @@ func (v2dbclient *V2Database) SubtractOverallStats(
 	fpStatsClient := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2FinalityProviderStatsCollection)
 
 	// Start a session
-	session, sessionErr := v2dbclient.Client.StartSession()
+	session, sessionErr := v2dbclient.Client.StartSession(v2dbclient.SessionOptions())
 	if sessionErr != nil {
 		return sessionErr
 	}
@@ -173,10 +171,7 @@ func (v2dbclient *V2Database) SubtractOverallStats(
 			upsertUpdate["$inc"].(bson.M)["active_finality_providers"] = -1
 		}
 
-		shardId, err := v2dbclient.generateOverallStatsId()
-		if err != nil {
-			return nil, err
-		}
+		shardId := v2dbclient.generateOverallStatsId(stakingTxHashHex)
 
 		upsertFilter := bson.M{"_id": shardId}
 
@@ -188,7 +183,7 @@ func (v2dbclient *V2Database) SubtractOverallStats(
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	_, txErr := session.WithTransaction(ctx, transactionWork, v2dbclient.TransactionOptions())
 	if txErr != nil {
 		return txErr
 	}
@@ -197,6 +192,7 @@ func (v2dbclient *V2Database) SubtractOverallStats(
 }
 
 // GetOverallStats fetches the overall stats from all the shards and sums them up
+// with an aggregation pipeline
 func (v2dbclient *V2Database) GetOverallStats(ctx context.Context) (*v2dbmodel.V2OverallStatsDocument, error) {
 	// The collection is sharded by the _id field, so we need to query all the shards
 	var shardsId []string
@@ -204,9 +200,22 @@ func (v2dbclient *V2Database) GetOverallStats(ctx context.Context) (*v2dbmodel.V
 		shardsId = append(shardsId, fmt.Sprintf("%d", i))
 	}
 
-	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2OverallStatsCollection)
-	filter := bson.M{"_id": bson.M{"$in": shardsId}}
-	cursor, err := client.Find(ctx, filter)
+	client := v2dbclient.Db(ctx).Collection(dbmodel.V2OverallStatsCollection)
+	// The shards are summed up server side, only the result is returned
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": shardsId}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                 nil,
+			"active_tvl":          bson.M{"$sum": "$active_tvl"},
+			"total_tvl":           bson.M{"$sum": "$total_tvl"},
+			"active_delegations":  bson.M{"$sum": "$active_delegations"},
+			"total_delegations":   bson.M{"$sum": "$total_delegations"},
+			"total_stakers":       bson.M{"$sum": "$total_stakers"},
+			"slashed_tvl":         bson.M{"$sum": "$slashed_tvl"},
+			"slashed_delegations": bson.M{"$sum": "$slashed_delegations"},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -217,35 +226,25 @@ func (v2dbclient *V2Database) GetOverallStats(ctx context.Context) (*v2dbmodel.V
 		return nil, err
 	}
 
-	// Sum up the stats for the overall stats
+	// No shard has been created yet
 	var result v2dbmodel.V2OverallStatsDocument
-	for _, stats := range overallStats {
-		result.ActiveTvl += stats.ActiveTvl
-		result.TotalTvl += stats.TotalTvl
-		result.ActiveDelegations += stats.ActiveDelegations
-		result.TotalDelegations += stats.TotalDelegations
-		result.TotalStakers += stats.TotalStakers
+	if len(overallStats) > 0 {
+		result = overallStats[0]
 	}
 
 	return &result, nil
 }
 
-// Generate the id for the overall stats document. Id is a random number ranged from 0-LogicalShardCount-1
+// Generate the id for the overall stats document. Id is a number ranged from 0-LogicalShardCount-1
+// derived from the staking tx hash, so that the retried writes of a delegation land on the same shard
 // It's a logical shard to avoid locking the same field during concurrent writes
 // The sharding number should never be reduced after roll out
-func (v2dbclient *V2Database) generateOverallStatsId() (string, error) {
-	max := big.NewInt(*v2dbclient.Cfg.LogicalShardCount)
-	// Generate a secure random number within the range [0, max)
-	n, err := rand.Int(rand.Reader, max)
-	if err != nil {
-		return "", err
-	}
-
-	return fmt.Sprint(n), nil
+func (v2dbclient *V2Database) generateOverallStatsId(stakingTxHashHex string) string {
+	return db.LogicalShardId(stakingTxHashHex, *v2dbclient.Cfg.LogicalShardCount)
 }
 
 func (v2dbclient *V2Database) updateStatsLockByFieldName(ctx context.Context, stakingTxHashHex, state string, fieldName string) error {
-	statsLockClient := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2StatsLockCollection)
+	statsLockClient := v2dbclient.Db(ctx).Collection(dbmodel.V2StatsLockCollection)
 	filter := bson.M{"_id": constructStatsLockId(stakingTxHashHex, state), fieldName: false}
 	update := bson.M{"$set": bson.M{fieldName: true}}
 	result, err := statsLockClient.UpdateOne(ctx, filter, update)
@@ -261,6 +260,66 @@ func (v2dbclient *V2Database) updateStatsLockByFieldName(ctx context.Context, st
 	return nil
 }
 
+// IncrementSlashedStats records the slashed amount of the delegation into the
+// overall, finality providers and staker stats in a single transaction.
+// This method is idempotent, only the first call will be processed. Otherwise it will return a notFoundError for duplicates
+func (v2dbclient *V2Database) IncrementSlashedStats(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, fpPkHexes []string, slashedAmount uint64,
+) error {
+	if _, err := v2dbclient.GetOrCreateStatsLock(ctx, stakingTxHashHex, slashedStatsState); err != nil {
+		return err
+	}
+	overallStatsClient := v2dbclient.Db(ctx).Collection(dbmodel.V2OverallStatsCollection)
+	stakerStatsClient := v2dbclient.Db(ctx).Collection(dbmodel.V2StakerStatsCollection)
+	fpStatsClient := v2dbclient.Db(ctx).Collection(dbmodel.V2FinalityProviderStatsCollection)
+
+	session, sessionErr := v2dbclient.Client.StartSession(v2dbclient.SessionOptions())
+	if sessionErr != nil {
+		return sessionErr
+	}
+	defer session.EndSession(ctx)
+
+	upsertUpdate := bson.M{
+		"$inc": bson.M{
+			"slashed_tvl":         int64(slashedAmount),
+			"slashed_delegations": 1,
+		},
+	}
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		// The stats are all updated in the same transaction, hence a single lock field
+		err := v2dbclient.updateStatsLockByFieldName(sessCtx, stakingTxHashHex, slashedStatsState, "overall_stats")
+		if err != nil {
+			return nil, err
+		}
+
+		shardId := v2dbclient.generateOverallStatsId(stakingTxHashHex)
+		_, err = overallStatsClient.UpdateOne(
+			sessCtx, bson.M{"_id": shardId}, upsertUpdate, options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, fpPkHex := range fpPkHexes {
+			_, err = fpStatsClient.UpdateOne(
+				sessCtx, bson.M{"_id": fpPkHex}, upsertUpdate, options.Update().SetUpsert(true),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+		_, err = stakerStatsClient.UpdateOne(
+			sessCtx, bson.M{"_id": stakerPkHex}, upsertUpdate, options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	_, txErr := session.WithTransaction(ctx, transactionWork, v2dbclient.TransactionOptions())
+	return txErr
+}
+
 func constructStatsLockId(stakingTxHashHex, state string) string {
 	return stakingTxHashHex + ":" + state
 }
@@ -298,7 +357,7 @@ func (v2dbclient *V2Database) SubtractFinalityProviderStats(
 
 // FindFinalityProviderStats fetches the finality provider stats from the database
 func (v2dbclient *V2Database) FindFinalityProviderStats(ctx context.Context, paginationToken string) (*db.DbResultMap[*v2dbmodel.V2FinalityProviderStatsDocument], error) {
-	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2FinalityProviderStatsCollection)
+	client := v2dbclient.Db(ctx).Collection(dbmodel.V2FinalityProviderStatsCollection)
 	options := options.Find().SetSort(bson.D{{Key: "active_tvl", Value: -1}}) // Sorting in descending order
 	var filter bson.M
 
@@ -327,7 +386,7 @@ func (v2dbclient *V2Database) FindFinalityProviderStats(ctx context.Context, pag
 func (v2dbclient *V2Database) FindFinalityProviderStatsByFinalityProviderPkHex(
 	ctx context.Context, finalityProviderPkHex []string,
 ) ([]*v2dbmodel.V2FinalityProviderStatsDocument, error) {
-	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2FinalityProviderStatsCollection)
+	client := v2dbclient.Db(ctx).Collection(dbmodel.V2FinalityProviderStatsCollection)
 	filter := bson.M{"_id": bson.M{"$in": finalityProviderPkHex}}
 	cursor, err := client.Find(ctx, filter)
 	if err != nil {
@@ -347,7 +406,7 @@ func (v2dbclient *V2Database) updateFinalityProviderStats(ctx context.Context, s
 	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2FinalityProviderStatsCollection)
 
 	// Start a session
-	session, sessionErr := v2dbclient.Client.StartSession()
+	session, sessionErr := v2dbclient.Client.StartSession(v2dbclient.SessionOptions())
 	if sessionErr != nil {
 		return sessionErr
 	}
@@ -369,7 +428,7 @@ func (v2dbclient *V2Database) updateFinalityProviderStats(ctx context.Context, s
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	_, txErr := session.WithTransaction(ctx, transactionWork, v2dbclient.TransactionOptions())
 	if txErr != nil {
 		return txErr
 	}
@@ -411,7 +470,7 @@ func (v2dbclient *V2Database) updateStakerStats(ctx context.Context, state, stak
 	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2StakerStatsCollection)
 
 	// Start a session
-	session, sessionErr := v2dbclient.Client.StartSession()
+	session, sessionErr := v2dbclient.Client.StartSession(v2dbclient.SessionOptions())
 	if sessionErr != nil {
 		return sessionErr
 	}
@@ -433,12 +492,12 @@ func (v2dbclient *V2Database) updateStakerStats(ctx context.Context, state, stak
 	}
 
 	// Execute the transaction
-	_, txErr := session.WithTransaction(ctx, transactionWork)
+	_, txErr := session.WithTransaction(ctx, transactionWork, v2dbclient.TransactionOptions())
 	return txErr
 }
 
 func (v2dbclient *V2Database) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v2dbmodel.V2StakerStatsDocument], error) {
-	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2StakerStatsCollection)
+	client := v2dbclient.Db(ctx).Collection(dbmodel.V2StakerStatsCollection)
 
 	opts := options.Find().SetSort(bson.D{{Key: "active_tvl", Value: -1}})
 	var filter bson.M
@@ -467,7 +526,7 @@ func (v2dbclient *V2Database) FindTopStakersByTvl(ctx context.Context, paginatio
 func (v2dbclient *V2Database) GetStakerStats(
 	ctx context.Context, stakerPkHex string,
 ) (*v2dbmodel.V2StakerStatsDocument, error) {
-	client := v2dbclient.Client.Database(v2dbclient.DbName).Collection(dbmodel.V2StakerStatsCollection)
+	client := v2dbclient.Db(ctx).Collection(dbmodel.V2StakerStatsCollection)
 	filter := bson.M{"_id": stakerPkHex}
 	var result v2dbmodel.V2StakerStatsDocument
 	err := client.FindOne(ctx, filter).Decode(&result)
@@ -483,3 +542,22 @@ func (v2dbclient *V2Database) GetStakerStats(
 	}
 	return &result, nil
 }
+
+// GetFinalityProvidersStats fetches the stats of the given finality providers.
+// The finality providers without any delegation are not returned.
+func (v2dbclient *V2Database) GetFinalityProvidersStats(
+	ctx context.Context, fpPkHexes []string,
+) ([]*v2dbmodel.V2FinalityProviderStatsDocument, error) {
+	client := v2dbclient.Db(ctx).Collection(dbmodel.V2FinalityProviderStatsCollection)
+	cursor, err := client.Find(ctx, bson.M{"_id": bson.M{"$in": fpPkHexes}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*v2dbmodel.V2FinalityProviderStatsDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,8 @@
+package v2dbmodel
+
+// V2CovenantSignaturesDocument holds the covenant members that have submitted
+// their signatures for the delegation, keyed by the staking tx hash
+type V2CovenantSignaturesDocument struct {
+	StakingTxHashHex  string   `bson:"_id"`
+	CovenantBtcPksHex []string `bson:"covenant_btc_pks_hex"`
+}
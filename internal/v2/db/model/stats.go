@@ -34,6 +34,16 @@ type V2OverallStatsDocument struct {
 	TotalStakers            uint64 `bson:"total_stakers"`
 	ActiveFinalityProviders uint64 `bson:"active_finality_providers"`
 	TotalFinalityProviders  uint64 `bson:"total_finality_providers"`
+	SlashedTvl              int64  `bson:"slashed_tvl"`
+	SlashedDelegations      int64  `bson:"slashed_delegations"`
+}
+
+// V2MaterializedOverallStatsDocument is the consolidation of the overall stats shards.
+// It's refreshed periodically so that the overall stats are served with a
+// single read.
+type V2MaterializedOverallStatsDocument struct {
+	V2OverallStatsDocument `bson:",inline"`
+	LastRefreshedAt        int64 `bson:"last_refreshed_at"`
 }
 
 type V2FinalityProviderStatsDocument struct {
@@ -42,6 +52,8 @@ type V2FinalityProviderStatsDocument struct {
 	TotalTvl              int64  `bson:"total_tvl"`
 	ActiveDelegations     int64  `bson:"active_delegations"`
 	TotalDelegations      int64  `bson:"total_delegations"`
+	SlashedTvl            int64  `bson:"slashed_tvl"`
+	SlashedDelegations    int64  `bson:"slashed_delegations"`
 }
 
 type V2FinalityProviderStatsPagination struct {
@@ -11,47 +11,61 @@ import (
 
 type V2QueueClient struct {
 	*queueclient.Queue
-	Handler                         *v2queuehandler.V2QueueHandler
-	ActiveStakingEventQueueClient   client.QueueClient
-	StakingExpiredEventQueueClient  client.QueueClient
-	UnbondingEventQueueClient       client.QueueClient
-	PendingStakingEventQueueClient  client.QueueClient
-	VerifiedStakingEventQueueClient client.QueueClient
+	Handler                           *v2queuehandler.V2QueueHandler
+	ActiveStakingEventQueueClient     client.QueueClient
+	StakingExpiredEventQueueClient    client.QueueClient
+	UnbondingEventQueueClient         client.QueueClient
+	PendingStakingEventQueueClient    client.QueueClient
+	VerifiedStakingEventQueueClient   client.QueueClient
+	CovenantSignatureEventQueueClient client.QueueClient
+	SlashedStakingEventQueueClient    client.QueueClient
 }
 
 func New(cfg *queueConfig.QueueConfig, handler *v2queuehandler.V2QueueHandler, queueClient *queueclient.Queue) *V2QueueClient {
-	activeStakingEventQueueClient, err := client.NewQueueClient(cfg, v2queueschema.ActiveStakingQueueName)
+	activeStakingEventQueueClient, err := queueClient.NewQueueClient(cfg, v2queueschema.ActiveStakingQueueName)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating ActiveStakingEventQueue")
 	}
 
-	stakingExpiredEventQueueClient, err := client.NewQueueClient(cfg, v2queueschema.ExpiredStakingQueueName)
+	stakingExpiredEventQueueClient, err := queueClient.NewQueueClient(cfg, v2queueschema.ExpiredStakingQueueName)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating StakingExpiredEventQueue")
 	}
 
-	unbondingEventQueueClient, err := client.NewQueueClient(cfg, v2queueschema.UnbondingStakingQueueName)
+	unbondingEventQueueClient, err := queueClient.NewQueueClient(cfg, v2queueschema.UnbondingStakingQueueName)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating UnbondingEventQueue")
 	}
 
-	pendingStakingEventQueueClient, err := client.NewQueueClient(cfg, v2queueschema.PendingStakingQueueName)
+	pendingStakingEventQueueClient, err := queueClient.NewQueueClient(cfg, v2queueschema.PendingStakingQueueName)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating PendingStakingEventQueue")
 	}
 
-	verifiedStakingEventQueueClient, err := client.NewQueueClient(cfg, v2queueschema.VerifiedStakingQueueName)
+	verifiedStakingEventQueueClient, err := queueClient.NewQueueClient(cfg, v2queueschema.VerifiedStakingQueueName)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while creating VerifiedStakingEventQueue")
 	}
 
+	covenantSignatureEventQueueClient, err := queueClient.NewQueueClient(cfg, v2queueschema.CovenantSignatureQueueName)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while creating CovenantSignatureEventQueue")
+	}
+
+	slashedStakingEventQueueClient, err := queueClient.NewQueueClient(cfg, v2queueschema.SlashedStakingQueueName)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while creating SlashedStakingEventQueue")
+	}
+
 	return &V2QueueClient{
-		Queue:                           queueClient,
-		Handler:                         handler,
-		ActiveStakingEventQueueClient:   activeStakingEventQueueClient,
-		StakingExpiredEventQueueClient:  stakingExpiredEventQueueClient,
-		UnbondingEventQueueClient:       unbondingEventQueueClient,
-		PendingStakingEventQueueClient:  pendingStakingEventQueueClient,
-		VerifiedStakingEventQueueClient: verifiedStakingEventQueueClient,
+		Queue:                             queueClient,
+		Handler:                           handler,
+		ActiveStakingEventQueueClient:     activeStakingEventQueueClient,
+		StakingExpiredEventQueueClient:    stakingExpiredEventQueueClient,
+		UnbondingEventQueueClient:         unbondingEventQueueClient,
+		PendingStakingEventQueueClient:    pendingStakingEventQueueClient,
+		VerifiedStakingEventQueueClient:   verifiedStakingEventQueueClient,
+		CovenantSignatureEventQueueClient: covenantSignatureEventQueueClient,
+		SlashedStakingEventQueueClient:    slashedStakingEventQueueClient,
 	}
 }
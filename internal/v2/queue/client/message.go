@@ -11,17 +11,30 @@ func (q *V2QueueClient) StartReceivingMessages() {
 	log.Printf("Starting to receive messages from verified staking queue")
 	queueclient.StartQueueMessageProcessing(
 		q.VerifiedStakingEventQueueClient,
-		q.Handler.VerifiedStakingHandler, q.Handler.HandleUnprocessedMessage,
-		q.MaxRetryAttempts, q.ProcessingTimeout,
+		q.Handler.VerifiedStakingHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.VerifiedStakingEventQueueClient.GetQueueName()),
 	)
 
 	log.Printf("Starting to receive messages from pending staking queue")
 	queueclient.StartQueueMessageProcessing(
 		q.PendingStakingEventQueueClient,
-		q.Handler.PendingStakingHandler, q.Handler.HandleUnprocessedMessage,
-		q.MaxRetryAttempts, q.ProcessingTimeout,
+		q.Handler.PendingStakingHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.PendingStakingEventQueueClient.GetQueueName()),
 	)
 
+	log.Printf("Starting to receive messages from covenant signature queue")
+	queueclient.StartQueueMessageProcessing(
+		q.CovenantSignatureEventQueueClient,
+		q.Handler.CovenantSignatureHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.CovenantSignatureEventQueueClient.GetQueueName()),
+	)
+
+	log.Printf("Starting to receive messages from slashed staking queue")
+	queueclient.StartQueueMessageProcessing(
+		q.SlashedStakingEventQueueClient,
+		q.Handler.SlashedStakingHandler, q.Handler.HandleUnprocessedMessage, q.ArchiveEvent, q.Backpressure,
+		q.MaxRetryAttempts, q.ProcessingTimeout, q.WorkerCount(q.SlashedStakingEventQueueClient.GetQueueName()),
+	)
 }
 
 // Turn off all message processing
@@ -43,4 +56,31 @@ func (q *V2QueueClient) StopReceivingMessages() {
 			Str("queueName", q.PendingStakingEventQueueClient.GetQueueName()).
 			Msg("error while stopping queue")
 	}
+
+	log.Printf("Stopping to receive messages from covenant signature queue")
+	covenantSignatureQueueErr := q.CovenantSignatureEventQueueClient.Stop()
+	if covenantSignatureQueueErr != nil {
+		log.Error().Err(covenantSignatureQueueErr).
+			Str("queueName", q.CovenantSignatureEventQueueClient.GetQueueName()).
+			Msg("error while stopping queue")
+	}
+
+	log.Printf("Stopping to receive messages from slashed staking queue")
+	slashedQueueErr := q.SlashedStakingEventQueueClient.Stop()
+	if slashedQueueErr != nil {
+		log.Error().Err(slashedQueueErr).
+			Str("queueName", q.SlashedStakingEventQueueClient.GetQueueName()).
+			Msg("error while stopping queue")
+	}
+}
+
+// GetConsumedQueueNames returns the names of all the queues consumed by the
+// v2 queue client
+func (q *V2QueueClient) GetConsumedQueueNames() []string {
+	return []string{
+		q.VerifiedStakingEventQueueClient.GetQueueName(),
+		q.PendingStakingEventQueueClient.GetQueueName(),
+		q.CovenantSignatureEventQueueClient.GetQueueName(),
+		q.SlashedStakingEventQueueClient.GetQueueName(),
+	}
 }
@@ -0,0 +1,26 @@
+package v2queuehandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2queueschema "github.com/babylonlabs-io/staking-api-service/internal/v2/queue/schema"
+	"github.com/rs/zerolog/log"
+)
+
+// CovenantSignatureHandler records the covenant member of the event as having
+// signed the delegation
+func (h *V2QueueHandler) CovenantSignatureHandler(ctx context.Context, messageBody string) *types.Error {
+	var covenantSignatureEvent v2queueschema.CovenantSignatureEvent
+	err := json.Unmarshal([]byte(messageBody), &covenantSignatureEvent)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to unmarshal the message body into CovenantSignatureEvent")
+		return types.NewError(http.StatusBadRequest, types.BadRequest, err)
+	}
+
+	return h.Service.SaveCovenantSignature(
+		ctx, covenantSignatureEvent.StakingTxHashHex, covenantSignatureEvent.CovenantBtcPkHex,
+	)
+}
@@ -20,6 +20,6 @@ func New(queueHandler *queuehandler.QueueHandler, service v2service.V2ServicePro
 	}
 }
 
-func (qh *V2QueueHandler) HandleUnprocessedMessage(ctx context.Context, messageBody, receipt string) *types.Error {
-	return qh.Service.SaveUnprocessableMessages(ctx, messageBody, receipt)
+func (qh *V2QueueHandler) HandleUnprocessedMessage(ctx context.Context, messageBody, receipt, reason string) *types.Error {
+	return qh.Service.SaveUnprocessableMessages(ctx, messageBody, receipt, reason)
 }
@@ -41,7 +41,7 @@ func (h *V2QueueHandler) PendingStakingHandler(ctx context.Context, messageBody
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to unmarshal the message body into PendingStakingEvent")
 		return types.NewError(http.StatusBadRequest, types.BadRequest, err)
-	}	
+	}
 	return nil
 }
 
@@ -66,3 +66,20 @@ func (h *V2QueueHandler) ExpiredStakingHandler(ctx context.Context, messageBody
 	}
 	return nil
 }
+
+// SlashedStakingHandler records the slashed amount of slashed staking events in the stats
+func (h *V2QueueHandler) SlashedStakingHandler(ctx context.Context, messageBody string) *types.Error {
+	var slashedStakingEvent v2queueschema.SlashedStakingEvent
+	err := json.Unmarshal([]byte(messageBody), &slashedStakingEvent)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to unmarshal the message body into SlashedStakingEvent")
+		return types.NewError(http.StatusBadRequest, types.BadRequest, err)
+	}
+	return h.Service.ProcessSlashedStakingStats(
+		ctx,
+		slashedStakingEvent.StakingTxHashHex,
+		slashedStakingEvent.StakerBtcPkHex,
+		slashedStakingEvent.FinalityProviderBtcPksHex,
+		slashedStakingEvent.SlashedAmount,
+	)
+}
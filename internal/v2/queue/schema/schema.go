@@ -3,40 +3,46 @@
 package v2queueschema
 
 const (
-	ActiveStakingQueueName    string = "active_staking_queue"
-	UnbondingStakingQueueName string = "unbonding_staking_queue"
-	WithdrawStakingQueueName  string = "withdraw_staking_queue"
-	ExpiredStakingQueueName   string = "expired_staking_queue"
-	StakingStatsQueueName     string = "staking_stats_queue"
-	BtcInfoQueueName          string = "btc_info_queue"
-	ConfirmedInfoQueueName    string = "confirmed_info_queue"
-	VerifiedStakingQueueName  string = "verified_staking_queue"
-	PendingStakingQueueName   string = "pending_staking_queue"
+	ActiveStakingQueueName     string = "active_staking_queue"
+	UnbondingStakingQueueName  string = "unbonding_staking_queue"
+	WithdrawStakingQueueName   string = "withdraw_staking_queue"
+	ExpiredStakingQueueName    string = "expired_staking_queue"
+	StakingStatsQueueName      string = "staking_stats_queue"
+	BtcInfoQueueName           string = "btc_info_queue"
+	ConfirmedInfoQueueName     string = "confirmed_info_queue"
+	VerifiedStakingQueueName   string = "verified_staking_queue"
+	PendingStakingQueueName    string = "pending_staking_queue"
+	CovenantSignatureQueueName string = "covenant_signature_queue"
+	SlashedStakingQueueName    string = "slashed_staking_queue"
 )
 
 const (
-	ActiveStakingEventType    EventType = 1
-	UnbondingStakingEventType EventType = 2
-	WithdrawStakingEventType  EventType = 3
-	ExpiredStakingEventType   EventType = 4
-	StatsEventType            EventType = 5
-	BtcInfoEventType          EventType = 6
-	ConfirmedInfoEventType    EventType = 7
-	VerifiedStakingEventType  EventType = 8
-	PendingStakingEventType   EventType = 9
+	ActiveStakingEventType     EventType = 1
+	UnbondingStakingEventType  EventType = 2
+	WithdrawStakingEventType   EventType = 3
+	ExpiredStakingEventType    EventType = 4
+	StatsEventType             EventType = 5
+	BtcInfoEventType           EventType = 6
+	ConfirmedInfoEventType     EventType = 7
+	VerifiedStakingEventType   EventType = 8
+	PendingStakingEventType    EventType = 9
+	CovenantSignatureEventType EventType = 10
+	SlashedStakingEventType    EventType = 11
 )
 
 // Event schema versions, only increment when the schema changes
 const (
-	ActiveEventVersion        int = 0
-	UnbondingEventVersion     int = 0
-	WithdrawEventVersion      int = 1
-	ExpiredEventVersion       int = 0
-	StatsEventVersion         int = 1
-	BtcInfoEventVersion       int = 0
-	ConfirmedInfoEventVersion int = 0
-	VerifiedEventVersion      int = 0
-	PendingEventVersion       int = 0
+	ActiveEventVersion            int = 0
+	UnbondingEventVersion         int = 0
+	WithdrawEventVersion          int = 1
+	ExpiredEventVersion           int = 0
+	StatsEventVersion             int = 1
+	BtcInfoEventVersion           int = 0
+	ConfirmedInfoEventVersion     int = 0
+	VerifiedEventVersion          int = 0
+	PendingEventVersion           int = 0
+	CovenantSignatureEventVersion int = 0
+	SlashedStakingEventVersion    int = 0
 )
 
 type EventType int
@@ -330,3 +336,68 @@ func NewPendingStakingEvent(stakingTxHashHex string) PendingStakingEvent {
 		StakingTxHashHex: stakingTxHashHex,
 	}
 }
+
+// CovenantSignatureEvent is emitted once a covenant member has submitted its
+// signatures for the delegation
+type CovenantSignatureEvent struct {
+	SchemaVersion    int       `json:"schema_version"`
+	EventType        EventType `json:"event_type"` // always 10. CovenantSignatureEventType
+	StakingTxHashHex string    `json:"staking_tx_hash_hex"`
+	CovenantBtcPkHex string    `json:"covenant_btc_pk_hex"`
+	SignatureHex     string    `json:"signature_hex"`
+}
+
+func (e CovenantSignatureEvent) GetEventType() EventType {
+	return CovenantSignatureEventType
+}
+
+func (e CovenantSignatureEvent) GetStakingTxHashHex() string {
+	return e.StakingTxHashHex
+}
+
+func NewCovenantSignatureEvent(
+	stakingTxHashHex string, covenantBtcPkHex string, signatureHex string,
+) CovenantSignatureEvent {
+	return CovenantSignatureEvent{
+		SchemaVersion:    CovenantSignatureEventVersion,
+		EventType:        CovenantSignatureEventType,
+		StakingTxHashHex: stakingTxHashHex,
+		CovenantBtcPkHex: covenantBtcPkHex,
+		SignatureHex:     signatureHex,
+	}
+}
+
+// SlashedStakingEvent is emitted once the delegation has been slashed, the
+// slashed amount is the amount of satoshis burnt by the slashing tx
+type SlashedStakingEvent struct {
+	SchemaVersion             int       `json:"schema_version"`
+	EventType                 EventType `json:"event_type"` // always 11. SlashedStakingEventType
+	StakingTxHashHex          string    `json:"staking_tx_hash_hex"`
+	StakerBtcPkHex            string    `json:"staker_btc_pk_hex"`
+	FinalityProviderBtcPksHex []string  `json:"finality_provider_btc_pks_hex"`
+	SlashedAmount             uint64    `json:"slashed_amount"`
+}
+
+func (e SlashedStakingEvent) GetEventType() EventType {
+	return SlashedStakingEventType
+}
+
+func (e SlashedStakingEvent) GetStakingTxHashHex() string {
+	return e.StakingTxHashHex
+}
+
+func NewSlashedStakingEvent(
+	stakingTxHashHex string,
+	stakerBtcPkHex string,
+	finalityProviderBtcPksHex []string,
+	slashedAmount uint64,
+) SlashedStakingEvent {
+	return SlashedStakingEvent{
+		SchemaVersion:             SlashedStakingEventVersion,
+		EventType:                 SlashedStakingEventType,
+		StakingTxHashHex:          stakingTxHashHex,
+		StakerBtcPkHex:            stakerBtcPkHex,
+		FinalityProviderBtcPksHex: finalityProviderBtcPksHex,
+		SlashedAmount:             slashedAmount,
+	}
+}
@@ -0,0 +1,109 @@
+package v2service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+type BsnPublic struct {
+	BsnId                  string `json:"bsn_id"`
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	Type                   string `json:"type"`
+	RegisteredBbnHeight    int64  `json:"registered_bbn_height"`
+	FinalityProvidersCount int64  `json:"finality_providers_count"`
+	ActiveTvl              int64  `json:"active_tvl"`
+	TotalTvl               int64  `json:"total_tvl"`
+}
+
+// GetBsns gets the registered BSNs along with the TVL aggregated over their
+// finality providers
+func (s *V2Service) GetBsns(ctx context.Context, paginationKey string) ([]*BsnPublic, string, *types.Error) {
+	resultMap, err := s.DbClients.IndexerDBClient.GetBsns(ctx, paginationKey)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching BSNs")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get BSNs")
+		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get BSNs")
+	}
+
+	bsnIds := make([]string, 0, len(resultMap.Data))
+	for _, bsn := range resultMap.Data {
+		bsnIds = append(bsnIds, bsn.BsnId)
+	}
+	fpPksByBsn, err := s.DbClients.IndexerDBClient.GetFinalityProviderPksByBsn(ctx, bsnIds)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get finality providers of BSNs")
+		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get BSNs")
+	}
+	var fpPks []string
+	for _, pks := range fpPksByBsn {
+		fpPks = append(fpPks, pks...)
+	}
+	fpStatsByPk := make(map[string]*v2dbmodel.V2FinalityProviderStatsDocument, len(fpPks))
+	if len(fpPks) > 0 {
+		fpStats, err := s.DbClients.V2DBClient.GetFinalityProvidersStats(ctx, fpPks)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to get finality providers stats of BSNs")
+			return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get BSNs")
+		}
+		for _, stats := range fpStats {
+			fpStatsByPk[stats.FinalityProviderPkHex] = stats
+		}
+	}
+
+	bsnsPublic := make([]*BsnPublic, 0, len(resultMap.Data))
+	for _, bsn := range resultMap.Data {
+		bsnPublic := &BsnPublic{
+			BsnId:                  bsn.BsnId,
+			Name:                   bsn.Name,
+			Description:            bsn.Description,
+			Type:                   bsn.Type,
+			RegisteredBbnHeight:    bsn.RegisteredBbnHeight,
+			FinalityProvidersCount: int64(len(fpPksByBsn[bsn.BsnId])),
+		}
+		// A delegation restakes to at most one finality provider per BSN, hence
+		// summing the finality providers TVL does not double count delegations
+		for _, pk := range fpPksByBsn[bsn.BsnId] {
+			if stats, ok := fpStatsByPk[pk]; ok {
+				bsnPublic.ActiveTvl += stats.ActiveTvl
+				bsnPublic.TotalTvl += stats.TotalTvl
+			}
+		}
+		bsnsPublic = append(bsnsPublic, bsnPublic)
+	}
+	return bsnsPublic, resultMap.PaginationToken, nil
+}
+
+// GetBsnFinalityProviders gets the finality providers securing the BSN
+func (s *V2Service) GetBsnFinalityProviders(
+	ctx context.Context, bsnId string, paginationKey string,
+) ([]*FinalityProviderPublic, string, *types.Error) {
+	if _, err := s.DbClients.IndexerDBClient.GetBsn(ctx, bsnId); err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Str("bsnId", bsnId).Msg("BSN not found")
+			return nil, "", types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "BSN not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Str("bsnId", bsnId).Msg("Failed to get BSN")
+		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get BSN")
+	}
+
+	resultMap, err := s.DbClients.IndexerDBClient.GetFinalityProvidersByBsn(ctx, bsnId, paginationKey)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("bsnId", bsnId).Msg("Failed to get finality providers of BSN")
+		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get finality providers")
+	}
+
+	providersPublic, svcErr := s.mapToFinalityProvidersPublic(ctx, resultMap.Data)
+	if svcErr != nil {
+		return nil, "", svcErr
+	}
+	return providersPublic, resultMap.PaginationToken, nil
+}
@@ -0,0 +1,95 @@
+package v2service
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	indexertypes "github.com/babylonlabs-io/staking-api-service/internal/indexer/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/rs/zerolog/log"
+)
+
+// CovenantQuorumPublic is the progress of the covenant signatures collection,
+// the delegation can only become active once the quorum is reached
+type CovenantQuorumPublic struct {
+	CollectedSignatures uint32   `json:"collected_signatures"`
+	RequiredSignatures  uint32   `json:"required_signatures"`
+	CovenantBtcPksHex   []string `json:"covenant_btc_pks_hex"`
+	QuorumReached       bool     `json:"quorum_reached"`
+}
+
+// SaveCovenantSignature records the covenant member as having signed the
+// delegation
+func (s *V2Service) SaveCovenantSignature(
+	ctx context.Context, stakingTxHashHex, covenantBtcPkHex string,
+) *types.Error {
+	err := s.DbClients.V2DBClient.SaveCovenantSignature(ctx, stakingTxHashHex, covenantBtcPkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("error while saving covenant signature")
+		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+	}
+	return nil
+}
+
+// getCovenantQuorums returns the covenant quorum progress of the delegations,
+// keyed by the staking tx hash. Only the signatures of the covenant members of
+// the delegation params version are counted. The quorum only enriches the
+// delegations, hence it's omitted rather than failing the request if the
+// signatures or the params cannot be read.
+func (s *V2Service) getCovenantQuorums(
+	ctx context.Context, delegations []indexerdbmodel.IndexerDelegationDetails,
+) map[string]*CovenantQuorumPublic {
+	if len(delegations) == 0 {
+		return nil
+	}
+	params, err := s.DbClients.IndexerDBClient.GetBbnStakingParams(ctx)
+	if err != nil && !db.IsNotFoundError(err) {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching babylon params for the covenant quorum")
+		return nil
+	}
+	paramsByVersion := make(map[uint32]*indexertypes.BbnStakingParams, len(params))
+	for _, p := range params {
+		paramsByVersion[p.Version] = p
+	}
+
+	stakingTxHashHexes := make([]string, 0, len(delegations))
+	for _, delegation := range delegations {
+		stakingTxHashHexes = append(stakingTxHashHexes, delegation.StakingTxHashHex)
+	}
+	signatures, err := s.DbClients.V2DBClient.FindCovenantSignatures(ctx, stakingTxHashHexes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching covenant signatures")
+		return nil
+	}
+	signersByTxHash := make(map[string][]string, len(signatures))
+	for _, signature := range signatures {
+		signersByTxHash[signature.StakingTxHashHex] = signature.CovenantBtcPksHex
+	}
+
+	quorums := make(map[string]*CovenantQuorumPublic, len(delegations))
+	for _, delegation := range delegations {
+		quorum := &CovenantQuorumPublic{CovenantBtcPksHex: []string{}}
+		delegationParams, ok := paramsByVersion[delegation.ParamsVersion]
+		if !ok {
+			log.Ctx(ctx).Warn().Uint32("paramsVersion", delegation.ParamsVersion).
+				Str("stakingTxHashHex", delegation.StakingTxHashHex).
+				Msg("babylon params version of the delegation not found")
+			quorums[delegation.StakingTxHashHex] = quorum
+			continue
+		}
+		for _, pk := range signersByTxHash[delegation.StakingTxHashHex] {
+			if slices.Contains(delegationParams.CovenantPks, pk) {
+				quorum.CovenantBtcPksHex = append(quorum.CovenantBtcPksHex, pk)
+			}
+		}
+		quorum.CollectedSignatures = uint32(len(quorum.CovenantBtcPksHex))
+		quorum.RequiredSignatures = delegationParams.CovenantQuorum
+		quorum.QuorumReached = quorum.CollectedSignatures >= quorum.RequiredSignatures
+		quorums[delegation.StakingTxHashHex] = quorum
+	}
+	return quorums
+}
@@ -40,6 +40,8 @@ type StakerDelegationPublic struct {
 	DelegationStaking         DelegationStaking       `json:"delegation_staking"`
 	DelegationUnbonding       DelegationUnbonding     `json:"delegation_unbonding"`
 	State                     v2types.DelegationState `json:"state"`
+	// CovenantQuorum is omitted if the covenant signatures could not be read
+	CovenantQuorum *CovenantQuorumPublic `json:"covenant_quorum,omitempty"`
 }
 
 func (s *V2Service) GetDelegation(ctx context.Context, stakingTxHashHex string) (*StakerDelegationPublic, *types.Error) {
@@ -57,6 +59,10 @@ func (s *V2Service) GetDelegation(ctx context.Context, stakingTxHashHex string)
 		return nil, types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get delegation state")
 	}
 
+	covenantQuorums := s.getCovenantQuorums(
+		ctx, []indexerdbmodel.IndexerDelegationDetails{*delegation},
+	)
+
 	delegationPublic := &StakerDelegationPublic{
 		ParamsVersion:             delegation.ParamsVersion,
 		FinalityProviderBtcPksHex: delegation.FinalityProviderBtcPksHex,
@@ -78,13 +84,17 @@ func (s *V2Service) GetDelegation(ctx context.Context, stakingTxHashHex string)
 				delegation.CovenantUnbondingSignatures,
 			),
 		},
-		State: state,
+		State:          state,
+		CovenantQuorum: covenantQuorums[delegation.StakingTxHashHex],
 	}
 	return delegationPublic, nil
 }
 
-func (s *V2Service) GetDelegations(ctx context.Context, stakerPkHex string, paginationKey string) ([]*StakerDelegationPublic, string, *types.Error) {
-	resultMap, err := s.DbClients.IndexerDBClient.GetDelegations(ctx, stakerPkHex, paginationKey)
+func (s *V2Service) GetDelegations(
+	ctx context.Context, stakerPkHex string,
+	rangeFilter *types.DelegationRangeFilter, paginationKey string,
+) ([]*StakerDelegationPublic, string, *types.Error) {
+	resultMap, err := s.DbClients.IndexerDBClient.GetDelegations(ctx, stakerPkHex, rangeFilter, paginationKey)
 	if err != nil {
 		if db.IsNotFoundError(err) {
 			log.Ctx(ctx).Warn().Err(err).Str("stakingTxHashHex", stakerPkHex).Msg("Staking delegations not found")
@@ -93,6 +103,8 @@ func (s *V2Service) GetDelegations(ctx context.Context, stakerPkHex string, pagi
 		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get staker delegations")
 	}
 
+	covenantQuorums := s.getCovenantQuorums(ctx, resultMap.Data)
+
 	// Initialize result structure
 	delegationsPublic := make([]*StakerDelegationPublic, 0, len(resultMap.Data))
 
@@ -124,7 +136,8 @@ func (s *V2Service) GetDelegations(ctx context.Context, stakerPkHex string, pagi
 					delegation.CovenantUnbondingSignatures,
 				),
 			},
-			State: state,
+			State:          state,
+			CovenantQuorum: covenantQuorums[delegation.StakingTxHashHex],
 		}
 		delegationsPublic = append(delegationsPublic, delegationPublic)
 	}
@@ -132,6 +145,20 @@ func (s *V2Service) GetDelegations(ctx context.Context, stakerPkHex string, pagi
 	return delegationsPublic, resultMap.PaginationToken, nil
 }
 
+// CountDelegations returns the total of the delegations of the staker matching
+// the filters of GetDelegations. The delegations are always counted, as no
+// counter of all the delegations of a staker is maintained.
+func (s *V2Service) CountDelegations(
+	ctx context.Context, stakerPKHex string, rangeFilter *types.DelegationRangeFilter,
+) (*types.TotalCount, *types.Error) {
+	count, err := s.DbClients.IndexerDBClient.CountDelegations(ctx, stakerPKHex, rangeFilter)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakerPkHex", stakerPKHex).Msg("Failed to count staker delegations")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return &types.TotalCount{Count: count}, nil
+}
+
 func getUnbondingSignatures(covenantSignatures []indexerdbmodel.CovenantSignature) []CovenantSignature {
 	covenantSignaturesPublic := make([]CovenantSignature, 0, len(covenantSignatures))
 	for _, covenantSignature := range covenantSignatures {
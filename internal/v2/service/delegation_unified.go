@@ -0,0 +1,206 @@
+package v2service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	v2types "github.com/babylonlabs-io/staking-api-service/internal/v2/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// ProtocolVersionPhase1 is the protocol version of the delegations
+	// staked during phase-1, served by the v1 api
+	ProtocolVersionPhase1 = 1
+	// ProtocolVersionPhase2 is the protocol version of the delegations
+	// registered on the Babylon chain, served by the v2 api
+	ProtocolVersionPhase2 = 2
+)
+
+// UnifiedDelegationPublic is the representation of a phase-1 or a phase-2
+// delegation shared by both protocol versions
+type UnifiedDelegationPublic struct {
+	ProtocolVersion        int      `json:"protocol_version"`
+	StakingTxHashHex       string   `json:"staking_tx_hash_hex"`
+	StakingTxHex           string   `json:"staking_tx_hex"`
+	StakerPkHex            string   `json:"staker_pk_hex"`
+	FinalityProviderPksHex []string `json:"finality_provider_pks_hex"`
+	StakingAmount          uint64   `json:"staking_amount"`
+	StakingTimelock        uint64   `json:"staking_timelock"`
+	StartHeight            uint64   `json:"start_height"`
+	// StartTimestamp is the staking tx timestamp for phase-1 delegations and
+	// the registration timestamp on the Babylon chain for phase-2 ones
+	StartTimestamp string `json:"start_timestamp"`
+	// State is the state of the delegation in the phase-2 vocabulary, which
+	// is a superset of the phase-1 one
+	State v2types.DelegationState `json:"state"`
+}
+
+// unifiedDelegationsPagination tells which protocol version the next page is
+// read from, along with the pagination key within that version
+type unifiedDelegationsPagination struct {
+	ProtocolVersion int    `json:"protocol_version"`
+	PaginationKey   string `json:"pagination_key"`
+}
+
+// GetUnifiedDelegations returns the phase-1 and the phase-2 delegations of the
+// staker as a single list. All the phase-1 delegations are paginated first,
+// followed by the phase-2 ones. A phase-1 delegation registered on the Babylon
+// chain is only listed as a phase-2 one.
+func (s *V2Service) GetUnifiedDelegations(
+	ctx context.Context, stakerPkHex string,
+	rangeFilter *types.DelegationRangeFilter, paginationKey string,
+) ([]*UnifiedDelegationPublic, string, *types.Error) {
+	page := &unifiedDelegationsPagination{ProtocolVersion: ProtocolVersionPhase1}
+	if paginationKey != "" {
+		decoded, err := dbmodel.DecodePaginationToken[unifiedDelegationsPagination](paginationKey)
+		if err != nil || (decoded.ProtocolVersion != ProtocolVersionPhase1 &&
+			decoded.ProtocolVersion != ProtocolVersionPhase2) {
+			return nil, "", types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid pagination key")
+		}
+		page = decoded
+	}
+
+	if page.ProtocolVersion == ProtocolVersionPhase1 {
+		delegations, nextKey, err := s.getPhase1UnifiedDelegations(ctx, stakerPkHex, rangeFilter, page.PaginationKey)
+		if err != nil {
+			return nil, "", err
+		}
+		if nextKey != "" {
+			token, tokenErr := unifiedDelegationsPaginationToken(ProtocolVersionPhase1, nextKey)
+			if tokenErr != nil {
+				return nil, "", tokenErr
+			}
+			return delegations, token, nil
+		}
+		// The phase-2 delegations are only read once the phase-1 ones are
+		// exhausted, right away if the page would otherwise be empty
+		if len(delegations) > 0 {
+			token, tokenErr := unifiedDelegationsPaginationToken(ProtocolVersionPhase2, "")
+			if tokenErr != nil {
+				return nil, "", tokenErr
+			}
+			return delegations, token, nil
+		}
+		page = &unifiedDelegationsPagination{ProtocolVersion: ProtocolVersionPhase2}
+	}
+
+	delegations, nextKey, err := s.getPhase2UnifiedDelegations(ctx, stakerPkHex, rangeFilter, page.PaginationKey)
+	if err != nil {
+		return nil, "", err
+	}
+	if nextKey == "" {
+		return delegations, "", nil
+	}
+	token, tokenErr := unifiedDelegationsPaginationToken(ProtocolVersionPhase2, nextKey)
+	if tokenErr != nil {
+		return nil, "", tokenErr
+	}
+	return delegations, token, nil
+}
+
+func (s *V2Service) getPhase1UnifiedDelegations(
+	ctx context.Context, stakerPkHex string,
+	rangeFilter *types.DelegationRangeFilter, paginationKey string,
+) ([]*UnifiedDelegationPublic, string, *types.Error) {
+	filter := &v1dbclient.DelegationFilter{}
+	if rangeFilter != nil {
+		filter.MinStakingValue = rangeFilter.MinValue
+		filter.MaxStakingValue = rangeFilter.MaxValue
+		filter.AfterTimestamp = rangeFilter.FromTimestamp
+		filter.BeforeTimestamp = rangeFilter.ToTimestamp
+		filter.IncludeArchived = rangeFilter.IncludeArchived
+	}
+	resultMap, err := s.DbClients.V1DBClient.FindDelegationsByStakerPk(ctx, stakerPkHex, filter, paginationKey)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			return nil, "", types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid pagination key")
+		}
+		log.Ctx(ctx).Error().Err(err).Str("stakerPkHex", stakerPkHex).Msg("Failed to find phase-1 delegations by staker pk")
+		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get phase-1 delegations")
+	}
+	stakingTxHashes := make([]string, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		stakingTxHashes = append(stakingTxHashes, d.StakingTxHashHex)
+	}
+	// The staking tx hash is kept by the registration on the Babylon chain,
+	// the registered delegations are left to the phase-2 pages
+	registered, err := s.DbClients.IndexerDBClient.GetRegisteredStakingTxHashes(ctx, stakingTxHashes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakerPkHex", stakerPkHex).Msg("Failed to find the registered phase-1 delegations")
+		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get phase-1 delegations")
+	}
+	delegations := make([]*UnifiedDelegationPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		if registered[d.StakingTxHashHex] {
+			continue
+		}
+		state, err := v2types.MapPhase1DelegationState(d.State, d.UnbondingTx != nil)
+		if err != nil {
+			return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get delegation state")
+		}
+		delegations = append(delegations, &UnifiedDelegationPublic{
+			ProtocolVersion:        ProtocolVersionPhase1,
+			StakingTxHashHex:       d.StakingTxHashHex,
+			StakingTxHex:           d.StakingTx.TxHex,
+			StakerPkHex:            d.StakerPkHex,
+			FinalityProviderPksHex: []string{d.FinalityProviderPkHex},
+			StakingAmount:          d.StakingValue,
+			StakingTimelock:        d.StakingTx.TimeLock,
+			StartHeight:            d.StakingTx.StartHeight,
+			StartTimestamp:         utils.ParseTimestampToIsoFormat(d.StakingTx.StartTimestamp),
+			State:                  state,
+		})
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+func (s *V2Service) getPhase2UnifiedDelegations(
+	ctx context.Context, stakerPkHex string,
+	rangeFilter *types.DelegationRangeFilter, paginationKey string,
+) ([]*UnifiedDelegationPublic, string, *types.Error) {
+	resultMap, err := s.DbClients.IndexerDBClient.GetDelegations(ctx, stakerPkHex, rangeFilter, paginationKey)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			return nil, "", types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid pagination key")
+		}
+		log.Ctx(ctx).Error().Err(err).Str("stakerPkHex", stakerPkHex).Msg("Failed to get phase-2 delegations by staker pk")
+		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get phase-2 delegations")
+	}
+	delegations := make([]*UnifiedDelegationPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		state, err := v2types.MapDelegationState(d.State, d.SubState)
+		if err != nil {
+			return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get delegation state")
+		}
+		delegations = append(delegations, &UnifiedDelegationPublic{
+			ProtocolVersion:        ProtocolVersionPhase2,
+			StakingTxHashHex:       d.StakingTxHashHex,
+			StakingTxHex:           d.StakingTxHex,
+			StakerPkHex:            d.StakerBtcPkHex,
+			FinalityProviderPksHex: d.FinalityProviderBtcPksHex,
+			StakingAmount:          d.StakingAmount,
+			StakingTimelock:        uint64(d.StakingTime),
+			StartHeight:            uint64(d.StartHeight),
+			StartTimestamp:         utils.ParseTimestampToIsoFormat(d.BTCDelegationCreatedBbnBlock.Timestamp),
+			State:                  state,
+		})
+	}
+	return delegations, resultMap.PaginationToken, nil
+}
+
+func unifiedDelegationsPaginationToken(protocolVersion int, paginationKey string) (string, *types.Error) {
+	token, err := dbmodel.GetPaginationToken(unifiedDelegationsPagination{
+		ProtocolVersion: protocolVersion,
+		PaginationKey:   paginationKey,
+	})
+	if err != nil {
+		return "", types.NewInternalServiceError(err)
+	}
+	return token, nil
+}
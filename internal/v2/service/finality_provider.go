@@ -7,6 +7,7 @@ import (
 	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
 	"github.com/rs/zerolog/log"
 )
 
@@ -19,6 +20,11 @@ type FinalityProviderPublic struct {
 	TotalTvl          int64                               `json:"total_tvl"`
 	ActiveDelegations int64                               `json:"active_delegations"`
 	TotalDelegations  int64                               `json:"total_delegations"`
+	BsnId             string                              `json:"bsn_id,omitempty"`
+	// SlashedTvl and SlashedDelegations are the realized slashing of the
+	// delegations to the finality provider
+	SlashedTvl         int64 `json:"slashed_tvl"`
+	SlashedDelegations int64 `json:"slashed_delegations"`
 }
 
 type FinalityProvidersPublic struct {
@@ -36,9 +42,44 @@ func mapToFinalityProviderPublic(provider indexerdbmodel.IndexerFinalityProvider
 		TotalTvl:          0,
 		ActiveDelegations: 0,
 		TotalDelegations:  0,
+		BsnId:             provider.BsnId,
 	}
 }
 
+// mapToFinalityProvidersPublic maps the finality providers along with their
+// slashing stats
+func (s *V2Service) mapToFinalityProvidersPublic(
+	ctx context.Context, providers []indexerdbmodel.IndexerFinalityProviderDetails,
+) ([]*FinalityProviderPublic, *types.Error) {
+	providersPublic := make([]*FinalityProviderPublic, 0, len(providers))
+	if len(providers) == 0 {
+		return providersPublic, nil
+	}
+	fpPks := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		fpPks = append(fpPks, provider.BtcPk)
+	}
+	fpStats, err := s.DbClients.V2DBClient.GetFinalityProvidersStats(ctx, fpPks)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get finality providers stats")
+		return nil, types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get finality providers")
+	}
+	fpStatsByPk := make(map[string]*v2dbmodel.V2FinalityProviderStatsDocument, len(fpStats))
+	for _, stats := range fpStats {
+		fpStatsByPk[stats.FinalityProviderPkHex] = stats
+	}
+
+	for _, provider := range providers {
+		providerPublic := mapToFinalityProviderPublic(provider)
+		if stats, ok := fpStatsByPk[provider.BtcPk]; ok {
+			providerPublic.SlashedTvl = stats.SlashedTvl
+			providerPublic.SlashedDelegations = stats.SlashedDelegations
+		}
+		providersPublic = append(providersPublic, providerPublic)
+	}
+	return providersPublic, nil
+}
+
 // GetFinalityProviders gets a list of finality providers with optional filters
 func (s *V2Service) GetFinalityProviders(ctx context.Context, state types.FinalityProviderQueryingState, paginationKey string) ([]*FinalityProviderPublic, string, *types.Error) {
 	resultMap, err := s.DbClients.IndexerDBClient.GetFinalityProviders(ctx, state, paginationKey)
@@ -50,9 +91,9 @@ func (s *V2Service) GetFinalityProviders(ctx context.Context, state types.Finali
 		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get finality providers")
 	}
 
-	providersPublic := make([]*FinalityProviderPublic, 0, len(resultMap.Data))
-	for _, provider := range resultMap.Data {
-		providersPublic = append(providersPublic, mapToFinalityProviderPublic(provider))
+	providersPublic, svcErr := s.mapToFinalityProvidersPublic(ctx, resultMap.Data)
+	if svcErr != nil {
+		return nil, "", svcErr
 	}
 	return providersPublic, resultMap.PaginationToken, nil
 }
@@ -68,9 +109,9 @@ func (s *V2Service) SearchFinalityProviders(ctx context.Context, searchQuery str
 		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to search finality providers")
 	}
 
-	providersPublic := make([]*FinalityProviderPublic, 0, len(resultMap.Data))
-	for _, provider := range resultMap.Data {
-		providersPublic = append(providersPublic, mapToFinalityProviderPublic(provider))
+	providersPublic, svcErr := s.mapToFinalityProvidersPublic(ctx, resultMap.Data)
+	if svcErr != nil {
+		return nil, "", svcErr
 	}
 	return providersPublic, resultMap.PaginationToken, nil
 }
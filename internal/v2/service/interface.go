@@ -13,7 +13,15 @@ type V2ServiceProvider interface {
 	SearchFinalityProviders(ctx context.Context, searchQuery string, paginationKey string) ([]*FinalityProviderPublic, string, *types.Error)
 	GetParams(ctx context.Context) (*ParamsPublic, *types.Error)
 	GetDelegation(ctx context.Context, stakingTxHashHex string) (*StakerDelegationPublic, *types.Error)
-	GetDelegations(ctx context.Context, stakerPKHex string, paginationKey string) ([]*StakerDelegationPublic, string, *types.Error)
+	GetDelegations(ctx context.Context, stakerPKHex string, rangeFilter *types.DelegationRangeFilter, paginationKey string) ([]*StakerDelegationPublic, string, *types.Error)
+	CountDelegations(ctx context.Context, stakerPKHex string, rangeFilter *types.DelegationRangeFilter) (*types.TotalCount, *types.Error)
+	GetUnifiedDelegations(ctx context.Context, stakerPkHex string, rangeFilter *types.DelegationRangeFilter, paginationKey string) ([]*UnifiedDelegationPublic, string, *types.Error)
 	GetOverallStats(ctx context.Context) (*OverallStatsPublic, *types.Error)
+	RefreshOverallStats(ctx context.Context) *types.Error
 	GetStakerStats(ctx context.Context, stakerPKHex string) (*StakerStatsPublic, *types.Error)
+	GetDelegationTransitionStatus(ctx context.Context, stakingTxHashHex string) (*DelegationTransitionStatusPublic, *types.Error)
+	GetBsns(ctx context.Context, paginationKey string) ([]*BsnPublic, string, *types.Error)
+	GetBsnFinalityProviders(ctx context.Context, bsnId string, paginationKey string) ([]*FinalityProviderPublic, string, *types.Error)
+	ProcessSlashedStakingStats(ctx context.Context, stakingTxHashHex, stakerPkHex string, fpPkHexes []string, slashedAmount uint64) *types.Error
+	SaveCovenantSignature(ctx context.Context, stakingTxHashHex, covenantBtcPkHex string) *types.Error
 }
@@ -2,8 +2,11 @@ package v2service
 
 import (
 	"context"
+	"time"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
 	"github.com/rs/zerolog/log"
 )
 
@@ -17,16 +20,18 @@ type OverallStatsPublic struct {
 	TotalStakers            uint64 `json:"total_stakers"`
 	ActiveFinalityProviders uint64 `json:"active_finality_providers"`
 	TotalFinalityProviders  uint64 `json:"total_finality_providers"`
+	SlashedTvl              int64  `json:"slashed_tvl"`
+	SlashedDelegations      int64  `json:"slashed_delegations"`
 }
 
 type StakerStatsPublic struct {
-	StakerPkHex                  string            `json:"_id"`
-	ActiveTvl                    int64             `json:"active_tvl"`
-	WithdrawableTvl              int64             `json:"withdrawable_tvl"`
-	SlashedTvl                   int64             `json:"slashed_tvl"`
-	ActiveDelegations            uint32            `json:"active_delegations"`
-	WithdrawableDelegations      uint32            `json:"withdrawable_delegations"`
-	SlashedDelegations           uint32            `json:"slashed_delegations"`
+	StakerPkHex             string `json:"_id"`
+	ActiveTvl               int64  `json:"active_tvl"`
+	WithdrawableTvl         int64  `json:"withdrawable_tvl"`
+	SlashedTvl              int64  `json:"slashed_tvl"`
+	ActiveDelegations       uint32 `json:"active_delegations"`
+	WithdrawableDelegations uint32 `json:"withdrawable_delegations"`
+	SlashedDelegations      uint32 `json:"slashed_delegations"`
 }
 
 func (s *V2Service) GetStakerStats(ctx context.Context, stakerPKHex string) (*StakerStatsPublic, *types.Error) {
@@ -37,18 +42,46 @@ func (s *V2Service) GetStakerStats(ctx context.Context, stakerPKHex string) (*St
 	}
 
 	return &StakerStatsPublic{
-		StakerPkHex:                  stakerStats.StakerPkHex,
-		ActiveTvl:                    stakerStats.ActiveTvl,
-		WithdrawableTvl:              stakerStats.WithdrawableTvl,
-		SlashedTvl:                   stakerStats.SlashedTvl,
-		ActiveDelegations:            stakerStats.ActiveDelegations,
-		WithdrawableDelegations:      stakerStats.WithdrawableDelegations,
-		SlashedDelegations:            stakerStats.SlashedDelegations,
+		StakerPkHex:             stakerStats.StakerPkHex,
+		ActiveTvl:               stakerStats.ActiveTvl,
+		WithdrawableTvl:         stakerStats.WithdrawableTvl,
+		SlashedTvl:              stakerStats.SlashedTvl,
+		ActiveDelegations:       stakerStats.ActiveDelegations,
+		WithdrawableDelegations: stakerStats.WithdrawableDelegations,
+		SlashedDelegations:      stakerStats.SlashedDelegations,
 	}, nil
 }
 
+// findOverallStats serves the materialized overall stats if the stats
+// refresher is enabled and the stats are fresh enough, otherwise the stats
+// are summed up from the shards.
+func (s *V2Service) findOverallStats(ctx context.Context) (*v2dbmodel.V2OverallStatsDocument, error) {
+	if refresherCfg := s.Service.Cfg.StatsRefresher; refresherCfg != nil {
+		materialized, err := s.Service.DbClients.V2DBClient.GetMaterializedOverallStats(ctx)
+		if err == nil {
+			if s.Service.Clock.Now().Sub(time.Unix(materialized.LastRefreshedAt, 0)) <= refresherCfg.MaxStaleness {
+				return &materialized.V2OverallStatsDocument, nil
+			}
+			log.Ctx(ctx).Warn().Int64("lastRefreshedAt", materialized.LastRefreshedAt).
+				Msg("materialized overall stats are stale, falling back to the shards")
+		} else if !db.IsNotFoundError(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("error while fetching materialized overall stats")
+		}
+	}
+	return s.Service.DbClients.V2DBClient.GetOverallStats(ctx)
+}
+
+// RefreshOverallStats refreshes the materialized overall stats
+func (s *V2Service) RefreshOverallStats(ctx context.Context) *types.Error {
+	if err := s.Service.DbClients.V2DBClient.RefreshMaterializedOverallStats(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while refreshing materialized overall stats")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
 func (s *V2Service) GetOverallStats(ctx context.Context) (*OverallStatsPublic, *types.Error) {
-	overallStats, err := s.Service.DbClients.V2DBClient.GetOverallStats(ctx)
+	overallStats, err := s.findOverallStats(ctx)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while fetching overall stats")
 		return nil, types.NewInternalServiceError(err)
@@ -64,5 +97,27 @@ func (s *V2Service) GetOverallStats(ctx context.Context) (*OverallStatsPublic, *
 		TotalStakers:            overallStats.TotalStakers,
 		ActiveFinalityProviders: overallStats.ActiveFinalityProviders,
 		TotalFinalityProviders:  overallStats.TotalFinalityProviders,
+		SlashedTvl:              overallStats.SlashedTvl,
+		SlashedDelegations:      overallStats.SlashedDelegations,
 	}, nil
 }
+
+// ProcessSlashedStakingStats records the slashed amount of the delegation in
+// the stats. This method tolerates duplicated calls, only the first call will be processed.
+func (s *V2Service) ProcessSlashedStakingStats(
+	ctx context.Context, stakingTxHashHex, stakerPkHex string, fpPkHexes []string, slashedAmount uint64,
+) *types.Error {
+	err := s.Service.DbClients.V2DBClient.IncrementSlashedStats(
+		ctx, stakingTxHashHex, stakerPkHex, fpPkHexes, slashedAmount,
+	)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			// This is a duplicate call, ignore it
+			return nil
+		}
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("error while incrementing slashed stats")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package v2service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2types "github.com/babylonlabs-io/staking-api-service/internal/v2/types"
+	"github.com/rs/zerolog/log"
+)
+
+// TransitionStatus is the status of the registration of a phase-1 delegation
+// on the Babylon chain
+type TransitionStatus string
+
+const (
+	// TransitionStatusRegistered means the delegation is registered and
+	// verified on the Babylon chain
+	TransitionStatusRegistered TransitionStatus = "REGISTERED"
+	// TransitionStatusPending means the delegation is registered on the
+	// Babylon chain but still waiting for the covenant signatures or the
+	// inclusion proof to be submitted
+	TransitionStatusPending TransitionStatus = "PENDING"
+	// TransitionStatusEligible means the delegation is not registered yet and
+	// can be registered on the Babylon chain
+	TransitionStatusEligible TransitionStatus = "ELIGIBLE"
+	// TransitionStatusIneligible means the delegation is not registered and
+	// can no longer be registered on the Babylon chain
+	TransitionStatusIneligible TransitionStatus = "INELIGIBLE"
+)
+
+type DelegationTransitionStatusPublic struct {
+	StakingTxHashHex string           `json:"staking_tx_hash_hex"`
+	Status           TransitionStatus `json:"status"`
+	Phase1State      string           `json:"phase1_state"`
+	// Phase2State is the state of the delegation on the Babylon chain, only
+	// set once the delegation is registered
+	Phase2State v2types.DelegationState `json:"phase2_state,omitempty"`
+	// Reason tells why the delegation is not eligible for transition
+	Reason string `json:"reason,omitempty"`
+}
+
+// GetDelegationTransitionStatus reports whether the phase-1 delegation has
+// been registered on the Babylon chain, by combining the phase-1 delegation
+// with the delegation registered on the Babylon chain if any.
+func (s *V2Service) GetDelegationTransitionStatus(
+	ctx context.Context, stakingTxHashHex string,
+) (*DelegationTransitionStatusPublic, *types.Error) {
+	phase1Delegation, err := s.DbClients.V1DBClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Str("stakingTxHashHex", stakingTxHashHex).Msg("Phase-1 delegation not found")
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "phase-1 delegation not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).Msg("Failed to get phase-1 delegation")
+		return nil, types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get phase-1 delegation")
+	}
+	status := &DelegationTransitionStatusPublic{
+		StakingTxHashHex: stakingTxHashHex,
+		Phase1State:      phase1Delegation.State.ToString(),
+	}
+
+	phase2Delegation, err := s.DbClients.IndexerDBClient.GetDelegation(ctx, stakingTxHashHex)
+	if err != nil && !db.IsNotFoundError(err) {
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).Msg("Failed to get phase-2 delegation")
+		return nil, types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get phase-2 delegation")
+	}
+	if phase2Delegation != nil {
+		state, err := v2types.MapDelegationState(phase2Delegation.State, phase2Delegation.SubState)
+		if err != nil {
+			return nil, types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "failed to get delegation state")
+		}
+		status.Phase2State = state
+		status.Status = TransitionStatusRegistered
+		if state == v2types.StatePending || state == v2types.StateVerified {
+			status.Status = TransitionStatusPending
+		}
+		return status, nil
+	}
+
+	// Only the delegations still locked in the staking output can be registered
+	if phase1Delegation.State != types.Active {
+		status.Status = TransitionStatusIneligible
+		status.Reason = fmt.Sprintf("phase-1 delegation is %s", phase1Delegation.State.ToString())
+		return status, nil
+	}
+	status.Status = TransitionStatusEligible
+	return status, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	indexertypes "github.com/babylonlabs-io/staking-api-service/internal/indexer/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 )
 
 // DelegationState represents the flattened state for frontend consumption
@@ -89,3 +90,29 @@ func MapDelegationState(state indexertypes.DelegationState, subState indexertype
 
 	return "", fmt.Errorf("invalid state/subState combination: state=%s, subState=%s", state, subState)
 }
+
+// MapPhase1DelegationState maps the state of a phase-1 delegation to the
+// frontend-facing states. The phase-1 delegations are never slashed, the
+// unbonding ones went through an unbonding tx i.e. early unbonding.
+func MapPhase1DelegationState(state types.DelegationState, earlyUnbonded bool) (DelegationState, error) {
+	switch state {
+	case types.Pending:
+		return StatePending, nil
+	case types.Active:
+		return StateActive, nil
+	case types.UnbondingRequested, types.Unbonding:
+		return StateEarlyUnbonding, nil
+	case types.Unbonded:
+		if earlyUnbonded {
+			return StateEarlyUnbondingWithdrawable, nil
+		}
+		return StateTimelockWithdrawable, nil
+	case types.Withdrawn:
+		if earlyUnbonded {
+			return StateEarlyUnbondingWithdrawn, nil
+		}
+		return StateTimelockWithdrawn, nil
+	}
+
+	return "", fmt.Errorf("invalid phase-1 state: %s", state)
+}
@@ -0,0 +1,325 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api"
+	handler "github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminRuntimeStatsPath = "/debug/runtime"
+	adminPprofPath        = "/debug/pprof/"
+)
+
+func adminGet(t *testing.T, testServer *TestServer, path, token string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, testServer.AdminServer.URL+path, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "making GET request to admin endpoint should not fail")
+	return resp
+}
+
+func TestAdminEndpointsRequireAuthToken(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	require.NotNil(t, testServer.AdminServer)
+
+	for _, path := range []string{adminRuntimeStatsPath, adminPprofPath, adminPprofPath + "heap"} {
+		resp := adminGet(t, testServer, path, "")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, path)
+
+		resp = adminGet(t, testServer, path, "wrong-token")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, path)
+	}
+}
+
+func TestAdminRuntimeStats(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp := adminGet(t, testServer, adminRuntimeStatsPath, testServer.Config.Admin.AuthToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "reading response body should not fail")
+	var response handler.PublicResponse[service.RuntimeStatsPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+
+	assert.Positive(t, response.Data.Goroutines)
+	assert.Positive(t, response.Data.HeapAllocBytes)
+	assert.LessOrEqual(t, len(response.Data.GC.RecentPausesMs), 10)
+	assert.GreaterOrEqual(t, response.Data.MongoSessions.StakingDb, 0)
+}
+
+func TestAdminPprof(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp := adminGet(t, testServer, adminPprofPath, testServer.Config.Admin.AuthToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = adminGet(t, testServer, adminPprofPath+"goroutine?debug=1", testServer.Config.Admin.AuthToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(bodyBytes), "goroutine profile")
+}
+
+func TestPprofIsNotServedOnThePublicListener(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + adminPprofPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func adminRequest(t *testing.T, testServer *TestServer, method, path, body string) *http.Response {
+	req, err := http.NewRequest(method, testServer.AdminServer.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testServer.Config.Admin.AuthToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "making request to admin endpoint should not fail")
+	return resp
+}
+
+func TestAdminHealthDetails(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp := adminGet(t, testServer, "/health/details", testServer.Config.Admin.AuthToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var response handler.PublicResponse[handler.HealthDetailsPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+	assert.Equal(t, service.HealthStatusHealthy, response.Data.Status)
+	var names []string
+	for _, component := range response.Data.Components {
+		names = append(names, component.Name)
+		assert.Equal(t, service.HealthStatusHealthy, component.Status, component.Name)
+	}
+	assert.Equal(t, []string{"staking_db", "indexer_db", "queue_connections", "queue_lag"}, names)
+}
+
+func TestAdminMetrics(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp := adminGet(t, testServer, "/metrics", testServer.Config.Admin.AuthToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestMaintenanceModeRejectsPublicRequests(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp := adminRequest(t, testServer, http.MethodPut, "/admin/maintenance", `{"enabled":true}`)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err := http.Get(testServer.Server.URL + "/v1/stats")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	// The health check is still served for the load balancers
+	resp, err = http.Get(testServer.Server.URL + healthCheckPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = adminRequest(t, testServer, http.MethodPut, "/admin/maintenance", `{"enabled":false}`)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(testServer.Server.URL + "/v1/stats")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestAdminReplayUnprocessableMessages(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	activeStakingEvent := buildActiveStakingEvent(t, 1)
+	data, err := json.Marshal(activeStakingEvent[0])
+	require.NoError(t, err)
+	testutils.InjectDbDocument(
+		testServer.Config, dbmodel.V1UnprocessableMsgCollection,
+		dbmodel.NewUnprocessableMessageDocument(string(data), "receipt", "exceeded retry attempts"),
+	)
+
+	resp := adminRequest(t, testServer, http.MethodPost, "/admin/unprocessable-messages/replay", "")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var response handler.PublicResponse[api.ReplayPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+	assert.Equal(t, 1, response.Data.Replayed)
+
+	time.Sleep(2 * time.Second)
+	docs, err := testutils.InspectDbDocuments[dbmodel.UnprocessableMessageDocument](
+		testServer.Config, dbmodel.V1UnprocessableMsgCollection,
+	)
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+}
+
+func TestAdminReplayUnprocessableMessagesDryRun(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	activeStakingEvent := buildActiveStakingEvent(t, 1)
+	data, err := json.Marshal(activeStakingEvent[0])
+	require.NoError(t, err)
+	testutils.InjectDbDocument(
+		testServer.Config, dbmodel.V1UnprocessableMsgCollection,
+		dbmodel.NewUnprocessableMessageDocument(string(data), "receipt", "exceeded retry attempts"),
+	)
+
+	resp := adminRequest(t, testServer, http.MethodPost, "/admin/unprocessable-messages/replay?dry_run=true", "")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var response handler.PublicResponse[api.ReplayPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+	assert.Equal(t, 1, response.Data.Replayed)
+	assert.True(t, response.Data.DryRun)
+
+	// The message is left in the db
+	docs, err := testutils.InspectDbDocuments[dbmodel.UnprocessableMessageDocument](
+		testServer.Config, dbmodel.V1UnprocessableMsgCollection,
+	)
+	require.NoError(t, err)
+	assert.Len(t, docs, 1)
+}
+
+func TestMaintenanceModeDryRun(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp := adminRequest(t, testServer, http.MethodPut, "/admin/maintenance?dry_run=true", `{"enabled":true}`)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var response handler.PublicResponse[api.DryRunPublic[api.MaintenancePublic]]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+	assert.True(t, response.Data.DryRun)
+	assert.False(t, response.Data.Before.Enabled)
+	assert.True(t, response.Data.After.Enabled)
+
+	// The public requests are still served
+	statsResp, err := http.Get(testServer.Server.URL + "/v1/stats")
+	require.NoError(t, err)
+	statsResp.Body.Close()
+	assert.Equal(t, http.StatusOK, statsResp.StatusCode)
+
+	invalidResp := adminRequest(t, testServer, http.MethodPut, "/admin/maintenance?dry_run=maybe", `{"enabled":true}`)
+	invalidResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, invalidResp.StatusCode)
+}
+
+func TestAdminScheduledJobs(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	startedAt := time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)
+	testutils.InjectDbDocument(testServer.Config, dbmodel.ScheduledJobsCollection, &dbmodel.ScheduledJobDocument{
+		Name:           "delegation_archiver",
+		Holder:         "replica-a",
+		LastTrigger:    dbmodel.ScheduledJobTriggerSchedule,
+		LastOutcome:    dbmodel.ScheduledJobSucceeded,
+		LastStartedAt:  startedAt,
+		LastFinishedAt: startedAt.Add(3 * time.Second),
+		LastDurationMs: 3000,
+		SuccessCount:   1,
+	})
+
+	// The test server does not run the scheduled jobs, the runs of the other
+	// replicas are listed
+	resp := adminGet(t, testServer, "/admin/jobs", testServer.Config.Admin.AuthToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var response handler.PublicResponse[[]api.ScheduledJobPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, api.ScheduledJobPublic{
+		Name: "delegation_archiver",
+		LastRun: &api.ScheduledJobRunPublic{
+			Holder:       "replica-a",
+			Trigger:      "schedule",
+			Outcome:      "succeeded",
+			StartedAt:    "2024-06-01T03:00:00Z",
+			FinishedAt:   "2024-06-01T03:00:03Z",
+			DurationMs:   3000,
+			SuccessCount: 1,
+		},
+	}, response.Data[0])
+
+	triggerResp := adminRequest(t, testServer, http.MethodPost, "/admin/jobs/stats_refresher/trigger", "")
+	triggerResp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, triggerResp.StatusCode)
+}
+
+func TestAdminSlo(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	statsResp, err := http.Get(testServer.Server.URL + "/v1/stats")
+	require.NoError(t, err)
+	statsResp.Body.Close()
+
+	resp := adminGet(t, testServer, "/admin/slo", testServer.Config.Admin.AuthToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var response handler.PublicResponse[[]api.RouteSloPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+	require.NotEmpty(t, response.Data)
+	assert.Equal(t, "/v1/stats", response.Data[0].Route)
+	assert.Equal(t, int64(1), response.Data[0].Requests)
+}
+
+func TestAdminIndexReport(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp := adminGet(t, testServer, "/admin/db/index-report", testServer.Config.Admin.AuthToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var response handler.PublicResponse[service.IndexReportPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+	assert.NotEmpty(t, response.Data.Indexes)
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomDelegationDocument(r *rand.Rand) *v1dbmodel.DelegationDocument {
+	_, stakingTxHashHex := testutils.RandomBytes(r, 32)
+	return &v1dbmodel.DelegationDocument{
+		StakingTxHashHex:      stakingTxHashHex,
+		StakerPkHex:           testutils.GeneratePks(1)[0],
+		FinalityProviderPkHex: testutils.GeneratePks(1)[0],
+		StakingValue:          uint64(testutils.RandomAmount(r)),
+		State:                 types.Active,
+		StakingTx: &v1dbmodel.TimelockTransaction{
+			TxHex:          "00",
+			StartTimestamp: time.Now().Unix(),
+			StartHeight:    100,
+			TimeLock:       1000,
+		},
+	}
+}
+
+func TestBulkWriteReportsFailuresPerItem(t *testing.T) {
+	ctx := context.Background()
+	cfg := testutils.LoadTestConfig()
+	dbClients := testutils.SetupTestDB(*cfg)
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	delegations := []*v1dbmodel.DelegationDocument{
+		randomDelegationDocument(r), randomDelegationDocument(r), randomDelegationDocument(r),
+	}
+	result, err := dbClients.V1DBClient.BulkSaveActiveStakingDelegations(ctx, delegations)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.Written)
+	assert.Empty(t, result.Failed)
+
+	// The duplicate doesn't prevent the new delegation from being saved
+	newDelegation := randomDelegationDocument(r)
+	result, err = dbClients.V1DBClient.BulkSaveActiveStakingDelegations(
+		ctx, []*v1dbmodel.DelegationDocument{delegations[1], newDelegation},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Written)
+	require.Len(t, result.Failed, 1)
+	assert.True(t, db.IsDuplicateKeyError(result.Failed[0]))
+	saved, err := dbClients.V1DBClient.FindDelegationByTxHashHex(ctx, newDelegation.StakingTxHashHex)
+	require.NoError(t, err)
+	assert.NotZero(t, saved.ChangeSeq)
+
+	// The delegation not found is reported, the others are transitioned
+	result, err = dbClients.V1DBClient.BulkTransitionToUnbondedState(ctx, []string{
+		delegations[0].StakingTxHashHex, "missing", delegations[2].StakingTxHashHex,
+	}, utils.QualifiedStatesToUnbonded(types.ActiveTxType))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Written)
+	require.Len(t, result.Failed, 1)
+	assert.True(t, db.IsNotFoundError(result.Failed[1]))
+
+	// Only the unbonded delegations are eligible to be withdrawn
+	withdrawalTx := &v1dbmodel.WithdrawalTransaction{TxHashHex: "withdrawal"}
+	result, err = dbClients.V1DBClient.BulkTransitionToWithdrawnState(ctx,
+		[]string{delegations[0].StakingTxHashHex, delegations[1].StakingTxHashHex},
+		map[string]*v1dbmodel.WithdrawalTransaction{delegations[0].StakingTxHashHex: withdrawalTx},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Written)
+	require.Len(t, result.Failed, 1)
+	assert.True(t, db.IsNotFoundError(result.Failed[1]))
+	withdrawn, err := dbClients.V1DBClient.FindDelegationByTxHashHex(ctx, delegations[0].StakingTxHashHex)
+	require.NoError(t, err)
+	assert.Equal(t, types.Withdrawn, withdrawn.State)
+	require.NotNil(t, withdrawn.WithdrawalTx)
+	assert.Equal(t, "withdrawal", withdrawn.WithdrawalTx.TxHashHex)
+}
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+)
+
+const delegationChangesUrl = "/v1/delegations/changes"
+
+func TestDelegationChanges(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := testutils.GenerateRandomActiveStakingEvents(r, &testutils.TestActiveEventGeneratorOpts{
+		NumOfEvents:       3,
+		FinalityProviders: testutils.GeneratePks(3),
+		Stakers:           testutils.GeneratePks(3),
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, activeStakingEvents)
+	// Wait for the changes to be processed
+	time.Sleep(5 * time.Second)
+
+	url := testServer.Server.URL + delegationChangesUrl
+	response := fetchSuccessfulResponse[[]v1service.DelegationChangePublic](t, url)
+	assert.Len(t, response.Data, 3)
+	for i := 0; i < len(response.Data)-1; i++ {
+		assert.Less(t, response.Data[i].ChangeSeq, response.Data[i+1].ChangeSeq)
+	}
+	cursor := response.Pagination.NextKey
+	assert.NotEmpty(t, cursor)
+
+	// No new change, the same cursor is returned
+	response = fetchSuccessfulResponse[[]v1service.DelegationChangePublic](t, url+"?since="+cursor)
+	assert.Len(t, response.Data, 0)
+	assert.Equal(t, cursor, response.Pagination.NextKey)
+
+	expiredEvent := client.NewExpiredStakingEvent(activeStakingEvents[1].StakingTxHashHex, types.ActiveTxType.ToString())
+	sendTestMessage(testServer.Queues.V1QueueClient.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredEvent})
+	time.Sleep(5 * time.Second)
+
+	response = fetchSuccessfulResponse[[]v1service.DelegationChangePublic](t, url+"?since="+cursor)
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, activeStakingEvents[1].StakingTxHashHex, response.Data[0].StakingTxHashHex)
+	assert.Equal(t, types.Unbonded.ToString(), response.Data[0].State)
+	assert.NotEqual(t, cursor, response.Pagination.NextKey)
+
+	resp, err := http.Get(url + "?since=invalid")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
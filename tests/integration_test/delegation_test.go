@@ -14,6 +14,7 @@ import (
 	handler "github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
 	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
 )
 
@@ -61,3 +62,221 @@ func TestGetDelegationByTxHashHex(t *testing.T) {
 	assert.Equal(t, "unbonded", response.Data.State)
 	assert.Equal(t, activeStakingEvent[0].StakingTxHashHex, response.Data.StakingTxHashHex)
 }
+
+func TestCheckDelegationExists(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	_, missingTxHashHex := testutils.RandomBytes(r, 32)
+	for _, path := range []string{delegationRouter, delegationRouter + "/exists"} {
+		for _, method := range []string{http.MethodHead, http.MethodGet} {
+			if path == delegationRouter && method == http.MethodGet {
+				continue
+			}
+			for txHashHex, expectedStatus := range map[string]int{
+				activeStakingEvent.StakingTxHashHex: http.StatusOK,
+				missingTxHashHex:                    http.StatusNotFound,
+			} {
+				req, err := http.NewRequest(method, testServer.Server.URL+path+"?staking_tx_hash_hex="+txHashHex, nil)
+				assert.NoError(t, err)
+				resp, err := http.DefaultClient.Do(req)
+				assert.NoError(t, err, "making %s request to %s should not fail", method, path)
+				bodyBytes, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				assert.NoError(t, err)
+
+				assert.Equal(t, expectedStatus, resp.StatusCode, "unexpected status of %s %s", method, path)
+				assert.Empty(t, bodyBytes, "the existence check should have no body")
+			}
+		}
+	}
+}
+
+func TestDelegationShouldBePendingUntilConfirmationDepthReached(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	// The staking tx is included in the tip block, hence only 1 confirmation
+	btcInfoEvent := &client.BtcInfoEvent{
+		EventType: client.BtcInfoEventType,
+		Height:    activeStakingEvent.StakingStartHeight,
+	}
+	sendTestMessage(testServer.Queues.V1QueueClient.BtcInfoQueueClient, []*client.BtcInfoEvent{btcInfoEvent})
+	time.Sleep(2 * time.Second)
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	url := testServer.Server.URL + delegationRouter + "?staking_tx_hash_hex=" + activeStakingEvent.StakingTxHashHex
+	response := fetchSuccessfulResponse[v1service.DelegationPublic](t, url)
+	assert.Equal(t, types.Pending.ToString(), response.Data.State)
+	assert.Equal(t, uint64(1), response.Data.Confirmations)
+
+	// Move the tip so that the staking tx reaches the confirmation depth of 10
+	btcInfoEvent = &client.BtcInfoEvent{
+		EventType: client.BtcInfoEventType,
+		Height:    activeStakingEvent.StakingStartHeight + 9,
+	}
+	sendTestMessage(testServer.Queues.V1QueueClient.BtcInfoQueueClient, []*client.BtcInfoEvent{btcInfoEvent})
+	time.Sleep(2 * time.Second)
+
+	response = fetchSuccessfulResponse[v1service.DelegationPublic](t, url)
+	assert.Equal(t, types.Active.ToString(), response.Data.State)
+	assert.Equal(t, uint64(10), response.Data.Confirmations)
+}
+
+func TestGetDelegationByAnyTxHash(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvent := testutils.GenerateRandomActiveStakingEvents(
+		r,
+		&testutils.TestActiveEventGeneratorOpts{
+			NumOfEvents:       1,
+			FinalityProviders: testutils.GeneratePks(1),
+			Stakers:           testutils.GeneratePks(1),
+		},
+	)[0]
+	unbondingTx, unbondingTxHex, err := testutils.GenerateRandomTx(r, nil)
+	assert.NoError(t, err)
+	unbondingTxHashHex := unbondingTx.TxHash().String()
+
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	byTxUrl := testServer.Server.URL + delegationRouter + "/by-tx?tx_hash_hex="
+	response := fetchSuccessfulResponse[v1service.DelegationPublic](t, byTxUrl+activeStakingEvent.StakingTxHashHex)
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, response.Data.StakingTxHashHex)
+
+	// The unbonding tx is not known yet
+	resp, err := http.Get(byTxUrl + unbondingTxHashHex)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+
+	unbondingEvent := client.NewUnbondingStakingEvent(
+		activeStakingEvent.StakingTxHashHex,
+		activeStakingEvent.StakingStartHeight+100,
+		time.Now().Unix(),
+		10,
+		0,
+		unbondingTxHex,
+		unbondingTxHashHex,
+	)
+	sendTestMessage(testServer.Queues.V1QueueClient.UnbondingStakingQueueClient, []client.UnbondingStakingEvent{unbondingEvent})
+	time.Sleep(2 * time.Second)
+
+	response = fetchSuccessfulResponse[v1service.DelegationPublic](t, byTxUrl+unbondingTxHashHex)
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, response.Data.StakingTxHashHex)
+	assert.Equal(t, types.Unbonding.ToString(), response.Data.State)
+
+	resp, err = http.Get(byTxUrl + "invalid")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
+
+func TestGetDelegationTimeline(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvent := testutils.GenerateRandomActiveStakingEvents(
+		r,
+		&testutils.TestActiveEventGeneratorOpts{
+			NumOfEvents:       1,
+			FinalityProviders: testutils.GeneratePks(1),
+			Stakers:           testutils.GeneratePks(1),
+		},
+	)[0]
+	unbondingTx, unbondingTxHex, err := testutils.GenerateRandomTx(r, nil)
+	assert.NoError(t, err)
+	unbondingTxHashHex := unbondingTx.TxHash().String()
+
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	timelineUrl := testServer.Server.URL + delegationRouter + "/timeline?staking_tx_hash_hex="
+
+	// Unknown delegation
+	resp, err := http.Get(timelineUrl + activeStakingEvent.StakingTxHashHex)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, []*client.ActiveStakingEvent{activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	unbondingStartHeight := activeStakingEvent.StakingStartHeight + 100
+	unbondingEvent := client.NewUnbondingStakingEvent(
+		activeStakingEvent.StakingTxHashHex,
+		unbondingStartHeight,
+		time.Now().Unix(),
+		10,
+		0,
+		unbondingTxHex,
+		unbondingTxHashHex,
+	)
+	sendTestMessage(testServer.Queues.V1QueueClient.UnbondingStakingQueueClient, []client.UnbondingStakingEvent{unbondingEvent})
+	time.Sleep(2 * time.Second)
+
+	expiredEvent := client.NewExpiredStakingEvent(activeStakingEvent.StakingTxHashHex, types.UnbondingTxType.ToString())
+	sendTestMessage(testServer.Queues.V1QueueClient.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredEvent})
+	time.Sleep(2 * time.Second)
+
+	timeline := fetchSuccessfulResponse[[]v1service.DelegationMilestonePublic](
+		t, timelineUrl+activeStakingEvent.StakingTxHashHex,
+	).Data
+	assert.Len(t, timeline, 3)
+	assert.Equal(t, "staked", timeline[0].Milestone)
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, timeline[0].TxHashHex)
+	assert.Equal(t, activeStakingEvent.StakingStartHeight, timeline[0].BlockHeight)
+	assert.Equal(t, "unbonding_confirmed", timeline[1].Milestone)
+	assert.Equal(t, unbondingTxHashHex, timeline[1].TxHashHex)
+	assert.Equal(t, unbondingStartHeight, timeline[1].BlockHeight)
+	assert.Equal(t, "expired", timeline[2].Milestone)
+	assert.Equal(t, unbondingStartHeight+10, timeline[2].BlockHeight)
+}
+
+func TestGetDelegationTransitionStatus(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := testutils.GenerateRandomActiveStakingEvents(
+		r,
+		&testutils.TestActiveEventGeneratorOpts{
+			NumOfEvents:       2,
+			FinalityProviders: testutils.GeneratePks(1),
+			Stakers:           testutils.GeneratePks(1),
+		},
+	)
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	transitionStatusUrl := testServer.Server.URL + "/v2/delegation/transition-status?staking_tx_hash_hex="
+
+	// Unknown phase-1 delegation
+	resp, err := http.Get(transitionStatusUrl + activeStakingEvents[0].StakingTxHashHex)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+	expiredEvent := client.NewExpiredStakingEvent(activeStakingEvents[1].StakingTxHashHex, types.ActiveTxType.ToString())
+	sendTestMessage(testServer.Queues.V1QueueClient.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredEvent})
+	time.Sleep(2 * time.Second)
+
+	// The active delegation is not registered on the Babylon chain yet
+	status := fetchSuccessfulResponse[v2service.DelegationTransitionStatusPublic](
+		t, transitionStatusUrl+activeStakingEvents[0].StakingTxHashHex,
+	).Data
+	assert.Equal(t, v2service.TransitionStatusEligible, status.Status)
+	assert.Equal(t, types.Active.ToString(), status.Phase1State)
+	assert.Empty(t, status.Phase2State)
+
+	// The unbonded delegation can no longer be registered
+	status = fetchSuccessfulResponse[v2service.DelegationTransitionStatusPublic](
+		t, transitionStatusUrl+activeStakingEvents[1].StakingTxHashHex,
+	).Data
+	assert.Equal(t, v2service.TransitionStatusIneligible, status.Status)
+	assert.Equal(t, types.Unbonded.ToString(), status.Phase1State)
+	assert.NotEmpty(t, status.Reason)
+
+	resp, err = http.Get(transitionStatusUrl + "invalid")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumedMessagesShouldBeArchived(t *testing.T) {
+	activeStakingEvent := buildActiveStakingEvent(t, 1)
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, activeStakingEvent)
+	time.Sleep(2 * time.Second)
+
+	docs, err := testutils.InspectDbDocuments[dbmodel.EventDocument](
+		testServer.Config, dbmodel.EventsCollection,
+	)
+	require.NoError(t, err, "inspecting the events collection should not fail")
+	require.Len(t, docs, 1, "expected the consumed message to be archived")
+
+	assert.Equal(t, client.ActiveStakingQueueName, docs[0].QueueName)
+	assert.Equal(t, activeStakingEvent[0].StakingTxHashHex, docs[0].StakingTxHashHex)
+	require.NotNil(t, docs[0].EventType)
+	assert.Equal(t, int(client.ActiveStakingEventType), *docs[0].EventType)
+	assert.Equal(t, dbmodel.EventOutcomeProcessed, docs[0].Outcome)
+	assert.Equal(t, 200, docs[0].StatusCode)
+	assert.NotEmpty(t, docs[0].Payload)
+	assert.False(t, docs[0].ProcessedAt.Before(docs[0].ReceivedAt))
+}
+
+func TestRejectedMessagesShouldBeArchivedWithOutcome(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage[string](testServer.Queues.V1QueueClient.ActiveStakingQueueClient, []string{"a rubbish message"})
+	// The message does not match the queue schema, hence it's not retried
+	time.Sleep(5 * time.Second)
+
+	docs, err := testutils.InspectDbDocuments[dbmodel.EventDocument](
+		testServer.Config, dbmodel.EventsCollection,
+	)
+	require.NoError(t, err, "inspecting the events collection should not fail")
+	require.Len(t, docs, 1)
+
+	assert.Equal(t, dbmodel.EventOutcomeUnprocessable, docs[0].Outcome)
+	assert.Equal(t, http.StatusBadRequest, docs[0].StatusCode)
+	assert.Contains(t, docs[0].Error, "schema violation")
+	assert.Nil(t, docs[0].EventType)
+	assert.Equal(t, "\"a rubbish message\"", docs[0].Payload)
+}
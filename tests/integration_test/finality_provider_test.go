@@ -12,7 +12,9 @@ import (
 	handler "github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
@@ -54,7 +56,7 @@ func TestGetFinalityProvidersSuccessfully(t *testing.T) {
 func TestGetFinalityProviderShouldNotFailInCaseOfDbFailure(t *testing.T) {
 	mockV1DBClient := new(testmock.V1DBClient)
 	mockV1DBClient.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(nil, errors.New("just an error"))
-	mockMongoClient := &mongo.Client{}
+	mockMongoClient := dbclient.WrapMongoClient(&mongo.Client{})
 	testServer := setupTestServer(t, &TestServerDependency{MockDbClients: dbclients.DbClients{
 		StakingMongoClient: mockMongoClient,
 		V1DBClient:         mockV1DBClient,
@@ -69,7 +71,7 @@ func TestGetFinalityProviderShouldReturnFallbackToGlobalParams(t *testing.T) {
 	}
 	mockV1DBClient := new(testmock.V1DBClient)
 	mockV1DBClient.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(mockedResultMap, nil)
-	mockMongoClient := &mongo.Client{}
+	mockMongoClient := dbclient.WrapMongoClient(&mongo.Client{})
 
 	testServer := setupTestServer(t, &TestServerDependency{MockDbClients: dbclients.DbClients{
 		StakingMongoClient: mockMongoClient,
@@ -81,7 +83,7 @@ func TestGetFinalityProviderShouldReturnFallbackToGlobalParams(t *testing.T) {
 func TestGetFinalityProviderReturn4xxErrorIfPageTokenInvalid(t *testing.T) {
 	mockV1DBClient := new(testmock.V1DBClient)
 	mockV1DBClient.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(nil, &db.InvalidPaginationTokenError{})
-	mockMongoClient := &mongo.Client{}
+	mockMongoClient := dbclient.WrapMongoClient(&mongo.Client{})
 
 	testServer := setupTestServer(t, &TestServerDependency{MockDbClients: dbclients.DbClients{
 		StakingMongoClient: mockMongoClient,
@@ -116,9 +118,9 @@ func FuzzGetFinalityProviderShouldReturnAllRegisteredFps(f *testing.F) {
 		fpParams, registeredFpsStats, notRegisteredFpsStats := setUpFinalityProvidersStatsDataSet(t, r, nil)
 
 		mockV1DBClient := new(testmock.V1DBClient)
-		mockV1DBClient.On("FindFinalityProviderStatsByFinalityProviderPkHex",
+		mockV1DBClient.On("FindFinalityProviderPkHexesWithStats",
 			mock.Anything, mock.Anything,
-		).Return(registeredFpsStats, nil)
+		).Return(fpStatsPkHexes(registeredFpsStats), nil)
 
 		mockedFinalityProviderStats := &db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument]{
 			Data:            append(registeredFpsStats, notRegisteredFpsStats...),
@@ -126,7 +128,7 @@ func FuzzGetFinalityProviderShouldReturnAllRegisteredFps(f *testing.F) {
 		}
 		mockV1DBClient.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(mockedFinalityProviderStats, nil)
 
-		mockMongoClient := &mongo.Client{}
+		mockMongoClient := dbclient.WrapMongoClient(&mongo.Client{})
 
 		testServer := setupTestServer(t, &TestServerDependency{MockDbClients: dbclients.DbClients{
 			StakingMongoClient: mockMongoClient,
@@ -259,9 +261,9 @@ func FuzzGetFinalityProviderShouldNotReturnRegisteredFpWithoutStakingForPaginate
 		fpParams, registeredFpsStats, notRegisteredFpsStats := setUpFinalityProvidersStatsDataSet(t, r, nil)
 
 		mockV1DBClient := new(testmock.V1DBClient)
-		mockV1DBClient.On("FindFinalityProviderStatsByFinalityProviderPkHex",
+		mockV1DBClient.On("FindFinalityProviderPkHexesWithStats",
 			mock.Anything, mock.Anything,
-		).Return(registeredFpsStats, nil)
+		).Return(fpStatsPkHexes(registeredFpsStats), nil)
 
 		registeredWithoutStakeFpsStats := registeredFpsStats[:len(registeredFpsStats)-testutils.RandomPositiveInt(r, len(registeredFpsStats))]
 
@@ -270,7 +272,7 @@ func FuzzGetFinalityProviderShouldNotReturnRegisteredFpWithoutStakingForPaginate
 			PaginationToken: "abcd",
 		}
 		mockV1DBClient.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(mockedFinalityProviderStats, nil)
-		mockMongoClient := &mongo.Client{}
+		mockMongoClient := dbclient.WrapMongoClient(&mongo.Client{})
 
 		testServer := setupTestServer(t, &TestServerDependency{MockDbClients: dbclients.DbClients{
 			StakingMongoClient: mockMongoClient,
@@ -324,9 +326,9 @@ func FuzzShouldNotReturnDefaultFpFromParamsWhenPageTokenIsPresent(f *testing.F)
 		mockV1DBClient := new(testmock.V1DBClient)
 		// Mock the response for the registered finality providers
 		numOfFpNotHaveStats := testutils.RandomPositiveInt(r, int(opts.NumOfRegisterFps))
-		mockV1DBClient.On("FindFinalityProviderStatsByFinalityProviderPkHex",
+		mockV1DBClient.On("FindFinalityProviderPkHexesWithStats",
 			mock.Anything, mock.Anything,
-		).Return(registeredFpsStats[:len(registeredFpsStats)-numOfFpNotHaveStats], nil)
+		).Return(fpStatsPkHexes(registeredFpsStats[:len(registeredFpsStats)-numOfFpNotHaveStats]), nil)
 
 		// We are mocking the last page of the response where there is no more data to fetch
 		mockedFinalityProviderStats := &db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument]{
@@ -335,7 +337,7 @@ func FuzzShouldNotReturnDefaultFpFromParamsWhenPageTokenIsPresent(f *testing.F)
 		}
 		mockV1DBClient.On("FindFinalityProviderStats", mock.Anything, mock.Anything).Return(mockedFinalityProviderStats, nil)
 
-		mockMongoClient := &mongo.Client{}
+		mockMongoClient := dbclient.WrapMongoClient(&mongo.Client{})
 		testServer := setupTestServer(t, &TestServerDependency{MockDbClients: dbclients.DbClients{
 			StakingMongoClient: mockMongoClient,
 			V1DBClient:         mockV1DBClient,
@@ -367,7 +369,7 @@ func FuzzGetFinalityProvider(f *testing.F) {
 		mockV1DBClient.On("FindFinalityProviderStatsByFinalityProviderPkHex",
 			mock.Anything, mock.Anything,
 		).Return(fpStats, nil)
-		mockMongoClient := &mongo.Client{}
+		mockMongoClient := dbclient.WrapMongoClient(&mongo.Client{})
 
 		testServer := setupTestServer(t, &TestServerDependency{MockDbClients: dbclients.DbClients{
 			StakingMongoClient: mockMongoClient,
@@ -470,3 +472,36 @@ func setUpFinalityProvidersStatsDataSet(t *testing.T, r *rand.Rand, opts *SetupF
 
 	return fpParams, registeredFpsStats, notRegisteredFpsStats
 }
+
+func fpStatsPkHexes(fpStats []*v1dbmodel.FinalityProviderStatsDocument) []string {
+	var pkHexes []string
+	for _, stats := range fpStats {
+		pkHexes = append(pkHexes, stats.FinalityProviderPkHex)
+	}
+	return pkHexes
+}
+
+func TestGetFinalityProviderDelegationCount(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	fpPk, err := testutils.RandomPk()
+	assert.NoError(t, err)
+	fpStats := generateFinalityProviderStatsDocument(r, fpPk)
+	testutils.InjectDbDocument(testServer.Config, dbmodel.V1FinalityProviderStatsCollection, fpStats)
+
+	url := testServer.Server.URL + "/v1/finality-provider/delegation-count?fp_btc_pk=" + fpPk
+	result := fetchSuccessfulResponse[v1service.FpDelegationCountPublic](t, url).Data
+	assert.Equal(t, fpPk, result.BtcPk)
+	assert.Equal(t, fpStats.ActiveDelegations, result.ActiveDelegations)
+	assert.Equal(t, fpStats.TotalDelegations, result.TotalDelegations)
+
+	// A finality provider without stats has no delegations
+	otherFpPk, err := testutils.RandomPk()
+	assert.NoError(t, err)
+	url = testServer.Server.URL + "/v1/finality-provider/delegation-count?fp_btc_pk=" + otherFpPk
+	result = fetchSuccessfulResponse[v1service.FpDelegationCountPublic](t, url).Data
+	assert.Zero(t, result.ActiveDelegations)
+	assert.Zero(t, result.TotalDelegations)
+}
@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// The golden files are (re)generated by running the test with the update flag:
+// go test ./tests/integration_test -run TestGoldenResponses -update-golden
+var updateGolden = flag.Bool("update-golden", false, "update the golden files of the API responses")
+
+const goldenDir = "testdata/golden"
+
+type goldenCase struct {
+	name   string
+	method string
+	path   string
+	body   string
+}
+
+// TestGoldenResponses snapshots the shape of the responses of every public
+// endpoint, so that breaking changes to the API responses are caught.
+// The data is randomly generated, hence the scalar values are normalized into
+// their JSON type and the arrays into the shape of their first element.
+func TestGoldenResponses(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	activeStakingEvents := testutils.GenerateRandomActiveStakingEvents(
+		r,
+		&testutils.TestActiveEventGeneratorOpts{
+			NumOfEvents:        3,
+			FinalityProviders:  testutils.GeneratePks(1),
+			Stakers:            testutils.GeneratePks(1),
+			EnforceNotOverflow: true,
+		},
+	)
+	event := activeStakingEvents[0]
+
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	addresses, err := utils.DeriveAddressesFromNoCoordPk(
+		event.StakerPkHex, testServer.Config.Server.BTCNetParam,
+	)
+	require.NoError(t, err)
+
+	cases := []goldenCase{
+		{name: "healthcheck", path: "/healthcheck"},
+		{name: "v1_staker_delegations", path: "/v1/staker/delegations?staker_btc_pk=" + event.StakerPkHex},
+		{name: "v1_unbonding_eligibility", path: "/v1/unbonding/eligibility?staking_tx_hash_hex=" + event.StakingTxHashHex},
+		{name: "v1_global_params", path: "/v1/global-params"},
+		{name: "v1_finality_providers", path: "/v1/finality-providers"},
+		{name: "v1_stats", path: "/v1/stats"},
+		{name: "v1_stats_staker", path: "/v1/stats/staker"},
+		{name: "v1_staker_delegation_check", path: "/v1/staker/delegation/check?address=" + addresses.Taproot},
+		{name: "v1_delegation", path: "/v1/delegation?staking_tx_hash_hex=" + event.StakingTxHashHex},
+		{name: "v1_delegation_by_tx", path: "/v1/delegation/by-tx?tx_hash_hex=" + event.StakingTxHashHex},
+		{name: "v1_delegation_timeline", path: "/v1/delegation/timeline?staking_tx_hash_hex=" + event.StakingTxHashHex},
+		{name: "v1_delegations_changes", path: "/v1/delegations/changes"},
+		{name: "v1_staker_pubkey_lookup", path: "/v1/staker/pubkey-lookup?address=" + addresses.Taproot},
+		{
+			name: "v1_staking_verify", method: http.MethodPost, path: "/v1/staking/verify",
+			body: `{"staking_tx_hex":"` + event.StakingTxHex + `"}`,
+		},
+		{name: "v1_unbonding_invalid_request", method: http.MethodPost, path: "/v1/unbonding", body: `{}`},
+		{name: "v2_finality_providers", path: "/v2/finality-providers"},
+		{name: "v2_params", path: "/v2/params"},
+		{name: "v2_delegation", path: "/v2/delegation?staking_tx_hash_hex=" + event.StakingTxHashHex},
+		{
+			name: "v2_delegation_transition_status",
+			path: "/v2/delegation/transition-status?staking_tx_hash_hex=" + event.StakingTxHashHex,
+		},
+		{name: "v2_delegations", path: "/v2/delegations?staker_pk_hex=" + event.StakerPkHex},
+		{name: "v2_stats", path: "/v2/stats"},
+		{name: "v2_staker_stats", path: "/v2/staker/stats?staker_pk_hex=" + event.StakerPkHex},
+		{name: "v2_bsns", path: "/v2/bsns"},
+		{name: "v2_bsn_finality_providers", path: "/v2/bsn/finality-providers?bsn_id=babylon"},
+	}
+
+	// The keys derived from the random data are replaced by a placeholder
+	keys := map[string]string{addresses.Taproot: "<taproot_address>"}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			snapshot := fetchGoldenSnapshot(t, testServer.Server.URL, c, keys)
+			assertGoldenSnapshot(t, c.name, snapshot)
+		})
+	}
+}
+
+func fetchGoldenSnapshot(t *testing.T, baseUrl string, c goldenCase, keys map[string]string) []byte {
+	method := c.method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequest(method, baseUrl+c.path, strings.NewReader(c.body))
+	require.NoError(t, err)
+	if c.body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var body interface{}
+	require.NoError(t, json.Unmarshal(bodyBytes, &body), "response body should be JSON: %s", bodyBytes)
+	snapshot, err := json.MarshalIndent(map[string]interface{}{
+		"status": resp.StatusCode,
+		"body":   normalizeGoldenValue(body, keys),
+	}, "", "  ")
+	require.NoError(t, err)
+	return append(snapshot, '\n')
+}
+
+// normalizeGoldenValue replaces the scalar values by their JSON type and the
+// arrays by their first element, only the shape of the value is kept. The
+// object keys found in keys are replaced by their placeholder.
+func normalizeGoldenValue(value interface{}, keys map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			if placeholder, ok := keys[key]; ok {
+				key = placeholder
+			}
+			normalized[key] = normalizeGoldenValue(field, keys)
+		}
+		return normalized
+	case []interface{}:
+		if len(v) == 0 {
+			return []interface{}{}
+		}
+		return []interface{}{normalizeGoldenValue(v[0], keys)}
+	case string:
+		return "<string>"
+	case float64:
+		return "<number>"
+	case bool:
+		return "<bool>"
+	default:
+		return nil
+	}
+}
+
+func assertGoldenSnapshot(t *testing.T, name string, snapshot []byte) {
+	path := filepath.Join(goldenDir, name+".json")
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(goldenDir, 0o755))
+		require.NoError(t, os.WriteFile(path, snapshot, 0o644))
+		t.Logf("golden file %s written, commit it to snapshot the response", path)
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("golden file %s is missing, run the test with -update-golden to write it", path)
+	}
+	require.NoError(t, err)
+	if !bytes.Equal(expected, snapshot) {
+		t.Errorf(
+			"response of %s does not match the golden file %s, run the test with -update-golden "+
+				"if the change is intended\nexpected:\n%s\nactual:\n%s",
+			name, path, expected, snapshot,
+		)
+	}
+}
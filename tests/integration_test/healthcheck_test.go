@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"testing"
 
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
 	testmock "github.com/babylonlabs-io/staking-api-service/tests/mocks"
 	"github.com/stretchr/testify/assert"
@@ -47,8 +48,8 @@ func TestHealthCheck(t *testing.T) {
 func TestHealthCheckDBError(t *testing.T) {
 	mockDbClients := new(testmock.DBClient)
 	mockDbClients.On("Ping", mock.Anything).Return(io.EOF) // Expect db error
-	mockMongoClient := &mongo.Client{}
-	mockIndexerDbClient := &mongo.Client{}
+	mockMongoClient := dbclient.WrapMongoClient(&mongo.Client{})
+	mockIndexerDbClient := dbclient.WrapMongoClient(&mongo.Client{})
 
 	testServer := setupTestServer(t, &TestServerDependency{MockDbClients: dbclients.DbClients{
 		StakingMongoClient: mockMongoClient,
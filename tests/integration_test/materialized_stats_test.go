@@ -0,0 +1,63 @@
+package tests
+
+import (
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	testmock "github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestOverallStatsServedFromMaterializedStats(t *testing.T) {
+	cfg := testutils.LoadTestConfig()
+	cfg.StatsRefresher = &config.StatsRefresherConfig{
+		Interval:     time.Second,
+		MaxStaleness: time.Minute,
+	}
+	materialized := &v1dbmodel.MaterializedOverallStatsDocument{
+		OverallStatsDocument: v1dbmodel.OverallStatsDocument{
+			TotalTvl:         1000,
+			TotalDelegations: 10,
+			TotalStakers:     5,
+		},
+		LastRefreshedAt: time.Now().Unix(),
+	}
+	shards := &v1dbmodel.OverallStatsDocument{
+		TotalTvl:         2000,
+		TotalDelegations: 20,
+		TotalStakers:     6,
+	}
+
+	mockV1DBClient := new(testmock.V1DBClient)
+	mockV1DBClient.On("GetMaterializedOverallStats", mock.Anything).Return(materialized, nil)
+	mockV1DBClient.On("GetOverallStats", mock.Anything).Return(shards, nil)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(&v1dbmodel.BtcInfo{}, nil)
+	testServer := setupTestServer(t, &TestServerDependency{
+		ConfigOverrides: cfg,
+		MockDbClients: dbclients.DbClients{
+			StakingMongoClient: dbclient.WrapMongoClient(&mongo.Client{}),
+			V1DBClient:         mockV1DBClient,
+		},
+	})
+	defer testServer.Close()
+
+	stats := fetchOverallStatsEndpoint(t, testServer)
+	assert.Equal(t, int64(1000), stats.TotalTvl)
+	assert.Equal(t, int64(10), stats.TotalDelegations)
+	assert.Equal(t, uint64(5), stats.TotalStakers)
+	mockV1DBClient.AssertNotCalled(t, "GetOverallStats", mock.Anything)
+
+	// Stale materialized stats are not served
+	materialized.LastRefreshedAt = time.Now().Add(-2 * time.Minute).Unix()
+	stats = fetchOverallStatsEndpoint(t, testServer)
+	assert.Equal(t, int64(2000), stats.TotalTvl)
+	assert.Equal(t, int64(20), stats.TotalDelegations)
+	assert.Equal(t, uint64(6), stats.TotalStakers)
+}
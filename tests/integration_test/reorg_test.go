@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1queueschema "github.com/babylonlabs-io/staking-api-service/internal/v1/queue/schema"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBtcReorgShouldRollbackDelegationAndStats(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	overallStats := fetchSuccessfulResponse[v1service.OverallStatsPublic](t, testServer.Server.URL+overallStatsEndpoint)
+	assert.Equal(t, int64(1), overallStats.Data.TotalDelegations)
+
+	reorgEvent := v1queueschema.NewBtcReorgEvent(
+		"000000000000000000012d6ed6e68f8c1fbb1b27bb5d4a8a4f7a93f1f0e7c1a2",
+		activeStakingEvent.StakingStartHeight,
+		[]string{activeStakingEvent.StakingTxHashHex},
+	)
+	sendTestMessage(testServer.Queues.V1QueueClient.BtcReorgQueueClient, []v1queueschema.BtcReorgEvent{reorgEvent})
+	time.Sleep(2 * time.Second)
+
+	// The delegation shall be removed
+	delegations, err := testutils.InspectDbDocuments[v1dbmodel.DelegationDocument](
+		testServer.Config, dbmodel.V1DelegationCollection,
+	)
+	if err != nil {
+		t.Fatalf("Failed to inspect DB documents: %v", err)
+	}
+	assert.Equal(t, 0, len(delegations), "expected the delegation to be rolled back")
+
+	// The removal shall be part of the delegation changes
+	changes := fetchSuccessfulResponse[[]v1service.DelegationChangePublic](
+		t, testServer.Server.URL+delegationChangesUrl,
+	)
+	if assert.Len(t, changes.Data, 1) {
+		assert.Equal(t, activeStakingEvent.StakingTxHashHex, changes.Data[0].StakingTxHashHex)
+		assert.True(t, changes.Data[0].Removed)
+	}
+
+	// The stats shall be reversed
+	overallStats = fetchSuccessfulResponse[v1service.OverallStatsPublic](t, testServer.Server.URL+overallStatsEndpoint)
+	assert.Equal(t, int64(0), overallStats.Data.TotalTvl)
+	assert.Equal(t, int64(0), overallStats.Data.TotalDelegations)
+	assert.Equal(t, uint64(0), overallStats.Data.TotalStakers)
+
+	// The reorged block shall be recorded
+	reorgs, err := testutils.InspectDbDocuments[v1dbmodel.BtcReorgDocument](
+		testServer.Config, dbmodel.V1BtcReorgCollection,
+	)
+	if err != nil {
+		t.Fatalf("Failed to inspect DB documents: %v", err)
+	}
+	assert.Equal(t, 1, len(reorgs))
+	assert.Equal(t, reorgEvent.BlockHash, reorgs[0].BlockHash)
+	assert.Equal(t, []string{activeStakingEvent.StakingTxHashHex}, reorgs[0].StakingTxHashHexes)
+
+	// Re-inclusion of the staking tx shall be processed again
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	overallStats = fetchSuccessfulResponse[v1service.OverallStatsPublic](t, testServer.Server.URL+overallStatsEndpoint)
+	assert.Equal(t, int64(activeStakingEvent.StakingValue), overallStats.Data.TotalTvl)
+	assert.Equal(t, int64(1), overallStats.Data.TotalDelegations)
+}
@@ -34,7 +34,7 @@ func TestReplayUnprocessableMessages(t *testing.T) {
 
 	testutils.InjectDbDocument(
 		testServer.Config, dbmodel.V1UnprocessableMsgCollection,
-		dbmodel.NewUnprocessableMessageDocument(doc, "receipt"),
+		dbmodel.NewUnprocessableMessageDocument(doc, "receipt", "exceeded retry attempts"),
 	)
 	dbClients, _ := testutils.DirectDbConnection(testServer.Config)
 	defer dbClients.StakingMongoClient.Disconnect(ctx)
@@ -21,18 +21,18 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/mongo"
 
-	queueConfig "github.com/babylonlabs-io/staking-queue-client/config"
-
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/logging"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
 	queueclients "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/clients"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1queueschema "github.com/babylonlabs-io/staking-api-service/internal/v1/queue/schema"
 	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
 )
 
@@ -48,16 +48,22 @@ type TestServerDependency struct {
 }
 
 type TestServer struct {
-	Server  *httptest.Server
-	Queues  *queueclients.QueueClients
-	Conn    *amqp091.Connection
-	channel *amqp091.Channel
-	Config  *config.Config
-	Db      *mongo.Client
+	Server *httptest.Server
+	// AdminServer serves the admin routes, nil if the admin listener is not
+	// configured
+	AdminServer *httptest.Server
+	Queues      *queueclients.QueueClients
+	Conn        *amqp091.Connection
+	channel     *amqp091.Channel
+	Config      *config.Config
+	Db          *mongo.Client
 }
 
 func (ts *TestServer) Close() {
 	ts.Server.Close()
+	if ts.AdminServer != nil {
+		ts.AdminServer.Close()
+	}
 	ts.Queues.V1QueueClient.StopReceivingMessages()
 	if err := ts.Conn.Close(); err != nil {
 		log.Fatalf("failed to close connection in test: %v", err)
@@ -86,6 +92,9 @@ func setupTestServer(t *testing.T, dep *TestServerDependency) *TestServer {
 	} else {
 		cfg = testutils.LoadTestConfig()
 	}
+	if err = logging.Init(cfg.Server.LogLevel, cfg.Logging); err != nil {
+		t.Fatalf("Failed to setup logging: %v", err)
+	}
 	metricsPort := cfg.Metrics.GetMetricsPort()
 	metrics.Init(metricsPort)
 
@@ -113,7 +122,10 @@ func setupTestServer(t *testing.T, dep *TestServerDependency) *TestServer {
 	if dep != nil && dep.MockedClients != nil {
 		c = dep.MockedClients
 	} else {
-		c = clients.New(cfg)
+		c, err = clients.New(cfg)
+		if err != nil {
+			t.Fatalf("Failed to setup clients: %v", err)
+		}
 	}
 
 	// setup test db
@@ -128,7 +140,14 @@ func setupTestServer(t *testing.T, dep *TestServerDependency) *TestServer {
 		t.Fatalf("Failed to initialize services: %v", err)
 	}
 
-	apiServer, err := api.New(context.Background(), cfg, services)
+	queues, conn, ch, err := setUpTestQueue(cfg, services)
+	if err != nil {
+		t.Fatalf("Failed to setup test queue: %v", err)
+	}
+
+	apiServer, err := api.New(context.Background(), cfg, services, func(ctx context.Context, dryRun bool) (int, error) {
+		return queues.ReplayUnprocessableMessages(ctx, dbClients.SharedDBClient, dryRun)
+	})
 	if err != nil {
 		t.Fatalf("Failed to initialize API server: %v", err)
 	}
@@ -141,26 +160,29 @@ func setupTestServer(t *testing.T, dep *TestServerDependency) *TestServer {
 	r.Use(middlewares.ContentLengthMiddleware(cfg))
 	apiServer.SetupRoutes(r)
 
-	queues, conn, ch, err := setUpTestQueue(cfg.Queue, services)
-	if err != nil {
-		t.Fatalf("Failed to setup test queue: %v", err)
-	}
-
 	// Create an httptest server
 	server := httptest.NewServer(r)
 
+	var adminServer *httptest.Server
+	if cfg.Admin != nil {
+		adminRouter := chi.NewRouter()
+		apiServer.SetupAdminRoutes(adminRouter)
+		adminServer = httptest.NewServer(adminRouter)
+	}
+
 	return &TestServer{
-		Server:  server,
-		Queues:  queues,
-		Conn:    conn,
-		channel: ch,
-		Config:  cfg,
-		Db:      dbClients.StakingMongoClient,
+		Server:      server,
+		AdminServer: adminServer,
+		Queues:      queues,
+		Conn:        conn,
+		channel:     ch,
+		Config:      cfg,
+		Db:          dbClients.StakingMongoClient.Current(),
 	}
 }
 
-func setUpTestQueue(cfg *queueConfig.QueueConfig, services *services.Services) (*queueclients.QueueClients, *amqp091.Connection, *amqp091.Channel, error) {
-	amqpURI := fmt.Sprintf("amqp://%s:%s@%s", cfg.QueueUser, cfg.QueuePassword, cfg.Url)
+func setUpTestQueue(cfg *config.Config, services *services.Services) (*queueclients.QueueClients, *amqp091.Connection, *amqp091.Channel, error) {
+	amqpURI := fmt.Sprintf("amqp://%s:%s@%s", cfg.Queue.QueueUser, cfg.Queue.QueuePassword, cfg.Queue.Url)
 	conn, err := amqp091.Dial(amqpURI)
 	if err != nil {
 		log.Fatal("failed to connect to RabbitMQ in test: ", err)
@@ -177,12 +199,14 @@ func setUpTestQueue(cfg *queueConfig.QueueConfig, services *services.Services) (
 		client.WithdrawStakingQueueName,
 		client.ExpiredStakingQueueName,
 		client.StakingStatsQueueName,
+		v1queueschema.BtcReorgQueueName,
 		// purge delay queues as well
 		client.ActiveStakingQueueName + "_delay",
 		client.UnbondingStakingQueueName + "_delay",
 		client.WithdrawStakingQueueName + "_delay",
 		client.ExpiredStakingQueueName + "_delay",
 		client.StakingStatsQueueName + "_delay",
+		v1queueschema.BtcReorgQueueName + "_delay",
 	})
 	if purgeError != nil {
 		log.Fatal("failed to purge queues in test: ", purgeError)
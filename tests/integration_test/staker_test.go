@@ -376,6 +376,151 @@ func TestReturnErrorWhenInvalidStatePassed(t *testing.T) {
 	assert.Equal(t, "invalid delegation state: invalid_state", response.Message)
 }
 
+func TestStakerDelegationsFilteredByValueAndTimestamp(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	activeStakingEvents := testutils.GenerateRandomActiveStakingEvents(r, &testutils.TestActiveEventGeneratorOpts{
+		NumOfEvents:       4,
+		FinalityProviders: testutils.GeneratePks(4),
+		Stakers:           testutils.GeneratePks(1),
+	})
+	for i, event := range activeStakingEvents {
+		event.StakingValue = uint64(i+1) * 1000
+		event.StakingStartTimestamp = int64(i+1) * 100000
+	}
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	baseUrl := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + activeStakingEvents[0].StakerPkHex
+
+	byValue := fetchSuccessfulResponse[[]v1service.DelegationPublic](
+		t, baseUrl+"&min_value=2000&max_value=3000",
+	).Data
+	assert.Len(t, byValue, 2)
+	for _, d := range byValue {
+		assert.True(t, d.StakingValue >= 2000 && d.StakingValue <= 3000)
+	}
+
+	byTimestamp := fetchSuccessfulResponse[[]v1service.DelegationPublic](
+		t, baseUrl+"&from_timestamp=300000",
+	).Data
+	assert.Len(t, byTimestamp, 2)
+	for _, d := range byTimestamp {
+		assert.True(t, d.StakingValue >= 3000)
+	}
+
+	combined := fetchSuccessfulResponse[[]v1service.DelegationPublic](
+		t, baseUrl+"&min_value=2000&to_timestamp=300000",
+	).Data
+	assert.Len(t, combined, 2)
+
+	resp, err := http.Get(baseUrl + "&min_value=3000&max_value=2000")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+	resp, err = http.Get(baseUrl + "&from_timestamp=-1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
+
+func TestStakerDelegationsWithSelectedFields(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	activeStakingEvents := testutils.GenerateRandomActiveStakingEvents(r, &testutils.TestActiveEventGeneratorOpts{
+		NumOfEvents:       3,
+		FinalityProviders: testutils.GeneratePks(3),
+		Stakers:           testutils.GeneratePks(1),
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	baseUrl := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + activeStakingEvents[0].StakerPkHex
+
+	delegations := fetchSuccessfulResponse[[]map[string]json.RawMessage](
+		t, baseUrl+"&fields=staking_tx_hash_hex,state,staking_value,confirmations",
+	).Data
+	assert.Len(t, delegations, 3)
+	for _, d := range delegations {
+		assert.Len(t, d, 4)
+		assert.Contains(t, d, "staking_tx_hash_hex")
+		assert.Contains(t, d, "state")
+		assert.Contains(t, d, "staking_value")
+		assert.Contains(t, d, "confirmations")
+	}
+
+	// The selected fields match the ones of the full delegations
+	full := fetchSuccessfulResponse[[]v1service.DelegationPublic](t, baseUrl).Data
+	trimmed := fetchSuccessfulResponse[[]v1service.DelegationPublic](
+		t, baseUrl+"&fields=staking_tx_hash_hex,staking_value",
+	).Data
+	assert.Len(t, trimmed, len(full))
+	for i := range full {
+		assert.Equal(t, full[i].StakingTxHashHex, trimmed[i].StakingTxHashHex)
+		assert.Equal(t, full[i].StakingValue, trimmed[i].StakingValue)
+		assert.Empty(t, trimmed[i].State)
+		assert.Nil(t, trimmed[i].StakingTx)
+	}
+
+	resp, err := http.Get(baseUrl + "&fields=staking_tx_hash_hex,unknown")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
+
+func TestStakerDelegationsStreamedAsNDJSON(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	// More than a page of delegations
+	activeStakingEvents := testutils.GenerateRandomActiveStakingEvents(r, &testutils.TestActiveEventGeneratorOpts{
+		NumOfEvents: int(testServer.Config.StakingDb.MaxPaginationLimit) + 5,
+		Stakers:     testutils.GeneratePks(1),
+	})
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(3 * time.Second)
+
+	stakerPk := activeStakingEvents[0].StakerPkHex
+	paginated := fetchStakerDelegations(t, testServer, stakerPk, "")
+
+	streamUrl := testServer.Server.URL + stakerDelegations + "/stream?staker_btc_pk=" + stakerPk
+	streamed := fetchStreamedStakerDelegations(t, streamUrl, "")
+	assert.Equal(t, paginated, streamed)
+
+	// The paginated endpoint streams as well if the request accepts ndjson
+	url := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk
+	streamed = fetchStreamedStakerDelegations(t, url, handler.NDJSONContentType)
+	assert.Equal(t, paginated, streamed)
+
+	// Errors occurring before the streaming started are regular responses
+	resp, err := http.Get(streamUrl + "&pagination_key=" + "aW52YWxpZA==")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+}
+
+func fetchStreamedStakerDelegations(t *testing.T, url, accept string) []v1service.DelegationPublic {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	assert.NoError(t, err)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, handler.NDJSONContentType, resp.Header.Get("Content-Type"))
+
+	var delegations []v1service.DelegationPublic
+	decoder := json.NewDecoder(resp.Body)
+	for decoder.More() {
+		var delegation v1service.DelegationPublic
+		assert.NoError(t, decoder.Decode(&delegation))
+		delegations = append(delegations, delegation)
+	}
+	return delegations
+}
+
 func fetchCheckStakerActiveDelegations(
 	t *testing.T, testServer *TestServer, btcAddress string, timeframe string,
 ) bool {
@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	handler "github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	v1handlers "github.com/babylonlabs-io/staking-api-service/internal/v1/api/handlers"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	stakingVerifyPath      = "/v1/staking/verify"
+	stakingEligibilityPath = "/v1/staking/eligibility"
+)
+
+func postVerifyStakingTx(t *testing.T, url string, stakingTxHex string) *http.Response {
+	requestBodyBytes, err := json.Marshal(v1handlers.VerifyStakingTxRequestPayload{
+		StakingTxHex: stakingTxHex,
+	})
+	require.NoError(t, err)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(requestBodyBytes))
+	require.NoError(t, err, "making POST request to staking verify endpoint should not fail")
+	return resp
+}
+
+func TestVerifyStakingTx(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	// Move the tip into the first params version which the test tx is built for
+	btcInfoEvent := &client.BtcInfoEvent{
+		EventType: client.BtcInfoEventType,
+		Height:    activeStakingEvent.StakingStartHeight,
+	}
+	sendTestMessage(testServer.Queues.V1QueueClient.BtcInfoQueueClient, []*client.BtcInfoEvent{btcInfoEvent})
+	time.Sleep(2 * time.Second)
+
+	resp := postVerifyStakingTx(t, testServer.Server.URL+stakingVerifyPath, activeStakingEvent.StakingTxHex)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "reading response body should not fail")
+	var response handler.PublicResponse[v1service.StakingTxVerificationPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+
+	report := response.Data
+	assert.True(t, report.Valid)
+	assert.Equal(t, uint64(0), report.ParamsVersion)
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, report.StakingTxHashHex)
+	assert.Equal(t, activeStakingEvent.StakerPkHex, report.StakerPkHex)
+	assert.Equal(t, activeStakingEvent.FinalityProviderPkHex, report.FinalityProviderPkHex)
+	assert.Equal(t, activeStakingEvent.StakingValue, report.StakingValue)
+	assert.Equal(t, activeStakingEvent.StakingTimeLock, report.StakingTime)
+	assert.Equal(t, activeStakingEvent.StakingOutputIndex, report.StakingOutputIndex)
+	assert.Len(t, report.Checks, 5)
+	for _, check := range report.Checks {
+		assert.True(t, check.Passed, "check %s should pass", check.Name)
+	}
+
+	// Move the tip into a params version with a different covenant committee
+	btcInfoEvent.Height = 250
+	sendTestMessage(testServer.Queues.V1QueueClient.BtcInfoQueueClient, []*client.BtcInfoEvent{btcInfoEvent})
+	time.Sleep(2 * time.Second)
+
+	resp = postVerifyStakingTx(t, testServer.Server.URL+stakingVerifyPath, activeStakingEvent.StakingTxHex)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err = io.ReadAll(resp.Body)
+	require.NoError(t, err, "reading response body should not fail")
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+
+	report = response.Data
+	assert.False(t, report.Valid)
+	assert.Equal(t, uint64(1), report.ParamsVersion)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, v1service.StakingTxCheckDecode, report.Checks[0].Name)
+	assert.True(t, report.Checks[0].Passed)
+	assert.Equal(t, v1service.StakingTxCheckStakingOutput, report.Checks[1].Name)
+	assert.False(t, report.Checks[1].Passed)
+	assert.NotEmpty(t, report.Checks[1].Message)
+}
+
+func TestVerifyStakingTxWithInvalidHex(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp := postVerifyStakingTx(t, testServer.Server.URL+stakingVerifyPath, "invalid")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
+
+func TestCheckStakingEligibility(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	requestBodyBytes, err := json.Marshal(v1handlers.StakingEligibilityRequestPayload{
+		StakerPkHex:           activeStakingEvent.StakerPkHex,
+		FinalityProviderPkHex: activeStakingEvent.FinalityProviderPkHex,
+		StakingAmount:         activeStakingEvent.StakingValue,
+		StakingTime:           activeStakingEvent.StakingTimeLock,
+	})
+	require.NoError(t, err)
+	resp, err := http.Post(
+		testServer.Server.URL+stakingEligibilityPath, "application/json", bytes.NewReader(requestBodyBytes),
+	)
+	require.NoError(t, err, "making POST request to staking eligibility endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "reading response body should not fail")
+	var response handler.PublicResponse[v1service.StakingEligibilityPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+	assert.True(t, response.Data.Eligible)
+	for _, check := range response.Data.Checks {
+		assert.True(t, check.Passed, "check %s should pass", check.Name)
+	}
+}
+
+func TestCheckStakingEligibilityWithInvalidPayload(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	requestBodyBytes, err := json.Marshal(v1handlers.StakingEligibilityRequestPayload{
+		StakerPkHex:   "invalid",
+		StakingAmount: 1,
+		StakingTime:   1,
+	})
+	require.NoError(t, err)
+	resp, err := http.Post(
+		testServer.Server.URL+stakingEligibilityPath, "application/json", bytes.NewReader(requestBodyBytes),
+	)
+	require.NoError(t, err, "making POST request to staking eligibility endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
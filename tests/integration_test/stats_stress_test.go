@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stressDelegation struct {
+	stakingTxHashHex string
+	stakerPkHex      string
+	fpPkHex          string
+	amount           uint64
+	unbonded         bool
+}
+
+// TestConcurrentStatsUpdates fires the stats updates of overlapping delegations
+// concurrently, each of them delivered several times, and checks the stats
+// totals against the ones expected from the delegations.
+func TestConcurrentStatsUpdates(t *testing.T) {
+	const (
+		numOfDelegations = 500
+		deliveries       = 3
+		workers          = 32
+		maxAttempts      = 5
+	)
+	ctx := context.Background()
+	cfg := testutils.LoadTestConfig()
+	dbClients := testutils.SetupTestDB(*cfg)
+	params, err := types.NewGlobalParams("../config/global-params-test.json")
+	require.NoError(t, err)
+	fps, err := types.NewFinalityProviders("../config/finality-providers-test.json")
+	require.NoError(t, err)
+	c, err := clients.New(cfg)
+	require.NoError(t, err)
+	service, err := v1service.New(ctx, cfg, params, fps, c, dbClients)
+	require.NoError(t, err)
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stakerPks := testutils.GeneratePks(10)
+	fpPks := testutils.GeneratePks(5)
+	delegations := make([]stressDelegation, 0, numOfDelegations)
+	for i := 0; i < numOfDelegations; i++ {
+		_, stakingTxHashHex := testutils.RandomBytes(r, 32)
+		delegations = append(delegations, stressDelegation{
+			stakingTxHashHex: stakingTxHashHex,
+			stakerPkHex:      stakerPks[r.Intn(len(stakerPks))],
+			fpPkHex:          fpPks[r.Intn(len(fpPks))],
+			amount:           uint64(testutils.RandomAmount(r)),
+			unbonded:         r.Intn(2) == 0,
+		})
+	}
+
+	var operations []testutils.StressOperation
+	for _, d := range delegations {
+		states := []types.DelegationState{types.Active}
+		if d.unbonded {
+			states = append(states, types.Unbonded)
+		}
+		for _, state := range states {
+			for i := 0; i < deliveries; i++ {
+				operations = append(operations, func() error {
+					if err := service.ProcessStakingStatsCalculation(
+						ctx, d.stakingTxHashHex, d.stakerPkHex, d.fpPkHex, state, d.amount,
+					); err != nil {
+						return err.Err
+					}
+					return nil
+				})
+			}
+		}
+	}
+	r.Shuffle(len(operations), func(i, j int) {
+		operations[i], operations[j] = operations[j], operations[i]
+	})
+
+	errs := testutils.RunConcurrently(workers, maxAttempts, operations)
+	require.Empty(t, errs)
+
+	type expectedStats struct {
+		activeTvl, totalTvl, activeDelegations, totalDelegations int64
+	}
+	var overall expectedStats
+	byStaker := make(map[string]*expectedStats)
+	byFp := make(map[string]*expectedStats)
+	for _, d := range delegations {
+		if byStaker[d.stakerPkHex] == nil {
+			byStaker[d.stakerPkHex] = &expectedStats{}
+		}
+		if byFp[d.fpPkHex] == nil {
+			byFp[d.fpPkHex] = &expectedStats{}
+		}
+		for _, stats := range []*expectedStats{&overall, byStaker[d.stakerPkHex], byFp[d.fpPkHex]} {
+			stats.totalTvl += int64(d.amount)
+			stats.totalDelegations++
+			if !d.unbonded {
+				stats.activeTvl += int64(d.amount)
+				stats.activeDelegations++
+			}
+		}
+	}
+
+	// The total stakers is not asserted, it's derived from the staker stats
+	// read outside of the transaction, hence only accurate when the
+	// delegations of a staker are processed sequentially
+	overallStats, err := dbClients.V1DBClient.GetOverallStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, overall.activeTvl, overallStats.ActiveTvl)
+	assert.Equal(t, overall.totalTvl, overallStats.TotalTvl)
+	assert.Equal(t, overall.activeDelegations, overallStats.ActiveDelegations)
+	assert.Equal(t, overall.totalDelegations, overallStats.TotalDelegations)
+
+	for stakerPkHex, expected := range byStaker {
+		stakerStats, err := dbClients.V1DBClient.GetStakerStats(ctx, stakerPkHex)
+		require.NoError(t, err)
+		assert.Equal(t, expected.activeTvl, stakerStats.ActiveTvl, "staker %s", stakerPkHex)
+		assert.Equal(t, expected.totalTvl, stakerStats.TotalTvl, "staker %s", stakerPkHex)
+		assert.Equal(t, expected.activeDelegations, stakerStats.ActiveDelegations, "staker %s", stakerPkHex)
+		assert.Equal(t, expected.totalDelegations, stakerStats.TotalDelegations, "staker %s", stakerPkHex)
+	}
+
+	fpStats, err := dbClients.V1DBClient.FindFinalityProviderStatsByFinalityProviderPkHex(ctx, fpPks)
+	require.NoError(t, err)
+	require.Len(t, fpStats, len(byFp))
+	for _, stats := range fpStats {
+		expected := byFp[stats.FinalityProviderPkHex]
+		require.NotNil(t, expected)
+		assert.Equal(t, expected.activeTvl, stats.ActiveTvl, "finality provider %s", stats.FinalityProviderPkHex)
+		assert.Equal(t, expected.totalTvl, stats.TotalTvl, "finality provider %s", stats.FinalityProviderPkHex)
+		assert.Equal(t, expected.activeDelegations, stats.ActiveDelegations, "finality provider %s", stats.FinalityProviderPkHex)
+		assert.Equal(t, expected.totalDelegations, stats.TotalDelegations, "finality provider %s", stats.FinalityProviderPkHex)
+	}
+}
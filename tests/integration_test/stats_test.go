@@ -11,10 +11,15 @@ import (
 	"time"
 
 	handler "github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1handler "github.com/babylonlabs-io/staking-api-service/internal/v1/api/handlers"
 	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
 	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	v2queueschema "github.com/babylonlabs-io/staking-api-service/internal/v2/queue/schema"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
 	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
 	"github.com/babylonlabs-io/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
@@ -324,6 +329,81 @@ func TestStatsEndpoints(t *testing.T) {
 	assert.Equal(t, int64(90), overallStats.ActiveTvl)
 }
 
+func TestOverallStatsServedFromResponseCache(t *testing.T) {
+	cfg, err := config.New("../config/config-test.yml")
+	require.NoError(t, err)
+	cfg.ResponseCache = &config.ResponseCacheConfig{
+		TTL:        2 * time.Second,
+		StaleTTL:   time.Minute,
+		MaxEntries: 10,
+	}
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	url := testServer.Server.URL + overallStatsEndpoint
+
+	fetchOverallStats := func() (string, v1service.OverallStatsPublic) {
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var response handler.PublicResponse[v1service.OverallStatsPublic]
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return resp.Header.Get(middlewares.CacheStatusHeader), response.Data
+	}
+
+	cacheStatus, _ := fetchOverallStats()
+	assert.Equal(t, "miss", cacheStatus)
+	cacheStatus, initialStats := fetchOverallStats()
+	assert.Equal(t, "hit", cacheStatus)
+
+	activeStakingEvent := getTestActiveStakingEvent()
+	err = sendTestMessage(
+		testServer.Queues.V1QueueClient.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent},
+	)
+	require.NoError(t, err)
+	time.Sleep(3 * time.Second)
+
+	// The stale stats are served while being refreshed in the background
+	cacheStatus, stats := fetchOverallStats()
+	assert.Equal(t, "stale", cacheStatus)
+	assert.Equal(t, initialStats, stats)
+	time.Sleep(500 * time.Millisecond)
+	cacheStatus, stats = fetchOverallStats()
+	assert.Equal(t, "hit", cacheStatus)
+	assert.Equal(t, initialStats.TotalDelegations+1, stats.TotalDelegations)
+}
+
+func TestCacheControlHeaderPerRoute(t *testing.T) {
+	cfg, err := config.New("../config/config-test.yml")
+	require.NoError(t, err)
+	cfg.CacheControl = &config.CacheControlConfig{
+		Routes: map[string]time.Duration{overallStatsEndpoint: 30 * time.Second},
+	}
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + overallStatsEndpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "public, max-age=30", resp.Header.Get("Cache-Control"))
+
+	// The delegations are not cacheable
+	activeStakingEvent := getTestActiveStakingEvent()
+	resp, err = http.Get(
+		testServer.Server.URL + "/v1/delegation?staking_tx_hash_hex=" + activeStakingEvent.StakingTxHashHex,
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+
+	// Nor the errors of the cacheable routes
+	resp, err = http.Post(testServer.Server.URL+overallStatsEndpoint, "application/json", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+}
+
 func TestReturnEmptyArrayWhenNoStakerStatsFound(t *testing.T) {
 	testServer := setupTestServer(t, nil)
 	defer testServer.Close()
@@ -532,3 +612,103 @@ func fetchStakerStatsEndpoint(t *testing.T, testServer *TestServer, stakerPk str
 
 	return responseBody.Data
 }
+
+func TestSlashedAmountsRecordedInV2Stats(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	stakerPk := testutils.GeneratePks(1)[0]
+	fpPks := testutils.GeneratePks(2)
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	_, stakingTxHashHex := testutils.RandomBytes(r, 32)
+	slashedEvent := v2queueschema.NewSlashedStakingEvent(stakingTxHashHex, stakerPk, fpPks, 1000)
+	// The redelivered event must not be double counted
+	sendTestMessage(
+		testServer.Queues.V2QueueClient.SlashedStakingEventQueueClient,
+		[]v2queueschema.SlashedStakingEvent{slashedEvent, slashedEvent},
+	)
+	time.Sleep(2 * time.Second)
+
+	overallStats := fetchSuccessfulResponse[v2service.OverallStatsPublic](
+		t, testServer.Server.URL+"/v2/stats",
+	).Data
+	assert.Equal(t, int64(1000), overallStats.SlashedTvl)
+	assert.Equal(t, int64(1), overallStats.SlashedDelegations)
+
+	stakerStats := fetchSuccessfulResponse[v2service.StakerStatsPublic](
+		t, testServer.Server.URL+"/v2/staker/stats?staker_pk_hex="+stakerPk,
+	).Data
+	assert.Equal(t, int64(1000), stakerStats.SlashedTvl)
+	assert.Equal(t, uint32(1), stakerStats.SlashedDelegations)
+
+	fpStats, err := testutils.InspectDbDocuments[v2dbmodel.V2FinalityProviderStatsDocument](
+		testServer.Config, dbmodel.V2FinalityProviderStatsCollection,
+	)
+	require.NoError(t, err)
+	assert.Len(t, fpStats, 2)
+	for _, stats := range fpStats {
+		assert.Contains(t, fpPks, stats.FinalityProviderPkHex)
+		assert.Equal(t, int64(1000), stats.SlashedTvl)
+		assert.Equal(t, int64(1), stats.SlashedDelegations)
+	}
+}
+
+func TestGetStakersStatsByPks(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	var stakerPks []string
+	for i := 0; i < 3; i++ {
+		stakerPk, err := testutils.RandomPk()
+		require.NoError(t, err)
+		stakerPks = append(stakerPks, stakerPk)
+	}
+	// The last staker has no stats
+	for i, stakerPk := range stakerPks[:2] {
+		testutils.InjectDbDocument(testServer.Config, dbmodel.V1StakerStatsCollection, &v1dbmodel.StakerStatsDocument{
+			StakerPkHex:       stakerPk,
+			ActiveTvl:         int64(100 * (i + 1)),
+			TotalTvl:          int64(200 * (i + 1)),
+			ActiveDelegations: int64(i + 1),
+			TotalDelegations:  int64(2 * (i + 1)),
+		})
+	}
+
+	payload, err := json.Marshal(map[string][]string{
+		"staker_btc_pks": {stakerPks[2], stakerPks[1], stakerPks[0]},
+	})
+	require.NoError(t, err)
+	resp, err := http.Post(testServer.Server.URL+"/v1/stakers/stats", "application/json", bytes.NewReader(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var response handler.PublicResponse[[]v1service.StakerStatsPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, stakerPks[1], response.Data[0].StakerPkHex)
+	assert.Equal(t, int64(200), response.Data[0].ActiveTvl)
+	assert.Equal(t, stakerPks[0], response.Data[1].StakerPkHex)
+	assert.Equal(t, int64(2), response.Data[1].TotalDelegations)
+
+	resp, err = http.Post(testServer.Server.URL+"/v1/stakers/stats", "application/json",
+		bytes.NewReader([]byte(`{"staker_btc_pks":["invalid"]}`)))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// The largest batch exceeds the max content length of the server
+	maxStakerPks := make([]string, 0, v1handler.MAX_NUM_STAKERS_STATS_PKS)
+	for len(maxStakerPks) < v1handler.MAX_NUM_STAKERS_STATS_PKS {
+		maxStakerPks = append(maxStakerPks, stakerPks[len(maxStakerPks)%len(stakerPks)])
+	}
+	payload, err = json.Marshal(map[string][]string{"staker_btc_pks": maxStakerPks})
+	require.NoError(t, err)
+	require.Greater(t, int64(len(payload)), testServer.Config.Server.MaxContentLength)
+	resp, err = http.Post(testServer.Server.URL+"/v1/stakers/stats", "application/json", bytes.NewReader(payload))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
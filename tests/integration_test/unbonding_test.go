@@ -12,6 +12,7 @@ import (
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/api"
 	handler "github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
 	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
@@ -30,6 +31,7 @@ import (
 const (
 	unbondingEligibilityPath = "/v1/unbonding/eligibility"
 	unbondingPath            = "/v1/unbonding"
+	unbondingStatusPath      = "/v1/unbonding/status"
 )
 
 func TestUnbondingRequest(t *testing.T) {
@@ -77,8 +79,8 @@ func TestUnbondingRequest(t *testing.T) {
 	var response api.ErrorResponse
 	err = json.Unmarshal(bodyBytes, &response)
 	assert.NoError(t, err, "unmarshalling response body should not fail")
-	assert.Equal(t, "FORBIDDEN", response.ErrorCode, "expected error code to be FORBIDDEN")
-	assert.Equal(t, "delegation state is not active", response.Message, "expected error message to be 'delegation state is not active'")
+	assert.Equal(t, types.InvalidStateTransition.String(), response.ErrorCode, "expected error code to be INVALID_STATE_TRANSITION")
+	assert.Equal(t, "delegation is not allowed to transition from unbonding_requested to unbonding_requested", response.Message)
 
 	// Let's make a POST request to the unbonding endpoint again
 	resp, err = http.Post(unbondingUrl, "application/json", bytes.NewReader(requestBodyBytes))
@@ -94,8 +96,8 @@ func TestUnbondingRequest(t *testing.T) {
 
 	err = json.Unmarshal(bodyBytes, &response)
 	assert.NoError(t, err, "unmarshalling response body should not fail")
-	assert.Equal(t, "FORBIDDEN", response.ErrorCode, "expected error code to be FORBIDDEN")
-	assert.Equal(t, "delegation state is not active", response.Message, "expected error message to be 'no active delegation found for unbonding request'")
+	assert.Equal(t, types.UnbondingRequestReplayed.String(), response.ErrorCode, "expected the resubmission to be rejected as a replay")
+	assert.Equal(t, "unbonding request has already been submitted", response.Message)
 
 	// The state should be updated to UnbondingRequested
 	getStakerDelegationUrl := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + activeStakingEvent.StakerPkHex
@@ -135,6 +137,18 @@ func TestUnbondingRequest(t *testing.T) {
 	assert.Equal(t, activeStakingEvent.StakingOutputIndex, results[0].StakingOutputIndex)
 	assert.Equal(t, activeStakingEvent.StakingTimeLock, results[0].StakingTimelock)
 	assert.Equal(t, activeStakingEvent.StakingValue, results[0].StakingAmount)
+
+	// The request is accepted until the unbonding pipeline acknowledges it
+	resp, err = http.Get(testServer.Server.URL + unbondingStatusPath + "?unbonding_tx_hash_hex=" + requestBody.UnbondingTxHashHex)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	bodyBytes, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var statusResponse handler.PublicResponse[v1service.UnbondingRequestStatusPublic]
+	require.NoError(t, json.Unmarshal(bodyBytes, &statusResponse))
+	assert.Equal(t, v1service.UnbondingRequestAccepted, statusResponse.Data.Status)
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, statusResponse.Data.StakingTxHashHex)
 }
 
 func TestUnbondingRequestEligibilityWhenNoMatchingDelegation(t *testing.T) {
@@ -261,7 +275,7 @@ func TestProcessUnbondingStakingEvent(t *testing.T) {
 	assert.NotNil(t, getStakerDelegationResponse.Data[0].UnbondingTx, "expected unbonding tx to be present in the response body")
 	assert.Equal(t, unbondingEvent.UnbondingTxHex, getStakerDelegationResponse.Data[0].UnbondingTx.TxHex, "expected unbonding tx to match")
 
-	_, err = time.Parse(time.RFC3339, getStakerDelegationResponse.Data[0].UnbondingTx.StartTimestamp)
+	_, err = time.Parse(time.RFC3339, getStakerDelegationResponse.Data[0].UnbondingTx.StartTimestamp.String())
 	assert.NoError(t, err, "expected timestamp to be in RFC3339 format")
 
 	// Let's also fetch the DB to make sure the expired check is processed
@@ -513,7 +527,7 @@ func TestUnbondingRequestValidation(t *testing.T) {
 		},
 		nil,
 	)
-	mockMongoClient := &mongo.Client{}
+	mockMongoClient := dbclient.WrapMongoClient(&mongo.Client{})
 	testServer := setupTestServer(t, &TestServerDependency{MockDbClients: dbclients.DbClients{
 		StakingMongoClient: mockMongoClient,
 		V1DBClient:         mockV1DBClient,
@@ -638,3 +652,41 @@ func TestContentLength(t *testing.T) {
 
 	assert.NotEqual(t, http.StatusRequestEntityTooLarge, resp.StatusCode, "expected status other than HTTP 413 Request Entity Too Large")
 }
+
+func TestRouteGroupContentLengthLimits(t *testing.T) {
+	cfg, err := config.New("../config/config-test.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The unbonding requests are allowed a larger body than the other routes
+	cfg.RouteLimits = &config.RouteLimitsConfig{
+		Public:    &config.RouteGroupLimits{MaxContentLength: 16},
+		Unbonding: &config.RouteGroupLimits{MaxContentLength: cfg.Server.MaxContentLength * 2},
+	}
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+
+	// Larger than the server wide limit, but within the unbonding one
+	payload := bytes.Repeat([]byte{'a'}, int(cfg.Server.MaxContentLength)+1)
+	resp, err := http.Post(testServer.Server.URL+unbondingPath, "application/json", bytes.NewReader(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	payload = bytes.Repeat([]byte{'a'}, int(cfg.Server.MaxContentLength)*2+1)
+	resp, err = http.Post(testServer.Server.URL+unbondingPath, "application/json", bytes.NewReader(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	var errorResponse api.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errorResponse))
+	assert.Equal(t, types.RequestTooLarge.String(), errorResponse.ErrorCode)
+
+	// The public routes are limited by the public group
+	resp, err = http.Post(
+		testServer.Server.URL+"/v1/staking/verify", "application/json", bytes.NewReader(bytes.Repeat([]byte{'a'}, 17)),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
@@ -14,8 +14,8 @@ func TestUnprocessableMessageShouldBeStoredInDB(t *testing.T) {
 	testServer := setupTestServer(t, nil)
 	defer testServer.Close()
 	sendTestMessage[string](testServer.Queues.V1QueueClient.ActiveStakingQueueClient, []string{"a rubbish message"})
-	// In test, we retry 3 times. (config is 2, but counting start from 0)
-	time.Sleep(20 * time.Second)
+	// The message does not match the queue schema, hence it's not retried
+	time.Sleep(5 * time.Second)
 
 	// Fetch from DB and check
 	docs, err := testutils.InspectDbDocuments[dbmodel.UnprocessableMessageDocument](
@@ -30,6 +30,7 @@ func TestUnprocessableMessageShouldBeStoredInDB(t *testing.T) {
 	}
 
 	assert.Equal(t, "\"a rubbish message\"", docs[0].MessageBody)
+	assert.Contains(t, docs[0].Reason, "schema violation")
 
 	// Also make sure the message is not in the queue anymore
 	count, err := inspectQueueMessageCount(t, testServer.Conn, client.ActiveStakingQueueName)
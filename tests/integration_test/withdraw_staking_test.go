@@ -3,6 +3,7 @@ package tests
 import (
 	"bytes"
 	"encoding/json"
+	"math/rand"
 	"net/http"
 	"testing"
 	"time"
@@ -10,6 +11,8 @@ import (
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1queueschema "github.com/babylonlabs-io/staking-api-service/internal/v1/queue/schema"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
 	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
 	"github.com/babylonlabs-io/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
@@ -302,3 +305,50 @@ func TestShouldIgnoreWithdrawnEventIfAlreadyWithdrawn(t *testing.T) {
 	}
 	assert.Equal(t, 0, count, "expected no message in the queue")
 }
+
+func TestWithdrawStakingEventWithWithdrawalTx(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.V1QueueClient.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	expiredEvent := client.ExpiredStakingEvent{
+		EventType:        client.ExpiredStakingEventType,
+		StakingTxHashHex: activeStakingEvent.StakingTxHashHex,
+		TxType:           types.ActiveTxType.ToString(),
+	}
+	sendTestMessage(testServer.Queues.V1QueueClient.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredEvent})
+	time.Sleep(2 * time.Second)
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	withdrawalTx, withdrawalTxHex, err := testutils.GenerateRandomTx(r, nil)
+	assert.NoError(t, err)
+	withdrawalTimestamp := time.Now().Unix()
+	withdrawEvent := v1queueschema.NewWithdrawStakingEvent(
+		activeStakingEvent.StakingTxHashHex, withdrawalTx.TxHash().String(),
+		withdrawalTxHex, 0, withdrawalTimestamp,
+	)
+	sendTestMessage(testServer.Queues.V1QueueClient.WithdrawStakingQueueClient, []v1queueschema.WithdrawStakingEvent{withdrawEvent})
+	time.Sleep(2 * time.Second)
+
+	results, err := testutils.InspectDbDocuments[v1model.DelegationDocument](
+		testServer.Config, dbmodel.V1DelegationCollection,
+	)
+	if err != nil {
+		t.Fatalf("Failed to inspect DB documents: %v", err)
+	}
+	assert.Equal(t, 1, len(results), "expected 1 document in the DB")
+	assert.Equal(t, types.Withdrawn, results[0].State, "expected state to be withdrawn")
+	assert.NotNil(t, results[0].WithdrawalTx)
+	assert.Equal(t, withdrawalTx.TxHash().String(), results[0].WithdrawalTx.TxHashHex)
+	assert.Equal(t, withdrawalTxHex, results[0].WithdrawalTx.TxHex)
+	assert.Equal(t, withdrawalTimestamp, results[0].WithdrawalTx.StartTimestamp)
+
+	// The delegation can be resolved by the withdrawal tx hash
+	url := testServer.Server.URL + delegationRouter + "/by-tx?tx_hash_hex=" + withdrawalTx.TxHash().String()
+	response := fetchSuccessfulResponse[v1service.DelegationPublic](t, url)
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, response.Data.StakingTxHashHex)
+	assert.NotNil(t, response.Data.WithdrawalTx)
+	assert.Equal(t, withdrawalTx.TxHash().String(), response.Data.WithdrawalTx.TxHashHex)
+}
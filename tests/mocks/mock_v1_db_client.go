@@ -10,6 +10,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 
 	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
@@ -22,27 +24,27 @@ type V1DBClient struct {
 	mock.Mock
 }
 
-// CheckDelegationExistByStakerPk provides a mock function with given fields: ctx, address, extraFilter
-func (_m *V1DBClient) CheckDelegationExistByStakerPk(ctx context.Context, address string, extraFilter *v1dbclient.DelegationFilter) (bool, error) {
-	ret := _m.Called(ctx, address, extraFilter)
+// AcquireLeaderLease provides a mock function with given fields: ctx, job, holder, now, expiresAt
+func (_m *V1DBClient) AcquireLeaderLease(ctx context.Context, job string, holder string, now time.Time, expiresAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, job, holder, now, expiresAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckDelegationExistByStakerPk")
+		panic("no return value specified for AcquireLeaderLease")
 	}
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter) (bool, error)); ok {
-		return rf(ctx, address, extraFilter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, job, holder, now, expiresAt)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter) bool); ok {
-		r0 = rf(ctx, address, extraFilter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, job, holder, now, expiresAt)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *v1dbclient.DelegationFilter) error); ok {
-		r1 = rf(ctx, address, extraFilter)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, job, holder, now, expiresAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -50,47 +52,55 @@ func (_m *V1DBClient) CheckDelegationExistByStakerPk(ctx context.Context, addres
 	return r0, r1
 }
 
-// DeleteUnprocessableMessage provides a mock function with given fields: ctx, Receipt
-func (_m *V1DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
-	ret := _m.Called(ctx, Receipt)
+// ArchiveDelegations provides a mock function with given fields: ctx, states, updatedBefore, limit
+func (_m *V1DBClient) ArchiveDelegations(ctx context.Context, states []types.DelegationState, updatedBefore int64, limit int64) (int64, error) {
+	ret := _m.Called(ctx, states, updatedBefore, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUnprocessableMessage")
+		panic("no return value specified for ArchiveDelegations")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
-		r0 = rf(ctx, Receipt)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.DelegationState, int64, int64) (int64, error)); ok {
+		return rf(ctx, states, updatedBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.DelegationState, int64, int64) int64); ok {
+		r0 = rf(ctx, states, updatedBefore, limit)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, []types.DelegationState, int64, int64) error); ok {
+		r1 = rf(ctx, states, updatedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// FindDelegationByTxHashHex provides a mock function with given fields: ctx, txHashHex
-func (_m *V1DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error) {
-	ret := _m.Called(ctx, txHashHex)
+// ArchivedDelegationExists provides a mock function with given fields: ctx, stakingTxHashHex
+func (_m *V1DBClient) ArchivedDelegationExists(ctx context.Context, stakingTxHashHex string) (bool, error) {
+	ret := _m.Called(ctx, stakingTxHashHex)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindDelegationByTxHashHex")
+		panic("no return value specified for ArchivedDelegationExists")
 	}
 
-	var r0 *v1dbmodel.DelegationDocument
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.DelegationDocument, error)); ok {
-		return rf(ctx, txHashHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, stakingTxHashHex)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.DelegationDocument); ok {
-		r0 = rf(ctx, txHashHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, stakingTxHashHex)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1dbmodel.DelegationDocument)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, txHashHex)
+		r1 = rf(ctx, stakingTxHashHex)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,29 +108,29 @@ func (_m *V1DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex s
 	return r0, r1
 }
 
-// FindDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, extraFilter, paginationToken
-func (_m *V1DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *v1dbclient.DelegationFilter, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
-	ret := _m.Called(ctx, stakerPk, extraFilter, paginationToken)
+// BulkSaveActiveStakingDelegations provides a mock function with given fields: ctx, delegations
+func (_m *V1DBClient) BulkSaveActiveStakingDelegations(ctx context.Context, delegations []*v1dbmodel.DelegationDocument) (*db.BulkWriteResult, error) {
+	ret := _m.Called(ctx, delegations)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindDelegationsByStakerPk")
+		panic("no return value specified for BulkSaveActiveStakingDelegations")
 	}
 
-	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r0 *db.BulkWriteResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
-		return rf(ctx, stakerPk, extraFilter, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, []*v1dbmodel.DelegationDocument) (*db.BulkWriteResult, error)); ok {
+		return rf(ctx, delegations)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
-		r0 = rf(ctx, stakerPk, extraFilter, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, []*v1dbmodel.DelegationDocument) *db.BulkWriteResult); ok {
+		r0 = rf(ctx, delegations)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+			r0 = ret.Get(0).(*db.BulkWriteResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *v1dbclient.DelegationFilter, string) error); ok {
-		r1 = rf(ctx, stakerPk, extraFilter, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, []*v1dbmodel.DelegationDocument) error); ok {
+		r1 = rf(ctx, delegations)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -128,29 +138,29 @@ func (_m *V1DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk st
 	return r0, r1
 }
 
-// FindFinalityProviderStats provides a mock function with given fields: ctx, paginationToken
-func (_m *V1DBClient) FindFinalityProviderStats(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error) {
-	ret := _m.Called(ctx, paginationToken)
+// BulkTransitionToUnbondedState provides a mock function with given fields: ctx, stakingTxHashHexes, eligiblePreviousState
+func (_m *V1DBClient) BulkTransitionToUnbondedState(ctx context.Context, stakingTxHashHexes []string, eligiblePreviousState []types.DelegationState) (*db.BulkWriteResult, error) {
+	ret := _m.Called(ctx, stakingTxHashHexes, eligiblePreviousState)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindFinalityProviderStats")
+		panic("no return value specified for BulkTransitionToUnbondedState")
 	}
 
-	var r0 *db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument]
+	var r0 *db.BulkWriteResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error)); ok {
-		return rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []types.DelegationState) (*db.BulkWriteResult, error)); ok {
+		return rf(ctx, stakingTxHashHexes, eligiblePreviousState)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument]); ok {
-		r0 = rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []types.DelegationState) *db.BulkWriteResult); ok {
+		r0 = rf(ctx, stakingTxHashHexes, eligiblePreviousState)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument])
+			r0 = ret.Get(0).(*db.BulkWriteResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, []string, []types.DelegationState) error); ok {
+		r1 = rf(ctx, stakingTxHashHexes, eligiblePreviousState)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -158,29 +168,29 @@ func (_m *V1DBClient) FindFinalityProviderStats(ctx context.Context, paginationT
 	return r0, r1
 }
 
-// FindFinalityProviderStatsByFinalityProviderPkHex provides a mock function with given fields: ctx, finalityProviderPkHex
-func (_m *V1DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx context.Context, finalityProviderPkHex []string) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
-	ret := _m.Called(ctx, finalityProviderPkHex)
+// BulkTransitionToWithdrawnState provides a mock function with given fields: ctx, stakingTxHashHexes, withdrawalTxs
+func (_m *V1DBClient) BulkTransitionToWithdrawnState(ctx context.Context, stakingTxHashHexes []string, withdrawalTxs map[string]*v1dbmodel.WithdrawalTransaction) (*db.BulkWriteResult, error) {
+	ret := _m.Called(ctx, stakingTxHashHexes, withdrawalTxs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindFinalityProviderStatsByFinalityProviderPkHex")
+		panic("no return value specified for BulkTransitionToWithdrawnState")
 	}
 
-	var r0 []*v1dbmodel.FinalityProviderStatsDocument
+	var r0 *db.BulkWriteResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*v1dbmodel.FinalityProviderStatsDocument, error)); ok {
-		return rf(ctx, finalityProviderPkHex)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, map[string]*v1dbmodel.WithdrawalTransaction) (*db.BulkWriteResult, error)); ok {
+		return rf(ctx, stakingTxHashHexes, withdrawalTxs)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []*v1dbmodel.FinalityProviderStatsDocument); ok {
-		r0 = rf(ctx, finalityProviderPkHex)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, map[string]*v1dbmodel.WithdrawalTransaction) *db.BulkWriteResult); ok {
+		r0 = rf(ctx, stakingTxHashHexes, withdrawalTxs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1dbmodel.FinalityProviderStatsDocument)
+			r0 = ret.Get(0).(*db.BulkWriteResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, finalityProviderPkHex)
+	if rf, ok := ret.Get(1).(func(context.Context, []string, map[string]*v1dbmodel.WithdrawalTransaction) error); ok {
+		r1 = rf(ctx, stakingTxHashHexes, withdrawalTxs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -188,29 +198,27 @@ func (_m *V1DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx conte
 	return r0, r1
 }
 
-// FindPkMappingsByNativeSegwitAddress provides a mock function with given fields: ctx, nativeSegwitAddresses
-func (_m *V1DBClient) FindPkMappingsByNativeSegwitAddress(ctx context.Context, nativeSegwitAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
-	ret := _m.Called(ctx, nativeSegwitAddresses)
+// CheckDelegationExistByStakerPk provides a mock function with given fields: ctx, address, extraFilter
+func (_m *V1DBClient) CheckDelegationExistByStakerPk(ctx context.Context, address string, extraFilter *v1dbclient.DelegationFilter) (bool, error) {
+	ret := _m.Called(ctx, address, extraFilter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindPkMappingsByNativeSegwitAddress")
+		panic("no return value specified for CheckDelegationExistByStakerPk")
 	}
 
-	var r0 []*dbmodel.PkAddressMapping
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*dbmodel.PkAddressMapping, error)); ok {
-		return rf(ctx, nativeSegwitAddresses)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter) (bool, error)); ok {
+		return rf(ctx, address, extraFilter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []*dbmodel.PkAddressMapping); ok {
-		r0 = rf(ctx, nativeSegwitAddresses)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter) bool); ok {
+		r0 = rf(ctx, address, extraFilter)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*dbmodel.PkAddressMapping)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, nativeSegwitAddresses)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v1dbclient.DelegationFilter) error); ok {
+		r1 = rf(ctx, address, extraFilter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -218,29 +226,29 @@ func (_m *V1DBClient) FindPkMappingsByNativeSegwitAddress(ctx context.Context, n
 	return r0, r1
 }
 
-// FindPkMappingsByTaprootAddress provides a mock function with given fields: ctx, taprootAddresses
-func (_m *V1DBClient) FindPkMappingsByTaprootAddress(ctx context.Context, taprootAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
-	ret := _m.Called(ctx, taprootAddresses)
+// ClaimExportJob provides a mock function with given fields: ctx, now, staleBefore
+func (_m *V1DBClient) ClaimExportJob(ctx context.Context, now time.Time, staleBefore time.Time) (*dbmodel.ExportJobDocument, error) {
+	ret := _m.Called(ctx, now, staleBefore)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindPkMappingsByTaprootAddress")
+		panic("no return value specified for ClaimExportJob")
 	}
 
-	var r0 []*dbmodel.PkAddressMapping
+	var r0 *dbmodel.ExportJobDocument
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*dbmodel.PkAddressMapping, error)); ok {
-		return rf(ctx, taprootAddresses)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (*dbmodel.ExportJobDocument, error)); ok {
+		return rf(ctx, now, staleBefore)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []*dbmodel.PkAddressMapping); ok {
-		r0 = rf(ctx, taprootAddresses)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) *dbmodel.ExportJobDocument); ok {
+		r0 = rf(ctx, now, staleBefore)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*dbmodel.PkAddressMapping)
+			r0 = ret.Get(0).(*dbmodel.ExportJobDocument)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, taprootAddresses)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, now, staleBefore)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -248,29 +256,109 @@ func (_m *V1DBClient) FindPkMappingsByTaprootAddress(ctx context.Context, taproo
 	return r0, r1
 }
 
-// FindTopStakersByTvl provides a mock function with given fields: ctx, paginationToken
-func (_m *V1DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error) {
-	ret := _m.Called(ctx, paginationToken)
+// CompleteExportJob provides a mock function with given fields: ctx, id, startedAt, objectKey, completedAt
+func (_m *V1DBClient) CompleteExportJob(ctx context.Context, id string, startedAt time.Time, objectKey string, completedAt time.Time) error {
+	ret := _m.Called(ctx, id, startedAt, objectKey, completedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindTopStakersByTvl")
+		panic("no return value specified for CompleteExportJob")
 	}
 
-	var r0 *db.DbResultMap[*v1dbmodel.StakerStatsDocument]
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string, time.Time) error); ok {
+		r0 = rf(ctx, id, startedAt, objectKey, completedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteIdempotencyKey provides a mock function with given fields: ctx, key, statusCode, contentType, body
+func (_m *V1DBClient) CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	ret := _m.Called(ctx, key, statusCode, contentType, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, []byte) error); ok {
+		r0 = rf(ctx, key, statusCode, contentType, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteScheduledJobRun provides a mock function with given fields: ctx, name, runErr, finishedAt, duration
+func (_m *V1DBClient) CompleteScheduledJobRun(ctx context.Context, name string, runErr error, finishedAt time.Time, duration time.Duration) error {
+	ret := _m.Called(ctx, name, runErr, finishedAt, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteScheduledJobRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, error, time.Time, time.Duration) error); ok {
+		r0 = rf(ctx, name, runErr, finishedAt, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, extraFilter
+func (_m *V1DBClient) CountDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *v1dbclient.DelegationFilter) (int64, error) {
+	ret := _m.Called(ctx, stakerPk, extraFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDelegationsByStakerPk")
+	}
+
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error)); ok {
-		return rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter) (int64, error)); ok {
+		return rf(ctx, stakerPk, extraFilter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*v1dbmodel.StakerStatsDocument]); ok {
-		r0 = rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter) int64); ok {
+		r0 = rf(ctx, stakerPk, extraFilter)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.DbResultMap[*v1dbmodel.StakerStatsDocument])
-		}
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v1dbclient.DelegationFilter) error); ok {
+		r1 = rf(ctx, stakerPk, extraFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountPkAddressMappings provides a mock function with given fields: ctx, pkHex
+func (_m *V1DBClient) CountPkAddressMappings(ctx context.Context, pkHex string) (int64, error) {
+	ret := _m.Called(ctx, pkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPkAddressMappings")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, pkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, pkHex)
+	} else {
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, paginationToken)
+		r1 = rf(ctx, pkHex)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -278,24 +366,1507 @@ func (_m *V1DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken s
 	return r0, r1
 }
 
-// FindUnprocessableMessages provides a mock function with given fields: ctx
-func (_m *V1DBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error) {
-	ret := _m.Called(ctx)
+// CountStakerPartnerAttributions provides a mock function with given fields: ctx, stakerPkHex
+func (_m *V1DBClient) CountStakerPartnerAttributions(ctx context.Context, stakerPkHex string) (int64, error) {
+	ret := _m.Called(ctx, stakerPkHex)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindUnprocessableMessages")
+		panic("no return value specified for CountStakerPartnerAttributions")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, stakerPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, stakerPkHex)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountStakerStats provides a mock function with given fields: ctx, estimated
+func (_m *V1DBClient) CountStakerStats(ctx context.Context, estimated bool) (int64, error) {
+	ret := _m.Called(ctx, estimated)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountStakerStats")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) (int64, error)); ok {
+		return rf(ctx, estimated)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) int64); ok {
+		r0 = rf(ctx, estimated)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, estimated)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelegationExists provides a mock function with given fields: ctx, stakingTxHashHex
+func (_m *V1DBClient) DelegationExists(ctx context.Context, stakingTxHashHex string) (bool, error) {
+	ret := _m.Called(ctx, stakingTxHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DelegationExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, stakingTxHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, stakingTxHashHex)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *V1DBClient) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePkAddressMappings provides a mock function with given fields: ctx, pkHex
+func (_m *V1DBClient) DeletePkAddressMappings(ctx context.Context, pkHex string) (int64, error) {
+	ret := _m.Called(ctx, pkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePkAddressMappings")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, pkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, pkHex)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUnprocessableMessage provides a mock function with given fields: ctx, Receipt
+func (_m *V1DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
+	ret := _m.Called(ctx, Receipt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnprocessableMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
+		r0 = rf(ctx, Receipt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Exists provides a mock function with given fields: ctx, collection, filter
+func (_m *V1DBClient) Exists(ctx context.Context, collection string, filter interface{}) (bool, error) {
+	ret := _m.Called(ctx, collection, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) (bool, error)); ok {
+		return rf(ctx, collection, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) bool); ok {
+		r0 = rf(ctx, collection, filter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, collection, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FailExportJob provides a mock function with given fields: ctx, id, startedAt, reason, completedAt
+func (_m *V1DBClient) FailExportJob(ctx context.Context, id string, startedAt time.Time, reason string, completedAt time.Time) error {
+	ret := _m.Called(ctx, id, startedAt, reason, completedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailExportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string, time.Time) error); ok {
+		r0 = rf(ctx, id, startedAt, reason, completedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAllFinalityProviderStats provides a mock function with given fields: ctx
+func (_m *V1DBClient) FindAllFinalityProviderStats(ctx context.Context) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllFinalityProviderStats")
+	}
+
+	var r0 []*v1dbmodel.FinalityProviderStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*v1dbmodel.FinalityProviderStatsDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*v1dbmodel.FinalityProviderStatsDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1dbmodel.FinalityProviderStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindApiKeyUsage provides a mock function with given fields: ctx, apiKey, fromDayStart
+func (_m *V1DBClient) FindApiKeyUsage(ctx context.Context, apiKey string, fromDayStart time.Time) ([]dbmodel.ApiKeyUsageDocument, error) {
+	ret := _m.Called(ctx, apiKey, fromDayStart)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindApiKeyUsage")
+	}
+
+	var r0 []dbmodel.ApiKeyUsageDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]dbmodel.ApiKeyUsageDocument, error)); ok {
+		return rf(ctx, apiKey, fromDayStart)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []dbmodel.ApiKeyUsageDocument); ok {
+		r0 = rf(ctx, apiKey, fromDayStart)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.ApiKeyUsageDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, apiKey, fromDayStart)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindArchivedDelegationByTxHashHex provides a mock function with given fields: ctx, stakingTxHashHex
+func (_m *V1DBClient) FindArchivedDelegationByTxHashHex(ctx context.Context, stakingTxHashHex string) (*v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindArchivedDelegationByTxHashHex")
+	}
+
+	var r0 *v1dbmodel.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, stakingTxHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, stakingTxHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindBtcReorgByBlockHash provides a mock function with given fields: ctx, blockHash
+func (_m *V1DBClient) FindBtcReorgByBlockHash(ctx context.Context, blockHash string) (*v1dbmodel.BtcReorgDocument, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBtcReorgByBlockHash")
+	}
+
+	var r0 *v1dbmodel.BtcReorgDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.BtcReorgDocument, error)); ok {
+		return rf(ctx, blockHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.BtcReorgDocument); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.BtcReorgDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationByAnyTxHashHex provides a mock function with given fields: ctx, txHashHex
+func (_m *V1DBClient) FindDelegationByAnyTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, txHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationByAnyTxHashHex")
+	}
+
+	var r0 *v1dbmodel.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, txHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, txHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, txHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationByTxHashHex provides a mock function with given fields: ctx, txHashHex
+func (_m *V1DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, txHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationByTxHashHex")
+	}
+
+	var r0 *v1dbmodel.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, txHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, txHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, txHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationChanges provides a mock function with given fields: ctx, cursor
+func (_m *V1DBClient) FindDelegationChanges(ctx context.Context, cursor string) ([]v1dbmodel.DelegationDocument, string, error) {
+	ret := _m.Called(ctx, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationChanges")
+	}
+
+	var r0 []v1dbmodel.DelegationDocument
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1dbmodel.DelegationDocument, string, error)); ok {
+		return rf(ctx, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, cursor)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, cursor)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindDelegationLabels provides a mock function with given fields: ctx, apiKey, stakingTxHashHex
+func (_m *V1DBClient) FindDelegationLabels(ctx context.Context, apiKey string, stakingTxHashHex string) (*v1dbmodel.DelegationLabelsDocument, error) {
+	ret := _m.Called(ctx, apiKey, stakingTxHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationLabels")
+	}
+
+	var r0 *v1dbmodel.DelegationLabelsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v1dbmodel.DelegationLabelsDocument, error)); ok {
+		return rf(ctx, apiKey, stakingTxHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1dbmodel.DelegationLabelsDocument); ok {
+		r0 = rf(ctx, apiKey, stakingTxHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.DelegationLabelsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, apiKey, stakingTxHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationLabelsByLabel provides a mock function with given fields: ctx, apiKey, label, paginationToken
+func (_m *V1DBClient) FindDelegationLabelsByLabel(ctx context.Context, apiKey string, label string, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationLabelsDocument], error) {
+	ret := _m.Called(ctx, apiKey, label, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationLabelsByLabel")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationLabelsDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*db.DbResultMap[v1dbmodel.DelegationLabelsDocument], error)); ok {
+		return rf(ctx, apiKey, label, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *db.DbResultMap[v1dbmodel.DelegationLabelsDocument]); ok {
+		r0 = rf(ctx, apiKey, label, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationLabelsDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, apiKey, label, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationMilestones provides a mock function with given fields: ctx, stakingTxHashHex
+func (_m *V1DBClient) FindDelegationMilestones(ctx context.Context, stakingTxHashHex string) ([]v1dbmodel.DelegationAuditTrailDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationMilestones")
+	}
+
+	var r0 []v1dbmodel.DelegationAuditTrailDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1dbmodel.DelegationAuditTrailDocument, error)); ok {
+		return rf(ctx, stakingTxHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1dbmodel.DelegationAuditTrailDocument); ok {
+		r0 = rf(ctx, stakingTxHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.DelegationAuditTrailDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, extraFilter, paginationToken
+func (_m *V1DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *v1dbclient.DelegationFilter, paginationToken string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error) {
+	ret := _m.Called(ctx, stakerPk, extraFilter, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByStakerPk")
+	}
+
+	var r0 *db.DbResultMap[v1dbmodel.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter, string) (*db.DbResultMap[v1dbmodel.DelegationDocument], error)); ok {
+		return rf(ctx, stakerPk, extraFilter, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter, string) *db.DbResultMap[v1dbmodel.DelegationDocument]); ok {
+		r0 = rf(ctx, stakerPk, extraFilter, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[v1dbmodel.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v1dbclient.DelegationFilter, string) error); ok {
+		r1 = rf(ctx, stakerPk, extraFilter, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindExpiredDelegations provides a mock function with given fields: ctx, height, limit
+func (_m *V1DBClient) FindExpiredDelegations(ctx context.Context, height uint64, limit int64) ([]v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, height, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExpiredDelegations")
+	}
+
+	var r0 []v1dbmodel.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64) ([]v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, height, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64) []v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, height, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int64) error); ok {
+		r1 = rf(ctx, height, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindExportJob provides a mock function with given fields: ctx, id
+func (_m *V1DBClient) FindExportJob(ctx context.Context, id string) (*dbmodel.ExportJobDocument, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExportJob")
+	}
+
+	var r0 *dbmodel.ExportJobDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*dbmodel.ExportJobDocument, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *dbmodel.ExportJobDocument); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dbmodel.ExportJobDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProviderPkHexesWithStats provides a mock function with given fields: ctx, finalityProviderPkHex
+func (_m *V1DBClient) FindFinalityProviderPkHexesWithStats(ctx context.Context, finalityProviderPkHex []string) ([]string, error) {
+	ret := _m.Called(ctx, finalityProviderPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProviderPkHexesWithStats")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, finalityProviderPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, finalityProviderPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, finalityProviderPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProviderStats provides a mock function with given fields: ctx, paginationToken
+func (_m *V1DBClient) FindFinalityProviderStats(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error) {
+	ret := _m.Called(ctx, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProviderStats")
+	}
+
+	var r0 *db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument], error)); ok {
+		return rf(ctx, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument]); ok {
+		r0 = rf(ctx, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[*v1dbmodel.FinalityProviderStatsDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProviderStatsByFinalityProviderPkHex provides a mock function with given fields: ctx, finalityProviderPkHex
+func (_m *V1DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx context.Context, finalityProviderPkHex []string) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+	ret := _m.Called(ctx, finalityProviderPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProviderStatsByFinalityProviderPkHex")
+	}
+
+	var r0 []*v1dbmodel.FinalityProviderStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*v1dbmodel.FinalityProviderStatsDocument, error)); ok {
+		return rf(ctx, finalityProviderPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*v1dbmodel.FinalityProviderStatsDocument); ok {
+		r0 = rf(ctx, finalityProviderPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1dbmodel.FinalityProviderStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, finalityProviderPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFpCommissionHistory provides a mock function with given fields: ctx, fpPkHex
+func (_m *V1DBClient) FindFpCommissionHistory(ctx context.Context, fpPkHex string) ([]v1dbmodel.FpCommissionChangeDocument, error) {
+	ret := _m.Called(ctx, fpPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFpCommissionHistory")
+	}
+
+	var r0 []v1dbmodel.FpCommissionChangeDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1dbmodel.FpCommissionChangeDocument, error)); ok {
+		return rf(ctx, fpPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1dbmodel.FpCommissionChangeDocument); ok {
+		r0 = rf(ctx, fpPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.FpCommissionChangeDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fpPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindHourlyOverallStats provides a mock function with given fields: ctx, at
+func (_m *V1DBClient) FindHourlyOverallStats(ctx context.Context, at time.Time) (*v1dbmodel.HourlyOverallStatsDocument, error) {
+	ret := _m.Called(ctx, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindHourlyOverallStats")
+	}
+
+	var r0 *v1dbmodel.HourlyOverallStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*v1dbmodel.HourlyOverallStatsDocument, error)); ok {
+		return rf(ctx, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *v1dbmodel.HourlyOverallStatsDocument); ok {
+		r0 = rf(ctx, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.HourlyOverallStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindIndexStats provides a mock function with given fields: ctx
+func (_m *V1DBClient) FindIndexStats(ctx context.Context) ([]dbmodel.IndexStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIndexStats")
+	}
+
+	var r0 []dbmodel.IndexStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.IndexStatsDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.IndexStatsDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.IndexStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPartnerStats provides a mock function with given fields: ctx, partnerId
+func (_m *V1DBClient) FindPartnerStats(ctx context.Context, partnerId string) (*v1dbmodel.PartnerStatsDocument, error) {
+	ret := _m.Called(ctx, partnerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPartnerStats")
+	}
+
+	var r0 *v1dbmodel.PartnerStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.PartnerStatsDocument, error)); ok {
+		return rf(ctx, partnerId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.PartnerStatsDocument); ok {
+		r0 = rf(ctx, partnerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.PartnerStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, partnerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPkMappingsByNativeSegwitAddress provides a mock function with given fields: ctx, nativeSegwitAddresses
+func (_m *V1DBClient) FindPkMappingsByNativeSegwitAddress(ctx context.Context, nativeSegwitAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
+	ret := _m.Called(ctx, nativeSegwitAddresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPkMappingsByNativeSegwitAddress")
+	}
+
+	var r0 []*dbmodel.PkAddressMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*dbmodel.PkAddressMapping, error)); ok {
+		return rf(ctx, nativeSegwitAddresses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*dbmodel.PkAddressMapping); ok {
+		r0 = rf(ctx, nativeSegwitAddresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dbmodel.PkAddressMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, nativeSegwitAddresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPkMappingsByTaprootAddress provides a mock function with given fields: ctx, taprootAddresses
+func (_m *V1DBClient) FindPkMappingsByTaprootAddress(ctx context.Context, taprootAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
+	ret := _m.Called(ctx, taprootAddresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPkMappingsByTaprootAddress")
+	}
+
+	var r0 []*dbmodel.PkAddressMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*dbmodel.PkAddressMapping, error)); ok {
+		return rf(ctx, taprootAddresses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*dbmodel.PkAddressMapping); ok {
+		r0 = rf(ctx, taprootAddresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dbmodel.PkAddressMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, taprootAddresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRecentDelegationsByFinalityProviderPk provides a mock function with given fields: ctx, fpPkHex, limit
+func (_m *V1DBClient) FindRecentDelegationsByFinalityProviderPk(ctx context.Context, fpPkHex string, limit int64) ([]v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, fpPkHex, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecentDelegationsByFinalityProviderPk")
+	}
+
+	var r0 []v1dbmodel.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, fpPkHex, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, fpPkHex, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, fpPkHex, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindScheduledJobRuns provides a mock function with given fields: ctx
+func (_m *V1DBClient) FindScheduledJobRuns(ctx context.Context) ([]dbmodel.ScheduledJobDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindScheduledJobRuns")
+	}
+
+	var r0 []dbmodel.ScheduledJobDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.ScheduledJobDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.ScheduledJobDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.ScheduledJobDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerStateSummaries provides a mock function with given fields: ctx, stakerPkHex
+func (_m *V1DBClient) FindStakerStateSummaries(ctx context.Context, stakerPkHex string) ([]v1dbmodel.StakerStateSummaryDocument, error) {
+	ret := _m.Called(ctx, stakerPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerStateSummaries")
+	}
+
+	var r0 []v1dbmodel.StakerStateSummaryDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1dbmodel.StakerStateSummaryDocument, error)); ok {
+		return rf(ctx, stakerPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []v1dbmodel.StakerStateSummaryDocument); ok {
+		r0 = rf(ctx, stakerPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1dbmodel.StakerStateSummaryDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerStatsByStakerPkHexes provides a mock function with given fields: ctx, stakerPkHexes
+func (_m *V1DBClient) FindStakerStatsByStakerPkHexes(ctx context.Context, stakerPkHexes []string) ([]*v1dbmodel.StakerStatsDocument, error) {
+	ret := _m.Called(ctx, stakerPkHexes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerStatsByStakerPkHexes")
+	}
+
+	var r0 []*v1dbmodel.StakerStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*v1dbmodel.StakerStatsDocument, error)); ok {
+		return rf(ctx, stakerPkHexes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*v1dbmodel.StakerStatsDocument); ok {
+		r0 = rf(ctx, stakerPkHexes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1dbmodel.StakerStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, stakerPkHexes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTopStakersByTvl provides a mock function with given fields: ctx, paginationToken
+func (_m *V1DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error) {
+	ret := _m.Called(ctx, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTopStakersByTvl")
+	}
+
+	var r0 *db.DbResultMap[*v1dbmodel.StakerStatsDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*v1dbmodel.StakerStatsDocument], error)); ok {
+		return rf(ctx, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*v1dbmodel.StakerStatsDocument]); ok {
+		r0 = rf(ctx, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[*v1dbmodel.StakerStatsDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnbondingByTxHashHex provides a mock function with given fields: ctx, unbondingTxHashHex
+func (_m *V1DBClient) FindUnbondingByTxHashHex(ctx context.Context, unbondingTxHashHex string) (*v1dbmodel.UnbondingDocument, error) {
+	ret := _m.Called(ctx, unbondingTxHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnbondingByTxHashHex")
+	}
+
+	var r0 *v1dbmodel.UnbondingDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.UnbondingDocument, error)); ok {
+		return rf(ctx, unbondingTxHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.UnbondingDocument); ok {
+		r0 = rf(ctx, unbondingTxHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.UnbondingDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, unbondingTxHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnbondingSignature provides a mock function with given fields: ctx, signatureHex
+func (_m *V1DBClient) FindUnbondingSignature(ctx context.Context, signatureHex string) (*v1dbmodel.UnbondingSignatureDocument, error) {
+	ret := _m.Called(ctx, signatureHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnbondingSignature")
+	}
+
+	var r0 *v1dbmodel.UnbondingSignatureDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.UnbondingSignatureDocument, error)); ok {
+		return rf(ctx, signatureHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.UnbondingSignatureDocument); ok {
+		r0 = rf(ctx, signatureHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.UnbondingSignatureDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, signatureHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnprocessableMessages provides a mock function with given fields: ctx
+func (_m *V1DBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnprocessableMessages")
+	}
+
+	var r0 []dbmodel.UnprocessableMessageDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.UnprocessableMessageDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.UnprocessableMessageDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.UnprocessableMessageDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestBtcInfo provides a mock function with given fields: ctx
+func (_m *V1DBClient) GetLatestBtcInfo(ctx context.Context) (*v1dbmodel.BtcInfo, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBtcInfo")
+	}
+
+	var r0 *v1dbmodel.BtcInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*v1dbmodel.BtcInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *v1dbmodel.BtcInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.BtcInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMaterializedOverallStats provides a mock function with given fields: ctx
+func (_m *V1DBClient) GetMaterializedOverallStats(ctx context.Context) (*v1dbmodel.MaterializedOverallStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMaterializedOverallStats")
+	}
+
+	var r0 *v1dbmodel.MaterializedOverallStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*v1dbmodel.MaterializedOverallStatsDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *v1dbmodel.MaterializedOverallStatsDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.MaterializedOverallStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrCreateStatsLock provides a mock function with given fields: ctx, stakingTxHashHex, state
+func (_m *V1DBClient) GetOrCreateStatsLock(ctx context.Context, stakingTxHashHex string, state string) (*v1dbmodel.StatsLockDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHex, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrCreateStatsLock")
+	}
+
+	var r0 *v1dbmodel.StatsLockDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v1dbmodel.StatsLockDocument, error)); ok {
+		return rf(ctx, stakingTxHashHex, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1dbmodel.StatsLockDocument); ok {
+		r0 = rf(ctx, stakingTxHashHex, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.StatsLockDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverallStats provides a mock function with given fields: ctx
+func (_m *V1DBClient) GetOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverallStats")
+	}
+
+	var r0 *v1dbmodel.OverallStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*v1dbmodel.OverallStatsDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *v1dbmodel.OverallStatsDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.OverallStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStakerStats provides a mock function with given fields: ctx, stakerPkHex
+func (_m *V1DBClient) GetStakerStats(ctx context.Context, stakerPkHex string) (*v1dbmodel.StakerStatsDocument, error) {
+	ret := _m.Called(ctx, stakerPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStakerStats")
+	}
+
+	var r0 *v1dbmodel.StakerStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.StakerStatsDocument, error)); ok {
+		return rf(ctx, stakerPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.StakerStatsDocument); ok {
+		r0 = rf(ctx, stakerPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.StakerStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementApiKeyUsage provides a mock function with given fields: ctx, apiKey, dayStart, requests, bytes
+func (_m *V1DBClient) IncrementApiKeyUsage(ctx context.Context, apiKey string, dayStart time.Time, requests int64, bytes int64) (*dbmodel.ApiKeyUsageDocument, error) {
+	ret := _m.Called(ctx, apiKey, dayStart, requests, bytes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementApiKeyUsage")
+	}
+
+	var r0 *dbmodel.ApiKeyUsageDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int64, int64) (*dbmodel.ApiKeyUsageDocument, error)); ok {
+		return rf(ctx, apiKey, dayStart, requests, bytes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int64, int64) *dbmodel.ApiKeyUsageDocument); ok {
+		r0 = rf(ctx, apiKey, dayStart, requests, bytes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dbmodel.ApiKeyUsageDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, int64, int64) error); ok {
+		r1 = rf(ctx, apiKey, dayStart, requests, bytes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementFinalityProviderStats provides a mock function with given fields: ctx, stakingTxHashHex, fpPkHex, amount
+func (_m *V1DBClient) IncrementFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, amount uint64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, fpPkHex, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementFinalityProviderStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, fpPkHex, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IncrementOverallStats provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, amount
+func (_m *V1DBClient) IncrementOverallStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementOverallStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IncrementOverflowStats provides a mock function with given fields: ctx, stakingTxHashHex, amount
+func (_m *V1DBClient) IncrementOverflowStats(ctx context.Context, stakingTxHashHex string, amount uint64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementOverflowStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IncrementStakerStats provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, amount
+func (_m *V1DBClient) IncrementStakerStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementStakerStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertErasureRecord provides a mock function with given fields: ctx, record
+func (_m *V1DBClient) InsertErasureRecord(ctx context.Context, record *dbmodel.ErasureRecordDocument) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertErasureRecord")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.ErasureRecordDocument) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertExportJob provides a mock function with given fields: ctx, job
+func (_m *V1DBClient) InsertExportJob(ctx context.Context, job *dbmodel.ExportJobDocument) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertExportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.ExportJobDocument) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertPkAddressMappings provides a mock function with given fields: ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven
+func (_m *V1DBClient) InsertPkAddressMappings(ctx context.Context, stakerPkHex string, taproot string, nativeSigwitOdd string, nativeSigwitEven string) error {
+	ret := _m.Called(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertPkAddressMappings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MigrateDelegations provides a mock function with given fields: ctx, limit
+func (_m *V1DBClient) MigrateDelegations(ctx context.Context, limit int64) (int64, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateDelegations")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *V1DBClient) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecomputeFinalityProviderStats provides a mock function with given fields: ctx
+func (_m *V1DBClient) RecomputeFinalityProviderStats(ctx context.Context) ([]*v1dbmodel.FinalityProviderStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecomputeFinalityProviderStats")
+	}
+
+	var r0 []*v1dbmodel.FinalityProviderStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*v1dbmodel.FinalityProviderStatsDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*v1dbmodel.FinalityProviderStatsDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1dbmodel.FinalityProviderStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecomputeOverallStats provides a mock function with given fields: ctx
+func (_m *V1DBClient) RecomputeOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecomputeOverallStats")
 	}
 
-	var r0 []dbmodel.UnprocessableMessageDocument
+	var r0 *v1dbmodel.OverallStatsDocument
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.UnprocessableMessageDocument, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (*v1dbmodel.OverallStatsDocument, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.UnprocessableMessageDocument); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) *v1dbmodel.OverallStatsDocument); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]dbmodel.UnprocessableMessageDocument)
+			r0 = ret.Get(0).(*v1dbmodel.OverallStatsDocument)
 		}
 	}
 
@@ -308,59 +1879,63 @@ func (_m *V1DBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.
 	return r0, r1
 }
 
-// GetLatestBtcInfo provides a mock function with given fields: ctx
-func (_m *V1DBClient) GetLatestBtcInfo(ctx context.Context) (*v1dbmodel.BtcInfo, error) {
+// RefreshMaterializedOverallStats provides a mock function with given fields: ctx
+func (_m *V1DBClient) RefreshMaterializedOverallStats(ctx context.Context) error {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLatestBtcInfo")
+		panic("no return value specified for RefreshMaterializedOverallStats")
 	}
 
-	var r0 *v1dbmodel.BtcInfo
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*v1dbmodel.BtcInfo, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) *v1dbmodel.BtcInfo); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
 		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1dbmodel.BtcInfo)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	return r0
+}
+
+// ReleaseLeaderLease provides a mock function with given fields: ctx, job, holder
+func (_m *V1DBClient) ReleaseLeaderLease(ctx context.Context, job string, holder string) error {
+	ret := _m.Called(ctx, job, holder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseLeaderLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, job, holder)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetOrCreateStatsLock provides a mock function with given fields: ctx, stakingTxHashHex, state
-func (_m *V1DBClient) GetOrCreateStatsLock(ctx context.Context, stakingTxHashHex string, state string) (*v1dbmodel.StatsLockDocument, error) {
-	ret := _m.Called(ctx, stakingTxHashHex, state)
+// RemoveStakerPartnerAttributions provides a mock function with given fields: ctx, stakerPkHex
+func (_m *V1DBClient) RemoveStakerPartnerAttributions(ctx context.Context, stakerPkHex string) (int64, error) {
+	ret := _m.Called(ctx, stakerPkHex)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOrCreateStatsLock")
+		panic("no return value specified for RemoveStakerPartnerAttributions")
 	}
 
-	var r0 *v1dbmodel.StatsLockDocument
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v1dbmodel.StatsLockDocument, error)); ok {
-		return rf(ctx, stakingTxHashHex, state)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, stakerPkHex)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1dbmodel.StatsLockDocument); ok {
-		r0 = rf(ctx, stakingTxHashHex, state)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, stakerPkHex)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1dbmodel.StatsLockDocument)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, stakingTxHashHex, state)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPkHex)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -368,29 +1943,63 @@ func (_m *V1DBClient) GetOrCreateStatsLock(ctx context.Context, stakingTxHashHex
 	return r0, r1
 }
 
-// GetOverallStats provides a mock function with given fields: ctx
-func (_m *V1DBClient) GetOverallStats(ctx context.Context) (*v1dbmodel.OverallStatsDocument, error) {
-	ret := _m.Called(ctx)
+// ReplaceFinalityProviderStats provides a mock function with given fields: ctx, stats
+func (_m *V1DBClient) ReplaceFinalityProviderStats(ctx context.Context, stats []*v1dbmodel.FinalityProviderStatsDocument) error {
+	ret := _m.Called(ctx, stats)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOverallStats")
+		panic("no return value specified for ReplaceFinalityProviderStats")
 	}
 
-	var r0 *v1dbmodel.OverallStatsDocument
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*v1dbmodel.FinalityProviderStatsDocument) error); ok {
+		r0 = rf(ctx, stats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplaceOverallStats provides a mock function with given fields: ctx, stats
+func (_m *V1DBClient) ReplaceOverallStats(ctx context.Context, stats *v1dbmodel.OverallStatsDocument) error {
+	ret := _m.Called(ctx, stats)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceOverallStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1dbmodel.OverallStatsDocument) error); ok {
+		r0 = rf(ctx, stats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenewExportJobClaim provides a mock function with given fields: ctx, id, startedAt, now
+func (_m *V1DBClient) RenewExportJobClaim(ctx context.Context, id string, startedAt time.Time, now time.Time) (bool, error) {
+	ret := _m.Called(ctx, id, startedAt, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewExportJobClaim")
+	}
+
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*v1dbmodel.OverallStatsDocument, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, id, startedAt, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *v1dbmodel.OverallStatsDocument); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, id, startedAt, now)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1dbmodel.OverallStatsDocument)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, id, startedAt, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -398,29 +2007,29 @@ func (_m *V1DBClient) GetOverallStats(ctx context.Context) (*v1dbmodel.OverallSt
 	return r0, r1
 }
 
-// GetStakerStats provides a mock function with given fields: ctx, stakerPkHex
-func (_m *V1DBClient) GetStakerStats(ctx context.Context, stakerPkHex string) (*v1dbmodel.StakerStatsDocument, error) {
-	ret := _m.Called(ctx, stakerPkHex)
+// ReserveIdempotencyKey provides a mock function with given fields: ctx, key, requestHash, lockTimeout
+func (_m *V1DBClient) ReserveIdempotencyKey(ctx context.Context, key string, requestHash string, lockTimeout time.Duration) (*dbmodel.IdempotencyKeyDocument, error) {
+	ret := _m.Called(ctx, key, requestHash, lockTimeout)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStakerStats")
+		panic("no return value specified for ReserveIdempotencyKey")
 	}
 
-	var r0 *v1dbmodel.StakerStatsDocument
+	var r0 *dbmodel.IdempotencyKeyDocument
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1dbmodel.StakerStatsDocument, error)); ok {
-		return rf(ctx, stakerPkHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (*dbmodel.IdempotencyKeyDocument, error)); ok {
+		return rf(ctx, key, requestHash, lockTimeout)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *v1dbmodel.StakerStatsDocument); ok {
-		r0 = rf(ctx, stakerPkHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) *dbmodel.IdempotencyKeyDocument); ok {
+		r0 = rf(ctx, key, requestHash, lockTimeout)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1dbmodel.StakerStatsDocument)
+			r0 = ret.Get(0).(*dbmodel.IdempotencyKeyDocument)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, stakerPkHex)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, requestHash, lockTimeout)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -428,35 +2037,45 @@ func (_m *V1DBClient) GetStakerStats(ctx context.Context, stakerPkHex string) (*
 	return r0, r1
 }
 
-// IncrementFinalityProviderStats provides a mock function with given fields: ctx, stakingTxHashHex, fpPkHex, amount
-func (_m *V1DBClient) IncrementFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, amount uint64) error {
-	ret := _m.Called(ctx, stakingTxHashHex, fpPkHex, amount)
+// RollbackReorgedDelegation provides a mock function with given fields: ctx, stakingTxHashHex
+func (_m *V1DBClient) RollbackReorgedDelegation(ctx context.Context, stakingTxHashHex string) (types.DelegationState, error) {
+	ret := _m.Called(ctx, stakingTxHashHex)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncrementFinalityProviderStats")
+		panic("no return value specified for RollbackReorgedDelegation")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, fpPkHex, amount)
+	var r0 types.DelegationState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (types.DelegationState, error)); ok {
+		return rf(ctx, stakingTxHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) types.DelegationState); ok {
+		r0 = rf(ctx, stakingTxHashHex)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(types.DelegationState)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// IncrementOverallStats provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, amount
-func (_m *V1DBClient) IncrementOverallStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
-	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, amount)
+// SaveActiveStakingDelegation provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, state
+func (_m *V1DBClient) SaveActiveStakingDelegation(ctx context.Context, stakingTxHashHex string, stakerPkHex string, fpPkHex string, stakingTxHex string, amount uint64, startHeight uint64, timelock uint64, outputIndex uint64, startTimestamp int64, isOverflow bool, state types.DelegationState) error {
+	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, state)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncrementOverallStats")
+		panic("no return value specified for SaveActiveStakingDelegation")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, uint64, uint64, uint64, uint64, int64, bool, types.DelegationState) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, state)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -464,17 +2083,17 @@ func (_m *V1DBClient) IncrementOverallStats(ctx context.Context, stakingTxHashHe
 	return r0
 }
 
-// IncrementStakerStats provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, amount
-func (_m *V1DBClient) IncrementStakerStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, amount uint64) error {
-	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, amount)
+// SaveBtcReorg provides a mock function with given fields: ctx, blockHash, blockHeight, stakingTxHashHexes
+func (_m *V1DBClient) SaveBtcReorg(ctx context.Context, blockHash string, blockHeight uint64, stakingTxHashHexes []string) error {
+	ret := _m.Called(ctx, blockHash, blockHeight, stakingTxHashHexes)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncrementStakerStats")
+		panic("no return value specified for SaveBtcReorg")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, []string) error); ok {
+		r0 = rf(ctx, blockHash, blockHeight, stakingTxHashHexes)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -482,17 +2101,17 @@ func (_m *V1DBClient) IncrementStakerStats(ctx context.Context, stakingTxHashHex
 	return r0
 }
 
-// InsertPkAddressMappings provides a mock function with given fields: ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven
-func (_m *V1DBClient) InsertPkAddressMappings(ctx context.Context, stakerPkHex string, taproot string, nativeSigwitOdd string, nativeSigwitEven string) error {
-	ret := _m.Called(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
+// SaveDelegationMilestone provides a mock function with given fields: ctx, stakingTxHashHex, milestone, txHashHex, blockHeight, timestamp
+func (_m *V1DBClient) SaveDelegationMilestone(ctx context.Context, stakingTxHashHex string, milestone v1dbmodel.DelegationMilestone, txHashHex string, blockHeight uint64, timestamp int64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, milestone, txHashHex, blockHeight, timestamp)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertPkAddressMappings")
+		panic("no return value specified for SaveDelegationMilestone")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = rf(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
+	if rf, ok := ret.Get(0).(func(context.Context, string, v1dbmodel.DelegationMilestone, string, uint64, int64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, milestone, txHashHex, blockHeight, timestamp)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -500,17 +2119,17 @@ func (_m *V1DBClient) InsertPkAddressMappings(ctx context.Context, stakerPkHex s
 	return r0
 }
 
-// Ping provides a mock function with given fields: ctx
-func (_m *V1DBClient) Ping(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// SaveEvent provides a mock function with given fields: ctx, event
+func (_m *V1DBClient) SaveEvent(ctx context.Context, event *dbmodel.EventDocument) error {
+	ret := _m.Called(ctx, event)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Ping")
+		panic("no return value specified for SaveEvent")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.EventDocument) error); ok {
+		r0 = rf(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -518,17 +2137,17 @@ func (_m *V1DBClient) Ping(ctx context.Context) error {
 	return r0
 }
 
-// SaveActiveStakingDelegation provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow
-func (_m *V1DBClient) SaveActiveStakingDelegation(ctx context.Context, stakingTxHashHex string, stakerPkHex string, fpPkHex string, stakingTxHex string, amount uint64, startHeight uint64, timelock uint64, outputIndex uint64, startTimestamp int64, isOverflow bool) error {
-	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow)
+// SaveFpCommissionChange provides a mock function with given fields: ctx, fpPkHex, sequence, commission, previousCommission
+func (_m *V1DBClient) SaveFpCommissionChange(ctx context.Context, fpPkHex string, sequence int64, commission string, previousCommission string) error {
+	ret := _m.Called(ctx, fpPkHex, sequence, commission, previousCommission)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveActiveStakingDelegation")
+		panic("no return value specified for SaveFpCommissionChange")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, uint64, uint64, uint64, uint64, int64, bool) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, string) error); ok {
+		r0 = rf(ctx, fpPkHex, sequence, commission, previousCommission)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -554,17 +2173,17 @@ func (_m *V1DBClient) SaveTimeLockExpireCheck(ctx context.Context, stakingTxHash
 	return r0
 }
 
-// SaveUnbondingTx provides a mock function with given fields: ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex
-func (_m *V1DBClient) SaveUnbondingTx(ctx context.Context, stakingTxHashHex string, unbondingTxHashHex string, txHex string, signatureHex string) error {
-	ret := _m.Called(ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex)
+// SaveUnbondingTx provides a mock function with given fields: ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex, psbtBase64
+func (_m *V1DBClient) SaveUnbondingTx(ctx context.Context, stakingTxHashHex string, unbondingTxHashHex string, txHex string, signatureHex string, psbtBase64 string) error {
+	ret := _m.Called(ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex, psbtBase64)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveUnbondingTx")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex, psbtBase64)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -572,17 +2191,17 @@ func (_m *V1DBClient) SaveUnbondingTx(ctx context.Context, stakingTxHashHex stri
 	return r0
 }
 
-// SaveUnprocessableMessage provides a mock function with given fields: ctx, messageBody, receipt
-func (_m *V1DBClient) SaveUnprocessableMessage(ctx context.Context, messageBody string, receipt string) error {
-	ret := _m.Called(ctx, messageBody, receipt)
+// SaveUnprocessableMessage provides a mock function with given fields: ctx, messageBody, receipt, reason
+func (_m *V1DBClient) SaveUnprocessableMessage(ctx context.Context, messageBody string, receipt string, reason string) error {
+	ret := _m.Called(ctx, messageBody, receipt, reason)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveUnprocessableMessage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, messageBody, receipt)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, messageBody, receipt, reason)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -620,6 +2239,96 @@ func (_m *V1DBClient) ScanDelegationsPaginated(ctx context.Context, paginationTo
 	return r0, r1
 }
 
+// SetDelegationLabels provides a mock function with given fields: ctx, apiKey, stakingTxHashHex, labels
+func (_m *V1DBClient) SetDelegationLabels(ctx context.Context, apiKey string, stakingTxHashHex string, labels []string) error {
+	ret := _m.Called(ctx, apiKey, stakingTxHashHex, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDelegationLabels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, apiKey, stakingTxHashHex, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDelegationPartner provides a mock function with given fields: ctx, stakingTxHashHex, partnerId
+func (_m *V1DBClient) SetDelegationPartner(ctx context.Context, stakingTxHashHex string, partnerId string) error {
+	ret := _m.Called(ctx, stakingTxHashHex, partnerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDelegationPartner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, partnerId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartScheduledJobRun provides a mock function with given fields: ctx, name, holder, trigger, startedAt
+func (_m *V1DBClient) StartScheduledJobRun(ctx context.Context, name string, holder string, trigger dbmodel.ScheduledJobTrigger, startedAt time.Time) error {
+	ret := _m.Called(ctx, name, holder, trigger, startedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartScheduledJobRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, dbmodel.ScheduledJobTrigger, time.Time) error); ok {
+		r0 = rf(ctx, name, holder, trigger, startedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StreamDelegationsByFinalityProviderPk provides a mock function with given fields: ctx, fpPkHex, fn
+func (_m *V1DBClient) StreamDelegationsByFinalityProviderPk(ctx context.Context, fpPkHex string, fn func(*v1dbmodel.DelegationDocument) error) error {
+	ret := _m.Called(ctx, fpPkHex, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamDelegationsByFinalityProviderPk")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*v1dbmodel.DelegationDocument) error) error); ok {
+		r0 = rf(ctx, fpPkHex, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StreamDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, extraFilter, paginationToken, fn
+func (_m *V1DBClient) StreamDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *v1dbclient.DelegationFilter, paginationToken string, fn func(*v1dbmodel.DelegationDocument) error) error {
+	ret := _m.Called(ctx, stakerPk, extraFilter, paginationToken, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamDelegationsByStakerPk")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbclient.DelegationFilter, string, func(*v1dbmodel.DelegationDocument) error) error); ok {
+		r0 = rf(ctx, stakerPk, extraFilter, paginationToken, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SubtractFinalityProviderStats provides a mock function with given fields: ctx, stakingTxHashHex, fpPkHex, amount
 func (_m *V1DBClient) SubtractFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, amount uint64) error {
 	ret := _m.Called(ctx, stakingTxHashHex, fpPkHex, amount)
@@ -674,6 +2383,36 @@ func (_m *V1DBClient) SubtractStakerStats(ctx context.Context, stakingTxHashHex
 	return r0
 }
 
+// TransitionPendingToActiveState provides a mock function with given fields: ctx, fromStartHeight, toStartHeight
+func (_m *V1DBClient) TransitionPendingToActiveState(ctx context.Context, fromStartHeight uint64, toStartHeight uint64) ([]string, error) {
+	ret := _m.Called(ctx, fromStartHeight, toStartHeight)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionPendingToActiveState")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]string, error)); ok {
+		return rf(ctx, fromStartHeight, toStartHeight)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []string); ok {
+		r0 = rf(ctx, fromStartHeight, toStartHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromStartHeight, toStartHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TransitionToUnbondedState provides a mock function with given fields: ctx, stakingTxHashHex, eligiblePreviousState
 func (_m *V1DBClient) TransitionToUnbondedState(ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState) error {
 	ret := _m.Called(ctx, stakingTxHashHex, eligiblePreviousState)
@@ -692,17 +2431,17 @@ func (_m *V1DBClient) TransitionToUnbondedState(ctx context.Context, stakingTxHa
 	return r0
 }
 
-// TransitionToUnbondingState provides a mock function with given fields: ctx, txHashHex, startHeight, timelock, outputIndex, txHex, startTimestamp
-func (_m *V1DBClient) TransitionToUnbondingState(ctx context.Context, txHashHex string, startHeight uint64, timelock uint64, outputIndex uint64, txHex string, startTimestamp int64) error {
-	ret := _m.Called(ctx, txHashHex, startHeight, timelock, outputIndex, txHex, startTimestamp)
+// TransitionToUnbondingState provides a mock function with given fields: ctx, txHashHex, startHeight, timelock, outputIndex, txHex, unbondingTxHashHex, startTimestamp
+func (_m *V1DBClient) TransitionToUnbondingState(ctx context.Context, txHashHex string, startHeight uint64, timelock uint64, outputIndex uint64, txHex string, unbondingTxHashHex string, startTimestamp int64) error {
+	ret := _m.Called(ctx, txHashHex, startHeight, timelock, outputIndex, txHex, unbondingTxHashHex, startTimestamp)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TransitionToUnbondingState")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64, uint64, string, int64) error); ok {
-		r0 = rf(ctx, txHashHex, startHeight, timelock, outputIndex, txHex, startTimestamp)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64, uint64, string, string, int64) error); ok {
+		r0 = rf(ctx, txHashHex, startHeight, timelock, outputIndex, txHex, unbondingTxHashHex, startTimestamp)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -710,17 +2449,17 @@ func (_m *V1DBClient) TransitionToUnbondingState(ctx context.Context, txHashHex
 	return r0
 }
 
-// TransitionToWithdrawnState provides a mock function with given fields: ctx, txHashHex
-func (_m *V1DBClient) TransitionToWithdrawnState(ctx context.Context, txHashHex string) error {
-	ret := _m.Called(ctx, txHashHex)
+// TransitionToWithdrawnState provides a mock function with given fields: ctx, txHashHex, withdrawalTx
+func (_m *V1DBClient) TransitionToWithdrawnState(ctx context.Context, txHashHex string, withdrawalTx *v1dbmodel.WithdrawalTransaction) error {
+	ret := _m.Called(ctx, txHashHex, withdrawalTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TransitionToWithdrawnState")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, txHashHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1dbmodel.WithdrawalTransaction) error); ok {
+		r0 = rf(ctx, txHashHex, withdrawalTx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -728,17 +2467,17 @@ func (_m *V1DBClient) TransitionToWithdrawnState(ctx context.Context, txHashHex
 	return r0
 }
 
-// UpsertLatestBtcInfo provides a mock function with given fields: ctx, height, confirmedTvl, unconfirmedTvl
-func (_m *V1DBClient) UpsertLatestBtcInfo(ctx context.Context, height uint64, confirmedTvl uint64, unconfirmedTvl uint64) error {
-	ret := _m.Called(ctx, height, confirmedTvl, unconfirmedTvl)
+// UpsertLatestBtcInfo provides a mock function with given fields: ctx, height, babylonHeight, confirmedTvl, unconfirmedTvl
+func (_m *V1DBClient) UpsertLatestBtcInfo(ctx context.Context, height uint64, babylonHeight uint64, confirmedTvl uint64, unconfirmedTvl uint64) error {
+	ret := _m.Called(ctx, height, babylonHeight, confirmedTvl, unconfirmedTvl)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpsertLatestBtcInfo")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64) error); ok {
-		r0 = rf(ctx, height, confirmedTvl, unconfirmedTvl)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64, uint64) error); ok {
+		r0 = rf(ctx, height, babylonHeight, confirmedTvl, unconfirmedTvl)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -746,6 +2485,36 @@ func (_m *V1DBClient) UpsertLatestBtcInfo(ctx context.Context, height uint64, co
 	return r0
 }
 
+// WaitForDelegationState provides a mock function with given fields: ctx, stakingTxHashHex, states
+func (_m *V1DBClient) WaitForDelegationState(ctx context.Context, stakingTxHashHex string, states []types.DelegationState) (*v1dbmodel.DelegationDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHex, states)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForDelegationState")
+	}
+
+	var r0 *v1dbmodel.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []types.DelegationState) (*v1dbmodel.DelegationDocument, error)); ok {
+		return rf(ctx, stakingTxHashHex, states)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []types.DelegationState) *v1dbmodel.DelegationDocument); ok {
+		r0 = rf(ctx, stakingTxHashHex, states)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1dbmodel.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []types.DelegationState) error); ok {
+		r1 = rf(ctx, stakingTxHashHex, states)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewV1DBClient creates a new instance of V1DBClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewV1DBClient(t interface {
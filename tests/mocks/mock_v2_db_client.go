@@ -7,6 +7,10 @@ import (
 
 	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
 )
 
 // V2DBClient is an autogenerated mock type for the V2DBClient type
@@ -14,6 +18,192 @@ type V2DBClient struct {
 	mock.Mock
 }
 
+// AcquireLeaderLease provides a mock function with given fields: ctx, job, holder, now, expiresAt
+func (_m *V2DBClient) AcquireLeaderLease(ctx context.Context, job string, holder string, now time.Time, expiresAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, job, holder, now, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireLeaderLease")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, job, holder, now, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, job, holder, now, expiresAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, job, holder, now, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimExportJob provides a mock function with given fields: ctx, now, staleBefore
+func (_m *V2DBClient) ClaimExportJob(ctx context.Context, now time.Time, staleBefore time.Time) (*dbmodel.ExportJobDocument, error) {
+	ret := _m.Called(ctx, now, staleBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimExportJob")
+	}
+
+	var r0 *dbmodel.ExportJobDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (*dbmodel.ExportJobDocument, error)); ok {
+		return rf(ctx, now, staleBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) *dbmodel.ExportJobDocument); ok {
+		r0 = rf(ctx, now, staleBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dbmodel.ExportJobDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, now, staleBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteExportJob provides a mock function with given fields: ctx, id, startedAt, objectKey, completedAt
+func (_m *V2DBClient) CompleteExportJob(ctx context.Context, id string, startedAt time.Time, objectKey string, completedAt time.Time) error {
+	ret := _m.Called(ctx, id, startedAt, objectKey, completedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteExportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string, time.Time) error); ok {
+		r0 = rf(ctx, id, startedAt, objectKey, completedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteIdempotencyKey provides a mock function with given fields: ctx, key, statusCode, contentType, body
+func (_m *V2DBClient) CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	ret := _m.Called(ctx, key, statusCode, contentType, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, []byte) error); ok {
+		r0 = rf(ctx, key, statusCode, contentType, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteScheduledJobRun provides a mock function with given fields: ctx, name, runErr, finishedAt, duration
+func (_m *V2DBClient) CompleteScheduledJobRun(ctx context.Context, name string, runErr error, finishedAt time.Time, duration time.Duration) error {
+	ret := _m.Called(ctx, name, runErr, finishedAt, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteScheduledJobRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, error, time.Time, time.Duration) error); ok {
+		r0 = rf(ctx, name, runErr, finishedAt, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountPkAddressMappings provides a mock function with given fields: ctx, pkHex
+func (_m *V2DBClient) CountPkAddressMappings(ctx context.Context, pkHex string) (int64, error) {
+	ret := _m.Called(ctx, pkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPkAddressMappings")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, pkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, pkHex)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *V2DBClient) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePkAddressMappings provides a mock function with given fields: ctx, pkHex
+func (_m *V2DBClient) DeletePkAddressMappings(ctx context.Context, pkHex string) (int64, error) {
+	ret := _m.Called(ctx, pkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePkAddressMappings")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, pkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, pkHex)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteUnprocessableMessage provides a mock function with given fields: ctx, Receipt
 func (_m *V2DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
 	ret := _m.Called(ctx, Receipt)
@@ -32,6 +222,172 @@ func (_m *V2DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt in
 	return r0
 }
 
+// Exists provides a mock function with given fields: ctx, collection, filter
+func (_m *V2DBClient) Exists(ctx context.Context, collection string, filter interface{}) (bool, error) {
+	ret := _m.Called(ctx, collection, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) (bool, error)); ok {
+		return rf(ctx, collection, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) bool); ok {
+		r0 = rf(ctx, collection, filter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, collection, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FailExportJob provides a mock function with given fields: ctx, id, startedAt, reason, completedAt
+func (_m *V2DBClient) FailExportJob(ctx context.Context, id string, startedAt time.Time, reason string, completedAt time.Time) error {
+	ret := _m.Called(ctx, id, startedAt, reason, completedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailExportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string, time.Time) error); ok {
+		r0 = rf(ctx, id, startedAt, reason, completedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindApiKeyUsage provides a mock function with given fields: ctx, apiKey, fromDayStart
+func (_m *V2DBClient) FindApiKeyUsage(ctx context.Context, apiKey string, fromDayStart time.Time) ([]dbmodel.ApiKeyUsageDocument, error) {
+	ret := _m.Called(ctx, apiKey, fromDayStart)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindApiKeyUsage")
+	}
+
+	var r0 []dbmodel.ApiKeyUsageDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]dbmodel.ApiKeyUsageDocument, error)); ok {
+		return rf(ctx, apiKey, fromDayStart)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []dbmodel.ApiKeyUsageDocument); ok {
+		r0 = rf(ctx, apiKey, fromDayStart)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.ApiKeyUsageDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, apiKey, fromDayStart)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindCovenantSignatures provides a mock function with given fields: ctx, stakingTxHashHexes
+func (_m *V2DBClient) FindCovenantSignatures(ctx context.Context, stakingTxHashHexes []string) ([]*v2dbmodel.V2CovenantSignaturesDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHexes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCovenantSignatures")
+	}
+
+	var r0 []*v2dbmodel.V2CovenantSignaturesDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*v2dbmodel.V2CovenantSignaturesDocument, error)); ok {
+		return rf(ctx, stakingTxHashHexes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*v2dbmodel.V2CovenantSignaturesDocument); ok {
+		r0 = rf(ctx, stakingTxHashHexes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v2dbmodel.V2CovenantSignaturesDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, stakingTxHashHexes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindExportJob provides a mock function with given fields: ctx, id
+func (_m *V2DBClient) FindExportJob(ctx context.Context, id string) (*dbmodel.ExportJobDocument, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExportJob")
+	}
+
+	var r0 *dbmodel.ExportJobDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*dbmodel.ExportJobDocument, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *dbmodel.ExportJobDocument); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dbmodel.ExportJobDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindIndexStats provides a mock function with given fields: ctx
+func (_m *V2DBClient) FindIndexStats(ctx context.Context) ([]dbmodel.IndexStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIndexStats")
+	}
+
+	var r0 []dbmodel.IndexStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.IndexStatsDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.IndexStatsDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.IndexStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindPkMappingsByNativeSegwitAddress provides a mock function with given fields: ctx, nativeSegwitAddresses
 func (_m *V2DBClient) FindPkMappingsByNativeSegwitAddress(ctx context.Context, nativeSegwitAddresses []string) ([]*dbmodel.PkAddressMapping, error) {
 	ret := _m.Called(ctx, nativeSegwitAddresses)
@@ -92,6 +448,36 @@ func (_m *V2DBClient) FindPkMappingsByTaprootAddress(ctx context.Context, taproo
 	return r0, r1
 }
 
+// FindScheduledJobRuns provides a mock function with given fields: ctx
+func (_m *V2DBClient) FindScheduledJobRuns(ctx context.Context) ([]dbmodel.ScheduledJobDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindScheduledJobRuns")
+	}
+
+	var r0 []dbmodel.ScheduledJobDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]dbmodel.ScheduledJobDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []dbmodel.ScheduledJobDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbmodel.ScheduledJobDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindUnprocessableMessages provides a mock function with given fields: ctx
 func (_m *V2DBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.UnprocessableMessageDocument, error) {
 	ret := _m.Called(ctx)
@@ -122,6 +508,210 @@ func (_m *V2DBClient) FindUnprocessableMessages(ctx context.Context) ([]dbmodel.
 	return r0, r1
 }
 
+// GetFinalityProvidersStats provides a mock function with given fields: ctx, fpPkHexes
+func (_m *V2DBClient) GetFinalityProvidersStats(ctx context.Context, fpPkHexes []string) ([]*v2dbmodel.V2FinalityProviderStatsDocument, error) {
+	ret := _m.Called(ctx, fpPkHexes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFinalityProvidersStats")
+	}
+
+	var r0 []*v2dbmodel.V2FinalityProviderStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*v2dbmodel.V2FinalityProviderStatsDocument, error)); ok {
+		return rf(ctx, fpPkHexes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*v2dbmodel.V2FinalityProviderStatsDocument); ok {
+		r0 = rf(ctx, fpPkHexes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v2dbmodel.V2FinalityProviderStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, fpPkHexes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMaterializedOverallStats provides a mock function with given fields: ctx
+func (_m *V2DBClient) GetMaterializedOverallStats(ctx context.Context) (*v2dbmodel.V2MaterializedOverallStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMaterializedOverallStats")
+	}
+
+	var r0 *v2dbmodel.V2MaterializedOverallStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*v2dbmodel.V2MaterializedOverallStatsDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *v2dbmodel.V2MaterializedOverallStatsDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v2dbmodel.V2MaterializedOverallStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverallStats provides a mock function with given fields: ctx
+func (_m *V2DBClient) GetOverallStats(ctx context.Context) (*v2dbmodel.V2OverallStatsDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverallStats")
+	}
+
+	var r0 *v2dbmodel.V2OverallStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*v2dbmodel.V2OverallStatsDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *v2dbmodel.V2OverallStatsDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v2dbmodel.V2OverallStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStakerStats provides a mock function with given fields: ctx, stakerPKHex
+func (_m *V2DBClient) GetStakerStats(ctx context.Context, stakerPKHex string) (*v2dbmodel.V2StakerStatsDocument, error) {
+	ret := _m.Called(ctx, stakerPKHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStakerStats")
+	}
+
+	var r0 *v2dbmodel.V2StakerStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v2dbmodel.V2StakerStatsDocument, error)); ok {
+		return rf(ctx, stakerPKHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v2dbmodel.V2StakerStatsDocument); ok {
+		r0 = rf(ctx, stakerPKHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v2dbmodel.V2StakerStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPKHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementApiKeyUsage provides a mock function with given fields: ctx, apiKey, dayStart, requests, bytes
+func (_m *V2DBClient) IncrementApiKeyUsage(ctx context.Context, apiKey string, dayStart time.Time, requests int64, bytes int64) (*dbmodel.ApiKeyUsageDocument, error) {
+	ret := _m.Called(ctx, apiKey, dayStart, requests, bytes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementApiKeyUsage")
+	}
+
+	var r0 *dbmodel.ApiKeyUsageDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int64, int64) (*dbmodel.ApiKeyUsageDocument, error)); ok {
+		return rf(ctx, apiKey, dayStart, requests, bytes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int64, int64) *dbmodel.ApiKeyUsageDocument); ok {
+		r0 = rf(ctx, apiKey, dayStart, requests, bytes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dbmodel.ApiKeyUsageDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, int64, int64) error); ok {
+		r1 = rf(ctx, apiKey, dayStart, requests, bytes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementSlashedStats provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, fpPkHexes, slashedAmount
+func (_m *V2DBClient) IncrementSlashedStats(ctx context.Context, stakingTxHashHex string, stakerPkHex string, fpPkHexes []string, slashedAmount uint64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, fpPkHexes, slashedAmount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementSlashedStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, uint64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, fpPkHexes, slashedAmount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertErasureRecord provides a mock function with given fields: ctx, record
+func (_m *V2DBClient) InsertErasureRecord(ctx context.Context, record *dbmodel.ErasureRecordDocument) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertErasureRecord")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.ErasureRecordDocument) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertExportJob provides a mock function with given fields: ctx, job
+func (_m *V2DBClient) InsertExportJob(ctx context.Context, job *dbmodel.ExportJobDocument) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertExportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.ExportJobDocument) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertPkAddressMappings provides a mock function with given fields: ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven
 func (_m *V2DBClient) InsertPkAddressMappings(ctx context.Context, stakerPkHex string, taproot string, nativeSigwitOdd string, nativeSigwitEven string) error {
 	ret := _m.Called(ctx, stakerPkHex, taproot, nativeSigwitOdd, nativeSigwitEven)
@@ -158,17 +748,165 @@ func (_m *V2DBClient) Ping(ctx context.Context) error {
 	return r0
 }
 
-// SaveUnprocessableMessage provides a mock function with given fields: ctx, messageBody, receipt
-func (_m *V2DBClient) SaveUnprocessableMessage(ctx context.Context, messageBody string, receipt string) error {
-	ret := _m.Called(ctx, messageBody, receipt)
+// RefreshMaterializedOverallStats provides a mock function with given fields: ctx
+func (_m *V2DBClient) RefreshMaterializedOverallStats(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveUnprocessableMessage")
+		panic("no return value specified for RefreshMaterializedOverallStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseLeaderLease provides a mock function with given fields: ctx, job, holder
+func (_m *V2DBClient) ReleaseLeaderLease(ctx context.Context, job string, holder string) error {
+	ret := _m.Called(ctx, job, holder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseLeaderLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, job, holder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenewExportJobClaim provides a mock function with given fields: ctx, id, startedAt, now
+func (_m *V2DBClient) RenewExportJobClaim(ctx context.Context, id string, startedAt time.Time, now time.Time) (bool, error) {
+	ret := _m.Called(ctx, id, startedAt, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewExportJobClaim")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, id, startedAt, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, id, startedAt, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, id, startedAt, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReserveIdempotencyKey provides a mock function with given fields: ctx, key, requestHash, lockTimeout
+func (_m *V2DBClient) ReserveIdempotencyKey(ctx context.Context, key string, requestHash string, lockTimeout time.Duration) (*dbmodel.IdempotencyKeyDocument, error) {
+	ret := _m.Called(ctx, key, requestHash, lockTimeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveIdempotencyKey")
+	}
+
+	var r0 *dbmodel.IdempotencyKeyDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (*dbmodel.IdempotencyKeyDocument, error)); ok {
+		return rf(ctx, key, requestHash, lockTimeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) *dbmodel.IdempotencyKeyDocument); ok {
+		r0 = rf(ctx, key, requestHash, lockTimeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dbmodel.IdempotencyKeyDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, requestHash, lockTimeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveCovenantSignature provides a mock function with given fields: ctx, stakingTxHashHex, covenantBtcPkHex
+func (_m *V2DBClient) SaveCovenantSignature(ctx context.Context, stakingTxHashHex string, covenantBtcPkHex string) error {
+	ret := _m.Called(ctx, stakingTxHashHex, covenantBtcPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCovenantSignature")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, messageBody, receipt)
+		r0 = rf(ctx, stakingTxHashHex, covenantBtcPkHex)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveEvent provides a mock function with given fields: ctx, event
+func (_m *V2DBClient) SaveEvent(ctx context.Context, event *dbmodel.EventDocument) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dbmodel.EventDocument) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveUnprocessableMessage provides a mock function with given fields: ctx, messageBody, receipt, reason
+func (_m *V2DBClient) SaveUnprocessableMessage(ctx context.Context, messageBody string, receipt string, reason string) error {
+	ret := _m.Called(ctx, messageBody, receipt, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUnprocessableMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, messageBody, receipt, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartScheduledJobRun provides a mock function with given fields: ctx, name, holder, trigger, startedAt
+func (_m *V2DBClient) StartScheduledJobRun(ctx context.Context, name string, holder string, trigger dbmodel.ScheduledJobTrigger, startedAt time.Time) error {
+	ret := _m.Called(ctx, name, holder, trigger, startedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartScheduledJobRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, dbmodel.ScheduledJobTrigger, time.Time) error); ok {
+		r0 = rf(ctx, name, holder, trigger, startedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
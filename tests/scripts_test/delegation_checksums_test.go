@@ -0,0 +1,36 @@
+package scripts_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/cmd/staking-api-service/scripts"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDelegationChecksums(t *testing.T) {
+	cfg := testutils.LoadTestConfig()
+	ctx := context.Background()
+	// Clean the database
+	testutils.SetupTestDB(*cfg)
+	docs := createNewDelegationDocuments(cfg, 3)
+	for _, doc := range docs {
+		doc.Checksum = doc.ComputeChecksum()
+	}
+	// The staking value of the first delegation has been mutated after insert
+	docs[0].StakingValue++
+	// The last delegation was inserted before the checksum was introduced
+	docs[2].Checksum = ""
+	for _, doc := range docs {
+		testutils.InjectDbDocument(cfg, dbmodel.V1DelegationCollection, doc)
+	}
+
+	report, err := scripts.CheckDelegationChecksums(ctx, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Verified)
+	assert.Equal(t, 1, report.Unverified)
+	assert.Equal(t, []string{docs[0].StakingTxHashHex}, report.Mismatches)
+}
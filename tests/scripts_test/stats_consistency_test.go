@@ -0,0 +1,105 @@
+package scripts_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/cmd/staking-api-service/scripts"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAndFixStatsConsistency(t *testing.T) {
+	cfg := testutils.LoadTestConfig()
+	ctx := context.Background()
+	// Clean the database
+	testutils.SetupTestDB(*cfg)
+	docs := createNewDelegationDocuments(cfg, 5)
+	// The first delegation has been unbonded through an unbonding tx
+	docs[0].UnbondingTx = &v1model.TimelockTransaction{TxHex: "unbonding"}
+	// The last delegation exceeded the staking cap, it's only counted in the
+	// overflow stats
+	docs[4].IsOverflow = true
+	stakers := make(map[string]struct{})
+	var activeTvl, totalTvl int64
+	for _, doc := range docs {
+		testutils.InjectDbDocument(cfg, dbmodel.V1DelegationCollection, doc)
+		if doc.IsOverflow {
+			continue
+		}
+		stakers[doc.StakerPkHex] = struct{}{}
+		totalTvl += int64(doc.StakingValue)
+		if doc.UnbondingTx == nil {
+			activeTvl += int64(doc.StakingValue)
+		}
+	}
+	// Skewed stats, as if an event had been counted twice
+	testutils.InjectDbDocument(cfg, dbmodel.V1OverallStatsCollection, &v1model.OverallStatsDocument{
+		Id:                "1",
+		ActiveTvl:         activeTvl + 100,
+		TotalTvl:          totalTvl + 100,
+		ActiveDelegations: 5,
+		TotalDelegations:  6,
+		TotalStakers:      uint64(len(stakers)),
+	})
+	// Stats of a finality provider without any delegation
+	testutils.InjectDbDocument(cfg, dbmodel.V1FinalityProviderStatsCollection, &v1model.FinalityProviderStatsDocument{
+		FinalityProviderPkHex: "unknown",
+		ActiveTvl:             100,
+		TotalTvl:              100,
+		ActiveDelegations:     1,
+		TotalDelegations:      1,
+	})
+	time.Sleep(2 * time.Second)
+
+	drifts, err := scripts.CheckStatsConsistency(ctx, cfg, false, false)
+	require.NoError(t, err)
+	assert.Contains(t, drifts, scripts.StatsDrift{
+		Key: "overall", Field: "active_tvl", Expected: activeTvl, Actual: activeTvl + 100,
+	})
+	assert.Contains(t, drifts, scripts.StatsDrift{
+		Key: "overall", Field: "active_delegations", Expected: 3, Actual: 5,
+	})
+	assert.Contains(t, drifts, scripts.StatsDrift{
+		Key: "overall", Field: "total_delegations", Expected: 4, Actual: 6,
+	})
+	assert.Contains(t, drifts, scripts.StatsDrift{
+		Key: "unknown", Field: "active_tvl", Expected: 0, Actual: 100,
+	})
+
+	// Checking does not change the stats
+	drifts2, err := scripts.CheckStatsConsistency(ctx, cfg, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, drifts, drifts2)
+
+	// Fix the stats, no drift is left afterwards
+	_, err = scripts.CheckStatsConsistency(ctx, cfg, true, false)
+	require.NoError(t, err)
+	drifts, err = scripts.CheckStatsConsistency(ctx, cfg, false, false)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+
+	// The overflow stats are only checked, and backfilled, when included
+	drifts, err = scripts.CheckStatsConsistency(ctx, cfg, false, true)
+	require.NoError(t, err)
+	assert.Contains(t, drifts, scripts.StatsDrift{
+		Key: "overall", Field: "overflow_tvl", Expected: int64(docs[4].StakingValue), Actual: 0,
+	})
+	_, err = scripts.CheckStatsConsistency(ctx, cfg, true, true)
+	require.NoError(t, err)
+	drifts, err = scripts.CheckStatsConsistency(ctx, cfg, false, true)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+
+	fpStats, err := testutils.InspectDbDocuments[v1model.FinalityProviderStatsDocument](
+		cfg, dbmodel.V1FinalityProviderStatsCollection,
+	)
+	require.NoError(t, err)
+	for _, stats := range fpStats {
+		assert.NotEqual(t, "unknown", stats.FinalityProviderPkHex)
+	}
+}
@@ -19,12 +19,13 @@ import (
 var setUpDbIndex = false
 
 func DirectDbConnection(cfg *config.Config) (*dbclients.DbClients, string) {
-	stakingMongoClient, err := mongo.Connect(
+	stakingClient, err := mongo.Connect(
 		context.TODO(), options.Client().ApplyURI(cfg.StakingDb.Address),
 	)
 	if err != nil {
 		log.Fatal(err)
 	}
+	stakingMongoClient := dbclient.WrapMongoClient(stakingClient)
 	dbClient, err := dbclient.New(context.TODO(), stakingMongoClient, cfg.StakingDb)
 	if err != nil {
 		log.Fatal(err)
@@ -39,12 +40,13 @@ func DirectDbConnection(cfg *config.Config) (*dbclients.DbClients, string) {
 	}
 
 	// IndexerDBClient
-	indexerMongoClient, err := mongo.Connect(
+	indexerClient, err := mongo.Connect(
 		context.TODO(), options.Client().ApplyURI(cfg.IndexerDb.Address),
 	)
 	if err != nil {
 		log.Fatal(err)
 	}
+	indexerMongoClient := dbclient.WrapMongoClient(indexerClient)
 
 	indexerdbClient, err := indexerdbclient.New(context.TODO(), indexerMongoClient, cfg.IndexerDb)
 	if err != nil {
@@ -73,7 +75,7 @@ func SetupTestDB(cfg config.Config) *dbclients.DbClients {
 		}
 		setUpDbIndex = true
 	}
-	if err := PurgeAllCollections(context.TODO(), dbClients.StakingMongoClient, dbName); err != nil {
+	if err := PurgeAllCollections(context.TODO(), dbClients.StakingMongoClient.Current(), dbName); err != nil {
 		log.Fatal("Failed to purge database:", err)
 	}
 
@@ -0,0 +1,46 @@
+package testutils
+
+import (
+	"sync"
+)
+
+// StressOperation is a single operation of a stress run
+type StressOperation func() error
+
+// RunConcurrently runs the operations with the given number of concurrent
+// workers, each failed operation is retried up to maxAttempts times to mimic
+// the redelivery of the queue messages. The errors of the operations still
+// failing after the last attempt are returned.
+func RunConcurrently(workers, maxAttempts int, operations []StressOperation) []error {
+	jobs := make(chan StressOperation)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures []error
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for operation := range jobs {
+				var err error
+				for attempt := 0; attempt < maxAttempts; attempt++ {
+					if err = operation(); err == nil {
+						break
+					}
+				}
+				if err != nil {
+					mu.Lock()
+					failures = append(failures, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, operation := range operations {
+		jobs <- operation
+	}
+	close(jobs)
+	wg.Wait()
+	return failures
+}
@@ -0,0 +1,31 @@
+package configtest
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldEncryptionValidate(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32)))
+	cfg := &config.FieldEncryptionConfig{
+		Keys:          map[string]string{"k1": key},
+		ActiveKeyId:   "k1",
+		BlindIndexKey: key,
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ActiveKeyId = "k2"
+	assert.ErrorContains(t, cfg.Validate(), "active key k2 is not configured")
+
+	cfg.ActiveKeyId = "k1"
+	cfg.Keys["k0"] = base64.StdEncoding.EncodeToString([]byte("short"))
+	assert.ErrorContains(t, cfg.Validate(), "key k0 must be a base64 encoded 32 bytes key")
+
+	delete(cfg.Keys, "k0")
+	cfg.BlindIndexKey = ""
+	assert.ErrorContains(t, cfg.Validate(), "blind index key")
+}
@@ -0,0 +1,62 @@
+package configtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ipFilterViper(t *testing.T, content string) *viper.Viper {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(content)))
+	return v
+}
+
+func TestReloadIpFilter(t *testing.T) {
+	previous := &config.IpFilterConfig{AdminAllowlist: []string{"10.0.0.0/8"}}
+	require.NoError(t, previous.Validate())
+
+	cfg, err := config.ReloadIpFilter(ipFilterViper(t, `
+ip-filter:
+  admin-allowlist:
+    - 192.168.0.0/16
+  public-denylist:
+    - 203.0.113.1
+`), previous)
+	require.NoError(t, err)
+	assert.Len(t, cfg.AdminAllowlistPrefixes, 1)
+	assert.Len(t, cfg.PublicDenylistPrefixes, 1)
+}
+
+func TestReloadIpFilterKeepsTheAdminAllowlist(t *testing.T) {
+	previous := &config.IpFilterConfig{AdminAllowlist: []string{"10.0.0.0/8"}}
+	require.NoError(t, previous.Validate())
+
+	// The section is missing, e.g. the file is read while being written
+	_, err := config.ReloadIpFilter(ipFilterViper(t, `server:
+  port: 8092
+`), previous)
+	assert.Error(t, err)
+
+	// All the clients would be allowed on the admin routes
+	_, err = config.ReloadIpFilter(ipFilterViper(t, `
+ip-filter:
+  public-denylist:
+    - 203.0.113.1
+`), previous)
+	assert.Error(t, err)
+
+	// The allowlist can stay empty if it was
+	cfg, err := config.ReloadIpFilter(ipFilterViper(t, `
+ip-filter:
+  public-denylist:
+    - 203.0.113.1
+`), &config.IpFilterConfig{})
+	require.NoError(t, err)
+	assert.Empty(t, cfg.AdminAllowlistPrefixes)
+}
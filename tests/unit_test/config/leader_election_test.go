@@ -0,0 +1,18 @@
+package configtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderElectionLeaseDuration(t *testing.T) {
+	cfg := &config.LeaderElectionConfig{LeaseDuration: 30 * time.Second}
+	assert.NoError(t, cfg.Validate())
+
+	// The lease is renewed every third of its duration
+	cfg.LeaseDuration = time.Second
+	assert.ErrorContains(t, cfg.Validate(), "at least 3s")
+}
@@ -0,0 +1,38 @@
+package configtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLongPollTimeoutWithinRouteTimeouts(t *testing.T) {
+	server := &config.ServerConfig{WriteTimeout: time.Minute}
+	cfg := &config.LongPollConfig{MaxTimeout: 30 * time.Second, MaxWaiters: 10}
+	assert.NoError(t, cfg.Validate(server, nil))
+
+	cfg.MaxTimeout = 500 * time.Millisecond
+	assert.ErrorContains(t, cfg.Validate(server, nil), "at least 1s")
+
+	// The timeouts of the public routes take precedence over the server ones
+	cfg.MaxTimeout = 30 * time.Second
+	routeLimits := &config.RouteLimitsConfig{Public: &config.RouteGroupLimits{WriteTimeout: 15 * time.Second}}
+	assert.ErrorContains(t, cfg.Validate(server, routeLimits), "write timeout")
+
+	routeLimits.Public = &config.RouteGroupLimits{HandlerTimeout: 30 * time.Second}
+	assert.ErrorContains(t, cfg.Validate(server, routeLimits), "handler timeout")
+
+	routeLimits.Public.HandlerTimeout = 45 * time.Second
+	assert.NoError(t, cfg.Validate(server, routeLimits))
+}
+
+func TestLongPollMaxWaitersRequired(t *testing.T) {
+	server := &config.ServerConfig{WriteTimeout: time.Minute}
+	cfg := &config.LongPollConfig{MaxTimeout: 30 * time.Second}
+	assert.ErrorContains(t, cfg.Validate(server, nil), "max waiters")
+
+	cfg.MaxWaiters = 1
+	assert.NoError(t, cfg.Validate(server, nil))
+}
@@ -0,0 +1,26 @@
+package configtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteGroupLimitsFallBackToServerMaxContentLength(t *testing.T) {
+	server := &config.ServerConfig{MaxContentLength: 4096}
+
+	// A group which is not configured is still limited by the server
+	var unset *config.RouteGroupLimits
+	assert.Equal(t, int64(4096), unset.WithServerDefaults(server).MaxContentLength)
+
+	limits := &config.RouteGroupLimits{ReadTimeout: time.Second}
+	withDefaults := limits.WithServerDefaults(server)
+	assert.Equal(t, int64(4096), withDefaults.MaxContentLength)
+	assert.Equal(t, time.Second, withDefaults.ReadTimeout)
+	assert.Equal(t, int64(0), limits.MaxContentLength, "the configured limits are left as is")
+
+	limits.MaxContentLength = 8192
+	assert.Equal(t, int64(8192), limits.WithServerDefaults(server).MaxContentLength)
+}
@@ -0,0 +1,24 @@
+package configtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerJobs(t *testing.T) {
+	cfg := &config.SchedulerConfig{Jobs: map[string]*config.ScheduledJobConfig{
+		"stats_refresher":     {Enabled: true, Schedule: "@every 30s", Jitter: 5 * time.Second},
+		"delegation_archiver": {Enabled: true, Schedule: "0 3 * * *"},
+		"expiry_scanner":      {},
+	}}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Jobs["expiry_scanner"].Schedule = "every 10 minutes"
+	assert.ErrorContains(t, cfg.Validate(), "invalid scheduled job expiry_scanner")
+
+	cfg.Jobs["expiry_scanner"] = &config.ScheduledJobConfig{Jitter: -time.Second}
+	assert.ErrorContains(t, cfg.Validate(), "jitter must not be negative")
+}
@@ -0,0 +1,31 @@
+package configtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSloValidate(t *testing.T) {
+	cfg := &config.SloConfig{
+		Window: time.Hour,
+		Routes: map[string]*config.RouteLatencyBudget{
+			"/v1/stats": {P95: 250 * time.Millisecond, P99: time.Second},
+		},
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Routes["/v1/stats"].P99 = 100 * time.Millisecond
+	assert.ErrorContains(t, cfg.Validate(), "p99 budget must not be lower than its p95 budget")
+
+	cfg.Routes = map[string]*config.RouteLatencyBudget{"/v2/stats": {}}
+	assert.ErrorContains(t, cfg.Validate(), "slo route /v2/stats has no latency budget")
+
+	cfg.Routes = map[string]*config.RouteLatencyBudget{"v2/stats": {P95: time.Second}}
+	assert.ErrorContains(t, cfg.Validate(), "must start with /")
+
+	cfg.Window = time.Second
+	assert.ErrorContains(t, cfg.Validate(), "at least 1m")
+}
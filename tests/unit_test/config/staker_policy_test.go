@@ -0,0 +1,34 @@
+package configtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStakerPolicyValidate(t *testing.T) {
+	cfg := &config.StakerPolicyConfig{
+		MaxActiveDelegations: 10,
+		MinStakingAmount:     50000,
+		MaxStakingAmount:     500000000,
+		Cooldown:             time.Hour,
+	}
+	assert.NoError(t, cfg.Validate())
+
+	// The max staking amount of 0 is unlimited
+	cfg.MaxStakingAmount = 0
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxStakingAmount = 10000
+	assert.ErrorContains(t, cfg.Validate(), "must not be lower than its min staking amount")
+
+	cfg.MaxStakingAmount = 0
+	cfg.Cooldown = 1500 * time.Millisecond
+	assert.ErrorContains(t, cfg.Validate(), "whole number of seconds")
+
+	cfg.Cooldown = 0
+	cfg.MaxActiveDelegations = -1
+	assert.ErrorContains(t, cfg.Validate(), "must not be negative")
+}
@@ -0,0 +1,130 @@
+package configtest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+// newTestCert creates a certificate signed by the parent, self-signed if the
+// parent is nil
+func newTestCert(t *testing.T, serial int64, isCA bool, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "staking-api-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+// writeFiles writes the certificate and its key as PEM files into the dir
+func (c *testCert) writeFiles(t *testing.T, dir, name string) (string, string) {
+	certFile := filepath.Join(dir, name+".crt")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0o600))
+	keyDer, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return certFile, keyFile
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+func TestTLSConfigRequiresClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, 1, true, nil)
+	caFile, _ := ca.writeFiles(t, dir, "ca")
+	serverCertFile, serverKeyFile := newTestCert(t, 2, false, ca).writeFiles(t, dir, "server")
+	cfg := &config.TLSConfig{CertFile: serverCertFile, KeyFile: serverKeyFile, ClientCAFile: caFile}
+	require.NoError(t, cfg.Validate())
+	tlsConfig, err := cfg.Build()
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = tlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs},
+		}}
+	}
+
+	// A client without a certificate is rejected during the handshake
+	_, err = newClient().Get(server.URL)
+	assert.Error(t, err)
+
+	// A client with a certificate not signed by the client CA is rejected
+	_, err = newClient(newTestCert(t, 3, false, nil).tlsCertificate()).Get(server.URL)
+	assert.Error(t, err)
+
+	resp, err := newClient(newTestCert(t, 4, false, ca).tlsCertificate()).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestTLSConfigValidation(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := newTestCert(t, 1, false, nil).writeFiles(t, dir, "server")
+
+	assert.ErrorContains(t, (&config.TLSConfig{CertFile: certFile}).Validate(), "must be set")
+	assert.ErrorContains(t,
+		(&config.TLSConfig{CertFile: certFile, KeyFile: filepath.Join(dir, "missing.key")}).Validate(),
+		"loading tls certificate",
+	)
+	assert.ErrorContains(t,
+		(&config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: keyFile}).Validate(),
+		"no certificate found",
+	)
+
+	tlsConfig, err := (&config.TLSConfig{CertFile: certFile, KeyFile: keyFile}).Build()
+	require.NoError(t, err)
+	assert.Equal(t, tls.NoClientCert, tlsConfig.ClientAuth)
+	assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+}
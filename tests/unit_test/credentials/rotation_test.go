@@ -0,0 +1,87 @@
+package credentialstest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDbConfig(username, password string) *config.Config {
+	return &config.Config{StakingDb: &config.DbConfig{Username: username, Password: password}}
+}
+
+func stakingDbTarget(rotated *[]credentials.Credentials, rotateErr *error) credentials.Target {
+	return credentials.Target{
+		Name: "staking-db",
+		Extract: func(cfg *config.Config) credentials.Credentials {
+			return credentials.Credentials{Username: cfg.StakingDb.Username, Password: cfg.StakingDb.Password}
+		},
+		Rotate: func(ctx context.Context, newCredentials credentials.Credentials) error {
+			if *rotateErr != nil {
+				return *rotateErr
+			}
+			*rotated = append(*rotated, newCredentials)
+			return nil
+		},
+	}
+}
+
+func TestRotatorOnlyRotatesChangedCredentials(t *testing.T) {
+	reloaded := newDbConfig("staking", "password-1")
+	var rotated []credentials.Credentials
+	var rotateErr error
+	rotator := credentials.NewRotator(
+		newDbConfig("staking", "password-1"),
+		func() (*config.Config, error) { return reloaded, nil },
+		stakingDbTarget(&rotated, &rotateErr),
+	)
+
+	rotator.Check(context.Background())
+	assert.Empty(t, rotated)
+
+	reloaded = newDbConfig("staking", "password-2")
+	rotator.Check(context.Background())
+	rotator.Check(context.Background())
+	assert.Equal(t, []credentials.Credentials{{Username: "staking", Password: "password-2"}}, rotated)
+}
+
+func TestRotatorRetriesFailedRotation(t *testing.T) {
+	reloaded := newDbConfig("staking", "password-2")
+	var rotated []credentials.Credentials
+	rotateErr := errors.New("authentication failed")
+	rotator := credentials.NewRotator(
+		newDbConfig("staking", "password-1"),
+		func() (*config.Config, error) { return reloaded, nil },
+		stakingDbTarget(&rotated, &rotateErr),
+	)
+
+	rotator.Check(context.Background())
+	assert.Empty(t, rotated)
+
+	rotateErr = nil
+	rotator.Check(context.Background())
+	assert.Equal(t, []credentials.Credentials{{Username: "staking", Password: "password-2"}}, rotated)
+}
+
+func TestRotatorKeepsCredentialsWhenReloadFails(t *testing.T) {
+	var rotated []credentials.Credentials
+	var rotateErr error
+	rotator := credentials.NewRotator(
+		newDbConfig("staking", "password-1"),
+		func() (*config.Config, error) { return nil, errors.New("secret manager unavailable") },
+		stakingDbTarget(&rotated, &rotateErr),
+	)
+
+	rotator.Check(context.Background())
+	assert.Empty(t, rotated)
+}
+
+func TestHolder(t *testing.T) {
+	holder := credentials.NewHolder(credentials.Credentials{Username: "user", Password: "password-1"})
+	holder.Set(credentials.Credentials{Username: "user", Password: "password-2"})
+	assert.Equal(t, "password-2", holder.Get().Password)
+}
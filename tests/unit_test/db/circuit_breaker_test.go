@@ -0,0 +1,121 @@
+package dbtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var transientErr = mongo.CommandError{Labels: []string{"NetworkError"}}
+
+func newTestCircuitBreaker() *db.CircuitBreaker {
+	return db.NewCircuitBreaker(&config.DbCircuitBreakerConfig{
+		FailureThreshold: 2,
+		OpenDuration:     50 * time.Millisecond,
+		MaxRetries:       2,
+		RetryBackoff:     time.Millisecond,
+	})
+}
+
+func TestCircuitBreakerRetriesTransientErrors(t *testing.T) {
+	cb := newTestCircuitBreaker()
+	calls := 0
+	err := cb.Execute(context.Background(), true, func() error {
+		calls++
+		if calls < 3 {
+			return transientErr
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	// Non retryable operations are executed once
+	calls = 0
+	err = cb.Execute(context.Background(), false, func() error {
+		calls++
+		return transientErr
+	})
+	assert.True(t, db.IsUnavailableError(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestCircuitBreakerDoesNotRetryNonTransientErrors(t *testing.T) {
+	cb := newTestCircuitBreaker()
+	notFoundErr := &db.NotFoundError{Message: "not found"}
+	calls := 0
+	err := cb.Execute(context.Background(), true, func() error {
+		calls++
+		return notFoundErr
+	})
+	assert.Equal(t, notFoundErr, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	cb := newTestCircuitBreaker()
+	failing := func() error { return transientErr }
+
+	for i := 0; i < 2; i++ {
+		err := cb.Execute(context.Background(), false, failing)
+		assert.True(t, db.IsUnavailableError(err))
+	}
+
+	// The circuit is open, the operation is not executed
+	calls := 0
+	err := cb.Execute(context.Background(), true, func() error {
+		calls++
+		return nil
+	})
+	var unavailableErr *db.UnavailableError
+	require.True(t, errors.As(err, &unavailableErr))
+	assert.Greater(t, unavailableErr.RetryAfter, time.Duration(0))
+	assert.Equal(t, 0, calls)
+
+	// After the open duration, a successful trial closes the circuit
+	time.Sleep(60 * time.Millisecond)
+	err = cb.Execute(context.Background(), true, func() error {
+		calls++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, cb.Execute(context.Background(), true, func() error { return nil }))
+}
+
+func TestNilCircuitBreakerExecutesOperation(t *testing.T) {
+	var cb *db.CircuitBreaker
+	err := cb.Execute(context.Background(), true, func() error { return transientErr })
+	assert.Equal(t, transientErr, err)
+}
+
+func TestCircuitBreakerExecuteWithResult(t *testing.T) {
+	cb := newTestCircuitBreaker()
+	calls := 0
+	result, err := db.ExecuteWithResult(context.Background(), cb, true, func() (int, error) {
+		calls++
+		if calls < 2 {
+			return 0, transientErr
+		}
+		return 42, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 42, result)
+
+	// The errors unrelated to the health of the database don't open the circuit
+	for i := 0; i < 3; i++ {
+		_, err = db.ExecuteWithResult(context.Background(), cb, false, func() (int, error) {
+			return 0, &db.NotFoundError{Message: "not found"}
+		})
+		assert.True(t, db.IsNotFoundError(err))
+	}
+	_, err = db.ExecuteWithResult(context.Background(), cb, false, func() (int, error) { return 1, nil })
+	assert.NoError(t, err)
+}
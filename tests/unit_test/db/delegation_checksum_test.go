@@ -0,0 +1,64 @@
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newChecksummedDelegation() *v1dbmodel.DelegationDocument {
+	delegation := &v1dbmodel.DelegationDocument{
+		StakingTxHashHex:      "staking-tx-hash",
+		StakerPkHex:           "staker",
+		FinalityProviderPkHex: "fp",
+		StakingValue:          1000,
+		State:                 types.Active,
+		StakingTx: &v1dbmodel.TimelockTransaction{
+			TxHex:       "staking-tx-hex",
+			OutputIndex: 1,
+			StartHeight: 100,
+			TimeLock:    150,
+		},
+	}
+	delegation.Checksum = delegation.ComputeChecksum()
+	return delegation
+}
+
+func TestDelegationChecksumIgnoresMutableFields(t *testing.T) {
+	delegation := newChecksummedDelegation()
+	delegation.State = types.Unbonded
+	delegation.UnbondingTx = &v1dbmodel.TimelockTransaction{TxHex: "unbonding-tx-hex"}
+	delegation.PartnerId = "partner"
+	delegation.ChangeSeq = 42
+
+	verified, matches := delegation.VerifyChecksum()
+	assert.True(t, verified)
+	assert.True(t, matches)
+}
+
+func TestDelegationChecksumDetectsMutations(t *testing.T) {
+	mutations := map[string]func(d *v1dbmodel.DelegationDocument){
+		"staking value": func(d *v1dbmodel.DelegationDocument) { d.StakingValue++ },
+		"staker pk":     func(d *v1dbmodel.DelegationDocument) { d.StakerPkHex = "other-staker" },
+		"fp pk":         func(d *v1dbmodel.DelegationDocument) { d.FinalityProviderPkHex = "other-fp" },
+		"staking tx":    func(d *v1dbmodel.DelegationDocument) { d.StakingTx.TxHex = "other-tx-hex" },
+		"timelock":      func(d *v1dbmodel.DelegationDocument) { d.StakingTx.TimeLock++ },
+	}
+	for name, mutate := range mutations {
+		delegation := newChecksummedDelegation()
+		mutate(delegation)
+		verified, matches := delegation.VerifyChecksum()
+		assert.True(t, verified, name)
+		assert.False(t, matches, name)
+	}
+}
+
+func TestDelegationWithoutChecksumIsNotVerified(t *testing.T) {
+	delegation := newChecksummedDelegation()
+	delegation.Checksum = ""
+
+	verified, _ := delegation.VerifyChecksum()
+	assert.False(t, verified)
+}
@@ -0,0 +1,87 @@
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestDelegationDocumentMigratedOnRead(t *testing.T) {
+	// A delegation written before the schema version was introduced
+	stored, err := bson.Marshal(bson.M{
+		"_id":                      "staking-tx-hash",
+		"staker_pk_hex":            "staker",
+		"finality_provider_pk_hex": "fp",
+		"staking_value":            1000,
+		"state":                    types.Active,
+	})
+	require.NoError(t, err)
+
+	var delegation v1dbmodel.DelegationDocument
+	require.NoError(t, bson.Unmarshal(stored, &delegation))
+	assert.Equal(t, "staking-tx-hash", delegation.StakingTxHashHex)
+	assert.Equal(t, types.Active, delegation.State)
+	assert.Equal(t, v1dbmodel.DelegationSchemaVersion, delegation.SchemaVersion)
+	assert.Equal(t, 0, delegation.StoredSchemaVersion())
+	assert.True(t, delegation.IsMigrated())
+
+	// The migrated document is written back at the current version
+	written, err := bson.Marshal(delegation)
+	require.NoError(t, err)
+	var reread v1dbmodel.DelegationDocument
+	require.NoError(t, bson.Unmarshal(written, &reread))
+	assert.Equal(t, v1dbmodel.DelegationSchemaVersion, reread.StoredSchemaVersion())
+	assert.False(t, reread.IsMigrated())
+}
+
+func TestDelegationDocumentsMigratedWithinSlices(t *testing.T) {
+	// The cursors decode the delegations as slice elements
+	stored, err := bson.Marshal(bson.M{"delegations": bson.A{
+		bson.M{"_id": "old"},
+		bson.M{"_id": "current", "schema_version": v1dbmodel.DelegationSchemaVersion},
+	}})
+	require.NoError(t, err)
+
+	var decoded struct {
+		Delegations []v1dbmodel.DelegationDocument `bson:"delegations"`
+	}
+	require.NoError(t, bson.Unmarshal(stored, &decoded))
+	require.Len(t, decoded.Delegations, 2)
+	assert.True(t, decoded.Delegations[0].IsMigrated())
+	assert.False(t, decoded.Delegations[1].IsMigrated())
+	for _, delegation := range decoded.Delegations {
+		assert.Equal(t, v1dbmodel.DelegationSchemaVersion, delegation.SchemaVersion)
+	}
+}
+
+func TestDelegationDocumentExpiryHeightMigrated(t *testing.T) {
+	stored, err := bson.Marshal(bson.M{"delegations": bson.A{
+		bson.M{
+			"_id":            "active",
+			"schema_version": 1,
+			"state":          types.Active,
+			"staking_tx":     bson.M{"start_height": 100, "timelock": 50},
+		},
+		bson.M{
+			"_id":            "unbonding",
+			"schema_version": 1,
+			"state":          types.Unbonding,
+			"staking_tx":     bson.M{"start_height": 100, "timelock": 50},
+			"unbonding_tx":   bson.M{"start_height": 120, "timelock": 10},
+		},
+	}})
+	require.NoError(t, err)
+
+	var decoded struct {
+		Delegations []v1dbmodel.DelegationDocument `bson:"delegations"`
+	}
+	require.NoError(t, bson.Unmarshal(stored, &decoded))
+	require.Len(t, decoded.Delegations, 2)
+	// The unbonding timelock replaces the staking one
+	assert.Equal(t, uint64(150), decoded.Delegations[0].ExpiryHeight)
+	assert.Equal(t, uint64(130), decoded.Delegations[1].ExpiryHeight)
+}
@@ -0,0 +1,41 @@
+package dbtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func duplicateKeyException(t *testing.T, keyPattern bson.M, message string) error {
+	var raw bson.Raw
+	if keyPattern != nil {
+		var err error
+		raw, err = bson.Marshal(bson.M{"code": 11000, "keyPattern": keyPattern})
+		require.NoError(t, err)
+	}
+	return mongo.WriteException{WriteErrors: mongo.WriteErrors{{
+		Code:    11000,
+		Message: message,
+		Raw:     raw,
+	}}}
+}
+
+func TestIsDuplicateKeyOnField(t *testing.T) {
+	err := duplicateKeyException(t, bson.M{"unbonding_tx_hash_hex": 1}, "E11000 duplicate key error")
+	assert.True(t, db.IsDuplicateKeyOnField(err, "unbonding_tx_hash_hex"))
+	assert.False(t, db.IsDuplicateKeyOnField(err, "_id"))
+
+	// The index is named in the message if the key pattern is not reported
+	err = duplicateKeyException(
+		t, nil, "E11000 duplicate key error collection: staking.unbonding_queue index: _id_ dup key",
+	)
+	assert.True(t, db.IsDuplicateKeyOnField(err, "_id"))
+	assert.False(t, db.IsDuplicateKeyOnField(err, "unbonding_tx_hash_hex"))
+
+	assert.False(t, db.IsDuplicateKeyOnField(errors.New("E11000 duplicate key error"), "_id"))
+}
@@ -0,0 +1,69 @@
+package dbtest
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func newTestFieldCipher(t *testing.T, activeKeyId string) *db.FieldCipher {
+	fieldCipher, err := db.NewFieldCipher(&config.FieldEncryptionConfig{
+		Keys:          map[string]string{"k1": testKey('a'), "k2": testKey('b')},
+		ActiveKeyId:   activeKeyId,
+		BlindIndexKey: testKey('c'),
+	})
+	require.NoError(t, err)
+	return fieldCipher
+}
+
+func TestFieldCipherRoundTrip(t *testing.T) {
+	fieldCipher := newTestFieldCipher(t, "k1")
+
+	encrypted, err := fieldCipher.Encrypt("campaign-a", "wallet:txhash")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "k1:"))
+	assert.NotContains(t, encrypted, "campaign-a")
+
+	value, err := fieldCipher.Decrypt(encrypted, "wallet:txhash")
+	require.NoError(t, err)
+	assert.Equal(t, "campaign-a", value)
+
+	// The value can't be moved to another document
+	_, err = fieldCipher.Decrypt(encrypted, "wallet:othertxhash")
+	assert.Error(t, err)
+}
+
+func TestFieldCipherDecryptsWithRotatedKey(t *testing.T) {
+	encrypted, err := newTestFieldCipher(t, "k1").Encrypt("campaign-a", "id")
+	require.NoError(t, err)
+
+	value, err := newTestFieldCipher(t, "k2").Decrypt(encrypted, "id")
+	require.NoError(t, err)
+	assert.Equal(t, "campaign-a", value)
+
+	_, err = newTestFieldCipher(t, "k2").Decrypt("k3:"+strings.TrimPrefix(encrypted, "k1:"), "id")
+	assert.ErrorContains(t, err, "unknown field encryption key k3")
+}
+
+func TestFieldCipherBlindIndex(t *testing.T) {
+	fieldCipher := newTestFieldCipher(t, "k1")
+
+	// The index does not depend on the active key
+	assert.Equal(t, fieldCipher.BlindIndex("campaign-a"), newTestFieldCipher(t, "k2").BlindIndex("campaign-a"))
+	assert.NotEqual(t, fieldCipher.BlindIndex("campaign-a"), fieldCipher.BlindIndex("campaign-b"))
+}
+
+func TestNewFieldCipherNotConfigured(t *testing.T) {
+	fieldCipher, err := db.NewFieldCipher(nil)
+	require.NoError(t, err)
+	assert.Nil(t, fieldCipher)
+}
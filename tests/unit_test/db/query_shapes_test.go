@@ -0,0 +1,82 @@
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func marshalCommand(t *testing.T, command bson.D) bson.Raw {
+	raw, err := bson.Marshal(command)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestShapeOfCommand(t *testing.T) {
+	find := marshalCommand(t, bson.D{
+		{Key: "find", Value: "delegations"},
+		{Key: "filter", Value: bson.D{
+			{Key: "staker_pk_hex", Value: "pk"},
+			{Key: "$or", Value: bson.A{bson.D{{Key: "state", Value: "active"}}, bson.D{{Key: "staker_pk_hex", Value: "pk"}}}},
+		}},
+		{Key: "sort", Value: bson.D{{Key: "staking_tx.start_height", Value: -1}, {Key: "_id", Value: 1}}},
+		{Key: "lsid", Value: bson.D{}},
+	})
+	shape, ok := db.ShapeOfCommand("find", find)
+	require.True(t, ok)
+	assert.Equal(t, db.QueryShape{
+		Collection: "delegations",
+		Filter:     []string{"staker_pk_hex", "state"},
+		Sort:       []string{"staking_tx.start_height", "_id"},
+	}, shape)
+
+	aggregate := marshalCommand(t, bson.D{
+		{Key: "aggregate", Value: "delegations"},
+		{Key: "pipeline", Value: bson.A{
+			bson.D{{Key: "$match", Value: bson.D{{Key: "finality_provider_pk_hex", Value: "fp"}}}},
+			bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$state"}}}},
+		}},
+	})
+	shape, ok = db.ShapeOfCommand("aggregate", aggregate)
+	require.True(t, ok)
+	assert.Equal(t, []string{"finality_provider_pk_hex"}, shape.Filter)
+	assert.Empty(t, shape.Sort)
+
+	_, ok = db.ShapeOfCommand("insert", marshalCommand(t, bson.D{{Key: "insert", Value: "delegations"}}))
+	assert.False(t, ok)
+}
+
+func TestQueryShapeSupportedBy(t *testing.T) {
+	shape := db.QueryShape{Collection: "delegations", Filter: []string{"staker_pk_hex", "state"}}
+	assert.True(t, shape.SupportedBy([]string{"staker_pk_hex", "staking_tx.start_height"}))
+	assert.False(t, shape.SupportedBy([]string{"staking_tx.start_height", "staker_pk_hex"}))
+
+	sortOnly := db.QueryShape{Collection: "delegations", Sort: []string{"_id"}}
+	assert.True(t, sortOnly.SupportedBy([]string{"_id"}))
+	assert.False(t, sortOnly.SupportedBy(nil))
+}
+
+func TestQueryShapesSnapshot(t *testing.T) {
+	shapes := db.QueryShapesOf("query-shapes-test")
+	find := marshalCommand(t, bson.D{
+		{Key: "find", Value: "delegations"},
+		{Key: "filter", Value: bson.D{{Key: "state", Value: "active"}}},
+	})
+	count := marshalCommand(t, bson.D{
+		{Key: "count", Value: "delegations"},
+		{Key: "query", Value: bson.D{{Key: "staker_pk_hex", Value: "pk"}}},
+	})
+	shapes.Record("find", find)
+	shapes.Record("count", count)
+	shapes.Record("count", count)
+	shapes.Record("ping", marshalCommand(t, bson.D{{Key: "ping", Value: 1}}))
+
+	snapshot := shapes.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, []string{"staker_pk_hex"}, snapshot[0].Filter)
+	assert.Equal(t, int64(2), snapshot[0].Count)
+	assert.Equal(t, int64(1), snapshot[1].Count)
+}
@@ -0,0 +1,33 @@
+package dbtest
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogicalShardIdIsDeterministic(t *testing.T) {
+	key := "a4d1f9b5c3e2a4d1f9b5c3e2a4d1f9b5c3e2a4d1f9b5c3e2a4d1f9b5c3e2a4d1"
+	assert.Equal(t, db.LogicalShardId(key, 10), db.LogicalShardId(key, 10))
+}
+
+func TestLogicalShardIdSpreadsKeysAcrossShards(t *testing.T) {
+	const shardCount = 4
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		shardId := db.LogicalShardId(fmt.Sprintf("staking-tx-%d", i), shardCount)
+		shard, err := strconv.Atoi(shardId)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, shard, 0)
+		assert.Less(t, shard, shardCount)
+		counts[shardId]++
+	}
+	assert.Len(t, counts, shardCount)
+	for _, count := range counts {
+		assert.Greater(t, count, 150)
+	}
+}
@@ -0,0 +1,44 @@
+package dbtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclient "github.com/babylonlabs-io/staking-api-service/internal/shared/db/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionOptionsUseConfiguredConcerns(t *testing.T) {
+	journal := true
+	db := &dbclient.Database{Cfg: &config.DbConfig{
+		WriteConcern:           "majority",
+		WriteConcernJournal:    &journal,
+		WriteConcernTimeout:    5 * time.Second,
+		TransactionReadConcern: "snapshot",
+	}}
+	opts := db.TransactionOptions()
+	require.NotNil(t, opts.WriteConcern)
+	assert.Equal(t, "majority", opts.WriteConcern.W)
+	assert.Equal(t, &journal, opts.WriteConcern.Journal)
+	assert.Equal(t, 5*time.Second, opts.WriteConcern.WTimeout)
+	require.NotNil(t, opts.ReadConcern)
+	assert.Equal(t, "snapshot", opts.ReadConcern.Level)
+
+	db.Cfg.WriteConcern = "2"
+	assert.Equal(t, 2, db.TransactionOptions().WriteConcern.W)
+}
+
+func TestTransactionOptionsInheritClientSettingsIfNotConfigured(t *testing.T) {
+	db := &dbclient.Database{Cfg: &config.DbConfig{}}
+	opts := db.TransactionOptions()
+	assert.Nil(t, opts.WriteConcern)
+	assert.Nil(t, opts.ReadConcern)
+	assert.Nil(t, db.SessionOptions().CausalConsistency)
+
+	causal := false
+	db.Cfg.CausalConsistency = &causal
+	require.NotNil(t, db.SessionOptions().CausalConsistency)
+	assert.False(t, *db.SessionOptions().CausalConsistency)
+}
@@ -0,0 +1,133 @@
+package errorreportingtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/errorreporting"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/getsentry/sentry-go"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransport keeps the events instead of sending them to Sentry
+type fakeTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *fakeTransport) Configure(sentry.ClientOptions) {}
+func (t *fakeTransport) Flush(time.Duration) bool       { return true }
+func (t *fakeTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func newReporter(t *testing.T, dedupWindow time.Duration) (*errorreporting.Reporter, *fakeTransport) {
+	transport := &fakeTransport{}
+	reporter, err := errorreporting.New(&config.ErrorReportingConfig{
+		Dsn:         "http://public@localhost:9000/1",
+		Environment: "test",
+		DedupWindow: dedupWindow,
+	}, transport)
+	require.NoError(t, err)
+	return reporter, transport
+}
+
+func TestCaptureHttpErrorReportsRouteContext(t *testing.T) {
+	reporter, transport := newReporter(t, 0)
+	router := chi.NewRouter()
+	router.Get("/v1/delegation", func(w http.ResponseWriter, r *http.Request) {
+		reporter.CaptureHttpError(
+			r, http.StatusInternalServerError, types.InternalServiceError, errors.New("failed to find delegation"),
+		)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/delegation?staking_tx_hash_hex=00", nil))
+
+	require.Len(t, transport.events, 1)
+	event := transport.events[0]
+	assert.Equal(
+		t, []string{"http", "GET", "/v1/delegation", "500", "INTERNAL_SERVICE_ERROR", "*errors.errorString"},
+		event.Fingerprint,
+	)
+	assert.Equal(t, "test", event.Environment)
+	assert.Equal(t, "/v1/delegation", event.Contexts["request"]["route"])
+	assert.Equal(t, "staking_tx_hash_hex=00", event.Contexts["request"]["query"])
+}
+
+func TestCaptureHttpErrorRedactsQueryParams(t *testing.T) {
+	transport := &fakeTransport{}
+	reporter, err := errorreporting.New(&config.ErrorReportingConfig{
+		Dsn:                 "http://public@localhost:9000/1",
+		RedactedQueryParams: []string{"address"},
+	}, transport)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/staker/delegation/check?address=bc1q&timeframe=today", nil)
+	reporter.CaptureHttpError(
+		r, http.StatusInternalServerError, types.InternalServiceError, errors.New("failed to check delegations"),
+	)
+
+	require.Len(t, transport.events, 1)
+	assert.Equal(
+		t, "address=%5BREDACTED%5D&timeframe=today", transport.events[0].Contexts["request"]["query"],
+	)
+}
+
+func TestCaptureUnprocessableMessageTruncatesPayload(t *testing.T) {
+	reporter, transport := newReporter(t, 0)
+	payload := make([]byte, 10*1024)
+	for i := range payload {
+		payload[i] = 'a'
+	}
+	reporter.CaptureUnprocessableMessage(context.Background(), string(payload), "invalid staking tx")
+
+	require.Len(t, transport.events, 1)
+	event := transport.events[0]
+	assert.Equal(t, []string{"unprocessable_message", "invalid staking tx"}, event.Fingerprint)
+	assert.Equal(t, "invalid staking tx", event.Contexts["message"]["reason"])
+	assert.Less(t, len(event.Contexts["message"]["payload"].(string)), len(payload))
+}
+
+func TestCaptureDeduplicatesFingerprints(t *testing.T) {
+	reporter, transport := newReporter(t, time.Hour)
+	for i := 0; i < 3; i++ {
+		reporter.CaptureUnprocessableMessage(context.Background(), "{}", "invalid staking tx")
+	}
+	reporter.CaptureUnprocessableMessage(context.Background(), "{}", "unknown finality provider")
+
+	require.Len(t, transport.events, 2)
+	assert.Equal(t, "invalid staking tx", transport.events[0].Contexts["message"]["reason"])
+	assert.Equal(t, "unknown finality provider", transport.events[1].Contexts["message"]["reason"])
+}
+
+func TestCaptureHttpErrorGroupsTheMessagesOfAnErrorType(t *testing.T) {
+	reporter, transport := newReporter(t, time.Hour)
+	r := httptest.NewRequest(http.MethodGet, "/v1/delegation", nil)
+	for _, txHash := range []string{"00", "01"} {
+		reporter.CaptureHttpError(r, http.StatusInternalServerError, types.InternalServiceError,
+			fmt.Errorf("failed to find delegation %s: %w", txHash, context.DeadlineExceeded))
+	}
+	reporter.CaptureHttpError(r, http.StatusInternalServerError, types.InternalServiceError,
+		fmt.Errorf("failed to find delegation 02: %w", errors.New("connection reset")))
+
+	require.Len(t, transport.events, 2)
+	assert.Equal(
+		t, []string{"http", "GET", "/v1/delegation", "500", "INTERNAL_SERVICE_ERROR", "context.deadlineExceededError"},
+		transport.events[0].Fingerprint,
+	)
+	assert.Equal(
+		t, []string{"http", "GET", "/v1/delegation", "500", "INTERNAL_SERVICE_ERROR", "*errors.errorString"},
+		transport.events[1].Fingerprint,
+	)
+}
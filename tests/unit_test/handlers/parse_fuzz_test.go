@@ -0,0 +1,126 @@
+package handlerstest
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"testing"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTxHash = "0b9c7b5f3c2d6f0e5a6a8d3d4f1e2c3b4a5968778695a4b3c2d1e0f9a8b7c6d5"
+	testPk     = "30bb400d3ef60a5bb66a3f5d9e0e870ccbf8ae1a4ab2263a9fabf90adf94c70a"
+)
+
+func newQueryRequest(query url.Values) *http.Request {
+	return &http.Request{URL: &url.URL{Path: "/", RawQuery: query.Encode()}}
+}
+
+func FuzzParseTxHashQuery(f *testing.F) {
+	for _, seed := range []string{testTxHash, "", "00", "0x" + testTxHash[2:], testTxHash + "00", "zz"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		request := newQueryRequest(url.Values{"staking_tx_hash_hex": {input}})
+		txHash, err := handler.ParseTxHashQuery(request, "staking_tx_hash_hex")
+		if err != nil {
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			return
+		}
+		// Only full length hex encoded hashes are accepted
+		assert.Equal(t, input, txHash)
+		decoded, decodeErr := hex.DecodeString(txHash)
+		require.NoError(t, decodeErr)
+		assert.Len(t, decoded, 32)
+	})
+}
+
+func FuzzParsePublicKeyQuery(f *testing.F) {
+	for _, seed := range []string{testPk, "", "02" + testPk, testPk[:62], "not-a-pk"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		request := newQueryRequest(url.Values{"staker_btc_pk": {input}})
+		pk, err := handler.ParsePublicKeyQuery(request, "staker_btc_pk", false)
+		if err != nil {
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			return
+		}
+		assert.Equal(t, input, pk)
+		_, pkErr := utils.GetSchnorrPkFromHex(pk)
+		assert.NoError(t, pkErr)
+	})
+}
+
+func FuzzParseBtcAddressQuery(f *testing.F) {
+	addresses, err := utils.DeriveAddressesFromNoCoordPk(testPk, &chaincfg.SigNetParams)
+	require.NoError(f, err)
+	for _, seed := range []string{
+		addresses.Taproot, addresses.NativeSegwitEven, addresses.NativeSegwitOdd,
+		"", "tb1", "bc1p89k3uz7fdt58gl3vtxqvfxcsgh0t923qfxyuw8l5qdz70fsxzzqq35fjjt",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		request := newQueryRequest(url.Values{"address": {input}})
+		address, err := handler.ParseBtcAddressQuery(request, "address", &chaincfg.SigNetParams)
+		if err != nil {
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			return
+		}
+		assert.Equal(t, input, address)
+		_, addressErr := utils.CheckBtcAddressType(address, &chaincfg.SigNetParams)
+		assert.NoError(t, addressErr)
+	})
+}
+
+func FuzzParsePaginationQuery(f *testing.F) {
+	token, err := dbmodel.GetPaginationToken(v1dbmodel.DelegationByStakerPagination{
+		StakingTxHashHex: testTxHash, StakingStartHeight: 850000,
+	})
+	require.NoError(f, err)
+	for _, seed := range []string{token, "", "e30=", "!!!!", "e30", "eyJhIjoxfQ=="} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		request := newQueryRequest(url.Values{"pagination_key": {input}})
+		paginationKey, err := handler.ParsePaginationQuery(request)
+		if err != nil {
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			return
+		}
+		assert.Equal(t, input, paginationKey)
+		// The accepted keys are decoded by the db clients, which must not panic
+		// whatever the content of the key
+		_, _ = dbmodel.DecodePaginationToken[v1dbmodel.DelegationByStakerPagination](paginationKey)
+		_, _ = dbmodel.DecodePaginationToken[v1dbmodel.StakerStatsByStakerPagination](paginationKey)
+		_, _ = dbmodel.DecodePaginationToken[v1dbmodel.DelegationChangeCursor](paginationKey)
+		_, _ = indexerdbmodel.DecodeFinalityProviderPaginationToken(paginationKey)
+	})
+}
+
+// The pagination keys handed out by the API must be accepted back
+func FuzzPaginationTokenRoundTrip(f *testing.F) {
+	f.Add(testTxHash, uint64(850000))
+	f.Add("", uint64(0))
+	f.Add("\xff\xfe?>", uint64(1))
+	f.Fuzz(func(t *testing.T, txHash string, height uint64) {
+		token, err := dbmodel.GetPaginationToken(v1dbmodel.DelegationByStakerPagination{
+			StakingTxHashHex: txHash, StakingStartHeight: height,
+		})
+		require.NoError(t, err)
+		request := newQueryRequest(url.Values{"pagination_key": {token}})
+		paginationKey, parseErr := handler.ParsePaginationQuery(request)
+		require.Nil(t, parseErr)
+		assert.Equal(t, token, paginationKey)
+	})
+}
@@ -0,0 +1,91 @@
+package loggingtest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/logging"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitAppliesModuleLevels(t *testing.T) {
+	err := logging.Init("info", &config.LoggingConfig{
+		Modules: map[string]string{config.LogModuleDb: "debug", config.LogModuleQueue: "error"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, logging.Settings{
+		Level:   "info",
+		Format:  config.LogFormatJson,
+		Modules: map[string]string{config.LogModuleDb: "debug", config.LogModuleQueue: "error"},
+	}, logging.Get())
+	// The most verbose module level goes through the global level
+	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+}
+
+func TestUpdateChangesOnlyTheGivenSettings(t *testing.T) {
+	require.NoError(t, logging.Init("info", &config.LoggingConfig{
+		Modules: map[string]string{config.LogModuleDb: "debug", config.LogModuleQueue: "error"},
+	}))
+
+	err := logging.Update(logging.Settings{
+		Level:   "warn",
+		Modules: map[string]string{config.LogModuleDb: "", config.LogModuleHandlers: "info"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, logging.Settings{
+		Level:   "warn",
+		Format:  config.LogFormatJson,
+		Modules: map[string]string{config.LogModuleQueue: "error", config.LogModuleHandlers: "info"},
+	}, logging.Get())
+	assert.Equal(t, zerolog.InfoLevel, zerolog.GlobalLevel())
+
+	// The invalid settings are rejected as a whole
+	testCases := []logging.Settings{
+		{Level: "verbose"},
+		{Level: "trace"},
+		{Format: "xml"},
+		{Level: "debug", Modules: map[string]string{"unknown": "debug"}},
+		{Level: "debug", Modules: map[string]string{"unknown": ""}},
+	}
+	for _, tc := range testCases {
+		assert.Error(t, logging.Update(tc))
+	}
+	assert.Equal(t, "warn", logging.Get().Level)
+}
+
+func TestModuleLoggersFilterByTheirOwnLevel(t *testing.T) {
+	require.NoError(t, logging.Init("warn", &config.LoggingConfig{
+		Modules: map[string]string{config.LogModuleDb: "debug"},
+	}))
+	// The console writer is created on the format switch, writing into the
+	// stderr in place then
+	output, err := os.CreateTemp(t.TempDir(), "logs")
+	require.NoError(t, err)
+	stderr := os.Stderr
+	os.Stderr = output
+	err = logging.Update(logging.Settings{Format: config.LogFormatConsole})
+	os.Stderr = stderr
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, logging.Update(logging.Settings{Format: config.LogFormatJson}))
+	}()
+
+	logging.Module(config.LogModuleDb).Debug().Msg("db debug log")
+	logging.Module(config.LogModuleQueue).Info().Msg("queue info log")
+	logging.Module(config.LogModuleQueue).Warn().Msg("queue warn log")
+	log.Info().Msg("other info log")
+	log.Error().Msg("other error log")
+
+	logs, err := os.ReadFile(output.Name())
+	require.NoError(t, err)
+	assert.Contains(t, string(logs), "db debug log")
+	assert.NotContains(t, string(logs), "queue info log")
+	assert.Contains(t, string(logs), "queue warn log")
+	assert.NotContains(t, string(logs), "other info log")
+	assert.Contains(t, string(logs), "other error log")
+}
@@ -0,0 +1,88 @@
+package middlewarestest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var logs bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&logs)
+	t.Cleanup(func() { log.Logger = previous })
+	return &logs
+}
+
+func newAccessLogRouter(cfg *config.AccessLogConfig) *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(middlewares.AccessLogMiddleware(cfg))
+	r.Post("/v1/unbonding", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write(body)
+	})
+	return r
+}
+
+func TestAccessLogRedactsQueryParamsAndBodyFields(t *testing.T) {
+	logs := captureLogs(t)
+	router := newAccessLogRouter(&config.AccessLogConfig{
+		ClientIdHeader:      "X-Client-Id",
+		RedactedQueryParams: []string{"address"},
+		LogBodies:           true,
+		MaxBodySize:         1024,
+		RedactedBodyFields:  []string{"staker_signed_signature_hex"},
+	})
+
+	body := `{"staking_tx_hash_hex":"abcd","nested":{"staker_signed_signature_hex":"secret"}}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/unbonding?address=bc1secret&limit=10", strings.NewReader(body))
+	req.Header.Set("X-Client-Id", "wallet")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotContains(t, logs.String(), "secret")
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "POST", entry["method"])
+	assert.Equal(t, "/v1/unbonding", entry["route"])
+	assert.Equal(t, "address=%5BREDACTED%5D&limit=10", entry["query"])
+	assert.Equal(t, float64(http.StatusAccepted), entry["status"])
+	assert.Equal(t, "wallet", entry["clientId"])
+	assert.Contains(t, entry, "latencyMs")
+
+	requestBody, ok := entry["requestBody"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "abcd", requestBody["staking_tx_hash_hex"])
+	assert.Equal(t, "[REDACTED]", requestBody["nested"].(map[string]interface{})["staker_signed_signature_hex"])
+	assert.Contains(t, entry, "responseBody")
+}
+
+func TestAccessLogSkipsBodiesThatCannotBeRedacted(t *testing.T) {
+	logs := captureLogs(t)
+	router := newAccessLogRouter(&config.AccessLogConfig{
+		LogBodies:          true,
+		MaxBodySize:        16,
+		RedactedBodyFields: []string{"staker_signed_signature_hex"},
+	})
+
+	body := `{"staker_signed_signature_hex":"secret"}`
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/unbonding", strings.NewReader(body)))
+
+	assert.NotContains(t, logs.String(), "secret")
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.NotContains(t, entry, "requestBody")
+	assert.NotContains(t, entry, "responseBody")
+}
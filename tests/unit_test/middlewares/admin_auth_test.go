@@ -0,0 +1,91 @@
+package middlewarestest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAdminAuthToken = "admin-auth-token-0123456789abcdef0123"
+	testOperatorToken  = "operator-token-0123456789abcdef012345"
+	testQueueToken     = "queue-admin-token-0123456789abcdef0123"
+)
+
+func newAdminRouter(t *testing.T) http.Handler {
+	cfg := &config.AdminConfig{
+		Host:         "127.0.0.1",
+		Port:         8093,
+		AuthToken:    testAdminAuthToken,
+		WriteTimeout: time.Minute,
+		Tokens: map[string]*config.AdminTokenConfig{
+			"operator": {Token: testOperatorToken, Roles: []string{config.AdminRoleReadOnly}},
+			"queue":    {Token: testQueueToken, Roles: []string{config.AdminRoleQueueAdmin}},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	r := chi.NewRouter()
+	r.Use(middlewares.AdminAuthMiddleware(cfg))
+	r.Get("/admin/maintenance", ok)
+	r.With(middlewares.RequireAdminRole(config.AdminRoleQueueAdmin)).Put("/admin/maintenance", ok)
+	r.With(middlewares.RequireAdminRole(config.AdminRoleDataAdmin)).Post("/admin/erasure", ok)
+	return r
+}
+
+func serveAdmin(handler http.Handler, method, path, token string) int {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestAdminRolesRestrictMutatingRoutes(t *testing.T) {
+	handler := newAdminRouter(t)
+
+	// Any admin token can inspect the service
+	for _, token := range []string{testAdminAuthToken, testOperatorToken, testQueueToken} {
+		assert.Equal(t, http.StatusOK, serveAdmin(handler, http.MethodGet, "/admin/maintenance", token))
+	}
+	assert.Equal(t, http.StatusUnauthorized, serveAdmin(handler, http.MethodGet, "/admin/maintenance", ""))
+	assert.Equal(t, http.StatusUnauthorized, serveAdmin(handler, http.MethodGet, "/admin/maintenance", "wrong-token"))
+
+	// The mutating routes require the role of the token
+	assert.Equal(t, http.StatusForbidden, serveAdmin(handler, http.MethodPut, "/admin/maintenance", testOperatorToken))
+	assert.Equal(t, http.StatusOK, serveAdmin(handler, http.MethodPut, "/admin/maintenance", testQueueToken))
+	assert.Equal(t, http.StatusForbidden, serveAdmin(handler, http.MethodPost, "/admin/erasure", testQueueToken))
+
+	// The admin auth token is granted all the roles
+	assert.Equal(t, http.StatusOK, serveAdmin(handler, http.MethodPut, "/admin/maintenance", testAdminAuthToken))
+	assert.Equal(t, http.StatusOK, serveAdmin(handler, http.MethodPost, "/admin/erasure", testAdminAuthToken))
+}
+
+func TestAdminTokensValidation(t *testing.T) {
+	cfg := &config.AdminConfig{
+		Host:         "127.0.0.1",
+		Port:         8093,
+		AuthToken:    testAdminAuthToken,
+		WriteTimeout: time.Minute,
+		Tokens: map[string]*config.AdminTokenConfig{
+			"operator": {Token: testOperatorToken, Roles: []string{"superuser"}},
+		},
+	}
+	assert.ErrorContains(t, cfg.Validate(), "invalid role")
+
+	cfg.Tokens["operator"] = &config.AdminTokenConfig{Token: testAdminAuthToken, Roles: []string{config.AdminRoleReadOnly}}
+	assert.ErrorContains(t, cfg.Validate(), "must differ")
+
+	cfg.Tokens["operator"] = &config.AdminTokenConfig{Token: testOperatorToken}
+	assert.ErrorContains(t, cfg.Validate(), "at least one role")
+}
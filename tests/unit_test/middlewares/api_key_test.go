@@ -0,0 +1,130 @@
+package middlewarestest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testApiKey = "partner-api-key-0123456789abcdef0123"
+
+// memoryApiKeyUsageStore sums the usage flushed by all the instances
+type memoryApiKeyUsageStore struct {
+	mu    sync.Mutex
+	usage map[string]*dbmodel.ApiKeyUsageDocument
+}
+
+func newMemoryApiKeyUsageStore() *memoryApiKeyUsageStore {
+	return &memoryApiKeyUsageStore{usage: make(map[string]*dbmodel.ApiKeyUsageDocument)}
+}
+
+func (s *memoryApiKeyUsageStore) IncrementApiKeyUsage(
+	ctx context.Context, apiKey string, dayStart time.Time, requests, bytes int64,
+) (*dbmodel.ApiKeyUsageDocument, *types.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := dbmodel.BuildApiKeyUsageId(apiKey, dayStart)
+	usage, ok := s.usage[id]
+	if !ok {
+		usage = &dbmodel.ApiKeyUsageDocument{Id: id, ApiKey: apiKey, DayStart: dayStart}
+		s.usage[id] = usage
+	}
+	usage.Requests += requests
+	usage.Bytes += bytes
+	copied := *usage
+	return &copied, nil
+}
+
+func newApiKeyQuotasHandler(
+	quotas *config.ApiKeyConfig, store middlewares.ApiKeyUsageStore, clock *testutils.FakeClock,
+) (*middlewares.ApiKeyQuotas, http.Handler) {
+	cfg := &config.ApiKeysConfig{
+		Keys:          map[string]*config.ApiKeyConfig{"partner": quotas},
+		FlushInterval: time.Minute,
+	}
+	apiKeyQuotas := middlewares.NewApiKeyQuotas(cfg, store, clock)
+	return apiKeyQuotas, apiKeyQuotas.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(middlewares.GetApiKeyName(r)))
+	}))
+}
+
+func serveWithApiKey(handler http.Handler, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/stats", nil)
+	if apiKey != "" {
+		req.Header.Set(middlewares.ApiKeyHeader, apiKey)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestApiKeyQuotasEnforceDailyRequestQuota(t *testing.T) {
+	metrics.Init(0)
+	clock := testutils.NewFakeClock(time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC))
+	store := newMemoryApiKeyUsageStore()
+	quotas := &config.ApiKeyConfig{Key: testApiKey, DailyRequestQuota: 3}
+	apiKeyQuotas, handler := newApiKeyQuotasHandler(quotas, store, clock)
+
+	for i := 0; i < 3; i++ {
+		rec := serveWithApiKey(handler, testApiKey)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "partner", rec.Body.String())
+	}
+	rec := serveWithApiKey(handler, testApiKey)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Contains(t, rec.Body.String(), types.TooManyRequests.String())
+	assert.Equal(t, "3601", rec.Header().Get("Retry-After"))
+
+	// The requests without api key are not limited
+	assert.Equal(t, http.StatusOK, serveWithApiKey(handler, "").Code)
+
+	apiKeyQuotas.Flush(context.Background())
+	usage := store.usage["partner:2024-05-01"]
+	require.NotNil(t, usage)
+	assert.Equal(t, int64(3), usage.Requests)
+	assert.Equal(t, int64(len("partner")*3), usage.Bytes)
+
+	// The quota is reset on the next day
+	clock.Advance(time.Hour)
+	assert.Equal(t, http.StatusOK, serveWithApiKey(handler, testApiKey).Code)
+}
+
+func TestApiKeyQuotasShareUsageAcrossInstances(t *testing.T) {
+	clock := testutils.NewFakeClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	store := newMemoryApiKeyUsageStore()
+	quotas := &config.ApiKeyConfig{Key: testApiKey, DailyRequestQuota: 2}
+	first, firstHandler := newApiKeyQuotasHandler(quotas, store, clock)
+	second, secondHandler := newApiKeyQuotasHandler(quotas, store, clock)
+
+	assert.Equal(t, http.StatusOK, serveWithApiKey(firstHandler, testApiKey).Code)
+	assert.Equal(t, http.StatusOK, serveWithApiKey(secondHandler, testApiKey).Code)
+	first.Flush(context.Background())
+	second.Flush(context.Background())
+	// The usage of the other instance is refreshed on the next flush
+	first.Flush(context.Background())
+
+	// Each instance has served a single request, but the quota is shared
+	assert.Equal(t, http.StatusTooManyRequests, serveWithApiKey(firstHandler, testApiKey).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveWithApiKey(secondHandler, testApiKey).Code)
+}
+
+func TestApiKeyQuotasRejectUnknownApiKey(t *testing.T) {
+	clock := testutils.NewFakeClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	_, handler := newApiKeyQuotasHandler(&config.ApiKeyConfig{Key: testApiKey}, newMemoryApiKeyUsageStore(), clock)
+
+	rec := serveWithApiKey(handler, "unknown-api-key")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), types.Unauthorized.String())
+}
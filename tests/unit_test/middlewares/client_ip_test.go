@@ -0,0 +1,105 @@
+package middlewarestest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIpResolution(t *testing.T) {
+	cfg := &config.ClientIpConfig{TrustedProxies: []string{"10.0.0.0/8", "192.168.1.1", "fd00::/8"}}
+	require.NoError(t, cfg.Validate())
+
+	testCases := []struct {
+		name          string
+		remoteAddr    string
+		forwardedFor  []string
+		realIp        string
+		expectedIp    string
+		withoutConfig bool
+	}{
+		{
+			name:         "untrusted remote address ignores the headers",
+			remoteAddr:   "203.0.113.7:4000",
+			forwardedFor: []string{"198.51.100.1"},
+			expectedIp:   "203.0.113.7",
+		},
+		{
+			name:         "trusted proxy forwards the client",
+			remoteAddr:   "10.1.2.3:4000",
+			forwardedFor: []string{"198.51.100.1"},
+			expectedIp:   "198.51.100.1",
+		},
+		{
+			name:         "forged hops before the client are ignored",
+			remoteAddr:   "10.1.2.3:4000",
+			forwardedFor: []string{"1.1.1.1, 198.51.100.1, 192.168.1.1"},
+			expectedIp:   "198.51.100.1",
+		},
+		{
+			name:         "multiple headers are combined",
+			remoteAddr:   "10.1.2.3:4000",
+			forwardedFor: []string{"1.1.1.1", "198.51.100.1:5555, 10.9.9.9"},
+			expectedIp:   "198.51.100.1",
+		},
+		{
+			name:         "all hops trusted resolves to the farthest one",
+			remoteAddr:   "10.1.2.3:4000",
+			forwardedFor: []string{"10.0.0.2, 192.168.1.1"},
+			expectedIp:   "10.0.0.2",
+		},
+		{
+			name:         "invalid hop stops at the closest trusted proxy",
+			remoteAddr:   "10.1.2.3:4000",
+			forwardedFor: []string{"198.51.100.1, not-an-ip"},
+			expectedIp:   "10.1.2.3",
+		},
+		{
+			name:       "real ip header is used without forwarded for",
+			remoteAddr: "[fd00::1]:4000",
+			realIp:     "2001:db8::1",
+			expectedIp: "2001:db8::1",
+		},
+		{
+			name:          "without config the remote address is used",
+			remoteAddr:    "10.1.2.3:4000",
+			forwardedFor:  []string{"198.51.100.1"},
+			expectedIp:    "10.1.2.3",
+			withoutConfig: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			middlewareCfg := cfg
+			if tc.withoutConfig {
+				middlewareCfg = nil
+			}
+			var resolvedIp string
+			handler := middlewares.ClientIpMiddleware(middlewareCfg)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					resolvedIp = middlewares.GetClientIp(r)
+				}),
+			)
+			req := httptest.NewRequest(http.MethodGet, "/v1/stats", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for _, value := range tc.forwardedFor {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+			if tc.realIp != "" {
+				req.Header.Set("X-Real-IP", tc.realIp)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tc.expectedIp, resolvedIp)
+		})
+	}
+}
+
+func TestClientIpConfigRejectsInvalidProxies(t *testing.T) {
+	cfg := &config.ClientIpConfig{TrustedProxies: []string{"10.0.0.0/33"}}
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,87 @@
+package middlewarestest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeprecationsConfig(t *testing.T, enforceSunset bool) *config.DeprecationsConfig {
+	cfg := &config.DeprecationsConfig{
+		EnforceSunset: enforceSunset,
+		Routes: map[string]*config.RouteDeprecation{
+			"/v1/staker/delegations": {
+				DeprecatedAt: "2025-01-15",
+				Sunset:       "2025-07-15",
+				Replacement:  "/v2/delegations",
+				Link:         "https://example.com/migration",
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+func serveDeprecated(cfg *config.DeprecationsConfig, clock *testutils.FakeClock, path string) *httptest.ResponseRecorder {
+	handler := middlewares.DeprecationMiddleware(cfg, clock)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestDeprecationHeadersAreSetOnDeprecatedRoutes(t *testing.T) {
+	clock := testutils.NewFakeClock(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	cfg := newDeprecationsConfig(t, false)
+
+	rec := serveDeprecated(cfg, clock, "/v1/staker/delegations")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "@1736899200", rec.Header().Get("Deprecation"))
+	assert.Equal(t, "Tue, 15 Jul 2025 00:00:00 GMT", rec.Header().Get("Sunset"))
+	assert.Equal(t, []string{
+		`<https://example.com/migration>; rel="deprecation"; type="text/html"`,
+		`</v2/delegations>; rel="successor-version"`,
+	}, rec.Header().Values("Link"))
+
+	rec = serveDeprecated(cfg, clock, "/v2/delegations")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+	assert.Empty(t, rec.Header().Get("Sunset"))
+}
+
+func TestDeprecatedRoutesPastSunset(t *testing.T) {
+	clock := testutils.NewFakeClock(time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC))
+
+	// Still served unless the sunset is enforced
+	rec := serveDeprecated(newDeprecationsConfig(t, false), clock, "/v1/staker/delegations")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveDeprecated(newDeprecationsConfig(t, true), clock, "/v1/staker/delegations")
+	assert.Equal(t, http.StatusGone, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Sunset"))
+
+	clock.Advance(-time.Second)
+	rec = serveDeprecated(newDeprecationsConfig(t, true), clock, "/v1/staker/delegations")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestDeprecationsConfigRejectsInvalidRoutes(t *testing.T) {
+	invalid := []*config.RouteDeprecation{
+		{DeprecatedAt: "15/01/2025"},
+		{DeprecatedAt: "2025-01-15", Sunset: "2025-01-01"},
+		{DeprecatedAt: "2025-01-15", Replacement: "v2/delegations"},
+		{DeprecatedAt: "2025-01-15", Link: "/migration"},
+	}
+	for _, deprecation := range invalid {
+		cfg := &config.DeprecationsConfig{Routes: map[string]*config.RouteDeprecation{"/v1/stats": deprecation}}
+		assert.Error(t, cfg.Validate(), "%+v", deprecation)
+	}
+}
@@ -0,0 +1,104 @@
+package middlewarestest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCountryResolver map[string]string
+
+func (f fakeCountryResolver) Country(addr netip.Addr) (string, error) {
+	if addr.String() == "192.0.2.1" {
+		return "", errors.New("corrupted database")
+	}
+	return f[addr.String()], nil
+}
+
+func newGeoGate(t *testing.T, action string) *middlewares.GeoGate {
+	cfg := &config.GeoGatingConfig{
+		DatabasePath:        "/var/lib/geoip/GeoLite2-Country.mmdb",
+		RestrictedCountries: []string{"KP", "IR"},
+		Action:              action,
+	}
+	require.NoError(t, cfg.Validate())
+	return middlewares.NewGeoGate(cfg, fakeCountryResolver{
+		"203.0.113.7":  "KP",
+		"198.51.100.1": "FR",
+	})
+}
+
+func serveGeoGated(gate *middlewares.GeoGate, remoteAddr string) (*httptest.ResponseRecorder, bool) {
+	served := false
+	handler := gate.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/v1/unbonding", nil)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec, served
+}
+
+func TestGeoGateBlocksRestrictedJurisdictions(t *testing.T) {
+	metrics.Init(0)
+	gate := newGeoGate(t, config.GeoGatingBlock)
+
+	rec, served := serveGeoGated(gate, "203.0.113.7:4000")
+	assert.False(t, served)
+	assert.Equal(t, http.StatusUnavailableForLegalReasons, rec.Code)
+	var body middlewares.GeoGatedResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, types.UnavailableForLegalReasons.String(), body.ErrorCode)
+	assert.Equal(t, "KP", body.Country)
+
+	rec, served = serveGeoGated(gate, "198.51.100.1:4000")
+	assert.True(t, served)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGeoGateFlagsRestrictedJurisdictions(t *testing.T) {
+	metrics.Init(0)
+	gate := newGeoGate(t, config.GeoGatingFlag)
+
+	rec, served := serveGeoGated(gate, "203.0.113.7:4000")
+	assert.True(t, served)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGeoGateServesUnresolvedClients(t *testing.T) {
+	gate := newGeoGate(t, config.GeoGatingBlock)
+
+	// Unknown to the database
+	_, served := serveGeoGated(gate, "10.1.2.3:4000")
+	assert.True(t, served)
+	// Failed lookup
+	_, served = serveGeoGated(gate, "192.0.2.1:4000")
+	assert.True(t, served)
+}
+
+func TestGeoGatingConfigRejectsInvalidSettings(t *testing.T) {
+	cfg := &config.GeoGatingConfig{
+		DatabasePath: "/var/lib/geoip/GeoLite2-Country.mmdb", RestrictedCountries: []string{"kp"}, Action: config.GeoGatingBlock,
+	}
+	assert.Error(t, cfg.Validate())
+
+	cfg.RestrictedCountries = []string{"KP"}
+	cfg.Action = "redirect"
+	assert.Error(t, cfg.Validate())
+
+	cfg.Action = config.GeoGatingFlag
+	cfg.DatabasePath = ""
+	assert.Error(t, cfg.Validate())
+}
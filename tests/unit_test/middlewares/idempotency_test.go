@@ -0,0 +1,192 @@
+package middlewarestest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryIdempotencyStore keeps the idempotency keys in memory
+type memoryIdempotencyStore struct {
+	mu   sync.Mutex
+	keys map[string]*dbmodel.IdempotencyKeyDocument
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{keys: make(map[string]*dbmodel.IdempotencyKeyDocument)}
+}
+
+func (s *memoryIdempotencyStore) ReserveIdempotencyKey(
+	ctx context.Context, key, requestHash string,
+) (*dbmodel.IdempotencyKeyDocument, *types.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.keys[key]; ok {
+		copied := *existing
+		return &copied, nil
+	}
+	s.keys[key] = &dbmodel.IdempotencyKeyDocument{Key: key, RequestHash: requestHash, CreatedAt: time.Now()}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) CompleteIdempotencyKey(
+	ctx context.Context, key string, statusCode int, contentType string, body []byte,
+) *types.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	document := s.keys[key]
+	document.Completed = true
+	document.StatusCode = statusCode
+	document.ContentType = contentType
+	document.Body = body
+	return nil
+}
+
+func (s *memoryIdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, key string) *types.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+	return nil
+}
+
+var testIdempotencyConfig = &config.IdempotencyConfig{Ttl: time.Hour, LockTimeout: time.Minute}
+
+func newIdempotentRequest(key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/unbonding", strings.NewReader(body))
+	req.Header.Set(middlewares.IdempotencyKeyHeader, key)
+	return req
+}
+
+func TestIdempotencyReplaysFirstResponse(t *testing.T) {
+	calls := 0
+	handler := middlewares.IdempotencyMiddleware(testIdempotencyConfig, newMemoryIdempotencyStore())(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"call":1}`))
+		}),
+	)
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, newIdempotentRequest("key-1", `{"tx":"a"}`))
+	retry := httptest.NewRecorder()
+	handler.ServeHTTP(retry, newIdempotentRequest("key-1", `{"tx":"a"}`))
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusAccepted, retry.Code)
+	assert.Equal(t, `{"call":1}`, retry.Body.String())
+	assert.Equal(t, "application/json", retry.Header().Get("Content-Type"))
+	assert.Equal(t, "true", retry.Header().Get(middlewares.IdempotentReplayedHeader))
+	assert.Empty(t, first.Header().Get(middlewares.IdempotentReplayedHeader))
+
+	// The key is not shared with other requests
+	other := httptest.NewRecorder()
+	handler.ServeHTTP(other, newIdempotentRequest("key-1", `{"tx":"b"}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, other.Code)
+	assert.Contains(t, other.Body.String(), types.IdempotencyKeyMismatch.String())
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyKeysAreScopedByClient(t *testing.T) {
+	calls := 0
+	handler := middlewares.IdempotencyMiddleware(testIdempotencyConfig, newMemoryIdempotencyStore())(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusAccepted)
+		}),
+	)
+
+	first := httptest.NewRecorder()
+	firstReq := newIdempotentRequest("key-1", `{"tx":"a"}`)
+	firstReq.RemoteAddr = "203.0.113.1:1234"
+	handler.ServeHTTP(first, firstReq)
+	other := httptest.NewRecorder()
+	otherReq := newIdempotentRequest("key-1", `{"tx":"b"}`)
+	otherReq.RemoteAddr = "203.0.113.2:1234"
+	handler.ServeHTTP(other, otherReq)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, http.StatusAccepted, other.Code)
+	assert.Empty(t, other.Header().Get(middlewares.IdempotentReplayedHeader))
+}
+
+func TestIdempotencyRetriesServerErrors(t *testing.T) {
+	calls := 0
+	handler := middlewares.IdempotencyMiddleware(testIdempotencyConfig, newMemoryIdempotencyStore())(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}),
+	)
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, newIdempotentRequest("key-1", `{}`))
+	retry := httptest.NewRecorder()
+	handler.ServeHTTP(retry, newIdempotentRequest("key-1", `{}`))
+
+	assert.Equal(t, http.StatusServiceUnavailable, first.Code)
+	assert.Equal(t, http.StatusAccepted, retry.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyRejectsConcurrentRetries(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	inProgress := make(chan struct{})
+	release := make(chan struct{})
+	handler := middlewares.IdempotencyMiddleware(testIdempotencyConfig, store)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(inProgress)
+			<-release
+			w.WriteHeader(http.StatusAccepted)
+		}),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", `{}`))
+	}()
+	<-inProgress
+
+	retry := httptest.NewRecorder()
+	handler.ServeHTTP(retry, newIdempotentRequest("key-1", `{}`))
+	assert.Equal(t, http.StatusConflict, retry.Code)
+	assert.Contains(t, retry.Body.String(), types.IdempotencyKeyInProgress.String())
+
+	close(release)
+	<-done
+}
+
+func TestIdempotencyWithoutKey(t *testing.T) {
+	calls := 0
+	handler := middlewares.IdempotencyMiddleware(testIdempotencyConfig, newMemoryIdempotencyStore())(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusAccepted)
+		}),
+	)
+
+	for i := 0; i < 2; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/unbonding", nil))
+	}
+	assert.Equal(t, 2, calls)
+
+	tooLong := httptest.NewRecorder()
+	handler.ServeHTTP(tooLong, newIdempotentRequest(strings.Repeat("k", 256), `{}`))
+	assert.Equal(t, http.StatusBadRequest, tooLong.Code)
+}
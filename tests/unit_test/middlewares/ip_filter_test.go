@@ -0,0 +1,60 @@
+package middlewarestest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIpFilterConfig(t *testing.T, adminAllowlist, publicDenylist []string) *config.IpFilterConfig {
+	cfg := &config.IpFilterConfig{AdminAllowlist: adminAllowlist, PublicDenylist: publicDenylist}
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+func serveFiltered(middleware func(http.Handler) http.Handler, remoteAddr string) int {
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/v1/stats", nil)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestPublicDenylistRejectsBlockedClients(t *testing.T) {
+	filter := middlewares.NewIpFilter(newIpFilterConfig(t, nil, []string{"203.0.113.0/24"}))
+
+	assert.Equal(t, http.StatusForbidden, serveFiltered(filter.PublicDenylistMiddleware, "203.0.113.7:4000"))
+	assert.Equal(t, http.StatusOK, serveFiltered(filter.PublicDenylistMiddleware, "198.51.100.1:4000"))
+}
+
+func TestAdminAllowlistRejectsOtherClients(t *testing.T) {
+	filter := middlewares.NewIpFilter(newIpFilterConfig(t, []string{"10.0.0.0/8"}, nil))
+
+	assert.Equal(t, http.StatusOK, serveFiltered(filter.AdminAllowlistMiddleware, "10.1.2.3:4000"))
+	assert.Equal(t, http.StatusForbidden, serveFiltered(filter.AdminAllowlistMiddleware, "198.51.100.1:4000"))
+
+	// An empty allowlist allows all the clients
+	filter.Update(newIpFilterConfig(t, nil, nil))
+	assert.Equal(t, http.StatusOK, serveFiltered(filter.AdminAllowlistMiddleware, "198.51.100.1:4000"))
+}
+
+func TestIpFilterUpdateAppliesToNextRequests(t *testing.T) {
+	filter := middlewares.NewIpFilter(newIpFilterConfig(t, nil, nil))
+	assert.Equal(t, http.StatusOK, serveFiltered(filter.PublicDenylistMiddleware, "203.0.113.7:4000"))
+
+	filter.Update(newIpFilterConfig(t, nil, []string{"203.0.113.7"}))
+	assert.Equal(t, http.StatusForbidden, serveFiltered(filter.PublicDenylistMiddleware, "203.0.113.7:4000"))
+}
+
+func TestIpFilterConfigRejectsInvalidLists(t *testing.T) {
+	cfg := &config.IpFilterConfig{PublicDenylist: []string{"203.0.113.0/24", "example.com"}}
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,132 @@
+package middlewarestest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLatencySlo(clock *testutils.FakeClock) *middlewares.LatencySlo {
+	return middlewares.NewLatencySlo(&config.SloConfig{
+		Window: time.Hour,
+		Routes: map[string]*config.RouteLatencyBudget{
+			"/v1/stats": {P95: 250 * time.Millisecond, P99: time.Second},
+			"/v2/stats": {P95: 250 * time.Millisecond},
+		},
+	}, clock)
+}
+
+func TestLatencySloSummary(t *testing.T) {
+	metrics.Init(0)
+	clock := testutils.NewFakeClock(time.Unix(1700000000, 0))
+	slo := newTestLatencySlo(clock)
+
+	// 90 fast requests, 8 over the p95 budget and 2 over the p99 budget
+	for i := 0; i < 90; i++ {
+		slo.Record("/v1/stats", 20*time.Millisecond)
+	}
+	for i := 0; i < 8; i++ {
+		slo.Record("/v1/stats", 400*time.Millisecond)
+	}
+	for i := 0; i < 2; i++ {
+		slo.Record("/v1/stats", 2*time.Second)
+	}
+
+	summary := slo.Summary()
+	require.Len(t, summary, 2)
+	v1Stats := summary[0]
+	assert.Equal(t, "/v1/stats", v1Stats.Route)
+	assert.Equal(t, int64(100), v1Stats.Requests)
+	assert.Equal(t, 500*time.Millisecond, v1Stats.P95)
+	assert.Equal(t, 2500*time.Millisecond, v1Stats.P99)
+	assert.InDelta(t, 2.0, v1Stats.P95BurnRate, 0.001)
+	assert.InDelta(t, 2.0, v1Stats.P99BurnRate, 0.001)
+
+	// The routes without request are reported with their budgets
+	assert.Equal(t, "/v2/stats", summary[1].Route)
+	assert.Zero(t, summary[1].Requests)
+	assert.Equal(t, 250*time.Millisecond, summary[1].P95Budget)
+}
+
+func TestLatencySloDropsRequestsOutOfTheWindow(t *testing.T) {
+	metrics.Init(0)
+	clock := testutils.NewFakeClock(time.Unix(1700000000, 0))
+	slo := newTestLatencySlo(clock)
+
+	slo.Record("/v1/stats", 2*time.Second)
+	clock.Advance(30 * time.Minute)
+	slo.Record("/v1/stats", 20*time.Millisecond)
+	assert.Equal(t, int64(2), slo.Summary()[0].Requests)
+
+	clock.Advance(45 * time.Minute)
+	summary := slo.Summary()[0]
+	assert.Equal(t, int64(1), summary.Requests)
+	assert.Zero(t, summary.P95BurnRate)
+}
+
+// sloBurnRate reads the burn rate gauge of the route and objective
+func sloBurnRate(t *testing.T, route, objective string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "http_slo_burn_rate" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["route"] == route && labels["objective"] == objective {
+				return metric.GetGauge().GetValue()
+			}
+		}
+	}
+	t.Fatalf("no burn rate gauge for route %s and objective %s", route, objective)
+	return 0
+}
+
+func TestLatencySloRefreshesIdleRoutes(t *testing.T) {
+	metrics.Init(0)
+	clock := testutils.NewFakeClock(time.Unix(1700000000, 0))
+	slo := newTestLatencySlo(clock)
+
+	slo.Record("/v1/stats", 2*time.Second)
+	assert.InDelta(t, 20.0, sloBurnRate(t, "/v1/stats", "p95"), 0.001)
+
+	// The burn rates decay once the requests have left the window, even
+	// without any new request
+	clock.Advance(2 * time.Hour)
+	slo.RefreshBurnRates()
+	assert.Zero(t, sloBurnRate(t, "/v1/stats", "p95"))
+	assert.Zero(t, sloBurnRate(t, "/v1/stats", "p99"))
+}
+
+func TestLatencySloMiddlewareOnlyTracksBudgetedRoutes(t *testing.T) {
+	metrics.Init(0)
+	clock := testutils.NewFakeClock(time.Unix(1700000000, 0))
+	slo := newTestLatencySlo(clock)
+	handler := slo.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clock.Advance(300 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, path := range []string{"/v1/stats", "/v1/global-params"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	summary := slo.Summary()
+	assert.Equal(t, int64(1), summary[0].Requests)
+	assert.InDelta(t, 20.0, summary[0].P95BurnRate, 0.001)
+}
@@ -0,0 +1,72 @@
+package middlewarestest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/middlewares"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecoveryRouter(handler http.HandlerFunc) *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(middlewares.RecoveryMiddleware)
+	r.Get("/v1/delegation", handler)
+	return r
+}
+
+func TestRecoveryRespondsWithInternalServiceError(t *testing.T) {
+	metrics.Init(0)
+	logs := captureLogs(t)
+	router := newRecoveryRouter(func(w http.ResponseWriter, r *http.Request) {
+		var delegation map[string]string
+		delegation["state"] = "active"
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/delegation", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, types.InternalServiceError.String(), body["errorCode"])
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "/v1/delegation", entry["route"])
+	assert.Contains(t, entry["panic"], "assignment to entry in nil map")
+	assert.NotEmpty(t, entry["stack"])
+}
+
+func TestRecoveryKeepsStartedResponse(t *testing.T) {
+	metrics.Init(0)
+	captureLogs(t)
+	router := newRecoveryRouter(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("partial"))
+		panic("failure while streaming the response")
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/delegation", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "partial", rec.Body.String())
+}
+
+func TestRecoveryRepanicsOnAbortHandler(t *testing.T) {
+	metrics.Init(0)
+	router := newRecoveryRouter(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/delegation", nil))
+	})
+}
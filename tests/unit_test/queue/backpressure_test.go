@@ -0,0 +1,60 @@
+package queuetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	queueclient "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func recordCommands(stats *db.CommandStats, count int, duration time.Duration, failures int) {
+	for i := 0; i < count; i++ {
+		stats.Record(duration, i < failures)
+	}
+}
+
+func TestBackpressureThrottlesWorkersWhileDbStruggles(t *testing.T) {
+	metrics.Init(0)
+	stats := db.CommandStatsOf("backpressure_test_db")
+	backpressure := queueclient.NewBackpressure(&config.QueueBackpressureConfig{
+		Interval:             time.Second,
+		LatencyThreshold:     100 * time.Millisecond,
+		FailureRateThreshold: 0.1,
+	}, stats)
+	assert.Equal(t, 8, backpressure.Limit(8))
+
+	// Slow commands halve the workers
+	recordCommands(stats, 20, 300*time.Millisecond, 0)
+	backpressure.Adjust()
+	assert.Equal(t, 4, backpressure.Limit(8))
+
+	// Failing commands halve the workers, down to a single worker
+	for i := 0; i < 4; i++ {
+		recordCommands(stats, 20, time.Millisecond, 5)
+		backpressure.Adjust()
+	}
+	assert.Equal(t, 1, backpressure.Limit(8))
+	assert.Equal(t, 1, backpressure.Limit(1))
+
+	// The workers are given back step by step while the db is healthy, an
+	// interval with too few commands doesn't tell it's struggling
+	recordCommands(stats, 2, time.Second, 2)
+	backpressure.Adjust()
+	assert.Equal(t, 2, backpressure.Limit(8))
+	recordCommands(stats, 20, time.Millisecond, 0)
+	backpressure.Adjust()
+	assert.Equal(t, 2, backpressure.Limit(8))
+	for i := 0; i < 8; i++ {
+		backpressure.Adjust()
+	}
+	assert.Equal(t, 8, backpressure.Limit(8))
+}
+
+func TestNilBackpressureDoesNotLimitWorkers(t *testing.T) {
+	var backpressure *queueclient.Backpressure
+	assert.Equal(t, 4, backpressure.Limit(4))
+}
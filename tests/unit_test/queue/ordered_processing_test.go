@@ -0,0 +1,83 @@
+package queuetest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	queueclient "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/client"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderedTestEvent struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	Seq              int    `json:"seq"`
+}
+
+func orderedTestMessage(stakingTxHashHex string, seq int) client.QueueMessage {
+	body, _ := json.Marshal(orderedTestEvent{StakingTxHashHex: stakingTxHashHex, Seq: seq})
+	return client.QueueMessage{Body: string(body), Receipt: fmt.Sprintf("%s-%d", stakingTxHashHex, seq)}
+}
+
+func TestQueueMessagesOfDelegationProcessedInOrder(t *testing.T) {
+	metrics.Init(0)
+	queueClient := &fakeQueueClient{msgs: make(chan client.QueueMessage, 64)}
+
+	var mu sync.Mutex
+	processed := make(map[string][]int)
+	slowStarted := make(chan struct{})
+	releaseSlow := make(chan struct{})
+	handler := func(ctx context.Context, messageBody string) *types.Error {
+		var event orderedTestEvent
+		require.NoError(t, json.Unmarshal([]byte(messageBody), &event))
+		if event.StakingTxHashHex == "slow" && event.Seq == 0 {
+			close(slowStarted)
+			<-releaseSlow
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		processed[event.StakingTxHashHex] = append(processed[event.StakingTxHashHex], event.Seq)
+		return nil
+	}
+	queueclient.StartQueueMessageProcessing(queueClient, handler, nil, nil, nil, 3, time.Second, 4)
+
+	queueClient.msgs <- orderedTestMessage("slow", 0)
+	<-slowStarted
+	for i := 0; i < 8; i++ {
+		queueClient.msgs <- orderedTestMessage(fmt.Sprintf("fast%d", i), 0)
+		queueClient.msgs <- orderedTestMessage(fmt.Sprintf("fast%d", i), 1)
+	}
+	queueClient.msgs <- orderedTestMessage("slow", 1)
+
+	// The other delegations are processed while the slow one is blocked
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(processed) > 0
+	}, time.Second, 10*time.Millisecond)
+	mu.Lock()
+	assert.Empty(t, processed["slow"])
+	mu.Unlock()
+
+	close(releaseSlow)
+	require.Eventually(t, func() bool {
+		queueClient.mu.Lock()
+		defer queueClient.mu.Unlock()
+		return len(queueClient.deleted) == 18
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []int{0, 1}, processed["slow"])
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, []int{0, 1}, processed[fmt.Sprintf("fast%d", i)])
+	}
+	require.NoError(t, queueClient.Stop())
+}
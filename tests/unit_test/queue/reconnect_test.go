@@ -0,0 +1,202 @@
+package queuetest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	queueclient "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/client"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testQueueName = "test_queue"
+
+type fakeQueueClient struct {
+	mu      sync.Mutex
+	msgs    chan client.QueueMessage
+	deleted []string
+	stopped bool
+}
+
+func newFakeQueueClient() *fakeQueueClient {
+	return &fakeQueueClient{msgs: make(chan client.QueueMessage, 1)}
+}
+
+func (f *fakeQueueClient) SendMessage(ctx context.Context, messageBody string) error { return nil }
+func (f *fakeQueueClient) ReceiveMessages() (<-chan client.QueueMessage, error) {
+	return f.msgs, nil
+}
+func (f *fakeQueueClient) DeleteMessage(receipt string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, receipt)
+	return nil
+}
+func (f *fakeQueueClient) Stop() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.stopped {
+		f.stopped = true
+		close(f.msgs)
+	}
+	return nil
+}
+func (f *fakeQueueClient) GetQueueName() string { return testQueueName }
+func (f *fakeQueueClient) ReQueueMessage(ctx context.Context, message client.QueueMessage) error {
+	return nil
+}
+func (f *fakeQueueClient) Ping(ctx context.Context) error { return nil }
+
+// breakConnection simulates the broker closing the channel
+func (f *fakeQueueClient) breakConnection() { _ = f.Stop() }
+
+func TestReconnectingQueueClientRecoversBrokenConsumer(t *testing.T) {
+	metrics.Init(0)
+	reconnectCfg := &config.QueueReconnectConfig{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+	}
+
+	var dialMu sync.Mutex
+	var dialed []*fakeQueueClient
+	failures := 2
+	dial := func() (client.QueueClient, error) {
+		dialMu.Lock()
+		defer dialMu.Unlock()
+		// The first dial creates the initial client, the following ones fail
+		// a few times to simulate the broker restarting
+		if len(dialed) > 0 && failures > 0 {
+			failures--
+			return nil, errors.New("broker unavailable")
+		}
+		fake := newFakeQueueClient()
+		dialed = append(dialed, fake)
+		return fake, nil
+	}
+
+	queueClient, err := queueclient.NewReconnectingQueueClient(reconnectCfg, testQueueName, dial)
+	require.NoError(t, err)
+	defer queueClient.Stop()
+	messages, err := queueClient.ReceiveMessages()
+	require.NoError(t, err)
+
+	dialed[0].msgs <- client.QueueMessage{Body: "first", Receipt: "1"}
+	first := <-messages
+	assert.Equal(t, "first", first.Body)
+	require.NoError(t, queueClient.DeleteMessage(first.Receipt))
+	assert.Equal(t, []string{"1"}, dialed[0].deleted)
+
+	dialed[0].breakConnection()
+	assert.Eventually(t, func() bool {
+		dialMu.Lock()
+		defer dialMu.Unlock()
+		return len(dialed) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return len(queueclient.DisconnectedQueueNames()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	dialMu.Lock()
+	reconnected := dialed[1]
+	dialMu.Unlock()
+	reconnected.msgs <- client.QueueMessage{Body: "second", Receipt: "1"}
+	second := <-messages
+	assert.Equal(t, "second", second.Body)
+
+	// The receipt of a message delivered before the reconnection is stale
+	assert.Error(t, queueClient.DeleteMessage(first.Receipt))
+	require.NoError(t, queueClient.DeleteMessage(second.Receipt))
+	assert.Equal(t, []string{"1"}, reconnected.deleted)
+}
+
+func TestReconnectingQueueClientPingWhileDisconnected(t *testing.T) {
+	metrics.Init(0)
+	reconnectCfg := &config.QueueReconnectConfig{
+		InitialBackoff:          10 * time.Millisecond,
+		MaxBackoff:              10 * time.Millisecond,
+		MaxDisconnectedDuration: 100 * time.Millisecond,
+	}
+	initial := newFakeQueueClient()
+	dialed := false
+	dial := func() (client.QueueClient, error) {
+		if !dialed {
+			dialed = true
+			return initial, nil
+		}
+		return nil, errors.New("broker unavailable")
+	}
+
+	queueClient, err := queueclient.NewReconnectingQueueClient(reconnectCfg, testQueueName, dial)
+	require.NoError(t, err)
+	defer queueClient.Stop()
+	_, err = queueClient.ReceiveMessages()
+	require.NoError(t, err)
+
+	initial.breakConnection()
+	assert.Eventually(t, func() bool {
+		return len(queueclient.DisconnectedQueueNames()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	// Healthy during the grace period, unhealthy once exceeded
+	assert.NoError(t, queueClient.Ping(context.Background()))
+	assert.Eventually(t, func() bool {
+		return queueClient.Ping(context.Background()) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReconnectingQueueClientRedial(t *testing.T) {
+	metrics.Init(0)
+	reconnectCfg := &config.QueueReconnectConfig{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+	}
+	var dialed []*fakeQueueClient
+	dialErr := error(nil)
+	dial := func() (client.QueueClient, error) {
+		if dialErr != nil {
+			return nil, dialErr
+		}
+		fake := newFakeQueueClient()
+		dialed = append(dialed, fake)
+		return fake, nil
+	}
+
+	queueClient, err := queueclient.NewReconnectingQueueClient(reconnectCfg, testQueueName, dial)
+	require.NoError(t, err)
+	defer queueClient.Stop()
+	messages, err := queueClient.ReceiveMessages()
+	require.NoError(t, err)
+
+	// The current client is kept if the redial fails, e.g. with invalid
+	// credentials
+	dialErr = errors.New("access refused")
+	assert.Error(t, queueClient.Redial(context.Background()))
+	dialed[0].msgs <- client.QueueMessage{Body: "first", Receipt: "1"}
+	first := <-messages
+	assert.Equal(t, "first", first.Body)
+
+	dialErr = nil
+	require.NoError(t, queueClient.Redial(context.Background()))
+	require.Len(t, dialed, 2)
+	assert.Eventually(t, func() bool {
+		dialed[0].mu.Lock()
+		defer dialed[0].mu.Unlock()
+		return dialed[0].stopped
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The messages are consumed from the redialed client, the ones delivered
+	// before can no longer be acknowledged
+	dialed[1].msgs <- client.QueueMessage{Body: "second", Receipt: "1"}
+	second := <-messages
+	assert.Equal(t, "second", second.Body)
+	assert.Error(t, queueClient.DeleteMessage(first.Receipt))
+	require.NoError(t, queueClient.DeleteMessage(second.Receipt))
+	assert.Equal(t, []string{"1"}, dialed[1].deleted)
+	// The redial is not seen as a disconnection
+	assert.Empty(t, queueclient.DisconnectedQueueNames())
+}
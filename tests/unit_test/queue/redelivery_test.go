@@ -0,0 +1,31 @@
+package queuetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedeliveryDelayIsExponentialAndCapped(t *testing.T) {
+	cfg := &config.QueueRedeliveryConfig{
+		InitialDelay: 5 * time.Second,
+		MaxDelay:     time.Minute,
+	}
+	assert.NoError(t, cfg.Validate())
+
+	assert.Equal(t, 5*time.Second, cfg.DelayFor(0))
+	assert.Equal(t, 10*time.Second, cfg.DelayFor(1))
+	assert.Equal(t, 20*time.Second, cfg.DelayFor(2))
+	assert.Equal(t, 40*time.Second, cfg.DelayFor(3))
+	assert.Equal(t, time.Minute, cfg.DelayFor(4))
+	assert.Equal(t, time.Minute, cfg.DelayFor(100))
+}
+
+func TestRedeliveryConfigValidation(t *testing.T) {
+	assert.Error(t, (&config.QueueRedeliveryConfig{}).Validate())
+	assert.Error(t, (&config.QueueRedeliveryConfig{
+		InitialDelay: time.Minute, MaxDelay: time.Second,
+	}).Validate())
+}
@@ -0,0 +1,176 @@
+package queuetest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	queueschema "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/schema"
+	v1queueschema "github.com/babylonlabs-io/staking-api-service/internal/v1/queue/schema"
+	v2queueschema "github.com/babylonlabs-io/staking-api-service/internal/v2/queue/schema"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func marshal(t *testing.T, event any) string {
+	jsonBytes, err := json.Marshal(event)
+	require.NoError(t, err)
+	return string(jsonBytes)
+}
+
+func TestEventsMatchTheirQueueSchema(t *testing.T) {
+	testCases := []struct {
+		queueName string
+		event     any
+	}{
+		{client.ActiveStakingQueueName, client.NewActiveStakingEvent(
+			"hash", "staker", "fp", math.MaxUint64, 1, 1, 1, 0, "tx", false,
+		)},
+		{client.UnbondingStakingQueueName, client.NewUnbondingStakingEvent(
+			"hash", 1, 1, 1, 0, "tx", "txhash",
+		)},
+		{client.WithdrawStakingQueueName, client.NewWithdrawStakingEvent("hash")},
+		{client.WithdrawStakingQueueName, v1queueschema.NewWithdrawStakingEvent(
+			"hash", "withdrawhash", "tx", 0, 1,
+		)},
+		{client.ExpiredStakingQueueName, client.NewExpiredStakingEvent("hash", "active")},
+		{client.StakingStatsQueueName, client.NewStatsEvent(
+			"hash", "staker", "fp", 1, "active", true,
+		)},
+		{client.BtcInfoQueueName, client.NewBtcInfoEvent(1, 2, 3)},
+		{v1queueschema.BtcReorgQueueName, v1queueschema.NewBtcReorgEvent(
+			"blockhash", 1, []string{"hash"},
+		)},
+		{v2queueschema.VerifiedStakingQueueName, v2queueschema.NewVerifiedStakingEvent("hash")},
+		{v2queueschema.PendingStakingQueueName, v2queueschema.NewPendingStakingEvent("hash")},
+		{v2queueschema.CovenantSignatureQueueName, v2queueschema.NewCovenantSignatureEvent(
+			"hash", "covenantpk", "signature",
+		)},
+		{v2queueschema.SlashedStakingQueueName, v2queueschema.NewSlashedStakingEvent(
+			"hash", "staker", []string{"fp"}, 1,
+		)},
+	}
+	for _, tc := range testCases {
+		assert.True(t, queueschema.HasSchema(tc.queueName), tc.queueName)
+		_, err := queueschema.ValidateMessage(tc.queueName, marshal(t, tc.event))
+		assert.NoError(t, err, tc.queueName)
+	}
+}
+
+func TestMessagesWithoutSchemaVersionUseTheFirstVersion(t *testing.T) {
+	// The stats events emitted before the overflow flag was added
+	message := `{"event_type":5,"staking_tx_hash_hex":"hash","staker_pk_hex":"staker",` +
+		`"finality_provider_pk_hex":"fp","staking_value":1,"state":"active"}`
+	version, err := queueschema.ValidateMessage(client.StakingStatsQueueName, message)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, version)
+
+	// The overflow flag is required from the version 1
+	var event map[string]any
+	require.NoError(t, json.Unmarshal([]byte(message), &event))
+	event["schema_version"] = 1
+	_, err = queueschema.ValidateMessage(client.StakingStatsQueueName, marshal(t, event))
+	assert.Error(t, err)
+}
+
+func TestMalformedMessagesViolateTheSchema(t *testing.T) {
+	testCases := []struct {
+		name    string
+		message string
+	}{
+		{"not json", `a rubbish message`},
+		{"not an object", `"a rubbish message"`},
+		{"trailing data", `{"event_type":3,"staking_tx_hash_hex":"hash"} {}`},
+		{"unknown version", `{"schema_version":7,"event_type":3,"staking_tx_hash_hex":"hash"}`},
+		{"invalid version", `{"schema_version":"1","event_type":3,"staking_tx_hash_hex":"hash"}`},
+		{"wrong event type", `{"event_type":4,"staking_tx_hash_hex":"hash"}`},
+		{"missing field", `{"event_type":3}`},
+		{"empty hash", `{"event_type":3,"staking_tx_hash_hex":""}`},
+		{"wrong type", `{"event_type":3,"staking_tx_hash_hex":1}`},
+		{"negative amount", `{"schema_version":1,"event_type":3,"staking_tx_hash_hex":"hash","withdrawal_output_index":-1}`},
+		{"fractional amount", `{"schema_version":1,"event_type":3,"staking_tx_hash_hex":"hash","withdrawal_output_index":1.5}`},
+	}
+	for _, tc := range testCases {
+		_, err := queueschema.ValidateMessage(client.WithdrawStakingQueueName, tc.message)
+		var violation *queueschema.SchemaViolationError
+		if assert.True(t, errors.As(err, &violation), tc.name) {
+			assert.Equal(t, client.WithdrawStakingQueueName, violation.QueueName)
+			assert.NotEmpty(t, violation.Reason, tc.name)
+		}
+	}
+}
+
+func TestArrayItemsAreValidated(t *testing.T) {
+	_, err := queueschema.ValidateMessage(
+		v1queueschema.BtcReorgQueueName,
+		`{"event_type":8,"block_hash":"blockhash","block_height":1,"staking_tx_hash_hexes":["hash",1]}`,
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "staking_tx_hash_hexes[1]")
+}
+
+func TestBtcInfoBabylonHeightIsOptional(t *testing.T) {
+	message := `{"event_type":6,"height":1,"confirmed_tvl":2,"unconfirmed_tvl":3}`
+	_, err := queueschema.ValidateMessage(client.BtcInfoQueueName, message)
+	assert.NoError(t, err)
+
+	message = `{"event_type":6,"height":1,"confirmed_tvl":2,"unconfirmed_tvl":3,"babylon_height":4}`
+	_, err = queueschema.ValidateMessage(client.BtcInfoQueueName, message)
+	assert.NoError(t, err)
+
+	message = `{"event_type":6,"height":1,"confirmed_tvl":2,"unconfirmed_tvl":3,"babylon_height":-4}`
+	_, err = queueschema.ValidateMessage(client.BtcInfoQueueName, message)
+	assert.Error(t, err)
+}
+
+func TestQueuesWithoutSchemaAreNotValidated(t *testing.T) {
+	assert.False(t, queueschema.HasSchema("unknown_queue"))
+	_, err := queueschema.ValidateMessage("unknown_queue", "a rubbish message")
+	assert.NoError(t, err)
+}
+
+func TestProducedVersionsAreTheLatestSchemaVersions(t *testing.T) {
+	testCases := []struct {
+		queueName string
+		version   int
+	}{
+		{client.ActiveStakingQueueName, client.ActiveEventVersion},
+		{client.UnbondingStakingQueueName, client.UnbondingEventVersion},
+		{client.WithdrawStakingQueueName, v1queueschema.WithdrawStakingEventVersion},
+		{client.ExpiredStakingQueueName, client.ExpiredEventVersion},
+		{client.StakingStatsQueueName, client.StatsEventVersion},
+		{client.BtcInfoQueueName, client.BtcInfoEventVersion},
+		{v1queueschema.BtcReorgQueueName, v1queueschema.BtcReorgEventVersion},
+		{v2queueschema.VerifiedStakingQueueName, v2queueschema.VerifiedEventVersion},
+		{v2queueschema.PendingStakingQueueName, v2queueschema.PendingEventVersion},
+		{v2queueschema.CovenantSignatureQueueName, v2queueschema.CovenantSignatureEventVersion},
+		{v2queueschema.SlashedStakingQueueName, v2queueschema.SlashedStakingEventVersion},
+	}
+	for _, tc := range testCases {
+		latest, ok := queueschema.LatestVersion(tc.queueName)
+		assert.True(t, ok, tc.queueName)
+		assert.Equal(t, tc.version, latest, tc.queueName)
+	}
+}
+
+func TestLatestAndPreviousSchemaVersionsAreRead(t *testing.T) {
+	assert.Equal(t, []int{0, 1}, queueschema.SupportedVersions(client.WithdrawStakingQueueName))
+	assert.Equal(t, []int{0}, queueschema.SupportedVersions(client.ActiveStakingQueueName))
+
+	for _, version := range []int{0, 1} {
+		message := fmt.Sprintf(`{"schema_version":%d,"event_type":3,"staking_tx_hash_hex":"hash"}`, version)
+		read, err := queueschema.ValidateMessage(client.WithdrawStakingQueueName, message)
+		assert.NoError(t, err)
+		assert.Equal(t, version, read)
+	}
+
+	read, err := queueschema.ValidateMessage(
+		client.WithdrawStakingQueueName, `{"schema_version":2,"event_type":3,"staking_tx_hash_hex":"hash"}`,
+	)
+	assert.Equal(t, 2, read)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "newer than the supported versions [0 1]")
+}
@@ -0,0 +1,129 @@
+package queuetest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	queueclient "github.com/babylonlabs-io/staking-api-service/internal/shared/queue/client"
+	"github.com/babylonlabs-io/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// sendingQueueClient records the messages sent into its queue
+type sendingQueueClient struct {
+	*fakeQueueClient
+	sentMu sync.Mutex
+	sent   []string
+}
+
+func (s *sendingQueueClient) SendMessage(ctx context.Context, messageBody string) error {
+	s.sentMu.Lock()
+	defer s.sentMu.Unlock()
+	s.sent = append(s.sent, messageBody)
+	return nil
+}
+
+func TestMirroringQueueClientCopiesFirstDeliveries(t *testing.T) {
+	inner := newFakeQueueClient()
+	mirror := &sendingQueueClient{fakeQueueClient: newFakeQueueClient()}
+	mirroring := queueclient.NewMirroringQueueClient(inner, mirror)
+
+	messages, err := mirroring.ReceiveMessages()
+	require.NoError(t, err)
+
+	inner.msgs <- client.QueueMessage{Body: "first", Receipt: "1"}
+	assert.Equal(t, "first", (<-messages).Body)
+	inner.msgs <- client.QueueMessage{Body: "redelivered", Receipt: "2", RetryAttempts: 1}
+	assert.Equal(t, "redelivered", (<-messages).Body)
+
+	assert.Equal(t, []string{"first"}, mirror.sent)
+
+	require.NoError(t, mirroring.Stop())
+	assert.True(t, mirror.stopped)
+	_, open := <-messages
+	assert.False(t, open)
+}
+
+func TestShadowQueueClientReportsProcessedMessages(t *testing.T) {
+	inner := newFakeQueueClient()
+	processed := make(chan string, 2)
+	shadow := queueclient.NewShadowQueueClient(inner, "active_staking_queue", func(messageBody string) {
+		processed <- messageBody
+	})
+	assert.Equal(t, "active_staking_queue", shadow.GetQueueName())
+
+	messages, err := shadow.ReceiveMessages()
+	require.NoError(t, err)
+
+	inner.msgs <- client.QueueMessage{Body: "requeued", Receipt: "1"}
+	message := <-messages
+	require.NoError(t, shadow.ReQueueMessage(context.Background(), message))
+
+	inner.msgs <- client.QueueMessage{Body: "processed", Receipt: "2"}
+	message = <-messages
+	require.NoError(t, shadow.DeleteMessage(message.Receipt))
+
+	select {
+	case body := <-processed:
+		assert.Equal(t, "processed", body)
+	case <-time.After(time.Second):
+		t.Fatal("processed message not reported")
+	}
+	assert.Empty(t, processed)
+}
+
+func TestDiffShadowDocuments(t *testing.T) {
+	sandbox := bson.M{"_id": "tx", "state": "unbonded", "value": int64(1), "updated_at": int64(2)}
+	primary := bson.M{"_id": "tx", "state": "active", "value": int64(1), "updated_at": int64(3)}
+	ignored := map[string]bool{"updated_at": true}
+
+	assert.Equal(t, []string{"state"}, queueclient.DiffShadowDocuments(sandbox, primary, ignored))
+	assert.Empty(t, queueclient.DiffShadowDocuments(primary, primary, nil))
+	assert.Empty(t, queueclient.DiffShadowDocuments(nil, nil, nil))
+	assert.Equal(
+		t, []string{"_id", "state", "value"},
+		queueclient.DiffShadowDocuments(sandbox, nil, ignored),
+	)
+}
+
+func TestShadowConfigValidation(t *testing.T) {
+	stakingDb := &config.DbConfig{Address: "mongodb://localhost:27017", DbName: "staking-api-sandbox"}
+
+	cfg := &config.ShadowConfig{Role: config.ShadowRoleMirror, QueueSuffix: "_shadow"}
+	assert.NoError(t, cfg.Validate(stakingDb))
+	assert.Equal(t, "active_staking_queue_shadow", cfg.QueueName("active_staking_queue"))
+	assert.True(t, cfg.IsMirror())
+	assert.False(t, cfg.IsShadow())
+
+	cfg = &config.ShadowConfig{Role: "replica", QueueSuffix: "_shadow"}
+	assert.Error(t, cfg.Validate(stakingDb))
+	cfg = &config.ShadowConfig{Role: config.ShadowRoleShadow}
+	assert.Error(t, cfg.Validate(stakingDb))
+
+	primaryDb := &config.DbConfig{
+		Username: "user", Password: "password", Address: "mongodb://localhost:27018",
+		DbName: stakingDb.DbName, MaxPaginationLimit: 10, DbBatchSizeLimit: 100,
+	}
+	cfg = &config.ShadowConfig{
+		Role:                config.ShadowRoleShadow,
+		QueueSuffix:         "_shadow",
+		PrimaryDb:           primaryDb,
+		CompareDelay:        time.Minute,
+		ComparedCollections: []string{"delegations"},
+	}
+	assert.NoError(t, cfg.Validate(stakingDb))
+	assert.True(t, cfg.IsShadow())
+
+	// The shadow deployment must not compare against its own db
+	primaryDb.Address = stakingDb.Address
+	assert.ErrorContains(t, cfg.Validate(stakingDb), "must differ from the staking db")
+
+	var disabled *config.ShadowConfig
+	assert.False(t, disabled.IsShadow())
+	assert.False(t, disabled.IsMirror())
+}
@@ -0,0 +1,170 @@
+package schedulertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/scheduler"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeElector leads the jobs of its leaders set
+type fakeElector struct {
+	leaders map[string]bool
+}
+
+func (e *fakeElector) Campaign(ctx context.Context, job string) {}
+
+func (e *fakeElector) IsLeader(job string) bool {
+	return e.leaders[job]
+}
+
+func (e *fakeElector) Holder() string {
+	return "replica-a"
+}
+
+func TestSchedulerRecordsTheJobRuns(t *testing.T) {
+	metrics.Init(0)
+	clk := testutils.NewFakeClock(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	startedAt := clk.Now()
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("StartScheduledJobRun", mock.Anything, "ok", "replica-a",
+		dbmodel.ScheduledJobTriggerSchedule, startedAt).Return(nil).Once()
+	mockDBClient.On("CompleteScheduledJobRun", mock.Anything, "ok", nil,
+		startedAt.Add(2*time.Second), 2*time.Second).Return(nil).Once()
+	runErr := errors.New("db unavailable")
+	mockDBClient.On("StartScheduledJobRun", mock.Anything, "failing", "replica-a",
+		dbmodel.ScheduledJobTriggerSchedule, mock.Anything).Return(nil).Once()
+	// The failure to record the outcome does not fail the scheduler
+	mockDBClient.On("CompleteScheduledJobRun", mock.Anything, "failing", runErr,
+		mock.Anything, time.Duration(0)).Return(errors.New("write conflict")).Once()
+	s, err := scheduler.New(mockDBClient, clk, &fakeElector{leaders: map[string]bool{"ok": true, "failing": true}})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Add(scheduler.Job{
+		Name: "ok", Schedule: "@every 1h", RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			clk.Advance(2 * time.Second)
+			return nil
+		},
+	}))
+	require.NoError(t, s.Add(scheduler.Job{
+		Name: "failing", Schedule: "0 * * * *", RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			return runErr
+		},
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+}
+
+func TestSchedulerSkipsTheJobsLedByAnotherReplica(t *testing.T) {
+	metrics.Init(0)
+	mockDBClient := mocks.NewDBClient(t)
+	s, err := scheduler.New(mockDBClient, testutils.NewFakeClock(time.Now()), &fakeElector{})
+	require.NoError(t, err)
+
+	ran := false
+	require.NoError(t, s.Add(scheduler.Job{
+		Name: "job", Schedule: "@every 1h", RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			ran = true
+			return nil
+		},
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	assert.False(t, ran)
+	assert.ErrorIs(t, s.Trigger("job"), scheduler.ErrNotLeader)
+	mockDBClient.AssertNotCalled(t, "StartScheduledJobRun",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSchedulerJobsStatus(t *testing.T) {
+	lastRun := dbmodel.ScheduledJobDocument{
+		Name: "job", Holder: "replica-b", LastOutcome: dbmodel.ScheduledJobSucceeded, SuccessCount: 3, FailureCount: 1,
+	}
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("FindScheduledJobRuns", mock.Anything).
+		Return([]dbmodel.ScheduledJobDocument{lastRun, {Name: "removed_job"}}, nil).Once()
+	s, err := scheduler.New(mockDBClient, testutils.NewFakeClock(time.Now()), &fakeElector{})
+	require.NoError(t, err)
+	require.NoError(t, s.Add(scheduler.Job{Name: "job", Schedule: "@every 1h"}))
+	require.NoError(t, s.Add(scheduler.Job{Name: "new_job", Schedule: "@every 1m"}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	jobs, err := s.Jobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+	assert.Equal(t, "job", jobs[0].Name)
+	assert.False(t, jobs[0].Leader)
+	assert.Equal(t, &lastRun, jobs[0].LastRun)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), jobs[0].NextRunAt, time.Minute)
+	// The jobs which never ran have no last run
+	assert.Equal(t, "new_job", jobs[1].Name)
+	assert.Nil(t, jobs[1].LastRun)
+	// The jobs not registered on this replica are listed with their last run
+	assert.Equal(t, scheduler.JobStatus{
+		Name: "removed_job", LastRun: &dbmodel.ScheduledJobDocument{Name: "removed_job"},
+	}, jobs[2])
+}
+
+func TestSchedulerTrigger(t *testing.T) {
+	metrics.Init(0)
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("StartScheduledJobRun", mock.Anything, "job", "replica-a",
+		dbmodel.ScheduledJobTriggerManual, mock.Anything).Return(nil).Once()
+	finished := make(chan struct{})
+	mockDBClient.On("CompleteScheduledJobRun", mock.Anything, "job", nil, mock.Anything, mock.Anything).
+		Return(nil).Once().Run(func(mock.Arguments) { close(finished) })
+	s, err := scheduler.New(mockDBClient, testutils.NewFakeClock(time.Now()), &fakeElector{leaders: map[string]bool{"job": true}})
+	require.NoError(t, err)
+	release := make(chan struct{})
+	require.NoError(t, s.Add(scheduler.Job{
+		Name: "job", Schedule: "@every 1h",
+		Run: func(ctx context.Context) error {
+			<-release
+			return nil
+		},
+	}))
+	assert.ErrorIs(t, s.Trigger("job"), scheduler.ErrNotStarted)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	assert.ErrorIs(t, s.Trigger("unknown"), scheduler.ErrUnknownJob)
+	assert.NoError(t, s.CanTrigger("job"))
+	require.NoError(t, s.Trigger("job"))
+	// The job can not be triggered again while running
+	assert.ErrorIs(t, s.Trigger("job"), scheduler.ErrAlreadyRunning)
+	assert.ErrorIs(t, s.CanTrigger("job"), scheduler.ErrAlreadyRunning)
+
+	close(release)
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("the triggered run did not complete")
+	}
+}
+
+func TestSchedulerRejectsInvalidSchedules(t *testing.T) {
+	s, err := scheduler.New(mocks.NewDBClient(t), testutils.NewFakeClock(time.Now()), nil)
+	require.NoError(t, err)
+
+	err = s.Add(scheduler.Job{Name: "job", Schedule: "every hour"})
+	assert.ErrorContains(t, err, "invalid schedule of job job")
+	assert.NoError(t, s.Add(scheduler.Job{Name: "job", Schedule: "*/5 * * * *"}))
+}
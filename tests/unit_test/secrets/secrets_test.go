@@ -0,0 +1,80 @@
+package secretstest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider map[string]string
+
+func (p fakeProvider) GetSecret(ctx context.Context, ref string) (string, error) {
+	value, ok := p[ref]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return value, nil
+}
+
+func TestIsReference(t *testing.T) {
+	assert.True(t, secrets.IsReference("aws-sm://staking-api/mongo#password"))
+	assert.True(t, secrets.IsReference("gcp-sm://projects/p/secrets/s/versions/latest"))
+	assert.True(t, secrets.IsReference("vault://secret/data/staking-api#password"))
+	assert.False(t, secrets.IsReference("mongodb://localhost:27017"))
+	assert.False(t, secrets.IsReference("password"))
+}
+
+func TestResolveJsonKey(t *testing.T) {
+	resolver := secrets.NewResolver(secrets.Options{Timeout: time.Second}).
+		WithProvider(secrets.AwsSecretsManagerScheme, fakeProvider{
+			"staking-api/mongo": `{"username":"staking","password":"s3cret","port":27017}`,
+			"staking-api/token": "plain-token",
+		})
+
+	value, err := resolver.Resolve(context.Background(), "aws-sm://staking-api/mongo#password")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	value, err = resolver.Resolve(context.Background(), "aws-sm://staking-api/mongo#port")
+	require.NoError(t, err)
+	assert.Equal(t, "27017", value)
+
+	value, err = resolver.Resolve(context.Background(), "aws-sm://staking-api/token")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-token", value)
+
+	_, err = resolver.Resolve(context.Background(), "aws-sm://staking-api/mongo#missing")
+	assert.Error(t, err)
+	_, err = resolver.Resolve(context.Background(), "aws-sm://staking-api/token#password")
+	assert.Error(t, err)
+	_, err = resolver.Resolve(context.Background(), "aws-sm://unknown")
+	assert.Error(t, err)
+}
+
+func TestResolveVaultKvV2Secret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" || r.URL.Path != "/v1/secret/data/staking-api" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"queue_password":"rabbit"},"metadata":{"version":3}}}`))
+	}))
+	defer server.Close()
+
+	resolver := secrets.NewResolver(secrets.Options{
+		VaultAddress: server.URL, VaultToken: "vault-token", Timeout: time.Second,
+	})
+	value, err := resolver.Resolve(context.Background(), "vault://secret/data/staking-api#queue_password")
+	require.NoError(t, err)
+	assert.Equal(t, "rabbit", value)
+
+	_, err = resolver.Resolve(context.Background(), "vault://secret/data/other#queue_password")
+	assert.Error(t, err)
+}
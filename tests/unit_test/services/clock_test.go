@@ -0,0 +1,46 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializedOverallStatsStalenessFollowsTheClock(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	fakeClock := testutils.NewFakeClock(now)
+
+	materialized := &v1dbmodel.MaterializedOverallStatsDocument{
+		OverallStatsDocument: v1dbmodel.OverallStatsDocument{TotalTvl: 100},
+		LastRefreshedAt:      now.Unix(),
+	}
+	shards := &v1dbmodel.OverallStatsDocument{TotalTvl: 200}
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetMaterializedOverallStats", mock.Anything).Return(materialized, nil)
+	mockV1DBClient.On("GetOverallStats", mock.Anything).Return(shards, nil).Once()
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(nil, &db.NotFoundError{})
+
+	cfg := &config.Config{StatsRefresher: &config.StatsRefresherConfig{
+		Interval: time.Minute, MaxStaleness: 5 * time.Minute,
+	}}
+	service := newTestV1Service(t, testServiceDeps{cfg: cfg, v1DB: mockV1DBClient})
+	service.Service.Clock = fakeClock
+
+	stats, svcErr := service.GetOverallStats(context.Background())
+	require.Nil(t, svcErr)
+	assert.Equal(t, int64(100), stats.TotalTvl, "fresh materialized stats should be served")
+
+	fakeClock.Advance(5*time.Minute + time.Second)
+	stats, svcErr = service.GetOverallStats(context.Background())
+	require.Nil(t, svcErr)
+	assert.Equal(t, int64(200), stats.TotalTvl, "stale materialized stats should fall back to the shards")
+}
@@ -0,0 +1,66 @@
+package servicestest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	indexertypes "github.com/babylonlabs-io/staking-api-service/internal/indexer/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func covenantQuorumTestIndexer(t *testing.T) *mocks.IndexerDBClient {
+	mockIndexerDBClient := mocks.NewIndexerDBClient(t)
+	mockIndexerDBClient.On("GetDelegation", mock.Anything, "staking-tx").
+		Return(&indexerdbmodel.IndexerDelegationDetails{
+			StakingTxHashHex: "staking-tx",
+			ParamsVersion:    1,
+			State:            indexertypes.StateVerified,
+		}, nil)
+	mockIndexerDBClient.On("GetBbnStakingParams", mock.Anything).Return([]*indexertypes.BbnStakingParams{{
+		Version:        1,
+		CovenantPks:    []string{"covenant-1", "covenant-2"},
+		CovenantQuorum: 2,
+	}}, nil)
+	return mockIndexerDBClient
+}
+
+func TestGetDelegationReportsCovenantQuorum(t *testing.T) {
+	mockV2DBClient := mocks.NewV2DBClient(t)
+	mockV2DBClient.On("FindCovenantSignatures", mock.Anything, []string{"staking-tx"}).
+		Return([]*v2dbmodel.V2CovenantSignaturesDocument{{
+			StakingTxHashHex:  "staking-tx",
+			CovenantBtcPksHex: []string{"covenant-1", "unknown"},
+		}}, nil)
+	service := newTestV2Service(t, testServiceDeps{
+		v2DB: mockV2DBClient, indexerDB: covenantQuorumTestIndexer(t),
+	})
+
+	delegation, svcErr := service.GetDelegation(context.Background(), "staking-tx")
+	require.Nil(t, svcErr)
+	assert.Equal(t, &v2service.CovenantQuorumPublic{
+		CollectedSignatures: 1,
+		RequiredSignatures:  2,
+		CovenantBtcPksHex:   []string{"covenant-1"},
+	}, delegation.CovenantQuorum)
+}
+
+func TestGetDelegationOmitsCovenantQuorumOnFailure(t *testing.T) {
+	mockV2DBClient := mocks.NewV2DBClient(t)
+	mockV2DBClient.On("FindCovenantSignatures", mock.Anything, []string{"staking-tx"}).
+		Return(nil, errors.New("connection reset"))
+	service := newTestV2Service(t, testServiceDeps{
+		v2DB: mockV2DBClient, indexerDB: covenantQuorumTestIndexer(t),
+	})
+
+	delegation, svcErr := service.GetDelegation(context.Background(), "staking-tx")
+	require.Nil(t, svcErr)
+	assert.Equal(t, "staking-tx", delegation.DelegationStaking.StakingTxHashHex)
+	assert.Nil(t, delegation.CovenantQuorum)
+}
@@ -0,0 +1,70 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var delegationArchiveTestConfig = &config.Config{DelegationArchive: &config.DelegationArchiveConfig{
+	Interval:  time.Hour,
+	MinAge:    30 * 24 * time.Hour,
+	BatchSize: 2,
+}}
+
+func TestArchiveDelegationsInBatches(t *testing.T) {
+	now := time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)
+	updatedBefore := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).Unix()
+	terminalStates := []types.DelegationState{types.Withdrawn}
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("ArchiveDelegations", mock.Anything, terminalStates, updatedBefore, int64(2)).
+		Return(int64(2), nil).Twice()
+	mockV1DBClient.On("ArchiveDelegations", mock.Anything, terminalStates, updatedBefore, int64(2)).
+		Return(int64(1), nil).Once()
+	service := newTestV1Service(t, testServiceDeps{
+		cfg: delegationArchiveTestConfig, v1DB: mockV1DBClient, now: now,
+	})
+
+	archived, svcErr := service.ArchiveDelegations(context.Background())
+	require.Nil(t, svcErr)
+	assert.Equal(t, int64(5), archived)
+}
+
+func TestIsDelegationPresentChecksArchive(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("DelegationExists", mock.Anything, mock.Anything).Return(false, nil)
+	mockV1DBClient.On("ArchivedDelegationExists", mock.Anything, "archived").Return(true, nil).Once()
+	mockV1DBClient.On("ArchivedDelegationExists", mock.Anything, "unknown").Return(false, nil).Once()
+	service := newTestV1Service(t, testServiceDeps{
+		cfg: delegationArchiveTestConfig, v1DB: mockV1DBClient, now: time.Now(),
+	})
+
+	// The replayed events of an archived delegation must not save it again
+	present, svcErr := service.IsDelegationPresent(context.Background(), "archived")
+	require.Nil(t, svcErr)
+	assert.True(t, present)
+
+	present, svcErr = service.IsDelegationPresent(context.Background(), "unknown")
+	require.Nil(t, svcErr)
+	assert.False(t, present)
+}
+
+func TestDelegationExistsChecksArchive(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("DelegationExists", mock.Anything, "archived").Return(false, nil)
+	mockV1DBClient.On("ArchivedDelegationExists", mock.Anything, "archived").Return(true, nil).Once()
+	service := newTestV1Service(t, testServiceDeps{
+		cfg: delegationArchiveTestConfig, v1DB: mockV1DBClient, now: time.Now(),
+	})
+
+	exists, svcErr := service.DelegationExists(context.Background(), "archived")
+	require.Nil(t, svcErr)
+	assert.True(t, exists)
+}
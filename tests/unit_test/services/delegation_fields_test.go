@@ -0,0 +1,40 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEveryDelegationFieldIsProjected checks that each field which can be
+// requested is fetched from the delegation documents, it would otherwise be
+// returned empty
+func TestEveryDelegationFieldIsProjected(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(nil, &db.NotFoundError{})
+	var projected []string
+	mockV1DBClient.On("FindDelegationsByStakerPk", mock.Anything, "staker", mock.Anything, "").
+		Run(func(args mock.Arguments) {
+			projected = args.Get(2).(*v1dbclient.DelegationFilter).Fields
+		}).
+		Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{}, nil)
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	for _, field := range utils.JSONFieldNames(v1service.DelegationPublic{}) {
+		projected = nil
+		_, _, svcErr := service.DelegationsByStakerPk(
+			context.Background(), "staker", "", nil, []string{field}, "",
+		)
+		require.Nil(t, svcErr)
+		assert.NotEmpty(t, projected, "field %s is not projected", field)
+	}
+}
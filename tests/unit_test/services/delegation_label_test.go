@@ -0,0 +1,77 @@
+package servicestest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDelegationLabelsOfUnknownDelegation(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("DelegationExists", mock.Anything, "staking-tx-hash").Return(false, nil).Once()
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	_, err := service.SetDelegationLabels(context.Background(), "custodian", "staking-tx-hash", []string{"customer-123"})
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	mockV1DBClient.AssertNotCalled(t, "SetDelegationLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDelegationLabelsAreScopedToTheApiKey(t *testing.T) {
+	labels := []string{"customer-123"}
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("DelegationExists", mock.Anything, "staking-tx-hash").Return(true, nil).Once()
+	mockV1DBClient.On("SetDelegationLabels", mock.Anything, "custodian", "staking-tx-hash", labels).Return(nil).Once()
+	mockV1DBClient.On("FindDelegationLabels", mock.Anything, "custodian", "staking-tx-hash").
+		Return(&v1dbmodel.DelegationLabelsDocument{
+			Id:               v1dbmodel.BuildDelegationLabelsId("custodian", "staking-tx-hash"),
+			ApiKey:           "custodian",
+			StakingTxHashHex: "staking-tx-hash",
+			Labels:           labels,
+		}, nil).Once()
+	mockV1DBClient.On("FindDelegationLabels", mock.Anything, "other", "staking-tx-hash").
+		Return(nil, &db.NotFoundError{Key: "staking-tx-hash"}).Once()
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	set, err := service.SetDelegationLabels(context.Background(), "custodian", "staking-tx-hash", labels)
+	require.Nil(t, err)
+	assert.Equal(t, labels, set.Labels)
+
+	// Another api key doesn't see the labels
+	other, err := service.GetDelegationLabels(context.Background(), "other", "staking-tx-hash")
+	require.Nil(t, err)
+	assert.Equal(t, "staking-tx-hash", other.StakingTxHashHex)
+	assert.Empty(t, other.Labels)
+}
+
+func TestGetDelegationsByLabel(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindDelegationLabelsByLabel", mock.Anything, "custodian", "customer-123", "").
+		Return(&db.DbResultMap[v1dbmodel.DelegationLabelsDocument]{
+			Data: []v1dbmodel.DelegationLabelsDocument{
+				{StakingTxHashHex: "a", Labels: []string{"customer-123"}},
+				{StakingTxHashHex: "b", Labels: []string{"customer-123", "cold"}},
+			},
+			PaginationToken: "next",
+		}, nil).Once()
+	mockV1DBClient.On("FindDelegationLabelsByLabel", mock.Anything, "custodian", "customer-123", "invalid").
+		Return(nil, &db.InvalidPaginationTokenError{Message: "Invalid pagination token"}).Once()
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	delegations, next, err := service.GetDelegationsByLabel(context.Background(), "custodian", "customer-123", "")
+	require.Nil(t, err)
+	require.Len(t, delegations, 2)
+	assert.Equal(t, "b", delegations[1].StakingTxHashHex)
+	assert.Equal(t, "next", next)
+
+	_, _, err = service.GetDelegationsByLabel(context.Background(), "custodian", "customer-123", "invalid")
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+}
@@ -0,0 +1,28 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDelegationsInBatches(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("MigrateDelegations", mock.Anything, int64(2)).Return(int64(2), nil).Once()
+	mockV1DBClient.On("MigrateDelegations", mock.Anything, int64(2)).Return(int64(0), nil).Once()
+	cfg := &config.Config{DelegationMigration: &config.DelegationMigrationConfig{
+		Interval:  time.Hour,
+		BatchSize: 2,
+	}}
+	service := newTestV1Service(t, testServiceDeps{cfg: cfg, v1DB: mockV1DBClient})
+
+	migrated, svcErr := service.MigrateDelegations(context.Background())
+	require.Nil(t, svcErr)
+	assert.Equal(t, int64(2), migrated)
+}
@@ -0,0 +1,122 @@
+package servicestest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	indexertypes "github.com/babylonlabs-io/staking-api-service/internal/indexer/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
+	v2types "github.com/babylonlabs-io/staking-api-service/internal/v2/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDelegationsPagesThroughBothProtocolVersions(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindDelegationsByStakerPk", mock.Anything, "staker", mock.Anything, "").
+		Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{
+			Data: []v1dbmodel.DelegationDocument{{
+				StakingTxHashHex:      "phase1-tx",
+				StakerPkHex:           "staker",
+				FinalityProviderPkHex: "fp",
+				StakingValue:          1000,
+				State:                 types.Active,
+				StakingTx:             &v1dbmodel.TimelockTransaction{TxHex: "phase1-tx-hex", StartHeight: 100, TimeLock: 64000},
+			}},
+			PaginationToken: "v1-next",
+		}, nil).Once()
+	mockV1DBClient.On("FindDelegationsByStakerPk", mock.Anything, "staker", mock.Anything, "v1-next").
+		Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{}, nil).Once()
+	mockIndexerDBClient := mocks.NewIndexerDBClient(t)
+	mockIndexerDBClient.On("GetRegisteredStakingTxHashes", mock.Anything, []string{"phase1-tx"}).
+		Return(map[string]bool{}, nil).Once()
+	mockIndexerDBClient.On("GetRegisteredStakingTxHashes", mock.Anything, []string{}).
+		Return(map[string]bool{}, nil).Once()
+	mockIndexerDBClient.On("GetDelegations", mock.Anything, "staker", mock.Anything, "").
+		Return(&db.DbResultMap[indexerdbmodel.IndexerDelegationDetails]{
+			Data: []indexerdbmodel.IndexerDelegationDetails{{
+				StakingTxHashHex:          "phase2-tx",
+				StakingTxHex:              "phase2-tx-hex",
+				StakerBtcPkHex:            "staker",
+				FinalityProviderBtcPksHex: []string{"fp"},
+				StakingAmount:             2000,
+				StakingTime:               150,
+				StartHeight:               200,
+				State:                     indexertypes.StateActive,
+			}},
+		}, nil).Once()
+	service := newTestV2Service(t, testServiceDeps{v1DB: mockV1DBClient, indexerDB: mockIndexerDBClient})
+
+	// The phase-1 delegations come first
+	page, token, err := service.GetUnifiedDelegations(context.Background(), "staker", nil, "")
+	require.Nil(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, v2service.ProtocolVersionPhase1, page[0].ProtocolVersion)
+	assert.Equal(t, "phase1-tx", page[0].StakingTxHashHex)
+	assert.Equal(t, []string{"fp"}, page[0].FinalityProviderPksHex)
+	assert.Equal(t, uint64(1000), page[0].StakingAmount)
+	assert.Equal(t, v2types.StateActive, page[0].State)
+	require.NotEmpty(t, token)
+
+	// The last page of phase-1 is empty, so the phase-2 delegations are
+	// served right away
+	page, token, err = service.GetUnifiedDelegations(context.Background(), "staker", nil, token)
+	require.Nil(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, v2service.ProtocolVersionPhase2, page[0].ProtocolVersion)
+	assert.Equal(t, "phase2-tx", page[0].StakingTxHashHex)
+	assert.Equal(t, uint64(2000), page[0].StakingAmount)
+	assert.Equal(t, uint64(150), page[0].StakingTimelock)
+	assert.Equal(t, v2types.StateActive, page[0].State)
+	assert.Empty(t, token)
+}
+
+func TestUnifiedDelegationsListsRegisteredPhase1DelegationsOnce(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindDelegationsByStakerPk", mock.Anything, "staker", mock.Anything, "").
+		Return(&db.DbResultMap[v1dbmodel.DelegationDocument]{
+			Data: []v1dbmodel.DelegationDocument{
+				{
+					StakingTxHashHex: "registered-tx",
+					State:            types.Active,
+					StakingTx:        &v1dbmodel.TimelockTransaction{},
+				},
+				{
+					StakingTxHashHex: "unbonded-tx",
+					State:            types.Unbonded,
+					StakingTx:        &v1dbmodel.TimelockTransaction{},
+					UnbondingTx:      &v1dbmodel.TimelockTransaction{},
+				},
+			},
+		}, nil).Once()
+	mockIndexerDBClient := mocks.NewIndexerDBClient(t)
+	mockIndexerDBClient.On("GetRegisteredStakingTxHashes", mock.Anything, []string{"registered-tx", "unbonded-tx"}).
+		Return(map[string]bool{"registered-tx": true}, nil).Once()
+	service := newTestV2Service(t, testServiceDeps{v1DB: mockV1DBClient, indexerDB: mockIndexerDBClient})
+
+	// The registered delegation is left to the phase-2 pages, the states
+	// share the phase-2 vocabulary
+	page, token, err := service.GetUnifiedDelegations(context.Background(), "staker", nil, "")
+	require.Nil(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "unbonded-tx", page[0].StakingTxHashHex)
+	assert.Equal(t, v2types.StateEarlyUnbondingWithdrawable, page[0].State)
+	assert.NotEmpty(t, token)
+}
+
+func TestUnifiedDelegationsInvalidPaginationKey(t *testing.T) {
+	service := newTestV2Service(t, testServiceDeps{
+		v1DB: mocks.NewV1DBClient(t), indexerDB: mocks.NewIndexerDBClient(t),
+	})
+
+	_, _, err := service.GetUnifiedDelegations(context.Background(), "staker", nil, "not-a-token")
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+}
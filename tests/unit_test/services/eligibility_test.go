@@ -0,0 +1,125 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	eligibilityStakerPkHex = "staker"
+	eligibilityFpPkHex     = "fp"
+)
+
+// eligibilityTestDeps registers the finality provider of the tests along with
+// the given params versions
+func eligibilityTestDeps(
+	mockV1DBClient *mocks.V1DBClient, versions ...*types.VersionedGlobalParams,
+) testServiceDeps {
+	return testServiceDeps{
+		cfg:               &config.Config{Server: &config.ServerConfig{}},
+		params:            &types.GlobalParams{Versions: versions},
+		finalityProviders: []types.FinalityProviderDetails{{BtcPk: eligibilityFpPkHex}},
+		v1DB:              mockV1DBClient,
+	}
+}
+
+func eligibilityTestParams(version, activationHeight uint64) *types.VersionedGlobalParams {
+	return &types.VersionedGlobalParams{
+		Version:          version,
+		ActivationHeight: activationHeight,
+		StakingCap:       1000,
+		MinStakingAmount: 10,
+		MaxStakingAmount: 500,
+		MinStakingTime:   100,
+		MaxStakingTime:   1000,
+	}
+}
+
+func failedEligibilityChecks(report *v1service.StakingEligibilityPublic) []string {
+	var failed []string
+	for _, check := range report.Checks {
+		if !check.Passed {
+			failed = append(failed, check.Name)
+		}
+	}
+	return failed
+}
+
+func TestCheckStakingEligibility(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(
+		&v1dbmodel.BtcInfo{BtcHeight: 150, UnconfirmedTvl: 600}, nil,
+	)
+	service := newTestV1Service(t, eligibilityTestDeps(
+		mockV1DBClient, eligibilityTestParams(0, 100), eligibilityTestParams(1, 200),
+	))
+
+	report, svcErr := service.CheckStakingEligibility(
+		context.Background(), eligibilityStakerPkHex, eligibilityFpPkHex, 400, 500,
+	)
+	require.Nil(t, svcErr)
+	assert.True(t, report.Eligible)
+	assert.Equal(t, uint64(0), report.ParamsVersion)
+	assert.Len(t, report.Checks, 5)
+	assert.Empty(t, failedEligibilityChecks(report))
+}
+
+func TestCheckStakingEligibilityReportsEachFailure(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(
+		&v1dbmodel.BtcInfo{BtcHeight: 150, UnconfirmedTvl: 900}, nil,
+	)
+	service := newTestV1Service(t, eligibilityTestDeps(mockV1DBClient, eligibilityTestParams(0, 100)))
+
+	// The amount is over the max and the headroom, the time is under the min
+	// and the staker delegates to its own unregistered key
+	report, svcErr := service.CheckStakingEligibility(
+		context.Background(), eligibilityStakerPkHex, eligibilityStakerPkHex, 600, 50,
+	)
+	require.Nil(t, svcErr)
+	assert.False(t, report.Eligible)
+	assert.Equal(t, []string{
+		v1service.StakingTxCheckStakingTime,
+		v1service.StakingTxCheckStakingAmount,
+		v1service.EligibilityCheckStakingCap,
+		v1service.StakingTxCheckFinalityProvider,
+		v1service.EligibilityCheckStaker,
+	}, failedEligibilityChecks(report))
+	assert.Equal(t, "staking amount 600 exceeds the staking cap headroom 100", report.Checks[2].Message)
+}
+
+func TestCheckStakingEligibilityWithCapHeight(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(nil, &db.NotFoundError{}).Once()
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(
+		&v1dbmodel.BtcInfo{BtcHeight: 300}, nil,
+	).Once()
+	params := eligibilityTestParams(0, 100)
+	params.StakingCap = 0
+	params.CapHeight = 300
+	service := newTestV1Service(t, eligibilityTestDeps(mockV1DBClient, params))
+
+	// The latest params apply until the tip height is known
+	report, svcErr := service.CheckStakingEligibility(
+		context.Background(), eligibilityStakerPkHex, eligibilityFpPkHex, 400, 500,
+	)
+	require.Nil(t, svcErr)
+	assert.True(t, report.Eligible)
+
+	report, svcErr = service.CheckStakingEligibility(
+		context.Background(), eligibilityStakerPkHex, eligibilityFpPkHex, 400, 500,
+	)
+	require.Nil(t, svcErr)
+	assert.False(t, report.Eligible)
+	assert.Equal(t, []string{v1service.EligibilityCheckStakingCap}, failedEligibilityChecks(report))
+}
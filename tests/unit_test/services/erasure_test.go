@@ -0,0 +1,62 @@
+package servicestest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var erasureTestNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestEraseStakerDataRecordsErasure(t *testing.T) {
+	stakerPkHex := testutils.GeneratePks(1)[0]
+	stakerPkHash := sha256.Sum256([]byte(stakerPkHex))
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("RemoveStakerPartnerAttributions", mock.Anything, stakerPkHex).Return(int64(3), nil).Once()
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("DeletePkAddressMappings", mock.Anything, stakerPkHex).Return(int64(1), nil).Once()
+	mockDBClient.On("InsertErasureRecord", mock.Anything,
+		mock.MatchedBy(func(record *dbmodel.ErasureRecordDocument) bool {
+			// The record must not keep the public key of the staker
+			return record.StakerPkHash == hex.EncodeToString(stakerPkHash[:]) &&
+				record.PartnerAttributions == 3 && record.AddressMappings == 1 &&
+				record.RequestedBy == "10.0.0.1"
+		}),
+	).Return(nil).Once()
+	service := newTestV1Service(t, testServiceDeps{
+		sharedDB: mockDBClient, v1DB: mockV1DBClient, now: erasureTestNow,
+	})
+
+	erasure, svcErr := service.EraseStakerData(context.Background(), stakerPkHex, "10.0.0.1")
+	require.Nil(t, svcErr)
+	assert.NotEmpty(t, erasure.Id)
+	assert.Equal(t, int64(3), erasure.PartnerAttributions)
+	assert.Equal(t, int64(1), erasure.AddressMappings)
+	assert.Equal(t, "2024-05-01T12:00:00Z", erasure.ErasedAt)
+}
+
+func TestEraseStakerDataNotRecordedOnFailure(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("RemoveStakerPartnerAttributions", mock.Anything, "staker").
+		Return(int64(0), errors.New("db unavailable")).Once()
+	mockDBClient := mocks.NewDBClient(t)
+	service := newTestV1Service(t, testServiceDeps{
+		sharedDB: mockDBClient, v1DB: mockV1DBClient, now: erasureTestNow,
+	})
+
+	_, svcErr := service.EraseStakerData(context.Background(), "staker", "10.0.0.1")
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusInternalServerError, svcErr.StatusCode)
+	mockDBClient.AssertNotCalled(t, "InsertErasureRecord", mock.Anything, mock.Anything)
+}
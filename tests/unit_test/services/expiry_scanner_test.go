@@ -0,0 +1,68 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var expiryScannerTestConfig = &config.Config{
+	ExpiryScanner: &config.ExpiryScannerConfig{GraceBlocks: 6, BatchSize: 100},
+}
+
+func TestScanExpiredDelegationsTransitionsToUnbonded(t *testing.T) {
+	active := v1dbmodel.DelegationDocument{
+		StakingTxHashHex: "active", State: types.Active,
+		StakingTx: &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 50},
+	}
+	unbonding := v1dbmodel.DelegationDocument{
+		StakingTxHashHex: "unbonding", State: types.Unbonding,
+		StakingTx:   &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 1000},
+		UnbondingTx: &v1dbmodel.TimelockTransaction{StartHeight: 180, TimeLock: 10},
+	}
+	transitioned := v1dbmodel.DelegationDocument{
+		StakingTxHashHex: "transitioned", State: types.Active,
+		StakingTx: &v1dbmodel.TimelockTransaction{StartHeight: 100, TimeLock: 50},
+	}
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(&v1dbmodel.BtcInfo{BtcHeight: 200}, nil).Once()
+	// The delegations expired more than the grace blocks below the tip
+	mockV1DBClient.On("FindExpiredDelegations", mock.Anything, uint64(194), int64(100)).
+		Return([]v1dbmodel.DelegationDocument{active, unbonding, transitioned}, nil).Once()
+	mockV1DBClient.On("TransitionToUnbondedState", mock.Anything, "active",
+		utils.QualifiedStatesToUnbonded(types.ActiveTxType)).Return(nil).Once()
+	mockV1DBClient.On("TransitionToUnbondedState", mock.Anything, "unbonding",
+		utils.QualifiedStatesToUnbonded(types.UnbondingTxType)).Return(nil).Once()
+	// Transitioned by its expiry event since it was found
+	mockV1DBClient.On("TransitionToUnbondedState", mock.Anything, "transitioned", mock.Anything).
+		Return(&db.NotFoundError{Key: "transitioned"}).Once()
+	mockV1DBClient.On("FindDelegationByTxHashHex", mock.Anything, "active").Return(&active, nil).Once()
+	mockV1DBClient.On("FindDelegationByTxHashHex", mock.Anything, "unbonding").Return(&unbonding, nil).Once()
+	mockV1DBClient.On("SaveDelegationMilestone", mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+	service := newTestV1Service(t, testServiceDeps{cfg: expiryScannerTestConfig, v1DB: mockV1DBClient})
+
+	counts, err := service.ScanExpiredDelegations(context.Background())
+	require.Nil(t, err)
+	assert.Equal(t, map[types.StakingTxType]int64{types.ActiveTxType: 1, types.UnbondingTxType: 1}, counts)
+}
+
+func TestScanExpiredDelegationsWithoutTip(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(nil, &db.NotFoundError{}).Once()
+	service := newTestV1Service(t, testServiceDeps{cfg: expiryScannerTestConfig, v1DB: mockV1DBClient})
+
+	counts, err := service.ScanExpiredDelegations(context.Background())
+	require.Nil(t, err)
+	assert.Empty(t, counts)
+	mockV1DBClient.AssertNotCalled(t, "FindExpiredDelegations", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,228 @@
+package servicestest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryObjectStore keeps the uploaded objects in memory
+type memoryObjectStore struct {
+	objects   map[string]string
+	uploadErr error
+	// blockUpload blocks the uploads until their context is done
+	blockUpload bool
+}
+
+func (s *memoryObjectStore) Upload(ctx context.Context, key string, body io.Reader, contentType string) error {
+	if s.uploadErr != nil {
+		return s.uploadErr
+	}
+	if s.blockUpload {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.objects[key] = string(content)
+	return nil
+}
+
+func (s *memoryObjectStore) SignedUrl(key string, ttl time.Duration) (string, error) {
+	return "https://exports.example.com/" + key + "?expires=" + ttl.String(), nil
+}
+
+var testExportsConfig = &config.ExportsConfig{
+	Bucket:       "exports",
+	Region:       "us-east-1",
+	Prefix:       "exports",
+	UrlTtl:       time.Hour,
+	PollInterval: time.Second,
+	JobTimeout:   time.Minute,
+	Retention:    24 * time.Hour,
+}
+
+func exportTestDeps(
+	mockDBClient *mocks.DBClient, mockV1DBClient *mocks.V1DBClient, store *memoryObjectStore,
+) testServiceDeps {
+	return testServiceDeps{
+		cfg:      &config.Config{Exports: testExportsConfig},
+		clients:  &clients.Clients{ObjectStore: store},
+		sharedDB: mockDBClient,
+		v1DB:     mockV1DBClient,
+		now:      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCreateExportJob(t *testing.T) {
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("InsertExportJob", mock.Anything, mock.Anything).Return(nil).Once()
+	service := newTestV1Service(t, exportTestDeps(mockDBClient, mocks.NewV1DBClient(t), nil))
+	fpPkHex := testutils.GeneratePks(1)[0]
+
+	job, svcErr := service.CreateExportJob(context.Background(), dbmodel.ExportFinalityProviderDelegations, fpPkHex)
+	require.Nil(t, svcErr)
+	assert.Equal(t, dbmodel.ExportJobPending, job.Status)
+	assert.Equal(t, fpPkHex, job.FinalityProviderPkHex)
+	assert.NotEmpty(t, job.Id)
+
+	_, svcErr = service.CreateExportJob(context.Background(), dbmodel.ExportFinalityProviderDelegations, "")
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusBadRequest, svcErr.StatusCode)
+
+	_, svcErr = service.CreateExportJob(context.Background(), "full_history", "")
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusBadRequest, svcErr.StatusCode)
+}
+
+func TestProcessNextExportJobWritesDelegations(t *testing.T) {
+	startedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	job := &dbmodel.ExportJobDocument{
+		Id:                    "job-1",
+		Type:                  dbmodel.ExportFinalityProviderDelegations,
+		FinalityProviderPkHex: "fp",
+		Status:                dbmodel.ExportJobRunning,
+		Attempts:              1,
+		StartedAt:             startedAt,
+	}
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("ClaimExportJob", mock.Anything, startedAt, startedAt.Add(-time.Minute)).Return(job, nil).Once()
+	mockDBClient.On("CompleteExportJob", mock.Anything, "job-1", startedAt, "exports/job-1.ndjson", startedAt).
+		Return(nil).Once()
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(&v1dbmodel.BtcInfo{BtcHeight: 109}, nil)
+	mockV1DBClient.On("StreamDelegationsByFinalityProviderPk", mock.Anything, "fp", mock.Anything).
+		Return(func(ctx context.Context, fpPkHex string, fn func(*v1dbmodel.DelegationDocument) error) error {
+			for _, txHash := range []string{"aa", "bb"} {
+				err := fn(&v1dbmodel.DelegationDocument{
+					StakingTxHashHex:      txHash,
+					FinalityProviderPkHex: fpPkHex,
+					State:                 types.Active,
+					StakingTx:             &v1dbmodel.TimelockTransaction{StartHeight: 100},
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	store := &memoryObjectStore{objects: make(map[string]string)}
+	service := newTestV1Service(t, exportTestDeps(mockDBClient, mockV1DBClient, store))
+
+	processed, svcErr := service.ProcessNextExportJob(context.Background())
+	require.Nil(t, svcErr)
+	assert.True(t, processed)
+
+	lines := strings.Split(strings.TrimSpace(store.objects["exports/job-1.ndjson"]), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"staking_tx_hash_hex":"aa"`)
+	assert.Contains(t, lines[0], `"confirmations":10`)
+	assert.Contains(t, lines[1], `"staking_tx_hash_hex":"bb"`)
+}
+
+func TestProcessNextExportJobRecordsFailures(t *testing.T) {
+	startedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	job := &dbmodel.ExportJobDocument{
+		Id:        "job-1",
+		Type:      dbmodel.ExportFinalityProvidersStats,
+		Status:    dbmodel.ExportJobRunning,
+		Attempts:  1,
+		StartedAt: startedAt,
+	}
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("ClaimExportJob", mock.Anything, mock.Anything, mock.Anything).Return(job, nil).Once()
+	mockDBClient.On("ClaimExportJob", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	mockDBClient.On("FailExportJob", mock.Anything, "job-1", startedAt, "bucket unavailable", startedAt).
+		Return(nil).Once()
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	// The upload may fail before the stats are read
+	mockV1DBClient.On("FindAllFinalityProviderStats", mock.Anything).Return(
+		[]*v1dbmodel.FinalityProviderStatsDocument{{FinalityProviderPkHex: "fp", ActiveTvl: 10}}, nil,
+	).Maybe()
+	store := &memoryObjectStore{uploadErr: errors.New("bucket unavailable")}
+	service := newTestV1Service(t, exportTestDeps(mockDBClient, mockV1DBClient, store))
+
+	processed, svcErr := service.ProcessNextExportJob(context.Background())
+	require.Nil(t, svcErr)
+	assert.True(t, processed)
+
+	// No job left to run
+	processed, svcErr = service.ProcessNextExportJob(context.Background())
+	require.Nil(t, svcErr)
+	assert.False(t, processed)
+}
+
+func TestProcessNextExportJobRenewsItsClaim(t *testing.T) {
+	startedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	job := &dbmodel.ExportJobDocument{
+		Id:        "job-1",
+		Type:      dbmodel.ExportFinalityProvidersStats,
+		Status:    dbmodel.ExportJobRunning,
+		Attempts:  1,
+		StartedAt: startedAt,
+	}
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("ClaimExportJob", mock.Anything, mock.Anything, mock.Anything).Return(job, nil).Once()
+	// The job is stopped once it has been claimed by another worker
+	mockDBClient.On("RenewExportJobClaim", mock.Anything, "job-1", startedAt, startedAt).Return(true, nil).Once()
+	mockDBClient.On("RenewExportJobClaim", mock.Anything, "job-1", startedAt, startedAt).Return(false, nil).Once()
+	mockDBClient.On("FailExportJob", mock.Anything, "job-1", startedAt, context.Canceled.Error(), startedAt).
+		Return(nil).Once()
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindAllFinalityProviderStats", mock.Anything).Return(
+		[]*v1dbmodel.FinalityProviderStatsDocument{}, nil,
+	).Maybe()
+	deps := exportTestDeps(mockDBClient, mockV1DBClient, &memoryObjectStore{blockUpload: true})
+	exportsConfig := *testExportsConfig
+	exportsConfig.JobTimeout = 30 * time.Millisecond
+	deps.cfg = &config.Config{Exports: &exportsConfig}
+	service := newTestV1Service(t, deps)
+
+	processed, svcErr := service.ProcessNextExportJob(context.Background())
+	require.Nil(t, svcErr)
+	assert.True(t, processed)
+}
+
+func TestGetExportJobSignsDownloadUrl(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("FindExportJob", mock.Anything, "job-1").Return(&dbmodel.ExportJobDocument{
+		Id:          "job-1",
+		Type:        dbmodel.ExportFinalityProvidersStats,
+		Status:      dbmodel.ExportJobCompleted,
+		ObjectKey:   "exports/job-1.ndjson",
+		CreatedAt:   createdAt,
+		CompletedAt: createdAt.Add(time.Minute),
+	}, nil)
+	service := newTestV1Service(t, exportTestDeps(mockDBClient, mocks.NewV1DBClient(t), &memoryObjectStore{}))
+
+	job, svcErr := service.GetExportJob(context.Background(), "job-1")
+	require.Nil(t, svcErr)
+	assert.Equal(t, &v1service.ExportJobPublic{
+		Id:                   "job-1",
+		Type:                 dbmodel.ExportFinalityProvidersStats,
+		Status:               dbmodel.ExportJobCompleted,
+		CreatedAt:            "2024-05-01T11:00:00Z",
+		CompletedAt:          "2024-05-01T11:01:00Z",
+		DownloadUrl:          "https://exports.example.com/exports/job-1.ndjson?expires=1h0m0s",
+		DownloadUrlExpiresAt: "2024-05-01T13:00:00Z",
+	}, job)
+}
@@ -0,0 +1,68 @@
+package servicestest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFinalityProviderDetail(t *testing.T) {
+	registeredFp := types.FinalityProviderDetails{
+		Description: types.FinalityProviderDescription{Moniker: "registered"},
+		Commission:  "0.05",
+		BtcPk:       "fp-registered",
+	}
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindFinalityProviderStatsByFinalityProviderPkHex", mock.Anything, []string{"fp-registered"}).
+		Return([]*v1dbmodel.FinalityProviderStatsDocument{
+			{FinalityProviderPkHex: "fp-registered", ActiveTvl: 100, TotalTvl: 150, ActiveDelegations: 1, TotalDelegations: 2},
+		}, nil).Once()
+	mockV1DBClient.On("FindFinalityProviderStatsByFinalityProviderPkHex", mock.Anything, []string{"fp-other"}).
+		Return([]*v1dbmodel.FinalityProviderStatsDocument{
+			{FinalityProviderPkHex: "fp-other", TotalTvl: 10, TotalDelegations: 1},
+		}, nil).Once()
+	mockV1DBClient.On("FindFinalityProviderStatsByFinalityProviderPkHex", mock.Anything, []string{"fp-unknown"}).
+		Return(nil, nil).Once()
+	mockV1DBClient.On("FindRecentDelegationsByFinalityProviderPk", mock.Anything, "fp-registered", int64(10)).
+		Return([]v1dbmodel.DelegationDocument{
+			{StakingTxHashHex: "bb", State: types.Active, StakingTx: &v1dbmodel.TimelockTransaction{StartHeight: 105}},
+			{StakingTxHashHex: "aa", State: types.Unbonded, StakingTx: &v1dbmodel.TimelockTransaction{StartHeight: 100}},
+		}, nil).Once()
+	mockV1DBClient.On("FindRecentDelegationsByFinalityProviderPk", mock.Anything, "fp-other", int64(10)).
+		Return(nil, nil).Once()
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(nil, &db.NotFoundError{})
+	service := newTestV1Service(t, testServiceDeps{
+		finalityProviders: []types.FinalityProviderDetails{registeredFp}, v1DB: mockV1DBClient,
+	})
+
+	fp, svcErr := service.GetFinalityProviderDetail(context.Background(), "fp-registered")
+	require.Nil(t, svcErr)
+	assert.Equal(t, v1service.FpRegistered, fp.Status)
+	assert.Equal(t, "registered", fp.Description.Moniker)
+	assert.Equal(t, "0.05", fp.Commission)
+	assert.Equal(t, int64(100), fp.ActiveTvl)
+	assert.Equal(t, int64(2), fp.TotalDelegations)
+	require.Len(t, fp.RecentDelegations, 2)
+	assert.Equal(t, "bb", fp.RecentDelegations[0].StakingTxHashHex)
+	assert.Equal(t, "aa", fp.RecentDelegations[1].StakingTxHashHex)
+
+	// A finality provider with delegations but without registry data
+	fp, svcErr = service.GetFinalityProviderDetail(context.Background(), "fp-other")
+	require.Nil(t, svcErr)
+	assert.Equal(t, v1service.FpUnregistered, fp.Status)
+	assert.Equal(t, "", fp.Description.Moniker)
+	assert.Empty(t, fp.RecentDelegations)
+
+	_, svcErr = service.GetFinalityProviderDetail(context.Background(), "fp-unknown")
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusNotFound, svcErr.StatusCode)
+}
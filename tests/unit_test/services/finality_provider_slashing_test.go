@@ -0,0 +1,40 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+
+	indexerdbmodel "github.com/babylonlabs-io/staking-api-service/internal/indexer/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v2dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v2/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFinalityProvidersReportsSlashing(t *testing.T) {
+	mockIndexerDBClient := mocks.NewIndexerDBClient(t)
+	mockIndexerDBClient.On("GetFinalityProviders", mock.Anything, types.FinalityProviderStateActive, "").
+		Return(&db.DbResultMap[indexerdbmodel.IndexerFinalityProviderDetails]{
+			Data: []indexerdbmodel.IndexerFinalityProviderDetails{{BtcPk: "fp-1"}, {BtcPk: "fp-2"}},
+		}, nil)
+	mockV2DBClient := mocks.NewV2DBClient(t)
+	mockV2DBClient.On("GetFinalityProvidersStats", mock.Anything, []string{"fp-1", "fp-2"}).
+		Return([]*v2dbmodel.V2FinalityProviderStatsDocument{{
+			FinalityProviderPkHex: "fp-2",
+			SlashedTvl:            5000,
+			SlashedDelegations:    2,
+		}}, nil)
+	service := newTestV2Service(t, testServiceDeps{v2DB: mockV2DBClient, indexerDB: mockIndexerDBClient})
+
+	providers, _, svcErr := service.GetFinalityProviders(context.Background(), types.FinalityProviderStateActive, "")
+	require.Nil(t, svcErr)
+	require.Len(t, providers, 2)
+	// The finality providers without stats have not been slashed
+	assert.Zero(t, providers[0].SlashedTvl)
+	assert.Zero(t, providers[0].SlashedDelegations)
+	assert.Equal(t, int64(5000), providers[1].SlashedTvl)
+	assert.Equal(t, int64(2), providers[1].SlashedDelegations)
+}
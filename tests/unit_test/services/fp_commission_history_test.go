@@ -0,0 +1,67 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncFinalityProviderCommissions(t *testing.T) {
+	fps := []types.FinalityProviderDetails{
+		{BtcPk: "fp-new", Commission: "0.05"},
+		{BtcPk: "fp-unchanged", Commission: "0.10"},
+		{BtcPk: "fp-changed", Commission: "0.20"},
+	}
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindFpCommissionHistory", mock.Anything, "fp-new").Return(nil, nil).Once()
+	mockV1DBClient.On("FindFpCommissionHistory", mock.Anything, "fp-unchanged").Return(
+		[]v1dbmodel.FpCommissionChangeDocument{{Sequence: 1, Commission: "0.10"}}, nil,
+	).Once()
+	mockV1DBClient.On("FindFpCommissionHistory", mock.Anything, "fp-changed").Return(
+		[]v1dbmodel.FpCommissionChangeDocument{
+			{Sequence: 2, Commission: "0.15", PreviousCommission: "0.10"},
+			{Sequence: 1, Commission: "0.10"},
+		}, nil,
+	).Once()
+	mockV1DBClient.On("SaveFpCommissionChange", mock.Anything, "fp-new", int64(1), "0.05", "").Return(nil).Once()
+	mockV1DBClient.On("SaveFpCommissionChange", mock.Anything, "fp-changed", int64(3), "0.20", "0.15").
+		Return(nil).Once()
+	service := newTestV1Service(t, testServiceDeps{finalityProviders: fps, v1DB: mockV1DBClient})
+
+	require.Nil(t, service.SyncFinalityProviderCommissions(context.Background()))
+	mockV1DBClient.AssertNotCalled(t, "SaveFpCommissionChange", mock.Anything, "fp-unchanged",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetFinalityProviderCommissionHistory(t *testing.T) {
+	recordedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).Unix()
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindFpCommissionHistory", mock.Anything, "fp").Return(
+		[]v1dbmodel.FpCommissionChangeDocument{
+			{Sequence: 2, Commission: "0.15", PreviousCommission: "0.10", RecordedAt: recordedAt},
+			{Sequence: 1, Commission: "0.10", RecordedAt: recordedAt - 3600},
+		}, nil,
+	).Once()
+	mockV1DBClient.On("FindFpCommissionHistory", mock.Anything, "fp-unknown").Return(nil, nil).Once()
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	history, svcErr := service.GetFinalityProviderCommissionHistory(context.Background(), "fp")
+	require.Nil(t, svcErr)
+	assert.Equal(t, []v1service.FpCommissionChangePublic{
+		{Commission: "0.15", PreviousCommission: "0.10", RecordedAt: utils.ParseTimestampToIsoFormat(recordedAt)},
+		{Commission: "0.10", RecordedAt: utils.ParseTimestampToIsoFormat(recordedAt - 3600)},
+	}, history)
+
+	history, svcErr = service.GetFinalityProviderCommissionHistory(context.Background(), "fp-unknown")
+	require.Nil(t, svcErr)
+	assert.Empty(t, history)
+}
@@ -0,0 +1,73 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	dbmodel "github.com/babylonlabs-io/staking-api-service/internal/shared/db/model"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func indexStats(collection, name string, ops int64, fields ...string) dbmodel.IndexStatsDocument {
+	index := dbmodel.IndexStatsDocument{Collection: collection, Name: name}
+	for _, field := range fields {
+		index.Key = append(index.Key, bson.E{Key: field, Value: 1})
+	}
+	index.Accesses.Ops = ops
+	index.Accesses.Since = time.Unix(1700000000, 0)
+	return index
+}
+
+func TestGetIndexReport(t *testing.T) {
+	const dbName = "index-report-test"
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("FindIndexStats", mock.Anything).Return([]dbmodel.IndexStatsDocument{
+		indexStats("delegations", "_id_", 0, "_id"),
+		indexStats("delegations", "staker_pk_hex_1", 42, "staker_pk_hex"),
+		indexStats("delegations", "state_1", 0, "state"),
+	}, nil)
+	svc, err := service.New(
+		context.Background(), &config.Config{StakingDb: &config.DbConfig{DbName: dbName}}, nil, nil, nil,
+		&dbclients.DbClients{SharedDBClient: mockDBClient},
+	)
+	require.NoError(t, err)
+
+	record := func(filterField string, times int) {
+		command, err := bson.Marshal(bson.D{
+			{Key: "find", Value: "delegations"},
+			{Key: "filter", Value: bson.D{{Key: filterField, Value: "value"}}},
+		})
+		require.NoError(t, err)
+		for i := 0; i < times; i++ {
+			db.QueryShapesOf(dbName).Record("find", command)
+		}
+	}
+	record("staker_pk_hex", 200)
+	record("finality_provider_pk_hex", 150)
+	// Not hot enough to be reported
+	record("unbonding_tx.tx_hash_hex", 10)
+
+	report, svcErr := svc.GetIndexReport(context.Background())
+	require.Nil(t, svcErr)
+	require.Len(t, report.Indexes, 3)
+	assert.False(t, report.Indexes[0].Unused, "the _id index is never unused")
+	assert.False(t, report.Indexes[1].Unused)
+	assert.True(t, report.Indexes[2].Unused)
+	assert.Equal(t, []string{"state"}, report.Indexes[2].Fields)
+
+	require.Len(t, report.MissingIndexes, 1)
+	assert.Equal(t, service.QueryShapePublic{
+		Collection: "delegations",
+		Filter:     []string{"finality_provider_pk_hex"},
+		Count:      150,
+	}, report.MissingIndexes[0])
+}
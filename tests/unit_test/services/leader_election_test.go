@@ -0,0 +1,93 @@
+package servicestest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const leaderElectionTestJob = "test_job"
+
+func newTestLeaderElector(t *testing.T, mockDBClient *mocks.DBClient, clk *testutils.FakeClock) *services.LeaderElector {
+	metrics.Init(0)
+	cfg := &config.LeaderElectionConfig{LeaseDuration: 30 * time.Second, InstanceId: "replica-a"}
+	elector, err := services.NewLeaderElector(cfg, mockDBClient, clk)
+	require.NoError(t, err)
+	return elector
+}
+
+func TestLeaderElectorAcquiresAndReleasesTheLease(t *testing.T) {
+	clk := testutils.NewFakeClock(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("AcquireLeaderLease", mock.Anything, leaderElectionTestJob, "replica-a",
+		clk.Now(), clk.Now().Add(30*time.Second)).Return(true, nil).Once()
+	released := make(chan struct{})
+	mockDBClient.On("ReleaseLeaderLease", mock.Anything, leaderElectionTestJob, "replica-a").
+		Return(nil).Once().Run(func(mock.Arguments) { close(released) })
+	elector := newTestLeaderElector(t, mockDBClient, clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	elector.Campaign(ctx, leaderElectionTestJob)
+	assert.True(t, elector.IsLeader(leaderElectionTestJob))
+	assert.False(t, elector.IsLeader("other_job"))
+
+	// The lease is released on shutdown for another replica to take over
+	cancel()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("the lease was not released")
+	}
+	assert.False(t, elector.IsLeader(leaderElectionTestJob))
+}
+
+func TestLeaderElectorLeaseHeldByAnotherReplica(t *testing.T) {
+	clk := testutils.NewFakeClock(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("AcquireLeaderLease", mock.Anything, leaderElectionTestJob, "replica-a",
+		mock.Anything, mock.Anything).Return(false, nil).Once()
+	elector := newTestLeaderElector(t, mockDBClient, clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	elector.Campaign(ctx, leaderElectionTestJob)
+	assert.False(t, elector.IsLeader(leaderElectionTestJob))
+
+	// The lease of the other replica is left untouched
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	mockDBClient.AssertNotCalled(t, "ReleaseLeaderLease", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLeaderElectorIsNotLeaderOnDbError(t *testing.T) {
+	clk := testutils.NewFakeClock(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	mockDBClient := mocks.NewDBClient(t)
+	mockDBClient.On("AcquireLeaderLease", mock.Anything, leaderElectionTestJob, "replica-a",
+		mock.Anything, mock.Anything).Return(false, errors.New("db unavailable")).Once()
+	elector := newTestLeaderElector(t, mockDBClient, clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	elector.Campaign(ctx, leaderElectionTestJob)
+	assert.False(t, elector.IsLeader(leaderElectionTestJob))
+}
+
+func TestLeaderElectorDefaultsToTheHostname(t *testing.T) {
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	elector, err := services.NewLeaderElector(
+		&config.LeaderElectionConfig{LeaseDuration: 30 * time.Second}, mocks.NewDBClient(t), testutils.NewFakeClock(time.Now()),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, hostname, elector.Holder())
+}
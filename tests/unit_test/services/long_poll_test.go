@@ -0,0 +1,90 @@
+package servicestest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var longPollTestConfig = &config.Config{LongPoll: &config.LongPollConfig{MaxTimeout: time.Second, MaxWaiters: 1}}
+
+func TestWaitForDelegationStateReached(t *testing.T) {
+	unbonded := &v1dbmodel.DelegationDocument{StakingTxHashHex: "staking-tx-hash", State: types.Unbonded}
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("WaitForDelegationState", mock.Anything, "staking-tx-hash", []types.DelegationState{types.Unbonded}).
+		Return(unbonded, nil).Once()
+	service := newTestV1Service(t, testServiceDeps{cfg: longPollTestConfig, v1DB: mockV1DBClient})
+
+	delegation, err := service.WaitForDelegationState(context.Background(), "staking-tx-hash", types.Unbonded, time.Second)
+	require.Nil(t, err)
+	assert.Equal(t, types.Unbonded, delegation.State)
+}
+
+func TestWaitForDelegationStateTimeout(t *testing.T) {
+	unbonding := &v1dbmodel.DelegationDocument{StakingTxHashHex: "staking-tx-hash", State: types.Unbonding}
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("WaitForDelegationState", mock.Anything, "staking-tx-hash", mock.Anything).
+		Return(func(ctx context.Context, _ string, _ []types.DelegationState) (*v1dbmodel.DelegationDocument, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).Once()
+	mockV1DBClient.On("FindDelegationByTxHashHex", mock.Anything, "staking-tx-hash").Return(unbonding, nil).Once()
+	service := newTestV1Service(t, testServiceDeps{cfg: longPollTestConfig, v1DB: mockV1DBClient})
+
+	// The delegation is returned in its current state once the timeout fires
+	delegation, err := service.WaitForDelegationState(
+		context.Background(), "staking-tx-hash", types.Unbonded, 10*time.Millisecond,
+	)
+	require.Nil(t, err)
+	assert.Equal(t, types.Unbonding, delegation.State)
+}
+
+func TestWaitForDelegationStateFailure(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("WaitForDelegationState", mock.Anything, "staking-tx-hash", mock.Anything).
+		Return(nil, errors.New("change streams are not supported")).Once()
+	service := newTestV1Service(t, testServiceDeps{cfg: longPollTestConfig, v1DB: mockV1DBClient})
+
+	_, err := service.WaitForDelegationState(context.Background(), "staking-tx-hash", types.Unbonded, time.Second)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+}
+
+func TestWaitForDelegationStateMaxWaiters(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	waiting, release := make(chan struct{}), make(chan struct{})
+	mockV1DBClient.On("WaitForDelegationState", mock.Anything, "staking-tx-hash", mock.Anything).
+		Run(func(mock.Arguments) {
+			close(waiting)
+			<-release
+		}).
+		Return(&v1dbmodel.DelegationDocument{StakingTxHashHex: "staking-tx-hash", State: types.Unbonded}, nil).Once()
+	service := newTestV1Service(t, testServiceDeps{cfg: longPollTestConfig, v1DB: mockV1DBClient})
+
+	done := make(chan *types.Error)
+	go func() {
+		_, err := service.WaitForDelegationState(context.Background(), "staking-tx-hash", types.Unbonded, time.Second)
+		done <- err
+	}()
+	<-waiting
+
+	// The only slot is held, the next waiter is rejected without opening a
+	// change stream
+	_, err := service.WaitForDelegationState(context.Background(), "staking-tx-hash", types.Unbonded, time.Second)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+	assert.Equal(t, types.ServiceUnavailable, err.ErrorCode)
+
+	close(release)
+	assert.Nil(t, <-done)
+}
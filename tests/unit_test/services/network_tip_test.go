@@ -0,0 +1,33 @@
+package servicestest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNetworkTip(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(nil, &db.NotFoundError{}).Once()
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(
+		&v1dbmodel.BtcInfo{BtcHeight: 850000, BabylonHeight: 120000}, nil,
+	).Once()
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	// No btc info event received yet
+	_, svcErr := service.GetNetworkTip(context.Background())
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusNotFound, svcErr.StatusCode)
+
+	tip, svcErr := service.GetNetworkTip(context.Background())
+	require.Nil(t, svcErr)
+	assert.Equal(t, &v1service.NetworkTipPublic{BtcHeight: 850000, BabylonHeight: 120000}, tip)
+}
@@ -0,0 +1,59 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessOverflowStatsCalculation(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetOrCreateStatsLock", mock.Anything, "staking-tx-hash", types.Active.ToString()).
+		Return(v1dbmodel.NewStatsLockDocument("staking-tx-hash:active", false, false, false), nil).Once()
+	mockV1DBClient.On("IncrementOverflowStats", mock.Anything, "staking-tx-hash", uint64(1000)).Return(nil).Once()
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	err := service.ProcessOverflowStatsCalculation(context.Background(), "staking-tx-hash", types.Active, 1000)
+	require.Nil(t, err)
+
+	// The overflow delegations are never subtracted
+	err = service.ProcessOverflowStatsCalculation(context.Background(), "staking-tx-hash", types.Unbonded, 1000)
+	require.Nil(t, err)
+	mockV1DBClient.AssertNotCalled(t, "GetOrCreateStatsLock", mock.Anything, "staking-tx-hash", types.Unbonded.ToString())
+}
+
+func TestProcessOverflowStatsCalculationIgnoresDuplicates(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetOrCreateStatsLock", mock.Anything, "staking-tx-hash", types.Active.ToString()).
+		Return(v1dbmodel.NewStatsLockDocument("staking-tx-hash:active", true, false, false), nil).Once()
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	err := service.ProcessOverflowStatsCalculation(context.Background(), "staking-tx-hash", types.Active, 1000)
+	require.Nil(t, err)
+	mockV1DBClient.AssertNotCalled(t, "IncrementOverflowStats", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOverallStatsExposeOverflowStats(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetOverallStats", mock.Anything).Return(&v1dbmodel.OverallStatsDocument{
+		TotalTvl:            5000,
+		TotalDelegations:    5,
+		OverflowTvl:         3000,
+		OverflowDelegations: 2,
+	}, nil).Once()
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(nil, &db.NotFoundError{}).Once()
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	stats, err := service.GetOverallStats(context.Background())
+	require.Nil(t, err)
+	assert.Equal(t, int64(5000), stats.TotalTvl)
+	assert.Equal(t, int64(3000), stats.OverflowTvl)
+	assert.Equal(t, int64(2), stats.OverflowDelegations)
+}
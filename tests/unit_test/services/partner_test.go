@@ -0,0 +1,83 @@
+package servicestest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPartnerId     = "example-wallet"
+	testPartnerSecret = "partner-secret"
+	testStakingTxHash = "0b3c1e4b2b0e7a9c4f4a1d2e3f405162738495a6b7c8d9e0f1a2b3c4d5e6f708"
+)
+
+var partnerTestConfig = &config.Config{Partners: &config.PartnersConfig{
+	Secrets: map[string]string{testPartnerId: testPartnerSecret},
+}}
+
+func TestAttributeDelegationToPartner(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("SetDelegationPartner", mock.Anything, testStakingTxHash, testPartnerId).Return(nil).Once()
+	service := newTestV1Service(t, testServiceDeps{cfg: partnerTestConfig, v1DB: mockV1DBClient})
+
+	signature := v1service.SignPartnerAttribution(testPartnerSecret, testPartnerId, testStakingTxHash)
+	svcErr := service.AttributeDelegationToPartner(context.Background(), testStakingTxHash, testPartnerId, signature)
+	assert.Nil(t, svcErr)
+
+	// Signed with another secret
+	forged := v1service.SignPartnerAttribution("another-secret", testPartnerId, testStakingTxHash)
+	svcErr = service.AttributeDelegationToPartner(context.Background(), testStakingTxHash, testPartnerId, forged)
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusForbidden, svcErr.StatusCode)
+
+	// Signed for another delegation
+	svcErr = service.AttributeDelegationToPartner(context.Background(), "aa"+testStakingTxHash[2:], testPartnerId, signature)
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusForbidden, svcErr.StatusCode)
+
+	svcErr = service.AttributeDelegationToPartner(context.Background(), testStakingTxHash, "unknown", signature)
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusBadRequest, svcErr.StatusCode)
+}
+
+func TestAttributeDelegationToAnotherPartner(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("SetDelegationPartner", mock.Anything, testStakingTxHash, testPartnerId).
+		Return(&db.DuplicateKeyError{Key: testStakingTxHash})
+	service := newTestV1Service(t, testServiceDeps{cfg: partnerTestConfig, v1DB: mockV1DBClient})
+
+	signature := v1service.SignPartnerAttribution(testPartnerSecret, testPartnerId, testStakingTxHash)
+	svcErr := service.AttributeDelegationToPartner(context.Background(), testStakingTxHash, testPartnerId, signature)
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusConflict, svcErr.StatusCode)
+	assert.Equal(t, types.Conflict, svcErr.ErrorCode)
+}
+
+func TestGetPartnerStats(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindPartnerStats", mock.Anything, testPartnerId).Return(
+		&v1dbmodel.PartnerStatsDocument{ActiveTvl: 10, TotalTvl: 30, ActiveDelegations: 1, TotalDelegations: 2}, nil,
+	)
+	service := newTestV1Service(t, testServiceDeps{cfg: partnerTestConfig, v1DB: mockV1DBClient})
+
+	stats, svcErr := service.GetPartnerStats(context.Background(), testPartnerId)
+	require.Nil(t, svcErr)
+	assert.Equal(t, &v1service.PartnerStatsPublic{
+		PartnerId: testPartnerId, ActiveTvl: 10, TotalTvl: 30, ActiveDelegations: 1, TotalDelegations: 2,
+	}, stats)
+
+	_, svcErr = service.GetPartnerStats(context.Background(), "unknown")
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusNotFound, svcErr.StatusCode)
+}
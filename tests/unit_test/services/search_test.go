@@ -0,0 +1,78 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// searchPkHex is a valid x-only public key, which is also formatted as a tx hash
+const searchPkHex = "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+
+func searchTestDeps(mockV1DBClient *mocks.V1DBClient) testServiceDeps {
+	return testServiceDeps{
+		cfg: &config.Config{Server: &config.ServerConfig{}},
+		finalityProviders: []types.FinalityProviderDetails{
+			{Description: types.FinalityProviderDescription{Moniker: "Babylon Foundation"}, BtcPk: "fp-foundation"},
+			{Description: types.FinalityProviderDescription{Moniker: "Staking Pool"}, BtcPk: "fp-pool"},
+		},
+		v1DB: mockV1DBClient,
+	}
+}
+
+func TestSearchHexMatchesDelegationsAndStakers(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindDelegationByAnyTxHashHex", mock.Anything, searchPkHex).Return(
+		&v1dbmodel.DelegationDocument{StakingTxHashHex: "aa", State: types.Active}, nil,
+	)
+	mockV1DBClient.On("FindFinalityProviderStatsByFinalityProviderPkHex", mock.Anything, []string{searchPkHex}).
+		Return(nil, nil)
+	mockV1DBClient.On("CheckDelegationExistByStakerPk", mock.Anything, searchPkHex, mock.Anything).Return(true, nil)
+	service := newTestV1Service(t, searchTestDeps(mockV1DBClient))
+
+	// The query is case insensitive
+	matches, svcErr := service.Search(context.Background(), " "+"79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798")
+	require.Nil(t, svcErr)
+	assert.Equal(t, []v1service.SearchMatchPublic{
+		{Type: v1service.SearchMatchDelegation, Id: "aa", Label: types.Active.ToString()},
+		{Type: v1service.SearchMatchStaker, Id: searchPkHex},
+	}, matches)
+}
+
+func TestSearchHexWithoutMatch(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindDelegationByAnyTxHashHex", mock.Anything, searchPkHex).Return(
+		nil, &db.NotFoundError{Key: searchPkHex},
+	)
+	mockV1DBClient.On("FindFinalityProviderStatsByFinalityProviderPkHex", mock.Anything, []string{searchPkHex}).
+		Return(nil, nil)
+	mockV1DBClient.On("CheckDelegationExistByStakerPk", mock.Anything, searchPkHex, mock.Anything).Return(false, nil)
+	service := newTestV1Service(t, searchTestDeps(mockV1DBClient))
+
+	matches, svcErr := service.Search(context.Background(), searchPkHex)
+	require.Nil(t, svcErr)
+	assert.Empty(t, matches)
+}
+
+func TestSearchFinalityProviderMoniker(t *testing.T) {
+	service := newTestV1Service(t, searchTestDeps(mocks.NewV1DBClient(t)))
+
+	matches, svcErr := service.Search(context.Background(), "pool")
+	require.Nil(t, svcErr)
+	assert.Equal(t, []v1service.SearchMatchPublic{
+		{Type: v1service.SearchMatchFinalityProvider, Id: "fp-pool", Label: "Staking Pool"},
+	}, matches)
+
+	matches, svcErr = service.Search(context.Background(), "unknown")
+	require.Nil(t, svcErr)
+	assert.Empty(t, matches)
+}
@@ -0,0 +1,82 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	dbclients "github.com/babylonlabs-io/staking-api-service/internal/shared/db/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/http/clients"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	v2service "github.com/babylonlabs-io/staking-api-service/internal/v2/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// testServiceDeps are the dependencies of the service under test, the ones
+// not set are left empty
+type testServiceDeps struct {
+	cfg               *config.Config
+	params            *types.GlobalParams
+	finalityProviders []types.FinalityProviderDetails
+	clients           *clients.Clients
+	sharedDB          *mocks.DBClient
+	v1DB              *mocks.V1DBClient
+	v2DB              *mocks.V2DBClient
+	indexerDB         *mocks.IndexerDBClient
+	// now fixes the clock of the service, the real clock is used if not set
+	now time.Time
+}
+
+func (deps testServiceDeps) config() *config.Config {
+	if deps.cfg == nil {
+		return &config.Config{}
+	}
+	return deps.cfg
+}
+
+// dbClients sets the mocks given, a nil mock would otherwise be a non-nil
+// interface value
+func (deps testServiceDeps) dbClients() *dbclients.DbClients {
+	dbClients := &dbclients.DbClients{}
+	if deps.sharedDB != nil {
+		dbClients.SharedDBClient = deps.sharedDB
+	}
+	if deps.v1DB != nil {
+		dbClients.V1DBClient = deps.v1DB
+	}
+	if deps.v2DB != nil {
+		dbClients.V2DBClient = deps.v2DB
+	}
+	if deps.indexerDB != nil {
+		dbClients.IndexerDBClient = deps.indexerDB
+	}
+	return dbClients
+}
+
+func newTestV1Service(t *testing.T, deps testServiceDeps) *v1service.V1Service {
+	service, err := v1service.New(
+		context.Background(), deps.config(), deps.params, deps.finalityProviders,
+		deps.clients, deps.dbClients(),
+	)
+	require.NoError(t, err)
+	if !deps.now.IsZero() {
+		service.Service.Clock = testutils.NewFakeClock(deps.now)
+	}
+	return service
+}
+
+func newTestV2Service(t *testing.T, deps testServiceDeps) *v2service.V2Service {
+	service, err := v2service.New(
+		context.Background(), deps.config(), deps.params, deps.finalityProviders,
+		deps.clients, deps.dbClients(),
+	)
+	require.NoError(t, err)
+	if !deps.now.IsZero() {
+		service.Service.Clock = testutils.NewFakeClock(deps.now)
+	}
+	return service
+}
@@ -0,0 +1,138 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/observability/metrics"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/babylonlabs-io/staking-api-service/tests/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func stakerPolicyTestDeps(
+	mockV1DBClient *mocks.V1DBClient, policy *config.StakerPolicyConfig,
+) testServiceDeps {
+	deps := eligibilityTestDeps(mockV1DBClient, eligibilityTestParams(0, 100))
+	deps.cfg.StakerPolicy = policy
+	return deps
+}
+
+func activeDelegationsFilter(filter *v1dbclient.DelegationFilter) bool {
+	return len(filter.States) == 2
+}
+
+func TestCheckStakingEligibilityWithStakerPolicy(t *testing.T) {
+	t0 := time.Unix(1700000000, 0)
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(&v1dbmodel.BtcInfo{BtcHeight: 150}, nil)
+	mockV1DBClient.On(
+		"CountDelegationsByStakerPk", mock.Anything, eligibilityStakerPkHex,
+		mock.MatchedBy(activeDelegationsFilter),
+	).Return(int64(3), nil)
+	mockV1DBClient.On(
+		"CountDelegationsByStakerPk", mock.Anything, eligibilityStakerPkHex,
+		mock.MatchedBy(func(filter *v1dbclient.DelegationFilter) bool {
+			return filter.AfterTimestamp == t0.Unix()-3599 && filter.BeforeTimestamp == t0.Unix()+3599
+		}),
+	).Return(int64(1), nil)
+	service := newTestV1Service(t, stakerPolicyTestDeps(mockV1DBClient, &config.StakerPolicyConfig{
+		MaxActiveDelegations: 3,
+		MinStakingAmount:     50,
+		MaxStakingAmount:     300,
+		Cooldown:             time.Hour,
+	}))
+	clock := testutils.NewFakeClock(t0)
+	service.Service.Clock = clock
+
+	report, svcErr := service.CheckStakingEligibility(
+		context.Background(), eligibilityStakerPkHex, eligibilityFpPkHex, 400, 500,
+	)
+	require.Nil(t, svcErr)
+	assert.False(t, report.Eligible)
+	assert.Len(t, report.Checks, 8)
+	assert.Equal(t, []string{
+		v1service.StakerPolicyMaxActiveDelegations,
+		v1service.StakerPolicyStakingAmount,
+		v1service.StakerPolicyCooldown,
+	}, failedEligibilityChecks(report))
+	assert.Equal(t, "staking amount 400 is above the staker policy max 300", report.Checks[6].Message)
+}
+
+func TestCheckStakingEligibilityWithoutStakerPolicyRules(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(&v1dbmodel.BtcInfo{BtcHeight: 150}, nil)
+	// Only the amount rule is set, the delegations of the staker are not read
+	service := newTestV1Service(t, stakerPolicyTestDeps(mockV1DBClient, &config.StakerPolicyConfig{
+		MinStakingAmount: 50,
+	}))
+
+	report, svcErr := service.CheckStakingEligibility(
+		context.Background(), eligibilityStakerPkHex, eligibilityFpPkHex, 400, 500,
+	)
+	require.Nil(t, svcErr)
+	assert.True(t, report.Eligible)
+	require.Len(t, report.Checks, 6)
+	assert.Equal(t, v1service.StakerPolicyStakingAmount, report.Checks[5].Name)
+}
+
+// stakerPolicyViolations reads the staker policy violations counter of the rule
+func stakerPolicyViolations(t *testing.T, rule string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "staker_policy_violations_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "rule" && label.GetValue() == rule {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestSaveActiveStakingDelegationFlagsStakerPolicyViolationsOnce(t *testing.T) {
+	metrics.Init(0)
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetLatestBtcInfo", mock.Anything).Return(nil, &db.NotFoundError{})
+	mockV1DBClient.On(
+		"CountDelegationsByStakerPk", mock.Anything, eligibilityStakerPkHex,
+		mock.MatchedBy(activeDelegationsFilter),
+	).Return(int64(5), nil)
+	saveArgs := []interface{}{
+		mock.Anything, "tx", eligibilityStakerPkHex, eligibilityFpPkHex, "txHex",
+		uint64(400), uint64(150), uint64(500), uint64(0), int64(1700000000), false, types.Active,
+	}
+	mockV1DBClient.On("SaveActiveStakingDelegation", saveArgs...).Return(nil).Once()
+	mockV1DBClient.On("SaveActiveStakingDelegation", saveArgs...).Return(&db.DuplicateKeyError{}).Once()
+	mockV1DBClient.On("SaveDelegationMilestone", mock.Anything, "tx", mock.Anything, "tx", uint64(150), int64(1700000000)).
+		Return(nil)
+	service := newTestV1Service(t, stakerPolicyTestDeps(mockV1DBClient, &config.StakerPolicyConfig{
+		MaxActiveDelegations: 3,
+	}))
+	before := stakerPolicyViolations(t, v1service.StakerPolicyMaxActiveDelegations)
+
+	// The violations don't prevent the delegation from being indexed, and
+	// they are not counted again when the event is redelivered
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, service.SaveActiveStakingDelegation(
+			context.Background(), "tx", eligibilityStakerPkHex, eligibilityFpPkHex,
+			400, 150, 1700000000, 500, 0, "txHex", false,
+		))
+	}
+	assert.Equal(t, before+1, stakerPolicyViolations(t, v1service.StakerPolicyMaxActiveDelegations))
+}
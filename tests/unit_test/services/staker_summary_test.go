@@ -0,0 +1,46 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStakerSummary(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindStakerStateSummaries", mock.Anything, "staker").Return(
+		[]v1dbmodel.StakerStateSummaryDocument{
+			{State: types.Active, Count: 2, Tvl: 300, FirstStakingTimestamp: 200, LastStakingTimestamp: 500},
+			{State: types.UnbondingRequested, Count: 1, Tvl: 50, FirstStakingTimestamp: 150, LastStakingTimestamp: 150},
+			{State: types.Unbonding, Count: 1, Tvl: 20, FirstStakingTimestamp: 300, LastStakingTimestamp: 300},
+			{State: types.Withdrawn, Count: 3, Tvl: 70, FirstStakingTimestamp: 100, LastStakingTimestamp: 400},
+		}, nil,
+	).Once()
+	mockV1DBClient.On("FindStakerStateSummaries", mock.Anything, "newcomer").Return(nil, nil).Once()
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	summary, svcErr := service.GetStakerSummary(context.Background(), "staker")
+	require.Nil(t, svcErr)
+	assert.Equal(t, int64(7), summary.TotalDelegations)
+	assert.Equal(t, int64(440), summary.TotalTvl)
+	assert.Equal(t, int64(100), summary.FirstStakingTimestamp)
+	assert.Equal(t, int64(500), summary.LastStakingTimestamp)
+	assert.Equal(t, int64(70), summary.WithdrawnAmount)
+	assert.Equal(t, int64(2), summary.PendingUnbondings)
+	assert.Equal(t, v1service.StakerStateSummaryPublic{Count: 2, Tvl: 300}, summary.States[types.Active])
+	assert.Equal(t, v1service.StakerStateSummaryPublic{}, summary.States[types.Pending])
+
+	// A staker without delegations has an empty summary with all the states
+	summary, svcErr = service.GetStakerSummary(context.Background(), "newcomer")
+	require.Nil(t, svcErr)
+	assert.Len(t, summary.States, 6)
+	assert.Zero(t, summary.TotalDelegations)
+	assert.Zero(t, summary.FirstStakingTimestamp)
+}
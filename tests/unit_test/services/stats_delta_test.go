@@ -0,0 +1,80 @@
+package servicestest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var statsDeltaTestConfig = &config.Config{StatsRefresher: &config.StatsRefresherConfig{
+	Interval:     time.Minute,
+	MaxStaleness: 5 * time.Minute,
+}}
+
+func TestGetStatsDelta(t *testing.T) {
+	now := time.Date(2024, 5, 8, 12, 30, 0, 0, time.UTC)
+	hourStart := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).Unix()
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindHourlyOverallStats", mock.Anything, now.Add(-7*24*time.Hour)).Return(
+		&v1dbmodel.HourlyOverallStatsDocument{
+			HourStart:         hourStart,
+			ActiveTvl:         1000,
+			TotalTvl:          1500,
+			ActiveDelegations: 10,
+			TotalDelegations:  15,
+			TotalStakers:      8,
+		}, nil,
+	).Once()
+	mockV1DBClient.On("GetMaterializedOverallStats", mock.Anything).Return(
+		&v1dbmodel.MaterializedOverallStatsDocument{
+			OverallStatsDocument: v1dbmodel.OverallStatsDocument{
+				ActiveTvl:         800,
+				TotalTvl:          2000,
+				ActiveDelegations: 9,
+				TotalDelegations:  20,
+				TotalStakers:      11,
+			},
+			LastRefreshedAt: now.Add(-time.Minute).Unix(),
+		}, nil,
+	).Once()
+	service := newTestV1Service(t, testServiceDeps{cfg: statsDeltaTestConfig, v1DB: mockV1DBClient, now: now})
+
+	delta, svcErr := service.GetStatsDelta(context.Background(), "7d")
+	require.Nil(t, svcErr)
+	assert.Equal(t, &v1service.StatsDeltaPublic{
+		Window:                 "7d",
+		From:                   utils.ParseTimestampToIsoFormat(hourStart),
+		ActiveTvlDelta:         -200,
+		TotalTvlDelta:          500,
+		ActiveDelegationsDelta: -1,
+		TotalDelegationsDelta:  5,
+		TotalStakersDelta:      3,
+	}, delta)
+}
+
+func TestGetStatsDeltaWithoutHistory(t *testing.T) {
+	now := time.Date(2024, 5, 8, 12, 30, 0, 0, time.UTC)
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindHourlyOverallStats", mock.Anything, now.Add(-24*time.Hour)).
+		Return(nil, &db.NotFoundError{Message: "Hourly overall stats not found"}).Once()
+	service := newTestV1Service(t, testServiceDeps{cfg: statsDeltaTestConfig, v1DB: mockV1DBClient, now: now})
+
+	_, svcErr := service.GetStatsDelta(context.Background(), "24h")
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusNotFound, svcErr.StatusCode)
+
+	_, svcErr = service.GetStatsDelta(context.Background(), "30d")
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusBadRequest, svcErr.StatusCode)
+}
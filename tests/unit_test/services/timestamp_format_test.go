@@ -0,0 +1,70 @@
+package servicestest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/api/handlers/handler"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	v1model "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegationTimestampFormats(t *testing.T) {
+	delegation := &v1model.DelegationDocument{
+		StakingTxHashHex: "staking-tx-hash",
+		State:            types.Unbonding,
+		StakingTx:        &v1model.TimelockTransaction{TxHex: "staking-tx", StartTimestamp: 1714564800},
+		UnbondingTx:      &v1model.TimelockTransaction{TxHex: "unbonding-tx", StartTimestamp: 1714568400},
+	}
+
+	// The timestamps are ISO8601 by default, along with the unix timestamps
+	delegationPublic := v1service.FromDelegationDocument(delegation, 0)
+	stakingTx, err := json.Marshal(delegationPublic.StakingTx)
+	require.NoError(t, err)
+	assert.Contains(
+		t, string(stakingTx),
+		`"start_timestamp":"`+utils.ParseTimestampToIsoFormat(1714564800)+`","start_timestamp_unix":1714564800`,
+	)
+	assert.Equal(t, int64(1714568400), delegationPublic.UnbondingTx.StartTimestampUnix)
+	assert.Nil(t, delegationPublic.WithdrawalTx)
+
+	// The unix timestamps are numbers
+	delegationPublic.FormatTimestamps(utils.TimestampFormatUnix)
+	stakingTx, err = json.Marshal(delegationPublic.StakingTx)
+	require.NoError(t, err)
+	assert.Contains(t, string(stakingTx), `"start_timestamp":1714564800,"start_timestamp_unix":1714564800`)
+	unbondingTx, err := json.Marshal(delegationPublic.UnbondingTx)
+	require.NoError(t, err)
+	assert.Contains(t, string(unbondingTx), `"start_timestamp":1714568400,`)
+
+	// Both formats are read back
+	var decoded v1service.TransactionPublic
+	require.NoError(t, json.Unmarshal(stakingTx, &decoded))
+	assert.Equal(t, int64(1714564800), decoded.StartTimestamp.Unix)
+	require.NoError(t, json.Unmarshal([]byte(`{"start_timestamp":"2024-05-01T12:00:00Z"}`), &decoded))
+	assert.Equal(t, int64(1714564800), decoded.StartTimestamp.Unix)
+}
+
+func TestParseTimestampFormatQuery(t *testing.T) {
+	parse := func(query string) (utils.TimestampFormat, *types.Error) {
+		request := &http.Request{URL: &url.URL{RawQuery: url.Values{"timestamp_format": {query}}.Encode()}}
+		return handler.ParseTimestampFormatQuery(request)
+	}
+
+	format, err := parse("")
+	require.Nil(t, err)
+	assert.Equal(t, utils.TimestampFormatISO8601, format)
+	format, err = parse("unix")
+	require.Nil(t, err)
+	assert.Equal(t, utils.TimestampFormatUnix, format)
+
+	_, err = parse("rfc1123")
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+}
@@ -0,0 +1,63 @@
+package servicestest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/config"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbclient "github.com/babylonlabs-io/staking-api-service/internal/v1/db/client"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func totalCountTestConfig(approximate bool) *config.Config {
+	return &config.Config{TotalCount: &config.TotalCountConfig{Approximate: approximate}}
+}
+
+func TestCountDelegationsByStakerPkApproximate(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("GetStakerStats", mock.Anything, "staker").Return(
+		&v1dbmodel.StakerStatsDocument{StakerPkHex: "staker", TotalDelegations: 42}, nil,
+	).Once()
+	mockV1DBClient.On("CountDelegationsByStakerPk", mock.Anything, "staker",
+		mock.MatchedBy(func(filter *v1dbclient.DelegationFilter) bool {
+			return len(filter.States) == 1 && filter.States[0] == types.Active
+		}),
+	).Return(int64(7), nil).Once()
+	service := newTestV1Service(t, testServiceDeps{cfg: totalCountTestConfig(true), v1DB: mockV1DBClient})
+
+	// The unfiltered delegations are counted by the staker stats
+	total, svcErr := service.CountDelegationsByStakerPk(
+		context.Background(), "staker", "", &types.DelegationRangeFilter{},
+	)
+	require.Nil(t, svcErr)
+	assert.Equal(t, &types.TotalCount{Count: 42, Approximate: true}, total)
+
+	// The filtered delegations can only be counted
+	total, svcErr = service.CountDelegationsByStakerPk(
+		context.Background(), "staker", types.Active, &types.DelegationRangeFilter{},
+	)
+	require.Nil(t, svcErr)
+	assert.Equal(t, &types.TotalCount{Count: 7}, total)
+}
+
+func TestCountDelegationsByStakerPkExact(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("CountDelegationsByStakerPk", mock.Anything, "staker", mock.Anything).
+		Return(int64(3), nil).Once()
+	mockV1DBClient.On("CountStakerStats", mock.Anything, false).Return(int64(12), nil).Once()
+	service := newTestV1Service(t, testServiceDeps{cfg: totalCountTestConfig(false), v1DB: mockV1DBClient})
+
+	total, svcErr := service.CountDelegationsByStakerPk(context.Background(), "staker", "", nil)
+	require.Nil(t, svcErr)
+	assert.Equal(t, &types.TotalCount{Count: 3}, total)
+
+	total, svcErr = service.CountStakers(context.Background())
+	require.Nil(t, svcErr)
+	assert.Equal(t, &types.TotalCount{Count: 12}, total)
+	mockV1DBClient.AssertNotCalled(t, "GetStakerStats", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,119 @@
+package servicestest
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/services/service"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitionHookIsRetriedUntilSuccess(t *testing.T) {
+	var attempts atomic.Int32
+	done := make(chan service.TransitionEvent, 1)
+	service.RegisterTransitionHook("retry-test", func(ctx context.Context, event service.TransitionEvent) error {
+		if attempts.Add(1) == 1 {
+			return errors.New("temporary failure")
+		}
+		done <- event
+		return nil
+	})
+	defer service.UnregisterTransitionHook("retry-test")
+
+	service.NotifyTransition(context.Background(), "staking-tx", types.Withdrawn, time.Now())
+
+	select {
+	case event := <-done:
+		assert.Equal(t, "staking-tx", event.StakingTxHashHex)
+		assert.Equal(t, types.Withdrawn, event.State)
+		assert.Equal(t, int32(2), attempts.Load())
+	case <-time.After(5 * time.Second):
+		t.Fatal("transition hook was not retried")
+	}
+}
+
+func TestTransitionHookPanicIsRecovered(t *testing.T) {
+	var attempts atomic.Int32
+	service.RegisterTransitionHook("panic-test", func(ctx context.Context, event service.TransitionEvent) error {
+		attempts.Add(1)
+		panic("hook bug")
+	})
+	defer service.UnregisterTransitionHook("panic-test")
+
+	service.NotifyTransition(context.Background(), "staking-tx", types.Active, time.Now())
+	assert.Eventually(t, func() bool { return attempts.Load() == 3 }, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestRegisterTransitionHookRejectsDuplicateName(t *testing.T) {
+	hook := func(ctx context.Context, event service.TransitionEvent) error { return nil }
+	service.RegisterTransitionHook("duplicate-test", hook)
+	defer service.UnregisterTransitionHook("duplicate-test")
+
+	require.Panics(t, func() { service.RegisterTransitionHook("duplicate-test", hook) })
+}
+
+// collectTransitionHookEvents registers a hook collecting the events until
+// the test ends
+func collectTransitionHookEvents(t *testing.T, name string) <-chan service.TransitionEvent {
+	events := make(chan service.TransitionEvent, 10)
+	service.RegisterTransitionHook(name, func(ctx context.Context, event service.TransitionEvent) error {
+		events <- event
+		return nil
+	})
+	t.Cleanup(func() { service.UnregisterTransitionHook(name) })
+	return events
+}
+
+func receiveTransitionHookEvents(t *testing.T, events <-chan service.TransitionEvent, n int) map[string]service.TransitionEvent {
+	received := make(map[string]service.TransitionEvent)
+	for i := 0; i < n; i++ {
+		select {
+		case event := <-events:
+			received[event.StakingTxHashHex] = event
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d of the %d transition hook events", i, n)
+		}
+	}
+	return received
+}
+
+func TestTransitionHooksRunForTheConfirmedDelegations(t *testing.T) {
+	events := collectTransitionHookEvents(t, "confirmation-test")
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("TransitionPendingToActiveState", mock.Anything, uint64(0), uint64(98)).
+		Return([]string{"tx1", "tx2"}, nil)
+	v1Service := newTestV1Service(t, testServiceDeps{
+		params: &types.GlobalParams{Versions: []*types.VersionedGlobalParams{{ConfirmationDepth: 3}}},
+		v1DB:   mockV1DBClient,
+	})
+
+	require.Nil(t, v1Service.TransitionConfirmedDelegationsToActive(context.Background(), 100))
+	received := receiveTransitionHookEvents(t, events, 2)
+	assert.Equal(t, types.Active, received["tx1"].State)
+	assert.Equal(t, types.Active, received["tx2"].State)
+	assert.False(t, received["tx1"].Removed)
+}
+
+func TestTransitionHooksRunForTheReorgedDelegations(t *testing.T) {
+	events := collectTransitionHookEvents(t, "reorg-test")
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("RollbackReorgedDelegation", mock.Anything, "tx1").Return(types.Pending, nil)
+	mockV1DBClient.On("RollbackReorgedDelegation", mock.Anything, "tx2").
+		Return(types.DelegationState(""), &db.NotFoundError{})
+	v1Service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	rolledBack, svcErr := v1Service.RollbackReorgedDelegations(context.Background(), []string{"tx1", "tx2"})
+	require.Nil(t, svcErr)
+	assert.Equal(t, []string{"tx1"}, rolledBack)
+	received := receiveTransitionHookEvents(t, events, 1)
+	assert.True(t, received["tx1"].Removed)
+	assert.Equal(t, types.Pending, received["tx1"].State)
+}
@@ -0,0 +1,71 @@
+package servicestest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnbondDelegationRejectsReplayedSignatures(t *testing.T) {
+	const (
+		stakingTxHashHex   = "aa"
+		unbondingTxHashHex = "bb"
+		signatureHex       = "cc"
+	)
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindDelegationByTxHashHex", mock.Anything, mock.Anything).Return(
+		&v1dbmodel.DelegationDocument{StakingTxHashHex: stakingTxHashHex, State: types.UnbondingRequested}, nil,
+	)
+	mockV1DBClient.On("FindUnbondingSignature", mock.Anything, signatureHex).Return(
+		&v1dbmodel.UnbondingSignatureDocument{
+			SignatureHex:       signatureHex,
+			StakingTxHashHex:   stakingTxHashHex,
+			UnbondingTxHashHex: unbondingTxHashHex,
+		}, nil,
+	)
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	// The same signed payload is resubmitted after the state change
+	svcErr := service.UnbondDelegation(
+		context.Background(), stakingTxHashHex, unbondingTxHashHex, "tx", signatureHex, "",
+	)
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusForbidden, svcErr.StatusCode)
+	assert.Equal(t, types.UnbondingRequestReplayed, svcErr.ErrorCode)
+	assert.Equal(t, "unbonding request has already been submitted", svcErr.Err.Error())
+
+	// The signature is submitted for another unbonding request
+	svcErr = service.UnbondDelegation(
+		context.Background(), stakingTxHashHex, "dd", "tx", signatureHex, "",
+	)
+	require.NotNil(t, svcErr)
+	assert.Equal(t, types.UnbondingRequestReplayed, svcErr.ErrorCode)
+	assert.Contains(t, svcErr.Err.Error(), "another unbonding request")
+	mockV1DBClient.AssertNotCalled(t, "SaveUnbondingTx")
+}
+
+func TestUnbondingRejectsInvalidStateTransitions(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindDelegationByTxHashHex", mock.Anything, "aa").Return(
+		&v1dbmodel.DelegationDocument{StakingTxHashHex: "aa", State: types.Withdrawn}, nil,
+	)
+	mockV1DBClient.On("FindUnbondingSignature", mock.Anything, "cc").Return(nil, &db.NotFoundError{})
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	svcErr := service.IsEligibleForUnbondingRequest(context.Background(), "aa")
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusForbidden, svcErr.StatusCode)
+	assert.Equal(t, types.InvalidStateTransition, svcErr.ErrorCode)
+
+	svcErr = service.UnbondDelegation(context.Background(), "aa", "bb", "unbonding-tx", "cc", "")
+	require.NotNil(t, svcErr)
+	assert.Equal(t, types.InvalidStateTransition, svcErr.ErrorCode)
+}
@@ -0,0 +1,84 @@
+package servicestest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/db"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	v1dbmodel "github.com/babylonlabs-io/staking-api-service/internal/v1/db/model"
+	v1service "github.com/babylonlabs-io/staking-api-service/internal/v1/service"
+	"github.com/babylonlabs-io/staking-api-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUnbondingRequestStatus(t *testing.T) {
+	const (
+		stakingTxHashHex   = "aa"
+		unbondingTxHashHex = "bb"
+	)
+	tests := []struct {
+		name           string
+		pipelineState  string
+		delegation     *v1dbmodel.DelegationDocument
+		expectedStatus string
+	}{
+		{"accepted", v1dbmodel.UnbondingInitialState, &v1dbmodel.DelegationDocument{}, v1service.UnbondingRequestAccepted},
+		{"picked up", "PROCESSING", &v1dbmodel.DelegationDocument{}, v1service.UnbondingRequestPickedUp},
+		{"broadcast", v1dbmodel.UnbondingSentState, &v1dbmodel.DelegationDocument{}, v1service.UnbondingRequestBroadcast},
+		{"failed", v1dbmodel.UnbondingInputAlreadySpentState, &v1dbmodel.DelegationDocument{}, v1service.UnbondingRequestFailed},
+		{
+			"confirmed", v1dbmodel.UnbondingSentState,
+			&v1dbmodel.DelegationDocument{UnbondingTx: &v1dbmodel.TimelockTransaction{TxHashHex: unbondingTxHashHex, StartHeight: 100}},
+			v1service.UnbondingRequestConfirmed,
+		},
+		{
+			"unbonded by another tx", v1dbmodel.UnbondingSentState,
+			&v1dbmodel.DelegationDocument{UnbondingTx: &v1dbmodel.TimelockTransaction{TxHashHex: "cc"}},
+			v1service.UnbondingRequestBroadcast,
+		},
+		{"archived delegation", v1dbmodel.UnbondingSentState, nil, v1service.UnbondingRequestBroadcast},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockV1DBClient := mocks.NewV1DBClient(t)
+			mockV1DBClient.On("FindUnbondingByTxHashHex", mock.Anything, unbondingTxHashHex).Return(
+				&v1dbmodel.UnbondingDocument{
+					StakingTxHashHex:   stakingTxHashHex,
+					UnbondingTxHashHex: unbondingTxHashHex,
+					State:              tt.pipelineState,
+				}, nil,
+			)
+			if tt.delegation != nil {
+				mockV1DBClient.On("FindDelegationByTxHashHex", mock.Anything, stakingTxHashHex).Return(tt.delegation, nil)
+			} else {
+				mockV1DBClient.On("FindDelegationByTxHashHex", mock.Anything, stakingTxHashHex).Return(
+					nil, &db.NotFoundError{Key: stakingTxHashHex},
+				)
+			}
+			service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+			status, svcErr := service.GetUnbondingRequestStatus(context.Background(), unbondingTxHashHex)
+			require.Nil(t, svcErr)
+			assert.Equal(t, tt.expectedStatus, status.Status)
+			assert.Equal(t, tt.pipelineState, status.PipelineState)
+			assert.Equal(t, stakingTxHashHex, status.StakingTxHashHex)
+		})
+	}
+}
+
+func TestGetUnbondingRequestStatusNotFound(t *testing.T) {
+	mockV1DBClient := mocks.NewV1DBClient(t)
+	mockV1DBClient.On("FindUnbondingByTxHashHex", mock.Anything, "bb").Return(
+		nil, &db.NotFoundError{Key: "bb"},
+	)
+	service := newTestV1Service(t, testServiceDeps{v1DB: mockV1DBClient})
+
+	_, svcErr := service.GetUnbondingRequestStatus(context.Background(), "bb")
+	require.NotNil(t, svcErr)
+	assert.Equal(t, http.StatusNotFound, svcErr.StatusCode)
+	assert.Equal(t, types.NotFound, svcErr.ErrorCode)
+}
@@ -1,11 +1,14 @@
 package utilstest
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
 	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDeriveAddressesFromNoCoordPk(t *testing.T) {
@@ -25,3 +28,43 @@ func TestDeriveAddressesFromNoCoordPk(t *testing.T) {
 	assert.Equal(t, expectedEvenAddress, addresses.NativeSegwitEven)
 	assert.Equal(t, expectedOddAddress, addresses.NativeSegwitOdd)
 }
+
+func TestVerifyUnbondingRequest(t *testing.T) {
+	const (
+		stakingTxHashHex = "06cb98777a9cc5d3556498f6e5607947759e60f01e1441b6ce375174b8588037"
+		stakingTxHex     = "0100000000010103f23a34f828a8335be0ce0400b0f1b8a5a26fd04b94abcff2ce59443b373fff0000000000ffffffff030000000000000000496a47010203040091fef91f0f00d010d6c918acb9197db6cd33d99ee617090fa8cb61fa2a31405a03d5a0bb72d71993e435d6c5a70e2aa4db500a62cfaae33c56050deefee64ec00064a08601000000000022512072a6ef79c17676fb1b54a157a4921fa57f4295dae778a423523a378171b09f3e756a042a01000000160014257cf22a8f4502076820609a30d7370856c342c40247304402203fd7b14cd32f7640c8575fe7516b2b3233c9eb3b956fe9d26bb67c156287787d0220093a1a2cd9276b53f60435fbbc55e9c007f607987c1d62f259f14ddd14a5cab501210291fef91f0f00d010d6c918acb9197db6cd33d99ee617090fa8cb61fa2a31405a00000000"
+		fpPkHex          = "03d5a0bb72d71993e435d6c5a70e2aa4db500a62cfaae33c56050deefee64ec0"
+	)
+	globalParams, err := types.NewGlobalParams("../../config/global-params-test.json")
+	require.NoError(t, err)
+
+	verify := func(params types.VersionedGlobalParams, sigHex string) error {
+		return utils.VerifyUnbondingRequest(
+			stakingTxHashHex, stakingTxHex, testUnbondingTxHashHex, testUnbondingTxHex,
+			testStakerPkHex, fpPkHex, sigHex, 100, 1, 100000,
+			&params, &chaincfg.SigNetParams,
+		)
+	}
+	assertCode := func(err error, code types.ErrorCode) {
+		var verificationErr *utils.UnbondingVerificationError
+		require.ErrorAs(t, err, &verificationErr)
+		assert.Equal(t, code, verificationErr.Code)
+	}
+
+	params := *globalParams.Versions[0]
+	assert.NoError(t, verify(params, testStakerSigHex))
+
+	// The signature is not from the staker
+	invalidSig := strings.Repeat("01", 64)
+	assertCode(verify(params, invalidSig), types.InvalidUnbondingSignature)
+
+	// A different unbonding fee
+	feeParams := params
+	feeParams.UnbondingFee = params.UnbondingFee + 1
+	assertCode(verify(feeParams, testStakerSigHex), types.UnbondingFeeMismatch)
+
+	// A different unbonding time leads to a different unbonding output script
+	scriptParams := params
+	scriptParams.UnbondingTime = params.UnbondingTime + 1
+	assertCode(verify(scriptParams, testStakerSigHex), types.UnbondingOutputMismatch)
+}
@@ -0,0 +1,47 @@
+package utilstest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fieldsTestPublic struct {
+	Hash     string  `json:"hash"`
+	Value    uint64  `json:"value"`
+	Optional *string `json:"optional,omitempty"`
+	Ignored  string  `json:"-"`
+	internal string
+}
+
+func TestJSONFieldNames(t *testing.T) {
+	expected := []string{"hash", "value", "optional"}
+	assert.Equal(t, expected, utils.JSONFieldNames(fieldsTestPublic{}))
+	assert.Equal(t, expected, utils.JSONFieldNames(&fieldsTestPublic{}))
+	assert.Equal(t, expected, utils.JSONFieldNames([]*fieldsTestPublic{}))
+}
+
+func TestSelectJSONFields(t *testing.T) {
+	item := fieldsTestPublic{Hash: "abc", Value: 10, internal: "x"}
+
+	selected, err := utils.SelectJSONFields(item, []string{"value", "optional"})
+	require.NoError(t, err)
+	encoded, err := json.Marshal(selected)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"value":10}`, string(encoded))
+
+	selected, err = utils.SelectJSONFields([]*fieldsTestPublic{&item, &item}, []string{"hash"})
+	require.NoError(t, err)
+	encoded, err = json.Marshal(selected)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"hash":"abc"},{"hash":"abc"}]`, string(encoded))
+
+	selected, err = utils.SelectJSONFields([]fieldsTestPublic{}, []string{"hash"})
+	require.NoError(t, err)
+	encoded, err = json.Marshal(selected)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(encoded))
+}
@@ -0,0 +1,77 @@
+package utilstest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStakerPkHex        = "91fef91f0f00d010d6c918acb9197db6cd33d99ee617090fa8cb61fa2a31405a"
+	testUnbondingTxHashHex = "17aad3b01a9fa134f0e6374b9ad1b049376a9bdbd889afc369dcb8074bbdf1b3"
+	testUnbondingTxHex     = "02000000000101378058b8745137ceb641141ef0609e75477960e5f6986455d3c59c7a7798cb060100000000ffffffff01905f010000000000225120ba296d88e83fd2faf5864ddda74ac8d0df6a7d76b39d5ef4c0751117a26cfd3104403c3d32c844ff751de59190ffc57427794450ba97b0d6ead43d53d865b34021abb52a6370382cfc46416f42f28d32704e504f84720d8458b5d51fee69d28cf94940728ab06ac8ab2f14b4c60cacb0e8932760f1559df93dd9053327e72cec53fc9749d5abaa4a96758b7f3588b3ad80e4c157233f1e689a37bea3ccaa9e50ba153ece2091fef91f0f00d010d6c918acb9197db6cd33d99ee617090fa8cb61fa2a31405aad2057349e985e742d5131e1e2b227b5170f6350ac2e2feb72254fcc25b3cee21a18ac2059d3532148a597a2d05c0395bf5f7176044b1cd312f37701a9b4d0aad70bc5a4ba20a5c60c2188e833d39d0fa798ab3f69aa12ed3dd2f3bad659effa252782de3c31ba20c8ccb03c379e452f10c81232b41a1ca8b63d0baf8387e57d302c987e5abb8527ba20ffeaec52a9b407b355ef6967a7ffc15fd6c3fe07de2844d61550475e7a5233e5ba539c61c150929b74c1a04954b78b4b6035e97a5e078a5a0f28ec96d547bfee9ace803ac0957294d847c7353393803c6a2c03d6b777bb2cbb69aa4c85291d2d9bb981bb59cc2f4c8bba33b96629a6e942aad0c9815a4e5e7322f63e521e762371b71e3c1300000000"
+	testStakerSigHex       = "728ab06ac8ab2f14b4c60cacb0e8932760f1559df93dd9053327e72cec53fc9749d5abaa4a96758b7f3588b3ad80e4c157233f1e689a37bea3ccaa9e50ba153e"
+)
+
+func writePsbtKV(t *testing.T, buf *bytes.Buffer, key, value []byte) {
+	require.NoError(t, wire.WriteVarBytes(buf, 0, key))
+	require.NoError(t, wire.WriteVarBytes(buf, 0, value))
+}
+
+// buildTestUnbondingPsbt builds a PSBT with the unsigned unbonding tx and the
+// taproot script spend signature of the given public key on the first input
+func buildTestUnbondingPsbt(t *testing.T, sigPkHex, sigHex string) string {
+	txBytes, err := hex.DecodeString(testUnbondingTxHex)
+	require.NoError(t, err)
+	tx := wire.NewMsgTx(wire.TxVersion)
+	require.NoError(t, tx.Deserialize(bytes.NewReader(txBytes)))
+	var unsignedTx bytes.Buffer
+	require.NoError(t, tx.SerializeNoWitness(&unsignedTx))
+
+	pk, err := hex.DecodeString(sigPkHex)
+	require.NoError(t, err)
+	sig, err := hex.DecodeString(sigHex)
+	require.NoError(t, err)
+	leafHash := bytes.Repeat([]byte{0x01}, 32)
+
+	var buf bytes.Buffer
+	buf.Write([]byte{0x70, 0x73, 0x62, 0x74, 0xff})
+	// global map
+	writePsbtKV(t, &buf, []byte{0x00}, unsignedTx.Bytes())
+	buf.WriteByte(0x00)
+	// input map
+	writePsbtKV(t, &buf, append(append([]byte{0x14}, pk...), leafHash...), sig)
+	buf.WriteByte(0x00)
+	// output map
+	buf.WriteByte(0x00)
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestParseUnbondingPsbt(t *testing.T) {
+	psbtBase64 := buildTestUnbondingPsbt(t, testStakerPkHex, testStakerSigHex)
+
+	unbondingPsbt, err := utils.ParseUnbondingPsbt(psbtBase64, testStakerPkHex)
+	require.NoError(t, err)
+	assert.Equal(t, testUnbondingTxHashHex, unbondingPsbt.UnbondingTxHashHex)
+	assert.Equal(t, testStakerSigHex, unbondingPsbt.StakerSigHex)
+	assert.True(t, utils.IsValidTxHex(unbondingPsbt.UnbondingTxHex))
+}
+
+func TestParseUnbondingPsbtWithoutStakerSignature(t *testing.T) {
+	otherPkHex := "03d5a0bb72d71993e435d6c5a70e2aa4db500a62cfaae33c56050deefee64ec0"
+	psbtBase64 := buildTestUnbondingPsbt(t, otherPkHex, testStakerSigHex)
+
+	_, err := utils.ParseUnbondingPsbt(psbtBase64, testStakerPkHex)
+	assert.ErrorContains(t, err, "psbt does not contain the staker signature")
+}
+
+func TestParseUnbondingPsbtWithInvalidMagic(t *testing.T) {
+	_, err := utils.ParseUnbondingPsbt(base64.StdEncoding.EncodeToString([]byte("notpsbt")), testStakerPkHex)
+	assert.ErrorContains(t, err, "invalid psbt magic bytes")
+}
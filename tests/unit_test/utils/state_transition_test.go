@@ -0,0 +1,57 @@
+package utilstest
+
+import (
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/types"
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	assert.True(t, types.CanTransition(types.Pending, types.Active))
+	assert.True(t, types.CanTransition(types.Active, types.UnbondingRequested))
+	assert.True(t, types.CanTransition(types.Active, types.Unbonding))
+	assert.True(t, types.CanTransition(types.UnbondingRequested, types.Unbonding))
+	assert.True(t, types.CanTransition(types.Unbonding, types.Unbonded))
+	assert.True(t, types.CanTransition(types.Unbonded, types.Withdrawn))
+
+	assert.False(t, types.CanTransition(types.Active, types.Withdrawn))
+	assert.False(t, types.CanTransition(types.UnbondingRequested, types.Unbonded))
+	assert.False(t, types.CanTransition(types.Unbonded, types.Active))
+	assert.False(t, types.CanTransition(types.Withdrawn, types.Unbonded))
+	assert.False(t, types.CanTransition(types.Active, types.Active))
+}
+
+func TestIsOutdatedTransition(t *testing.T) {
+	assert.True(t, types.IsOutdatedTransition(types.Unbonding, types.Unbonding))
+	assert.True(t, types.IsOutdatedTransition(types.Withdrawn, types.Unbonding))
+	assert.True(t, types.IsOutdatedTransition(types.Withdrawn, types.Unbonded))
+	assert.True(t, types.IsOutdatedTransition(types.Active, types.Pending))
+
+	assert.False(t, types.IsOutdatedTransition(types.Active, types.Unbonding))
+	assert.False(t, types.IsOutdatedTransition(types.UnbondingRequested, types.Unbonded))
+	assert.False(t, types.IsOutdatedTransition(types.Unbonded, types.Withdrawn))
+}
+
+func TestTerminalStates(t *testing.T) {
+	assert.Equal(t, []types.DelegationState{types.Withdrawn}, types.TerminalStates())
+}
+
+func TestQualifiedStatesDerivedFromTransitionTable(t *testing.T) {
+	assert.Equal(t, []types.DelegationState{types.Pending}, utils.QualifiedStatesToActive())
+	assert.Equal(t, []types.DelegationState{types.Active}, utils.QualifiedStatesToUnbondingRequest())
+	assert.Equal(t,
+		[]types.DelegationState{types.Pending, types.Active, types.UnbondingRequested},
+		utils.QualifiedStatesToUnbonding(),
+	)
+	assert.Equal(t,
+		[]types.DelegationState{types.Pending, types.Active},
+		utils.QualifiedStatesToUnbonded(types.ActiveTxType),
+	)
+	assert.Equal(t,
+		[]types.DelegationState{types.Unbonding},
+		utils.QualifiedStatesToUnbonded(types.UnbondingTxType),
+	)
+	assert.Equal(t, []types.DelegationState{types.Unbonded}, utils.QualifiedStatesToWithdraw())
+}
@@ -0,0 +1,33 @@
+package versiontest
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/babylonlabs-io/staking-api-service/internal/shared/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReturnsInjectedBuildInfo(t *testing.T) {
+	defer func(v, commit, date, features string) {
+		version.Version, version.GitCommit, version.BuildDate, version.Features = v, commit, date, features
+	}(version.Version, version.GitCommit, version.BuildDate, version.Features)
+	version.Version = "v1.2.3"
+	version.GitCommit = "0123abcd"
+	version.BuildDate = "2024-05-01T12:00:00Z"
+	version.Features = "exports, partners,,"
+
+	assert.Equal(t, version.Info{
+		Version:   "v1.2.3",
+		GitCommit: "0123abcd",
+		BuildDate: "2024-05-01T12:00:00Z",
+		GoVersion: runtime.Version(),
+		Features:  []string{"exports", "partners"},
+	}, version.Get())
+}
+
+func TestGetWithoutFeatures(t *testing.T) {
+	info := version.Get()
+	assert.NotNil(t, info.Features)
+	assert.Empty(t, info.Features)
+}